@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/module"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// aliasCmd represents the alias command
+var aliasCmd = &cobra.Command{
+	Use:   "alias [module|binary] [name]",
+	Short: "Rename the binary of an installed module",
+	Long: `Rename the binary of an installed module in GOBIN. The alias is kept in
+the module record, so updates and reinstalls keep installing the binary
+under it, and remove deletes the aliased binary only.
+
+Aliases let two tools with the same binary name live side by side, such as
+two major versions of golangci-lint. An existing binary is never
+overwritten. An alias replaces a kubectl plugin registration.
+
+Without arguments, aliased modules are listed.
+
+Examples:
+  glix alias
+  glix alias github.com/golangci/golangci-lint/cmd/golangci-lint golangci-lint-v1
+  glix alias golangci-lint-v1 --reset`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if aliasReset {
+			return cobra.ExactArgs(1)(cmd, args)
+		}
+
+		if len(args) == 1 {
+			return fmt.Errorf("specify the alias name, or --reset to restore the default name")
+		}
+
+		return cobra.RangeArgs(0, 2)(cmd, args)
+	},
+	ValidArgsFunction: completeInstalledModules,
+	SilenceUsage:      true,
+	RunE:              runAlias,
+}
+
+var aliasReset bool
+
+func init() {
+	rootCmd.AddCommand(aliasCmd)
+
+	aliasCmd.Flags().BoolVar(&aliasReset, "reset", false, "Restore the default binary name")
+}
+
+func runAlias(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	if len(args) == 0 {
+		return printAliases(cmd, grpcClient)
+	}
+
+	var alias string
+	if !aliasReset {
+		alias = args[1]
+
+		if err := module.ValidateAlias(alias); err != nil {
+			return err
+		}
+	}
+
+	mod, err := findInstalledTool(ctx, grpcClient, args[0])
+	if err != nil {
+		return err
+	}
+
+	// The shim and every stored version carry the name
+	if mod.GetShimPath() != "" {
+		return fmt.Errorf("%s is installed with a shim; reinstall it under another name with 'glix install --as <name> %s'", mod.GetName(), mod.GetName())
+	}
+
+	if alias == mod.GetAlias() {
+		cmd.Printf("%s is already installed as %s\n", mod.GetName(), module.ManagedBinaryName(mod.GetName(), "", alias))
+		return nil
+	}
+
+	_, src := module.RecordedBinary(mod)
+	if _, err := os.Stat(src); err != nil {
+		return fmt.Errorf("installed binary of %s not found: %w", mod.GetName(), err)
+	}
+
+	dest := module.ManagedBinaryPath(mod.GetName(), "", alias)
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("%s already exists; remove it first or choose another name", dest)
+	}
+
+	if err := os.Rename(src, dest); err != nil {
+		return fmt.Errorf("failed to rename binary: %w", err)
+	}
+
+	if _, err := grpcClient.SetAlias(ctx, mod.GetName(), alias, dest); err != nil {
+		// Keep the binary where the record says it is
+		_ = os.Rename(dest, src)
+		return err
+	}
+
+	cmd.Printf("Renamed %s to %s\n", src, dest)
+
+	writeReceipt(mod.GetName(), mod.GetVersion(), dest, "", serverNamespace(ctx, grpcClient), func(phase, message string) {
+		cmd.Printf("[%s] %s\n", phase, message)
+	})
+
+	warnShadowing(module.ManagedBinaryName(mod.GetName(), "", alias), func(phase, message string) {
+		cmd.Printf("[%s] %s\n", phase, message)
+	})
+
+	return nil
+}
+
+// printAliases lists the modules installed under an alias
+func printAliases(cmd *cobra.Command, grpcClient *client.Client) error {
+	resp, err := grpcClient.ListModules(cmd.Context(), 0, 0, "")
+	if err != nil {
+		return fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	aliased := slices.DeleteFunc(resp.GetModules(), func(mod *pb.ModuleProto) bool {
+		return mod.GetAlias() == ""
+	})
+
+	if len(aliased) == 0 {
+		cmd.Println("No aliased modules installed")
+		return nil
+	}
+
+	t := newTable(
+		column{Header: "ALIAS"},
+		column{Header: "MODULE", Shrink: true},
+		column{Header: "VERSION"},
+	)
+
+	for _, mod := range aliased {
+		t.addRow(mod.GetAlias(), mod.GetName(), mod.GetVersion())
+	}
+
+	return t.write(cmd.OutOrStdout())
+}
@@ -0,0 +1,402 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/tui"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// errVulnerable makes audit exit non-zero so it can gate CI jobs
+var errVulnerable = errors.New("vulnerable modules")
+
+// auditCmd represents the audit command
+var auditCmd = &cobra.Command{
+	Use:   "audit [module...]",
+	Short: "Scan installed binaries for known vulnerabilities with govulncheck",
+	Long: `Scan the binaries of installed modules, all of them by default, with
+govulncheck and store the findings, replacing those of the previous scan.
+govulncheck reports the known vulnerabilities of the modules and the Go
+version a binary was built with, and whether the binary calls the
+vulnerable code; only called vulnerabilities make the command fail.
+
+Installs and updates are scanned as well when govulncheck is installed,
+unless --no-audit is given; 'glix report' shows the findings of the last
+scan. govulncheck is looked up on PATH and in GOBIN:
+
+  glix install golang.org/x/vuln/cmd/govulncheck
+
+The command exits with status 1 when a binary calls vulnerable code, so it
+can gate CI jobs.
+
+Examples:
+  glix audit
+  glix audit github.com/user/tool
+  glix audit --cached --json      # Findings of the last scans, without scanning`,
+	ValidArgsFunction: completeInstalledModules,
+	SilenceUsage:      true,
+	RunE:              runAudit,
+}
+
+var (
+	auditJSON   bool
+	auditCached bool
+	noAudit     bool
+)
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+
+	auditCmd.Flags().BoolVar(&auditJSON, "json", false, "Print results as JSON")
+	auditCmd.Flags().BoolVar(&auditCached, "cached", false, "Show the findings of the last scans instead of scanning")
+
+	for _, c := range []*cobra.Command{installCmd, updateCmd} {
+		c.Flags().BoolVar(&noAudit, "no-audit", false, "Skip the govulncheck scan of the installed binary")
+	}
+}
+
+// auditEntry is one module of the audit report
+type auditEntry struct {
+	Name     string         `json:"name"`
+	Version  string         `json:"version"`
+	Scanned  string         `json:"scanned,omitempty"` // RFC 3339
+	Error    string         `json:"error,omitempty"`
+	Findings []auditFinding `json:"findings"`
+}
+
+// auditFinding is a vulnerability of an audit entry
+type auditFinding struct {
+	ID      string   `json:"id"`
+	Summary string   `json:"summary,omitempty"`
+	Aliases []string `json:"aliases,omitempty"`
+	Module  string   `json:"module"`
+	Found   string   `json:"found_version,omitempty"`
+	Fixed   string   `json:"fixed_version,omitempty"`
+	Called  bool     `json:"called"`
+}
+
+func runAudit(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	resp, err := grpcClient.ListModules(ctx, 0, 0, "")
+	if err != nil {
+		return fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	modules, err := selectModules(resp.GetModules(), args)
+	if err != nil {
+		return err
+	}
+
+	if !auditCached {
+		if _, err := module.FindGovulncheck(); err != nil {
+			return err
+		}
+	}
+
+	reports := make(map[string]*pb.VulnReportProto)
+
+	if auditCached {
+		stored, err := grpcClient.ListVulnReports(ctx, "")
+		if err != nil {
+			return err
+		}
+
+		for _, r := range stored {
+			reports[r.GetName()] = r
+		}
+	}
+
+	entries := make([]auditEntry, 0, len(modules))
+	vulnerable := 0
+
+	for _, mod := range modules {
+		entry := auditEntry{Name: mod.GetName(), Version: mod.GetVersion(), Findings: []auditFinding{}}
+
+		report, ok := reports[mod.GetName()]
+		if !auditCached {
+			cmd.Printf("Scanning %s@%s...\n", mod.GetName(), mod.GetVersion())
+
+			_, binPath := module.RecordedBinary(mod)
+
+			report, err = scanVulnerabilities(ctx, grpcClient, mod.GetName(), mod.GetVersion(), binPath)
+			ok = report != nil
+
+			if err != nil {
+				entry.Error = err.Error()
+			}
+		}
+
+		if ok {
+			entry.Version = report.GetVersion()
+			entry.Scanned = time.Unix(0, report.GetScannedUnixNano()).Format(time.RFC3339)
+
+			for _, f := range report.GetFindings() {
+				entry.Findings = append(entry.Findings, auditFinding{
+					ID:      f.GetId(),
+					Summary: f.GetSummary(),
+					Aliases: f.GetAliases(),
+					Module:  f.GetModule(),
+					Found:   f.GetFoundVersion(),
+					Fixed:   f.GetFixedVersion(),
+					Called:  f.GetCalled(),
+				})
+			}
+
+			if calledVulns(report) > 0 {
+				vulnerable++
+			}
+		} else if entry.Error == "" {
+			entry.Error = "not scanned"
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if auditJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+
+		if err := enc.Encode(entries); err != nil {
+			return fmt.Errorf("failed to encode results: %w", err)
+		}
+	} else {
+		printAuditTable(cmd, entries)
+	}
+
+	if vulnerable > 0 {
+		return fmt.Errorf("%w: %d module(s) call vulnerable code", errVulnerable, vulnerable)
+	}
+
+	return nil
+}
+
+// selectModules returns the installed modules named by args, or all of them
+// when there are none
+func selectModules(installed []*pb.ModuleProto, args []string) ([]*pb.ModuleProto, error) {
+	if len(args) == 0 {
+		return installed, nil
+	}
+
+	byName := make(map[string]*pb.ModuleProto, len(installed))
+	for _, mod := range installed {
+		byName[mod.GetName()] = mod
+	}
+
+	selected := make([]*pb.ModuleProto, 0, len(args))
+
+	for _, arg := range args {
+		name, _ := parseModulePath(arg)
+
+		mod, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("module %s is not installed", name)
+		}
+
+		selected = append(selected, mod)
+	}
+
+	return selected, nil
+}
+
+// scanVulnerabilities scans the binary of an installed module version with
+// govulncheck and stores the findings
+func scanVulnerabilities(ctx context.Context, grpcClient *client.Client, name, version, binPath string) (*pb.VulnReportProto, error) {
+	if _, err := os.Stat(binPath); err != nil {
+		return nil, fmt.Errorf("binary missing: %s", binPath)
+	}
+
+	findings, err := module.ScanBinary(ctx, binPath)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &pb.VulnReportProto{
+		Name:            name,
+		Version:         version,
+		ScannedUnixNano: time.Now().UnixNano(),
+		Findings:        findings,
+	}
+
+	if err := grpcClient.StoreVulnReport(ctx, report); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+// auditInstalled scans a binary that was just installed when govulncheck is
+// available, warning about vulnerable code it calls. Scans are skipped
+// silently without govulncheck, so installing it opts in.
+func auditInstalled(ctx context.Context, grpcClient *client.Client, m *module.Module, progressHandler func(phase, message string)) {
+	if noAudit || m.BinaryPath == "" {
+		return
+	}
+
+	if _, err := module.FindGovulncheck(); err != nil {
+		return
+	}
+
+	// govulncheck needs the vulnerability database
+	if m.Offline() {
+		progressHandler("audit", fmt.Sprintf("Offline: skipped the vulnerability scan; run 'glix audit %s' when online", m.Name))
+		return
+	}
+
+	progressHandler("audit", "Scanning for known vulnerabilities with govulncheck...")
+
+	report, err := scanVulnerabilities(ctx, grpcClient, m.Name, m.Version, m.BinaryPath)
+	if err != nil {
+		progressHandler("warning", fmt.Sprintf("vulnerability scan failed: %v", err))
+	}
+
+	if report == nil {
+		return
+	}
+
+	if banner := vulnBanner(report); banner != "" {
+		progressHandler("warning", banner)
+		return
+	}
+
+	progressHandler("audit", fmt.Sprintf("No known vulnerabilities in code %s calls", m.Name))
+}
+
+// calledVulns counts the findings of a report whose code the binary calls
+func calledVulns(report *pb.VulnReportProto) int {
+	n := 0
+
+	for _, f := range report.GetFindings() {
+		if f.GetCalled() {
+			n++
+		}
+	}
+
+	return n
+}
+
+// vulnBanner warns about the vulnerabilities a binary calls, or returns ""
+// when it calls none
+func vulnBanner(report *pb.VulnReportProto) string {
+	var called []string
+
+	for _, f := range report.GetFindings() {
+		if f.GetCalled() {
+			called = append(called, describeFinding(f))
+		}
+	}
+
+	if len(called) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%s@%s calls code with %d known vulnerability(ies): %s; see 'glix audit %s'",
+		report.GetName(), report.GetVersion(), len(called), strings.Join(called, ", "), report.GetName())
+}
+
+// describeFinding names a vulnerability with the affected module version and
+// the version fixing it
+func describeFinding(f *pb.VulnFindingProto) string {
+	fix := "no fix available"
+	if f.GetFixedVersion() != "" {
+		fix = "fixed in " + f.GetFixedVersion()
+	}
+
+	return fmt.Sprintf("%s (%s@%s, %s)", f.GetId(), f.GetModule(), f.GetFoundVersion(), fix)
+}
+
+// printAuditTable writes entries as an aligned table to stdout
+func printAuditTable(cmd *cobra.Command, entries []auditEntry) {
+	t := newTable(
+		column{Header: "MODULE", Shrink: true},
+		column{Header: "VERSION"},
+		column{Header: "CALLED"},
+		column{Header: "REQUIRED"},
+		column{Header: "VULNERABILITIES", Shrink: true, KeepStart: true},
+	)
+
+	for _, e := range entries {
+		if e.Error != "" {
+			t.addRow(e.Name, e.Version, "-", "-", tui.ErrorStyle.Render(e.Error))
+			continue
+		}
+
+		called, required := 0, 0
+
+		var ids []string
+
+		for _, f := range e.Findings {
+			if f.Called {
+				called++
+
+				ids = append(ids, f.ID)
+			} else {
+				required++
+			}
+		}
+
+		t.addRow(e.Name, e.Version, fmt.Sprint(called), fmt.Sprint(required), strings.Join(ids, ", "))
+	}
+
+	_ = t.write(cmd.OutOrStdout())
+}
+
+// printVulnReport writes the findings of the last scan of a module
+func printVulnReport(cmd *cobra.Command, mod *pb.ModuleProto, report *pb.VulnReportProto) {
+	scanned := time.Unix(0, report.GetScannedUnixNano()).Format(time.RFC3339)
+
+	if report.GetVersion() != mod.GetVersion() {
+		cmd.Printf("\nVulnerabilities: last scanned %s at %s; run 'glix audit %s' to scan %s\n",
+			report.GetVersion(), scanned, mod.GetName(), mod.GetVersion())
+
+		return
+	}
+
+	if len(report.GetFindings()) == 0 {
+		cmd.Printf("\nVulnerabilities: none known (scanned %s)\n", scanned)
+		return
+	}
+
+	if n := calledVulns(report); n > 0 {
+		cmd.Printf("\n%s\n", tui.WarningStyle.Render(fmt.Sprintf("WARNING: this binary calls code with %d known vulnerability(ies)", n)))
+	}
+
+	cmd.Printf("\nVulnerabilities (%d, scanned %s):\n", len(report.GetFindings()), scanned)
+
+	for _, f := range report.GetFindings() {
+		marker := "!"
+		if !f.GetCalled() {
+			marker = "-"
+		}
+
+		cmd.Printf("  %s %s", marker, describeFinding(f))
+
+		if f.GetSummary() != "" {
+			cmd.Printf(": %s", f.GetSummary())
+		}
+
+		if !f.GetCalled() {
+			cmd.Print(" [not called]")
+		}
+
+		cmd.Println()
+	}
+}
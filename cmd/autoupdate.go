@@ -1,11 +1,17 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/inovacc/glix/internal/autoupdate"
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/humanize"
+	pb "github.com/inovacc/glix/pkg/api/v1"
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
 )
 
 // autoUpdateCmd represents the auto-update parent command
@@ -17,6 +23,10 @@ var autoUpdateCmd = &cobra.Command{
 When enabled, glix will periodically check for updates to all installed
 modules and automatically install newer versions.
 
+The settings and statistics are kept by the glix server, which runs the
+checks, so these commands also configure a remote server (see 'glix
+context').
+
 Examples:
   glix auto-update status     # Show current auto-update status
   glix auto-update enable     # Enable automatic updates
@@ -66,7 +76,10 @@ var autoUpdateConfigCmd = &cobra.Command{
 Examples:
   glix auto-update config --interval 12h    # Check every 12 hours
   glix auto-update config --notify-only     # Only notify, don't auto-install
-  glix auto-update config --no-notify-only  # Auto-install updates`,
+  glix auto-update config --no-notify-only  # Auto-install updates
+  glix auto-update config --pre             # Update every module to pre-releases
+
+Modules installed with --pre update to pre-releases whatever --pre says here.`,
 	RunE: runAutoUpdateConfig,
 }
 
@@ -74,6 +87,8 @@ var (
 	autoUpdateInterval   string
 	autoUpdateNotifyOnly bool
 	autoUpdateNoNotify   bool
+	autoUpdatePre        bool
+	autoUpdateNoPre      bool
 )
 
 func init() {
@@ -89,11 +104,37 @@ func init() {
 	autoUpdateConfigCmd.Flags().StringVar(&autoUpdateInterval, "interval", "", "Update check interval (e.g., 24h, 12h, 1h)")
 	autoUpdateConfigCmd.Flags().BoolVar(&autoUpdateNotifyOnly, "notify-only", false, "Only notify about updates, don't auto-install")
 	autoUpdateConfigCmd.Flags().BoolVar(&autoUpdateNoNotify, "no-notify-only", false, "Auto-install updates (disable notify-only)")
+	autoUpdateConfigCmd.Flags().BoolVar(&autoUpdatePre, "pre", false, "Update all modules to pre-releases, not only those installed with --pre")
+	autoUpdateConfigCmd.Flags().BoolVar(&autoUpdateNoPre, "no-pre", false, "Update only modules installed with --pre to pre-releases")
+}
+
+// withAutoUpdateClient runs fn with a client of the server that keeps the
+// auto-update configuration
+func withAutoUpdateClient(ctx context.Context, fn func(grpcClient *client.Client) error) error {
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	return fn(grpcClient)
 }
 
 func runAutoUpdateStatus(cmd *cobra.Command, _ []string) error {
-	store := autoupdate.GetStore()
-	cfg := store.Get()
+	var cfg autoupdate.Config
+
+	if err := withAutoUpdateClient(cmd.Context(), func(grpcClient *client.Client) error {
+		var err error
+
+		cfg, err = autoupdate.LoadConfig(cmd.Context(), grpcClient)
+
+		return err
+	}); err != nil {
+		return err
+	}
 
 	cmd.Println("Auto-Update Status")
 	cmd.Println("==================")
@@ -105,7 +146,7 @@ func runAutoUpdateStatus(cmd *cobra.Command, _ []string) error {
 		cmd.Println("Status:        DISABLED")
 	}
 
-	cmd.Printf("Interval:      %s\n", formatDuration(cfg.Interval))
+	cmd.Printf("Interval:      %s\n", humanize.Duration(cfg.Interval))
 
 	if cfg.NotifyOnly {
 		cmd.Println("Mode:          Notify only (no auto-install)")
@@ -113,6 +154,12 @@ func runAutoUpdateStatus(cmd *cobra.Command, _ []string) error {
 		cmd.Println("Mode:          Auto-install updates")
 	}
 
+	if cfg.IncludePrerel {
+		cmd.Println("Pre-releases:  All modules")
+	} else {
+		cmd.Println("Pre-releases:  Modules installed with --pre")
+	}
+
 	cmd.Println()
 	cmd.Println("Statistics")
 	cmd.Println("----------")
@@ -120,29 +167,30 @@ func runAutoUpdateStatus(cmd *cobra.Command, _ []string) error {
 	if cfg.LastCheck.IsZero() {
 		cmd.Println("Last check:    Never")
 	} else {
-		cmd.Printf("Last check:    %s (%s ago)\n",
+		cmd.Printf("Last check:    %s (%s)\n",
 			cfg.LastCheck.Format(time.RFC3339),
-			formatDuration(time.Since(cfg.LastCheck)))
+			humanize.Since(cfg.LastCheck))
 	}
 
 	if cfg.LastUpdate.IsZero() {
 		cmd.Println("Last update:   Never")
 	} else {
-		cmd.Printf("Last update:   %s (%s ago)\n",
+		cmd.Printf("Last update:   %s (%s)\n",
 			cfg.LastUpdate.Format(time.RFC3339),
-			formatDuration(time.Since(cfg.LastUpdate)))
+			humanize.Since(cfg.LastUpdate))
 	}
 
 	cmd.Printf("Total checks:  %d\n", cfg.CheckedCount)
 	cmd.Printf("Total updates: %d\n", cfg.UpdatedCount)
+	cmd.Printf("Pending:       %d\n", cfg.PendingCount)
 
 	// Show next check time if enabled
 	if cfg.Enabled && !cfg.LastCheck.IsZero() {
 		nextCheck := cfg.LastCheck.Add(cfg.Interval)
 		if nextCheck.After(time.Now()) {
-			cmd.Printf("\nNext check:    %s (in %s)\n",
+			cmd.Printf("\nNext check:    %s (%s)\n",
 				nextCheck.Format(time.RFC3339),
-				formatDuration(time.Until(nextCheck)))
+				humanize.Since(nextCheck))
 		} else {
 			cmd.Println("\nNext check:    Pending (will run soon)")
 		}
@@ -152,9 +200,7 @@ func runAutoUpdateStatus(cmd *cobra.Command, _ []string) error {
 }
 
 func runAutoUpdateEnable(cmd *cobra.Command, _ []string) error {
-	store := autoupdate.GetStore()
-
-	if err := store.SetEnabled(true); err != nil {
+	if err := setAutoUpdateConfig(cmd.Context(), &pb.SetAutoUpdateConfigRequest{Enabled: proto.Bool(true)}); err != nil {
 		return fmt.Errorf("failed to enable auto-update: %w", err)
 	}
 
@@ -168,9 +214,7 @@ func runAutoUpdateEnable(cmd *cobra.Command, _ []string) error {
 }
 
 func runAutoUpdateDisable(cmd *cobra.Command, _ []string) error {
-	store := autoupdate.GetStore()
-
-	if err := store.SetEnabled(false); err != nil {
+	if err := setAutoUpdateConfig(cmd.Context(), &pb.SetAutoUpdateConfigRequest{Enabled: proto.Bool(false)}); err != nil {
 		return fmt.Errorf("failed to disable auto-update: %w", err)
 	}
 
@@ -185,13 +229,23 @@ func runAutoUpdateNow(cmd *cobra.Command, _ []string) error {
 	cmd.Println("Running update check...")
 	cmd.Println()
 
-	scheduler := autoupdate.NewScheduler(nil)
+	var result *autoupdate.CheckResult
 
-	result, err := scheduler.RunOnce(ctx)
-	if err != nil {
+	if err := withAutoUpdateClient(ctx, func(grpcClient *client.Client) error {
+		var err error
+
+		scheduler := autoupdate.NewScheduler(nil, grpcClient)
+		scheduler.SetClient(grpcClient.Service())
+
+		result, err = scheduler.RunOnce(ctx)
+
+		return err
+	}); err != nil {
 		return fmt.Errorf("update check failed: %w", err)
 	}
 
+	printLibraryResults(cmd, result.Libraries)
+
 	// Display results
 	if result.ModulesCount == 0 {
 		cmd.Println("No modules installed")
@@ -235,51 +289,82 @@ func runAutoUpdateNow(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// printLibraryResults shows what a check found for the watched libraries
+func printLibraryResults(cmd *cobra.Command, libraries []autoupdate.LibraryResult) {
+	if len(libraries) == 0 {
+		return
+	}
+
+	cmd.Printf("Checked %d watched librar(ies)\n", len(libraries))
+
+	for _, lib := range libraries {
+		if lib.Error != nil {
+			cmd.Printf("  Error: %v\n", lib.Error)
+		}
+
+		if lib.NewRelease {
+			cmd.Printf("  Release available: %s %s -> %s\n", lib.Path, lib.Version, lib.Latest)
+		}
+
+		if len(lib.NewVulns) > 0 {
+			cmd.Printf("  Vulnerable: %s@%s (%s)\n", lib.Path, lib.Version, strings.Join(lib.NewVulns, ", "))
+		}
+	}
+
+	cmd.Println()
+}
+
+// setAutoUpdateConfig applies a change to the auto-update configuration
+func setAutoUpdateConfig(ctx context.Context, req *pb.SetAutoUpdateConfigRequest) error {
+	return withAutoUpdateClient(ctx, func(grpcClient *client.Client) error {
+		_, err := grpcClient.SetAutoUpdateConfig(ctx, req)
+		return err
+	})
+}
+
 func runAutoUpdateConfig(cmd *cobra.Command, _ []string) error {
-	store := autoupdate.GetStore()
-	changed := false
+	ctx := cmd.Context()
+
+	// The changes are applied together, so they take effect at once
+	req := &pb.SetAutoUpdateConfigRequest{}
 
-	// Handle interval
 	if autoUpdateInterval != "" {
 		interval, err := time.ParseDuration(autoUpdateInterval)
 		if err != nil {
 			return fmt.Errorf("invalid interval format: %w", err)
 		}
 
-		if err := store.SetInterval(interval); err != nil {
-			return err
-		}
-
-		cmd.Printf("Interval set to: %s\n", formatDuration(interval))
-
-		changed = true
+		req.IntervalNano = proto.Int64(int64(interval))
 	}
 
-	// Handle notify-only flags
 	if autoUpdateNotifyOnly {
-		if err := store.SetNotifyOnly(true); err != nil {
-			return err
-		}
-
-		cmd.Println("Mode set to: notify-only (no auto-install)")
-
-		changed = true
+		req.NotifyOnly = proto.Bool(true)
 	} else if autoUpdateNoNotify {
-		if err := store.SetNotifyOnly(false); err != nil {
-			return err
-		}
-
-		cmd.Println("Mode set to: auto-install updates")
+		req.NotifyOnly = proto.Bool(false)
+	}
 
-		changed = true
+	if autoUpdatePre {
+		req.IncludePrerelease = proto.Bool(true)
+	} else if autoUpdateNoPre {
+		req.IncludePrerelease = proto.Bool(false)
 	}
 
-	if !changed {
+	if req.IntervalNano == nil && req.NotifyOnly == nil && req.IncludePrerelease == nil {
 		// Show current config
-		cfg := store.Get()
+		var cfg autoupdate.Config
+
+		if err := withAutoUpdateClient(ctx, func(grpcClient *client.Client) error {
+			var err error
+
+			cfg, err = autoupdate.LoadConfig(ctx, grpcClient)
+
+			return err
+		}); err != nil {
+			return err
+		}
 
 		cmd.Println("Current configuration:")
-		cmd.Printf("  Interval:     %s\n", formatDuration(cfg.Interval))
+		cmd.Printf("  Interval:     %s\n", humanize.Duration(cfg.Interval))
 
 		if cfg.NotifyOnly {
 			cmd.Println("  Mode:         notify-only")
@@ -287,43 +372,46 @@ func runAutoUpdateConfig(cmd *cobra.Command, _ []string) error {
 			cmd.Println("  Mode:         auto-install")
 		}
 
+		if cfg.IncludePrerel {
+			cmd.Println("  Pre-releases: all modules")
+		} else {
+			cmd.Println("  Pre-releases: modules installed with --pre")
+		}
+
 		cmd.Println()
 		cmd.Println("Use flags to modify:")
 		cmd.Println("  --interval <duration>   Set check interval (e.g., 24h, 12h)")
 		cmd.Println("  --notify-only           Only notify, don't auto-install")
 		cmd.Println("  --no-notify-only        Auto-install updates")
-	}
-
-	return nil
-}
+		cmd.Println("  --pre                   Update all modules to pre-releases")
+		cmd.Println("  --no-pre                Update only modules installed with --pre to pre-releases")
 
-// formatDuration formats a duration in a human-readable way
-func formatDuration(d time.Duration) string {
-	if d < time.Minute {
-		return d.Round(time.Second).String()
+		return nil
 	}
 
-	if d < time.Hour {
-		return fmt.Sprintf("%dm", int(d.Minutes()))
+	if err := setAutoUpdateConfig(ctx, req); err != nil {
+		return err
 	}
 
-	if d < 24*time.Hour {
-		hours := int(d.Hours())
+	if req.IntervalNano != nil {
+		cmd.Printf("Interval set to: %s\n", humanize.Duration(time.Duration(req.GetIntervalNano())))
+	}
 
-		mins := int(d.Minutes()) % 60
-		if mins > 0 {
-			return fmt.Sprintf("%dh%dm", hours, mins)
+	if req.NotifyOnly != nil {
+		if req.GetNotifyOnly() {
+			cmd.Println("Mode set to: notify-only (no auto-install)")
+		} else {
+			cmd.Println("Mode set to: auto-install updates")
 		}
-
-		return fmt.Sprintf("%dh", hours)
 	}
 
-	days := int(d.Hours()) / 24
-
-	hours := int(d.Hours()) % 24
-	if hours > 0 {
-		return fmt.Sprintf("%dd%dh", days, hours)
+	if req.IncludePrerelease != nil {
+		if req.GetIncludePrerelease() {
+			cmd.Println("Pre-releases set to: all modules")
+		} else {
+			cmd.Println("Pre-releases set to: modules installed with --pre")
+		}
 	}
 
-	return fmt.Sprintf("%dd", days)
+	return nil
 }
@@ -0,0 +1,353 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/inovacc/glix/internal/humanize"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/profiles"
+	"github.com/spf13/cobra"
+)
+
+// buildCmd represents the build command
+var buildCmd = &cobra.Command{
+	Use:   "build <module>[@version]",
+	Short: "Cross-build a CLI for several platforms into a dist directory",
+	Long: `Build the binary of a module for several platforms at once into a dist
+directory, with a checksums file, without installing anything or recording
+it in the database. glix doubles as a quick release builder this way, for
+third-party CLIs as well as local checkouts.
+
+Each platform is built the way 'glix install --goos --goarch' builds it:
+with GoReleaser when the module has a config, with go install otherwise,
+falling back to make or mage, as --build-strategy says. Builds run in
+parallel, --jobs at a time. Artifacts are named
+<binary>_<version>_<goos>_<goarch> (.exe on Windows), and
+<binary>_<version>_checksums.txt lists their SHA-256 in the format
+'sha256sum -c' checks.
+
+Examples:
+  glix build github.com/junegunn/fzf --platforms linux/amd64,darwin/arm64
+  glix build github.com/inovacc/twig@v1.2.0 --platforms linux/amd64,linux/arm64,windows/amd64 -o release/
+  glix build ./path/to/checkout --ldflags "-s -w" --trimpath`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runBuild,
+}
+
+var (
+	buildPlatforms []string
+	buildDist      string
+	buildJobs      int
+	buildProfile   string
+)
+
+func init() {
+	rootCmd.AddCommand(buildCmd)
+
+	buildCmd.Flags().StringSliceVar(&buildPlatforms, "platforms", []string{runtime.GOOS + "/" + runtime.GOARCH}, "Comma-separated goos/goarch platforms to build for")
+	buildCmd.Flags().StringVarP(&buildDist, "dist", "o", "dist", "Directory the artifacts and checksums are written to")
+	buildCmd.Flags().IntVarP(&buildJobs, "jobs", "j", runtime.NumCPU(), "Number of platforms built at the same time")
+	buildCmd.Flags().StringVar(&buildProfile, "profile", "", "Download into the module cache of this profile (see 'glix profile')")
+	_ = buildCmd.RegisterFlagCompletionFunc("profile", completeProfiles)
+}
+
+// buildResult is the outcome of building one platform
+type buildResult struct {
+	platform string
+	artifact string
+	duration time.Duration
+	output   []string // Build output, shown when the build fails
+	err      error
+}
+
+func runBuild(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	type target struct{ goos, goarch string }
+
+	var targets []target
+
+	for _, platform := range buildPlatforms {
+		goos, goarch, err := module.ParsePlatform(platform)
+		if err != nil {
+			return err
+		}
+
+		targets = append(targets, target{goos, goarch})
+	}
+
+	strategy, err := buildStrategy(cmd, "")
+	if err != nil {
+		return err
+	}
+
+	if buildJobs < 1 {
+		return fmt.Errorf("--jobs must be at least 1")
+	}
+
+	// Resolve the version once, so every platform builds the same one
+	modulePath, version := parseModulePath(args[0])
+
+	spec, err := resolveBuild(ctx, cmd, modulePath, version)
+	if err != nil {
+		return err
+	}
+
+	dist, err := filepath.Abs(buildDist)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dist, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dist, err)
+	}
+
+	jobs := min(buildJobs, len(targets))
+
+	out := newPlainRenderer(cmd, false)
+	out.Printf("Building %s@%s for %d platform(s), %d at a time, into %s\n", spec.Name, spec.Version, len(targets), jobs, dist)
+
+	// Lines of concurrent builds are prefixed with their platform
+	width := 0
+	for _, platform := range buildPlatforms {
+		width = max(width, len(platform))
+	}
+
+	results := make([]*buildResult, len(targets))
+	sem := make(chan struct{}, jobs)
+
+	var wg sync.WaitGroup
+
+	for i, t := range targets {
+		results[i] = &buildResult{platform: t.goos + "/" + t.goarch}
+
+		wg.Add(1)
+
+		go func(r *buildResult, goos, goarch string) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			progress := func(phase, message string) {
+				if quietOutput {
+					out.Progress(phase, r.platform+": "+message)
+					return
+				}
+
+				out.Printf("%-*s  [%s] %s\n", width, r.platform, phase, message)
+			}
+
+			start := time.Now()
+			r.artifact, r.err = buildPlatform(ctx, cmd, spec, goos, goarch, dist, strategy, progress, func(_, line string) {
+				r.output = append(r.output, line)
+			})
+			r.duration = time.Since(start)
+
+			if r.err != nil {
+				if quietOutput {
+					out.Progress("error", r.platform+": "+r.err.Error())
+					return
+				}
+
+				out.Printf("%-*s  [failed] %v\n", width, r.platform, r.err)
+
+				return
+			}
+
+			out.Printf("%-*s  [done] %s in %s\n", width, r.platform, filepath.Base(r.artifact), humanize.Duration(r.duration))
+		}(results[i], t.goos, t.goarch)
+	}
+
+	wg.Wait()
+
+	return out.Finish(printBuildSummary(out, spec, dist, results))
+}
+
+// resolveBuild resolves the module and version to build, the CLI of the
+// module when discovery finds one
+func resolveBuild(ctx context.Context, cmd *cobra.Command, modulePath, version string) (*module.Module, error) {
+	workDir, err := module.NewWorkDir("build")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = os.RemoveAll(workDir)
+	}()
+
+	m, err := newBuildModule(ctx, cmd, workDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if module.IsLocalPath(modulePath) {
+		err = m.FetchLocalModuleInfo(modulePath)
+	} else {
+		fullPath := modulePath
+		if version != "" && version != "latest" {
+			fullPath = modulePath + "@" + version
+		}
+
+		err = m.FetchModuleInfo(fullPath)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch module info: %w", err)
+	}
+
+	return m, nil
+}
+
+// newBuildModule returns a module building in workDir with the profile,
+// privacy and offline mode given on cmd
+func newBuildModule(ctx context.Context, cmd *cobra.Command, workDir string) (*module.Module, error) {
+	m, err := module.NewModule(ctx, "go", workDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create module: %w", err)
+	}
+
+	proxy, err := goProxy(cmd, "")
+	if err != nil {
+		return nil, err
+	}
+
+	m.SetGoProxy(proxy)
+	m.SetPrivate(privateModules(cmd, false))
+	m.SetOffline(offline())
+
+	if err := profiles.Apply(m, buildProfile); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// buildPlatform builds spec for goos/goarch into dist and returns the path
+// of the artifact
+func buildPlatform(
+	ctx context.Context,
+	cmd *cobra.Command,
+	spec *module.Module,
+	goos, goarch, dist string,
+	strategy module.BuildStrategy,
+	progressHandler func(phase, message string),
+	outputHandler func(stream, line string),
+) (string, error) {
+	workDir, err := module.NewWorkDir("build")
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = os.RemoveAll(workDir)
+	}()
+
+	m, err := newBuildModule(ctx, cmd, workDir)
+	if err != nil {
+		return "", err
+	}
+
+	// The resolved package and version need no discovery again, and
+	// resolving them again is not worth reporting
+	if spec.LocalPath != "" {
+		err = m.FetchLocalModuleInfo(spec.LocalPath)
+	} else {
+		err = m.FetchModuleInfo(spec.Name + "@" + spec.Version)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch module info: %w", err)
+	}
+
+	m.SetProgressHandler(progressHandler)
+	m.SetPlatform(goos, goarch)
+	m.SetBuildFlags(buildFlags(cmd, nil))
+	m.SetBuildStrategy(strategy)
+
+	return m.BuildArtifact(ctx, dist, outputHandler)
+}
+
+// printBuildSummary lists the results of the builds, with the build output
+// of failed ones, and writes the checksums of the artifacts. It fails when
+// any platform failed.
+func printBuildSummary(out *plainRenderer, spec *module.Module, dist string, results []*buildResult) error {
+	var (
+		artifacts []string
+		failed    int
+	)
+
+	for _, r := range results {
+		if r.err == nil || len(r.output) == 0 {
+			continue
+		}
+
+		out.Printf("\nBuild output of %s:\n", r.platform)
+
+		for _, line := range r.output {
+			out.Printf("  %s\n", line)
+		}
+	}
+
+	out.Printf("\n")
+
+	t := newTable(
+		column{Header: "STATUS", Style: eventOutcomeStyle},
+		column{Header: "PLATFORM"},
+		column{Header: "ARTIFACT", Shrink: true},
+		column{Header: "SIZE"},
+		column{Header: "TIME"},
+		column{Header: "ERROR", Shrink: true, KeepStart: true},
+	)
+
+	for _, r := range results {
+		if r.err != nil {
+			failed++
+
+			t.addRow("failed", r.platform, "", "", humanize.Duration(r.duration), strings.ReplaceAll(r.err.Error(), "\n", " "))
+
+			continue
+		}
+
+		artifacts = append(artifacts, r.artifact)
+
+		size := ""
+		if info, err := os.Stat(r.artifact); err == nil {
+			size = humanize.Size(info.Size())
+		}
+
+		t.addRow("ok", r.platform, filepath.Base(r.artifact), size, humanize.Duration(r.duration), "")
+	}
+
+	if !quietOutput {
+		if err := t.write(out.cmd.OutOrStdout()); err != nil {
+			return err
+		}
+	}
+
+	if len(artifacts) > 0 {
+		checksums := filepath.Join(dist, module.ChecksumsName(module.BinaryName(spec.Name), spec.Version))
+
+		if err := module.WriteChecksums(checksums, artifacts); err != nil {
+			return err
+		}
+
+		out.Printf("\nChecksums: %s\n", checksums)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d platform(s) failed to build", failed, len(results))
+	}
+
+	out.Status(fmt.Sprintf("Built %d artifact(s) into %s", len(artifacts), dist))
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"github.com/inovacc/glix/internal/module"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// go build flags of install, update and build, see buildFlags
+var (
+	buildLDFlags  string
+	buildTags     string
+	buildTrimPath bool
+	preferBinary  bool
+	prerelease    bool
+	privateModule bool
+	goProxyFlag   string
+	offlineMode   bool
+	strategyFlag  string
+)
+
+func init() {
+	for _, c := range []*cobra.Command{installCmd, updateCmd} {
+		c.Flags().BoolVar(&preferBinary, "prefer-binary", false, "Install the prebuilt binary of the GitHub release when there is one")
+		c.Flags().BoolVar(&prerelease, "pre", false, "Install pre-releases such as rc and beta tags when they are newer than the latest release")
+	}
+
+	for _, c := range []*cobra.Command{installCmd, updateCmd, buildCmd} {
+		c.Flags().StringVar(&buildLDFlags, "ldflags", "", `Build with these -ldflags, e.g. "-s -w"`)
+		c.Flags().StringVar(&buildTags, "tags", "", "Build with these comma-separated build tags")
+		c.Flags().BoolVar(&buildTrimPath, "trimpath", false, "Build with -trimpath")
+		c.Flags().BoolVar(&privateModule, "private", false, "Fetch the module from its repository, without the module proxy and checksum database (GOPRIVATE)")
+		c.Flags().BoolVar(&offlineMode, "offline", false, "Resolve and build from the local module cache only, without the network")
+		c.Flags().StringVar(&goProxyFlag, "goproxy", "", "Download through this GOPROXY, e.g. https://athens.corp,direct, instead of the profile's or the environment's")
+		c.Flags().StringVar(&strategyFlag, "build-strategy", "", "Build from source with auto, go, goreleaser, make[:target] or mage[:target] (default auto)")
+		_ = c.RegisterFlagCompletionFunc("build-strategy", cobra.FixedCompletions([]string{
+			module.StrategyAuto, module.StrategyGo, module.StrategyGoReleaser, module.StrategyMake, module.StrategyMage,
+		}, cobra.ShellCompDirectiveNoFileComp))
+	}
+}
+
+// buildFlags returns the build flags given on cmd, which replace the recorded
+// ones as a whole, or the recorded flags when none are given
+func buildFlags(cmd *cobra.Command, recorded *pb.BuildFlagsProto) module.BuildFlags {
+	f := cmd.Flags()
+	if !f.Changed("ldflags") && !f.Changed("tags") && !f.Changed("trimpath") {
+		return module.BuildFlagsFromProto(recorded)
+	}
+
+	return module.BuildFlags{
+		LDFlags:  buildLDFlags,
+		Tags:     module.ParseTags(buildTags),
+		TrimPath: buildTrimPath,
+	}
+}
+
+// preferBinaries returns whether to install prebuilt release binaries: as
+// given on cmd, or as recorded when --prefer-binary is not given
+func preferBinaries(cmd *cobra.Command, recorded bool) bool {
+	if cmd.Flags().Changed("prefer-binary") {
+		return preferBinary
+	}
+
+	return recorded
+}
+
+// prereleases returns whether installs pick pre-releases: as given on cmd,
+// or as recorded when --pre is not given
+func prereleases(cmd *cobra.Command, recorded bool) bool {
+	if cmd.Flags().Changed("pre") {
+		return prerelease
+	}
+
+	return recorded
+}
+
+// buildStrategy returns the build strategy given on cmd, or the recorded one
+// when --build-strategy is not given
+func buildStrategy(cmd *cobra.Command, recorded string) (module.BuildStrategy, error) {
+	if cmd.Flags().Changed("build-strategy") {
+		return module.ParseBuildStrategy(strategyFlag)
+	}
+
+	return module.ParseBuildStrategy(recorded)
+}
+
+// offline returns whether to work from the local module cache only: with
+// --offline, or when GOPROXY=off rules out the network anyway
+func offline() bool {
+	return offlineMode || module.OfflineEnv()
+}
+
+// goProxy returns the GOPROXY to download through: as given on cmd, where
+// an empty --goproxy goes back to that of the profile or the environment, or
+// as recorded when --goproxy is not given
+func goProxy(cmd *cobra.Command, recorded string) (string, error) {
+	if !cmd.Flags().Changed("goproxy") {
+		return recorded, nil
+	}
+
+	if goProxyFlag == "" {
+		return "", nil
+	}
+
+	if err := module.ValidateGoProxy(goProxyFlag); err != nil {
+		return "", err
+	}
+
+	return goProxyFlag, nil
+}
+
+// privateModules returns whether to treat the module as private: as given on
+// cmd, or as recorded when --private is not given
+func privateModules(cmd *cobra.Command, recorded bool) bool {
+	if cmd.Flags().Changed("private") {
+		return privateModule
+	}
+
+	return recorded
+}
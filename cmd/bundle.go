@@ -0,0 +1,338 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/inovacc/glix/internal/bundle"
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/hooks"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/quarantine"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// bundleCmd represents the bundle parent command
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Package modules for installation on air-gapped machines",
+	Long: `Bundles carry modules to machines without network access. 'bundle create'
+runs on a connected machine and packages prebuilt binaries, the module zips
+and go.mod files needed to build them, and a manifest with their SHA-256
+and go.sum hashes into a single tar.zst file.
+
+'bundle install' runs entirely offline. Every file is verified against the
+manifest before anything is installed, and a bundle with modified, missing,
+or extra files is rejected. Prebuilt binaries are used when the platform
+matches; otherwise each module is rebuilt from the bundled sources.
+Each module is checked against this machine's denylist, install policy and
+binary owners, and the install hooks run around it, as for 'glix install'.
+
+Examples:
+  glix bundle create github.com/spf13/cobra-cli golang.org/x/tools/cmd/goimports@v0.28.0 -o tools.tar.zst
+  glix bundle show tools.tar.zst
+  glix bundle install tools.tar.zst`,
+}
+
+// bundleCreateCmd packages modules into a bundle
+var bundleCreateCmd = &cobra.Command{
+	Use:   "create <module[@version]>...",
+	Short: "Package modules into a bundle",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runBundleCreate,
+}
+
+// bundleInstallCmd installs a bundle offline
+var bundleInstallCmd = &cobra.Command{
+	Use:   "install <bundle>",
+	Short: "Verify and install the modules in a bundle without network access",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runBundleInstall,
+}
+
+// bundleShowCmd lists the contents of a bundle
+var bundleShowCmd = &cobra.Command{
+	Use:   "show <bundle>",
+	Short: "Verify a bundle and list its modules",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runBundleShow,
+}
+
+var bundleOutput string
+
+func init() {
+	rootCmd.AddCommand(bundleCmd)
+
+	bundleCmd.AddCommand(bundleCreateCmd)
+	bundleCmd.AddCommand(bundleInstallCmd)
+	bundleCmd.AddCommand(bundleShowCmd)
+
+	bundleCreateCmd.Flags().StringVarP(&bundleOutput, "output", "o", "bundle.tar.zst", "Bundle file to write")
+}
+
+func runBundleCreate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	progressHandler := func(phase, message string) {
+		cmd.Printf("[%s] %s\n", phase, message)
+	}
+
+	modules := make([]*module.Module, 0, len(args))
+
+	for _, arg := range args {
+		workDir, err := module.NewWorkDir("bundle")
+		if err != nil {
+			return err
+		}
+
+		m, err := resolveBundleModule(cmd, workDir, arg, progressHandler)
+
+		_ = os.RemoveAll(workDir)
+
+		if err != nil {
+			return err
+		}
+
+		modules = append(modules, m)
+	}
+
+	manifest, err := bundle.Create(ctx, bundleOutput, modules, progressHandler)
+	if err != nil {
+		_ = os.Remove(bundleOutput)
+		return err
+	}
+
+	cmd.Printf("Created %s: %d module(s), %d module cache file(s), built for %s/%s\n",
+		bundleOutput, len(manifest.Modules), len(manifest.Files), manifest.GOOS, manifest.GOARCH)
+
+	return nil
+}
+
+// resolveBundleModule resolves a module for bundling, applying the install
+// policy on the connected machine since the target may not be able to
+func resolveBundleModule(cmd *cobra.Command, workDir, arg string, progressHandler func(phase, message string)) (*module.Module, error) {
+	if module.IsLocalPath(arg) {
+		return nil, fmt.Errorf("local paths cannot be bundled: %s", arg)
+	}
+
+	m, err := module.NewModule(cmd.Context(), "go", workDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create module: %w", err)
+	}
+
+	m.SetProgressHandler(progressHandler)
+
+	if err := m.FetchModuleInfo(arg); err != nil {
+		return nil, fmt.Errorf("failed to fetch module info for %s: %w", arg, err)
+	}
+
+	if err := enforcePolicy(cmd.Context(), m, progressHandler); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func runBundleInstall(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	b, err := openBundle(cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = b.Close()
+	}()
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	if !b.Manifest.MatchesPlatform() {
+		cmd.Printf("[build] Bundle was built for %s/%s; rebuilding from bundled sources\n", b.Manifest.GOOS, b.Manifest.GOARCH)
+	}
+
+	scanner, err := quarantine.Load(quarantine.DefaultPath())
+	if err != nil {
+		return err
+	}
+
+	gobin := module.GetGoBinDirectory()
+	namespace := serverNamespace(ctx, grpcClient)
+
+	for _, e := range b.Manifest.Modules {
+		m := e.Module
+		cmd.Printf("[install] Installing %s@%s...\n", m.Name, m.Version)
+
+		progressHandler := func(phase, message string) {
+			cmd.Printf("[%s] %s\n", phase, message)
+		}
+
+		m.SetProgressHandler(progressHandler)
+
+		if err := checkBundled(ctx, grpcClient, m, namespace, progressHandler); err != nil {
+			return err
+		}
+
+		if err := m.RunHooks(ctx, true); err != nil {
+			return err
+		}
+
+		if err := installBundled(ctx, b, e, gobin, scanner); err != nil {
+			recordFailure(ctx, grpcClient, pb.EventAction_EVENT_ACTION_INSTALL, m.Name, "", m.Version, err)
+			return err
+		}
+
+		m.Time = time.Now()
+		m.SetBinaryPath(module.InstalledBinaryPath(m.Name))
+
+		if err := m.RunHooks(ctx, false); err != nil {
+			return err
+		}
+
+		claimOwnership(m, namespace, progressHandler)
+		writeReceipt(m.Name, m.Version, m.BinaryPath, m.ShimPath, namespace, progressHandler)
+
+		if err := grpcClient.StoreModule(ctx, m); err != nil {
+			cmd.Printf("[warning] failed to store module in database: %v\n", err)
+		}
+	}
+
+	cmd.Printf("Installed %d module(s) from %s\n", len(b.Manifest.Modules), args[0])
+
+	return nil
+}
+
+// checkBundled applies the checks of glix install to a bundled module on
+// this machine: its denylist, install policy, and binary owners, which may
+// differ from those of the machine that created the bundle. The configured
+// hooks are set to run around the install.
+func checkBundled(ctx context.Context, grpcClient *client.Client, m *module.Module, namespace string, progressHandler func(phase, message string)) error {
+	var badVersions []string
+	if resp, err := grpcClient.GetModule(ctx, m.Name, ""); err == nil {
+		badVersions = resp.GetModule().GetBadVersions()
+	}
+
+	// Bundles install offline, so the denylist catalogs synced last apply
+	if reason := excludedReason(m.Name, m.Version, badVersions); reason != "" {
+		return fmt.Errorf("%s@%s is %s; it cannot be installed from the bundle", m.Name, m.Version, reason)
+	}
+
+	if err := enforcePolicy(ctx, m, progressHandler); err != nil {
+		return err
+	}
+
+	if err := checkOwnership(module.InstalledBinaryPath(m.Name), namespace, progressHandler); err != nil {
+		return err
+	}
+
+	return hooks.Apply(m)
+}
+
+// installBundled installs the binaries of a bundled module in gobin. With a
+// quarantine scanner they are staged first, and placed only once every one
+// of them is clean.
+func installBundled(ctx context.Context, b *bundle.Bundle, e bundle.Entry, gobin string, scanner *quarantine.Config) error {
+	if scanner == nil {
+		return b.Install(ctx, e, gobin)
+	}
+
+	staging, err := os.MkdirTemp(b.Dir, "staging-")
+	if err != nil {
+		return fmt.Errorf("failed to create staging directory: %w", err)
+	}
+
+	defer func() {
+		_ = os.RemoveAll(staging)
+	}()
+
+	if err := b.Install(ctx, e, staging); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(staging)
+	if err != nil {
+		return fmt.Errorf("failed to read staging directory: %w", err)
+	}
+
+	e.Module.SetScanner(scanner.Scan)
+
+	for _, f := range entries {
+		if err := e.Module.ScanBinary(ctx, filepath.Join(staging, f.Name())); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(gobin, 0755); err != nil {
+		return fmt.Errorf("failed to create GOBIN directory: %w", err)
+	}
+
+	for _, f := range entries {
+		if err := module.RestoreBinary(filepath.Join(staging, f.Name()), filepath.Join(gobin, f.Name())); err != nil {
+			return fmt.Errorf("failed to install %s: %w", f.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+func runBundleShow(cmd *cobra.Command, args []string) error {
+	b, err := openBundle(cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = b.Close()
+	}()
+
+	cmd.Println()
+	cmd.Printf("Bundle:   %s\n", args[0])
+	cmd.Printf("Created:  %s\n", b.Manifest.Created.Format("2006-01-02 15:04"))
+	cmd.Printf("Platform: %s/%s\n", b.Manifest.GOOS, b.Manifest.GOARCH)
+	cmd.Printf("Cache:    %d file(s)\n", len(b.Manifest.Files))
+	cmd.Println()
+	cmd.Printf("Modules (%d):\n", len(b.Manifest.Modules))
+
+	for _, e := range b.Manifest.Modules {
+		cmd.Printf("  %s@%s\n", e.Module.Name, e.Module.Version)
+
+		for _, bin := range e.Binaries {
+			cmd.Printf("    %s (sha256 %s)\n", path.Base(bin.Path), bin.SHA256[:12])
+		}
+	}
+
+	cmd.Println()
+
+	return nil
+}
+
+// openBundle extracts and verifies a bundle
+func openBundle(cmd *cobra.Command, file string) (*bundle.Bundle, error) {
+	cmd.Printf("[verify] Verifying %s...\n", file)
+
+	b, err := bundle.Open(file)
+	if err != nil {
+		return nil, err
+	}
+
+	count := len(b.Manifest.Files)
+	for _, e := range b.Manifest.Modules {
+		count += len(e.Binaries)
+	}
+
+	cmd.Printf("[verify] %d file(s) match the manifest\n", count)
+
+	return b, nil
+}
@@ -11,26 +11,131 @@ import (
 const commandTree = `# Command Tree
 
 glix [module]
++-- alias                                    # Rename the binary of an installed module
++-- audit                                    # Scan installed binaries for known vul...
 +-- auto-update                              # Manage automatic update settings
 |   +-- config                               # Configure auto-update settings
 |   +-- disable                              # Disable automatic updates
 |   +-- enable                               # Enable automatic updates
 |   +-- now                                  # Run update check immediately
 |   \-- status                               # Show auto-update status
++-- build                                    # Cross-build a CLI for several platfor...
++-- bundle                                   # Package modules for installation on a...
+|   +-- create                               # Package modules into a bundle
+|   +-- install                              # Verify and install the modules in a b...
+|   \-- show                                 # Verify a bundle and list its modules
 +-- cmdtree                                  # Display command tree visualization
++-- completion-server                        # Answer completion queries over a loca...
++-- constraint                               # Manage version constraints between in...
+|   +-- add                                  # Declare a constraint for a module
+|   +-- check                                # Check installed modules against decla...
+|   +-- list                                 # List declared constraints
+|   \-- remove                               # Remove a constraint by its number in ...
++-- context                                  # Manage the glix servers the CLI talks to
+|   +-- add                                  # Add or replace a context
+|   +-- current                              # Show the current context
+|   +-- list                                 # List contexts
+|   +-- remove                               # Remove a context
+|   \-- use                                  # Make a context the current one
++-- db                                       # Work with the glix database
+|   \-- export                               # Export installed modules, dependencie...
++-- denylist                                 # Manage module versions that must not ...
+|   +-- add                                  # Deny a module version on this machine
+|   +-- catalog                              # Manage shared denylist catalogs
+|   |   +-- add                              # Subscribe to a denylist catalog and s...
+|   |   \-- remove                           # Unsubscribe from a denylist catalog
+|   +-- list                                 # List denied versions and configured c...
+|   +-- remove                               # Remove a version from the local denylist
+|   \-- sync                                 # Fetch denied versions from the config...
++-- deps                                     # Show the dependency tree of an instal...
++-- dev                                      # Install a CLI from a local directory,...
++-- doctor                                   # Diagnose problems with the glix insta...
++-- export                                   # Write the installed modules to a mani...
++-- fleet                                    # Report inventories to a central glix ...
+|   +-- config                               # Configure reporting to a central server
+|   +-- list                                 # Show which machines run which module ...
+|   +-- push                                 # Report this machine's inventory now
+|   \-- status                               # Show fleet reporting configuration
++-- help-for                                 # Show the captured help and man pages ...
++-- history                                  # Show the history of installs, updates...
++-- hold                                     # Suppress updates for a module until a...
++-- hooks                                    # Inspect the commands run before and a...
+|   +-- run                                  # Run the hooks of an event for an inst...
+|   \-- show                                 # Show the hooks file location and hooks
++-- import                                   # Install and remove modules to match a...
++-- info                                     # Inspect a remote module without insta...
 +-- install                                  # Install a Go module
++-- licenses                                 # List the licenses of installed module...
 +-- list                                     # List all installed modules
++-- metrics                                  # Export metrics about installed tools
+|   \-- write                                # Write metrics in the node_exporter te...
 +-- monitor                                  # Check all installed modules for avail...
++-- naming                                   # Name installed binaries by a template
++-- outdated                                 # List installed modules with newer ver...
++-- path                                     # Put the bin directory on PATH
+|   \-- add                                  # Add a directory, GOBIN by default, to...
++-- policy                                   # Inspect the install-time policy
+|   +-- check                                # Evaluate the policy for a module vers...
+|   \-- show                                 # Show the policy file location and rules
++-- prefetch                                 # Warm the caches for the modules of a ...
++-- private                                  # Configure installs of private modules
+|   +-- add                                  # Fetch modules matching a pattern from...
+|   +-- list                                 # List the private module configuration
+|   +-- netrc                                # Use a netrc file for the credentials ...
+|   +-- remove                               # Remove private module patterns and SS...
+|   \-- ssh                                  # Fetch repositories of a host over SSH...
++-- profile                                  # Manage install profiles with their ow...
+|   +-- add                                  # Add or update a profile
+|   +-- list                                 # List profiles with their modules and ...
+|   \-- remove                               # Remove a profile and delete its modul...
++-- prune                                    # Delete orphaned binaries and stale wo...
++-- quarantine                               # Inspect the scanner built binaries mu...
+|   +-- scan                                 # Scan a file with the configured scanner
+|   \-- show                                 # Show the quarantine file location and...
++-- readme                                   # Show a module's README in the terminal
++-- rebuild                                  # Rebuild an installed module from sour...
++-- refresh                                  # Re-resolve the metadata of installed ...
 +-- remove                                   # Remove an installed Go module
 +-- report                                   # Show details about an installed module
++-- report-broken                            # Mark the installed version as bad and...
++-- rollback                                 # Restore the previously installed vers...
++-- run                                      # Run a Go tool without installing it
++-- search                                   # Search the Go package index for insta...
 +-- service                                  # Manage the glix background service
 |   +-- install                              # Install the glix service on the system
 |   +-- start                                # Start the glix service
 |   +-- status                               # Show the glix service status
 |   +-- stop                                 # Stop the glix service
 |   \-- uninstall                            # Remove the glix service from the system
++-- snapshot                                 # Manage named snapshots of the install...
+|   +-- create                               # Capture the installed module set
+|   +-- delete                               # Delete a stored snapshot
+|   +-- diff                                 # Show differences between two snapshots
+|   +-- list                                 # List stored snapshots
+|   \-- restore                              # Install, remove, or roll back modules...
++-- stats                                    # Show weekly trends of installs, updat...
++-- suggest                                  # Suggest tools installed outside glix ...
++-- tasks                                    # Manage tasks the daemon runs on a sch...
+|   +-- add                                  # Add a task that runs a command on a s...
+|   +-- list                                 # List tasks with their schedules and l...
+|   +-- remove                               # Remove a user task, or restore a buil...
+|   +-- run                                  # Run a task now and wait for it to finish
+|   \-- schedule                             # Change the schedule of a task, or ena...
++-- token                                    # Manage scoped API tokens for automation
+|   +-- create                               # Create a token and print it
+|   +-- list                                 # List tokens with their scope and expiry
+|   \-- revoke                               # Revoke a token
++-- unhold                                   # Release a hold so the module updates ...
 +-- update                                   # Update an installed Go module to the ...
-\-- version                                  # Print version information
++-- use                                      # Switch the active version of a tool i...
++-- verify                                   # Check installed binaries against the ...
++-- verify-manifest                          # Check that installed modules match a ...
++-- version                                  # Print version information
++-- watch                                    # Watch library modules for new release...
+|   +-- add                                  # Watch a library module, at the versio...
+|   +-- list                                 # List watched library modules with the...
+|   \-- remove                               # Stop watching a library module
+\-- which                                    # Show which installed module provides ...
 `
 
 var cmdtreeCmd = &cobra.Command{
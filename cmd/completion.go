@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/spf13/cobra"
+)
+
+// completionTimeout bounds how long completing module names may take,
+// including starting an on-demand server
+const completionTimeout = 5 * time.Second
+
+// completionCmd represents the completion command
+var completionCmd = &cobra.Command{
+	Use:   "completion <bash|zsh|fish|powershell>",
+	Short: "Generate a shell completion script",
+	Long: `Generate a completion script for glix for the given shell.
+
+Commands, flags, and the names of installed modules (for remove, update,
+and report) are completed. Module names are read from the glix server,
+which is started on demand.
+
+Bash (requires the bash-completion package):
+  source <(glix completion bash)
+  # Load for every session
+  glix completion bash > /etc/bash_completion.d/glix
+
+Zsh:
+  # Enable completion once, if not already done
+  echo "autoload -U compinit; compinit" >> ~/.zshrc
+  glix completion zsh > "${fpath[1]}/_glix"
+
+Fish:
+  glix completion fish > ~/.config/fish/completions/glix.fish
+
+PowerShell:
+  glix completion powershell | Out-String | Invoke-Expression
+  # Load for every session
+  glix completion powershell >> $PROFILE
+
+Start a new shell for the completion to take effect. Custom completers
+for large inventories can query 'glix completion-server' instead, which
+answers from memory.`,
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	DisableFlagsInUseLine: true,
+	RunE:                  runCompletion,
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
+
+func runCompletion(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
+
+	switch args[0] {
+	case "bash":
+		return rootCmd.GenBashCompletionV2(out, true)
+	case "zsh":
+		return rootCmd.GenZshCompletion(out)
+	case "fish":
+		return rootCmd.GenFishCompletion(out, true)
+	case "powershell":
+		return rootCmd.GenPowerShellCompletionWithDesc(out)
+	default:
+		return fmt.Errorf("unsupported shell %q", args[0])
+	}
+}
+
+// completeInstalledModules completes the first argument with the names of
+// installed modules, described by their version
+func completeInstalledModules(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), completionTimeout)
+	defer cancel()
+
+	// Log lines would end up in the shell's completion output
+	cfg := client.DefaultDiscoveryConfig()
+	cfg.Logger = nil
+
+	grpcClient, err := client.GetClient(ctx, cfg)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	resp, err := grpcClient.ListModules(ctx, 0, 0, "")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+
+	for _, mod := range resp.GetModules() {
+		if strings.HasPrefix(mod.GetName(), toComplete) {
+			names = append(names, mod.GetName()+"\t"+mod.GetVersion())
+		}
+	}
+
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/completion"
+	"github.com/inovacc/glix/internal/owners"
+	"github.com/spf13/cobra"
+)
+
+// completionServerCmd represents the completion-server command
+var completionServerCmd = &cobra.Command{
+	Use:   "completion-server",
+	Short: "Answer completion queries over a local socket for fast shell completers",
+	Long: `Serve completion candidates from memory over a loopback TCP socket, so
+shell completers stay fast with large inventories. Completing through
+'glix completion' starts glix and queries the server on every key press;
+the completion server keeps the installed modules, binaries, known
+versions, snapshot tags and namespaces in memory, reloads them from the glix
+server every --refresh, and answers in well under a millisecond.
+
+Queries are sent one per line; each is answered with one candidate per line,
+a tab and a description following those that have one, and an empty line:
+
+  modules <prefix>             Installed modules, described by version
+  binaries <prefix>            Installed binaries, described by module
+  versions <module> <prefix>   Known versions of a module, newest first
+  tags <prefix>                Snapshot names
+  namespaces <prefix>          Server namespaces
+  ping                         Answered with pong
+
+Invalid queries are answered with a line starting with "error: ".
+Reloading keeps an on-demand glix server from idling out.
+
+Examples:
+  glix completion-server &
+  printf 'modules github.com/\n' | nc -q 1 127.0.0.1 9744
+
+Bash:
+  _glix_modules() {
+    exec {fd}<>/dev/tcp/127.0.0.1/9744 || return
+    printf 'modules %s\n' "$1" >&$fd
+    while IFS= read -r line <&$fd && [[ -n $line ]]; do
+      COMPREPLY+=("${line%%$'\t'*}")
+    done
+    exec {fd}>&-
+  }
+
+Zsh:
+  zmodload zsh/net/tcp
+  ztcp 127.0.0.1 9744 && print -u $REPLY "versions $module $PREFIX"`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runCompletionServer,
+}
+
+var (
+	completionServerListen  string
+	completionServerRefresh time.Duration
+)
+
+func init() {
+	rootCmd.AddCommand(completionServerCmd)
+
+	completionServerCmd.Flags().StringVar(&completionServerListen, "listen", completion.DefaultAddress, "Loopback address to answer queries on")
+	completionServerCmd.Flags().DurationVar(&completionServerRefresh, "refresh", completion.DefaultRefresh, "How often to reload the candidates from the glix server")
+}
+
+func runCompletionServer(cmd *cobra.Command, _ []string) error {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+	}))
+
+	srv, err := completion.New(completion.Config{
+		Address: completionServerListen,
+		Refresh: completionServerRefresh,
+		Logger:  logger,
+	}, loadCompletionIndex)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	return srv.Start(ctx)
+}
+
+// loadCompletionIndex reads the candidates of the completion server from the
+// glix server
+func loadCompletionIndex(ctx context.Context) (*completion.Index, error) {
+	// Log lines of starting an on-demand server are of no use here
+	cfg := client.DefaultDiscoveryConfig()
+	cfg.Logger = nil
+
+	grpcClient, err := client.GetClient(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	modules, err := grpcClient.ListModules(ctx, 0, 0, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	snapshots, err := grpcClient.ListSnapshots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	// Namespaces of other servers are known from the binaries they manage
+	namespaces := owners.GetStore().Namespaces()
+	if status, err := grpcClient.GetStatus(ctx); err == nil {
+		namespaces = append(namespaces, status.GetNamespace())
+	}
+
+	return completion.NewIndex(modules.GetModules(), snapshots.GetSnapshots(), namespaces), nil
+}
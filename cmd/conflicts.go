@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/module"
+)
+
+// installForce lets install replace the binary of another installed module
+// of the same name
+var installForce bool
+
+func init() {
+	installCmd.Flags().BoolVar(&installForce, "force", false, "Install even when the binary replaces the one of another installed module")
+}
+
+// checkBinaryConflict refuses an install whose binary would replace the
+// binary of another installed module, such as two modules both installing
+// a binary named server, unless --force is given
+func checkBinaryConflict(ctx context.Context, grpcClient *client.Client, m *module.Module, progressHandler func(phase, message string)) error {
+	resp, err := grpcClient.ListModules(ctx, 0, 0, "")
+	if err != nil {
+		// Without the records there is nothing to compare against
+		return nil
+	}
+
+	path := m.InstallPath()
+
+	for _, other := range resp.GetModules() {
+		if other.GetName() == m.Name {
+			continue
+		}
+
+		_, binPath := module.RecordedBinary(other)
+		if !module.SameCommand(cmp.Or(other.GetShimPath(), binPath), path) {
+			continue
+		}
+
+		if installForce {
+			progressHandler("warning", fmt.Sprintf("replacing %s of %s, which stays recorded; remove it with 'glix remove %s'",
+				path, other.GetName(), other.GetName()))
+
+			continue
+		}
+
+		return fmt.Errorf("%s would replace %s, the binary of %s@%s; install it under another name with --as <name>, or replace it with --force",
+			m.Name, path, other.GetName(), other.GetVersion())
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/constraints"
+	"github.com/spf13/cobra"
+)
+
+// constraintCmd represents the constraint parent command
+var constraintCmd = &cobra.Command{
+	Use:   "constraint",
+	Short: "Manage version constraints between installed modules",
+	Long: `Constraints declare relationships between tools that must be kept in
+lockstep. Before installing an update, glix checks every constraint that
+involves the module and refuses the update when one would be violated,
+reporting the conflict instead of breaking your pipeline.
+
+Constraint kinds:
+  --match <module>   Versions must agree at --level (major, minor or exact).
+                     The target is looked up among installed modules first,
+                     then in the constrained module's own dependencies.
+  --max-go <target>  The module's go directive must not be newer than a Go
+                     version (1.22) or the go directive of a project go.mod.
+
+Examples:
+  glix constraint add google.golang.org/grpc/cmd/protoc-gen-go-grpc --match google.golang.org/grpc
+  glix constraint add github.com/golangci/golangci-lint --max-go ./go.mod
+  glix constraint list
+  glix constraint check
+  glix constraint remove 1`,
+}
+
+// constraintAddCmd declares a new constraint
+var constraintAddCmd = &cobra.Command{
+	Use:   "add <module>",
+	Short: "Declare a constraint for a module",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConstraintAdd,
+}
+
+// constraintListCmd lists declared constraints
+var constraintListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List declared constraints",
+	RunE:  runConstraintList,
+}
+
+// constraintRemoveCmd removes a declared constraint
+var constraintRemoveCmd = &cobra.Command{
+	Use:   "remove <number>",
+	Short: "Remove a constraint by its number in 'constraint list'",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConstraintRemove,
+}
+
+// constraintCheckCmd checks the installed modules against all constraints
+var constraintCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check installed modules against declared match constraints",
+	Long: `Check the installed modules against every declared match constraint.
+max-go constraints are evaluated when a module is installed or updated.`,
+	RunE: runConstraintCheck,
+}
+
+var (
+	constraintMatch string
+	constraintLevel string
+	constraintMaxGo string
+)
+
+func init() {
+	rootCmd.AddCommand(constraintCmd)
+
+	constraintCmd.AddCommand(constraintAddCmd)
+	constraintCmd.AddCommand(constraintListCmd)
+	constraintCmd.AddCommand(constraintRemoveCmd)
+	constraintCmd.AddCommand(constraintCheckCmd)
+
+	constraintAddCmd.Flags().StringVar(&constraintMatch, "match", "", "Module whose version must match")
+	constraintAddCmd.Flags().StringVar(&constraintLevel, "level", constraints.LevelMajor, "Match level: major, minor or exact")
+	constraintAddCmd.Flags().StringVar(&constraintMaxGo, "max-go", "", "Maximum Go version, or a go.mod whose go directive is the maximum")
+	constraintAddCmd.MarkFlagsMutuallyExclusive("match", "max-go")
+	constraintAddCmd.MarkFlagsOneRequired("match", "max-go")
+}
+
+func runConstraintAdd(cmd *cobra.Command, args []string) error {
+	modulePath, _ := parseModulePath(args[0])
+
+	c := constraints.Constraint{Module: modulePath}
+
+	if constraintMatch != "" {
+		c.Kind = constraints.KindMatch
+		c.Target, _ = parseModulePath(constraintMatch)
+		c.Level = constraintLevel
+	} else {
+		c.Kind = constraints.KindMaxGo
+		c.Target = constraintMaxGo
+	}
+
+	if err := constraints.GetStore().Add(c); err != nil {
+		return err
+	}
+
+	cmd.Printf("Constraint added: %s\n", c)
+
+	return nil
+}
+
+func runConstraintList(cmd *cobra.Command, _ []string) error {
+	declared := constraints.GetStore().List()
+	if len(declared) == 0 {
+		cmd.Println("No constraints declared")
+		return nil
+	}
+
+	cmd.Println()
+	cmd.Printf("Constraints (%d):\n", len(declared))
+	cmd.Println()
+
+	for i, c := range declared {
+		cmd.Printf("  %d. %s\n", i+1, c)
+	}
+
+	cmd.Println()
+
+	return nil
+}
+
+func runConstraintRemove(cmd *cobra.Command, args []string) error {
+	index, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid constraint number %q", args[0])
+	}
+
+	removed, err := constraints.GetStore().Remove(index)
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("Constraint removed: %s\n", removed)
+
+	return nil
+}
+
+func runConstraintCheck(cmd *cobra.Command, _ []string) error {
+	declared := constraints.GetStore().List()
+	if len(declared) == 0 {
+		cmd.Println("No constraints declared")
+		return nil
+	}
+
+	cfg := client.DefaultDiscoveryConfig()
+
+	grpcClient, err := client.GetClient(cmd.Context(), cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	resp, err := grpcClient.ListModules(cmd.Context(), 0, 0, "")
+	if err != nil {
+		return fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	modules := make([]constraints.Module, 0, len(resp.GetModules()))
+	for _, m := range resp.GetModules() {
+		modules = append(modules, constraints.FromProto(m))
+	}
+
+	conflicts := constraints.Check(declared, modules, nil)
+	if len(conflicts) == 0 {
+		cmd.Printf("All %d constraint(s) satisfied\n", len(declared))
+		return nil
+	}
+
+	for _, c := range conflicts {
+		cmd.Printf("  - %s\n", c)
+	}
+
+	return fmt.Errorf("%d constraint conflict(s)", len(conflicts))
+}
@@ -0,0 +1,386 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/contexts"
+	"github.com/inovacc/glix/internal/server"
+	"github.com/spf13/cobra"
+)
+
+// localOnlyAnnotation marks commands that change or inspect binaries on this
+// machine. Only the local server records them, so these commands refuse to
+// run against a remote one.
+const localOnlyAnnotation = "glix.local-only"
+
+// contextCmd represents the context command
+var contextCmd = &cobra.Command{
+	Use:   "context",
+	Short: "Manage the glix servers the CLI talks to",
+	Long: `Manage named glix servers, kubeconfig-style, so one CLI can manage the
+local daemon and daemons on other machines.
+
+Commands target the current context, which is "local" (the server on this
+machine, started on demand) unless another one is selected with 'glix
+context use'. The global --server flag overrides it for a single command
+and takes a context name or a host:port address. Addresses given directly
+connect in plaintext; define a context to use TLS.
+
+Only "local" is started on demand; other servers must be running. Commands
+that work with binaries on this machine (install, update, remove, ...)
+refuse to target a server on another machine.
+
+A daemon serves TLS when started with --tls-cert and --tls-key (see 'glix
+service install') and binds a reachable address with --bind. On the
+daemon's own machine, reach it through a context on localhost with
+--ca-file set to its certificate.
+
+Daemons with API tokens (see 'glix token') require one from other
+machines. --token-file names the file holding it; $GLIX_TOKEN overrides
+it, e.g. in CI. Tokens are only sent over TLS.
+
+Examples:
+  glix context add work-vm --address 10.0.0.5:9742 --tls --ca-file ca.pem
+  glix context add shared --address glix.internal:9742 --tls --token-file ~/.glix-token
+  glix context use work-vm
+  glix list                          # Modules on work-vm
+  glix list --server local           # Modules on this machine
+  glix context use local`,
+}
+
+var contextListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List contexts",
+	Args:  cobra.NoArgs,
+	RunE:  runContextList,
+}
+
+var contextCurrentCmd = &cobra.Command{
+	Use:   "current",
+	Short: "Show the current context",
+	Args:  cobra.NoArgs,
+	RunE:  runContextCurrent,
+}
+
+var contextUseCmd = &cobra.Command{
+	Use:               "use <name>",
+	Short:             "Make a context the current one",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeContexts,
+	SilenceUsage:      true,
+	RunE:              runContextUse,
+}
+
+var contextAddCmd = &cobra.Command{
+	Use:          "add <name>",
+	Short:        "Add or replace a context",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runContextAdd,
+}
+
+var contextRemoveCmd = &cobra.Command{
+	Use:               "remove <name>",
+	Short:             "Remove a context",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeContexts,
+	SilenceUsage:      true,
+	RunE:              runContextRemove,
+}
+
+var (
+	contextAddress    string
+	contextTLS        bool
+	contextCAFile     string
+	contextServerName string
+	contextTokenFile  string
+)
+
+// tokenEnv names the environment variable holding an API token, which
+// overrides the token file of the context
+const tokenEnv = "GLIX_TOKEN"
+
+func init() {
+	rootCmd.AddCommand(contextCmd)
+
+	contextCmd.AddCommand(contextListCmd)
+	contextCmd.AddCommand(contextCurrentCmd)
+	contextCmd.AddCommand(contextUseCmd)
+	contextCmd.AddCommand(contextAddCmd)
+	contextCmd.AddCommand(contextRemoveCmd)
+
+	// Named --address: --server is the global flag selecting the target
+	contextAddCmd.Flags().StringVar(&contextAddress, "address", "", "Server address (host:port)")
+	contextAddCmd.Flags().BoolVar(&contextTLS, "tls", false, "Connect over TLS")
+	contextAddCmd.Flags().StringVar(&contextCAFile, "ca-file", "", "Verify the server against this PEM CA bundle instead of the system roots (implies --tls)")
+	contextAddCmd.Flags().StringVar(&contextServerName, "server-name", "", "Name to verify the server certificate against (implies --tls)")
+	contextAddCmd.Flags().StringVar(&contextTokenFile, "token-file", "", "File holding the API token for servers that require one (see 'glix token')")
+	_ = contextAddCmd.MarkFlagRequired("address")
+
+	for _, c := range []*cobra.Command{
+		installCmd, updateCmd, removeCmd, rollbackCmd, aliasCmd, pruneCmd,
+		rebuildCmd, monitorCmd, reportBrokenCmd, bundleInstallCmd,
+		snapshotRestoreCmd, importCmd, devCmd, doctorCmd, verifyManifestCmd,
+		whichCmd, suggestCmd, profileListCmd, profileRemoveCmd,
+	} {
+		if c.Annotations == nil {
+			c.Annotations = make(map[string]string)
+		}
+
+		c.Annotations[localOnlyAnnotation] = "true"
+	}
+}
+
+// selectServer points the client at the server named by --server, or else
+// the current context
+func selectServer(cmd *cobra.Command) error {
+	target, name, err := resolveServer(serverFlag)
+	if err != nil {
+		return err
+	}
+
+	if target, err = withToken(target, name); err != nil {
+		return err
+	}
+
+	client.SetTarget(target)
+
+	if target.Remote && cmd.Annotations[localOnlyAnnotation] == "true" {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%s works with binaries on this machine and cannot target %s; use --server %s", cmd.CommandPath(), name, contexts.Local)
+	}
+
+	return nil
+}
+
+// resolveServer returns the target for a --server value, a context name or
+// address, and a name to describe it by
+func resolveServer(value string) (client.Target, string, error) {
+	store := contexts.GetStore()
+
+	if value == "" {
+		c, ok := store.Current()
+		if !ok {
+			return client.LocalTarget(), contexts.Local, nil
+		}
+
+		return contextTarget(c), c.Name, nil
+	}
+
+	if value == contexts.Local {
+		return client.LocalTarget(), contexts.Local, nil
+	}
+
+	if c, ok := store.Get(value); ok {
+		return contextTarget(c), c.Name, nil
+	}
+
+	address, err := normalizeAddress(value)
+	if err != nil {
+		return client.Target{}, "", fmt.Errorf("--server %q is neither a context nor an address: %w", value, err)
+	}
+
+	return client.Target{Address: address, Remote: !isLoopback(address)}, address, nil
+}
+
+func contextTarget(c contexts.Context) client.Target {
+	return client.Target{Address: c.Server, TLS: c.Transport(), Remote: !isLoopback(c.Server)}
+}
+
+// withToken sets the API token of a target reached over TLS or on another
+// machine, from $GLIX_TOKEN or else the token file of its context. Servers
+// trust plaintext clients on their own machine without one.
+func withToken(t client.Target, name string) (client.Target, error) {
+	if !t.Remote && !t.TLS.Enabled {
+		return t, nil
+	}
+
+	if t.Token = os.Getenv(tokenEnv); t.Token != "" {
+		return t, nil
+	}
+
+	c, ok := contexts.GetStore().Get(name)
+	if !ok || c.TokenFile == "" {
+		return t, nil
+	}
+
+	data, err := os.ReadFile(c.TokenFile)
+	if err != nil {
+		return t, fmt.Errorf("failed to read the token file of context %s: %w", name, err)
+	}
+
+	t.Token = strings.TrimSpace(string(data))
+
+	return t, nil
+}
+
+// isLoopback reports whether a host:port address is on this machine, such
+// as a daemon serving TLS reached through a context
+func isLoopback(address string) bool {
+	host, _, _ := net.SplitHostPort(address)
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
+
+// normalizeAddress validates host:port, defaulting the port
+func normalizeAddress(address string) (string, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		// No port
+		host, port = address, strconv.Itoa(server.DefaultPort)
+	}
+
+	if host == "" {
+		return "", fmt.Errorf("missing host")
+	}
+
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return "", fmt.Errorf("invalid port %q", port)
+	}
+
+	return net.JoinHostPort(host, port), nil
+}
+
+func runContextList(cmd *cobra.Command, _ []string) error {
+	store := contexts.GetStore()
+
+	current := contexts.Local
+	if c, ok := store.Current(); ok {
+		current = c.Name
+	}
+
+	t := newTable(
+		column{Header: "CURRENT"},
+		column{Header: "NAME"},
+		column{Header: "SERVER"},
+		column{Header: "TLS", Shrink: true},
+	)
+
+	marker := func(name string) string {
+		if name == current {
+			return "*"
+		}
+
+		return ""
+	}
+
+	t.addRow(marker(contexts.Local), contexts.Local, client.LocalTarget().Address+" (on demand)", "no")
+
+	for _, c := range store.List() {
+		tls := "no"
+
+		switch {
+		case c.CAFile != "":
+			tls = "yes, CA " + c.CAFile
+		case c.TLS:
+			tls = "yes"
+		}
+
+		t.addRow(marker(c.Name), c.Name, c.Server, tls)
+	}
+
+	return t.write(cmd.OutOrStdout())
+}
+
+func runContextCurrent(cmd *cobra.Command, _ []string) error {
+	name := contexts.Local
+	if c, ok := contexts.GetStore().Current(); ok {
+		name = c.Name
+	}
+
+	_, err := fmt.Fprintln(cmd.OutOrStdout(), name)
+
+	return err
+}
+
+func runContextUse(cmd *cobra.Command, args []string) error {
+	if err := contexts.GetStore().Use(args[0]); err != nil {
+		return err
+	}
+
+	cmd.Printf("Switched to context %s\n", args[0])
+
+	return nil
+}
+
+func runContextAdd(cmd *cobra.Command, args []string) error {
+	address, err := normalizeAddress(contextAddress)
+	if err != nil {
+		return fmt.Errorf("invalid address %q: %w", contextAddress, err)
+	}
+
+	caFile := contextCAFile
+	if caFile != "" {
+		// Contexts are used from any directory
+		if caFile, err = filepath.Abs(caFile); err != nil {
+			return fmt.Errorf("failed to resolve CA file: %w", err)
+		}
+	}
+
+	tokenFile := contextTokenFile
+	if tokenFile != "" {
+		if !contextTLS && caFile == "" && contextServerName == "" {
+			return fmt.Errorf("--token-file needs --tls: API tokens are only sent over TLS")
+		}
+
+		if tokenFile, err = filepath.Abs(tokenFile); err != nil {
+			return fmt.Errorf("failed to resolve token file: %w", err)
+		}
+	}
+
+	c := contexts.Context{
+		Name:       args[0],
+		Server:     address,
+		TLS:        contextTLS || caFile != "" || contextServerName != "",
+		CAFile:     caFile,
+		ServerName: contextServerName,
+		TokenFile:  tokenFile,
+	}
+
+	if err := contexts.GetStore().Set(c); err != nil {
+		return err
+	}
+
+	cmd.Printf("Added context %s (%s)\n", c.Name, c.Server)
+
+	if !c.TLS {
+		cmd.Println("Warning: the connection is not encrypted; use --tls unless the network is trusted")
+	}
+
+	return nil
+}
+
+func runContextRemove(cmd *cobra.Command, args []string) error {
+	if err := contexts.GetStore().Remove(args[0]); err != nil {
+		return err
+	}
+
+	cmd.Printf("Removed context %s\n", args[0])
+
+	return nil
+}
+
+// completeContexts completes the first argument with context names
+func completeContexts(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	names := []string{contexts.Local + "\tthis machine"}
+	for _, c := range contexts.GetStore().List() {
+		names = append(names, c.Name+"\t"+c.Server)
+	}
+
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/export"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// dbCmd represents the db parent command
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Work with the glix database",
+}
+
+// dbExportCmd exports a table of the database
+var dbExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export installed modules, dependencies or history as CSV or Parquet",
+	Long: `Export a table of the glix database as CSV or Parquet, so tool usage and
+update latency can be analyzed with any data tool instead of a Bolt reader.
+
+Tables:
+  modules       one row per installed module with its version, binary and
+                install settings
+  dependencies  one row per recorded dependency of an installed module,
+                with its depth in the tree and the module requiring it
+  history       one row per install, update or remove, with the versions
+                involved and whether it succeeded
+
+Every row carries the host name, so exports of a fleet of machines can be
+concatenated. Times are UTC: RFC 3339 in CSV, timestamps in microseconds in
+Parquet. Empty values are null.
+
+Examples:
+  glix db export --table modules > modules.csv
+  glix db export --table history --format parquet -f history.parquet
+  glix --server build-01 db export --table dependencies -f build-01-deps.csv`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runDBExport,
+}
+
+// Tables of db export
+const (
+	tableModules      = "modules"
+	tableDependencies = "dependencies"
+	tableHistory      = "history"
+)
+
+var dbExportTables = []string{tableModules, tableDependencies, tableHistory}
+
+var (
+	dbExportFormat string
+	dbExportTable  string
+	dbExportFile   string
+)
+
+func init() {
+	rootCmd.AddCommand(dbCmd)
+	dbCmd.AddCommand(dbExportCmd)
+
+	dbExportCmd.Flags().StringVar(&dbExportFormat, "format", export.FormatCSV, "Export format: "+strings.Join(export.Formats, " or "))
+	dbExportCmd.Flags().StringVar(&dbExportTable, "table", tableModules, "Table to export: "+strings.Join(dbExportTables, ", "))
+	dbExportCmd.Flags().StringVarP(&dbExportFile, "file", "f", "", "Write to this file instead of stdout")
+
+	_ = dbExportCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(export.Formats, cobra.ShellCompDirectiveNoFileComp))
+	_ = dbExportCmd.RegisterFlagCompletionFunc("table", cobra.FixedCompletions(dbExportTables, cobra.ShellCompDirectiveNoFileComp))
+}
+
+func runDBExport(cmd *cobra.Command, _ []string) error {
+	if !export.ValidFormat(dbExportFormat) {
+		return fmt.Errorf("invalid --format %q: must be %s", dbExportFormat, strings.Join(export.Formats, " or "))
+	}
+
+	if !slices.Contains(dbExportTables, dbExportTable) {
+		return fmt.Errorf("invalid --table %q: must be one of %s", dbExportTable, strings.Join(dbExportTables, ", "))
+	}
+
+	if dbExportFormat == export.FormatParquet && dbExportFile == "" && term.IsTerminal(int(os.Stdout.Fd())) {
+		return fmt.Errorf("parquet is binary; write it to a file with --file or redirect stdout")
+	}
+
+	ctx := cmd.Context()
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	// Rows name the host of the server, which --server may point elsewhere
+	host, _ := os.Hostname()
+	if status, err := grpcClient.GetStatus(ctx); err == nil && status.GetHostname() != "" {
+		host = status.GetHostname()
+	}
+
+	var table *export.Table
+
+	switch dbExportTable {
+	case tableModules:
+		table, err = exportModules(ctx, grpcClient, host)
+	case tableDependencies:
+		table, err = exportDependencies(ctx, grpcClient, host)
+	case tableHistory:
+		table, err = exportHistory(ctx, grpcClient, host)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = cmd.OutOrStdout()
+
+	if dbExportFile != "" {
+		f, err := os.Create(dbExportFile)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", dbExportFile, err)
+		}
+
+		defer func() {
+			_ = f.Close()
+		}()
+
+		w = f
+	}
+
+	if err := export.Write(w, table, dbExportFormat); err != nil {
+		return fmt.Errorf("failed to export %s: %w", dbExportTable, err)
+	}
+
+	if dbExportFile != "" {
+		cmd.Printf("Exported %d %s row(s) to %s\n", len(table.Rows), dbExportTable, dbExportFile)
+	}
+
+	return nil
+}
+
+// nullable returns nil for empty strings, which are exported as null
+func nullable(s string) any {
+	if s == "" {
+		return nil
+	}
+
+	return s
+}
+
+// unixNano converts a timestamp of the database, zero when unset
+func unixNano(ns int64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, ns).UTC()
+}
+
+// exportModules returns the installed modules as a table
+func exportModules(ctx context.Context, grpcClient *client.Client, host string) (*export.Table, error) {
+	resp, err := grpcClient.ListModules(ctx, 0, 0, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	t := export.NewTable(tableModules,
+		export.Column{Name: "host", Kind: export.String},
+		export.Column{Name: "name", Kind: export.String},
+		export.Column{Name: "root_module", Kind: export.String},
+		export.Column{Name: "version", Kind: export.String},
+		export.Column{Name: "previous_version", Kind: export.String},
+		export.Column{Name: "installed_at", Kind: export.Time},
+		export.Column{Name: "binary_name", Kind: export.String},
+		export.Column{Name: "binary_path", Kind: export.String},
+		export.Column{Name: "alias", Kind: export.String},
+		export.Column{Name: "sum", Kind: export.String},
+		export.Column{Name: "go_mod_sum", Kind: export.String},
+		export.Column{Name: "license", Kind: export.String},
+		export.Column{Name: "profile", Kind: export.String},
+		export.Column{Name: "platform", Kind: export.String},
+		export.Column{Name: "version_constraint", Kind: export.String},
+		export.Column{Name: "local_path", Kind: export.String},
+		export.Column{Name: "build_strategy", Kind: export.String},
+		export.Column{Name: "go_version", Kind: export.String},
+		export.Column{Name: "vcs_revision", Kind: export.String},
+		export.Column{Name: "vcs_modified", Kind: export.Bool},
+		export.Column{Name: "binary_size", Kind: export.Int},
+		export.Column{Name: "prefer_binary", Kind: export.Bool},
+		export.Column{Name: "prerelease", Kind: export.Bool},
+		export.Column{Name: "goproxy", Kind: export.String},
+		export.Column{Name: "private", Kind: export.Bool},
+		export.Column{Name: "bad_versions", Kind: export.String},
+		export.Column{Name: "available_versions", Kind: export.Int},
+	)
+
+	for _, mod := range resp.GetModules() {
+		// Records from before provenance was recorded have no build info
+		var vcsModified, binarySize any
+		if p := mod.GetProvenance(); p != nil {
+			vcsModified, binarySize = p.GetVcsModified(), p.GetBinarySize()
+		}
+
+		t.Add(
+			host,
+			mod.GetName(),
+			nullable(mod.GetRootModule()),
+			mod.GetVersion(),
+			nullable(mod.GetPreviousVersion()),
+			unixNano(mod.GetTimestampUnixNano()),
+			nullable(mod.GetBinaryName()),
+			nullable(mod.GetBinaryPath()),
+			nullable(mod.GetAlias()),
+			nullable(mod.GetSum()),
+			nullable(mod.GetGoModSum()),
+			nullable(mod.GetLicense()),
+			nullable(mod.GetProfile()),
+			nullable(mod.GetPlatform()),
+			nullable(mod.GetVersionConstraint()),
+			nullable(mod.GetLocalPath()),
+			nullable(mod.GetBuildStrategy()),
+			nullable(mod.GetProvenance().GetGoVersion()),
+			nullable(mod.GetProvenance().GetVcsRevision()),
+			vcsModified,
+			binarySize,
+			mod.GetPreferBinary(),
+			mod.GetPrerelease(),
+			nullable(mod.GetGoproxy()),
+			mod.GetPrivate(),
+			nullable(strings.Join(mod.GetBadVersions(), " ")),
+			int64(len(mod.GetVersions())),
+		)
+	}
+
+	return t, nil
+}
+
+// exportDependencies returns the recorded dependencies of the installed
+// modules as a table, one row per dependency in the tree
+func exportDependencies(ctx context.Context, grpcClient *client.Client, host string) (*export.Table, error) {
+	resp, err := grpcClient.ListModules(ctx, 0, 0, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	t := export.NewTable(tableDependencies,
+		export.Column{Name: "host", Kind: export.String},
+		export.Column{Name: "module", Kind: export.String},
+		export.Column{Name: "module_version", Kind: export.String},
+		export.Column{Name: "dependency", Kind: export.String},
+		export.Column{Name: "version", Kind: export.String},
+		export.Column{Name: "license", Kind: export.String},
+		export.Column{Name: "depth", Kind: export.Int},
+		export.Column{Name: "parent", Kind: export.String},
+	)
+
+	for _, mod := range resp.GetModules() {
+		deps, err := grpcClient.GetDependencies(ctx, mod.GetName(), "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dependencies of %s: %w", mod.GetName(), err)
+		}
+
+		var walk func(deps []*pb.DependencyProto, depth int64, parent string)
+
+		walk = func(deps []*pb.DependencyProto, depth int64, parent string) {
+			for _, dep := range deps {
+				t.Add(host, mod.GetName(), mod.GetVersion(), dep.GetName(), dep.GetVersion(),
+					nullable(dep.GetLicense()), depth, parent)
+				walk(dep.GetDependencies(), depth+1, dep.GetName())
+			}
+		}
+
+		walk(deps.GetDependencies().GetDependencies(), 1, mod.GetName())
+	}
+
+	return t, nil
+}
+
+// exportHistory returns every recorded install, update and remove as a table
+func exportHistory(ctx context.Context, grpcClient *client.Client, host string) (*export.Table, error) {
+	events, err := grpcClient.GetHistory(ctx, "", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	t := export.NewTable(tableHistory,
+		export.Column{Name: "host", Kind: export.String},
+		export.Column{Name: "time", Kind: export.Time},
+		export.Column{Name: "action", Kind: export.String},
+		export.Column{Name: "module", Kind: export.String},
+		export.Column{Name: "from_version", Kind: export.String},
+		export.Column{Name: "to_version", Kind: export.String},
+		export.Column{Name: "success", Kind: export.Bool},
+		export.Column{Name: "error", Kind: export.String},
+	)
+
+	for _, e := range events {
+		t.Add(host, unixNano(e.GetTimestampUnixNano()), eventActionName(e.GetAction()), e.GetName(),
+			nullable(e.GetFromVersion()), nullable(e.GetToVersion()), e.GetSuccess(), nullable(e.GetErrorMessage()))
+	}
+
+	return t, nil
+}
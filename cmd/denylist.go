@@ -0,0 +1,334 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/inovacc/glix/internal/denylist"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/modver"
+	"github.com/inovacc/glix/internal/signing"
+	"github.com/spf13/cobra"
+)
+
+// denylistCmd represents the denylist parent command
+var denylistCmd = &cobra.Command{
+	Use:   "denylist",
+	Short: "Manage module versions that must not be installed",
+	Long: `The denylist names module versions that must never be installed, such as
+a release with a critical regression. Entries come from a local list and
+from shared catalogs: JSON documents served over http(s) or read from a
+file, so a team can publish one list for every machine.
+
+When the latest version of a module is denied, install, update and
+auto-update resolve to the newest allowed version instead. monitor flags
+installed versions that are denied so they can be downgraded.
+
+Catalog format:
+  {"denied": [{"module": "github.com/example/tool", "version": "v1.4.0",
+               "reason": "corrupts config files"}]}
+
+Catalogs are synced by 'glix denylist sync', by the daemon's catalog-refresh
+task and auto-update checks, and by install, update and monitor once the
+last sync is a day old.
+
+A catalog added with --key is only accepted with a valid detached signature
+from that pinned minisign or cosign public key, so a compromised catalog
+host cannot push entries to every subscribed machine. The signature is read
+from the catalog URL plus .minisig (minisign) or .sig (cosign) unless
+--signature-url is given. A catalog that fails verification keeps its
+previously synced entries.
+
+Examples:
+  glix denylist add github.com/example/tool@v1.4.0 --reason "corrupts config"
+  glix denylist catalog add https://example.com/glix/denylist.json
+  glix denylist catalog add https://example.com/glix/denylist.json --key team.pub
+  glix denylist sync
+  glix denylist list
+  glix denylist remove github.com/example/tool@v1.4.0`,
+}
+
+// denylistAddCmd denies a version locally
+var denylistAddCmd = &cobra.Command{
+	Use:   "add <module@version>",
+	Short: "Deny a module version on this machine",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDenylistAdd,
+}
+
+// denylistRemoveCmd removes a local entry
+var denylistRemoveCmd = &cobra.Command{
+	Use:   "remove <module@version>",
+	Short: "Remove a version from the local denylist",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDenylistRemove,
+}
+
+// denylistListCmd lists local and catalog entries
+var denylistListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List denied versions and configured catalogs",
+	RunE:  runDenylistList,
+}
+
+// denylistSyncCmd fetches the configured catalogs
+var denylistSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Fetch denied versions from the configured catalogs",
+	RunE:  runDenylistSync,
+}
+
+// denylistCatalogCmd groups catalog subscription commands
+var denylistCatalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "Manage shared denylist catalogs",
+}
+
+// denylistCatalogAddCmd subscribes to a catalog
+var denylistCatalogAddCmd = &cobra.Command{
+	Use:   "add <url|file>",
+	Short: "Subscribe to a denylist catalog and sync it",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDenylistCatalogAdd,
+}
+
+// denylistCatalogRemoveCmd unsubscribes from a catalog
+var denylistCatalogRemoveCmd = &cobra.Command{
+	Use:   "remove <url|file>",
+	Short: "Unsubscribe from a denylist catalog",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDenylistCatalogRemove,
+}
+
+var (
+	denylistReason      string
+	catalogKey          string
+	catalogSignatureURL string
+)
+
+func init() {
+	rootCmd.AddCommand(denylistCmd)
+
+	denylistCmd.AddCommand(denylistAddCmd)
+	denylistCmd.AddCommand(denylistRemoveCmd)
+	denylistCmd.AddCommand(denylistListCmd)
+	denylistCmd.AddCommand(denylistSyncCmd)
+	denylistCmd.AddCommand(denylistCatalogCmd)
+
+	denylistCatalogCmd.AddCommand(denylistCatalogAddCmd)
+	denylistCatalogCmd.AddCommand(denylistCatalogRemoveCmd)
+
+	denylistAddCmd.Flags().StringVar(&denylistReason, "reason", "", "Why the version is denied")
+
+	denylistCatalogAddCmd.Flags().StringVar(&catalogKey, "key", "", "Pinned minisign or cosign public key (file or inline) the catalog must be signed with")
+	denylistCatalogAddCmd.Flags().StringVar(&catalogSignatureURL, "signature-url", "", "Where to fetch the detached signature (default: catalog URL plus .minisig or .sig)")
+}
+
+// parseDeniedVersion splits module@version, requiring an exact version
+func parseDeniedVersion(arg string) (string, string, error) {
+	modulePath, version := parseModulePath(arg)
+	if version == "" || version == "latest" {
+		return "", "", fmt.Errorf("specify an exact version as <module>@<version>")
+	}
+
+	return modulePath, version, nil
+}
+
+func runDenylistAdd(cmd *cobra.Command, args []string) error {
+	modulePath, version, err := parseDeniedVersion(args[0])
+	if err != nil {
+		return err
+	}
+
+	entry := denylist.Entry{Module: modulePath, Version: version, Reason: denylistReason}
+	if err := denylist.GetStore().Add(entry); err != nil {
+		return err
+	}
+
+	cmd.Printf("Denied %s\n", entry)
+
+	return nil
+}
+
+func runDenylistRemove(cmd *cobra.Command, args []string) error {
+	modulePath, version, err := parseDeniedVersion(args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := denylist.GetStore().Remove(modulePath, version); err != nil {
+		return err
+	}
+
+	cmd.Printf("Removed %s@%s from the denylist\n", modulePath, version)
+
+	return nil
+}
+
+func runDenylistList(cmd *cobra.Command, _ []string) error {
+	s := denylist.GetStore()
+	entries := s.List()
+
+	if len(entries) == 0 {
+		cmd.Println("No versions are denied")
+	} else {
+		cmd.Println()
+		cmd.Printf("Denied versions (%d):\n", len(entries))
+		cmd.Println()
+
+		for _, e := range entries {
+			cmd.Printf("  %s (%s)\n", e, e.Source)
+
+			if e.Reason != "" {
+				cmd.Printf("    Reason: %s\n", e.Reason)
+			}
+		}
+	}
+
+	if catalogs := s.Catalogs(); len(catalogs) > 0 {
+		cmd.Println()
+		cmd.Println("Catalogs:")
+
+		for _, c := range catalogs {
+			if key, err := signing.ParseKey(c.PublicKey); c.Signed() && err == nil {
+				cmd.Printf("  %s (signed: %s)\n", c.URL, key.Scheme())
+			} else {
+				cmd.Printf("  %s\n", c.URL)
+			}
+		}
+
+		if synced := s.LastSync(); !synced.IsZero() {
+			cmd.Printf("Last synced: %s\n", synced.Format("2006-01-02 15:04"))
+		}
+	}
+
+	cmd.Println()
+
+	return nil
+}
+
+func runDenylistSync(cmd *cobra.Command, _ []string) error {
+	s := denylist.GetStore()
+	if len(s.Catalogs()) == 0 {
+		cmd.Println("No catalogs configured")
+		return nil
+	}
+
+	err := s.Sync(cmd.Context())
+
+	cmd.Printf("Synced %d catalog(s), %d denied version(s) in total\n", len(s.Catalogs()), len(s.List()))
+
+	return err
+}
+
+func runDenylistCatalogAdd(cmd *cobra.Command, args []string) error {
+	s := denylist.GetStore()
+
+	source := denylist.CatalogSource{URL: args[0], SignatureURL: catalogSignatureURL}
+
+	if catalogKey != "" {
+		key, err := readPublicKey(catalogKey)
+		if err != nil {
+			return err
+		}
+
+		source.PublicKey = key
+	} else if catalogSignatureURL != "" {
+		return fmt.Errorf("--signature-url requires --key")
+	}
+
+	if err := s.AddCatalog(source); err != nil {
+		return err
+	}
+
+	cmd.Printf("Added catalog %s\n", args[0])
+
+	return s.Sync(cmd.Context())
+}
+
+func runDenylistCatalogRemove(cmd *cobra.Command, args []string) error {
+	if err := denylist.GetStore().RemoveCatalog(args[0]); err != nil {
+		return err
+	}
+
+	cmd.Printf("Removed catalog %s\n", args[0])
+
+	return nil
+}
+
+// readPublicKey returns the contents of a key file, or the value itself when
+// it is not a file (an inline minisign key)
+func readPublicKey(value string) (string, error) {
+	data, err := os.ReadFile(value)
+	if err == nil {
+		return string(data), nil
+	}
+
+	if !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read public key: %w", err)
+	}
+
+	return value, nil
+}
+
+// syncDenylist refreshes stale catalogs; failures keep the cached entries
+func syncDenylist(ctx context.Context, progressHandler func(phase, message string)) {
+	if err := denylist.GetStore().SyncIfStale(ctx); err != nil {
+		progressHandler("warning", fmt.Sprintf("failed to sync denylist catalogs: %v", err))
+	}
+}
+
+// excludedReason explains why a version must not be installed, or returns ""
+// when it is allowed. badVersions are the versions reported broken locally.
+func excludedReason(moduleName, version string, badVersions []string) string {
+	if slices.Contains(badVersions, version) {
+		return "reported broken"
+	}
+
+	if e, ok := denylist.GetStore().Denied(moduleName, version); ok {
+		if e.Reason != "" {
+			return fmt.Sprintf("denied: %s", e.Reason)
+		}
+
+		return "denied"
+	}
+
+	return ""
+}
+
+// resolveAllowed re-resolves m when its resolved version is excluded, picking
+// the newest allowed version newer than floor (any version when floor is "").
+// It returns false when no allowed version exists.
+func resolveAllowed(m *module.Module, floor string, badVersions []string, progressHandler func(phase, message string)) (bool, error) {
+	reason := excludedReason(m.Name, m.Version, badVersions)
+	if reason == "" {
+		return true, nil
+	}
+
+	// A fresh install falls back to versions of the kind it resolved to
+	current := floor
+	if current == "" {
+		current = m.Version
+	}
+
+	policy := m.UpdatePolicy()
+
+	alt := modver.Newest(m.Versions, func(v string) bool {
+		return (floor == "" || modver.Newer(v, floor)) && policy.Allows(v, current) && m.Allows(v) && excludedReason(m.Name, v, badVersions) == ""
+	})
+
+	if alt == "" {
+		progressHandler("resolve", fmt.Sprintf("%s@%s is %s and no allowed version is available", m.Name, m.Version, reason))
+		return false, nil
+	}
+
+	progressHandler("resolve", fmt.Sprintf("%s@%s is %s; using %s", m.Name, m.Version, reason, alt))
+
+	if err := m.FetchModuleInfo(fmt.Sprintf("%s@%s", m.Name, alt)); err != nil {
+		return false, fmt.Errorf("failed to fetch module info: %w", err)
+	}
+
+	return true, nil
+}
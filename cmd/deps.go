@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/inovacc/glix/internal/client"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// depsCmd represents the deps command
+var depsCmd = &cobra.Command{
+	Use:   "deps <module>",
+	Short: "Show the dependency tree of an installed module",
+	Long: `Print the dependencies recorded when a module was installed as a tree,
+with the version of each dependency. Output goes to stdout.
+
+--depth limits how many levels of nested dependencies are shown. --ascii
+draws the tree with ASCII characters for terminals without Unicode, and
+--json prints the tree as JSON.
+
+Examples:
+  glix deps github.com/spf13/cobra
+  glix deps github.com/spf13/cobra --depth 1
+  glix deps github.com/spf13/cobra --json | jq '.dependencies[].name'`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeInstalledModules,
+	SilenceUsage:      true,
+	RunE:              runDeps,
+}
+
+var (
+	depsDepth int
+	depsJSON  bool
+	depsASCII bool
+)
+
+func init() {
+	rootCmd.AddCommand(depsCmd)
+
+	depsCmd.Flags().IntVar(&depsDepth, "depth", 0, "Levels of dependencies to show (0 shows all)")
+	depsCmd.Flags().BoolVar(&depsJSON, "json", false, "Print the tree as JSON")
+	depsCmd.Flags().BoolVar(&depsASCII, "ascii", false, "Draw the tree with ASCII characters")
+	depsCmd.MarkFlagsMutuallyExclusive("json", "ascii")
+}
+
+// treeBranches are the line prefixes a tree is drawn with
+type treeBranches struct {
+	item, last, pipe, space string
+}
+
+var (
+	unicodeBranches = treeBranches{item: "├── ", last: "└── ", pipe: "│   ", space: "    "}
+	asciiBranches   = treeBranches{item: "|-- ", last: "`-- ", pipe: "|   ", space: "    "}
+)
+
+func runDeps(cmd *cobra.Command, args []string) error {
+	if depsDepth < 0 {
+		return fmt.Errorf("--depth must not be negative")
+	}
+
+	if depsJSON {
+		outputFormat = outputJSON
+	}
+
+	ctx := cmd.Context()
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	modResp, err := grpcClient.GetModule(ctx, args[0], "")
+	if err != nil {
+		return fmt.Errorf("failed to get module: %w", err)
+	}
+
+	if !modResp.GetFound() {
+		return fmt.Errorf("module %s is not installed", args[0])
+	}
+
+	mod := modResp.GetModule()
+
+	// Dependencies are recorded for the installed version only
+	depsResp, err := grpcClient.GetDependencies(ctx, mod.GetName(), "")
+	if err != nil {
+		return fmt.Errorf("failed to get dependencies: %w", err)
+	}
+
+	root := &pb.DependencyProto{
+		Name:         mod.GetName(),
+		Version:      mod.GetVersion(),
+		Hash:         mod.GetHash(),
+		License:      mod.GetLicense(),
+		Dependencies: pruneDependencies(depsResp.GetDependencies().GetDependencies(), depsDepth),
+	}
+
+	if structuredOutput() {
+		return printMessage(cmd, root)
+	}
+
+	branches := unicodeBranches
+	if depsASCII {
+		branches = asciiBranches
+	}
+
+	w := cmd.OutOrStdout()
+
+	_, _ = fmt.Fprintln(w, dependencyLabel(root))
+	writeDependencyTree(w, root.GetDependencies(), "", branches)
+
+	if len(root.GetDependencies()) == 0 {
+		cmd.Printf("No dependencies recorded for %s\n", mod.GetName())
+	}
+
+	return nil
+}
+
+// pruneDependencies copies deps down to depth levels, or all of them when
+// depth is 0
+func pruneDependencies(deps []*pb.DependencyProto, depth int) []*pb.DependencyProto {
+	if len(deps) == 0 {
+		return nil
+	}
+
+	pruned := make([]*pb.DependencyProto, 0, len(deps))
+	for _, dep := range deps {
+		var nested []*pb.DependencyProto
+		if depth != 1 {
+			nested = pruneDependencies(dep.GetDependencies(), max(depth-1, 0))
+		}
+
+		pruned = append(pruned, &pb.DependencyProto{
+			Name:         dep.GetName(),
+			Version:      dep.GetVersion(),
+			Versions:     dep.GetVersions(),
+			Hash:         dep.GetHash(),
+			Dependencies: nested,
+			License:      dep.GetLicense(),
+		})
+	}
+
+	return pruned
+}
+
+// writeDependencyTree draws deps under a node whose lines start with prefix
+func writeDependencyTree(w io.Writer, deps []*pb.DependencyProto, prefix string, b treeBranches) {
+	for i, dep := range deps {
+		branch, indent := b.item, b.pipe
+		if i == len(deps)-1 {
+			branch, indent = b.last, b.space
+		}
+
+		_, _ = fmt.Fprintf(w, "%s%s%s\n", prefix, branch, dependencyLabel(dep))
+		writeDependencyTree(w, dep.GetDependencies(), prefix+indent, b)
+	}
+}
+
+// dependencyLabel names a dependency with its version
+func dependencyLabel(dep *pb.DependencyProto) string {
+	return strings.TrimSpace(dep.GetName() + " " + dep.GetVersion())
+}
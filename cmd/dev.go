@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/tui"
+	"github.com/inovacc/glix/internal/watch"
+	"github.com/spf13/cobra"
+)
+
+// devCmd represents the dev command
+var devCmd = &cobra.Command{
+	Use:   "dev <path>",
+	Short: "Install a CLI from a local directory, optionally rebuilding on change",
+	Long: `Build and install a CLI from a local working copy, like
+'glix install ./path'. With --watch, glix keeps running and rebuilds and
+reinstalls the binary whenever Go sources, go.mod/go.sum, or embedded
+assets change — a live-reload installer for CLI development.
+
+Examples:
+  glix dev ./mycli
+  glix dev --watch ./mycli
+  glix dev --watch --debounce 2s ./mycli`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDev,
+}
+
+var (
+	devWatch    bool
+	devDebounce = watch.DefaultDebounce
+)
+
+func init() {
+	rootCmd.AddCommand(devCmd)
+
+	devCmd.Flags().BoolVarP(&devWatch, "watch", "w", false, "Rebuild and reinstall whenever sources change")
+	devCmd.Flags().DurationVar(&devDebounce, "debounce", watch.DefaultDebounce, "Quiet period after the last change before rebuilding")
+}
+
+func runDev(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	dir, err := module.ResolveLocalPath(args[0])
+	if err != nil {
+		return err
+	}
+
+	if !devWatch {
+		if IsTUIEnabled() {
+			return runInstallWithTUI(ctx, cmd, dir, "")
+		}
+
+		return runInstallPlainText(ctx, cmd, dir, "")
+	}
+
+	if IsTUIEnabled() {
+		return runDevWatchWithTUI(ctx, cmd, dir)
+	}
+
+	progressHandler := func(phase, message string) {
+		cmd.Printf("[%s] %s\n", phase, message)
+	}
+
+	outputHandler := func(stream, line string) {
+		if stream == "stderr" {
+			_, _ = fmt.Fprintln(cmd.ErrOrStderr(), line)
+		} else {
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), line)
+		}
+	}
+
+	statusHandler := func(text string) {
+		cmd.Printf("Status: %s\n", text)
+	}
+
+	return doDevWatch(ctx, cmd, dir, progressHandler, outputHandler, statusHandler)
+}
+
+func runDevWatchWithTUI(ctx context.Context, cmd *cobra.Command, dir string) error {
+	t := tui.New()
+
+	// Quitting the TUI cancels the watch loop
+	tuiCtx, tuiCancel := context.WithCancel(ctx)
+	defer tuiCancel()
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- doDevWatch(tuiCtx, cmd, dir, t.ProgressHandler(), t.OutputHandler(), t.SetStatus)
+	}()
+
+	go func() {
+		err := <-errCh
+		t.Done(err)
+	}()
+
+	if err := t.Start(tuiCtx); err != nil {
+		return fmt.Errorf("TUI error: %w", err)
+	}
+
+	return nil
+}
+
+// doDevWatch performs an initial install and then reinstalls on every change
+// until ctx is cancelled. Build failures are reported but do not stop watching.
+func doDevWatch(
+	ctx context.Context,
+	cmd *cobra.Command,
+	dir string,
+	progressHandler func(phase, message string),
+	outputHandler func(stream, line string),
+	statusHandler func(text string),
+) error {
+	build := func() {
+		if _, err := doInstall(ctx, cmd, dir, "", progressHandler, outputHandler, statusHandler); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			progressHandler("error", err.Error())
+			statusHandler("Build failed, waiting for changes...")
+
+			return
+		}
+
+		statusHandler(fmt.Sprintf("Watching %s for changes...", dir))
+	}
+
+	build()
+
+	progressHandler("watch", fmt.Sprintf("Watching %s (press q or Ctrl+C to stop)", dir))
+
+	return watch.Run(ctx, watch.Config{
+		Dir:      dir,
+		Debounce: devDebounce,
+		OnChange: func(paths []string) {
+			progressHandler("change", describeChanges(dir, paths))
+			build()
+		},
+		OnError: func(err error) {
+			progressHandler("warning", fmt.Sprintf("watcher: %v", err))
+		},
+	})
+}
+
+// describeChanges summarizes changed paths relative to the watched directory
+func describeChanges(dir string, paths []string) string {
+	const maxShown = 3
+
+	rel := make([]string, 0, min(len(paths), maxShown))
+
+	for _, p := range paths[:min(len(paths), maxShown)] {
+		if r, err := filepath.Rel(dir, p); err == nil {
+			p = r
+		}
+
+		rel = append(rel, p)
+	}
+
+	summary := strings.Join(rel, ", ")
+	if len(paths) > maxShown {
+		summary += fmt.Sprintf(" and %d more", len(paths)-maxShown)
+	}
+
+	return fmt.Sprintf("Changed: %s, rebuilding...", summary)
+}
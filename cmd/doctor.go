@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/spf13/cobra"
+)
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose problems with the glix installation",
+	Long: `Run a set of checks against the local environment and installed modules
+and report anything that keeps glix-managed binaries from working as
+expected.
+
+Checks:
+  gobin    GOBIN is listed in PATH
+  shadow   Installed binaries that share their name with executables from
+           brew, apt, scoop or other sources, with the PATH resolution order
+  orphans  go and goreleaser builds left running by a glix process that
+           died, holding file locks; they are killed with their children
+
+Examples:
+  glix doctor`,
+	RunE: runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// doctorCheck is a single diagnostic; it returns the number of problems found
+type doctorCheck struct {
+	Name string
+	Run  func(ctx context.Context, cmd *cobra.Command, grpcClient *client.Client) (int, error)
+}
+
+var doctorChecks = []doctorCheck{
+	{Name: "gobin", Run: checkGoBinOnPath},
+	{Name: "shadow", Run: checkShadowedBinaries},
+	{Name: "orphans", Run: checkOrphanedProcesses},
+}
+
+func runDoctor(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	cfg := client.DefaultDiscoveryConfig()
+
+	grpcClient, err := client.GetClient(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	var problems int
+
+	for _, check := range doctorChecks {
+		n, err := check.Run(ctx, cmd, grpcClient)
+		if err != nil {
+			cmd.Printf("[%s] check failed: %v\n", check.Name, err)
+
+			problems++
+
+			continue
+		}
+
+		problems += n
+	}
+
+	cmd.Println()
+
+	if problems > 0 {
+		return fmt.Errorf("doctor found %d problem(s)", problems)
+	}
+
+	cmd.Println("No problems found")
+
+	return nil
+}
+
+func checkGoBinOnPath(_ context.Context, cmd *cobra.Command, _ *client.Client) (int, error) {
+	gobin := module.GetGoBinDirectory()
+
+	if module.IsOnPath(gobin) {
+		cmd.Printf("[gobin] %s is on PATH\n", gobin)
+		return 0, nil
+	}
+
+	cmd.Printf("[gobin] %s is not on PATH; installed binaries cannot be run by name (fix with 'glix path add')\n", gobin)
+
+	return 1, nil
+}
+
+func checkShadowedBinaries(ctx context.Context, cmd *cobra.Command, grpcClient *client.Client) (int, error) {
+	resp, err := grpcClient.ListModules(ctx, 0, 0, "")
+	if err != nil {
+		return 0, fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	// Only binaries that lose to another executable count as problems
+	var conflicts, shadowed int
+
+	for _, mod := range resp.GetModules() {
+		conflict := module.FindShadowConflict(module.ManagedBinaryName(mod.GetName(), mod.GetKubectlPlugin(), mod.GetAlias()))
+		if conflict == nil {
+			continue
+		}
+
+		conflicts++
+
+		state := "shadows other binaries"
+		if conflict.Shadowed() {
+			state = "is SHADOWED"
+			shadowed++
+		}
+
+		cmd.Printf("[shadow] %s (%s) %s; resolution order:\n", conflict.Binary, mod.GetName(), state)
+
+		for i, b := range conflict.Order {
+			cmd.Printf("    %d. %s (%s)\n", i+1, b.Path, b.Source)
+		}
+	}
+
+	if conflicts == 0 {
+		cmd.Printf("[shadow] no PATH conflicts among %d installed module(s)\n", len(resp.GetModules()))
+	}
+
+	return shadowed, nil
+}
+
+func checkOrphanedProcesses(_ context.Context, cmd *cobra.Command, _ *client.Client) (int, error) {
+	orphans, err := module.FindOrphans()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(orphans) == 0 {
+		cmd.Println("[orphans] no builds left running by earlier glix runs")
+		return 0, nil
+	}
+
+	// Reaped orphans are fixed; only those that survive count as problems
+	var problems int
+
+	for _, rec := range orphans {
+		if err := module.ReapOrphan(rec); err != nil {
+			cmd.Printf("[orphans] %s (pid %d, started %s): %v\n", rec.Command, rec.PID, rec.Started.Local().Format(time.DateTime), err)
+
+			problems++
+
+			continue
+		}
+
+		cmd.Printf("[orphans] killed %s (pid %d, started %s) left running by glix pid %d\n",
+			rec.Command, rec.PID, rec.Started.Local().Format(time.DateTime), rec.Owner)
+	}
+
+	return problems, nil
+}
@@ -0,0 +1,389 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/fleet"
+	"github.com/inovacc/glix/internal/humanize"
+	"github.com/inovacc/glix/internal/modver"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// fleetCmd represents the fleet parent command
+var fleetCmd = &cobra.Command{
+	Use:   "fleet",
+	Short: "Report inventories to a central glix server and query them",
+	Long: `Fleet mode aggregates the module inventories of many workstations on one
+central glix server.
+
+On each workstation, set the central server with 'glix fleet config
+--report-to <host:port>'. Central servers that serve TLS or require API
+tokens are reached with --tls, --ca-file and --token-file. The workstation daemon then pushes its installed
+modules at the configured interval (default 1h) while it runs, so reporting
+works best with the daemon installed as a service ('glix service install').
+
+The central server is a regular glix daemon bound to a reachable address,
+e.g. 'glix service run --bind 0.0.0.0'. Run 'glix fleet list' there, or
+anywhere with --server, to see which machines run which versions.
+
+Examples:
+  glix fleet config --report-to fleet.example.com:9742
+  glix fleet push
+  glix fleet list --module golangci-lint
+  glix fleet list --server fleet.example.com:9742 --host build-01`,
+}
+
+// fleetStatusCmd shows fleet reporting configuration
+var fleetStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show fleet reporting configuration",
+	RunE:  runFleetStatus,
+}
+
+// fleetConfigCmd configures fleet reporting
+var fleetConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Configure reporting to a central server",
+	Long: `Configure where and how often this machine reports its inventory.
+
+Examples:
+  glix fleet config --report-to fleet.example.com:9742
+  glix fleet config --interval 30m --host alice-laptop
+  glix fleet config --tls --ca-file ca.pem --token-file fleet.token
+  glix fleet config --no-report                  # Stop reporting`,
+	RunE: runFleetConfig,
+}
+
+// fleetPushCmd reports the inventory immediately
+var fleetPushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Report this machine's inventory now",
+	RunE:  runFleetPush,
+}
+
+// fleetListCmd lists reported inventories
+var fleetListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show which machines run which module versions",
+	Long: `List the inventories reported to a central server. Without --server the
+server of the current context is queried, which is the central server when
+run there.
+
+Examples:
+  glix fleet list
+  glix fleet list --module golangci-lint
+  glix fleet list --server fleet.example.com:9742 --host build-01`,
+	RunE: runFleetList,
+}
+
+var (
+	fleetReportTo string
+	fleetNoReport bool
+	fleetInterval string
+	fleetHost     string
+
+	fleetTLS        bool
+	fleetCAFile     string
+	fleetServerName string
+	fleetTokenFile  string
+
+	fleetListModule string
+	fleetListHost   string
+)
+
+func init() {
+	rootCmd.AddCommand(fleetCmd)
+
+	fleetCmd.AddCommand(fleetStatusCmd)
+	fleetCmd.AddCommand(fleetConfigCmd)
+	fleetCmd.AddCommand(fleetPushCmd)
+	fleetCmd.AddCommand(fleetListCmd)
+
+	fleetConfigCmd.Flags().StringVar(&fleetReportTo, "report-to", "", "Central server address (host:port) to report to")
+	fleetConfigCmd.Flags().BoolVar(&fleetNoReport, "no-report", false, "Stop reporting to the central server")
+	fleetConfigCmd.Flags().StringVar(&fleetInterval, "interval", "", "Report interval (e.g., 1h, 30m)")
+	fleetConfigCmd.Flags().StringVar(&fleetHost, "host", "", "Name to report this machine as (default: hostname)")
+	fleetConfigCmd.Flags().BoolVar(&fleetTLS, "tls", false, "Connect to the central server over TLS")
+	fleetConfigCmd.Flags().StringVar(&fleetCAFile, "ca-file", "", "PEM CA bundle to verify the central server with (implies --tls)")
+	fleetConfigCmd.Flags().StringVar(&fleetServerName, "server-name", "", "Name to verify the server certificate against (implies --tls)")
+	fleetConfigCmd.Flags().StringVar(&fleetTokenFile, "token-file", "", "File holding the API token for the central server (needs --tls)")
+
+	fleetListCmd.Flags().StringVarP(&fleetListModule, "module", "m", "", "Only show modules whose path contains this")
+	fleetListCmd.Flags().StringVar(&fleetListHost, "host", "", "Only show this host")
+}
+
+func runFleetStatus(cmd *cobra.Command, _ []string) error {
+	cfg := fleet.GetStore().Get()
+
+	cmd.Println("Fleet Reporting")
+	cmd.Println("===============")
+	cmd.Println()
+
+	if cfg.ReportTo == "" {
+		cmd.Println("Report to:     (disabled)")
+	} else {
+		cmd.Printf("Report to:     %s\n", cfg.ReportTo)
+	}
+
+	cmd.Printf("Host:          %s\n", cfg.HostName())
+
+	if cfg.TLS {
+		cmd.Println("Transport:     TLS")
+	} else {
+		cmd.Println("Transport:     plaintext")
+	}
+
+	if cfg.CAFile != "" {
+		cmd.Printf("CA file:       %s\n", cfg.CAFile)
+	}
+
+	if cfg.TokenFile != "" {
+		cmd.Printf("Token file:    %s\n", cfg.TokenFile)
+	}
+
+	cmd.Printf("Interval:      %s\n", humanize.Duration(cfg.Interval))
+
+	if cfg.LastReport.IsZero() {
+		cmd.Println("Last report:   Never")
+	} else {
+		cmd.Printf("Last report:   %s (%s)\n",
+			cfg.LastReport.Format(time.RFC3339),
+			humanize.Since(cfg.LastReport))
+	}
+
+	if cfg.LastError != "" {
+		cmd.Printf("Last error:    %s\n", cfg.LastError)
+	}
+
+	return nil
+}
+
+func runFleetConfig(cmd *cobra.Command, _ []string) error {
+	store := fleet.GetStore()
+	changed := false
+
+	if fleetReportTo != "" && fleetNoReport {
+		return fmt.Errorf("--report-to and --no-report are mutually exclusive")
+	}
+
+	if fleetReportTo != "" || fleetNoReport {
+		if err := store.SetReportTo(fleetReportTo); err != nil {
+			return err
+		}
+
+		if fleetNoReport {
+			cmd.Println("Fleet reporting disabled")
+		} else {
+			cmd.Printf("Reporting to: %s\n", fleetReportTo)
+		}
+
+		changed = true
+	}
+
+	if fleetInterval != "" {
+		interval, err := time.ParseDuration(fleetInterval)
+		if err != nil {
+			return fmt.Errorf("invalid interval format: %w", err)
+		}
+
+		if err := store.SetInterval(interval); err != nil {
+			return err
+		}
+
+		cmd.Printf("Interval set to: %s\n", humanize.Duration(interval))
+
+		changed = true
+	}
+
+	if fleetHost != "" {
+		if err := store.SetHost(fleetHost); err != nil {
+			return err
+		}
+
+		cmd.Printf("Reporting as: %s\n", fleetHost)
+
+		changed = true
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("tls") || flags.Changed("ca-file") || flags.Changed("server-name") || flags.Changed("token-file") {
+		if err := setFleetTransport(cmd); err != nil {
+			return err
+		}
+
+		cmd.Println("Fleet server connection updated")
+
+		changed = true
+	}
+
+	if !changed {
+		return runFleetStatus(cmd, nil)
+	}
+
+	return nil
+}
+
+// setFleetTransport stores how the central server is reached, keeping the
+// settings whose flags were not given. Files are stored as absolute paths
+// since the daemon runs from another directory.
+func setFleetTransport(cmd *cobra.Command) error {
+	store := fleet.GetStore()
+	cfg := store.Get()
+	flags := cmd.Flags()
+
+	t := cfg.Transport()
+	tokenFile := cfg.TokenFile
+
+	if flags.Changed("tls") {
+		t.Enabled = fleetTLS
+	}
+
+	if flags.Changed("ca-file") {
+		t.CAFile = fleetCAFile
+	}
+
+	if flags.Changed("server-name") {
+		t.ServerName = fleetServerName
+	}
+
+	if flags.Changed("token-file") {
+		tokenFile = fleetTokenFile
+	}
+
+	var err error
+
+	if t.CAFile != "" {
+		if t.CAFile, err = filepath.Abs(t.CAFile); err != nil {
+			return fmt.Errorf("failed to resolve CA file: %w", err)
+		}
+	}
+
+	if tokenFile != "" {
+		if tokenFile, err = filepath.Abs(tokenFile); err != nil {
+			return fmt.Errorf("failed to resolve token file: %w", err)
+		}
+	}
+
+	t.Enabled = t.Enabled || t.CAFile != "" || t.ServerName != ""
+
+	return store.SetTransport(t, tokenFile)
+}
+
+func runFleetPush(cmd *cobra.Command, _ []string) error {
+	store := fleet.GetStore()
+	cfg := store.Get()
+
+	if cfg.ReportTo == "" {
+		return fmt.Errorf("no central server configured; run 'glix fleet config --report-to <host:port>'")
+	}
+
+	grpcClient, err := client.GetClient(cmd.Context(), client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	resp, err := grpcClient.ListModules(cmd.Context(), 0, 0, "")
+	if err != nil {
+		return fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	pushErr := fleet.Push(cmd.Context(), cfg, fleet.NewInventory(cfg.HostName(), resp.GetModules()))
+
+	if err := store.RecordReport(pushErr); err != nil {
+		return err
+	}
+
+	if pushErr != nil {
+		return pushErr
+	}
+
+	cmd.Printf("Reported %d module(s) as %s to %s\n", len(resp.GetModules()), cfg.HostName(), cfg.ReportTo)
+
+	return nil
+}
+
+func runFleetList(cmd *cobra.Command, _ []string) error {
+	grpcClient, err := client.GetClient(cmd.Context(), client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	inventories, err := grpcClient.ListInventories(cmd.Context(), fleetListModule, fleetListHost)
+	if err != nil {
+		return fmt.Errorf("failed to list inventories: %w", err)
+	}
+
+	if len(inventories) == 0 {
+		cmd.Println("No inventories reported")
+		return nil
+	}
+
+	cmd.Println()
+	cmd.Printf("Reporting machines (%d):\n", len(inventories))
+	cmd.Println()
+
+	for _, inv := range inventories {
+		reported := time.Unix(0, inv.GetReportedUnixNano())
+
+		cmd.Printf("  %s (%s/%s, reported %s)\n",
+			inv.GetHost(), inv.GetGoos(), inv.GetGoarch(), humanize.Since(reported))
+
+		for _, mod := range inv.GetModules() {
+			cmd.Printf("    %s@%s\n", mod.GetName(), mod.GetVersion())
+		}
+	}
+
+	if fleetListModule != "" {
+		printFleetVersionSummary(cmd, inventories)
+	}
+
+	cmd.Println()
+
+	return nil
+}
+
+// printFleetVersionSummary counts the machines running each version of the
+// matched modules
+func printFleetVersionSummary(cmd *cobra.Command, inventories []*pb.InventoryProto) {
+	counts := make(map[string]map[string]int)
+
+	for _, inv := range inventories {
+		for _, mod := range inv.GetModules() {
+			if counts[mod.GetName()] == nil {
+				counts[mod.GetName()] = make(map[string]int)
+			}
+
+			counts[mod.GetName()][mod.GetVersion()]++
+		}
+	}
+
+	cmd.Println()
+	cmd.Println("Versions:")
+
+	names := slices.Sorted(maps.Keys(counts))
+	for _, name := range names {
+		cmd.Printf("  %s\n", name)
+
+		versions := slices.SortedFunc(maps.Keys(counts[name]), func(a, b string) int {
+			return modver.Compare(b, a)
+		})
+
+		for _, v := range versions {
+			cmd.Printf("    %-20s %d machine(s)\n", v, counts[name][v])
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// helpForCmd represents the help-for command
+var helpForCmd = &cobra.Command{
+	Use:   "help-for <tool>",
+	Short: "Show the captured help and man pages of an installed tool",
+	Long: `Show the --help output and man pages of an installed tool, captured when
+it was installed with --capture-help. Reading them needs neither the
+source nor the network, which helps on servers without a checkout.
+
+Capturing runs the installed binary with --help (or -h when that prints
+nothing) and collects the roff man pages the module ships. Updates capture
+them again for the new version once they were captured for a module.
+
+The tool can be given by module path or binary name. --man shows the man
+page of the binary, or the one named; a terminal with man installed gets
+it rendered, other output the roff source.
+
+Examples:
+  glix install --capture-help github.com/junegunn/fzf
+  glix help-for fzf
+  glix help-for fzf --man
+  glix help-for github.com/junegunn/fzf --man fzf-tmux.1`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeInstalledModules,
+	SilenceUsage:      true,
+	RunE:              runHelpFor,
+}
+
+var (
+	helpForMan string
+
+	// captureHelpFlag asks install and update to capture the help of the tool
+	captureHelpFlag bool
+)
+
+func init() {
+	rootCmd.AddCommand(helpForCmd)
+
+	helpForCmd.Flags().StringVar(&helpForMan, "man", "", "Show a man page instead of --help: the binary's, or the one named")
+	helpForCmd.Flags().Lookup("man").NoOptDefVal = "*"
+
+	for _, c := range []*cobra.Command{installCmd, updateCmd} {
+		c.Flags().BoolVar(&captureHelpFlag, "capture-help", false, "Capture the tool's --help output and man pages for 'glix help-for'")
+	}
+}
+
+func runHelpFor(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	mod, err := findInstalledTool(ctx, grpcClient, args[0])
+	if err != nil {
+		return err
+	}
+
+	doc, err := module.LoadHelp(mod.GetName(), mod.GetVersion())
+	if err != nil {
+		return err
+	}
+
+	if helpForMan == "" {
+		if doc.Help == "" {
+			cmd.Printf("%s printed no help when it was installed\n", doc.Binary)
+		} else {
+			if _, err := fmt.Fprint(cmd.OutOrStdout(), doc.Help); err != nil {
+				return err
+			}
+		}
+
+		if len(doc.ManPages) > 0 {
+			names := make([]string, 0, len(doc.ManPages))
+			for _, p := range doc.ManPages {
+				names = append(names, p.Name())
+			}
+
+			cmd.Printf("\nMan pages: %s (glix help-for %s --man <page>)\n", strings.Join(names, ", "), args[0])
+		}
+
+		return nil
+	}
+
+	name := helpForMan
+	if name == "*" {
+		name = ""
+	}
+
+	page, ok := doc.ManPage(name)
+	if !ok {
+		if name == "" {
+			return fmt.Errorf("%s@%s ships no man pages", mod.GetName(), mod.GetVersion())
+		}
+
+		return fmt.Errorf("%s@%s has no man page %s", mod.GetName(), mod.GetVersion(), name)
+	}
+
+	return showManPage(cmd, page)
+}
+
+// showManPage renders a man page with man(1) on a terminal and prints its
+// roff source otherwise
+func showManPage(cmd *cobra.Command, page module.ManPage) error {
+	manPath, err := exec.LookPath("man")
+	if err != nil || !term.IsTerminal(int(os.Stdout.Fd())) {
+		_, err := fmt.Fprint(cmd.OutOrStdout(), page.Content)
+		return err
+	}
+
+	// man reads local pages from a file named like one
+	dir, err := os.MkdirTemp("", "glix-man-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	file := filepath.Join(dir, page.Name())
+	if err := os.WriteFile(file, []byte(page.Content), 0644); err != nil {
+		return fmt.Errorf("failed to write man page: %w", err)
+	}
+
+	man := exec.CommandContext(cmd.Context(), manPath, "-l", file)
+	man.Stdin = os.Stdin
+	man.Stdout = os.Stdout
+	man.Stderr = os.Stderr
+
+	return man.Run()
+}
+
+// captureHelp stores the help of an installed tool when --capture-help is
+// given, or when it was captured for an earlier version
+func captureHelp(ctx context.Context, m *module.Module, progressHandler func(phase, message string)) {
+	if !captureHelpFlag && !module.HasHelp(m.Name) {
+		return
+	}
+
+	progressHandler("help", "Capturing help and man pages...")
+
+	doc, err := m.CaptureHelp(ctx)
+	if err != nil {
+		progressHandler("warning", fmt.Sprintf("failed to capture help: %v", err))
+		return
+	}
+
+	progressHandler("help", fmt.Sprintf("Captured help and %d man page(s); see 'glix help-for %s'", len(doc.ManPages), doc.Binary))
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/humanize"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/tui"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history [module]",
+	Short: "Show the history of installs, updates, and removes",
+	Long: `List installs, updates, and removes, newest first, with the versions
+involved and whether they succeeded. Changes made by the CLI, the
+auto-updater, and the dashboard are all recorded.
+
+Examples:
+  glix history
+  glix history github.com/golangci/golangci-lint/cmd/golangci-lint
+  glix history --limit 100
+  glix history --output json | jq '.events[] | select(.success | not)'`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runHistory,
+}
+
+var historyLimit int32
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+
+	historyCmd.Flags().Int32VarP(&historyLimit, "limit", "n", 20, "Number of events to show (0 = all)")
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	grpcClient, err := client.GetClient(cmd.Context(), client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	var name string
+	if len(args) > 0 {
+		name, _ = parseModulePath(args[0])
+	}
+
+	events, err := grpcClient.GetHistory(cmd.Context(), name, historyLimit)
+	if err != nil {
+		return err
+	}
+
+	if structuredOutput() {
+		return printMessage(cmd, &pb.GetHistoryResponse{Events: events})
+	}
+
+	if len(events) == 0 {
+		cmd.Println("No history recorded")
+		return nil
+	}
+
+	t := newTable(
+		column{Header: "TIME"},
+		column{Header: "ACTION"},
+		column{Header: "MODULE", Shrink: true},
+		column{Header: "VERSION"},
+		column{Header: "OUTCOME", Shrink: true, KeepStart: true, Style: eventOutcomeStyle},
+	)
+
+	for _, e := range events {
+		outcome := "ok"
+		if build := e.GetBuild(); build != nil {
+			outcome += fmt.Sprintf(" in %s, %s", humanize.Duration(time.Duration(build.GetDurationNano())), humanize.Size(build.GetBinarySize()))
+		}
+
+		if !e.GetSuccess() {
+			outcome = "failed: " + e.GetErrorMessage()
+		} else if scan := e.GetScan(); scan != nil {
+			outcome += "; scanned clean by " + scan.GetScanner()
+		}
+
+		t.addRow(
+			time.Unix(0, e.GetTimestampUnixNano()).Format("2006-01-02 15:04:05"),
+			eventActionName(e.GetAction()),
+			e.GetName(),
+			eventVersions(e),
+			outcome,
+		)
+	}
+
+	return t.write(cmd.OutOrStdout())
+}
+
+// eventOutcomeStyle colors an outcome cell of the history table
+func eventOutcomeStyle(outcome string) lipgloss.Style {
+	if strings.HasPrefix(outcome, "ok") {
+		return tui.SuccessStyle
+	}
+
+	return tui.ErrorStyle
+}
+
+// eventActionName returns a short lowercase name for an event action
+func eventActionName(action pb.EventAction) string {
+	return strings.ToLower(strings.TrimPrefix(action.String(), "EVENT_ACTION_"))
+}
+
+// eventVersions describes the versions an event moved between
+func eventVersions(e *pb.EventProto) string {
+	switch {
+	case e.GetFromVersion() != "" && e.GetToVersion() != "":
+		return e.GetFromVersion() + " -> " + e.GetToVersion()
+	case e.GetToVersion() != "":
+		return e.GetToVersion()
+	default:
+		return e.GetFromVersion()
+	}
+}
+
+// recordFailure adds a failed change to the event history. Successful
+// changes are recorded by the server when they are stored. Binaries the
+// quarantine scanner rejected keep its verdict.
+func recordFailure(ctx context.Context, grpcClient *client.Client, action pb.EventAction, name, from, to string, cause error) {
+	event := &pb.EventProto{
+		Action:       action,
+		Name:         name,
+		FromVersion:  from,
+		ToVersion:    to,
+		ErrorMessage: cause.Error(),
+	}
+
+	var quarantineErr *module.QuarantineError
+	if errors.As(cause, &quarantineErr) {
+		event.Scan = quarantineErr.Result
+	}
+
+	_ = grpcClient.RecordEvent(ctx, event)
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/hold"
+	"github.com/inovacc/glix/internal/humanize"
+	"github.com/spf13/cobra"
+)
+
+// holdCmd represents the hold command
+var holdCmd = &cobra.Command{
+	Use:   "hold [module]",
+	Short: "Suppress updates for a module until a date",
+	Long: `Hold back updates for an installed module until a date, for riding out a
+known-bad release. update, monitor --update and auto-update skip held
+modules; once the hold expires, normal update policy resumes on its own.
+
+Without a module, lists the active holds.
+
+Examples:
+  glix hold github.com/inovacc/twig --until 2025-03-01
+  glix hold github.com/inovacc/twig --for 30d --reason "v1.4 breaks config parsing"
+  glix hold                      # List active holds
+  glix unhold github.com/inovacc/twig`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runHold,
+}
+
+// unholdCmd releases a hold early
+var unholdCmd = &cobra.Command{
+	Use:   "unhold <module>",
+	Short: "Release a hold so the module updates normally again",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runUnhold,
+}
+
+var (
+	holdUntil  string
+	holdFor    string
+	holdReason string
+)
+
+func init() {
+	rootCmd.AddCommand(holdCmd)
+	rootCmd.AddCommand(unholdCmd)
+
+	holdCmd.Flags().StringVar(&holdUntil, "until", "", "Hold through this date (YYYY-MM-DD or RFC 3339)")
+	holdCmd.Flags().StringVar(&holdFor, "for", "", "Hold for a length of time (e.g. 30d, 2w, 36h)")
+	holdCmd.Flags().StringVar(&holdReason, "reason", "", "Why the module is held")
+	holdCmd.MarkFlagsMutuallyExclusive("until", "for")
+}
+
+func runHold(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return printHolds(cmd)
+	}
+
+	modulePath, _ := parseModulePath(args[0])
+
+	var (
+		until time.Time
+		err   error
+	)
+
+	switch {
+	case holdUntil != "":
+		until, err = hold.ParseUntil(holdUntil)
+	case holdFor != "":
+		var d time.Duration
+
+		d, err = hold.ParseFor(holdFor)
+		until = time.Now().Add(d)
+	default:
+		return fmt.Errorf("specify how long to hold with --until or --for")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	cfg := client.DefaultDiscoveryConfig()
+
+	grpcClient, err := client.GetClient(cmd.Context(), cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	resp, err := grpcClient.GetModule(cmd.Context(), modulePath, "")
+	if err != nil {
+		return fmt.Errorf("failed to query module: %w", err)
+	}
+
+	if !resp.GetFound() {
+		return fmt.Errorf("module %q is not installed", modulePath)
+	}
+
+	h := hold.Hold{
+		Module:  modulePath,
+		Until:   until,
+		Reason:  holdReason,
+		Created: time.Now(),
+	}
+
+	if err := hold.GetStore().Set(h); err != nil {
+		return err
+	}
+
+	cmd.Printf("Holding %s@%s until %s\n", modulePath, resp.GetModule().GetVersion(), until.Format("2006-01-02 15:04"))
+
+	return nil
+}
+
+func runUnhold(cmd *cobra.Command, args []string) error {
+	modulePath, _ := parseModulePath(args[0])
+
+	if err := hold.GetStore().Release(modulePath); err != nil {
+		return err
+	}
+
+	cmd.Printf("Released hold on %s\n", modulePath)
+
+	return nil
+}
+
+func printHolds(cmd *cobra.Command) error {
+	holds := hold.GetStore().List()
+	if len(holds) == 0 {
+		cmd.Println("No modules are held")
+		return nil
+	}
+
+	cmd.Println()
+	cmd.Printf("Held modules (%d):\n", len(holds))
+	cmd.Println()
+
+	for _, h := range holds {
+		cmd.Printf("  %s\n", h.Module)
+		cmd.Printf("    Until: %s (%s left)\n", h.Until.Format("2006-01-02 15:04"), humanize.Duration(time.Until(h.Until)))
+
+		if h.Reason != "" {
+			cmd.Printf("    Reason: %s\n", h.Reason)
+		}
+	}
+
+	cmd.Println()
+
+	return nil
+}
+
+// describeHold returns a short explanation for a held module, or "" when not held
+func describeHold(moduleName string) string {
+	h, ok := hold.GetStore().Get(moduleName)
+	if !ok {
+		return ""
+	}
+
+	desc := fmt.Sprintf("held until %s", h.Until.Format("2006-01-02 15:04"))
+	if h.Reason != "" {
+		desc += fmt.Sprintf(" (%s)", h.Reason)
+	}
+
+	return desc
+}
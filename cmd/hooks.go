@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/hooks"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/spf13/cobra"
+)
+
+// hooksCmd represents the hooks parent command
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Inspect the commands run before and after installs, updates and removes",
+	Long: `Hooks are commands of your own that glix runs around installs, updates
+and removes: to notify a team, sign a binary or sync dotfiles. Installs,
+updates, auto-updates and removes all run them.
+
+The hooks live in hooks.yaml in the glix config directory; without that file
+no hooks run. Each hook lists the events it runs on:
+
+  pre-install, post-install, pre-update, post-update, pre-remove, post-remove
+
+A failing pre hook aborts the operation unless it sets ignore_failure. A
+failing post hook only warns: the operation already happened.
+
+  hooks:
+    - name: sign
+      on: [post-install, post-update]
+      command: [sh, -c, 'cosign sign-blob --yes --key ~/.cosign.key "$GLIX_BINARY"']
+      modules: [github.com/acme/*]
+      timeout: 2m
+    - name: notify
+      on: [post-update]
+      command: [sh, -c, 'notify-send "$GLIX_MODULE $GLIX_PREVIOUS_VERSION -> $GLIX_VERSION"']
+      ignore_failure: true
+
+modules limits a hook to modules matching path prefix patterns, as GOPRIVATE
+matches them. timeout defaults to 1m.
+
+Commands get the module in environment variables; those without a value are
+left out:
+
+  GLIX_HOOK_EVENT        the event, e.g. post-update
+  GLIX_MODULE            the module path
+  GLIX_ROOT_MODULE       the module root, for commands in a subdirectory
+  GLIX_VERSION           the version installed, updated to or removed
+  GLIX_PREVIOUS_VERSION  the version an update replaces
+  GLIX_BINARY            the binary, or where it is about to be placed
+  GLIX_BINARY_HASH       the hash of the binary, after it is placed
+
+Examples:
+  glix hooks show
+  glix hooks run post-install github.com/acme/tool`,
+}
+
+// hooksShowCmd prints the configured hooks
+var hooksShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the hooks file location and hooks",
+	RunE:  runHooksShow,
+}
+
+// hooksRunCmd runs the hooks of an event for an installed module
+var hooksRunCmd = &cobra.Command{
+	Use:          "run <event> <module>",
+	Short:        "Run the hooks of an event for an installed module, to try them",
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE:         runHooksRun,
+}
+
+func init() {
+	rootCmd.AddCommand(hooksCmd)
+
+	hooksCmd.AddCommand(hooksShowCmd)
+	hooksCmd.AddCommand(hooksRunCmd)
+}
+
+func runHooksShow(cmd *cobra.Command, _ []string) error {
+	path := hooks.DefaultPath()
+
+	c, err := hooks.Load(path)
+	if err != nil {
+		return err
+	}
+
+	if c == nil || len(c.Hooks) == 0 {
+		cmd.Printf("No hooks at %s\n", path)
+		return nil
+	}
+
+	cmd.Printf("Hooks: %s\n", path)
+
+	for _, h := range c.Hooks {
+		events := make([]string, 0, len(h.On))
+		for _, event := range h.On {
+			events = append(events, string(event))
+		}
+
+		cmd.Printf("\n%s\n", h.Name)
+		cmd.Printf("  On:       %s\n", strings.Join(events, ", "))
+		cmd.Printf("  Command:  %s\n", strings.Join(h.Command, " "))
+
+		if len(h.Modules) > 0 {
+			cmd.Printf("  Modules:  %s\n", strings.Join(h.Modules, ", "))
+		}
+
+		cmd.Printf("  Timeout:  %s\n", h.Timeout)
+
+		if h.IgnoreFailure {
+			cmd.Printf("  Failures: ignored\n")
+		}
+	}
+
+	return nil
+}
+
+func runHooksRun(cmd *cobra.Command, args []string) error {
+	event := module.HookEvent(args[0])
+	if !slices.Contains(module.HookEvents, event) {
+		return fmt.Errorf("unknown event %q", args[0])
+	}
+
+	c, err := hooks.Load(hooks.DefaultPath())
+	if err != nil {
+		return err
+	}
+
+	if c == nil {
+		return fmt.Errorf("no hooks configured at %s", hooks.DefaultPath())
+	}
+
+	grpcClient, err := client.GetClient(cmd.Context(), client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	resp, err := grpcClient.GetModule(cmd.Context(), args[1], "")
+	if err != nil {
+		return fmt.Errorf("failed to query module: %w", err)
+	}
+
+	if !resp.GetFound() {
+		return fmt.Errorf("module %q is not installed", args[1])
+	}
+
+	mod := resp.GetModule()
+	_, binPath := module.RecordedBinary(mod)
+
+	info := module.HookInfo{
+		Module:     mod.GetName(),
+		RootModule: mod.GetRootModule(),
+		Version:    mod.GetVersion(),
+		Binary:     binPath,
+		BinaryHash: mod.GetBinaryHash(),
+	}
+
+	if len(c.Matching(event, info.Module)) == 0 {
+		cmd.Printf("No %s hooks for %s\n", event, info.Module)
+		return nil
+	}
+
+	return c.Run(cmd.Context(), event, info, func(phase, message string) {
+		cmd.Printf("[%s] %s\n", phase, message)
+	})
+}
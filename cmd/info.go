@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/inovacc/glix/internal/module"
+	"github.com/spf13/cobra"
+)
+
+// maxInfoVersions is how many versions info shows without --all-versions
+const maxInfoVersions = 10
+
+// infoCmd represents the info command
+var infoCmd = &cobra.Command{
+	Use:   "info <module>",
+	Short: "Inspect a remote module without installing it",
+	Long: `Resolve a module through the module proxy and show its latest version,
+available versions, whether the path is a main package, and the CLI
+binaries discovered in the root module. Nothing is built, written to GOBIN
+or recorded in the database.
+
+Examples:
+  glix info github.com/inovacc/twig
+  glix info github.com/golangci/golangci-lint --all-versions`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInfo,
+}
+
+var infoAllVersions bool
+
+func init() {
+	rootCmd.AddCommand(infoCmd)
+
+	infoCmd.Flags().BoolVar(&infoAllVersions, "all-versions", false, "List every available version")
+}
+
+func runInfo(cmd *cobra.Command, args []string) error {
+	workDir, err := module.NewWorkDir("info")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = os.RemoveAll(workDir)
+	}()
+
+	m, err := module.NewModule(cmd.Context(), "go", workDir)
+	if err != nil {
+		return fmt.Errorf("failed to create module: %w", err)
+	}
+
+	info, err := m.Inspect(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to inspect module: %w", err)
+	}
+
+	cmd.Println()
+	cmd.Printf("Module:      %s\n", info.Path)
+
+	if info.RootModule != info.Path {
+		cmd.Printf("Root module: %s\n", info.RootModule)
+	}
+
+	latest := info.Latest
+	if !info.Published.IsZero() {
+		latest += fmt.Sprintf(" (published %s)", info.Published.Format("2006-01-02"))
+	}
+
+	cmd.Printf("Latest:      %s\n", latest)
+
+	if info.HasMain {
+		cmd.Println("Installable: yes (package main)")
+	} else {
+		cmd.Println("Installable: no package main at this path")
+	}
+
+	if len(info.CLIPaths) > 0 {
+		cmd.Println()
+		cmd.Printf("CLI paths (%d):\n", len(info.CLIPaths))
+
+		for _, p := range info.CLIPaths {
+			cmd.Printf("  %s\n", p)
+		}
+	}
+
+	versions := info.Versions
+	if !infoAllVersions && len(versions) > maxInfoVersions {
+		versions = versions[:maxInfoVersions]
+	}
+
+	cmd.Println()
+	cmd.Printf("Versions (%d): %s", len(info.Versions), strings.Join(versions, ", "))
+
+	if len(versions) < len(info.Versions) {
+		cmd.Printf(", ... (--all-versions to list all)")
+	}
+
+	cmd.Println()
+	cmd.Println()
+
+	return nil
+}
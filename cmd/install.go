@@ -1,17 +1,25 @@
 package cmd
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"time"
 
 	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/hooks"
 	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/profiles"
+	"github.com/inovacc/glix/internal/quarantine"
 	"github.com/inovacc/glix/internal/tui"
+	pb "github.com/inovacc/glix/pkg/api/v1"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 // installCmd represents the install command
@@ -20,26 +28,293 @@ var installCmd = &cobra.Command{
 	Short: "Install a Go module",
 	Long: `Install a Go module from a repository and track it in the database.
 
-The module can be specified as a full import path or a GitHub URL.
-glix will automatically detect CLI binaries in the repository if the
-root is not installable.
+The module can be specified as a full import path, a GitHub URL, or a
+local directory (./path or file:// URL). glix will automatically detect
+CLI binaries in the repository if the root is not installable: main
+packages in its cmd, cli, tools, apps, app and bin directories
+(--discover-dirs), GoReleaser builds and the go install commands of its
+README. When it finds several, glix asks which to install in a terminal
+and otherwise installs the most likely one, preferring the one the README
+installs; --first always does so, and --select-all installs all of them.
+--all-binaries installs every CLI of the repository, also when the path
+given is one, each tracked as its own entry of the same root module.
+Library modules without any CLI, such as github.com/pkg/errors, fail with
+the packages they provide and the go get command adding them to a project;
+in a terminal glix offers to watch them for new releases instead.
+
+The version can also be a branch or a commit, to install an unreleased
+build. It is resolved to the pseudo-version of the commit, which is what
+gets recorded; later updates move to the next release.
+
+Versions retracted by their author are never picked for latest or a range,
+and a retracted version requested by name is refused unless
+--allow-retracted is given.
+
+A caret or tilde range installs the newest release it allows and is
+recorded, so updates and auto-updates stay within it: ^1.2 never leaves
+v1, ^0.3 stays on v0.3.x and ~1.4 stays on v1.4.x. Installing another
+version or range replaces the recorded one.
+
+Latest means the latest release. --pre installs a pre-release such as an
+rc or beta tag instead when it is newer. The preference is recorded, so
+updates and auto-updates move on to newer pre-releases too.
+
+--prefer-binary installs the prebuilt binary of the module's GitHub release
+for the target platform instead of compiling, after verifying it against
+the checksum file of the release. Modules without such a release, or
+installed with build flags, are built from source. The preference is
+recorded, so updates keep using release binaries.
+
+--build-strategy picks how the binary is built: go, goreleaser, or a
+Makefile or magefile target such as make:cli (default target build). By
+default modules build with GoReleaser when they have a config, else with
+go install, falling back to make build or mage build when go install
+fails. The strategy is recorded, so updates build the same way.
+
+Local directories are built in place from the working copy, honoring its
+go.mod and replace directives, and are recorded as dev installs.
 
 Examples:
   glix install github.com/inovacc/twig
   glix install https://github.com/inovacc/twig
   glix install github.com/inovacc/twig@latest
-  glix install github.com/inovacc/twig@v1.0.0`,
-	Args: cobra.ExactArgs(1),
+  glix install github.com/inovacc/twig@v1.0.0
+  glix install github.com/inovacc/twig@main
+  glix install github.com/inovacc/twig@4f2c1ab
+  glix install github.com/inovacc/twig@^1.2
+  glix install github.com/inovacc/twig@~1.4
+  glix install ./path/to/checkout
+  glix install file:///home/me/src/mytool
+
+Protoc plugin sets:
+  --protoc-set installs protoc-gen-go, protoc-gen-go-grpc,
+  protoc-gen-grpc-gateway and protoc-gen-openapiv2 at versions known to
+  work together, then verifies the installed plugins against an embedded
+  compatibility matrix.
+
+  glix install --protoc-set latest
+  glix install --protoc-set 2024.1
+  glix install --protoc-set list
+
+kubectl plugins:
+  Binaries named kubectl-<name> are recorded as kubectl plugins
+  automatically. --kubectl-plugin registers any other CLI as a plugin by
+  renaming its binary to kubectl-<name> so 'kubectl <name>' finds it.
+
+  glix install --kubectl-plugin github.com/example/view-secret
+
+Aliases:
+  --as installs the binary under another name, e.g. to keep two major
+  versions of a tool side by side. The alias is kept across updates and
+  reinstalls, and remove deletes the aliased binary only. 'glix alias'
+  renames an installed binary later.
+
+  glix install --as golangci-lint-v1 github.com/golangci/golangci-lint/cmd/golangci-lint
+  glix install github.com/golangci/golangci-lint/v2/cmd/golangci-lint
+
+  An install whose binary would replace the binary of another installed
+  module, such as two modules both installing a binary named server, is
+  refused; install it under another name with --as, or replace the other
+  binary with --force.
+
+  --name-template names the binary by a template instead, e.g.
+  {name}-{major} to keep it apart from a distro-installed binary of the
+  same name. 'glix naming' sets templates for first installs.
+
+  glix install --name-template "{name}-{major}" github.com/golangci/golangci-lint/cmd/golangci-lint
+
+Bin directory:
+  Binaries go to GOBIN, which is checked for being writable before
+  anything is built. --bin-dir installs to another directory instead, e.g.
+  when GOBIN is on a read-only or network file system. The directory is
+  kept across updates and reinstalls; add it to PATH.
+
+  glix install --bin-dir ~/.local/bin github.com/inovacc/twig
+
+Shims:
+  --shim keeps every installed version side by side in a version store in
+  the glix directory, and writes a small shim in GOBIN that runs the active
+  one. Updates add the new version to the store; 'glix use' switches to
+  another stored version instantly, without rebuilding. The mode is kept
+  across updates and reinstalls.
+
+  glix install --shim github.com/golangci/golangci-lint/v2/cmd/golangci-lint
+  glix use golangci-lint@v2.1.0
+
+Private modules:
+  --private fetches the module from its repository, without the module
+  proxy and checksum database, as GOPRIVATE does; updates keep doing so.
+  'glix private' configures patterns of private modules and the
+  credentials git and go use. Installs that fail because access was
+  refused say so.
+
+  glix install --private github.com/acme/tool
+
+Offline:
+  --offline resolves and builds from the local module cache only, for
+  air-gapped machines or a flaky network: latest is the newest cached
+  version, and release binaries, the denylist sync and the vulnerability
+  scan are skipped. GOPROXY=off in the environment implies it.
+  'glix update --offline' updates to newer cached versions.
+
+  glix install --offline github.com/acme/tool
+
+Profiles:
+  --profile downloads and builds from the module cache of an install
+  profile instead of the shared one, keeping e.g. company modules apart.
+  Updates and reinstalls keep the profile. See 'glix profile'.
+
+  glix install --profile work github.com/acme/tool
+
+Module proxies:
+  --goproxy downloads the module through another GOPROXY, such as a
+  company's Artifactory or Athens proxy, instead of the one of its profile
+  or the environment; updates keep using it, and --goproxy "" goes back.
+  Profiles can name a proxy for all their installs, see 'glix profile'.
+
+  glix install --goproxy https://athens.corp,direct corp.example.com/tool
+
+Cross-compiling:
+  --goos and --goarch build the binary for another platform. It is placed
+  in a directory per platform under the bin directory, such as
+  $GOBIN/linux_arm64, and updates keep building for that platform.
+
+  glix install --goos linux --goarch arm64 github.com/inovacc/twig
+
+Build flags:
+  --ldflags, --tags and --trimpath are passed to go build. They are
+  recorded with the install, so updates and auto-updates rebuild with the
+  same flags; giving any of them on a later install or update replaces the
+  recorded set. Modules with a GoReleaser config are built with go install
+  instead when flags are set.
+
+  glix install --ldflags "-s -w" --trimpath github.com/inovacc/twig
+  glix install --tags netgo,osusergo github.com/inovacc/twig
+
+Slow builds:
+  A build that prints nothing for a minute reports a heartbeat with the
+  elapsed time. After --stall-threshold (10m by default, or
+  GLIX_STALL_THRESHOLD) of silence a warning shows what the build process
+  is doing, so a hung build can be told from a slow one and cancelled.
+
+Bulk installs:
+  --file installs every module listed in a file, one module[@version] per
+  line with # comments, several at a time (--jobs). "-" reads the list
+  from stdin. A summary of the installs is printed at the end, and the
+  command fails when any of them failed.
+
+  glix install -f tools.txt
+  cat team-tools.txt | glix install -f - --jobs 8`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if protocSet != "" || installFile != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	RunE: runInstall,
 }
 
+var (
+	installKubectlPlugin bool
+	installAs            string
+	installBinDir        string
+	installStallAfter    time.Duration
+	installProfile       string
+	installGOOS          string
+	installGOARCH        string
+	installDiscoverDirs  []string
+	installFirst         bool
+	installSelectAll     bool
+	installAllBinaries   bool
+	installShim          bool
+	installAllowRetract  bool
+
+	// installPicker asks which CLI to install in interactive installs
+	installPicker module.CLISelector
+)
+
+// maxLibraryPackages is the number of packages listed for a library module
+// that has no CLI to install
+const maxLibraryPackages = 10
+
 func init() {
 	rootCmd.AddCommand(installCmd)
+
+	installCmd.Flags().BoolVar(&installKubectlPlugin, "kubectl-plugin", false, "Register the binary as a kubectl plugin (kubectl-<name>)")
+	installCmd.Flags().StringVar(&installAs, "as", "", "Install the binary under this name instead of its default")
+	installCmd.Flags().StringVar(&installBinDir, "bin-dir", "", "Install the binary in this directory instead of GOBIN, e.g. when GOBIN is read-only")
+	installCmd.Flags().DurationVar(&installStallAfter, "stall-threshold", module.StallThreshold(), "Warn that the build may be stalled after printing nothing for this long")
+	installCmd.Flags().StringVar(&installProfile, "profile", "", "Download into the module cache of this profile (see 'glix profile')")
+	_ = installCmd.RegisterFlagCompletionFunc("profile", completeProfiles)
+	installCmd.Flags().StringVar(&installGOOS, "goos", "", "Cross-compile for this operating system (default: this machine's)")
+	installCmd.Flags().StringVar(&installGOARCH, "goarch", "", "Cross-compile for this architecture (default: this machine's)")
+	installCmd.Flags().StringSliceVar(&installDiscoverDirs, "discover-dirs", nil, "Top-level directories searched for CLIs of a module without one at its root, or * for all (default cmd,cli,tools,apps,app,bin, or GLIX_DISCOVERY_DIRS)")
+	installCmd.Flags().BoolVar(&installFirst, "first", false, "Install the best ranked CLI when discovery finds several, without asking")
+	installCmd.Flags().BoolVar(&installSelectAll, "select-all", false, "Install every CLI discovery finds, without asking")
+	installCmd.Flags().BoolVar(&installAllBinaries, "all-binaries", false, "Install every CLI of the module's repository, also next to a CLI path, as sibling entries")
+	installCmd.Flags().BoolVar(&installShim, "shim", false, "Keep installed versions side by side behind a shim in GOBIN, switched with 'glix use'")
+	installCmd.Flags().BoolVar(&installAllowRetract, "allow-retracted", false, "Install a version its author retracted when it is requested by name")
+	installCmd.MarkFlagsMutuallyExclusive("as", "kubectl-plugin")
+	installCmd.MarkFlagsMutuallyExclusive("shim", "kubectl-plugin")
+	installCmd.MarkFlagsMutuallyExclusive("first", "select-all")
+	installCmd.MarkFlagsMutuallyExclusive("as", "select-all")
+	installCmd.MarkFlagsMutuallyExclusive("first", "all-binaries")
+	installCmd.MarkFlagsMutuallyExclusive("as", "all-binaries")
 }
 
 func runInstall(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 
+	if installFile != "" {
+		if protocSet != "" {
+			return fmt.Errorf("--file and --protoc-set cannot be combined")
+		}
+
+		return runInstallFile(ctx, cmd, installFile)
+	}
+
+	if protocSet != "" {
+		return runInstallProtocSet(ctx, cmd, protocSet)
+	}
+
+	if installAs != "" {
+		if err := module.ValidateAlias(installAs); err != nil {
+			return err
+		}
+	}
+
+	if err := validateNameTemplate(); err != nil {
+		return err
+	}
+
+	if _, err := module.ParseBuildStrategy(strategyFlag); err != nil {
+		return err
+	}
+
+	if installBinDir != "" {
+		dir, err := filepath.Abs(installBinDir)
+		if err != nil {
+			return fmt.Errorf("failed to resolve --bin-dir: %w", err)
+		}
+
+		installBinDir = dir
+	}
+
+	// Local working copies are passed through as absolute directories
+	if module.IsLocalPath(args[0]) {
+		dir, err := module.ResolveLocalPath(args[0])
+		if err != nil {
+			return err
+		}
+
+		if IsTUIEnabled() {
+			return runInstallWithTUI(ctx, cmd, dir, "")
+		}
+
+		return runInstallPlainText(ctx, cmd, dir, "")
+	}
+
 	// Parse module path and version
 	modulePath, version := parseModulePath(args[0])
 
@@ -61,9 +336,24 @@ func runInstallWithTUI(ctx context.Context, cmd *cobra.Command, modulePath, vers
 	// Channel to communicate errors from the installation goroutine
 	errCh := make(chan error, 1)
 
+	// Closed once installed and installErr are set
+	done := make(chan struct{})
+
+	var (
+		installed  *module.Module
+		installErr error
+	)
+
+	if pickCLIs() {
+		installPicker = cliSelector(t.Select)
+	}
+
 	// Run installation in background
 	go func() {
-		errCh <- doInstall(tuiCtx, cmd, modulePath, version, t.ProgressHandler(), t.OutputHandler(), t.SetStatus)
+		defer close(done)
+
+		installed, installErr = installSelected(tuiCtx, cmd, modulePath, version, t.ProgressHandler(), t.OutputHandler(), t.SetStatus)
+		errCh <- installErr
 	}()
 
 	// Start TUI - this blocks until done
@@ -74,41 +364,135 @@ func runInstallWithTUI(ctx context.Context, cmd *cobra.Command, modulePath, vers
 	}()
 
 	// Run TUI
-	if err := t.Start(tuiCtx); err != nil {
-		return fmt.Errorf("TUI error: %w", err)
+	tuiErr := t.Start(tuiCtx)
+
+	// Quitting the TUI early cancels the installation; wait for it to stop
+	// before reading its results
+	tuiCancel()
+	<-done
+
+	if tuiErr != nil {
+		return fmt.Errorf("TUI error: %w", tuiErr)
+	}
+
+	if installErr != nil {
+		if explainLibraryModule(ctx, cmd, installErr) {
+			return nil
+		}
+
+		return installErr
 	}
 
+	offerPathFix(cmd, installed)
+
 	return nil
 }
 
 func runInstallPlainText(ctx context.Context, cmd *cobra.Command, modulePath, version string) error {
-	cmd.Printf("Installing module: %s", modulePath)
+	r := newPlainRenderer(cmd, true)
 
 	if version != "" {
-		cmd.Printf("@%s", version)
+		r.Printf("Installing module: %s@%s\n", modulePath, version)
+	} else {
+		r.Printf("Installing module: %s\n", modulePath)
+	}
+
+	if pickCLIs() {
+		installPicker = cliSelector(func(title string, choices []tui.Choice) (int, error) {
+			return tui.Pick(ctx, title, choices)
+		})
 	}
 
-	cmd.Println()
+	m, err := installSelected(ctx, cmd, modulePath, version, r.Progress, r.Output, r.Status)
+	if err := r.Finish(err); err != nil {
+		if explainLibraryModule(ctx, cmd, err) {
+			return nil
+		}
+
+		return err
+	}
 
-	progressHandler := func(phase, message string) {
-		cmd.Printf("[%s] %s\n", phase, message)
+	offerPathFix(cmd, m)
+
+	return nil
+}
+
+// installSelected installs a module and, with --select-all or
+// --all-binaries, every other CLI discovered in its root module at the same
+// version, each recorded as an entry of its own. It returns the module
+// installed first.
+func installSelected(
+	ctx context.Context,
+	cmd *cobra.Command,
+	modulePath, version string,
+	progressHandler func(phase, message string),
+	outputHandler func(stream, line string),
+	statusHandler func(text string),
+) (*module.Module, error) {
+	m, err := doInstall(ctx, cmd, modulePath, version, progressHandler, outputHandler, statusHandler)
+	if err != nil || !installSelectAll && !installAllBinaries {
+		return m, err
 	}
 
-	outputHandler := func(stream, line string) {
-		if stream == "stderr" {
-			_, _ = fmt.Fprintln(cmd.ErrOrStderr(), line)
-		} else {
-			_, _ = fmt.Fprintln(cmd.OutOrStdout(), line)
+	// The others were discovered already, and are installed one by one
+	defer func(all bool) { installAllBinaries = all }(installAllBinaries)
+	installAllBinaries = false
+
+	installed := []string{module.BinaryName(m.Name)}
+
+	for _, path := range m.DiscoveredCLIs() {
+		if path == m.Name {
+			continue
 		}
+
+		if _, err := doInstall(ctx, cmd, path, m.Version, progressHandler, outputHandler, statusHandler); err != nil {
+			return m, fmt.Errorf("failed to install %s: %w", path, err)
+		}
+
+		installed = append(installed, module.BinaryName(path))
 	}
 
-	statusHandler := func(text string) {
-		cmd.Printf("Status: %s\n", text)
+	if len(installed) > 1 {
+		progressHandler("complete", fmt.Sprintf("Installed %d CLIs of %s@%s: %s", len(installed), m.RootModule, m.Version, strings.Join(installed, ", ")))
 	}
 
-	return doInstall(ctx, cmd, modulePath, version, progressHandler, outputHandler, statusHandler)
+	return m, nil
+}
+
+// pickCLIs reports whether to ask which CLI to install when discovery finds
+// several, rather than installing the best ranked one
+func pickCLIs() bool {
+	return !installFirst && !installSelectAll && !installAllBinaries && !quietOutput &&
+		term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// cliSelector asks with pick which of several discovered CLIs to install
+func cliSelector(pick func(title string, choices []tui.Choice) (int, error)) module.CLISelector {
+	return func(candidates []string) (string, error) {
+		choices := make([]tui.Choice, len(candidates))
+		for i, path := range candidates {
+			description := "binary " + module.BinaryName(path)
+			if i == 0 {
+				description += ", recommended"
+			}
+
+			choices[i] = tui.Choice{Title: path, Description: description}
+		}
+
+		i, err := pick(fmt.Sprintf("%d CLIs found, pick the one to install", len(candidates)), choices)
+		if errors.Is(err, tui.ErrPickCancelled) {
+			return "", fmt.Errorf("no CLI selected; pass --first to install %s, or --select-all", candidates[0])
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		return candidates[i], nil
+	}
 }
 
+// doInstall installs a module and records it, returning the installed module
 func doInstall(
 	ctx context.Context,
 	cmd *cobra.Command,
@@ -116,7 +500,7 @@ func doInstall(
 	progressHandler func(phase, message string),
 	outputHandler func(stream, line string),
 	statusHandler func(text string),
-) error {
+) (*module.Module, error) {
 	statusHandler(fmt.Sprintf("Installing %s", modulePath))
 
 	// Connect to server first (starts on-demand server if needed)
@@ -124,22 +508,18 @@ func doInstall(
 
 	grpcClient, err := client.GetClient(ctx, cfg)
 	if err != nil {
-		return fmt.Errorf("failed to connect to server: %w", err)
+		return nil, fmt.Errorf("failed to connect to server: %w", err)
 	}
 
 	defer func() {
 		_ = grpcClient.Close()
 	}()
 
-	// Create a unique working directory for this install
-	cacheDir, err := module.GetApplicationCacheDirectory()
+	// Create a unique working directory for this install; bulk installs run
+	// several at once
+	workDir, err := module.NewWorkDir("install")
 	if err != nil {
-		return fmt.Errorf("failed to get cache directory: %w", err)
-	}
-
-	workDir := filepath.Join(cacheDir, fmt.Sprintf("install-%d", time.Now().UnixNano()))
-	if err := os.MkdirAll(workDir, 0755); err != nil {
-		return fmt.Errorf("failed to create working directory: %w", err)
+		return nil, err
 	}
 
 	defer func() {
@@ -149,11 +529,62 @@ func doInstall(
 	// Create module instance
 	m, err := module.NewModule(ctx, "go", workDir)
 	if err != nil {
-		return fmt.Errorf("failed to create module: %w", err)
+		return nil, fmt.Errorf("failed to create module: %w", err)
 	}
 
 	// Set progress handler to show what's happening
 	m.SetProgressHandler(progressHandler)
+	m.SetStallThreshold(installStallAfter)
+	m.SetVersionStore(grpcClient.VersionStore())
+	m.SetDiscoveryDirs(installDiscoverDirs)
+	m.SetCLISelector(installPicker)
+	m.SetAllBinaries(installAllBinaries)
+	m.SetAllowRetracted(installAllowRetract)
+
+	// Reinstalls keep downloading into the module cache of their profile,
+	// through their module proxy and fetching private modules from their
+	// repository unless --profile, --goproxy and --private say otherwise
+	profile := installProfile
+	recordedPrivate := false
+	recordedProxy := ""
+
+	if resp, err := grpcClient.GetModule(ctx, modulePath, ""); err == nil && resp.GetFound() {
+		if !cmd.Flags().Changed("profile") {
+			profile = resp.GetModule().GetProfile()
+		}
+
+		recordedPrivate = resp.GetModule().GetPrivate()
+		recordedProxy = resp.GetModule().GetGoproxy()
+	}
+
+	proxy, err := goProxy(cmd, recordedProxy)
+	if err != nil {
+		return nil, err
+	}
+
+	m.SetGoProxy(proxy)
+	m.SetPrivate(privateModules(cmd, recordedPrivate))
+	m.SetOffline(offline())
+
+	if m.Offline() {
+		progressHandler("offline", "Offline: resolving versions from the local module cache")
+	}
+
+	if err := profiles.Apply(m, profile); err != nil {
+		return nil, err
+	}
+
+	if err := quarantine.Apply(m); err != nil {
+		return nil, err
+	}
+
+	if err := hooks.Apply(m); err != nil {
+		return nil, err
+	}
+
+	if profile != "" {
+		progressHandler("profile", fmt.Sprintf("Using the module cache of profile %s", profile))
+	}
 
 	// Build full module path with version if specified
 	fullPath := modulePath
@@ -162,8 +593,120 @@ func doInstall(
 	}
 
 	// Fetch module info (CLI performs this locally)
-	if err := m.FetchModuleInfo(fullPath); err != nil {
-		return fmt.Errorf("failed to fetch module info: %w", err)
+	if module.IsLocalPath(modulePath) {
+		err = m.FetchLocalModuleInfo(modulePath)
+	} else {
+		err = m.FetchModuleInfo(fullPath)
+	}
+
+	if err != nil {
+		recordFailure(ctx, grpcClient, pb.EventAction_EVENT_ACTION_INSTALL, modulePath, "", version, err)
+		return nil, fmt.Errorf("failed to fetch module info: %w", err)
+	}
+
+	if !module.IsLocalPath(modulePath) {
+		if err := checkInstallAllowed(ctx, grpcClient, m, version, progressHandler); err != nil {
+			return nil, err
+		}
+
+		if err := enforcePolicy(ctx, m, progressHandler); err != nil {
+			return nil, err
+		}
+	}
+
+	// Reinstalls keep the recorded alias, bin directory, platform, build flags,
+	// binary preference and shim unless --as or --name-template, --bin-dir,
+	// --goos, --goarch, the build flags and --prefer-binary name others.
+	// Naming rules only name the binaries of first installs.
+	var (
+		previousBinary, recordedPlatform string
+		recordedFlags                    *pb.BuildFlagsProto
+		recordedStrategy                 string
+		recordedAlias, previousShim      string
+		recordedPrefer, recordedPre      bool
+		reinstall                        bool
+	)
+
+	if resp, err := grpcClient.GetModule(ctx, m.Name, ""); err == nil && resp.GetFound() {
+		reinstall = true
+		recordedAlias = resp.GetModule().GetAlias()
+		m.Alias = recordedAlias
+		m.SetBinDir(resp.GetModule().GetBinDir())
+		_, previousBinary = module.RecordedBinary(resp.GetModule())
+		recordedPlatform = resp.GetModule().GetPlatform()
+		recordedFlags = resp.GetModule().GetBuildFlags()
+		recordedStrategy = resp.GetModule().GetBuildStrategy()
+		recordedPrefer = resp.GetModule().GetPreferBinary()
+		recordedPre = resp.GetModule().GetPrerelease()
+		previousShim = resp.GetModule().GetShimPath()
+	}
+
+	if installAs != "" {
+		m.Alias = installAs
+	} else if alias, ok, err := templateAlias(m, !reinstall); err != nil {
+		return nil, err
+	} else if ok {
+		m.Alias = alias
+	}
+
+	goos, goarch := runtime.GOOS, runtime.GOARCH
+	if recordedPlatform != "" {
+		goos, goarch, _ = module.ParsePlatform(recordedPlatform)
+	}
+
+	m.SetPlatform(cmp.Or(installGOOS, goos), cmp.Or(installGOARCH, goarch))
+
+	if installBinDir != "" || m.Platform() != recordedPlatform {
+		base := installBinDir
+		if base == "" {
+			base = cmp.Or(m.BinDir(), module.GetGoBinDirectory())
+
+			if recordedPlatform != "" {
+				base = filepath.Dir(base)
+			}
+		}
+
+		m.SetBinDir(platformBinDir(base, m.Platform()))
+	}
+
+	if platform := m.Platform(); platform != "" {
+		progressHandler("platform", fmt.Sprintf("Cross-compiling for %s into %s", platform, m.BinDir()))
+	}
+
+	m.SetShim(installShim || previousShim != "")
+
+	if m.Shim() && m.Platform() != "" {
+		return nil, fmt.Errorf("shims run the binary on this machine and cannot be used for %s builds", m.Platform())
+	}
+
+	m.SetBuildFlags(buildFlags(cmd, recordedFlags))
+
+	strategy, err := buildStrategy(cmd, recordedStrategy)
+	if err != nil {
+		return nil, err
+	}
+
+	m.SetBuildStrategy(strategy)
+
+	if !strategy.IsZero() {
+		progressHandler("build", fmt.Sprintf("Building with strategy %s", strategy))
+	}
+
+	if flags := m.BuildFlags(); !flags.IsZero() {
+		progressHandler("build", fmt.Sprintf("Building with %s", flags))
+	}
+
+	m.SetPreferBinary(preferBinaries(cmd, recordedPrefer))
+	m.SetPrerelease(prereleases(cmd, recordedPre))
+
+	// Servers of other namespaces may manage binaries in the same directory
+	namespace := serverNamespace(ctx, grpcClient)
+	if err := checkOwnership(m.InstallPath(), namespace, progressHandler); err != nil {
+		return nil, err
+	}
+
+	if err := checkBinaryConflict(ctx, grpcClient, m, progressHandler); err != nil {
+		return nil, err
 	}
 
 	progressHandler("install", fmt.Sprintf("Installing %s@%s...", m.Name, m.Version))
@@ -171,7 +714,52 @@ func doInstall(
 
 	// Install module locally with streaming output
 	if err := m.InstallModuleWithStreaming(ctx, outputHandler); err != nil {
-		return fmt.Errorf("installation failed: %w", err)
+		recordFailure(ctx, grpcClient, pb.EventAction_EVENT_ACTION_INSTALL, m.Name, "", m.Version, err)
+
+		var binDirErr *module.BinDirError
+		if errors.As(err, &binDirErr) {
+			return nil, fmt.Errorf("%w; install to a writable directory with --bin-dir <dir>", err)
+		}
+
+		return nil, fmt.Errorf("installation failed: %w", err)
+	}
+
+	if m.Alias != "" {
+		progressHandler("alias", fmt.Sprintf("Installed as %s (%s)", m.Alias, m.BinaryPath))
+	}
+
+	// A new alias leaves the binary under the previous name behind, and a
+	// first shim the binary it replaces
+	if m.Shim() {
+		if previousShim == "" && previousBinary != "" && previousBinary != m.ShimPath {
+			if err := os.Remove(previousBinary); err == nil {
+				progressHandler("shim", fmt.Sprintf("Removed previous binary %s", previousBinary))
+			}
+		}
+
+		if previousShim != "" && previousShim != m.ShimPath {
+			if err := os.Remove(previousShim); err == nil {
+				progressHandler("shim", fmt.Sprintf("Removed previous shim %s", previousShim))
+			}
+		}
+
+		progressHandler("shim", fmt.Sprintf("%s runs %s", m.ShimPath, m.BinaryPath))
+	} else if previousBinary != "" && previousBinary != m.BinaryPath && (m.Alias != "" || recordedAlias != "") {
+		if err := os.Remove(previousBinary); err == nil {
+			progressHandler("alias", fmt.Sprintf("Removed previous binary %s", previousBinary))
+		}
+	}
+
+	registerKubectlPlugin(m, installKubectlPlugin, progressHandler)
+	claimOwnership(m, namespace, progressHandler)
+	writeReceipt(m.Name, m.Version, m.BinaryPath, m.ShimPath, namespace, progressHandler)
+	captureHelp(ctx, m, progressHandler)
+
+	// Binaries for other platforms cannot run here, so where PATH finds them
+	// does not matter
+	if m.Platform() == "" {
+		warnShadowing(module.ManagedBinaryName(m.Name, m.KubectlPlugin, m.Alias), progressHandler)
+		warnNotOnPath(cmp.Or(m.ShimPath, m.BinaryPath), progressHandler)
 	}
 
 	// Store module info in database via server
@@ -181,12 +769,162 @@ func doInstall(
 		progressHandler("warning", fmt.Sprintf("failed to store module in database: %v", err))
 	}
 
+	auditInstalled(ctx, grpcClient, m, progressHandler)
+
 	progressHandler("complete", fmt.Sprintf("Module %s installed successfully", m.Name))
 	statusHandler(fmt.Sprintf("Installed %s@%s", m.Name, m.Version))
 
+	return m, nil
+}
+
+// explainLibraryModule lists the packages of a module that failed to install
+// for having no CLI, whose error suggests go get instead, and in a terminal, offers to watch it for new releases
+// instead. It reports whether the module is watched now.
+func explainLibraryModule(ctx context.Context, cmd *cobra.Command, err error) bool {
+	var libErr *module.LibraryModuleError
+	if quietOutput || !errors.As(err, &libErr) {
+		return false
+	}
+
+	// The error says to go get the module; usage would only bury it
+	cmd.SilenceUsage = true
+
+	if n := len(libErr.Packages); n > 0 {
+		cmd.Printf("\n%s is a library module with %d importable package(s):\n", libErr.RootModule, n)
+
+		for i, pkg := range libErr.Packages {
+			if i == maxLibraryPackages {
+				cmd.Printf("  ... and %d more\n", n-i)
+				break
+			}
+
+			cmd.Printf("  %s\n", pkg)
+		}
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		cmd.Printf("Watch it for new releases with 'glix watch add %s'\n", libErr.RootModule)
+		return false
+	}
+
+	if !confirm(cmd, "Watch it for new releases instead?") {
+		return false
+	}
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		cmd.Printf("Warning: failed to connect to server: %v\n", err)
+		return false
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	if err := grpcClient.StoreLibraryWatch(ctx, &pb.LibraryWatchProto{
+		Path:          libErr.RootModule,
+		Version:       libErr.Version,
+		LatestVersion: libErr.Version,
+	}); err != nil {
+		cmd.Printf("Warning: %v\n", err)
+		return false
+	}
+
+	cmd.Printf("Watching %s for new releases; no binary was installed\n", libErr.RootModule)
+
+	return true
+}
+
+// checkInstallAllowed keeps an unpinned install off denied and broken
+// versions; explicitly requested versions are installed with a warning
+func checkInstallAllowed(ctx context.Context, grpcClient *client.Client, m *module.Module, version string, progressHandler func(phase, message string)) error {
+	// Offline installs check the catalogs synced last
+	if !m.Offline() {
+		syncDenylist(ctx, progressHandler)
+	}
+
+	var badVersions []string
+	if resp, err := grpcClient.GetModule(ctx, m.Name, ""); err == nil {
+		badVersions = resp.GetModule().GetBadVersions()
+	}
+
+	// Ranges fall back within themselves like unpinned installs
+	if version != "" && version != "latest" && !module.IsVersionRange(version) {
+		if reason := excludedReason(m.Name, m.Version, badVersions); reason != "" {
+			progressHandler("warning", fmt.Sprintf("%s@%s is %s; installing it because it was requested explicitly", m.Name, m.Version, reason))
+		}
+
+		return nil
+	}
+
+	ok, err := resolveAllowed(m, "", badVersions, progressHandler)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("no allowed version of %s is available", m.Name)
+	}
+
 	return nil
 }
 
+// registerKubectlPlugin records binaries named kubectl-<name> as kubectl
+// plugins, and renames other binaries into that form when force is set
+func registerKubectlPlugin(m *module.Module, force bool, progressHandler func(phase, message string)) {
+	// An alias names the binary explicitly
+	if m.Alias != "" {
+		return
+	}
+
+	if _, ok := module.KubectlPluginName(module.BinaryName(m.Name)); !ok && !force {
+		return
+	}
+
+	binaryPath, err := m.RegisterKubectlPlugin()
+	if err != nil {
+		progressHandler("warning", err.Error())
+		return
+	}
+
+	progressHandler("kubectl", fmt.Sprintf("Registered kubectl plugin: kubectl %s (%s)", m.KubectlPlugin, binaryPath))
+
+	if gobin := module.GetGoBinDirectory(); !module.IsOnPath(gobin) {
+		progressHandler("warning", fmt.Sprintf("%s is not on PATH, kubectl will not find the plugin", gobin))
+	}
+}
+
+// warnShadowing reports executables elsewhere on PATH that share the binary name
+func warnShadowing(binary string, progressHandler func(phase, message string)) {
+	conflict := module.FindShadowConflict(binary)
+	if conflict == nil {
+		return
+	}
+
+	if conflict.Shadowed() {
+		winner := conflict.Order[0]
+		progressHandler("warning", fmt.Sprintf("%s is shadowed by %s (%s), which comes earlier in PATH",
+			conflict.Managed.Path, winner.Path, winner.Source))
+
+		return
+	}
+
+	for _, other := range conflict.Order[1:] {
+		progressHandler("warning", fmt.Sprintf("%s now shadows %s (%s)", conflict.Managed.Path, other.Path, other.Source))
+	}
+}
+
+// platformBinDir returns the bin directory of installs for a goos/goarch
+// platform: a directory per platform under base, or base itself for this
+// machine
+func platformBinDir(base, platform string) string {
+	if platform == "" {
+		return module.CustomBinDir(base)
+	}
+
+	return filepath.Join(base, module.PlatformDir(platform))
+}
+
 // parseModulePath extracts the module path and version from the input
 func parseModulePath(input string) (string, string) {
 	// Remove common URL prefixes
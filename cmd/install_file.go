@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/humanize"
+	"github.com/inovacc/glix/internal/manifest"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/spf13/cobra"
+)
+
+// defaultInstallJobs is how many modules a bulk install builds at once
+const defaultInstallJobs = 4
+
+var (
+	installFile string
+	installJobs int
+)
+
+func init() {
+	installCmd.Flags().StringVarP(&installFile, "file", "f", "", `Install the modules listed in a file, one module[@version] per line ("-" reads stdin)`)
+	installCmd.Flags().IntVarP(&installJobs, "jobs", "j", defaultInstallJobs, "Modules installed at once with --file")
+	installCmd.MarkFlagsMutuallyExclusive("file", "as")
+	installCmd.MarkFlagsMutuallyExclusive("file", "kubectl-plugin")
+}
+
+// bulkInstall is one module of a bulk install
+type bulkInstall struct {
+	spec     string // As listed
+	name     string
+	version  string // Installed version
+	err      error
+	duration time.Duration
+	output   []string // Build output, shown when the install fails
+}
+
+// runInstallFile installs every module listed in a file concurrently and
+// prints a summary of the results
+func runInstallFile(ctx context.Context, cmd *cobra.Command, path string) error {
+	// Failures are reported per module; usage would only bury them
+	cmd.SilenceUsage = true
+
+	specs, err := readInstallList(cmd, path)
+	if err != nil {
+		return err
+	}
+
+	if len(specs) == 0 {
+		return fmt.Errorf("the module list is empty")
+	}
+
+	return installModules(ctx, cmd, specs)
+}
+
+// installModules installs modules concurrently, given as module[@version]
+// or local paths, and prints a summary of the results
+func installModules(ctx context.Context, cmd *cobra.Command, specs []string) error {
+	// Start the server once, before the installs race to start it
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	_ = grpcClient.Close()
+
+	jobs := max(1, min(installJobs, len(specs)))
+
+	out := newPlainRenderer(cmd, false)
+	out.Printf("Installing %d module(s), %d at a time\n", len(specs), jobs)
+
+	// Lines of concurrent installs are prefixed with their module
+	width := 0
+	for _, spec := range specs {
+		width = max(width, len(spec))
+	}
+
+	var (
+		mu   sync.Mutex
+		done int
+	)
+
+	results := make([]*bulkInstall, len(specs))
+	sem := make(chan struct{}, jobs)
+
+	var wg sync.WaitGroup
+
+	for i, spec := range specs {
+		results[i] = &bulkInstall{spec: spec}
+
+		wg.Add(1)
+
+		go func(r *bulkInstall) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			installListed(ctx, cmd, r, func(phase, message string) {
+				if quietOutput {
+					out.Progress(phase, r.spec+": "+message)
+					return
+				}
+
+				out.Printf("%-*s  [%s] %s\n", width, r.spec, phase, message)
+			})
+
+			mu.Lock()
+			done++
+			progress := fmt.Sprintf("%d/%d", done, len(specs))
+			mu.Unlock()
+
+			if r.err != nil {
+				out.Printf("%-*s  [failed %s] %v\n", width, r.spec, progress, r.err)
+				return
+			}
+
+			out.Printf("%-*s  [done %s] %s@%s in %s\n", width, r.spec, progress, r.name, r.version, humanize.Duration(r.duration))
+		}(results[i])
+	}
+
+	wg.Wait()
+
+	return out.Finish(printBulkSummary(out, results))
+}
+
+// readInstallList reads the module list of a bulk install from a file or,
+// for "-", stdin
+func readInstallList(cmd *cobra.Command, path string) ([]string, error) {
+	var r io.Reader = cmd.InOrStdin()
+
+	name := "stdin"
+	if path != "-" {
+		name = path
+
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open module list: %w", err)
+		}
+
+		defer func() {
+			_ = f.Close()
+		}()
+
+		r = f
+	}
+
+	specs, err := manifest.ReadList(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return specs, nil
+}
+
+// installListed installs one listed module, recording the outcome in r
+func installListed(ctx context.Context, cmd *cobra.Command, r *bulkInstall, progressHandler func(phase, message string)) {
+	start := time.Now()
+
+	modulePath, version := parseModulePath(r.spec)
+	if module.IsLocalPath(r.spec) {
+		dir, err := module.ResolveLocalPath(r.spec)
+		if err != nil {
+			r.err = err
+			return
+		}
+
+		modulePath, version = dir, ""
+	}
+
+	var mu sync.Mutex
+
+	outputHandler := func(stream, line string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		r.output = append(r.output, line)
+	}
+
+	// Only the start and the outcome of each install are worth a line when
+	// several run at once
+	quietProgress := func(phase, message string) {
+		if phase == "install" || phase == "warning" {
+			progressHandler(phase, message)
+		}
+	}
+
+	m, err := doInstall(ctx, cmd, modulePath, version, quietProgress, outputHandler, func(string) {})
+
+	r.duration = time.Since(start)
+	r.name = modulePath
+
+	if err != nil {
+		r.err = err
+		return
+	}
+
+	r.name, r.version = m.Name, m.Version
+}
+
+// printBulkSummary prints the outcome of every module of a bulk install,
+// with the build output of failed ones, and fails when any install failed
+func printBulkSummary(out *plainRenderer, results []*bulkInstall) error {
+	failed := 0
+
+	for _, r := range results {
+		if r.err == nil || len(r.output) == 0 {
+			continue
+		}
+
+		out.Printf("\nBuild output of %s:\n", r.spec)
+
+		for _, line := range r.output {
+			out.Printf("  %s\n", line)
+		}
+	}
+
+	out.Printf("\n")
+
+	t := newTable(
+		column{Header: "STATUS", Style: eventOutcomeStyle},
+		column{Header: "MODULE", Shrink: true},
+		column{Header: "VERSION"},
+		column{Header: "TIME"},
+		column{Header: "ERROR", Shrink: true, KeepStart: true},
+	)
+
+	for _, r := range results {
+		status, errText := "ok", ""
+
+		if r.err != nil {
+			failed++
+
+			status = "failed"
+			errText = strings.ReplaceAll(r.err.Error(), "\n", " ")
+		}
+
+		t.addRow(status, r.name, r.version, humanize.Duration(r.duration), errText)
+	}
+
+	if !quietOutput {
+		if err := t.write(out.cmd.OutOrStdout()); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d module(s) failed to install", failed, len(results))
+	}
+
+	out.Status(fmt.Sprintf("Installed %d module(s)", len(results)))
+	out.Printf("\nInstalled %d module(s)\n", len(results))
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/protocset"
+	"github.com/spf13/cobra"
+)
+
+// protocSetList lists the available sets instead of installing one
+const protocSetList = "list"
+
+var protocSet string
+
+func init() {
+	installCmd.Flags().StringVar(&protocSet, "protoc-set", "", `Install a compatible set of protoc plugins ("latest", a set name, or "list")`)
+}
+
+// runInstallProtocSet installs every plugin of a set from the embedded
+// compatibility matrix and verifies the installed plugins afterwards
+func runInstallProtocSet(ctx context.Context, cmd *cobra.Command, name string) error {
+	matrix, err := protocset.Load()
+	if err != nil {
+		return err
+	}
+
+	if name == protocSetList {
+		printProtocSets(cmd, matrix)
+		return nil
+	}
+
+	set, err := matrix.Lookup(name)
+	if err != nil {
+		return err
+	}
+
+	tools, err := matrix.Tools(set)
+	if err != nil {
+		return err
+	}
+
+	// Refuse to install a set the matrix itself considers broken
+	if violations := matrix.Check(set.Versions); len(violations) > 0 {
+		return fmt.Errorf("protoc set %s is inconsistent: %s", set.Name, violations[0])
+	}
+
+	cfg := client.DefaultDiscoveryConfig()
+
+	grpcClient, err := client.GetClient(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	out := newPlainRenderer(cmd, false)
+
+	out.Printf("Installing protoc set %s (%s):\n", set.Name, set.Description)
+
+	for _, tool := range tools {
+		out.Printf("  %s@%s\n", tool.Name, tool.Version)
+	}
+
+	out.Printf("\n")
+
+	var failed int
+
+	for _, tool := range tools {
+		out.Printf("[install] %s@%s\n", tool.Module, tool.Version)
+
+		if err := updateModuleCore(ctx, grpcClient, fmt.Sprintf("%s@%s", tool.Module, tool.Version)); err != nil {
+			out.Progress("error", fmt.Sprintf("%s: %v", tool.Name, err))
+
+			failed++
+		}
+	}
+
+	violations, err := checkInstalledProtocPlugins(ctx, grpcClient, matrix)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range violations {
+		out.Progress("warning", fmt.Sprint(v))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("protoc set %s finished with %d error(s)", set.Name, failed)
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("installed protoc plugins are incompatible (%d problem(s))", len(violations))
+	}
+
+	out.Status(fmt.Sprintf("Protoc set %s installed and verified", set.Name))
+	out.Printf("\nProtoc set %s installed and verified\n", set.Name)
+
+	return out.Finish(nil)
+}
+
+// checkInstalledProtocPlugins verifies the tracked protoc plugins against the matrix
+func checkInstalledProtocPlugins(ctx context.Context, grpcClient *client.Client, matrix *protocset.Matrix) ([]protocset.Violation, error) {
+	resp, err := grpcClient.ListModules(ctx, 0, 0, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	installed := make(map[string]string)
+
+	for _, m := range resp.GetModules() {
+		if name, ok := matrix.ToolByModule(m.GetName()); ok {
+			installed[name] = m.GetVersion()
+		}
+	}
+
+	return matrix.Check(installed), nil
+}
+
+func printProtocSets(cmd *cobra.Command, matrix *protocset.Matrix) {
+	cmd.Println("Available protoc sets (newest first):")
+
+	for _, s := range matrix.Sets {
+		cmd.Printf("\n  %s - %s\n", s.Name, s.Description)
+
+		tools, err := matrix.Tools(&s)
+		if err != nil {
+			cmd.Printf("    %v\n", err)
+			continue
+		}
+
+		for _, tool := range tools {
+			cmd.Printf("    %s@%s\n", tool.Name, tool.Version)
+		}
+	}
+}
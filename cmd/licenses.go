@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/module"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// licenseNotDetected stands for licenses that were not detected, in modules
+// installed before license detection or dependencies never downloaded
+const licenseNotDetected = "-"
+
+// licensesCmd represents the licenses command
+var licensesCmd = &cobra.Command{
+	Use:   "licenses [module]",
+	Short: "List the licenses of installed modules and their dependencies",
+	Long: `List the licenses detected at install from the license files of each
+module and its dependencies in the module cache. Output goes to stdout.
+
+Without arguments, every installed module is listed with its license and a
+count of the licenses of its dependencies. Name a module to list the license
+of each of its dependencies.
+
+Licenses are SPDX identifiers; "unknown" marks a license file glix does not
+recognize and "none" a module without one. Modules installed before glix
+detected licenses, and dependencies whose source was never downloaded, show
+"-"; reinstall a module to detect them.
+
+Examples:
+  glix licenses
+  glix licenses github.com/spf13/cobra`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeInstalledModules,
+	SilenceUsage:      true,
+	RunE:              runLicenses,
+}
+
+func init() {
+	rootCmd.AddCommand(licensesCmd)
+}
+
+func runLicenses(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	if len(args) == 0 {
+		resp, err := grpcClient.ListModules(ctx, 0, 0, "")
+		if err != nil {
+			return fmt.Errorf("failed to list modules: %w", err)
+		}
+
+		if len(resp.GetModules()) == 0 {
+			cmd.Println("No modules installed")
+			return nil
+		}
+
+		t := newTable(
+			column{Header: "MODULE", Shrink: true},
+			column{Header: "VERSION"},
+			column{Header: "LICENSE"},
+			column{Header: "DEPENDENCIES", Shrink: true, KeepStart: true},
+		)
+
+		for _, mod := range resp.GetModules() {
+			t.addRow(mod.GetName(), mod.GetVersion(), licenseName(mod.GetLicense()),
+				licenseSummary(module.FlattenDependencies(mod.GetDependencies())))
+		}
+
+		return t.write(cmd.OutOrStdout())
+	}
+
+	modResp, err := grpcClient.GetModule(ctx, args[0], "")
+	if err != nil {
+		return fmt.Errorf("failed to get module: %w", err)
+	}
+
+	if !modResp.GetFound() {
+		return fmt.Errorf("module %s is not installed", args[0])
+	}
+
+	mod := modResp.GetModule()
+
+	depsResp, err := grpcClient.GetDependencies(ctx, mod.GetName(), "")
+	if err != nil {
+		return fmt.Errorf("failed to get dependencies: %w", err)
+	}
+
+	deps := module.FlattenDependencies(depsResp.GetDependencies().GetDependencies())
+
+	w := cmd.OutOrStdout()
+	_, _ = fmt.Fprintf(w, "%s %s: %s\n", mod.GetName(), mod.GetVersion(), licenseName(mod.GetLicense()))
+
+	if len(deps) == 0 {
+		cmd.Printf("No dependencies recorded for %s\n", mod.GetName())
+		return nil
+	}
+
+	_, _ = fmt.Fprintln(w)
+
+	t := newTable(
+		column{Header: "DEPENDENCY", Shrink: true},
+		column{Header: "VERSION"},
+		column{Header: "LICENSE"},
+	)
+
+	for _, dep := range deps {
+		t.addRow(dep.GetName(), dep.GetVersion(), licenseName(dep.GetLicense()))
+	}
+
+	if err := t.write(w); err != nil {
+		return err
+	}
+
+	cmd.Printf("\nDependency licenses: %s\n", licenseSummary(deps))
+
+	return nil
+}
+
+// licenseSummary counts the licenses of deps, most common first, e.g.
+// "12 MIT, 3 Apache-2.0"
+func licenseSummary(deps []*pb.DependencyProto) string {
+	counts := make(map[string]int)
+	for _, dep := range deps {
+		counts[licenseName(dep.GetLicense())]++
+	}
+
+	licenses := make([]string, 0, len(counts))
+	for license := range counts {
+		licenses = append(licenses, license)
+	}
+
+	slices.SortFunc(licenses, func(a, b string) int {
+		return cmp.Or(cmp.Compare(counts[b], counts[a]), cmp.Compare(a, b))
+	})
+
+	parts := make([]string, 0, len(licenses))
+	for _, license := range licenses {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[license], license))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// licenseName returns license, or licenseNotDetected when it is empty
+func licenseName(license string) string {
+	if license == "" {
+		return licenseNotDetected
+	}
+
+	return license
+}
@@ -1,10 +1,20 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/humanize"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/modver"
+	"github.com/inovacc/glix/internal/tui"
+	pb "github.com/inovacc/glix/pkg/api/v1"
 	"github.com/spf13/cobra"
 )
 
@@ -19,7 +29,21 @@ Shows module names, versions, and installation times.
 Examples:
   glix list
   glix list --filter cobra
-  glix list --limit 10`,
+  glix list --limit 10
+  glix list --kubectl-plugins
+  glix list --check         # Annotate rows with the latest available version
+  glix list --security      # Roll up vulnerabilities and flagged licenses
+  glix list --output json   # The installed modules as JSON, for scripts
+
+With --check, latest versions come from the daemon's version cache, which
+queries the module proxy's @latest endpoint only on a miss. No modules are
+downloaded or resolved; use 'glix monitor' for a full check.
+
+With --security, the daemon rolls up what it already knows about each
+module and its dependency tree: the known vulnerabilities found by the last
+'glix audit' scan of the installed version, and the modules whose license is
+missing or warned about or denied by the install policy ('glix policy').
+Nothing is looked up; modules never scanned show "not scanned".`,
 	RunE: runList,
 }
 
@@ -27,6 +51,10 @@ var (
 	listLimit  int32
 	listOffset int32
 	listFilter string
+
+	listKubectlPlugins bool
+	listCheck          bool
+	listSecurity       bool
 )
 
 func init() {
@@ -35,6 +63,10 @@ func init() {
 	listCmd.Flags().Int32VarP(&listLimit, "limit", "l", 0, "Maximum number of modules to show (0 = all)")
 	listCmd.Flags().Int32VarP(&listOffset, "offset", "o", 0, "Number of modules to skip")
 	listCmd.Flags().StringVarP(&listFilter, "filter", "f", "", "Filter modules by name")
+	listCmd.Flags().BoolVarP(&listCheck, "check", "c", false, "Show the latest available version for each module")
+	listCmd.Flags().BoolVar(&listKubectlPlugins, "kubectl-plugins", false, "Show only modules registered as kubectl plugins")
+	listCmd.Flags().BoolVar(&listSecurity, "security", false, "Show vulnerability and flagged license counts across each module's dependency tree")
+	listCmd.MarkFlagsMutuallyExclusive("security", "kubectl-plugins")
 }
 
 func runList(cmd *cobra.Command, args []string) error {
@@ -51,12 +83,36 @@ func runList(cmd *cobra.Command, args []string) error {
 	}()
 
 	// List modules
-	resp, err := grpcClient.ListModules(cmd.Context(), listLimit, listOffset, listFilter)
+	list := grpcClient.ListModules
+	if listSecurity {
+		list = grpcClient.ListModulesSecurity
+	}
+
+	resp, err := list(cmd.Context(), listLimit, listOffset, listFilter)
 	if err != nil {
 		return fmt.Errorf("failed to list modules: %w", err)
 	}
 
 	modules := resp.GetModules()
+
+	if structuredOutput() {
+		if listCheck {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("--check has no %s output; use 'glix outdated --json'", outputFormat)
+		}
+
+		if listKubectlPlugins {
+			plugins := kubectlPlugins(modules)
+			resp = &pb.ListModulesResponse{Modules: plugins, TotalCount: int64(len(plugins))}
+		}
+
+		return printMessage(cmd, resp)
+	}
+
+	if listKubectlPlugins {
+		return printKubectlPlugins(cmd, modules)
+	}
+
 	if len(modules) == 0 {
 		cmd.Println("No modules installed")
 
@@ -67,31 +123,91 @@ func runList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	var latest map[string]*pb.LatestVersionInfo
+
+	if listCheck {
+		latest, err = lookupLatestVersions(cmd.Context(), grpcClient, modules, false)
+		if err != nil {
+			return err
+		}
+	}
+
 	cmd.Println()
 	cmd.Printf("Installed modules (%d):\n", resp.GetTotalCount())
 	cmd.Println()
 
+	columns := []column{
+		{Header: "MODULE", Shrink: true},
+		{Header: "VERSION"},
+	}
+
+	if listCheck {
+		columns = append(columns, column{Header: "LATEST", Shrink: true, KeepStart: true, Style: latestStyle})
+	}
+
+	columns = append(columns,
+		column{Header: "INSTALLED"},
+		column{Header: "DEPS"},
+	)
+
+	if listSecurity {
+		columns = append(columns,
+			column{Header: "VULNS", Style: vulnsStyle},
+			column{Header: "LICENSES", Shrink: true, KeepStart: true, Style: flaggedLicensesStyle},
+		)
+	}
+
+	columns = append(columns,
+		column{Header: "NOTES", Shrink: true, KeepStart: true, Style: func(string) lipgloss.Style { return tui.WarningStyle }},
+	)
+
+	t := newTable(columns...)
+
+	security := make(map[string]*pb.SecuritySummary, len(resp.GetSecurity()))
+	for _, summary := range resp.GetSecurity() {
+		security[summary.GetName()] = summary
+	}
+
 	for _, mod := range modules {
 		// Format installation time
 		installedAt := ""
 
 		if mod.GetTimestampUnixNano() > 0 {
-			t := time.Unix(0, mod.GetTimestampUnixNano())
-			installedAt = t.Format("2006-01-02 15:04")
+			installedAt = humanize.Since(time.Unix(0, mod.GetTimestampUnixNano()))
 		}
 
-		// Count dependencies
-		depCount := len(mod.GetDependencies())
+		row := []string{mod.GetName(), mod.GetVersion()}
+
+		if listCheck {
+			var annotation string
+			if info, ok := latest[mod.GetName()]; ok {
+				annotation = describeLatest(mod.GetVersion(), info)
+			}
+
+			row = append(row, annotation)
+		}
 
-		cmd.Printf("  %s@%s\n", mod.GetName(), mod.GetVersion())
+		row = append(row, installedAt, strconv.Itoa(len(mod.GetDependencies())))
 
-		if installedAt != "" {
-			cmd.Printf("    Installed: %s | Dependencies: %d\n", installedAt, depCount)
+		if listSecurity {
+			row = append(row, describeVulns(security[mod.GetName()]), describeFlaggedLicenses(security[mod.GetName()]))
 		}
+
+		row = append(row, listNotes(mod))
+
+		t.addRow(row...)
+	}
+
+	if err := t.write(cmd.OutOrStdout()); err != nil {
+		return err
 	}
 
 	cmd.Println()
 
+	if listSecurity && slices.ContainsFunc(resp.GetSecurity(), func(s *pb.SecuritySummary) bool { return !s.GetScanned() }) {
+		cmd.Println("Scan the modules not scanned with 'glix audit'")
+	}
+
 	// Show pagination info if applicable
 	if listLimit > 0 && resp.GetTotalCount() > int64(len(modules)) {
 		cmd.Printf("Showing %d of %d modules\n", len(modules), resp.GetTotalCount())
@@ -99,3 +215,206 @@ func runList(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// printKubectlPlugins lists modules registered as kubectl plugins
+func printKubectlPlugins(cmd *cobra.Command, modules []*pb.ModuleProto) error {
+	plugins := kubectlPlugins(modules)
+
+	if len(plugins) == 0 {
+		cmd.Println("No kubectl plugins installed")
+		return nil
+	}
+
+	cmd.Println()
+	cmd.Printf("kubectl plugins (%d):\n", len(plugins))
+	cmd.Println()
+
+	t := newTable(
+		column{Header: "PLUGIN"},
+		column{Header: "MODULE", Shrink: true},
+		column{Header: "VERSION"},
+	)
+
+	for _, mod := range plugins {
+		t.addRow("kubectl "+mod.GetKubectlPlugin(), mod.GetName(), mod.GetVersion())
+	}
+
+	if err := t.write(cmd.OutOrStdout()); err != nil {
+		return err
+	}
+
+	cmd.Println()
+
+	if gobin := module.GetGoBinDirectory(); !module.IsOnPath(gobin) {
+		cmd.Printf("Warning: %s is not on PATH, kubectl will not find these plugins\n", gobin)
+	}
+
+	return nil
+}
+
+// kubectlPlugins returns the modules registered as kubectl plugins
+func kubectlPlugins(modules []*pb.ModuleProto) []*pb.ModuleProto {
+	return slices.DeleteFunc(slices.Clone(modules), func(mod *pb.ModuleProto) bool {
+		return mod.GetKubectlPlugin() == ""
+	})
+}
+
+// lookupLatestVersions fetches latest versions for non-local modules from the
+// daemon, bypassing its cache when refresh is set
+func lookupLatestVersions(ctx context.Context, grpcClient *client.Client, modules []*pb.ModuleProto, refresh bool) (map[string]*pb.LatestVersionInfo, error) {
+	names := make([]string, 0, len(modules))
+
+	for _, mod := range modules {
+		// Local/dev installs have no upstream versions to compare against
+		if mod.GetLocalPath() == "" {
+			names = append(names, mod.GetName())
+		}
+	}
+
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	resp, err := grpcClient.GetLatestVersions(ctx, names, refresh)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest versions: %w", err)
+	}
+
+	latest := make(map[string]*pb.LatestVersionInfo, len(resp.GetVersions()))
+	for _, info := range resp.GetVersions() {
+		latest[info.GetName()] = info
+	}
+
+	return latest, nil
+}
+
+// listNotes summarizes what is special about an installed module: a local
+// install, a kubectl plugin, an alias, a cross-compiled platform, a hold, or
+// versions reported broken
+func listNotes(mod *pb.ModuleProto) string {
+	var notes []string
+
+	if mod.GetLocalPath() != "" {
+		notes = append(notes, "local: "+mod.GetLocalPath())
+	}
+
+	if mod.GetKubectlPlugin() != "" {
+		notes = append(notes, "kubectl "+mod.GetKubectlPlugin())
+	}
+
+	if mod.GetAlias() != "" {
+		notes = append(notes, "as "+mod.GetAlias())
+	}
+
+	if mod.GetPlatform() != "" {
+		notes = append(notes, "for "+mod.GetPlatform())
+	}
+
+	if flags := module.BuildFlagsFromProto(mod.GetBuildFlags()); !flags.IsZero() {
+		notes = append(notes, "built with "+flags.String())
+	}
+
+	if strategy := mod.GetBuildStrategy(); strategy != "" {
+		notes = append(notes, "built by "+strategy)
+	}
+
+	if c := mod.GetVersionConstraint(); c != "" {
+		notes = append(notes, "within "+c)
+	}
+
+	if mod.GetPreferBinary() {
+		notes = append(notes, "prebuilt binaries")
+	}
+
+	if proxy := mod.GetGoproxy(); proxy != "" {
+		notes = append(notes, "via "+proxy)
+	}
+
+	if mod.GetPrerelease() {
+		notes = append(notes, "pre-releases")
+	}
+
+	if mod.GetPrivate() {
+		notes = append(notes, "private")
+	}
+
+	if held := describeHold(mod.GetName()); held != "" {
+		notes = append(notes, held)
+	}
+
+	if bad := mod.GetBadVersions(); len(bad) > 0 {
+		notes = append(notes, "broken: "+strings.Join(bad, ", "))
+	}
+
+	return strings.Join(notes, "; ")
+}
+
+// latestStyle colors a latest version annotation by whether it is an update
+func latestStyle(annotation string) lipgloss.Style {
+	switch {
+	case strings.HasSuffix(annotation, "(update available)"):
+		return tui.SuccessStyle
+	case strings.HasPrefix(annotation, "unknown"):
+		return tui.ErrorStyle
+	default:
+		return lipgloss.NewStyle()
+	}
+}
+
+// describeLatest formats the latest version annotation for a list row
+func describeLatest(installed string, info *pb.LatestVersionInfo) string {
+	if info.GetErrorMessage() != "" {
+		return fmt.Sprintf("unknown (%s)", info.GetErrorMessage())
+	}
+
+	if modver.Default.IsUpdate(info.GetLatestVersion(), installed) {
+		return fmt.Sprintf("%s (update available)", info.GetLatestVersion())
+	}
+
+	return fmt.Sprintf("%s (up to date)", info.GetLatestVersion())
+}
+
+// describeVulns formats the vulnerability count of a security summary, e.g.
+// "2 (1 called)"
+func describeVulns(summary *pb.SecuritySummary) string {
+	switch {
+	case !summary.GetScanned():
+		return "not scanned"
+	case summary.GetCalledVulnerabilities() > 0:
+		return fmt.Sprintf("%d (%d called)", summary.GetVulnerabilities(), summary.GetCalledVulnerabilities())
+	default:
+		return strconv.Itoa(int(summary.GetVulnerabilities()))
+	}
+}
+
+// describeFlaggedLicenses formats the flagged licenses of a security summary,
+// e.g. "3 flagged: GPL-3.0, none"
+func describeFlaggedLicenses(summary *pb.SecuritySummary) string {
+	if summary.GetFlaggedLicenses() == 0 {
+		return "ok"
+	}
+
+	return fmt.Sprintf("%d flagged: %s", summary.GetFlaggedLicenses(), strings.Join(summary.GetFlagged(), ", "))
+}
+
+// vulnsStyle colors a vulnerability count by whether the binary calls the
+// vulnerable code
+func vulnsStyle(vulns string) lipgloss.Style {
+	switch {
+	case strings.HasSuffix(vulns, "called)"):
+		return tui.ErrorStyle
+	case vulns != "0" && vulns != "not scanned":
+		return tui.WarningStyle
+	default:
+		return lipgloss.NewStyle()
+	}
+}
+
+// flaggedLicensesStyle highlights flagged licenses
+func flaggedLicensesStyle(licenses string) lipgloss.Style {
+	if licenses != "ok" {
+		return tui.WarningStyle
+	}
+
+	return lipgloss.NewStyle()
+}
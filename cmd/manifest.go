@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/manifest"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write the installed modules to a manifest file",
+	Long: `Dump the installed module set to a versioned manifest listing each
+module's name, version, and the SHA-256 of its installed binary. The
+manifest can be committed, applied on another machine with 'glix import',
+or used as a CI gate with 'glix verify-manifest'.
+
+The format follows the output file extension (.json for JSON, YAML
+otherwise) unless --format is given. Without --output the manifest is
+written to stdout. Modules installed from local paths cannot be reproduced
+elsewhere and are skipped.
+
+Examples:
+  glix export -o tools.yaml
+  glix export --format json > tools.json
+  glix export -o tools.yaml --no-hash       # Pin versions only`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import <manifest>",
+	Short: "Install and remove modules to match a manifest",
+	Long: `Converge the installed module set on a manifest written by 'glix export':
+missing modules are installed, modules at another version are reinstalled
+at the declared one, and modules not in the manifest are removed unless
+--keep-extra is set.
+
+Afterwards the result is checked like 'glix verify-manifest', without
+counting extra modules. Binary hashes depend on the platform and Go
+toolchain, so export with --no-hash when importing on different machines.
+
+Examples:
+  glix import tools.yaml --dry-run
+  glix import tools.yaml
+  glix import tools.json --keep-extra`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runImport,
+}
+
+var (
+	exportOutput string
+	exportFormat string
+	exportNoHash bool
+
+	importDryRun    bool
+	importKeepExtra bool
+)
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Manifest file to write (default: stdout)")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "", "Manifest format: yaml or json (default: from the file extension)")
+	exportCmd.Flags().BoolVar(&exportNoHash, "no-hash", false, "Omit binary hashes")
+
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Show the changes without applying them")
+	importCmd.Flags().BoolVar(&importKeepExtra, "keep-extra", false, "Do not remove modules missing from the manifest")
+}
+
+func runExport(cmd *cobra.Command, _ []string) error {
+	format := exportFormat
+	if format == "" {
+		format = manifest.FormatFromPath(exportOutput)
+	}
+
+	grpcClient, err := client.GetClient(cmd.Context(), client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	resp, err := grpcClient.ListModules(cmd.Context(), 0, 0, "")
+	if err != nil {
+		return fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	var modules []*pb.ModuleProto
+
+	hash := make(map[string]bool)
+
+	for _, mod := range resp.GetModules() {
+		if mod.GetLocalPath() != "" {
+			cmd.Printf("[skip] %s: installed from %s\n", mod.GetName(), mod.GetLocalPath())
+			continue
+		}
+
+		modules = append(modules, mod)
+		hash[mod.GetName()] = !exportNoHash
+	}
+
+	m := manifest.New(installedEntries(modules, hash))
+
+	var w io.Writer = cmd.OutOrStdout()
+
+	if exportOutput != "" {
+		f, err := os.Create(exportOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create manifest: %w", err)
+		}
+
+		defer func() {
+			_ = f.Close()
+		}()
+
+		w = f
+	}
+
+	if err := m.Encode(w, format); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	if exportOutput != "" {
+		cmd.Printf("Exported %d module(s) to %s\n", len(m.Modules), exportOutput)
+	}
+
+	return nil
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	m, err := manifest.Load(args[0])
+	if err != nil {
+		return err
+	}
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	resp, err := grpcClient.ListModules(ctx, 0, 0, "")
+	if err != nil {
+		return fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	target := make([]*pb.ModuleProto, 0, len(m.Modules))
+	for _, e := range m.Modules {
+		target = append(target, &pb.ModuleProto{Name: e.Name, Version: e.Version})
+	}
+
+	changes := diffModuleSets(resp.GetModules(), target)
+	if len(changes) > 0 {
+		cmd.Printf("Applying %s:\n", args[0])
+		printSnapshotChanges(cmd, changes)
+
+		if importDryRun {
+			return nil
+		}
+
+		cmd.Println()
+
+		if failed := applyModuleChanges(ctx, cmd, grpcClient, changes, importKeepExtra); failed > 0 {
+			return fmt.Errorf("import finished with %d error(s)", failed)
+		}
+
+		if resp, err = grpcClient.ListModules(ctx, 0, 0, ""); err != nil {
+			return fmt.Errorf("failed to list modules: %w", err)
+		}
+	} else if importDryRun {
+		cmd.Printf("Installed modules already match %s\n", args[0])
+		return nil
+	}
+
+	hashed := make(map[string]bool)
+	for _, e := range m.Modules {
+		hashed[e.Name] = e.Hash != ""
+	}
+
+	if drift := manifest.Compare(m, installedEntries(resp.GetModules(), hashed), false); len(drift) > 0 {
+		for _, d := range drift {
+			cmd.Println(d)
+		}
+
+		return fmt.Errorf("%w: %d difference(s)", errManifestDrift, len(drift))
+	}
+
+	cmd.Printf("Installed modules match %s\n", args[0])
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/inovacc/glix/internal/autoupdate"
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/metrics"
+	"github.com/spf13/cobra"
+)
+
+// metricsCmd represents the metrics parent command
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Export metrics about installed tools",
+	Long: `Export glix state as Prometheus metrics for monitoring workstation tool
+hygiene without a long-running daemon.`,
+}
+
+// metricsWriteCmd writes a node_exporter textfile
+var metricsWriteCmd = &cobra.Command{
+	Use:   "write <file>",
+	Short: "Write metrics in the node_exporter textfile format",
+	Long: `Write installed module counts, pending update counts, and the age of the
+last update check to a .prom file for the node_exporter textfile
+collector. The file is replaced atomically; use "-" to print to stdout.
+
+Pending updates reflect the last 'glix monitor' or auto-update check.
+Run this from cron or a systemd timer, optionally after 'glix monitor'.
+
+Examples:
+  glix metrics write /var/lib/node_exporter/textfile/glix.prom
+  glix metrics write -`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMetricsWrite,
+}
+
+func init() {
+	rootCmd.AddCommand(metricsCmd)
+
+	metricsCmd.AddCommand(metricsWriteCmd)
+}
+
+func runMetricsWrite(cmd *cobra.Command, args []string) error {
+	cfg := client.DefaultDiscoveryConfig()
+
+	grpcClient, err := client.GetClient(cmd.Context(), cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	resp, err := grpcClient.ListModules(cmd.Context(), 0, 0, "")
+	if err != nil {
+		return fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	updateCfg, err := autoupdate.LoadConfig(cmd.Context(), grpcClient)
+	if err != nil {
+		return err
+	}
+
+	snapshot := metrics.Snapshot{
+		PendingUpdates:    updateCfg.PendingCount,
+		UpdatesInstalled:  updateCfg.UpdatedCount,
+		LastCheck:         updateCfg.LastCheck,
+		AutoUpdateEnabled: updateCfg.Enabled,
+		Now:               time.Now(),
+	}
+
+	for _, m := range resp.GetModules() {
+		snapshot.Modules = append(snapshot.Modules, metrics.Module{
+			Name:    m.GetName(),
+			Version: m.GetVersion(),
+			Local:   m.GetLocalPath() != "",
+		})
+	}
+
+	if args[0] == "-" {
+		return metrics.Write(cmd.OutOrStdout(), snapshot)
+	}
+
+	if err := metrics.WriteFile(args[0], snapshot); err != nil {
+		return err
+	}
+
+	cmd.Printf("Metrics written to %s\n", args[0])
+
+	return nil
+}
@@ -4,13 +4,20 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/hooks"
 	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/modver"
+	"github.com/inovacc/glix/internal/profiles"
+	"github.com/inovacc/glix/internal/quarantine"
 	"github.com/inovacc/glix/internal/tui"
+	pb "github.com/inovacc/glix/pkg/api/v1"
 	"github.com/spf13/cobra"
 )
 
@@ -29,7 +36,8 @@ available version from the Go proxy and reports any that can be updated.
 
 Examples:
   glix monitor              # Check for updates
-  glix monitor --update     # Check and update all outdated modules`,
+  glix monitor --update     # Check and update all outdated modules
+  glix monitor --output json  # Latest versions as JSON, without progress`,
 	RunE: runMonitor,
 }
 
@@ -44,12 +52,17 @@ type moduleStatus struct {
 	InstalledVersion string
 	LatestVersion    string
 	HasUpdate        bool
+	Retracted        string // Why the author retracted the installed version
 	Error            error
 }
 
 func runMonitor(cmd *cobra.Command, _ []string) error {
 	ctx := cmd.Context()
 
+	if structuredOutput() {
+		return runMonitorStructured(ctx, cmd)
+	}
+
 	if IsTUIEnabled() {
 		return runMonitorWithTUI(ctx)
 	}
@@ -70,7 +83,8 @@ func runMonitorWithTUI(ctx context.Context) error {
 
 	// Run monitor in background
 	go func() {
-		errCh <- doMonitor(tuiCtx, t.ProgressHandler(), t.OutputHandler(), t.SetStatus)
+		_, err := doMonitor(tuiCtx, t.ProgressHandler(), t.OutputHandler(), t.SetStatus)
+		errCh <- err
 	}()
 
 	// Wait for completion
@@ -104,15 +118,49 @@ func runMonitorPlainText(ctx context.Context, cmd *cobra.Command) error {
 		// In plain text mode, status is shown via progress
 	}
 
-	return doMonitor(ctx, progressHandler, outputHandler, statusHandler)
+	_, err := doMonitor(ctx, progressHandler, outputHandler, statusHandler)
+
+	return err
 }
 
+// runMonitorStructured prints the latest version of each module as JSON or
+// YAML, without progress output
+func runMonitorStructured(ctx context.Context, cmd *cobra.Command) error {
+	quiet := func(string, string) {}
+
+	statuses, err := doMonitor(ctx, quiet, quiet, func(string) {})
+	if err != nil {
+		return err
+	}
+
+	resp := &pb.GetLatestVersionsResponse{}
+	checked := time.Now().UnixNano()
+
+	for _, s := range statuses {
+		info := &pb.LatestVersionInfo{
+			Name:            s.Name,
+			LatestVersion:   s.LatestVersion,
+			CheckedUnixNano: checked,
+		}
+
+		if s.Error != nil {
+			info.ErrorMessage = s.Error.Error()
+		}
+
+		resp.Versions = append(resp.Versions, info)
+	}
+
+	return printMessage(cmd, resp)
+}
+
+// doMonitor checks the installed modules for updates, applying them with
+// --update, and returns the status of each module checked
 func doMonitor(
 	ctx context.Context,
 	progressHandler func(phase, message string),
 	outputHandler func(stream, line string),
 	statusHandler func(text string),
-) error {
+) ([]moduleStatus, error) {
 	statusHandler("Checking for updates...")
 
 	// Connect to server
@@ -122,7 +170,7 @@ func doMonitor(
 
 	grpcClient, err := client.GetClient(ctx, cfg)
 	if err != nil {
-		return fmt.Errorf("failed to connect to server: %w", err)
+		return nil, fmt.Errorf("failed to connect to server: %w", err)
 	}
 
 	defer func() {
@@ -134,7 +182,7 @@ func doMonitor(
 
 	resp, err := grpcClient.ListModules(ctx, 0, 0, "")
 	if err != nil {
-		return fmt.Errorf("failed to list modules: %w", err)
+		return nil, fmt.Errorf("failed to list modules: %w", err)
 	}
 
 	modules := resp.GetModules()
@@ -142,12 +190,19 @@ func doMonitor(
 		progressHandler("complete", "No modules installed")
 		statusHandler("No modules installed")
 
-		return nil
+		return nil, nil
 	}
 
 	progressHandler("check", fmt.Sprintf("Checking %d module(s) for updates...", len(modules)))
 	statusHandler(fmt.Sprintf("Checking %d modules...", len(modules)))
 
+	syncDenylist(ctx, progressHandler)
+
+	// Local/dev installs have no upstream versions to compare against
+	modules = slices.DeleteFunc(modules, func(mod *pb.ModuleProto) bool {
+		return mod.GetLocalPath() != ""
+	})
+
 	// Check each module for updates concurrently
 	statuses := make([]moduleStatus, len(modules))
 
@@ -161,17 +216,17 @@ func doMonitor(
 	for i, mod := range modules {
 		wg.Add(1)
 
-		go func(idx int, modName, modVersion string) {
+		go func(idx int, mod *pb.ModuleProto) {
 			defer wg.Done()
 
-			statuses[idx] = checkModuleUpdate(ctx, modName, modVersion)
+			statuses[idx] = checkModuleUpdate(ctx, grpcClient.VersionStore(), mod)
 
 			mu.Lock()
 
 			checked++
-			progressHandler("check", fmt.Sprintf("Checked %d/%d: %s", checked, len(modules), modName))
+			progressHandler("check", fmt.Sprintf("Checked %d/%d: %s", checked, len(modules), mod.GetName()))
 			mu.Unlock()
-		}(i, mod.GetName(), mod.GetVersion())
+		}(i, mod)
 	}
 
 	wg.Wait()
@@ -183,7 +238,16 @@ func doMonitor(
 		errors           []moduleStatus
 	)
 
-	for _, status := range statuses {
+	for i, status := range statuses {
+		// Denied and reported-broken versions are never offered as updates
+		if status.HasUpdate {
+			if reason := excludedReason(status.Name, status.LatestVersion, modules[i].GetBadVersions()); reason != "" {
+				progressHandler("skip", fmt.Sprintf("Ignoring %s@%s: %s", status.Name, status.LatestVersion, reason))
+
+				status.HasUpdate = false
+			}
+		}
+
 		if status.Error != nil {
 			errors = append(errors, status)
 		} else if status.HasUpdate {
@@ -197,8 +261,24 @@ func doMonitor(
 	if len(updatesAvailable) > 0 {
 		progressHandler("result", fmt.Sprintf("%d update(s) available:", len(updatesAvailable)))
 
+		t := newTable(
+			column{Header: "MODULE", Shrink: true},
+			column{Header: "INSTALLED"},
+			column{Header: "LATEST", Style: func(string) lipgloss.Style { return tui.SuccessStyle }},
+			column{Header: "NOTE", Shrink: true, KeepStart: true, Style: func(string) lipgloss.Style { return tui.WarningStyle }},
+		)
+
 		for _, s := range updatesAvailable {
-			outputHandler("stdout", fmt.Sprintf("  %s: %s -> %s", s.Name, s.InstalledVersion, s.LatestVersion))
+			note := describeHold(s.Name)
+			if s.Retracted != "" {
+				note = strings.TrimPrefix(note+"; retracted: "+s.Retracted, "; ")
+			}
+
+			t.addRow(s.Name, s.InstalledVersion, s.LatestVersion, note)
+		}
+
+		for _, line := range t.lines(2) {
+			outputHandler("stdout", line)
 		}
 	}
 
@@ -206,6 +286,14 @@ func doMonitor(
 		progressHandler("result", fmt.Sprintf("%d module(s) up to date", len(upToDate)))
 	}
 
+	if downgrades := excludedInstalls(modules); len(downgrades) > 0 {
+		progressHandler("result", fmt.Sprintf("%d installed version(s) should be downgraded:", len(downgrades)))
+
+		for _, line := range downgrades {
+			outputHandler("stdout", line)
+		}
+	}
+
 	if len(errors) > 0 {
 		progressHandler("result", fmt.Sprintf("%d error(s):", len(errors)))
 
@@ -221,10 +309,17 @@ func doMonitor(
 	statusHandler(summary)
 
 	// If --update flag is set, update all outdated modules
+	var updated int
+
 	if monitorUpdateAll && len(updatesAvailable) > 0 {
 		progressHandler("update", "Updating outdated modules...")
 
 		for _, s := range updatesAvailable {
+			if held := describeHold(s.Name); held != "" {
+				progressHandler("skip", fmt.Sprintf("Skipping %s: %s", s.Name, held))
+				continue
+			}
+
 			progressHandler("update", fmt.Sprintf("Updating %s: %s -> %s", s.Name, s.InstalledVersion, s.LatestVersion))
 			statusHandler(fmt.Sprintf("Updating %s...", s.Name))
 
@@ -232,6 +327,8 @@ func doMonitor(
 				progressHandler("error", fmt.Sprintf("Failed to update %s: %v", s.Name, err))
 			} else {
 				progressHandler("update", fmt.Sprintf("Updated %s to %s", s.Name, s.LatestVersion))
+
+				updated++
 			}
 		}
 
@@ -241,29 +338,66 @@ func doMonitor(
 		progressHandler("complete", "Check complete")
 	}
 
-	return nil
+	// Record the check so metrics and status reflect pending updates
+	if _, err := grpcClient.SetAutoUpdateConfig(ctx, &pb.SetAutoUpdateConfigRequest{
+		RecordCheck: &pb.AutoUpdateCheck{
+			Updated: int64(updated),
+			Pending: int64(len(updatesAvailable) - updated),
+		},
+	}); err != nil {
+		progressHandler("warning", fmt.Sprintf("failed to record check: %v", err))
+	}
+
+	return statuses, nil
 }
 
-// checkModuleUpdate checks if a module has an available update
-func checkModuleUpdate(ctx context.Context, moduleName, installedVersion string) moduleStatus {
+// excludedInstalls describes installed versions that are denied or reported
+// broken, with the version to downgrade to
+func excludedInstalls(modules []*pb.ModuleProto) []string {
+	var lines []string
+
+	for _, mod := range modules {
+		reason := excludedReason(mod.GetName(), mod.GetVersion(), mod.GetBadVersions())
+		if reason == "" {
+			continue
+		}
+
+		policy := modver.Policy{Prereleases: mod.GetPrerelease()}
+
+		target := modver.Newest(mod.GetVersions(), func(v string) bool {
+			return modver.Compare(v, mod.GetVersion()) < 0 && policy.Allows(v, mod.GetVersion()) && excludedReason(mod.GetName(), v, mod.GetBadVersions()) == ""
+		})
+
+		line := fmt.Sprintf("  %s@%s: %s", mod.GetName(), mod.GetVersion(), reason)
+		if target != "" {
+			line += fmt.Sprintf(" (downgrade with 'glix install %s@%s')", mod.GetName(), target)
+		} else {
+			line += " (no allowed earlier version)"
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// checkModuleUpdate checks if an installed module has an available update
+// within its version range, and a pre-release when it opted into them
+func checkModuleUpdate(ctx context.Context, versions module.VersionStore, mod *pb.ModuleProto) moduleStatus {
+	moduleName, installedVersion := mod.GetName(), mod.GetVersion()
+
 	status := moduleStatus{
 		Name:             moduleName,
 		InstalledVersion: installedVersion,
 	}
 
 	// Create a unique working directory
-	cacheDir, err := module.GetApplicationCacheDirectory()
+	workDir, err := module.NewWorkDir("monitor")
 	if err != nil {
 		status.Error = err
 		return status
 	}
 
-	workDir := filepath.Join(cacheDir, fmt.Sprintf("monitor-%d", time.Now().UnixNano()))
-	if err := os.MkdirAll(workDir, 0755); err != nil {
-		status.Error = err
-		return status
-	}
-
 	defer func() {
 		_ = os.RemoveAll(workDir)
 	}()
@@ -275,14 +409,26 @@ func checkModuleUpdate(ctx context.Context, moduleName, installedVersion string)
 		return status
 	}
 
+	if err := profiles.Apply(m, mod.GetProfile()); err != nil {
+		status.Error = err
+		return status
+	}
+
+	m.SetGoProxy(mod.GetGoproxy())
+	m.SetConstraint(mod.GetVersionConstraint())
+	m.SetPrerelease(mod.GetPrerelease())
+	m.SetVersionStore(versions)
+
 	// Fetch latest version info
 	if err := m.FetchModuleInfo(moduleName); err != nil {
 		status.Error = err
 		return status
 	}
 
+	// A retracted install is due a move to the latest version, even a lower one
 	status.LatestVersion = m.Version
-	status.HasUpdate = isNewerVersion(m.Version, installedVersion)
+	status.Retracted = m.RetractedInstall(installedVersion)
+	status.HasUpdate = status.Retracted != "" || m.UpdatePolicy().IsUpdate(m.Version, installedVersion)
 
 	return status
 }
@@ -290,16 +436,11 @@ func checkModuleUpdate(ctx context.Context, moduleName, installedVersion string)
 // updateModuleCore updates a single module (core logic without TUI)
 func updateModuleCore(ctx context.Context, grpcClient *client.Client, moduleName string) error {
 	// Create a unique working directory
-	cacheDir, err := module.GetApplicationCacheDirectory()
+	workDir, err := module.NewWorkDir("update")
 	if err != nil {
 		return err
 	}
 
-	workDir := filepath.Join(cacheDir, fmt.Sprintf("update-%d", time.Now().UnixNano()))
-	if err := os.MkdirAll(workDir, 0755); err != nil {
-		return err
-	}
-
 	defer func() {
 		_ = os.RemoveAll(workDir)
 	}()
@@ -310,11 +451,81 @@ func updateModuleCore(ctx context.Context, grpcClient *client.Client, moduleName
 		return err
 	}
 
+	m.SetVersionStore(grpcClient.VersionStore())
+
+	// The installed record comes first: its profile decides the module cache
+	// the update downloads into
+	var installed *pb.ModuleProto
+
+	name, _ := parseModulePath(moduleName)
+	if resp, err := grpcClient.GetModule(ctx, name, ""); err == nil {
+		installed = resp.GetModule()
+
+		// Aliased binaries stay under their alias, in their bin directory
+		m.Alias = installed.GetAlias()
+		m.SetBinDir(installed.GetBinDir())
+		m.SetRecordedPlatform(installed.GetPlatform())
+		m.SetBuildFlags(module.BuildFlagsFromProto(installed.GetBuildFlags()))
+		m.SetPreferBinary(installed.GetPreferBinary())
+		m.SetPrerelease(installed.GetPrerelease())
+		m.SetShim(installed.GetShimPath() != "")
+		m.SetBuildStrategy(module.BuildStrategyFromRecord(installed.GetBuildStrategy()))
+		m.SetGoProxy(installed.GetGoproxy())
+		m.SetPrivate(installed.GetPrivate())
+		m.SetConstraint(installed.GetVersionConstraint())
+
+		if err := profiles.Apply(m, installed.GetProfile()); err != nil {
+			return err
+		}
+	}
+
+	if err := quarantine.Apply(m); err != nil {
+		return err
+	}
+
+	if err := hooks.Apply(m); err != nil {
+		return err
+	}
+
+	m.SetPreviousVersion(installed.GetVersion())
+
 	// Fetch latest module info
 	if err := m.FetchModuleInfo(moduleName); err != nil {
 		return err
 	}
 
+	// Unpinned updates never move to a denied or reported-broken version
+	if _, version := parseModulePath(moduleName); version == "" && installed != nil {
+		// Moving off a retracted version has no floor
+		floor := installed.GetVersion()
+		if m.RetractedInstall(floor) != "" {
+			floor = ""
+		}
+
+		ok, err := resolveAllowed(m, floor, installed.GetBadVersions(), func(string, string) {})
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return fmt.Errorf("no allowed update for %s", m.Name)
+		}
+	}
+
+	// Refuse updates that would break declared constraints
+	if err := checkUpdateConstraints(ctx, grpcClient, m); err != nil {
+		return err
+	}
+
+	if err := enforcePolicy(ctx, m, func(string, string) {}); err != nil {
+		return err
+	}
+
+	namespace := serverNamespace(ctx, grpcClient)
+	if err := checkOwnership(m.InstallPath(), namespace, func(string, string) {}); err != nil {
+		return err
+	}
+
 	// Output handler (suppress output during batch update)
 	outputHandler := func(stream string, line string) {
 		// Silent update - could add verbose flag later
@@ -322,9 +533,15 @@ func updateModuleCore(ctx context.Context, grpcClient *client.Client, moduleName
 
 	// Install the new version
 	if err := m.InstallModuleWithStreaming(ctx, outputHandler); err != nil {
+		recordFailure(ctx, grpcClient, pb.EventAction_EVENT_ACTION_UPDATE, m.Name, installed.GetVersion(), m.Version, err)
 		return err
 	}
 
+	// Keep kubectl plugin registrations across reinstalls
+	registerKubectlPlugin(m, installed.GetKubectlPlugin() != "", func(string, string) {})
+	claimOwnership(m, namespace, func(string, string) {})
+	writeReceipt(m.Name, m.Version, m.BinaryPath, m.ShimPath, namespace, func(string, string) {})
+
 	// Store updated module info
 	return grpcClient.StoreModule(ctx, m)
 }
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/naming"
+	"github.com/spf13/cobra"
+)
+
+// namingCmd represents the naming command
+var namingCmd = &cobra.Command{
+	Use:   "naming [template]",
+	Short: "Name installed binaries by a template",
+	Long: `Set the template the binaries of new installs are named by, for every
+module or with --module for the modules matching a module path or a
+path.Match pattern such as github.com/acme/*. The rule of the exact module
+path wins over patterns, longer patterns win over shorter ones, and the
+default applies to the other modules.
+
+Placeholders:
+  {name}   the default binary name, e.g. golangci-lint
+  {owner}  the second module path element, e.g. golangci
+  {repo}   the third module path element, e.g. golangci-lint
+  {host}   the first module path element, e.g. github.com
+  {major}  the major version, e.g. v1
+
+The name a template gives is kept as the alias of the module, so updates,
+reinstalls and remove use it as they use names set with --as or 'glix
+alias'. Rules apply to first installs; modules installed before keep their
+names until reinstalled with --name-template or renamed with 'glix alias'.
+
+Without a template, lists the rules.
+
+Examples:
+  glix naming "{name}-{major}"        # Coexist with distro-installed tools
+  glix naming --module "github.com/acme/*" "acme-{name}"
+  glix naming --reset --module "github.com/acme/*"
+  glix naming                         # List rules
+  glix install --name-template "{owner}-{name}" github.com/inovacc/twig`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if namingReset {
+			return cobra.NoArgs(cmd, args)
+		}
+
+		return cobra.MaximumNArgs(1)(cmd, args)
+	},
+	SilenceUsage: true,
+	RunE:         runNaming,
+}
+
+var (
+	namingModule        string
+	namingReset         bool
+	installNameTemplate string
+)
+
+func init() {
+	rootCmd.AddCommand(namingCmd)
+
+	namingCmd.Flags().StringVar(&namingModule, "module", "", "Apply the template to modules matching this path or pattern only")
+	namingCmd.Flags().BoolVar(&namingReset, "reset", false, "Remove the template, restoring default names for new installs")
+
+	installCmd.Flags().StringVar(&installNameTemplate, "name-template", "", "Name the binary by a template such as {name}-{major} (see 'glix naming')")
+	installCmd.MarkFlagsMutuallyExclusive("as", "name-template")
+}
+
+func runNaming(cmd *cobra.Command, args []string) error {
+	store := naming.GetStore()
+
+	if namingReset {
+		if err := store.Unset(namingModule); err != nil {
+			return err
+		}
+
+		cmd.Printf("Removed the naming template of %s\n", describePattern(namingModule))
+
+		return nil
+	}
+
+	if len(args) == 0 {
+		return printNamingRules(cmd)
+	}
+
+	rule := naming.Rule{Pattern: namingModule, Template: args[0]}
+	if err := store.Set(rule); err != nil {
+		return err
+	}
+
+	example, _ := naming.Expand(rule.Template, "github.com/golangci/golangci-lint/cmd/golangci-lint", "v1.64.8")
+	cmd.Printf("Binaries of %s are named %s (e.g. %s)\n", describePattern(namingModule), rule.Template, example)
+
+	return nil
+}
+
+// describePattern names the modules a rule pattern applies to
+func describePattern(pattern string) string {
+	if pattern == "" {
+		return "every module"
+	}
+
+	return pattern
+}
+
+// printNamingRules lists the naming rules
+func printNamingRules(cmd *cobra.Command) error {
+	rules := naming.GetStore().List()
+	if len(rules) == 0 {
+		cmd.Println("No naming templates; binaries get their default names")
+		return nil
+	}
+
+	t := newTable(
+		column{Header: "MODULES", Shrink: true},
+		column{Header: "TEMPLATE"},
+	)
+
+	for _, r := range rules {
+		t.addRow(describePattern(r.Pattern), r.Template)
+	}
+
+	return t.write(cmd.OutOrStdout())
+}
+
+// templateAlias returns the alias a naming template gives the binary of m,
+// and whether a template applies: the one given with --name-template, or on
+// first installs the rule matching the module. A template giving the
+// default name leaves the binary unaliased.
+func templateAlias(m *module.Module, firstInstall bool) (string, bool, error) {
+	template := installNameTemplate
+	if template == "" {
+		if !firstInstall {
+			return "", false, nil
+		}
+
+		rule, ok := naming.GetStore().Lookup(m.Name)
+		if !ok {
+			return "", false, nil
+		}
+
+		template = rule.Template
+	}
+
+	name, err := naming.Expand(template, m.Name, m.Version)
+	if err != nil {
+		return "", false, err
+	}
+
+	if name == module.BinaryName(m.Name) {
+		return "", true, nil
+	}
+
+	return name, true, nil
+}
+
+// validateNameTemplate checks --name-template before anything is fetched
+func validateNameTemplate() error {
+	if installNameTemplate == "" {
+		return nil
+	}
+
+	if err := naming.Validate(installNameTemplate); err != nil {
+		return fmt.Errorf("invalid --name-template: %w", err)
+	}
+
+	if !strings.Contains(installNameTemplate, "{") {
+		return fmt.Errorf("--name-template %q has no placeholder; use --as to pick a fixed name", installNameTemplate)
+	}
+
+	return nil
+}
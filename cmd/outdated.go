@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/hold"
+	"github.com/inovacc/glix/internal/humanize"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/modver"
+	"github.com/inovacc/glix/internal/tui"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// Outdated statuses
+const (
+	outdatedUpdate   = "update available"
+	outdatedUpToDate = "up to date"
+	outdatedHeld     = "held"
+	outdatedExcluded = "excluded"
+	outdatedRange    = "outside range"
+	outdatedUnknown  = "unknown"
+)
+
+// errUpdatesAvailable makes outdated exit non-zero so it can gate CI jobs
+var errUpdatesAvailable = errors.New("updates available")
+
+// outdatedCmd represents the outdated command
+var outdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "List installed modules with newer versions available",
+	Long: `Compare installed versions with the latest versions and print the result
+as a fixed-width table, or as JSON with --json. Output goes to stdout.
+
+Latest versions come from the daemon's version cache, which its
+version-refresh task fills every 30 minutes (see 'glix tasks'), so the
+report is read without waiting for the module proxy. The proxy's @latest
+endpoint is queried only for modules missing from the cache, or for all
+with --refresh. The table notes how long ago the oldest version was
+checked; local installs are skipped.
+Modules that are held, whose latest version is denied or reported broken,
+or whose latest version is outside the range they were installed with (see
+'glix install module@^1.2') are listed but do not count as outdated;
+'glix update' still installs the newest release within a range.
+
+The command exits with status 1 when at least one update is available, so it
+can gate CI jobs.
+
+Examples:
+  glix outdated
+  glix outdated --all             # Include modules that are up to date
+  glix outdated --refresh         # Check the proxy now
+  glix outdated --json | jq '.[].name'`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runOutdated,
+}
+
+var (
+	outdatedJSON    bool
+	outdatedAll     bool
+	outdatedRefresh bool
+)
+
+func init() {
+	rootCmd.AddCommand(outdatedCmd)
+
+	outdatedCmd.Flags().BoolVar(&outdatedJSON, "json", false, "Print results as JSON")
+	outdatedCmd.Flags().BoolVarP(&outdatedAll, "all", "a", false, "Include modules that are up to date")
+	outdatedCmd.Flags().BoolVar(&outdatedRefresh, "refresh", false, "Query the module proxy instead of the daemon's version cache")
+}
+
+// outdatedEntry is one row of the outdated report
+type outdatedEntry struct {
+	Name      string `json:"name"`
+	Installed string `json:"installed"`
+	Latest    string `json:"latest,omitempty"`
+	Status    string `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Checked   string `json:"checked,omitempty"` // When the latest version was checked, RFC 3339
+}
+
+func runOutdated(cmd *cobra.Command, _ []string) error {
+	grpcClient, err := client.GetClient(cmd.Context(), client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	resp, err := grpcClient.ListModules(cmd.Context(), 0, 0, "")
+	if err != nil {
+		return fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	latest, err := lookupLatestVersions(cmd.Context(), grpcClient, resp.GetModules(), outdatedRefresh)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]outdatedEntry, 0, len(latest))
+	updates := 0
+
+	var oldest time.Time
+
+	for _, mod := range resp.GetModules() {
+		info, ok := latest[mod.GetName()]
+		if !ok {
+			continue
+		}
+
+		if checked := time.Unix(0, info.GetCheckedUnixNano()); info.GetCached() && (oldest.IsZero() || checked.Before(oldest)) {
+			oldest = checked
+		}
+
+		entry := classifyOutdated(mod, info)
+		if entry.Status == outdatedUpdate {
+			updates++
+		}
+
+		if outdatedAll || entry.Status != outdatedUpToDate {
+			entries = append(entries, entry)
+		}
+	}
+
+	if outdatedJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+
+		if err := enc.Encode(entries); err != nil {
+			return fmt.Errorf("failed to encode results: %w", err)
+		}
+	} else if len(entries) == 0 {
+		cmd.Println("All modules are up to date")
+	} else {
+		printOutdatedTable(cmd, entries)
+	}
+
+	if !outdatedJSON && !oldest.IsZero() {
+		cmd.Printf("Latest versions checked %s; --refresh checks now\n", humanize.Since(oldest))
+	}
+
+	if updates > 0 {
+		return fmt.Errorf("%w: %d module(s)", errUpdatesAvailable, updates)
+	}
+
+	return nil
+}
+
+// classifyOutdated compares an installed module with its latest version
+func classifyOutdated(mod *pb.ModuleProto, info *pb.LatestVersionInfo) outdatedEntry {
+	entry := outdatedEntry{
+		Name:      mod.GetName(),
+		Installed: mod.GetVersion(),
+		Latest:    info.GetLatestVersion(),
+		Status:    outdatedUpToDate,
+	}
+
+	if info.GetCheckedUnixNano() > 0 {
+		entry.Checked = time.Unix(0, info.GetCheckedUnixNano()).Format(time.RFC3339)
+	}
+
+	switch {
+	case info.GetErrorMessage() != "":
+		entry.Status = outdatedUnknown
+		entry.Detail = info.GetErrorMessage()
+	case !modver.Default.IsUpdate(entry.Latest, entry.Installed):
+	case !module.InRange(mod.GetVersionConstraint(), entry.Latest):
+		entry.Status = outdatedRange
+		entry.Detail = mod.GetVersionConstraint()
+	case excludedReason(entry.Name, entry.Latest, mod.GetBadVersions()) != "":
+		entry.Status = outdatedExcluded
+		entry.Detail = excludedReason(entry.Name, entry.Latest, mod.GetBadVersions())
+	default:
+		if h, ok := hold.GetStore().Get(entry.Name); ok {
+			entry.Status = outdatedHeld
+			entry.Detail = "until " + h.Until.Format("2006-01-02 15:04")
+		} else {
+			entry.Status = outdatedUpdate
+		}
+	}
+
+	return entry
+}
+
+// printOutdatedTable writes entries as an aligned table to stdout
+func printOutdatedTable(cmd *cobra.Command, entries []outdatedEntry) {
+	t := newTable(
+		column{Header: "MODULE", Shrink: true},
+		column{Header: "INSTALLED"},
+		column{Header: "LATEST"},
+		column{Header: "STATUS", Shrink: true, KeepStart: true, Style: outdatedStatusStyle},
+	)
+
+	for _, e := range entries {
+		status := e.Status
+		if e.Detail != "" {
+			status += " (" + e.Detail + ")"
+		}
+
+		t.addRow(e.Name, e.Installed, e.Latest, status)
+	}
+
+	_ = t.write(cmd.OutOrStdout())
+}
+
+// outdatedStatusStyle colors a status cell of the outdated table
+func outdatedStatusStyle(status string) lipgloss.Style {
+	switch {
+	case strings.HasPrefix(status, outdatedUpdate):
+		return tui.SuccessStyle
+	case strings.HasPrefix(status, outdatedHeld), strings.HasPrefix(status, outdatedExcluded), strings.HasPrefix(status, outdatedRange):
+		return tui.WarningStyle
+	case strings.HasPrefix(status, outdatedUnknown):
+		return tui.ErrorStyle
+	default:
+		return lipgloss.NewStyle()
+	}
+}
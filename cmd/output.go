@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// Formats of the global --output flag
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// structuredOutputAnnotation marks read commands that print their protobuf
+// response as JSON or YAML with --output
+const structuredOutputAnnotation = "glix.structured-output"
+
+var outputFormat string
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", outputTable,
+		"Output format of read commands: table, json, or yaml")
+
+	for _, c := range []*cobra.Command{listCmd, reportCmd, monitorCmd, historyCmd, serviceStatusCmd, depsCmd, statsCmd, watchListCmd, tokenCreateCmd, tokenListCmd} {
+		if c.Annotations == nil {
+			c.Annotations = make(map[string]string)
+		}
+
+		c.Annotations[structuredOutputAnnotation] = "true"
+	}
+}
+
+// checkOutputFormat rejects unknown formats, and structured formats for
+// commands that have no structured output
+func checkOutputFormat(cmd *cobra.Command) error {
+	if !slices.Contains([]string{outputTable, outputJSON, outputYAML}, outputFormat) {
+		return fmt.Errorf("invalid --output %q: must be table, json, or yaml", outputFormat)
+	}
+
+	if structuredOutput() && cmd.Annotations[structuredOutputAnnotation] != "true" {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%s has no %s output", cmd.CommandPath(), outputFormat)
+	}
+
+	return nil
+}
+
+// structuredOutput reports whether --output selected JSON or YAML
+func structuredOutput() bool {
+	return outputFormat == outputJSON || outputFormat == outputYAML
+}
+
+// printMessage writes a protobuf message to stdout in the format selected
+// with --output, using the protobuf JSON field names
+func printMessage(cmd *cobra.Command, msg proto.Message) error {
+	data, err := protojson.MarshalOptions{
+		UseProtoNames:   true,
+		EmitUnpopulated: true,
+	}.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	w := cmd.OutOrStdout()
+
+	if outputFormat == outputYAML {
+		var v any
+		if err := json.Unmarshal(data, &v); err != nil {
+			return fmt.Errorf("failed to encode output: %w", err)
+		}
+
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+
+		if err := enc.Encode(v); err != nil {
+			return fmt.Errorf("failed to encode output: %w", err)
+		}
+
+		return enc.Close()
+	}
+
+	// protojson varies its whitespace on purpose; indent it consistently
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	buf.WriteByte('\n')
+
+	_, err = buf.WriteTo(w)
+
+	return err
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/owners"
+	"github.com/spf13/cobra"
+)
+
+// takeOwnership lets install, update and bundle install overwrite a binary
+// that the server of another namespace manages
+var takeOwnership bool
+
+func init() {
+	for _, c := range []*cobra.Command{installCmd, updateCmd, bundleInstallCmd} {
+		c.Flags().BoolVar(&takeOwnership, "take-ownership", false,
+			"Overwrite a binary managed by another namespace and manage it from this one")
+	}
+}
+
+// serverNamespace returns the namespace of the server, or "" when it cannot
+// tell, which leaves ownership unchecked
+func serverNamespace(ctx context.Context, grpcClient *client.Client) string {
+	status, err := grpcClient.GetStatus(ctx)
+	if err != nil {
+		return ""
+	}
+
+	return status.GetNamespace()
+}
+
+// checkOwnership refuses to overwrite the binary at path when the server of
+// another namespace manages it, unless --take-ownership is given
+func checkOwnership(path, namespace string, progressHandler func(phase, message string)) error {
+	owner, conflict := owners.GetStore().Conflict(path, namespace)
+	if !conflict {
+		return nil
+	}
+
+	if takeOwnership {
+		progressHandler("warning", fmt.Sprintf("taking over %s from %s", path, owner))
+		return nil
+	}
+
+	return fmt.Errorf("%s is managed by %s; install to another directory with 'glix install --bin-dir', or overwrite it with --take-ownership", path, owner)
+}
+
+// claimOwnership records the namespace as the manager of the installed
+// binary of m, or of its shim
+func claimOwnership(m *module.Module, namespace string, progressHandler func(phase, message string)) {
+	path := cmp.Or(m.ShimPath, m.BinaryPath)
+	if namespace == "" || path == "" {
+		return
+	}
+
+	err := owners.GetStore().Claim(owners.Owner{
+		Path:      path,
+		Namespace: namespace,
+		Module:    m.Name,
+		Profile:   m.Profile(),
+	})
+	if err != nil {
+		progressHandler("warning", fmt.Sprintf("failed to record the owner of %s: %v", path, err))
+	}
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/inovacc/glix/internal/module"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// pathCmd represents the path command
+var pathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Put the bin directory on PATH",
+	Long: `Make installed binaries run by name by adding their directory to PATH in
+the startup file of your shell.
+
+After every install glix checks that the installed command resolves on
+PATH, and prints the line that fixes it for your shell when its directory
+is missing. Interactive installs offer to add the line right away.
+
+The shell is detected from $SHELL: bash (~/.bashrc, ~/.bash_profile on
+macOS), zsh (~/.zshrc), fish (~/.config/fish/config.fish), PowerShell
+(its profile) and sh (~/.profile) for any other shell.
+
+Examples:
+  glix path add                  # Add GOBIN
+  glix path add ~/.local/bin
+  glix path add --shell zsh --yes`,
+}
+
+var pathAddCmd = &cobra.Command{
+	Use:          "add [dir]",
+	Short:        "Add a directory, GOBIN by default, to PATH in your shell's startup file",
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runPathAdd,
+}
+
+var (
+	pathShell string
+	pathYes   bool
+)
+
+func init() {
+	rootCmd.AddCommand(pathCmd)
+	pathCmd.AddCommand(pathAddCmd)
+
+	pathAddCmd.Flags().StringVar(&pathShell, "shell", "",
+		fmt.Sprintf("Shell to configure: %s (default: detected from $SHELL)", strings.Join(module.Shells, ", ")))
+	pathAddCmd.Flags().BoolVarP(&pathYes, "yes", "y", false, "Edit the startup file without asking")
+}
+
+func runPathAdd(cmd *cobra.Command, args []string) error {
+	dir := module.GetGoBinDirectory()
+	if len(args) > 0 {
+		dir = args[0]
+	}
+
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+
+	shell := pathShell
+	if shell == "" {
+		shell = module.DetectShell()
+	}
+
+	fix, err := module.NewPathFix(shell, dir)
+	if err != nil {
+		return err
+	}
+
+	if fix.Persisted() {
+		cmd.Printf("%s adds %s to PATH already\n", fix.Profile, fix.Dir)
+
+		if !module.IsOnPath(fix.Dir) {
+			cmd.Printf("Start a new shell, or run: %s\n", fix.Line)
+		}
+
+		return nil
+	}
+
+	if !pathYes {
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return fmt.Errorf("not changing %s without confirmation; pass --yes", fix.Profile)
+		}
+
+		if !confirmPathFix(cmd, fix) {
+			return nil
+		}
+	}
+
+	return persistPathFix(cmd, fix)
+}
+
+// confirmPathFix shows the line a fix adds and asks whether to add it
+func confirmPathFix(cmd *cobra.Command, fix module.PathFix) bool {
+	cmd.Printf("Add %s to PATH by appending to %s:\n  %s\n", fix.Dir, fix.Profile, fix.Line)
+
+	return confirm(cmd, "Proceed?")
+}
+
+// confirm asks a yes/no question, defaulting to no
+func confirm(cmd *cobra.Command, question string) bool {
+	cmd.Printf("%s [y/N] ", question)
+
+	answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
+
+// persistPathFix appends the line of a fix to the startup file
+func persistPathFix(cmd *cobra.Command, fix module.PathFix) error {
+	if _, err := fix.Persist(); err != nil {
+		return err
+	}
+
+	cmd.Printf("Added %s to PATH in %s\n", fix.Dir, fix.Profile)
+	cmd.Printf("Start a new shell, or run: %s\n", fix.Line)
+
+	return nil
+}
+
+// commandName returns the name an installed binary is run by
+func commandName(binPath string) string {
+	return strings.TrimSuffix(filepath.Base(binPath), ".exe")
+}
+
+// warnNotOnPath checks that an installed binary runs by name, and warns with
+// the line adding its directory to PATH in the user's shell when it does not.
+// Other executables of the same name winning over it are reported by
+// warnShadowing.
+func warnNotOnPath(binPath string, progressHandler func(phase, message string)) {
+	if binPath == "" {
+		return
+	}
+
+	dir, name := filepath.Dir(binPath), commandName(binPath)
+
+	if module.IsOnPath(dir) {
+		if _, err := exec.LookPath(name); err != nil {
+			progressHandler("warning", fmt.Sprintf("'%s' is not found although %s is on PATH: %v", name, dir, err))
+		}
+
+		return
+	}
+
+	fix, err := module.NewPathFix(module.DetectShell(), dir)
+	if err != nil {
+		progressHandler("warning", fmt.Sprintf("%s is not on PATH, so '%s' is not found", dir, name))
+		return
+	}
+
+	if fix.Persisted() {
+		progressHandler("warning", fmt.Sprintf("%s is not on PATH in this shell, so '%s' is not found; %s adds it, so start a new shell or run: %s",
+			dir, name, fix.Profile, fix.Line))
+
+		return
+	}
+
+	progressHandler("warning", fmt.Sprintf("%s is not on PATH, so '%s' is not found; add this line to %s, or run 'glix path add %s': %s",
+		dir, name, fix.Profile, dir, fix.Line))
+}
+
+// offerPathFix asks to add the directory of an installed binary to PATH
+// when it is missing there and the install runs in a terminal
+func offerPathFix(cmd *cobra.Command, m *module.Module) {
+	if quietOutput || m == nil || m.BinaryPath == "" || m.Platform() != "" {
+		return
+	}
+
+	dir := filepath.Dir(m.BinaryPath)
+	if module.IsOnPath(dir) || !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return
+	}
+
+	fix, err := module.NewPathFix(module.DetectShell(), dir)
+	if err != nil || fix.Persisted() {
+		return
+	}
+
+	if !confirmPathFix(cmd, fix) {
+		return
+	}
+
+	if err := persistPathFix(cmd, fix); err != nil {
+		cmd.Printf("Warning: %v\n", err)
+	}
+}
@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/policy"
+	"github.com/spf13/cobra"
+)
+
+// policyCmd represents the policy parent command
+var policyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Inspect the install-time policy",
+	Long: `A policy is evaluated before every install and update, including
+auto-updates, and allows, warns about or denies the module version. It lives
+in policy.yaml in the glix config directory; without that file everything
+is allowed. Local/dev installs are not subject to the policy.
+
+Inputs: module, version, license (SPDX id from the license file, "none" or
+"unknown"), vulns (known vulnerabilities from OSV.dev, -1 when unknown) and
+source_host (the first element of the module path).
+
+Rules are tried in order and the first match decides; 'default' applies
+when none match. Every condition set on a rule must match, and a list
+matches when any entry does. '*' in patterns matches any characters.
+
+  default: allow
+  rules:
+    - name: internal
+      action: allow
+      source_host: [git.example.com]
+    - name: no-copyleft
+      action: deny
+      license: [GPL-*, AGPL-*]
+      message: copyleft licenses need legal review
+    - name: vulnerable
+      action: deny
+      min_vulns: 1
+    - name: pre-1.0
+      action: warn
+      version: ["<v1.0.0"]
+
+An external Open Policy Agent decision can be added; the stricter of the
+rule and OPA decisions wins. The decision is an action string or an object
+with action and message fields.
+
+  opa:
+    url: http://localhost:8181/v1/data/glix/decision
+    # or, evaluated with the opa CLI:
+    # bundle: /etc/glix/policy-bundle
+    # query: data.glix.decision
+
+Examples:
+  glix policy show
+  glix policy check github.com/example/tool@v0.9.0`,
+}
+
+// policyShowCmd prints the loaded policy
+var policyShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the policy file location and rules",
+	RunE:  runPolicyShow,
+}
+
+// policyCheckCmd evaluates the policy for a module without installing it
+var policyCheckCmd = &cobra.Command{
+	Use:   "check <module[@version]>",
+	Short: "Evaluate the policy for a module version without installing it",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPolicyCheck,
+}
+
+func init() {
+	rootCmd.AddCommand(policyCmd)
+
+	policyCmd.AddCommand(policyShowCmd)
+	policyCmd.AddCommand(policyCheckCmd)
+}
+
+func runPolicyShow(cmd *cobra.Command, _ []string) error {
+	path := policy.DefaultPath()
+
+	p, err := policy.Load(path)
+	if err != nil {
+		return err
+	}
+
+	if p == nil {
+		cmd.Printf("No policy at %s; all installs are allowed\n", path)
+		return nil
+	}
+
+	cmd.Printf("Policy: %s\n", path)
+	cmd.Printf("Default: %s\n", p.Default)
+	cmd.Println()
+
+	for i, r := range p.Rules {
+		cmd.Printf("  %d. %s -> %s\n", i+1, r.Name, r.Action)
+
+		for _, cond := range describeRule(r) {
+			cmd.Printf("       %s\n", cond)
+		}
+	}
+
+	if p.OPA != nil {
+		if p.OPA.URL != "" {
+			cmd.Printf("\nOPA: %s\n", p.OPA.URL)
+		} else {
+			cmd.Printf("\nOPA bundle: %s\n", p.OPA.Bundle)
+		}
+	}
+
+	return nil
+}
+
+// describeRule lists the conditions set on a rule
+func describeRule(r policy.Rule) []string {
+	var conds []string
+
+	for _, c := range []struct {
+		name   string
+		values []string
+	}{
+		{"module", r.Module},
+		{"version", r.Version},
+		{"license", r.License},
+		{"source_host", r.SourceHost},
+	} {
+		if len(c.values) > 0 {
+			conds = append(conds, fmt.Sprintf("%s: %s", c.name, strings.Join(c.values, ", ")))
+		}
+	}
+
+	if r.MinVulns != nil {
+		conds = append(conds, fmt.Sprintf("min_vulns: %d", *r.MinVulns))
+	}
+
+	if r.Message != "" {
+		conds = append(conds, fmt.Sprintf("message: %s", r.Message))
+	}
+
+	return conds
+}
+
+func runPolicyCheck(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	p, err := policy.Load(policy.DefaultPath())
+	if err != nil {
+		return err
+	}
+
+	if p == nil {
+		cmd.Println("No policy configured; all installs are allowed")
+		return nil
+	}
+
+	workDir, err := module.NewWorkDir("policy")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = os.RemoveAll(workDir)
+	}()
+
+	m, err := module.NewModule(ctx, "go", workDir)
+	if err != nil {
+		return fmt.Errorf("failed to create module: %w", err)
+	}
+
+	modulePath, version := parseModulePath(args[0])
+	if version != "" {
+		modulePath += "@" + version
+	}
+
+	if err := m.FetchModuleInfo(modulePath); err != nil {
+		return fmt.Errorf("failed to fetch module info: %w", err)
+	}
+
+	in, warnings := policy.BuildInput(ctx, p, m)
+	for _, w := range warnings {
+		cmd.Printf("Warning: %s\n", w)
+	}
+
+	cmd.Printf("Module:      %s\n", in.Module)
+	cmd.Printf("Version:     %s\n", in.Version)
+	cmd.Printf("Source host: %s\n", in.SourceHost)
+
+	if p.NeedsLicense() {
+		cmd.Printf("License:     %s\n", in.License)
+	}
+
+	if p.NeedsVulns() {
+		cmd.Printf("Vulns:       %d\n", in.Vulns)
+	}
+
+	d, err := p.Evaluate(ctx, in)
+	if err != nil {
+		return fmt.Errorf("policy evaluation failed: %w", err)
+	}
+
+	cmd.Printf("Decision:    %s\n", d)
+
+	return nil
+}
+
+// enforcePolicy evaluates the install policy for a resolved module. A deny
+// decision or a failed evaluation is returned as an error; warnings are
+// reported through progressHandler.
+func enforcePolicy(ctx context.Context, m *module.Module, progressHandler func(phase, message string)) error {
+	p, err := policy.Load(policy.DefaultPath())
+	if err != nil {
+		return err
+	}
+
+	if p == nil || m.LocalPath != "" {
+		return nil
+	}
+
+	progressHandler("policy", "Evaluating install policy...")
+
+	in, warnings := policy.BuildInput(ctx, p, m)
+	for _, w := range warnings {
+		progressHandler("warning", w)
+	}
+
+	d, err := p.Evaluate(ctx, in)
+	if err != nil {
+		return fmt.Errorf("policy evaluation failed: %w", err)
+	}
+
+	switch d.Action {
+	case policy.ActionDeny:
+		return fmt.Errorf("%s@%s blocked by policy (%s)", m.Name, m.Version, d)
+	case policy.ActionWarn:
+		progressHandler("warning", fmt.Sprintf("policy: %s@%s %s", m.Name, m.Version, d))
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/inovacc/glix/internal/humanize"
+	"github.com/inovacc/glix/internal/manifest"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/spf13/cobra"
+)
+
+// prefetchCmd represents the prefetch command
+var prefetchCmd = &cobra.Command{
+	Use:   "prefetch --manifest <manifest>",
+	Short: "Warm the caches for the modules of a manifest without installing them",
+	Long: `Download the module zips of every module in a manifest, with their
+dependencies, and build each one into a temporary directory, so the module
+cache and the Go build cache hold everything 'glix import' needs. Nothing is
+installed to GOBIN or recorded in the database.
+
+Run it ahead of time on a fast connection, or in an early image build stage,
+and a later 'glix import' of the manifest only links the binaries. With
+--download-only, only the module zips are fetched and imports still compile.
+
+Examples:
+  glix prefetch --manifest tools.yaml
+  glix prefetch --manifest tools.yaml --download-only`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runPrefetch,
+}
+
+var (
+	prefetchManifest     string
+	prefetchDownloadOnly bool
+)
+
+func init() {
+	rootCmd.AddCommand(prefetchCmd)
+
+	prefetchCmd.Flags().StringVar(&prefetchManifest, "manifest", "", "Manifest listing the modules to prefetch")
+	prefetchCmd.Flags().BoolVar(&prefetchDownloadOnly, "download-only", false, "Download the modules without building them")
+	_ = prefetchCmd.MarkFlagRequired("manifest")
+}
+
+func runPrefetch(cmd *cobra.Command, _ []string) error {
+	m, err := manifest.Load(prefetchManifest)
+	if err != nil {
+		return err
+	}
+
+	if len(m.Modules) == 0 {
+		cmd.Printf("No modules in %s\n", prefetchManifest)
+		return nil
+	}
+
+	var failed int
+
+	for _, e := range m.Modules {
+		target := fmt.Sprintf("%s@%s", e.Name, e.Version)
+		cmd.Printf("[prefetch] %s\n", target)
+
+		start := time.Now()
+
+		if err := prefetchModule(cmd.Context(), target); err != nil {
+			cmd.Printf("[error] %s: %v\n", e.Name, err)
+
+			failed++
+
+			continue
+		}
+
+		cmd.Printf("[done] %s in %s\n", target, humanize.Duration(time.Since(start)))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("prefetch finished with %d error(s)", failed)
+	}
+
+	cmd.Printf("Prefetched %d module(s) from %s\n", len(m.Modules), prefetchManifest)
+
+	return nil
+}
+
+// prefetchModule downloads a module version with its dependencies and, unless
+// --download-only is set, builds it into a directory that is thrown away
+func prefetchModule(ctx context.Context, target string) error {
+	workDir, err := module.NewWorkDir("prefetch")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = os.RemoveAll(workDir)
+	}()
+
+	m, err := module.NewModule(ctx, "go", workDir)
+	if err != nil {
+		return fmt.Errorf("failed to create module: %w", err)
+	}
+
+	// Resolving downloads the module and its dependencies
+	if err := m.FetchModuleInfo(target); err != nil {
+		return fmt.Errorf("failed to resolve: %w", err)
+	}
+
+	if prefetchDownloadOnly {
+		_, err := m.SourceDir()
+		return err
+	}
+
+	// The build goes the way of an install, filling the same build cache
+	m.SetBinDir(filepath.Join(workDir, "bin"))
+
+	if err := m.InstallModuleWithStreaming(ctx, func(string, string) {}); err != nil {
+		return fmt.Errorf("failed to build: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// privateCmd represents the private parent command
+var privateCmd = &cobra.Command{
+	Use:   "private",
+	Short: "Configure installs of private modules",
+	Long: `Configure how installs reach private modules: modules the public module
+proxy and checksum database cannot serve, such as a company's tools.
+
+Modules matching a pattern added with 'glix private add' are fetched from
+their repository directly, as with GOPRIVATE, and patterns added with
+--nosumdb are still downloaded through the proxy but not checked against
+the checksum database, as with GONOSUMDB. The patterns extend those of the
+environment, and apply to the go commands of every install and update,
+including those the daemon runs. 'glix install --private' marks a single
+module as private instead, and updates keep treating it so.
+
+Credentials:
+  Repositories are fetched with git, which must be able to authenticate
+  without prompting. 'glix private ssh <host>' makes git fetch from a host
+  over SSH, using your SSH keys and agent, instead of HTTPS.
+  'glix private netrc <file>' points go's HTTPS requests, such as those to
+  a private GOPROXY, at a netrc file with credentials.
+
+Installs that fail because access was refused say so, with these commands
+as the way out.
+
+Examples:
+  glix private add "github.com/acme/*"
+  glix private add --nosumdb go.acme.dev
+  glix private ssh github.com
+  glix private netrc ~/.netrc-acme
+  glix private list
+  glix private remove "github.com/acme/*"
+  glix install --private github.com/acme/tool`,
+}
+
+var privateAddCmd = &cobra.Command{
+	Use:          "add <pattern>...",
+	Short:        "Fetch modules matching a pattern from their repository",
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runPrivateAdd,
+}
+
+var privateRemoveCmd = &cobra.Command{
+	Use:          "remove <pattern|host>...",
+	Short:        "Remove private module patterns and SSH hosts",
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runPrivateRemove,
+}
+
+var privateSSHCmd = &cobra.Command{
+	Use:          "ssh <host>...",
+	Short:        "Fetch repositories of a host over SSH instead of HTTPS",
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runPrivateSSH,
+}
+
+var privateNetrcCmd = &cobra.Command{
+	Use:   "netrc <file>",
+	Short: "Use a netrc file for the credentials of go's HTTPS requests",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if privateNetrcReset {
+			return cobra.NoArgs(cmd, args)
+		}
+
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	SilenceUsage: true,
+	RunE:         runPrivateNetrc,
+}
+
+var privateListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List the private module configuration",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runPrivateList,
+}
+
+var (
+	privateNoSumDB    bool
+	privateNetrcReset bool
+)
+
+func init() {
+	rootCmd.AddCommand(privateCmd)
+	privateCmd.AddCommand(privateAddCmd, privateRemoveCmd, privateSSHCmd, privateNetrcCmd, privateListCmd)
+
+	privateAddCmd.Flags().BoolVar(&privateNoSumDB, "nosumdb", false, "Only skip the checksum database, still downloading through the proxy")
+	privateNetrcCmd.Flags().BoolVar(&privateNetrcReset, "reset", false, "Stop using a netrc file of glix's own")
+}
+
+// updatePrivateConfig loads the private module config, applies change and
+// saves it
+func updatePrivateConfig(change func(c *module.PrivateConfig) error) error {
+	c, err := module.LoadPrivateConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := change(&c); err != nil {
+		return err
+	}
+
+	return module.SavePrivateConfig(c)
+}
+
+func runPrivateAdd(cmd *cobra.Command, args []string) error {
+	for _, pattern := range args {
+		if strings.ContainsAny(pattern, ", ") {
+			return fmt.Errorf("invalid pattern %q: give patterns as separate arguments", pattern)
+		}
+
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	err := updatePrivateConfig(func(c *module.PrivateConfig) error {
+		list := &c.Patterns
+		if privateNoSumDB {
+			list = &c.NoSumDB
+		}
+
+		for _, pattern := range args {
+			if !slices.Contains(*list, pattern) {
+				*list = append(*list, pattern)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if privateNoSumDB {
+		cmd.Printf("Modules matching %s are not checked against the checksum database\n", strings.Join(args, ", "))
+	} else {
+		cmd.Printf("Modules matching %s are fetched from their repository\n", strings.Join(args, ", "))
+	}
+
+	return nil
+}
+
+func runPrivateRemove(cmd *cobra.Command, args []string) error {
+	return updatePrivateConfig(func(c *module.PrivateConfig) error {
+		for _, arg := range args {
+			found := false
+
+			for _, list := range []*[]string{&c.Patterns, &c.NoSumDB, &c.SSHHosts} {
+				if i := slices.Index(*list, arg); i != -1 {
+					*list = slices.Delete(*list, i, i+1)
+					found = true
+				}
+			}
+
+			if !found {
+				return fmt.Errorf("%s is neither a private module pattern nor an SSH host", arg)
+			}
+
+			cmd.Printf("Removed %s\n", arg)
+		}
+
+		return nil
+	})
+}
+
+func runPrivateSSH(cmd *cobra.Command, args []string) error {
+	for _, host := range args {
+		if host == "" || strings.ContainsAny(host, "/:@ ") {
+			return fmt.Errorf("invalid host %q: give a host name such as github.com", host)
+		}
+	}
+
+	err := updatePrivateConfig(func(c *module.PrivateConfig) error {
+		for _, host := range args {
+			if !slices.Contains(c.SSHHosts, host) {
+				c.SSHHosts = append(c.SSHHosts, host)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("git fetches from %s over SSH (ssh://git@<host>/...)\n", strings.Join(args, ", "))
+
+	return nil
+}
+
+func runPrivateNetrc(cmd *cobra.Command, args []string) error {
+	if privateNetrcReset {
+		if err := updatePrivateConfig(func(c *module.PrivateConfig) error {
+			c.Netrc = ""
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		cmd.Println("go uses the netrc file of the environment again")
+
+		return nil
+	}
+
+	path, err := filepath.Abs(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", args[0], err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("netrc file not found: %w", err)
+	}
+
+	if err := updatePrivateConfig(func(c *module.PrivateConfig) error {
+		c.Netrc = path
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	cmd.Printf("go reads credentials from %s\n", path)
+
+	if info.Mode().Perm()&0077 != 0 {
+		cmd.Printf("%s\n", tui.WarningStyle.Render(fmt.Sprintf("%s can be read by other users; restrict it with chmod 600", path)))
+	}
+
+	return nil
+}
+
+func runPrivateList(cmd *cobra.Command, _ []string) error {
+	c, err := module.LoadPrivateConfig()
+	if err != nil {
+		return err
+	}
+
+	t := newTable(
+		column{Header: "SETTING"},
+		column{Header: "VALUE", Shrink: true, KeepStart: true},
+	)
+
+	rows := 0
+	add := func(setting string, values ...string) {
+		if len(values) > 0 && values[0] != "" {
+			t.addRow(setting, strings.Join(values, ", "))
+			rows++
+		}
+	}
+
+	add("private (GOPRIVATE)", c.Patterns...)
+	add("no checksum database (GONOSUMDB)", c.NoSumDB...)
+	add("over SSH", c.SSHHosts...)
+	add("netrc", c.Netrc)
+
+	for _, env := range []string{"GOPRIVATE", "GONOPROXY", "GONOSUMDB"} {
+		add(env+" (environment)", os.Getenv(env))
+	}
+
+	if rows == 0 {
+		cmd.Println("No private module configuration; add patterns with 'glix private add <pattern>'")
+		return nil
+	}
+
+	return t.write(cmd.OutOrStdout())
+}
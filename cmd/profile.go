@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/humanize"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/profiles"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// profileCmd represents the profile command
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage install profiles with their own module cache",
+	Long: `Manage install profiles: named module caches that keep the downloads of
+some installs apart from the shared module cache, e.g. a company's modules
+from personal ones.
+
+Modules installed with 'glix install --profile <name>' are downloaded and
+built from the profile's module cache, and updates and reinstalls keep using
+it. A profile can add GOFLAGS to the go commands of its installs; the GOFLAGS
+of the environment still apply.
+
+A profile can also download through a module proxy of its own, such as a
+company's Artifactory or Athens proxy, while other installs keep using the
+public one. --goproxy sets its GOPROXY and --gonosumdb adds the module
+patterns the proxy serves but the checksum database does not know to
+GONOSUMDB. 'glix install --goproxy' picks a proxy for a single module.
+
+Removing a profile deletes its module cache, so everything its installs
+downloaded is gone. The binaries stay installed.
+
+Examples:
+  glix profile add work --goflags=-mod=mod
+  glix profile add corp --goproxy https://athens.corp,direct --gonosumdb 'corp.example.com/*'
+  glix install --profile work github.com/acme/tool
+  glix profile list
+  glix profile remove work`,
+}
+
+var profileAddCmd = &cobra.Command{
+	Use:          "add <name>",
+	Short:        "Add or update a profile",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runProfileAdd,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List profiles with their modules and module cache size",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runProfileList,
+}
+
+var profileRemoveCmd = &cobra.Command{
+	Use:               "remove <name>",
+	Short:             "Remove a profile and delete its module cache",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeProfiles,
+	SilenceUsage:      true,
+	RunE:              runProfileRemove,
+}
+
+var (
+	profileGoFlags   string
+	profileGoProxy   string
+	profileGoNoSumDB []string
+	profileForce     bool
+)
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileAddCmd, profileListCmd, profileRemoveCmd)
+
+	profileAddCmd.Flags().StringVar(&profileGoFlags, "goflags", "", "GOFLAGS to add for the go commands of the profile")
+	profileAddCmd.Flags().StringVar(&profileGoProxy, "goproxy", "", "GOPROXY the profile downloads through, e.g. https://athens.corp,direct")
+	profileAddCmd.Flags().StringSliceVar(&profileGoNoSumDB, "gonosumdb", nil, "Module path patterns to add to GONOSUMDB, served by the proxy but unknown to the checksum database")
+	profileRemoveCmd.Flags().BoolVar(&profileForce, "force", false, "Remove the profile even if installed modules use it")
+}
+
+func runProfileAdd(cmd *cobra.Command, args []string) error {
+	p := profiles.Profile{
+		Name:      args[0],
+		GoFlags:   strings.TrimSpace(profileGoFlags),
+		GoProxy:   strings.TrimSpace(profileGoProxy),
+		GoNoSumDB: profileGoNoSumDB,
+		Created:   time.Now(),
+	}
+
+	if err := profiles.GetStore().Set(p); err != nil {
+		return err
+	}
+
+	cmd.Printf("Profile %s uses the module cache %s\n", p.Name, module.ProfileModCache(p.Name))
+
+	if p.GoProxy != "" {
+		cmd.Printf("Profile %s downloads through %s\n", p.Name, p.GoProxy)
+	}
+
+	return nil
+}
+
+func runProfileList(cmd *cobra.Command, _ []string) error {
+	list := profiles.GetStore().List()
+	if len(list) == 0 {
+		cmd.Println("No profiles; add one with 'glix profile add <name>'")
+		return nil
+	}
+
+	modules, err := listInstalledModules(cmd)
+	if err != nil {
+		return err
+	}
+
+	t := newTable(
+		column{Header: "NAME"},
+		column{Header: "GOFLAGS", Shrink: true, KeepStart: true},
+		column{Header: "GOPROXY", Shrink: true, KeepStart: true},
+		column{Header: "MODULES"},
+		column{Header: "CACHE"},
+	)
+
+	for _, p := range list {
+		goflags := p.GoFlags
+		if goflags == "" {
+			goflags = "-"
+		}
+
+		t.addRow(p.Name, goflags, cmp.Or(p.GoProxy, "-"), strconv.Itoa(len(profileModules(modules, p.Name))),
+			humanize.Size(module.ProfileCacheSize(p.Name)))
+	}
+
+	return t.write(cmd.OutOrStdout())
+}
+
+func runProfileRemove(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	if _, ok := profiles.GetStore().Get(name); !ok {
+		return fmt.Errorf("profile %s not found", name)
+	}
+
+	modules, err := listInstalledModules(cmd)
+	if err != nil {
+		return err
+	}
+
+	if users := profileModules(modules, name); len(users) > 0 && !profileForce {
+		return fmt.Errorf("profile %s is used by %s; remove them first or use --force", name, strings.Join(users, ", "))
+	}
+
+	size := module.ProfileCacheSize(name)
+
+	if err := module.RemoveModCache(cmd.Context(), "go", module.ProfileModCache(name)); err != nil {
+		return fmt.Errorf("failed to delete the module cache of %s: %w", name, err)
+	}
+
+	if err := os.RemoveAll(module.GetProfileDirectory(name)); err != nil {
+		return fmt.Errorf("failed to delete the directory of %s: %w", name, err)
+	}
+
+	if err := profiles.GetStore().Remove(name); err != nil {
+		return err
+	}
+
+	cmd.Printf("Removed profile %s, freed %s\n", name, humanize.Size(size))
+
+	return nil
+}
+
+// listInstalledModules returns the module records of the server
+func listInstalledModules(cmd *cobra.Command) ([]*pb.ModuleProto, error) {
+	grpcClient, err := client.GetClient(cmd.Context(), client.DefaultDiscoveryConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	resp, err := grpcClient.ListModules(cmd.Context(), 0, 0, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	return resp.GetModules(), nil
+}
+
+// profileModules returns the names of the modules installed with a profile
+func profileModules(modules []*pb.ModuleProto, profile string) []string {
+	var names []string
+
+	for _, mod := range modules {
+		if mod.GetProfile() == profile {
+			names = append(names, mod.GetName())
+		}
+	}
+
+	return names
+}
+
+// completeProfiles completes profile names
+func completeProfiles(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for _, p := range profiles.GetStore().List() {
+		names = append(names, p.Name)
+	}
+
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
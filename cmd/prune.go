@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/humanize"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/spf13/cobra"
+)
+
+// pruneCmd represents the prune command
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete orphaned binaries and stale work directories",
+	Long: `Reconcile GOBIN and the cache directory against the database and
+delete what was left behind:
+
+  - binaries in GOBIN built from a module glix installed before that is no
+    longer installed, e.g. after a removal that failed half-way
+  - install-*, update-*, monitor-* and other work directories of runs that
+    crashed or were interrupted, and the cache directories holding them
+
+Binaries are matched by the package path the Go toolchain records in them,
+so binaries of packages glix never managed are left alone. Only modules in
+the event history count as managed; see 'glix history'.
+
+Examples:
+  glix prune --dry-run
+  glix prune
+  glix prune --older-than 0   # Include work directories of recent runs`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runPrune,
+}
+
+var (
+	pruneDryRun    bool
+	pruneOlderThan time.Duration
+)
+
+func init() {
+	rootCmd.AddCommand(pruneCmd)
+
+	pruneCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "Show what would be deleted without deleting it")
+	pruneCmd.Flags().DurationVar(&pruneOlderThan, "older-than", time.Hour, "Only delete work directories of runs started at least this long ago")
+}
+
+func runPrune(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	resp, err := grpcClient.ListModules(ctx, 0, 0, "")
+	if err != nil {
+		return fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	events, err := grpcClient.GetHistory(ctx, "", 0)
+	if err != nil {
+		return err
+	}
+
+	installed := make(map[string]bool, len(resp.GetModules()))
+	for _, mod := range resp.GetModules() {
+		installed[mod.GetName()] = true
+	}
+
+	known := make(map[string]bool, len(events))
+	for _, e := range events {
+		known[e.GetName()] = true
+	}
+
+	binaries, err := module.FindOrphanedBinaries(installed, known)
+	if err != nil {
+		return err
+	}
+
+	workDirs, err := module.FindStaleWorkDirs(pruneOlderThan)
+	if err != nil {
+		return err
+	}
+
+	orphans := append(binaries, workDirs...)
+	if len(orphans) == 0 {
+		cmd.Println("Nothing to prune")
+		return nil
+	}
+
+	var (
+		freed  int64
+		failed int
+	)
+
+	for _, o := range orphans {
+		if pruneDryRun {
+			cmd.Printf("[dry-run] Would remove %s (%s)\n", o.Path, o.Reason)
+		} else if err := module.RemoveOrphan(o); err != nil {
+			cmd.Printf("[warning] %v\n", err)
+
+			failed++
+
+			continue
+		} else {
+			cmd.Printf("[prune] Removed %s (%s)\n", o.Path, o.Reason)
+		}
+
+		freed += o.Size
+	}
+
+	if pruneDryRun {
+		cmd.Printf("\n%d item(s) would be removed, freeing %s\n", len(orphans), humanize.Size(freed))
+		return nil
+	}
+
+	cmd.Printf("\nRemoved %d item(s), freed %s\n", len(orphans)-failed, humanize.Size(freed))
+
+	if failed > 0 {
+		return fmt.Errorf("failed to remove %d item(s)", failed)
+	}
+
+	return nil
+}
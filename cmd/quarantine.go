@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/inovacc/glix/internal/manifest"
+	"github.com/inovacc/glix/internal/quarantine"
+	"github.com/spf13/cobra"
+)
+
+// quarantineCmd represents the quarantine parent command
+var quarantineCmd = &cobra.Command{
+	Use:   "quarantine",
+	Short: "Inspect the scanner built binaries must pass before they are installed",
+	Long: `With a quarantine configured, every install, update, rebuild and bundle
+install builds into a staging directory and submits the binary to a scanner.
+The binary is moved into the bin directory only on a clean verdict; a
+rejection or a failed scan aborts the install and leaves the installed
+version in place. Verdicts are recorded in 'glix history'.
+
+The quarantine lives in quarantine.yaml in the glix config directory;
+without that file binaries are installed without a scan. It names either a
+local scanner command or an ICAP service.
+
+A command gets the binary in place of {binary}, or as its last argument. Exit
+code 0 is clean, infected_exit_codes (default [1]) are rejections, and any
+other exit code fails the install.
+
+  command: [clamscan, --no-summary, "{binary}"]
+  infected_exit_codes: [1]
+  timeout: 5m
+
+An ICAP service (RFC 3507) gets the binary in a RESPMOD request and passes
+it by answering 204 No Content.
+
+  icap: icap://scanner.example.com:1344/avscan
+
+Examples:
+  glix quarantine show
+  glix quarantine scan ./dist/tool`,
+}
+
+// quarantineShowCmd prints the configured scanner
+var quarantineShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the quarantine file location and scanner",
+	RunE:  runQuarantineShow,
+}
+
+// quarantineScanCmd runs the scanner on a file
+var quarantineScanCmd = &cobra.Command{
+	Use:          "scan <file>",
+	Short:        "Scan a file with the configured scanner",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runQuarantineScan,
+}
+
+func init() {
+	rootCmd.AddCommand(quarantineCmd)
+
+	quarantineCmd.AddCommand(quarantineShowCmd)
+	quarantineCmd.AddCommand(quarantineScanCmd)
+}
+
+func runQuarantineShow(cmd *cobra.Command, _ []string) error {
+	path := quarantine.DefaultPath()
+
+	c, err := quarantine.Load(path)
+	if err != nil {
+		return err
+	}
+
+	if c == nil {
+		cmd.Printf("No quarantine at %s; binaries are installed without a scan\n", path)
+		return nil
+	}
+
+	cmd.Printf("Quarantine: %s\n", path)
+
+	if c.ICAP != "" {
+		cmd.Printf("ICAP:       %s\n", c.ICAP)
+	} else {
+		cmd.Printf("Command:    %s\n", strings.Join(c.Command, " "))
+		cmd.Printf("Infected:   exit codes %s\n", strings.Trim(fmt.Sprint(c.InfectedExitCodes), "[]"))
+	}
+
+	cmd.Printf("Timeout:    %s\n", c.Timeout)
+
+	return nil
+}
+
+func runQuarantineScan(cmd *cobra.Command, args []string) error {
+	c, err := quarantine.Load(quarantine.DefaultPath())
+	if err != nil {
+		return err
+	}
+
+	if c == nil {
+		return fmt.Errorf("no quarantine configured at %s", quarantine.DefaultPath())
+	}
+
+	hash, err := manifest.HashFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	result, err := c.Scan(cmd.Context(), args[0])
+	if err != nil {
+		return err
+	}
+
+	verdict := "clean"
+	if !result.GetClean() {
+		verdict = "REJECTED"
+	}
+
+	out := cmd.OutOrStdout()
+	_, _ = fmt.Fprintf(out, "Scanner: %s\n", result.GetScanner())
+	_, _ = fmt.Fprintf(out, "SHA-256: %s\n", hash)
+	_, _ = fmt.Fprintf(out, "Verdict: %s\n", verdict)
+
+	if result.GetDetail() != "" {
+		_, _ = fmt.Fprintf(out, "Detail:  %s\n", result.GetDetail())
+	}
+
+	if !result.GetClean() {
+		return fmt.Errorf("%s was rejected by %s", args[0], result.GetScanner())
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// readmeCmd represents the readme command
+var readmeCmd = &cobra.Command{
+	Use:   "readme <module>[@version]",
+	Short: "Show a module's README in the terminal",
+	Long: `Fetch the README of a module from the module proxy and render its
+Markdown in the terminal. For a package path such as a cmd/ directory, the
+package's own README is preferred over the module root's.
+
+Without a version, the installed version is shown for installed modules and
+the latest version otherwise. READMEs are cached per version, so showing
+one again works offline. Output that is not a terminal gets the raw
+Markdown.
+
+Examples:
+  glix readme github.com/golangci/golangci-lint/cmd/golangci-lint
+  glix readme github.com/spf13/cobra-cli@v1.3.0
+  glix readme github.com/inovacc/twig --raw | less`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runReadme,
+}
+
+// defaultReadmeWidth is the wrap width when the terminal size is unknown
+const defaultReadmeWidth = 80
+
+var (
+	readmeRaw   bool
+	readmeWidth int
+)
+
+func init() {
+	rootCmd.AddCommand(readmeCmd)
+
+	readmeCmd.Flags().BoolVar(&readmeRaw, "raw", false, "Print the Markdown source without rendering")
+	readmeCmd.Flags().IntVar(&readmeWidth, "width", 0, "Wrap width (default: terminal width, at most 100)")
+}
+
+func runReadme(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	target := args[0]
+
+	// Installed modules show the README of the version in use
+	if modulePath, version := parseModulePath(target); version == "" {
+		if grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig()); err == nil {
+			if resp, err := grpcClient.GetModule(ctx, modulePath, ""); err == nil && resp.GetFound() && resp.GetModule().GetLocalPath() == "" {
+				target = modulePath + "@" + resp.GetModule().GetVersion()
+			}
+
+			_ = grpcClient.Close()
+		}
+	}
+
+	workDir, err := module.NewWorkDir("readme")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = os.RemoveAll(workDir)
+	}()
+
+	m, err := module.NewModule(ctx, "go", workDir)
+	if err != nil {
+		return fmt.Errorf("failed to create module: %w", err)
+	}
+
+	r, err := m.FetchReadme(target)
+	if err != nil {
+		return fmt.Errorf("failed to fetch README of %s: %w", target, err)
+	}
+
+	cmd.Printf("%s@%s: %s\n\n", r.Path, r.Version, r.File)
+
+	content := r.Content
+
+	fd := int(os.Stdout.Fd())
+	if !readmeRaw && term.IsTerminal(fd) && isMarkdown(r.File) {
+		width := readmeWidth
+		if width <= 0 {
+			width = defaultReadmeWidth
+			if w, _, err := term.GetSize(fd); err == nil && w > 0 {
+				width = min(w-2, 100)
+			}
+		}
+
+		renderer, err := glamour.NewTermRenderer(glamour.WithAutoStyle(), glamour.WithWordWrap(width))
+		if err != nil {
+			return fmt.Errorf("failed to create Markdown renderer: %w", err)
+		}
+
+		if content, err = renderer.Render(content); err != nil {
+			return fmt.Errorf("failed to render README: %w", err)
+		}
+	}
+
+	_, err = fmt.Fprint(cmd.OutOrStdout(), content)
+
+	return err
+}
+
+// isMarkdown reports whether a README file is Markdown. READMEs without an
+// extension usually are; .txt ones are shown as they are.
+func isMarkdown(file string) bool {
+	return !strings.EqualFold(filepath.Ext(file), ".txt")
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/quarantine"
+	"github.com/spf13/cobra"
+)
+
+// rebuildCmd represents the rebuild command
+var rebuildCmd = &cobra.Command{
+	Use:   "rebuild <module|binary>",
+	Short: "Rebuild an installed module from source and compare the binaries",
+	Long: `Rebuild an installed module from its published source, at the installed
+version and with the toolchain, build flags (-ldflags, -tags, -trimpath, ...)
+and build environment (CGO_ENABLED, GOOS, GOARCH, ...) recorded in the
+installed binary, and compare the result with the binary in GOBIN.
+
+Go builds are reproducible, so a binary built from the published source
+comes out byte for byte identical. A mismatch means the installed binary was
+built from different source, dependencies, or settings than it claims, or
+was modified after it was built.
+
+With --verify, only the comparison is reported, and glix exits with an
+error when the binaries differ, for integrity checks in CI and on sensitive
+machines. Without it, the installed binary is replaced by the rebuilt one
+when they differ.
+
+The recorded toolchain is selected with GOTOOLCHAIN and downloaded if it
+is not installed. Local installs and GoReleaser, make and mage builds
+carry no module version and cannot be rebuilt.
+
+Examples:
+  glix rebuild github.com/golangci/golangci-lint/cmd/golangci-lint --verify
+  glix rebuild golangci-lint --verify
+  glix rebuild golangci-lint          # Replace the binary with a fresh build`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runRebuild,
+}
+
+var rebuildVerify bool
+
+func init() {
+	rootCmd.AddCommand(rebuildCmd)
+
+	rebuildCmd.Flags().BoolVar(&rebuildVerify, "verify", false, "Only compare, failing when the installed binary is not reproducible")
+}
+
+func runRebuild(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	mod, err := findInstalledTool(ctx, grpcClient, args[0])
+	if err != nil {
+		return err
+	}
+
+	if mod.GetLocalPath() != "" {
+		return fmt.Errorf("%s is a local install from %s and cannot be rebuilt from published source", mod.GetName(), mod.GetLocalPath())
+	}
+
+	_, binPath := module.RecordedBinary(mod)
+	if _, err := os.Stat(binPath); err != nil {
+		return fmt.Errorf("installed binary of %s not found: %w", mod.GetName(), err)
+	}
+
+	workDir, err := module.NewWorkDir("rebuild")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = os.RemoveAll(workDir)
+	}()
+
+	m, err := module.NewModule(ctx, "go", workDir)
+	if err != nil {
+		return fmt.Errorf("failed to create module: %w", err)
+	}
+
+	m.SetProgressHandler(func(phase, message string) {
+		cmd.Printf("[%s] %s\n", phase, message)
+	})
+
+	outDir := filepath.Join(workDir, "bin")
+
+	result, err := m.Rebuild(ctx, binPath, outDir, func(stream, line string) {
+		cmd.Printf("  %s\n", line)
+	})
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+
+	_, _ = fmt.Fprintf(out, "Module:     %s@%s\n", result.Package, result.Version)
+	_, _ = fmt.Fprintf(out, "Toolchain:  %s\n", result.GoVersion)
+	_, _ = fmt.Fprintf(out, "Installed:  %s  %s\n", result.InstalledHash, result.BinaryPath)
+	_, _ = fmt.Fprintf(out, "Rebuilt:    %s\n", result.RebuiltHash)
+
+	if result.Reproducible {
+		_, _ = fmt.Fprintln(out, "Result:     reproducible, the installed binary matches the source")
+		return nil
+	}
+
+	_, _ = fmt.Fprintln(out, "Result:     NOT reproducible")
+
+	if len(result.Differences) == 0 {
+		_, _ = fmt.Fprintln(out, "            Build info matches; the binary was modified after it was built")
+	}
+
+	for _, d := range result.Differences {
+		_, _ = fmt.Fprintf(out, "            %s\n", d)
+	}
+
+	if rebuildVerify {
+		return fmt.Errorf("installed binary of %s does not match a rebuild from source", mod.GetName())
+	}
+
+	if err := quarantine.Apply(m); err != nil {
+		return err
+	}
+
+	if err := m.ScanBinary(ctx, result.RebuiltPath); err != nil {
+		return err
+	}
+
+	if err := module.RestoreBinary(result.RebuiltPath, binPath); err != nil {
+		return err
+	}
+
+	cmd.Printf("[rebuild] Replaced %s with the rebuilt binary\n", binPath)
+
+	writeReceipt(mod.GetName(), mod.GetVersion(), binPath, mod.GetShimPath(), serverNamespace(ctx, grpcClient), func(phase, message string) {
+		cmd.Printf("[%s] %s\n", phase, message)
+	})
+
+	// The rebuilt binary is the one 'glix verify' expects from now on
+	if _, err := grpcClient.VerifyBinaries(ctx, []string{mod.GetName()}, true, false); err != nil {
+		return err
+	}
+
+	return nil
+}
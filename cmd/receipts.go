@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/inovacc/glix/internal/receipts"
+)
+
+// writeReceipt records the files installed for a module by the server of
+// namespace, so 'glix remove' can delete them without the database. Modules
+// installed with --shim list their shim too.
+func writeReceipt(name, version, binaryPath, shimPath, namespace string, progressHandler func(phase, message string)) {
+	if namespace == "" || binaryPath == "" {
+		return
+	}
+
+	err := receipts.GetStore().Write(namespace, name, version, receipts.InstalledFiles(binaryPath, shimPath)...)
+	if err != nil {
+		progressHandler("warning", fmt.Sprintf("failed to write the install receipt of %s: %v", name, err))
+	}
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/hold"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/profiles"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// refreshCmd represents the refresh command
+var refreshCmd = &cobra.Command{
+	Use:   "refresh [module|--all]",
+	Short: "Re-resolve the metadata of installed modules without reinstalling",
+	Long: `Resolve the stored metadata of installed modules again and update the
+database, without building or reinstalling anything: the available versions
+and root module, the dependencies, go.sum hashes and license of the
+installed version, and the daemon's cached latest version.
+
+When the package a module was installed from no longer builds a binary at
+the latest version, as after a repository renamed its cmd directory, the
+CLI replacing it is discovered and the record (with its hold) moves to the
+new package path, so 'glix update' finds it again. A newer major version,
+which is a module of its own, is only reported.
+
+Local installs are skipped.
+
+Examples:
+  glix refresh golangci-lint
+  glix refresh github.com/golangci/golangci-lint/cmd/golangci-lint
+  glix refresh --all`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeInstalledModules,
+	SilenceUsage:      true,
+	RunE:              runRefresh,
+}
+
+var refreshAll bool
+
+func init() {
+	rootCmd.AddCommand(refreshCmd)
+
+	refreshCmd.Flags().BoolVar(&refreshAll, "all", false, "Refresh every installed module")
+}
+
+func runRefresh(cmd *cobra.Command, args []string) error {
+	switch {
+	case refreshAll && len(args) > 0:
+		return fmt.Errorf("--all refreshes every module; drop %q or --all", args[0])
+	case !refreshAll && len(args) == 0:
+		return fmt.Errorf("requires a module, or --all to refresh every module")
+	}
+
+	ctx := cmd.Context()
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	var modules []*pb.ModuleProto
+
+	if refreshAll {
+		resp, err := grpcClient.ListModules(ctx, 0, 0, "")
+		if err != nil {
+			return fmt.Errorf("failed to list modules: %w", err)
+		}
+
+		modules = resp.GetModules()
+	} else {
+		mod, err := findInstalledTool(ctx, grpcClient, args[0])
+		if err != nil {
+			return err
+		}
+
+		modules = []*pb.ModuleProto{mod}
+	}
+
+	var (
+		names  []string
+		failed int
+	)
+
+	for i, mod := range modules {
+		if refreshAll {
+			cmd.Printf("[%d/%d] %s\n", i+1, len(modules), mod.GetName())
+		}
+
+		if mod.GetLocalPath() != "" {
+			cmd.Printf("  Skipped: installed from %s\n", mod.GetLocalPath())
+			continue
+		}
+
+		name, err := refreshModule(ctx, cmd, grpcClient, mod)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			cmd.Printf("  Failed: %v\n", err)
+
+			failed++
+
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	// The daemon's cached latest versions are queried again as well
+	if len(names) > 0 {
+		if _, err := grpcClient.GetLatestVersions(ctx, names, true); err != nil {
+			cmd.Printf("warning: failed to refresh latest versions: %v\n", err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d module(s) failed to refresh", failed, len(modules))
+	}
+
+	return nil
+}
+
+// refreshModule refreshes the record of one installed module, prints what
+// changed, and returns the name it is stored under
+func refreshModule(ctx context.Context, cmd *cobra.Command, grpcClient *client.Client, mod *pb.ModuleProto) (string, error) {
+	workDir, err := module.NewWorkDir("refresh")
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = os.RemoveAll(workDir)
+	}()
+
+	m, err := module.NewModule(ctx, "go", workDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to create module: %w", err)
+	}
+
+	// A batch only reports discoveries and problems
+	m.SetProgressHandler(func(phase, message string) {
+		if !refreshAll || phase == "discover" || phase == "warning" {
+			cmd.Printf("  [%s] %s\n", phase, message)
+		}
+	})
+
+	m.SetGoProxy(mod.GetGoproxy())
+	m.SetPrivate(mod.GetPrivate())
+	m.SetOffline(offline())
+	m.SetVersionStore(grpcClient.VersionStore())
+
+	if err := profiles.Apply(m, mod.GetProfile()); err != nil {
+		return "", err
+	}
+
+	res, err := m.Refresh(mod)
+	if err != nil {
+		return "", err
+	}
+
+	if err := grpcClient.RefreshModule(ctx, res.Record, res.MovedFrom); err != nil {
+		return "", err
+	}
+
+	name := res.Record.GetName()
+
+	if res.MovedFrom != "" {
+		moveHold(res.MovedFrom, name)
+		cmd.Printf("  Moved: %s -> %s\n", res.MovedFrom, name)
+	}
+
+	for _, change := range res.Changes {
+		cmd.Printf("  %s\n", change)
+	}
+
+	if len(res.Changes) == 0 {
+		cmd.Printf("  Up to date\n")
+	}
+
+	for _, warning := range res.Warnings {
+		cmd.Printf("  warning: %s\n", warning)
+	}
+
+	if res.NewerMajor != "" {
+		cmd.Printf("  New major version: install it with 'glix install %s'\n", res.NewerMajor)
+	}
+
+	return name, nil
+}
+
+// moveHold keeps the hold of a module whose package moved
+func moveHold(from, to string) {
+	store := hold.GetStore()
+
+	h, ok := store.Get(from)
+	if !ok {
+		return
+	}
+
+	h.Module = to
+
+	if err := store.Set(h); err == nil {
+		_ = store.Release(from)
+	}
+}
@@ -3,11 +3,9 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
 
 	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/remove"
 	"github.com/inovacc/glix/internal/tui"
 	"github.com/spf13/cobra"
 )
@@ -19,15 +17,35 @@ var removeCmd = &cobra.Command{
 	Long: `Remove a previously installed Go module by deleting its binary
 from GOBIN and removing its entry from the database.
 
+With a version, only that version is removed. When it is the installed
+version, the binary on disk is checked to be a build of it (as 'go version
+-m' shows) before it is deleted; a binary built from another version, for
+example by a go install outside glix, is left alone unless --force is
+given. Other versions glix tracks in the install history (see 'glix
+rollback --list') are removed from the history along with their cached
+binary, keeping the installed version.
+
+Every install writes a receipt listing the files glix created for the
+tool (see 'Install Receipts' in the README). Remove deletes the files of the
+receipt, leaving those changed since, unless --force is given. A module the
+database no longer knows is removed from its receipt alone. Modules
+installed with --shim lose their shim and every stored version.
+
 Example:
   glix remove github.com/inovacc/twig
-  glix remove github.com/inovacc/twig@v1.0.0`,
-	Args: cobra.ExactArgs(1),
-	RunE: runRemove,
+  glix remove github.com/inovacc/twig@v1.0.0
+  glix remove github.com/inovacc/twig@v1.0.0 --force`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeInstalledModules,
+	RunE:              runRemove,
 }
 
+var removeForce bool
+
 func init() {
 	rootCmd.AddCommand(removeCmd)
+
+	removeCmd.Flags().BoolVar(&removeForce, "force", false, "Delete the binary even if it is not a build of the given version or changed since it was installed")
 }
 
 func runRemove(cmd *cobra.Command, args []string) error {
@@ -75,23 +93,16 @@ func runRemoveWithTUI(ctx context.Context, modulePath, version string) error {
 }
 
 func runRemovePlainText(ctx context.Context, cmd *cobra.Command, modulePath, version string) error {
-	cmd.Printf("Removing module: %s", modulePath)
+	// Removes are quick; the progress lines say enough without status lines
+	r := newPlainRenderer(cmd, false)
 
 	if version != "" {
-		cmd.Printf("@%s", version)
+		r.Printf("Removing module: %s@%s\n", modulePath, version)
+	} else {
+		r.Printf("Removing module: %s\n", modulePath)
 	}
 
-	cmd.Println()
-
-	progressHandler := func(phase, message string) {
-		cmd.Printf("[%s] %s\n", phase, message)
-	}
-
-	statusHandler := func(text string) {
-		// In plain text mode, we don't need a separate status line
-	}
-
-	return doRemove(ctx, modulePath, version, progressHandler, statusHandler)
+	return r.Finish(doRemove(ctx, modulePath, version, r.Progress, r.Status))
 }
 
 func doRemove(
@@ -102,49 +113,7 @@ func doRemove(
 ) error {
 	statusHandler(fmt.Sprintf("Removing %s", modulePath))
 
-	// Try to remove binary from GOBIN
-	progressHandler("binary", "Removing binary from GOBIN...")
-
-	binaryName := filepath.Base(modulePath)
-	if idx := strings.LastIndex(binaryName, "/"); idx != -1 {
-		binaryName = binaryName[idx+1:]
-	}
-
-	gobin := os.Getenv("GOBIN")
-	if gobin == "" {
-		gopath := os.Getenv("GOPATH")
-		if gopath == "" {
-			home, _ := os.UserHomeDir()
-			gopath = filepath.Join(home, "go")
-		}
-
-		gobin = filepath.Join(gopath, "bin")
-	}
-
-	// Try common binary extensions
-	binaryRemoved := false
-
-	extensions := []string{"", ".exe"}
-	for _, ext := range extensions {
-		binaryPath := filepath.Join(gobin, binaryName+ext)
-		if _, err := os.Stat(binaryPath); err == nil {
-			if err := os.Remove(binaryPath); err != nil {
-				progressHandler("warning", fmt.Sprintf("failed to remove binary %s: %v", binaryPath, err))
-			} else {
-				progressHandler("binary", fmt.Sprintf("Removed: %s", binaryPath))
-
-				binaryRemoved = true
-			}
-
-			break
-		}
-	}
-
-	if !binaryRemoved {
-		progressHandler("binary", "Binary not found in GOBIN")
-	}
-
-	// Try to use the gRPC client to remove from database
+	// Connect to server (starts on-demand server if needed)
 	progressHandler("database", "Connecting to server...")
 
 	cfg := client.DefaultDiscoveryConfig()
@@ -158,20 +127,7 @@ func doRemove(
 		_ = grpcClient.Close()
 	}()
 
-	// Remove from database
-	progressHandler("database", "Removing from database...")
+	opts := remove.Options{Module: modulePath, Version: version, Force: removeForce}
 
-	resp, err := grpcClient.Remove(ctx, modulePath, version)
-	if err != nil {
-		return fmt.Errorf("failed to remove module from database: %w", err)
-	}
-
-	if !resp.GetSuccess() {
-		return fmt.Errorf("failed to remove module: %s", resp.GetErrorMessage())
-	}
-
-	progressHandler("complete", "Module removed successfully")
-	statusHandler(fmt.Sprintf("Removed %s", modulePath))
-
-	return nil
+	return remove.Run(ctx, grpcClient.Service(), opts, progressHandler, statusHandler)
 }
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+// quietOutput makes install, update, remove, and build print only their
+// result
+var quietOutput bool
+
+func init() {
+	for _, c := range []*cobra.Command{installCmd, updateCmd, removeCmd, buildCmd} {
+		c.Flags().BoolVarP(&quietOutput, "quiet", "q", false,
+			"Print only the result line; warnings go to stderr and failures set the exit code")
+	}
+}
+
+// plainRenderer prints the progress of install, update, and remove as plain
+// text. With --quiet it drops progress and build output, sends warnings and
+// errors to stderr, and prints only the last status, the result of the command, once
+// it succeeded.
+type plainRenderer struct {
+	cmd        *cobra.Command
+	showStatus bool
+
+	mu     sync.Mutex
+	result string
+}
+
+// newPlainRenderer returns a renderer for cmd; showStatus prints status
+// changes as lines of their own
+func newPlainRenderer(cmd *cobra.Command, showStatus bool) *plainRenderer {
+	return &plainRenderer{cmd: cmd, showStatus: showStatus}
+}
+
+// Printf prints a line of progress
+func (r *plainRenderer) Printf(format string, args ...any) {
+	if quietOutput {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cmd.Printf(format, args...)
+}
+
+// Progress prints a step of the command
+func (r *plainRenderer) Progress(phase, message string) {
+	if quietOutput {
+		if phase == "warning" || phase == "error" {
+			_, _ = fmt.Fprintf(r.cmd.ErrOrStderr(), "%s: %s\n", phase, message)
+		}
+
+		return
+	}
+
+	r.Printf("[%s] %s\n", phase, message)
+}
+
+// Output prints a line of build output
+func (r *plainRenderer) Output(stream, line string) {
+	if quietOutput {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stream == "stderr" {
+		_, _ = fmt.Fprintln(r.cmd.ErrOrStderr(), line)
+	} else {
+		_, _ = fmt.Fprintln(r.cmd.OutOrStdout(), line)
+	}
+}
+
+// Status records the status of the command, the last of which is its result
+func (r *plainRenderer) Status(text string) {
+	r.mu.Lock()
+	r.result = text
+	r.mu.Unlock()
+
+	if r.showStatus {
+		r.Printf("Status: %s\n", text)
+	}
+}
+
+// Finish prints the result to stdout with --quiet when the command
+// succeeded, and returns err
+func (r *plainRenderer) Finish(err error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !quietOutput {
+		return err
+	}
+
+	// The error alone says what went wrong
+	if err != nil {
+		r.cmd.SilenceUsage = true
+		return err
+	}
+
+	if r.result != "" {
+		_, _ = fmt.Fprintln(r.cmd.OutOrStdout(), r.result)
+	}
+
+	return err
+}
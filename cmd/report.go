@@ -1,10 +1,16 @@
 package cmd
 
 import (
+	"cmp"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/humanize"
+	pb "github.com/inovacc/glix/pkg/api/v1"
 	"github.com/spf13/cobra"
 )
 
@@ -14,13 +20,27 @@ var reportCmd = &cobra.Command{
 	Short: "Show details about an installed module",
 	Long: `Display detailed information about an installed Go module.
 
-Shows the module name, version, installation time, and dependencies.
+Shows the module name, version, installation time, dependencies, and the
+vulnerabilities found by the last govulncheck scan (see 'glix audit').
+
+The build section shows how the installed binary was built, as 'go version
+-m' reports it at install time: the Go toolchain, the VCS revision and
+whether the working copy was modified, the binary size, and build settings
+such as -ldflags, CGO_ENABLED, GOOS and GOARCH. Modules installed before
+glix recorded it have no build section until they are reinstalled.
+
+The module zip hash is the go.sum hash (h1:) of the module source glix
+built from, hashed from the downloaded zip. The binary hash is the one
+'glix verify' checks the installed binary against, in the algorithm
+GLIX_HASH_ALGORITHM named at install (sha256 by default).
 
 Examples:
   glix report github.com/inovacc/twig
-  glix report github.com/spf13/cobra`,
-	Args: cobra.ExactArgs(1),
-	RunE: runReport,
+  glix report github.com/spf13/cobra
+  glix report github.com/spf13/cobra --output yaml`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeInstalledModules,
+	RunE:              runReport,
 }
 
 var reportVersion string
@@ -52,6 +72,10 @@ func runReport(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get module: %w", err)
 	}
 
+	if structuredOutput() {
+		return printMessage(cmd, resp)
+	}
+
 	if !resp.GetFound() {
 		cmd.Printf("Module %q not found in database\n", moduleName)
 
@@ -71,11 +95,23 @@ func runReport(cmd *cobra.Command, args []string) error {
 
 	if mod.GetTimestampUnixNano() > 0 {
 		installedAt := time.Unix(0, mod.GetTimestampUnixNano())
-		cmd.Printf("Installed: %s\n", installedAt.Format(time.RFC3339))
+		cmd.Printf("Installed: %s (%s)\n", installedAt.Format(time.RFC3339), humanize.Since(installedAt))
+	}
+
+	if mod.GetLocalPath() != "" {
+		cmd.Printf("Source: %s (local install)\n", mod.GetLocalPath())
+	}
+
+	if mod.GetLicense() != "" {
+		cmd.Printf("License: %s\n", mod.GetLicense())
 	}
 
 	if mod.GetHash() != "" {
-		cmd.Printf("Hash: %s\n", mod.GetHash())
+		cmd.Printf("Module zip hash: %s\n", mod.GetHash())
+	}
+
+	if mod.GetBinaryHash() != "" {
+		cmd.Printf("Binary hash: %s\n", mod.GetBinaryHash())
 	}
 
 	if len(mod.GetVersions()) > 0 {
@@ -88,6 +124,12 @@ func runReport(cmd *cobra.Command, args []string) error {
 		cmd.Printf("Latest versions: %v\n", versions[:showCount])
 	}
 
+	printProvenance(cmd, mod.GetProvenance())
+
+	if reports, err := grpcClient.ListVulnReports(cmd.Context(), mod.GetName()); err == nil && len(reports) > 0 {
+		printVulnReport(cmd, mod, reports[0])
+	}
+
 	// Show dependencies
 	deps := mod.GetDependencies()
 	if len(deps) > 0 {
@@ -104,3 +146,39 @@ func runReport(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// printProvenance shows how the installed binary of a module was built
+func printProvenance(cmd *cobra.Command, p *pb.BuildProvenanceProto) {
+	if p == nil {
+		return
+	}
+
+	cmd.Println("\nBuild:")
+	cmd.Printf("  Go: %s\n", p.GetGoVersion())
+
+	if revision := p.GetVcsRevision(); revision != "" {
+		details := []string{cmp.Or(p.GetVcs(), "vcs")}
+		if t := p.GetVcsTime(); t != "" {
+			details = append(details, t)
+		}
+
+		if p.GetVcsModified() {
+			details = append(details, "modified")
+		}
+
+		cmd.Printf("  Revision: %s (%s)\n", revision, strings.Join(details, ", "))
+	}
+
+	cmd.Printf("  Size: %s\n", humanize.Size(p.GetBinarySize()))
+
+	if settings := p.GetSettings(); len(settings) > 0 {
+		cmd.Println("  Settings:")
+
+		for _, key := range slices.Sorted(maps.Keys(settings)) {
+			// Unset variables such as CGO_CFLAGS say nothing
+			if settings[key] != "" {
+				cmd.Printf("    %s=%s\n", key, settings[key])
+			}
+		}
+	}
+}
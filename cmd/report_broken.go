@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/modver"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// reportBrokenCmd represents the report-broken command
+var reportBrokenCmd = &cobra.Command{
+	Use:   "report-broken <tool>",
+	Short: "Mark the installed version as bad and roll back",
+	Long: `Report that the installed version of a tool is broken. The version is
+recorded as bad, the previous version is reinstalled, and update, monitor
+--update and auto-update never move the module to a bad version again.
+
+The tool may be given by module path or by binary name. The rollback target
+is the version installed before the current one; when that is unknown or
+also bad, the newest release older than the bad version is used.
+
+Examples:
+  glix report-broken github.com/inovacc/twig
+  glix report-broken twig
+  glix report-broken twig --no-rollback   # Only record the bad version`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReportBroken,
+}
+
+var reportBrokenNoRollback bool
+
+func init() {
+	rootCmd.AddCommand(reportBrokenCmd)
+
+	reportBrokenCmd.Flags().BoolVar(&reportBrokenNoRollback, "no-rollback", false, "Record the bad version without reinstalling the previous one")
+}
+
+func runReportBroken(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg := client.DefaultDiscoveryConfig()
+
+	grpcClient, err := client.GetClient(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	mod, err := findInstalledTool(ctx, grpcClient, args[0])
+	if err != nil {
+		return err
+	}
+
+	if mod.GetLocalPath() != "" {
+		return fmt.Errorf("module %q was installed from %s and has no versions to roll back to", mod.GetName(), mod.GetLocalPath())
+	}
+
+	badVersion := mod.GetVersion()
+
+	mod, err = grpcClient.MarkBadVersion(ctx, mod.GetName(), badVersion)
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("Marked %s@%s as broken; it will not be installed by updates\n", mod.GetName(), badVersion)
+
+	if reportBrokenNoRollback {
+		return nil
+	}
+
+	target := rollbackTarget(mod)
+	if target == "" {
+		return fmt.Errorf("no earlier good version of %s to roll back to", mod.GetName())
+	}
+
+	cmd.Printf("Rolling back %s: %s -> %s\n", mod.GetName(), badVersion, target)
+
+	history, err := grpcClient.GetInstallHistory(ctx, mod.GetName())
+	if err != nil {
+		return err
+	}
+
+	if err := rollbackModule(ctx, cmd, grpcClient, mod, target, history); err != nil {
+		return fmt.Errorf("rollback failed: %w", err)
+	}
+
+	cmd.Printf("Rolled back %s to %s\n", mod.GetName(), target)
+
+	return nil
+}
+
+// findInstalledTool resolves a module path or binary name to an installed module
+func findInstalledTool(ctx context.Context, grpcClient *client.Client, tool string) (*pb.ModuleProto, error) {
+	modulePath, _ := parseModulePath(tool)
+
+	resp, err := grpcClient.GetModule(ctx, modulePath, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query module: %w", err)
+	}
+
+	if resp.GetFound() {
+		return resp.GetModule(), nil
+	}
+
+	list, err := grpcClient.ListModules(ctx, 0, 0, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	for _, mod := range list.GetModules() {
+		if name, _ := module.RecordedBinary(mod); name == tool {
+			return mod, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%q is not an installed module or binary", tool)
+}
+
+// rollbackTarget picks the version to reinstall after the current one was
+// reported broken: the previously installed version, or else the newest
+// known release older than the current one. Bad versions are never picked.
+func rollbackTarget(mod *pb.ModuleProto) string {
+	bad := mod.GetBadVersions()
+
+	if prev := mod.GetPreviousVersion(); prev != "" && !slices.Contains(bad, prev) {
+		return prev
+	}
+
+	var target string
+
+	for _, v := range mod.GetVersions() {
+		if slices.Contains(bad, v) || modver.Compare(v, mod.GetVersion()) >= 0 {
+			continue
+		}
+
+		if target == "" || modver.Newer(v, target) {
+			target = v
+		}
+	}
+
+	return target
+}
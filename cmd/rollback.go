@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/module"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+)
+
+// rollbackCmd represents the rollback command
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback <tool>",
+	Short: "Restore the previously installed version of a tool",
+	Long: `Revert a tool to the version installed before the current one.
+
+glix keeps a per-module install history and the binaries of the last few
+installed versions. When the binary of the target version is still cached it
+is restored as-is, without network access or a rebuild; otherwise the
+version is reinstalled. Versions that are denied or reported broken are
+skipped.
+
+The tool may be given by module path or by binary name. Unlike
+'report-broken', the current version is not marked as bad, so later updates
+may install it again.
+
+Examples:
+  glix rollback golangci-lint
+  glix rollback github.com/inovacc/twig --to v1.2.0
+  glix rollback twig --list               # Show the install history`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRollback,
+}
+
+var (
+	rollbackTo   string
+	rollbackList bool
+)
+
+func init() {
+	rootCmd.AddCommand(rollbackCmd)
+
+	rollbackCmd.Flags().StringVar(&rollbackTo, "to", "", "Version to roll back to (default: the previously installed one)")
+	rollbackCmd.Flags().BoolVar(&rollbackList, "list", false, "List the install history instead of rolling back")
+}
+
+func runRollback(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	mod, err := findInstalledTool(ctx, grpcClient, args[0])
+	if err != nil {
+		return err
+	}
+
+	history, err := grpcClient.GetInstallHistory(ctx, mod.GetName())
+	if err != nil {
+		return err
+	}
+
+	if rollbackList {
+		printInstallHistory(cmd, mod, history)
+		return nil
+	}
+
+	if mod.GetLocalPath() != "" {
+		return fmt.Errorf("module %q was installed from %s and has no versions to roll back to", mod.GetName(), mod.GetLocalPath())
+	}
+
+	target := rollbackTo
+	if target == "" {
+		target = previousInstall(mod, history)
+	}
+
+	if target == "" {
+		return fmt.Errorf("no earlier allowed version of %s to roll back to", mod.GetName())
+	}
+
+	if target == mod.GetVersion() {
+		return fmt.Errorf("%s@%s is already installed", mod.GetName(), target)
+	}
+
+	if reason := excludedReason(mod.GetName(), target, mod.GetBadVersions()); reason != "" {
+		return fmt.Errorf("%s@%s is %s", mod.GetName(), target, reason)
+	}
+
+	cmd.Printf("Rolling back %s: %s -> %s\n", mod.GetName(), mod.GetVersion(), target)
+
+	if err := rollbackModule(ctx, cmd, grpcClient, mod, target, history); err != nil {
+		return fmt.Errorf("rollback failed: %w", err)
+	}
+
+	cmd.Printf("Rolled back %s to %s\n", mod.GetName(), target)
+
+	return nil
+}
+
+// previousInstall picks the most recently installed version other than the
+// current one that is neither denied nor reported broken. Without a usable
+// install history it falls back to rollbackTarget.
+func previousInstall(mod *pb.ModuleProto, history []*pb.ModuleProto) string {
+	for i := len(history) - 1; i >= 0; i-- {
+		v := history[i].GetVersion()
+		if v == mod.GetVersion() || history[i].GetLocalPath() != "" {
+			continue
+		}
+
+		if excludedReason(mod.GetName(), v, mod.GetBadVersions()) == "" {
+			return v
+		}
+	}
+
+	return rollbackTarget(mod)
+}
+
+// rollbackModule installs target in place of the current version. The cached
+// binary and install record of target are restored when both are available;
+// otherwise the version is reinstalled from the proxy.
+func rollbackModule(ctx context.Context, cmd *cobra.Command, grpcClient *client.Client, mod *pb.ModuleProto, target string, history []*pb.ModuleProto) error {
+	record := installRecord(history, target)
+
+	// Shimmed modules keep their versions in the version store
+	if mod.GetShimPath() != "" {
+		if _, ok := module.StoredBinary(mod.GetName(), target); ok {
+			return useVersion(ctx, cmd, grpcClient, mod, target, record)
+		}
+
+		return updateModuleCore(ctx, grpcClient, fmt.Sprintf("%s@%s", mod.GetName(), target))
+	}
+
+	cached, ok := module.CachedBinary(mod.GetName(), target)
+	if record == nil || !ok {
+		return updateModuleCore(ctx, grpcClient, fmt.Sprintf("%s@%s", mod.GetName(), target))
+	}
+
+	dest := module.ManagedBinaryPath(record.GetName(), record.GetKubectlPlugin(), record.GetAlias())
+
+	// Binaries installed with --bin-dir are restored where they are now
+	if dir := mod.GetBinDir(); dir != "" {
+		dest = filepath.Join(dir, filepath.Base(dest))
+	}
+
+	cmd.Printf("[install] Restoring cached binary to %s\n", dest)
+
+	if err := module.RestoreBinary(cached, dest); err != nil {
+		return err
+	}
+
+	restored := restoreRecord(mod, record, dest)
+
+	writeReceipt(restored.GetName(), restored.GetVersion(), dest, "", serverNamespace(ctx, grpcClient), func(phase, message string) {
+		cmd.Printf("[%s] %s\n", phase, message)
+	})
+
+	return grpcClient.StoreModuleRecord(ctx, restored)
+}
+
+// installRecord returns the latest install record of version in history,
+// or nil
+func installRecord(history []*pb.ModuleProto, version string) *pb.ModuleProto {
+	var record *pb.ModuleProto
+
+	for _, h := range history {
+		if h.GetVersion() == version && h.GetLocalPath() == "" {
+			record = h
+		}
+	}
+
+	return record
+}
+
+// restoreRecord returns the install record of an earlier version restored
+// to binPath, with the settings of the installed module mod
+func restoreRecord(mod, record *pb.ModuleProto, binPath string) *pb.ModuleProto {
+	restored := proto.Clone(record).(*pb.ModuleProto)
+	restored.TimestampUnixNano = time.Now().UnixNano()
+	restored.BinaryPath = binPath
+	restored.BinDir = mod.GetBinDir()
+	restored.Profile = mod.GetProfile()
+	restored.Platform = mod.GetPlatform()
+	restored.BuildFlags = mod.GetBuildFlags()
+	restored.VersionConstraint = mod.GetVersionConstraint()
+	restored.PreferBinary = mod.GetPreferBinary()
+	restored.Prerelease = mod.GetPrerelease()
+	restored.Goproxy = mod.GetGoproxy()
+	restored.BuildStrategy = mod.GetBuildStrategy()
+	restored.Private = mod.GetPrivate()
+	restored.ShimPath = mod.GetShimPath()
+
+	// Restoring a binary builds nothing
+	restored.Build = nil
+
+	return restored
+}
+
+// printInstallHistory lists the install records of a module, newest first
+func printInstallHistory(cmd *cobra.Command, mod *pb.ModuleProto, history []*pb.ModuleProto) {
+	if len(history) == 0 {
+		cmd.Printf("No install history for %s\n", mod.GetName())
+		return
+	}
+
+	cmd.Println()
+	cmd.Printf("Install history of %s:\n", mod.GetName())
+	cmd.Println()
+
+	for i := len(history) - 1; i >= 0; i-- {
+		h := history[i]
+		installed := time.Unix(0, h.GetTimestampUnixNano())
+
+		var notes []string
+
+		if i == len(history)-1 && h.GetVersion() == mod.GetVersion() {
+			notes = append(notes, "current")
+		}
+
+		if reason := excludedReason(mod.GetName(), h.GetVersion(), mod.GetBadVersions()); reason != "" {
+			notes = append(notes, reason)
+		}
+
+		if _, ok := module.CachedBinary(mod.GetName(), h.GetVersion()); ok {
+			notes = append(notes, "cached")
+		} else if _, ok := module.StoredBinary(mod.GetName(), h.GetVersion()); ok && mod.GetShimPath() != "" {
+			notes = append(notes, "stored")
+		}
+
+		line := fmt.Sprintf("  %-20s %s", h.GetVersion(), installed.Format("2006-01-02 15:04"))
+		if len(notes) > 0 {
+			line += fmt.Sprintf("  (%s)", strings.Join(notes, ", "))
+		}
+
+		cmd.Println(line)
+	}
+
+	cmd.Println()
+}
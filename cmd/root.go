@@ -1,13 +1,21 @@
 package cmd
 
 import (
+	"context"
+	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 )
 
-var noTUI bool
+var (
+	noTUI      bool
+	wideOutput bool
+	serverFlag string
+)
 
 var rootCmd = &cobra.Command{
 	Use:   "glix [module]",
@@ -24,11 +32,28 @@ Usage:
   glix service <cmd>     - Manage the glix background service
   glix <module>          - Shorthand for install`,
 	Args: cobra.ArbitraryArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkOutputFormat(cmd); err != nil {
+			return err
+		}
+
+		// Quiet commands print nothing but their result, not even the logs
+		// of starting a server
+		if quietOutput {
+			slog.SetDefault(slog.New(slog.DiscardHandler))
+		}
+
+		return selectServer(cmd)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) == 0 {
 			return cmd.Help()
 		}
 
+		if err := selectServer(installCmd); err != nil {
+			return err
+		}
+
 		// Direct invocation acts as shorthand for installation
 		// Reuse the install command logic
 		return runInstall(cmd, args)
@@ -36,7 +61,34 @@ Usage:
 }
 
 func Execute() {
-	cobra.CheckErr(rootCmd.Execute())
+	ctx, stop := interruptContext()
+	defer stop()
+
+	cobra.CheckErr(rootCmd.ExecuteContext(ctx))
+}
+
+// interruptContext returns a context cancelled by the first interrupt, so
+// commands stop their builds, killing their process groups, and save their
+// progress. A second interrupt kills glix right away.
+func interruptContext() (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-sigCh:
+			signal.Stop(sigCh)
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(sigCh)
+		cancel()
+	}
 }
 
 // GetRootCmd returns the root command for introspection purposes.
@@ -45,15 +97,20 @@ func GetRootCmd() *cobra.Command {
 }
 
 func init() {
+	// Replaced by the completion command, which documents installing the scripts
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 	rootCmd.PersistentFlags().BoolVar(&noTUI, "no-tui", false,
 		"Disable TUI, use plain text output")
+	rootCmd.PersistentFlags().BoolVar(&wideOutput, "wide", false,
+		"Do not truncate table columns to the terminal width")
+	rootCmd.PersistentFlags().StringVar(&serverFlag, "server", "",
+		`Server to talk to: a context name, host:port, or "local" (default: the current context)`)
 }
 
 // IsTUIEnabled returns whether the TUI should be used
 // Returns false if --no-tui flag is set or if not running in a terminal
 func IsTUIEnabled() bool {
-	if noTUI {
+	if noTUI || quietOutput {
 		return false
 	}
 	// Also disable TUI if not running in a terminal
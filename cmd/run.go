@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/inovacc/glix/internal/module"
+	"github.com/spf13/cobra"
+	"golang.org/x/mod/semver"
+)
+
+// runCmd represents the run command
+var runCmd = &cobra.Command{
+	Use:   "run <module>[@version] [args...]",
+	Short: "Run a Go tool without installing it",
+	Long: `Build a Go module in a temporary directory and run it with the given
+arguments, without installing it to GOBIN or recording it in the database.
+Everything after the module is passed to the tool; put glix flags before
+the module.
+
+With --keep, the built binary is kept in the run cache (the three most
+recent versions per module) and later runs of that version start it
+directly, without a network round trip when the version is given
+exactly. Versions kept earlier are reused with or without --keep.
+
+The exit code of the tool is the exit code of glix.
+
+Examples:
+  glix run golang.org/x/tools/cmd/stringer -type=Color
+  glix run --keep github.com/google/go-licenses@v1.6.0 report ./...
+  glix run github.com/inovacc/twig@latest -- --help`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runRun,
+}
+
+var runKeep bool
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+
+	runCmd.Flags().BoolVar(&runKeep, "keep", false, "Keep the built binary for later runs")
+
+	// Flags after the module belong to the tool
+	runCmd.Flags().SetInterspersed(false)
+}
+
+func runRun(cmd *cobra.Command, args []string) error {
+	code, err := runEphemeral(cmd, args[0], args[1:])
+	if err != nil {
+		return err
+	}
+
+	// Exit after the work directory is gone
+	if code != 0 {
+		os.Exit(code)
+	}
+
+	return nil
+}
+
+// runEphemeral builds a module unless it was kept, runs it, and returns its
+// exit code
+func runEphemeral(cmd *cobra.Command, target string, toolArgs []string) (int, error) {
+	ctx := cmd.Context()
+
+	if len(toolArgs) > 0 && toolArgs[0] == "--" {
+		toolArgs = toolArgs[1:]
+	}
+
+	// An exact version that was kept needs no resolving
+	if name, version, ok := strings.Cut(target, "@"); ok && semver.IsValid(version) && semver.Canonical(version) == version {
+		if binPath, ok := module.KeptRunBinary(name, version); ok {
+			return runTool(binPath, toolArgs)
+		}
+	}
+
+	workDir, err := module.NewWorkDir("run")
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		_ = os.RemoveAll(workDir)
+	}()
+
+	m, err := module.NewModule(ctx, "go", workDir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create module: %w", err)
+	}
+
+	m.SetProgressHandler(func(phase, message string) {
+		cmd.Printf("[%s] %s\n", phase, message)
+	})
+
+	if err := m.FetchModuleInfo(target); err != nil {
+		return 0, fmt.Errorf("failed to resolve %s: %w", target, err)
+	}
+
+	if binPath, ok := module.KeptRunBinary(m.Name, m.Version); ok {
+		return runTool(binPath, toolArgs)
+	}
+
+	cmd.Printf("[build] Building %s@%s...\n", m.Name, m.Version)
+
+	m.SetBinDir(filepath.Join(workDir, "bin"))
+
+	if err := m.InstallModuleWithStreaming(ctx, func(stream, line string) {
+		cmd.Printf("  %s\n", line)
+	}); err != nil {
+		return 0, fmt.Errorf("failed to build %s@%s: %w", m.Name, m.Version, err)
+	}
+
+	if runKeep {
+		if err := module.KeepRunBinary(m.Name, m.Version, m.BinaryPath); err != nil {
+			return 0, err
+		}
+
+		cmd.Printf("[keep] Kept %s@%s for later runs\n", m.Name, m.Version)
+	}
+
+	return runTool(m.BinaryPath, toolArgs)
+}
+
+// runTool runs a binary on the terminal of glix and returns its exit code
+func runTool(binPath string, args []string) (int, error) {
+	tool := exec.Command(binPath, args...)
+	tool.Stdin = os.Stdin
+	tool.Stdout = os.Stdout
+	tool.Stderr = os.Stderr
+
+	// Interrupts reach the tool directly from the terminal; glix waits for
+	// it to handle them
+	signal.Ignore(os.Interrupt)
+	defer signal.Reset(os.Interrupt)
+
+	err := tool.Run()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		// Killed by a signal
+		if exitErr.ExitCode() < 0 {
+			return 1, nil
+		}
+
+		return exitErr.ExitCode(), nil
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to run %s: %w", filepath.Base(binPath), err)
+	}
+
+	return 0, nil
+}
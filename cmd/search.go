@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/spf13/cobra"
+)
+
+// searchCmd represents the search command
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search the Go package index for installable tools",
+	Long: `Search pkg.go.dev for packages matching a query and show their import
+paths, descriptions and latest versions. Any result can be passed to
+'glix install'.
+
+Examples:
+  glix search protobuf generator
+  glix search linter --limit 25`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSearch,
+}
+
+var searchLimit int32
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+
+	searchCmd.Flags().Int32VarP(&searchLimit, "limit", "l", 10, "Maximum number of results")
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	query := strings.Join(args, " ")
+	cfg := client.DefaultDiscoveryConfig()
+
+	grpcClient, err := client.GetClient(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	results, err := grpcClient.Search(ctx, query, searchLimit)
+	if err != nil {
+		return err
+	}
+
+	if len(results) == 0 {
+		cmd.Printf("No packages found for %q\n", query)
+		return nil
+	}
+
+	installed := make(map[string]string)
+
+	if resp, err := grpcClient.ListModules(ctx, 0, 0, ""); err == nil {
+		for _, mod := range resp.GetModules() {
+			installed[mod.GetName()] = mod.GetVersion()
+		}
+	}
+
+	cmd.Println()
+	cmd.Printf("Results for %q (%d):\n", query, len(results))
+	cmd.Println()
+
+	for _, r := range results {
+		line := "  " + r.GetPath()
+		if r.GetVersion() != "" {
+			line += " " + r.GetVersion()
+		}
+
+		if version, ok := installed[r.GetPath()]; ok {
+			line += fmt.Sprintf(" [installed %s]", version)
+		}
+
+		cmd.Println(line)
+
+		if r.GetSynopsis() != "" {
+			cmd.Printf("    %s\n", r.GetSynopsis())
+		}
+	}
+
+	cmd.Println()
+	cmd.Println("Install with: glix install <path>")
+
+	return nil
+}
@@ -2,10 +2,15 @@ package cmd
 
 import (
 	"fmt"
+	"path/filepath"
+	"time"
 
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/dashboard"
 	"github.com/inovacc/glix/internal/module"
 	"github.com/inovacc/glix/internal/server"
 	"github.com/inovacc/glix/internal/service"
+	"github.com/inovacc/glix/internal/transport"
 	"github.com/spf13/cobra"
 )
 
@@ -17,6 +22,13 @@ var serviceInstallCmd = &cobra.Command{
 This will register glix as a system service that starts automatically
 on boot and runs the gRPC server in the background.
 
+The service runs until stopped. With --idle-timeout it stops after that long
+without requests, unless a scheduled task such as an auto-update check is
+running or due within --task-window, and the next command starts an
+on-demand server. On-demand servers stop after 5 minutes without requests
+and stay up for tasks due within 10 minutes; GLIX_IDLE_TIMEOUT and
+GLIX_TASK_WINDOW change these, and GLIX_IDLE_TIMEOUT=0 keeps them running.
+
 Platform-specific behavior:
   - Windows: Registers as a Windows Service
   - Linux: Creates a systemd unit file
@@ -29,6 +41,11 @@ var (
 	installDatabasePath string
 	installPort         int
 	installBindAddress  string
+	installDashboard    string
+	installTLSCert      string
+	installTLSKey       string
+	installIdleTimeout  time.Duration
+	installTaskWindow   time.Duration
 )
 
 func init() {
@@ -38,6 +55,12 @@ func init() {
 	serviceInstallCmd.Flags().StringVar(&installDatabasePath, "database", "", "Path to the database file")
 	serviceInstallCmd.Flags().IntVar(&installPort, "port", server.DefaultPort, "Port for the gRPC server")
 	serviceInstallCmd.Flags().StringVar(&installBindAddress, "bind", "localhost", "Address to bind the server to")
+	serviceInstallCmd.Flags().StringVar(&installDashboard, "dashboard", "", "Serve the web dashboard on this loopback address (e.g. "+dashboard.DefaultAddress+")")
+	serviceInstallCmd.Flags().StringVar(&installTLSCert, "tls-cert", "", "Serve TLS with this PEM certificate, for CLIs on other machines")
+	serviceInstallCmd.Flags().StringVar(&installTLSKey, "tls-key", "", "PEM private key of the TLS certificate")
+	serviceInstallCmd.Flags().DurationVar(&installIdleTimeout, "idle-timeout", 0, "Stop the service after this duration without requests (0 = run until stopped)")
+	serviceInstallCmd.Flags().DurationVar(&installTaskWindow, "task-window", client.DefaultTaskWindow, "With --idle-timeout, stay up while a scheduled task is due within this duration")
+	serviceInstallCmd.MarkFlagsRequiredTogether("tls-cert", "tls-key")
 }
 
 func runServiceInstall(cmd *cobra.Command, args []string) error {
@@ -50,6 +73,27 @@ func runServiceInstall(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("service is already installed, use 'glix service uninstall' first")
 	}
 
+	if installDashboard != "" {
+		if err := dashboard.ValidateAddress(installDashboard); err != nil {
+			return err
+		}
+	}
+
+	if installTLSCert != "" {
+		if _, err := transport.ServerCredentials(installTLSCert, installTLSKey); err != nil {
+			return err
+		}
+
+		// The service manager starts glix in another directory
+		if installTLSCert, err = filepath.Abs(installTLSCert); err != nil {
+			return fmt.Errorf("failed to resolve TLS certificate: %w", err)
+		}
+
+		if installTLSKey, err = filepath.Abs(installTLSKey); err != nil {
+			return fmt.Errorf("failed to resolve TLS key: %w", err)
+		}
+	}
+
 	// Use default database path if not specified
 	dbPath := installDatabasePath
 	if dbPath == "" {
@@ -61,6 +105,11 @@ func runServiceInstall(cmd *cobra.Command, args []string) error {
 		DatabasePath: dbPath,
 		Port:         installPort,
 		BindAddress:  installBindAddress,
+		Dashboard:    installDashboard,
+		TLSCertFile:  installTLSCert,
+		TLSKeyFile:   installTLSKey,
+		IdleTimeout:  installIdleTimeout,
+		TaskWindow:   installTaskWindow,
 	}
 
 	cmd.Printf("Installing glix service...\n")
@@ -69,6 +118,18 @@ func runServiceInstall(cmd *cobra.Command, args []string) error {
 	cmd.Printf("  Port:         %d\n", cfg.Port)
 	cmd.Printf("  Bind Address: %s\n", cfg.BindAddress)
 
+	if cfg.Dashboard != "" {
+		cmd.Printf("  Dashboard:    http://%s\n", cfg.Dashboard)
+	}
+
+	if cfg.TLSCertFile != "" {
+		cmd.Printf("  TLS:          %s\n", cfg.TLSCertFile)
+	}
+
+	if cfg.IdleTimeout > 0 {
+		cmd.Printf("  Idle Timeout: %s (staying up for tasks due within %s)\n", cfg.IdleTimeout, cfg.TaskWindow)
+	}
+
 	if err := mgr.Install(cmd.Context(), cfg); err != nil {
 		return fmt.Errorf("failed to install service: %w", err)
 	}
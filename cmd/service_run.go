@@ -9,6 +9,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/inovacc/glix/internal/dashboard"
 	"github.com/inovacc/glix/internal/module"
 	glixServer "github.com/inovacc/glix/internal/server"
 	"github.com/spf13/cobra"
@@ -28,6 +29,10 @@ var (
 	runPort         int
 	runBindAddress  string
 	runIdleTimeout  time.Duration
+	runTaskWindow   time.Duration
+	runDashboard    string
+	runTLSCert      string
+	runTLSKey       string
 )
 
 func init() {
@@ -38,6 +43,11 @@ func init() {
 	serviceRunCmd.Flags().IntVar(&runPort, "port", glixServer.DefaultPort, "Port for the gRPC server")
 	serviceRunCmd.Flags().StringVar(&runBindAddress, "bind", "localhost", "Address to bind the server to")
 	serviceRunCmd.Flags().DurationVar(&runIdleTimeout, "idle-timeout", 0, "Shutdown after this duration of inactivity (0 = disabled)")
+	serviceRunCmd.Flags().DurationVar(&runTaskWindow, "task-window", 0, "Stay up past the idle timeout while a scheduled task is due within this duration")
+	serviceRunCmd.Flags().StringVar(&runDashboard, "dashboard", "", "Serve the web dashboard on this loopback address (e.g. "+dashboard.DefaultAddress+")")
+	serviceRunCmd.Flags().StringVar(&runTLSCert, "tls-cert", "", "Serve TLS with this PEM certificate")
+	serviceRunCmd.Flags().StringVar(&runTLSKey, "tls-key", "", "PEM private key of the TLS certificate")
+	serviceRunCmd.MarkFlagsRequiredTogether("tls-cert", "tls-key")
 }
 
 func runServiceRun(cmd *cobra.Command, args []string) error {
@@ -58,7 +68,12 @@ func runServiceRun(cmd *cobra.Command, args []string) error {
 		Port:         runPort,
 		BindAddress:  runBindAddress,
 		IdleTimeout:  runIdleTimeout,
+		TaskWindow:   runTaskWindow,
 		Logger:       logger,
+
+		DashboardAddress: runDashboard,
+		TLSCertFile:      runTLSCert,
+		TLSKeyFile:       runTLSKey,
 	}
 
 	srv, err := glixServer.New(cfg)
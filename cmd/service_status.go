@@ -5,7 +5,9 @@ import (
 	"time"
 
 	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/humanize"
 	"github.com/inovacc/glix/internal/service"
+	"github.com/inovacc/glix/internal/transport"
 	"github.com/spf13/cobra"
 )
 
@@ -15,7 +17,9 @@ var serviceStatusCmd = &cobra.Command{
 	Long: `Display the current status of the glix background service.
 
 Shows both the system service status (requires admin) and the
-gRPC server status (no admin required).`,
+gRPC server status (no admin required). With --output json or yaml only
+the gRPC server status is printed, and the command fails when the server
+is not running.`,
 	RunE: runServiceStatus,
 }
 
@@ -24,6 +28,10 @@ func init() {
 }
 
 func runServiceStatus(cmd *cobra.Command, args []string) error {
+	if structuredOutput() {
+		return printServerStatus(cmd)
+	}
+
 	cmd.Printf("Glix Service Status\n")
 	cmd.Printf("-------------------\n")
 
@@ -50,8 +58,7 @@ func runServiceStatus(cmd *cobra.Command, args []string) error {
 	// Check gRPC server status (no admin required)
 	cmd.Printf("\ngRPC Server:\n")
 
-	cfg := client.DefaultConfig()
-	cfg.DialTimeout = 2 * time.Second
+	cfg := serviceStatusConfig()
 
 	grpcClient, err := client.New(cfg)
 	if err != nil {
@@ -78,32 +85,57 @@ func runServiceStatus(cmd *cobra.Command, args []string) error {
 	cmd.Printf("  Address:   %s\n", status.GetAddress())
 	cmd.Printf("  Namespace: %s\n", status.GetNamespace())
 	cmd.Printf("  Database:  %s\n", status.GetDatabasePath())
-	cmd.Printf("  Uptime:    %s\n", formatUptime(status.GetUptimeSeconds()))
+	cmd.Printf("  Uptime:    %s\n", humanize.Duration(time.Duration(status.GetUptimeSeconds())*time.Second))
 	cmd.Printf("  Modules:   %d\n", status.GetModuleCount())
 
+	cmd.Printf("\nAuto-update:\n")
+	cmd.Printf("  Enabled:    %v\n", status.GetAutoupdateEnabled())
+
+	if last := status.GetLastAutoupdateCheckUnixNano(); last > 0 {
+		cmd.Printf("  Last check: %s\n", time.Unix(0, last).Format("2006-01-02 15:04"))
+		cmd.Printf("  Pending:    %d update(s)\n", status.GetPendingUpdateCount())
+	} else {
+		cmd.Printf("  Last check: never\n")
+	}
+
 	return nil
 }
 
-func formatUptime(seconds int64) string {
-	d := time.Duration(seconds) * time.Second
-
-	if d < time.Minute {
-		return fmt.Sprintf("%ds", seconds)
+// serviceStatusConfig returns the client config of the service on this
+// machine, whatever the current context
+func serviceStatusConfig() client.Config {
+	cfg := client.DefaultConfig()
+	if client.CurrentTarget().Remote {
+		cfg.Address = client.LocalTarget().Address
+		cfg.TLS = transport.TLS{}
+		cfg.Token = ""
 	}
 
-	if d < time.Hour {
-		return fmt.Sprintf("%dm %ds", int(d.Minutes()), int(d.Seconds())%60)
-	}
+	cfg.DialTimeout = 2 * time.Second
 
-	hours := int(d.Hours())
-	mins := int(d.Minutes()) % 60
+	return cfg
+}
+
+// printServerStatus prints the status the gRPC server reports, failing when
+// it is not running
+func printServerStatus(cmd *cobra.Command) error {
+	cmd.SilenceUsage = true
+
+	cfg := serviceStatusConfig()
 
-	if hours < 24 {
-		return fmt.Sprintf("%dh %dm", hours, mins)
+	grpcClient, err := client.New(cfg)
+	if err != nil {
+		return fmt.Errorf("server not running at %s", cfg.Address)
 	}
 
-	days := hours / 24
-	hours = hours % 24
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	status, err := grpcClient.GetStatus(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("server not responding at %s: %w", cfg.Address, err)
+	}
 
-	return fmt.Sprintf("%dd %dh %dm", days, hours, mins)
+	return printMessage(cmd, status)
 }
@@ -0,0 +1,405 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/server"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// currentSnapshotName refers to the live installed set in snapshot diff
+const currentSnapshotName = server.CurrentSnapshotName
+
+// snapshotCmd represents the snapshot parent command
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Manage named snapshots of the installed module set",
+	Long: `Snapshots record the full set of installed modules and their versions
+under a name, like git tags for your toolchain. A snapshot can later be
+compared against another snapshot or restored, which installs, removes,
+and rolls back modules until the installed set matches it.
+
+Examples:
+  glix snapshot create before-upgrade
+  glix snapshot list
+  glix snapshot diff before-upgrade            # Compare against installed set
+  glix snapshot diff before-upgrade after-upgrade
+  glix snapshot restore before-upgrade
+  glix snapshot delete before-upgrade`,
+}
+
+// snapshotCreateCmd captures the installed module set
+var snapshotCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Capture the installed module set",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSnapshotCreate,
+}
+
+// snapshotListCmd lists stored snapshots
+var snapshotListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List stored snapshots",
+	RunE:  runSnapshotList,
+}
+
+// snapshotDiffCmd compares two snapshots
+var snapshotDiffCmd = &cobra.Command{
+	Use:   "diff <a> [b]",
+	Short: "Show differences between two snapshots",
+	Long: `Show the modules added, removed, or changed between snapshot <a> and
+snapshot <b>. When <b> is omitted (or is "current"), the currently
+installed module set is used.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSnapshotDiff,
+}
+
+// snapshotRestoreCmd converges the installed set on a snapshot
+var snapshotRestoreCmd = &cobra.Command{
+	Use:   "restore <name>",
+	Short: "Install, remove, or roll back modules to match a snapshot",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSnapshotRestore,
+}
+
+// snapshotDeleteCmd removes a stored snapshot
+var snapshotDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a stored snapshot",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSnapshotDelete,
+}
+
+var (
+	snapshotDescription string
+	snapshotOverwrite   bool
+	snapshotDryRun      bool
+	snapshotKeepExtra   bool
+)
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+
+	snapshotCmd.AddCommand(snapshotCreateCmd)
+	snapshotCmd.AddCommand(snapshotListCmd)
+	snapshotCmd.AddCommand(snapshotDiffCmd)
+	snapshotCmd.AddCommand(snapshotRestoreCmd)
+	snapshotCmd.AddCommand(snapshotDeleteCmd)
+
+	snapshotCreateCmd.Flags().StringVarP(&snapshotDescription, "description", "d", "", "Description stored with the snapshot")
+	snapshotCreateCmd.Flags().BoolVar(&snapshotOverwrite, "overwrite", false, "Replace an existing snapshot with the same name")
+
+	snapshotRestoreCmd.Flags().BoolVar(&snapshotDryRun, "dry-run", false, "Show the changes without applying them")
+	snapshotRestoreCmd.Flags().BoolVar(&snapshotKeepExtra, "keep-extra", false, "Do not remove modules missing from the snapshot")
+}
+
+// snapshotChangeKind describes how a module differs between two sets
+type snapshotChangeKind string
+
+const (
+	snapshotAdded   snapshotChangeKind = "added"
+	snapshotRemoved snapshotChangeKind = "removed"
+	snapshotChanged snapshotChangeKind = "changed"
+)
+
+// snapshotChange is a single module difference between two module sets
+type snapshotChange struct {
+	Kind        snapshotChangeKind
+	Name        string
+	FromVersion string
+	ToVersion   string
+}
+
+func runSnapshotCreate(cmd *cobra.Command, args []string) error {
+	cfg := client.DefaultDiscoveryConfig()
+
+	grpcClient, err := client.GetClient(cmd.Context(), cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	snapshot, err := grpcClient.CreateSnapshot(cmd.Context(), args[0], snapshotDescription, snapshotOverwrite)
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("Snapshot %q created with %d module(s)\n", snapshot.GetName(), len(snapshot.GetModules()))
+
+	return nil
+}
+
+func runSnapshotList(cmd *cobra.Command, _ []string) error {
+	cfg := client.DefaultDiscoveryConfig()
+
+	grpcClient, err := client.GetClient(cmd.Context(), cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	resp, err := grpcClient.ListSnapshots(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	snapshots := resp.GetSnapshots()
+	if len(snapshots) == 0 {
+		cmd.Println("No snapshots stored")
+		return nil
+	}
+
+	cmd.Println()
+	cmd.Printf("Snapshots (%d):\n", len(snapshots))
+	cmd.Println()
+
+	for _, s := range snapshots {
+		created := time.Unix(0, s.GetCreatedUnixNano()).Format("2006-01-02 15:04")
+
+		cmd.Printf("  %s\n", s.GetName())
+		cmd.Printf("    Created: %s | Modules: %d\n", created, len(s.GetModules()))
+
+		if s.GetDescription() != "" {
+			cmd.Printf("    %s\n", s.GetDescription())
+		}
+	}
+
+	cmd.Println()
+
+	return nil
+}
+
+func runSnapshotDiff(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg := client.DefaultDiscoveryConfig()
+
+	grpcClient, err := client.GetClient(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	other := currentSnapshotName
+	if len(args) == 2 {
+		other = args[1]
+	}
+
+	from, err := loadSnapshotModules(ctx, grpcClient, args[0])
+	if err != nil {
+		return err
+	}
+
+	to, err := loadSnapshotModules(ctx, grpcClient, other)
+	if err != nil {
+		return err
+	}
+
+	changes := diffModuleSets(from, to)
+	if len(changes) == 0 {
+		cmd.Printf("No differences between %s and %s\n", args[0], other)
+		return nil
+	}
+
+	cmd.Printf("Differences from %s to %s:\n", args[0], other)
+	printSnapshotChanges(cmd, changes)
+
+	return nil
+}
+
+func runSnapshotRestore(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg := client.DefaultDiscoveryConfig()
+
+	grpcClient, err := client.GetClient(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	target, err := loadSnapshotModules(ctx, grpcClient, args[0])
+	if err != nil {
+		return err
+	}
+
+	installed, err := loadSnapshotModules(ctx, grpcClient, currentSnapshotName)
+	if err != nil {
+		return err
+	}
+
+	changes := diffModuleSets(installed, target)
+	if len(changes) == 0 {
+		cmd.Printf("Installed modules already match snapshot %q\n", args[0])
+		return nil
+	}
+
+	cmd.Printf("Restoring snapshot %q:\n", args[0])
+	printSnapshotChanges(cmd, changes)
+
+	if snapshotDryRun {
+		return nil
+	}
+
+	cmd.Println()
+
+	if failed := applyModuleChanges(ctx, cmd, grpcClient, changes, snapshotKeepExtra); failed > 0 {
+		return fmt.Errorf("snapshot restore finished with %d error(s)", failed)
+	}
+
+	cmd.Printf("\nSnapshot %q restored\n", args[0])
+
+	return nil
+}
+
+func runSnapshotDelete(cmd *cobra.Command, args []string) error {
+	cfg := client.DefaultDiscoveryConfig()
+
+	grpcClient, err := client.GetClient(cmd.Context(), cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	resp, err := grpcClient.DeleteSnapshot(cmd.Context(), args[0])
+	if err != nil {
+		return fmt.Errorf("failed to delete snapshot: %w", err)
+	}
+
+	if !resp.GetSuccess() {
+		return fmt.Errorf("failed to delete snapshot: %s", resp.GetErrorMessage())
+	}
+
+	cmd.Printf("Snapshot %q deleted\n", args[0])
+
+	return nil
+}
+
+// applyModuleChanges installs, updates, and (unless keepExtra is set) removes
+// modules to apply a diff, returning the number of failed changes
+func applyModuleChanges(ctx context.Context, cmd *cobra.Command, grpcClient *client.Client, changes []snapshotChange, keepExtra bool) int {
+	var failed int
+
+	for _, c := range changes {
+		switch c.Kind {
+		case snapshotAdded, snapshotChanged:
+			cmd.Printf("[install] %s@%s\n", c.Name, c.ToVersion)
+
+			if err := updateModuleCore(ctx, grpcClient, fmt.Sprintf("%s@%s", c.Name, c.ToVersion)); err != nil {
+				cmd.Printf("[error] %s: %v\n", c.Name, err)
+
+				failed++
+			}
+		case snapshotRemoved:
+			if keepExtra {
+				continue
+			}
+
+			progressHandler := func(phase, message string) {
+				cmd.Printf("[%s] %s\n", phase, message)
+			}
+
+			if err := doRemove(ctx, c.Name, "", progressHandler, func(string) {}); err != nil {
+				cmd.Printf("[error] %s: %v\n", c.Name, err)
+
+				failed++
+			}
+		}
+	}
+
+	return failed
+}
+
+// loadSnapshotModules returns the modules of a stored snapshot, or the
+// currently installed modules when name is "current"
+func loadSnapshotModules(ctx context.Context, grpcClient *client.Client, name string) ([]*pb.ModuleProto, error) {
+	if name == currentSnapshotName {
+		resp, err := grpcClient.ListModules(ctx, 0, 0, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list modules: %w", err)
+		}
+
+		return resp.GetModules(), nil
+	}
+
+	resp, err := grpcClient.GetSnapshot(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshot: %w", err)
+	}
+
+	if !resp.GetFound() {
+		return nil, fmt.Errorf("snapshot %q not found", name)
+	}
+
+	return resp.GetSnapshot().GetModules(), nil
+}
+
+// diffModuleSets returns the changes needed to go from one module set to another
+func diffModuleSets(from, to []*pb.ModuleProto) []snapshotChange {
+	fromVersions := make(map[string]string, len(from))
+	for _, m := range from {
+		fromVersions[m.GetName()] = m.GetVersion()
+	}
+
+	toVersions := make(map[string]string, len(to))
+	for _, m := range to {
+		toVersions[m.GetName()] = m.GetVersion()
+	}
+
+	var changes []snapshotChange
+
+	for name, toVersion := range toVersions {
+		fromVersion, ok := fromVersions[name]
+
+		switch {
+		case !ok:
+			changes = append(changes, snapshotChange{Kind: snapshotAdded, Name: name, ToVersion: toVersion})
+		case fromVersion != toVersion:
+			changes = append(changes, snapshotChange{Kind: snapshotChanged, Name: name, FromVersion: fromVersion, ToVersion: toVersion})
+		}
+	}
+
+	for name, fromVersion := range fromVersions {
+		if _, ok := toVersions[name]; !ok {
+			changes = append(changes, snapshotChange{Kind: snapshotRemoved, Name: name, FromVersion: fromVersion})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+
+	return changes
+}
+
+// printSnapshotChanges prints a diff in a compact +/-/~ format
+func printSnapshotChanges(cmd *cobra.Command, changes []snapshotChange) {
+	for _, c := range changes {
+		switch c.Kind {
+		case snapshotAdded:
+			cmd.Printf("  + %s@%s\n", c.Name, c.ToVersion)
+		case snapshotRemoved:
+			cmd.Printf("  - %s@%s\n", c.Name, c.FromVersion)
+		case snapshotChanged:
+			cmd.Printf("  ~ %s: %s -> %s\n", c.Name, c.FromVersion, c.ToVersion)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/humanize"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// maxStatsWeeks bounds --trend, about two years of weekly history
+const maxStatsWeeks = 104
+
+// sparkLevels are the bars of a sparkline, lowest first; the first is only
+// used for weeks without events
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show weekly trends of installs, updates, and failures, and build metrics",
+	Long: `Chart the installs, updates, and failures of the event history per week,
+with a sparkline for each and a table of the weekly counts. Weeks start on
+Monday and the last one is the current week.
+
+Installs and updates count successful changes; failures count failed
+installs, updates, and removes. The history keeps the last 1000 events, so
+long trends of busy machines may start late.
+
+Build metrics summarize how long the installs and updates of the weeks took
+and the size of their binaries. Cache hits found the module source in the
+module cache; cold builds downloaded it first.
+
+Examples:
+  glix stats
+  glix stats --trend 26w
+  glix stats --output json | jq '.weeks[].failures'`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runStats,
+}
+
+var statsTrend string
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().StringVar(&statsTrend, "trend", "12w", "Number of weeks to chart, e.g. 12w")
+}
+
+func runStats(cmd *cobra.Command, _ []string) error {
+	weeks, err := parseWeeks(statsTrend)
+	if err != nil {
+		return err
+	}
+
+	grpcClient, err := client.GetClient(cmd.Context(), client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	resp, err := grpcClient.GetStats(cmd.Context(), int32(weeks))
+	if err != nil {
+		return err
+	}
+
+	if structuredOutput() {
+		return printMessage(cmd, &pb.GetStatsResponse{Weeks: resp.GetWeeks(), Builds: resp.GetBuilds()})
+	}
+
+	stats := resp.GetWeeks()
+
+	if len(stats) == 0 {
+		cmd.Println("No history recorded")
+		return nil
+	}
+
+	series := []struct {
+		label string
+		count func(*pb.WeeklyStats) int32
+	}{
+		{"Installs", (*pb.WeeklyStats).GetInstalls},
+		{"Updates", (*pb.WeeklyStats).GetUpdates},
+		{"Failures", (*pb.WeeklyStats).GetFailures},
+	}
+
+	w := cmd.OutOrStdout()
+	_, _ = fmt.Fprintf(w, "Events per week since %s\n\n", weekDate(stats[0]))
+
+	for _, s := range series {
+		values := make([]int32, len(stats))
+
+		var total int32
+		for i, week := range stats {
+			values[i] = s.count(week)
+			total += values[i]
+		}
+
+		_, _ = fmt.Fprintf(w, "%-9s %s %d\n", s.label, sparkline(values), total)
+	}
+
+	_, _ = fmt.Fprintln(w)
+
+	t := newTable(
+		column{Header: "WEEK"},
+		column{Header: "INSTALLS"},
+		column{Header: "UPDATES"},
+		column{Header: "REMOVES"},
+		column{Header: "FAILURES"},
+	)
+
+	for _, week := range stats {
+		t.addRow(weekDate(week), strconv.Itoa(int(week.GetInstalls())), strconv.Itoa(int(week.GetUpdates())),
+			strconv.Itoa(int(week.GetRemoves())), strconv.Itoa(int(week.GetFailures())))
+	}
+
+	if err := t.write(w); err != nil {
+		return err
+	}
+
+	if builds := resp.GetBuilds(); builds != nil {
+		_, _ = fmt.Fprintln(w)
+		printBuildStats(w, builds)
+	}
+
+	return nil
+}
+
+// printBuildStats prints the build metrics of the weeks
+func printBuildStats(w io.Writer, b *pb.BuildStats) {
+	duration := func(nano int64) string {
+		return humanize.Duration(time.Duration(nano))
+	}
+
+	_, _ = fmt.Fprintf(w, "Builds    %d, %d from cache (%d%%)\n", b.GetBuilds(), b.GetCacheHits(), 100*b.GetCacheHits()/b.GetBuilds())
+	_, _ = fmt.Fprintf(w, "Duration  median %s, p95 %s", duration(b.GetMedianDurationNano()), duration(b.GetP95DurationNano()))
+
+	if b.GetCacheHits() > 0 && b.GetCacheHits() < b.GetBuilds() {
+		_, _ = fmt.Fprintf(w, "; cached %s, cold %s", duration(b.GetMedianCachedDurationNano()), duration(b.GetMedianColdDurationNano()))
+	}
+
+	_, _ = fmt.Fprintf(w, "\nSize      median %s, %s in all\n", humanize.Size(b.GetMedianBinarySize()), humanize.Size(b.GetTotalBinarySize()))
+	_, _ = fmt.Fprintf(w, "Slowest   %s (%s)\n", b.GetSlowest(), duration(b.GetSlowestDurationNano()))
+	_, _ = fmt.Fprintf(w, "Largest   %s (%s)\n", b.GetLargest(), humanize.Size(b.GetLargestBinarySize()))
+}
+
+// parseWeeks parses a number of weeks such as "12w" or "12"
+func parseWeeks(s string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(s), "w"))
+	if err != nil || n < 1 || n > maxStatsWeeks {
+		return 0, fmt.Errorf("invalid --trend %q: want 1w to %dw", s, maxStatsWeeks)
+	}
+
+	return n, nil
+}
+
+// sparkline draws values as bars scaled to the largest one. Weeks with
+// events get at least the second level, so they stand out from empty ones.
+func sparkline(values []int32) string {
+	var peak int32
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+
+	var b strings.Builder
+
+	for _, v := range values {
+		level := 0
+		if v > 0 {
+			level = 1 + int(v)*(len(sparkLevels)-2)/int(peak)
+		}
+
+		b.WriteRune(sparkLevels[level])
+	}
+
+	return b.String()
+}
+
+// weekDate formats the first day of a week
+func weekDate(week *pb.WeeklyStats) string {
+	return time.Unix(0, week.GetWeekStartUnixNano()).Format(time.DateOnly)
+}
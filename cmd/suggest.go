@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/suggest"
+	"github.com/spf13/cobra"
+)
+
+// suggestCmd represents the suggest command
+var suggestCmd = &cobra.Command{
+	Use:   "suggest --from-history [module|binary...]",
+	Short: "Suggest tools installed outside glix to bring under its management",
+	Long: `Find Go tools used on this machine without glix, to migrate to glix in
+one command.
+
+--from-history scans the shell history (bash, zsh, fish and PowerShell, or
+the files given with --history) for 'go install <package>@<version>'
+invocations, and GOBIN for Go binaries glix does not manage. The tools are
+listed most used first, by how often the history runs their binary. A
+binary found in GOBIN is suggested at the version it was built from.
+
+With --install the suggested tools are installed with glix, replacing the
+binaries of plain 'go install' runs. Name modules or binaries to install
+only those.
+
+Examples:
+  glix suggest --from-history
+  glix suggest --from-history --install
+  glix suggest --from-history --install gopls dlv
+  glix suggest --from-history --history ~/old_bash_history`,
+	SilenceUsage: true,
+	RunE:         runSuggest,
+}
+
+var (
+	suggestFromHistory bool
+	suggestHistory     []string
+	suggestInstall     bool
+)
+
+func init() {
+	rootCmd.AddCommand(suggestCmd)
+
+	suggestCmd.Flags().BoolVar(&suggestFromHistory, "from-history", false, "Suggest tools from the shell history and GOBIN")
+	suggestCmd.Flags().StringSliceVar(&suggestHistory, "history", nil, "History files to scan (default: the shell history files found)")
+	suggestCmd.Flags().BoolVar(&suggestInstall, "install", false, "Install the suggested tools with glix")
+	_ = suggestCmd.MarkFlagRequired("from-history")
+}
+
+func runSuggest(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	files := suggestHistory
+	if len(files) == 0 {
+		files = suggest.HistoryFiles()
+	}
+
+	h := suggest.NewHistory()
+
+	for _, path := range files {
+		if err := h.ReadFile(path); err != nil {
+			return err
+		}
+	}
+
+	binaries, err := suggest.ScanGoBin(module.GetGoBinDirectory())
+	if err != nil {
+		return err
+	}
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	resp, err := grpcClient.ListModules(ctx, 0, 0, "")
+
+	_ = grpcClient.Close()
+
+	if err != nil {
+		return fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	managed := make(map[string]bool)
+	for _, mod := range resp.GetModules() {
+		managed[mod.GetName()] = true
+	}
+
+	suggestions := suggest.Suggest(h, binaries, managed)
+
+	if len(args) > 0 {
+		for _, arg := range args {
+			if !slices.ContainsFunc(suggestions, func(s suggest.Suggestion) bool { return matchesSuggestion(s, arg) }) {
+				return fmt.Errorf("%s is not among the suggestions", arg)
+			}
+		}
+
+		suggestions = slices.DeleteFunc(suggestions, func(s suggest.Suggestion) bool {
+			return !slices.ContainsFunc(args, func(arg string) bool { return matchesSuggestion(s, arg) })
+		})
+	}
+
+	if len(suggestions) == 0 {
+		cmd.Printf("No tools to suggest: scanned %d history file(s) and %s\n", len(files), module.GetGoBinDirectory())
+		return nil
+	}
+
+	t := newTable(
+		column{Header: "MODULE", Shrink: true},
+		column{Header: "VERSION"},
+		column{Header: "BINARY"},
+		column{Header: "USES"},
+		column{Header: "FOUND IN"},
+	)
+
+	for _, s := range suggestions {
+		version := s.Version
+		if version == "" {
+			version = "latest"
+		}
+
+		t.addRow(s.Module, version, s.Binary, strconv.Itoa(s.Uses), strings.Join(s.Sources, ", "))
+	}
+
+	if err := t.write(cmd.OutOrStdout()); err != nil {
+		return err
+	}
+
+	if !suggestInstall {
+		cmd.Printf("\n%d tool(s) not managed by glix; install them with 'glix suggest --from-history --install'\n", len(suggestions))
+		return nil
+	}
+
+	cmd.Println()
+
+	specs := make([]string, 0, len(suggestions))
+	for _, s := range suggestions {
+		specs = append(specs, s.Spec())
+	}
+
+	return installModules(ctx, cmd, specs)
+}
+
+// matchesSuggestion reports whether arg names the module or binary of s
+func matchesSuggestion(s suggest.Suggestion, arg string) bool {
+	return arg == s.Module || arg == s.Binary
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"golang.org/x/term"
+)
+
+// tableGap separates table columns
+const tableGap = "  "
+
+// minShrinkWidth is the narrowest a shrinkable column gets
+const minShrinkWidth = 16
+
+// tableHeaderStyle is used for column headers (bold, dim)
+var tableHeaderStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("245"))
+
+// column describes a table column
+type column struct {
+	Header string
+
+	// Shrink marks the column truncated from the left when the table is
+	// wider than the terminal, e.g. module paths, whose tails are the most
+	// telling part. --wide disables truncation.
+	Shrink bool
+
+	// KeepStart truncates a shrinkable column from the right instead, for
+	// free text such as error messages
+	KeepStart bool
+
+	// Style, if set, colors cell values, e.g. by status
+	Style func(value string) lipgloss.Style
+}
+
+// table writes rows as aligned columns, colored when the output is a
+// terminal and fitted to the terminal width
+type table struct {
+	columns []column
+	rows    [][]string
+}
+
+func newTable(columns ...column) *table {
+	return &table{columns: columns}
+}
+
+// addRow adds a row of plain cell values, one per column
+func (t *table) addRow(values ...string) {
+	t.rows = append(t.rows, values)
+}
+
+// write renders the table to w, fitting it to the terminal width of stdout
+// unless --wide is set or stdout is not a terminal
+func (t *table) write(w io.Writer) error {
+	_, err := io.WriteString(w, t.render(outputWidth()))
+	return err
+}
+
+// lines renders the table fitted like write, indented by indent columns,
+// one string per line, for output that is emitted line by line
+func (t *table) lines(indent int) []string {
+	width := outputWidth()
+	if width > 0 {
+		width = max(width-indent, 1)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(t.render(width), "\n"), "\n")
+	for i := range lines {
+		lines[i] = strings.Repeat(" ", indent) + lines[i]
+	}
+
+	return lines
+}
+
+// outputWidth returns the width tables are fitted to: the terminal width of
+// stdout, or 0 (unlimited) with --wide or when stdout is not a terminal
+func outputWidth() int {
+	if wideOutput {
+		return 0
+	}
+
+	if cols, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+		return cols
+	}
+
+	return 0
+}
+
+// render returns the table fitted to width columns; 0 means unlimited
+func (t *table) render(width int) string {
+	widths := make([]int, len(t.columns))
+	for i, c := range t.columns {
+		widths[i] = ansi.StringWidth(c.Header)
+	}
+
+	for _, row := range t.rows {
+		for i := range t.columns {
+			if i < len(row) {
+				widths[i] = max(widths[i], ansi.StringWidth(row[i]))
+			}
+		}
+	}
+
+	if width > 0 {
+		t.fit(widths, width)
+	}
+
+	var b strings.Builder
+
+	headers := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		headers[i] = c.Header
+	}
+
+	t.writeRow(&b, headers, widths, func(int, string) lipgloss.Style { return tableHeaderStyle })
+
+	for _, row := range t.rows {
+		t.writeRow(&b, row, widths, func(i int, value string) lipgloss.Style {
+			if style := t.columns[i].Style; style != nil {
+				return style(value)
+			}
+
+			return lipgloss.NewStyle()
+		})
+	}
+
+	return b.String()
+}
+
+// fit narrows shrinkable columns, widest first, until the table fits width
+func (t *table) fit(widths []int, width int) {
+	total := ansi.StringWidth(tableGap) * (len(widths) - 1)
+	for _, w := range widths {
+		total += w
+	}
+
+	for total > width {
+		widest := -1
+
+		for i, c := range t.columns {
+			if c.Shrink && widths[i] > minShrinkWidth && (widest < 0 || widths[i] > widths[widest]) {
+				widest = i
+			}
+		}
+
+		if widest < 0 {
+			return
+		}
+
+		shrink := min(total-width, widths[widest]-minShrinkWidth)
+		widths[widest] -= shrink
+		total -= shrink
+	}
+}
+
+func (t *table) writeRow(b *strings.Builder, values []string, widths []int, style func(int, string) lipgloss.Style) {
+	var line strings.Builder
+
+	for i := range t.columns {
+		value := ""
+		if i < len(values) {
+			value = values[i]
+		}
+
+		if ansi.StringWidth(value) > widths[i] {
+			if t.columns[i].KeepStart {
+				value = ansi.Truncate(value, widths[i], "…")
+			} else {
+				value = ansi.TruncateLeft(value, ansi.StringWidth(value)-widths[i]+1, "…")
+			}
+		}
+
+		if i > 0 {
+			line.WriteString(tableGap)
+		}
+
+		if value != "" {
+			line.WriteString(style(i, value).Render(value))
+		}
+
+		// The last column is not padded, so lines carry no trailing spaces
+		if i < len(t.columns)-1 {
+			line.WriteString(strings.Repeat(" ", widths[i]-ansi.StringWidth(value)))
+		}
+	}
+
+	b.WriteString(strings.TrimRight(line.String(), " "))
+	b.WriteString("\n")
+}
@@ -0,0 +1,309 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/humanize"
+	"github.com/inovacc/glix/internal/tasks"
+	"github.com/inovacc/glix/internal/tui"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// tasksCmd represents the tasks parent command
+var tasksCmd = &cobra.Command{
+	Use:   "tasks",
+	Short: "Manage tasks the daemon runs on a schedule",
+	Long: `The daemon runs recurring tasks on cron schedules:
+
+  autoupdate        Update check, when enabled and its interval has passed
+  cache-gc          Delete work directories left by crashed or interrupted runs
+  reconcile         Report missing and orphaned binaries in GOBIN
+  backup            Back up the database to the backups directory
+  catalog-refresh   Sync the shared denylist catalogs
+  version-refresh   Check latest versions of installed modules for outdated
+
+Schedules are five-field cron expressions (minute hour day-of-month month
+day-of-week) or @hourly, @daily, @weekly, @monthly, @yearly, in local time.
+User tasks run a command on a schedule; the command is started directly,
+not through a shell, and is stopped after an hour.
+
+Schedules and user tasks are kept in tasks.json in the config directory;
+the daemon picks up changes within a minute.
+
+Examples:
+  glix tasks list
+  glix tasks run backup
+  glix tasks schedule backup "0 */12 * * *"
+  glix tasks schedule cache-gc --disable
+  glix tasks add nightly-update @daily -- glix update --all
+  glix tasks remove nightly-update`,
+}
+
+// tasksListCmd lists tasks and their last runs
+var tasksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tasks with their schedules and last runs",
+	Args:  cobra.NoArgs,
+	RunE:  runTasksList,
+}
+
+// tasksRunCmd runs a task now
+var tasksRunCmd = &cobra.Command{
+	Use:          "run <name>",
+	Short:        "Run a task now and wait for it to finish",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runTasksRun,
+}
+
+// tasksScheduleCmd changes when a task runs
+var tasksScheduleCmd = &cobra.Command{
+	Use:          "schedule <name> [cron expression]",
+	Short:        "Change the schedule of a task, or enable or disable it",
+	Args:         cobra.RangeArgs(1, 2),
+	SilenceUsage: true,
+	RunE:         runTasksSchedule,
+}
+
+// tasksAddCmd adds a user task
+var tasksAddCmd = &cobra.Command{
+	Use:          "add <name> <cron expression> -- <command> [args...]",
+	Short:        "Add a task that runs a command on a schedule",
+	Args:         cobra.MinimumNArgs(3),
+	SilenceUsage: true,
+	RunE:         runTasksAdd,
+}
+
+// tasksRemoveCmd removes a user task or resets a built-in one
+var tasksRemoveCmd = &cobra.Command{
+	Use:          "remove <name>",
+	Short:        "Remove a user task, or restore a built-in task's default schedule",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runTasksRemove,
+}
+
+var (
+	tasksDisable bool
+	tasksEnable  bool
+)
+
+func init() {
+	rootCmd.AddCommand(tasksCmd)
+	tasksCmd.AddCommand(tasksListCmd)
+	tasksCmd.AddCommand(tasksRunCmd)
+	tasksCmd.AddCommand(tasksScheduleCmd)
+	tasksCmd.AddCommand(tasksAddCmd)
+	tasksCmd.AddCommand(tasksRemoveCmd)
+
+	tasksScheduleCmd.Flags().BoolVar(&tasksDisable, "disable", false, "Stop running the task on its schedule")
+	tasksScheduleCmd.Flags().BoolVar(&tasksEnable, "enable", false, "Run the task on its schedule again")
+	tasksScheduleCmd.MarkFlagsMutuallyExclusive("disable", "enable")
+}
+
+func runTasksList(cmd *cobra.Command, _ []string) error {
+	grpcClient, err := client.GetClient(cmd.Context(), client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	list, err := grpcClient.ListTasks(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	t := newTable(
+		column{Header: "NAME"},
+		column{Header: "SCHEDULE"},
+		column{Header: "NEXT RUN"},
+		column{Header: "LAST RUN"},
+		column{Header: "RESULT", Shrink: true, KeepStart: true, Style: taskResultStyle},
+	)
+
+	for _, task := range list {
+		next := "disabled"
+		if task.GetNextRunUnixNano() > 0 {
+			next = time.Unix(0, task.GetNextRunUnixNano()).Format("2006-01-02 15:04")
+		}
+
+		t.addRow(task.GetName(), task.GetSchedule(), next, taskLastRun(task), taskResult(task))
+	}
+
+	return t.write(cmd.OutOrStdout())
+}
+
+// taskLastRun describes when a task last ran and for how long
+func taskLastRun(task *pb.TaskProto) string {
+	if task.GetLastRunUnixNano() == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%s (%s)",
+		time.Unix(0, task.GetLastRunUnixNano()).Format("2006-01-02 15:04"),
+		humanize.Duration(time.Duration(task.GetLastDurationNano())))
+}
+
+// taskResult describes the outcome of a task's last run
+func taskResult(task *pb.TaskProto) string {
+	switch {
+	case task.GetRunning():
+		return "running"
+	case task.GetLastRunUnixNano() == 0:
+		return ""
+	case task.GetLastSuccess():
+		return "ok: " + task.GetLastResult()
+	default:
+		return "failed: " + task.GetLastResult()
+	}
+}
+
+// taskResultStyle colors a result cell of the tasks table
+func taskResultStyle(result string) lipgloss.Style {
+	switch {
+	case strings.HasPrefix(result, "ok:"):
+		return tui.SuccessStyle
+	case strings.HasPrefix(result, "failed:"):
+		return tui.ErrorStyle
+	default:
+		return tui.WarningStyle
+	}
+}
+
+func runTasksRun(cmd *cobra.Command, args []string) error {
+	grpcClient, err := client.GetClient(cmd.Context(), client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	cmd.Printf("[task] Running %s...\n", args[0])
+
+	start := time.Now()
+
+	result, err := grpcClient.RunTask(cmd.Context(), args[0])
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("[task] %s finished in %s: %s\n", args[0], humanize.Duration(time.Since(start)), result)
+
+	return nil
+}
+
+func runTasksSchedule(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	if len(args) == 1 && !tasksDisable && !tasksEnable {
+		return fmt.Errorf("specify a cron expression, --disable, or --enable")
+	}
+
+	if _, err := lookupTask(cmd, name); err != nil {
+		return err
+	}
+
+	store := tasks.GetStore()
+	c, _ := store.Get(name)
+
+	if len(args) == 2 {
+		c.Schedule = args[1]
+	}
+
+	switch {
+	case tasksDisable:
+		c.Disabled = true
+	case tasksEnable:
+		c.Disabled = false
+	}
+
+	if err := store.Set(name, c); err != nil {
+		return err
+	}
+
+	switch {
+	case c.Disabled:
+		cmd.Printf("Task %s disabled\n", name)
+	case len(args) == 2:
+		cmd.Printf("Task %s scheduled at %q\n", name, c.Schedule)
+	default:
+		cmd.Printf("Task %s enabled\n", name)
+	}
+
+	return nil
+}
+
+func runTasksAdd(cmd *cobra.Command, args []string) error {
+	name, schedule := args[0], args[1]
+
+	if dash := cmd.ArgsLenAtDash(); dash != 2 {
+		return fmt.Errorf("separate the command with --, e.g. glix tasks add %s %q -- glix update --all", name, schedule)
+	}
+
+	if err := tasks.ValidateName(name); err != nil {
+		return err
+	}
+
+	if task, err := lookupTask(cmd, name); err == nil {
+		if task.GetBuiltin() {
+			return fmt.Errorf("%s is a built-in task; use 'glix tasks schedule' to change it", name)
+		}
+
+		return fmt.Errorf("task %s already exists", name)
+	}
+
+	if err := tasks.GetStore().Set(name, tasks.TaskConfig{Schedule: schedule, Command: args[2:]}); err != nil {
+		return err
+	}
+
+	cmd.Printf("Task %s added\n", name)
+
+	return nil
+}
+
+func runTasksRemove(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	if err := tasks.GetStore().Delete(name); err != nil {
+		return err
+	}
+
+	cmd.Printf("Task %s removed\n", name)
+
+	return nil
+}
+
+// lookupTask returns a task known to the daemon
+func lookupTask(cmd *cobra.Command, name string) (*pb.TaskProto, error) {
+	grpcClient, err := client.GetClient(cmd.Context(), client.DefaultDiscoveryConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	list, err := grpcClient.ListTasks(cmd.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	i := slices.IndexFunc(list, func(t *pb.TaskProto) bool { return t.GetName() == name })
+	if i < 0 {
+		return nil, fmt.Errorf("unknown task %q", name)
+	}
+
+	return list[i], nil
+}
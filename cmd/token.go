@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/humanize"
+	"github.com/inovacc/glix/internal/server"
+	"github.com/inovacc/glix/internal/tui"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// tokenCmd represents the token command
+var tokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage scoped API tokens for automation",
+	Long: `Manage API tokens, so CI agents and dashboards can talk to a shared
+daemon with no more privilege than they need.
+
+Each token has a scope:
+  read     Queries: list, info, outdated, history, status
+  install  Read, and recording installs, updates and removes
+  admin    Everything, including running tasks and managing tokens
+
+Clients on the daemon's machine need no token. Clients on other machines
+may call everything until the first token is created; from then on they
+need a token, sent over TLS, from $GLIX_TOKEN or the --token-file of their
+context (see 'glix context'). Only the hash of a token is stored, so the
+token is shown once, when it is created; creating a token of the same name
+replaces it.
+
+Managing tokens takes an admin token or a client on the daemon's machine.
+
+Examples:
+  glix token create dashboard --scope read
+  glix token create ci --scope install --expires 720h > ci-token
+  glix token list
+  glix token revoke ci`,
+}
+
+var tokenCreateCmd = &cobra.Command{
+	Use:          "create <name>",
+	Short:        "Create a token and print it",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runTokenCreate,
+}
+
+var tokenListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List tokens with their scope and expiry",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runTokenList,
+}
+
+var tokenRevokeCmd = &cobra.Command{
+	Use:               "revoke <name>",
+	Short:             "Revoke a token",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTokens,
+	SilenceUsage:      true,
+	RunE:              runTokenRevoke,
+}
+
+var (
+	tokenScope   string
+	tokenExpires time.Duration
+)
+
+func init() {
+	rootCmd.AddCommand(tokenCmd)
+	tokenCmd.AddCommand(tokenCreateCmd, tokenListCmd, tokenRevokeCmd)
+
+	tokenCreateCmd.Flags().StringVar(&tokenScope, "scope", server.ScopeRead, "What the token may do: "+strings.Join(server.Scopes, ", "))
+	tokenCreateCmd.Flags().DurationVar(&tokenExpires, "expires", 90*24*time.Hour, "Lifetime of the token (0 = never expires)")
+	_ = tokenCreateCmd.RegisterFlagCompletionFunc("scope", cobra.FixedCompletions(server.Scopes, cobra.ShellCompDirectiveNoFileComp))
+}
+
+func runTokenCreate(cmd *cobra.Command, args []string) error {
+	if !server.ValidScope(tokenScope) {
+		return fmt.Errorf("unknown scope %q: want %s", tokenScope, strings.Join(server.Scopes, ", "))
+	}
+
+	if tokenExpires < 0 {
+		return fmt.Errorf("--expires must not be negative")
+	}
+
+	ctx := cmd.Context()
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	resp, err := grpcClient.CreateToken(ctx, args[0], tokenScope, tokenExpires)
+	if err != nil {
+		return err
+	}
+
+	if structuredOutput() {
+		return printMessage(cmd, resp)
+	}
+
+	expires := "never expires"
+	if at := resp.GetToken().GetExpiresUnixNano(); at > 0 {
+		expires = "expires " + time.Unix(0, at).Format("2006-01-02 15:04")
+	}
+
+	// The token goes to stdout alone, so it can be redirected to a file
+	cmd.Printf("Created %s token %s (%s); it is not shown again:\n", resp.GetToken().GetScope(), resp.GetToken().GetName(), expires)
+
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), resp.GetValue())
+
+	return err
+}
+
+func runTokenList(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	tokens, err := grpcClient.ListTokens(ctx)
+	if err != nil {
+		return err
+	}
+
+	if structuredOutput() {
+		return printMessage(cmd, &pb.ListTokensResponse{Tokens: tokens})
+	}
+
+	if len(tokens) == 0 {
+		cmd.Println("No tokens; clients on other machines need none until one is created with 'glix token create'")
+		return nil
+	}
+
+	t := newTable(
+		column{Header: "NAME", Shrink: true},
+		column{Header: "SCOPE"},
+		column{Header: "CREATED"},
+		column{Header: "EXPIRES", Style: tokenExpiresStyle},
+		column{Header: "LAST USED"},
+	)
+
+	now := time.Now()
+
+	for _, token := range tokens {
+		expires := "never"
+		if at := time.Unix(0, token.GetExpiresUnixNano()); token.GetExpiresUnixNano() > 0 {
+			expires = humanize.Ago(at, now)
+			if !at.After(now) {
+				expires = "expired"
+			}
+		}
+
+		used := "never"
+		if at := token.GetLastUsedUnixNano(); at > 0 {
+			used = humanize.Ago(time.Unix(0, at), now)
+		}
+
+		t.addRow(token.GetName(), token.GetScope(), time.Unix(0, token.GetCreatedUnixNano()).Format("2006-01-02 15:04"), expires, used)
+	}
+
+	return t.write(cmd.OutOrStdout())
+}
+
+// tokenExpiresStyle highlights expired tokens
+func tokenExpiresStyle(expires string) lipgloss.Style {
+	if expires == "expired" {
+		return tui.ErrorStyle
+	}
+
+	return lipgloss.NewStyle()
+}
+
+func runTokenRevoke(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	if err := grpcClient.RevokeToken(ctx, args[0]); err != nil {
+		return err
+	}
+
+	cmd.Printf("Revoked token %s\n", args[0])
+
+	return nil
+}
+
+// completeTokens completes the names of API tokens
+func completeTokens(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), completionTimeout)
+	defer cancel()
+
+	// Log lines would end up in the shell's completion output
+	cfg := client.DefaultDiscoveryConfig()
+	cfg.Logger = nil
+
+	grpcClient, err := client.GetClient(ctx, cfg)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	tokens, err := grpcClient.ListTokens(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+
+	for _, token := range tokens {
+		if strings.HasPrefix(token.GetName(), toComplete) {
+			names = append(names, token.GetName()+"\t"+token.GetScope())
+		}
+	}
+
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -2,16 +2,23 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/inovacc/glix/internal/batch"
 	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/constraints"
+	"github.com/inovacc/glix/internal/hooks"
 	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/modver"
+	"github.com/inovacc/glix/internal/profiles"
+	"github.com/inovacc/glix/internal/quarantine"
 	"github.com/inovacc/glix/internal/tui"
+	pb "github.com/inovacc/glix/pkg/api/v1"
 	"github.com/spf13/cobra"
-	"golang.org/x/mod/semver"
 )
 
 // updateCmd represents the update command
@@ -22,31 +29,79 @@ var updateCmd = &cobra.Command{
 This will fetch the latest version from the Go proxy, install it,
 and update the database entry.
 
+With --all every installed module is updated, one after another, ordered
+by --order: size updates the smallest binaries first, deps the modules with
+the fewest dependencies, name alphabetically. The progress is saved after
+each module, so an interrupted batch resumes where it left off on the next
+'glix update --all' (--restart starts over). A summary lists the outcome
+and duration of every module.
+
+Held modules (see 'glix hold') are skipped unless --ignore-hold is given.
+Updates are checked against declared constraints (see 'glix constraint')
+and refused when they would break one; --ignore-constraints overrides.
+
+An installed version its author retracted is replaced by the latest
+version, even when that is a downgrade; --allow-retracted keeps it.
+
+Modules installed with --pre update to newer pre-releases; --pre here
+records the preference for a module installed without it.
+
 Example:
   glix update github.com/inovacc/twig
-  glix update twig`,
-	Args: cobra.ExactArgs(1),
-	RunE: runUpdate,
+  glix update twig
+  glix update --all
+  glix update --all --order deps`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeInstalledModules,
+	RunE:              runUpdate,
 }
 
+var (
+	updateAllowRetracted    bool
+	updateIgnoreConstraints bool
+	updateIgnoreHold        bool
+	updateStallAfter        time.Duration
+)
+
 func init() {
 	rootCmd.AddCommand(updateCmd)
+
+	updateCmd.Flags().BoolVar(&updateIgnoreHold, "ignore-hold", false, "Update even if the module is held")
+	updateCmd.Flags().BoolVar(&updateAllowRetracted, "allow-retracted", false, "Keep installed versions their author retracted instead of moving off them")
+	updateCmd.Flags().BoolVar(&updateIgnoreConstraints, "ignore-constraints", false, "Update even if declared constraints would be violated")
+	updateCmd.Flags().DurationVar(&updateStallAfter, "stall-threshold", module.StallThreshold(), "Warn that the build may be stalled after printing nothing for this long")
 }
 
 func runUpdate(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 
+	if _, err := module.ParseBuildStrategy(strategyFlag); err != nil {
+		return err
+	}
+
+	if updateAll {
+		if len(args) > 0 {
+			return fmt.Errorf("--all updates every module; drop %q or --all", args[0])
+		}
+
+		return runUpdateAll(cmd)
+	}
+
+	if len(args) == 0 {
+		return fmt.Errorf("requires a module, or --all to update every module")
+	}
+
 	// Parse module path (strip URL prefixes if any)
 	modulePath, _ := parseModulePath(args[0])
 
 	if IsTUIEnabled() {
-		return runUpdateWithTUI(ctx, modulePath)
+		return runUpdateWithTUI(ctx, cmd, modulePath)
 	}
 
 	return runUpdatePlainText(ctx, cmd, modulePath)
 }
 
-func runUpdateWithTUI(ctx context.Context, modulePath string) error {
+func runUpdateWithTUI(ctx context.Context, cmd *cobra.Command, modulePath string) error {
 	// Create TUI instance
 	t := tui.New()
 
@@ -59,7 +114,7 @@ func runUpdateWithTUI(ctx context.Context, modulePath string) error {
 
 	// Run update in background
 	go func() {
-		errCh <- doUpdate(tuiCtx, modulePath, t.ProgressHandler(), t.OutputHandler(), t.SetStatus)
+		errCh <- doUpdate(tuiCtx, cmd, modulePath, t.ProgressHandler(), t.OutputHandler(), t.SetStatus)
 	}()
 
 	// Wait for update to complete
@@ -77,32 +132,39 @@ func runUpdateWithTUI(ctx context.Context, modulePath string) error {
 }
 
 func runUpdatePlainText(ctx context.Context, cmd *cobra.Command, modulePath string) error {
-	progressHandler := func(phase, message string) {
-		cmd.Printf("[%s] %s\n", phase, message)
-	}
-
-	outputHandler := func(stream, line string) {
-		if stream == "stderr" {
-			_, _ = fmt.Fprintln(cmd.ErrOrStderr(), line)
-		} else {
-			_, _ = fmt.Fprintln(cmd.OutOrStdout(), line)
-		}
-	}
-
-	statusHandler := func(text string) {
-		cmd.Printf("Status: %s\n", text)
-	}
+	r := newPlainRenderer(cmd, true)
 
-	return doUpdate(ctx, modulePath, progressHandler, outputHandler, statusHandler)
+	return r.Finish(doUpdate(ctx, cmd, modulePath, r.Progress, r.Output, r.Status))
 }
 
 func doUpdate(
 	ctx context.Context,
+	cmd *cobra.Command,
 	modulePath string,
 	progressHandler func(phase, message string),
 	outputHandler func(stream, line string),
 	statusHandler func(text string),
 ) error {
+	_, err := updateModule(ctx, cmd, modulePath, progressHandler, outputHandler, statusHandler)
+	return err
+}
+
+// updateOutcome is what an update of one module came to
+type updateOutcome struct {
+	status batch.Status
+	to     string // Version updated to
+	detail string // Why the module was skipped
+}
+
+// updateModule updates one installed module and reports the outcome
+func updateModule(
+	ctx context.Context,
+	cmd *cobra.Command,
+	modulePath string,
+	progressHandler func(phase, message string),
+	outputHandler func(stream, line string),
+	statusHandler func(text string),
+) (updateOutcome, error) {
 	statusHandler(fmt.Sprintf("Updating %s", modulePath))
 
 	// Connect to server to get current module info
@@ -110,7 +172,7 @@ func doUpdate(
 
 	grpcClient, err := client.GetClient(ctx, cfg)
 	if err != nil {
-		return fmt.Errorf("failed to connect to server: %w", err)
+		return updateOutcome{}, fmt.Errorf("failed to connect to server: %w", err)
 	}
 
 	defer func() {
@@ -122,27 +184,34 @@ func doUpdate(
 
 	resp, err := grpcClient.GetModule(ctx, modulePath, "")
 	if err != nil {
-		return fmt.Errorf("failed to query module: %w", err)
+		return updateOutcome{}, fmt.Errorf("failed to query module: %w", err)
 	}
 
 	if !resp.GetFound() {
-		return fmt.Errorf("module %q is not installed, use 'glix install %s' first", modulePath, modulePath)
+		return updateOutcome{}, fmt.Errorf("module %q is not installed, use 'glix install %s' first", modulePath, modulePath)
 	}
 
 	installedModule := resp.GetModule()
 	installedVersion := installedModule.GetVersion()
 
+	if installedModule.GetLocalPath() != "" {
+		return updateOutcome{}, fmt.Errorf("module %q was installed from %s, reinstall it with 'glix install %s'",
+			modulePath, installedModule.GetLocalPath(), installedModule.GetLocalPath())
+	}
+
 	progressHandler("check", fmt.Sprintf("Installed: %s@%s", modulePath, installedVersion))
 
-	// Create a unique working directory for this update
-	cacheDir, err := module.GetApplicationCacheDirectory()
-	if err != nil {
-		return fmt.Errorf("failed to get cache directory: %w", err)
+	if held := describeHold(modulePath); held != "" && !updateIgnoreHold {
+		progressHandler("complete", fmt.Sprintf("Skipping %s: %s", modulePath, held))
+		statusHandler(fmt.Sprintf("Held: %s@%s", modulePath, installedVersion))
+
+		return updateOutcome{status: batch.StatusSkipped, detail: held}, nil
 	}
 
-	workDir := filepath.Join(cacheDir, fmt.Sprintf("update-%d", time.Now().UnixNano()))
-	if err := os.MkdirAll(workDir, 0755); err != nil {
-		return fmt.Errorf("failed to create working directory: %w", err)
+	// Create a unique working directory for this update
+	workDir, err := module.NewWorkDir("update")
+	if err != nil {
+		return updateOutcome{}, err
 	}
 
 	defer func() {
@@ -152,27 +221,128 @@ func doUpdate(
 	// Create module instance to fetch latest version info
 	m, err := module.NewModule(ctx, "go", workDir)
 	if err != nil {
-		return fmt.Errorf("failed to create module: %w", err)
+		return updateOutcome{}, fmt.Errorf("failed to create module: %w", err)
 	}
 
 	// Set progress handler
 	m.SetProgressHandler(progressHandler)
+	m.SetStallThreshold(updateStallAfter)
+	m.SetVersionStore(grpcClient.VersionStore())
+
+	// Aliased binaries stay under their alias, in their bin directory
+	m.Alias = installedModule.GetAlias()
+	m.SetBinDir(installedModule.GetBinDir())
+	m.SetRecordedPlatform(installedModule.GetPlatform())
+	m.SetBuildFlags(buildFlags(cmd, installedModule.GetBuildFlags()))
+	m.SetPreferBinary(preferBinaries(cmd, installedModule.GetPreferBinary()))
+	m.SetPrerelease(prereleases(cmd, installedModule.GetPrerelease()))
+	m.SetShim(installedModule.GetShimPath() != "")
+
+	strategy, err := buildStrategy(cmd, installedModule.GetBuildStrategy())
+	if err != nil {
+		return updateOutcome{}, err
+	}
+
+	m.SetBuildStrategy(strategy)
+	proxy, err := goProxy(cmd, installedModule.GetGoproxy())
+	if err != nil {
+		return updateOutcome{}, err
+	}
+
+	m.SetGoProxy(proxy)
+	m.SetPrivate(privateModules(cmd, installedModule.GetPrivate()))
+	m.SetOffline(offline())
+	m.SetConstraint(installedModule.GetVersionConstraint())
+	m.SetAllowRetracted(updateAllowRetracted)
+
+	if err := profiles.Apply(m, installedModule.GetProfile()); err != nil {
+		return updateOutcome{}, err
+	}
+
+	if err := quarantine.Apply(m); err != nil {
+		return updateOutcome{}, err
+	}
+
+	if err := hooks.Apply(m); err != nil {
+		return updateOutcome{}, err
+	}
+
+	m.SetPreviousVersion(installedVersion)
 
 	// Fetch latest module info
-	progressHandler("fetch", "Fetching latest version information...")
+	if m.Offline() {
+		progressHandler("fetch", "Offline: looking for newer versions in the local module cache...")
+	} else {
+		progressHandler("fetch", "Fetching latest version information...")
+	}
 
 	if err := m.FetchModuleInfo(modulePath); err != nil {
-		return fmt.Errorf("failed to fetch module info: %w", err)
+		recordFailure(ctx, grpcClient, pb.EventAction_EVENT_ACTION_UPDATE, modulePath, installedVersion, "", err)
+		return updateOutcome{}, fmt.Errorf("failed to fetch module info: %w", err)
 	}
 
 	latestVersion := m.Version
 
-	// Compare versions
-	if !isNewerVersion(latestVersion, installedVersion) {
-		progressHandler("complete", fmt.Sprintf("Already at latest version: %s@%s", modulePath, installedVersion))
+	// Releases beyond the recorded range are pointed out, never installed
+	if c := m.Constraint(); c != "" && len(m.Versions) > 0 && modver.Newer(m.Versions[0], latestVersion) {
+		progressHandler("versions", fmt.Sprintf("%s is outside %s; install it with 'glix install %s@%s'", m.Versions[0], c, modulePath, m.Versions[0]))
+	}
+
+	// A retracted install moves to the latest version, even a lower one
+	floor := installedVersion
+
+	if rationale := m.RetractedInstall(installedVersion); rationale != "" {
+		progressHandler("versions", fmt.Sprintf("%s@%s is retracted by its author (%s); moving to %s", modulePath, installedVersion, rationale, latestVersion))
+
+		floor = ""
+	} else if !m.UpdatePolicy().IsUpdate(latestVersion, installedVersion) {
+		if c := m.Constraint(); c != "" {
+			progressHandler("complete", fmt.Sprintf("Already at newest version within %s: %s@%s", c, modulePath, installedVersion))
+		} else if m.Offline() {
+			progressHandler("complete", fmt.Sprintf("Already at the newest cached version: %s@%s", modulePath, installedVersion))
+		} else {
+			progressHandler("complete", fmt.Sprintf("Already at latest version: %s@%s", modulePath, installedVersion))
+		}
 		statusHandler(fmt.Sprintf("Up to date: %s@%s", modulePath, installedVersion))
 
-		return nil
+		return updateOutcome{status: batch.StatusCurrent}, nil
+	}
+
+	// Denied and reported-broken versions fall back to the newest allowed
+	// update; offline, the catalogs synced last are checked
+	if !m.Offline() {
+		syncDenylist(ctx, progressHandler)
+	}
+
+	ok, err := resolveAllowed(m, floor, installedModule.GetBadVersions(), progressHandler)
+	if err != nil {
+		return updateOutcome{}, err
+	}
+
+	if !ok {
+		progressHandler("complete", fmt.Sprintf("Skipping %s: no allowed update", modulePath))
+		statusHandler(fmt.Sprintf("No allowed update: %s@%s", modulePath, installedVersion))
+
+		return updateOutcome{status: batch.StatusSkipped, detail: "no allowed update"}, nil
+	}
+
+	latestVersion = m.Version
+
+	if !updateIgnoreConstraints {
+		progressHandler("constraints", "Checking constraints...")
+
+		if err := checkUpdateConstraints(ctx, grpcClient, m); err != nil {
+			return updateOutcome{}, err
+		}
+	}
+
+	if err := enforcePolicy(ctx, m, progressHandler); err != nil {
+		return updateOutcome{}, err
+	}
+
+	namespace := serverNamespace(ctx, grpcClient)
+	if err := checkOwnership(m.InstallPath(), namespace, progressHandler); err != nil {
+		return updateOutcome{}, err
 	}
 
 	progressHandler("update", fmt.Sprintf("Updating %s: %s -> %s", modulePath, installedVersion, latestVersion))
@@ -180,9 +350,21 @@ func doUpdate(
 
 	// Install the new version locally with streaming output
 	if err := m.InstallModuleWithStreaming(ctx, outputHandler); err != nil {
-		return fmt.Errorf("update failed: %w", err)
+		recordFailure(ctx, grpcClient, pb.EventAction_EVENT_ACTION_UPDATE, m.Name, installedVersion, m.Version, err)
+
+		var binDirErr *module.BinDirError
+		if errors.As(err, &binDirErr) {
+			return updateOutcome{}, fmt.Errorf("%w; move the binary to a writable directory with 'glix install --bin-dir <dir> %s'", err, m.Name)
+		}
+
+		return updateOutcome{}, fmt.Errorf("update failed: %w", err)
 	}
 
+	registerKubectlPlugin(m, installedModule.GetKubectlPlugin() != "", progressHandler)
+	claimOwnership(m, namespace, progressHandler)
+	writeReceipt(m.Name, m.Version, m.BinaryPath, m.ShimPath, namespace, progressHandler)
+	captureHelp(ctx, m, progressHandler)
+
 	// Store updated module info in database via server
 	progressHandler("store", "Saving to database...")
 
@@ -190,35 +372,39 @@ func doUpdate(
 		progressHandler("warning", fmt.Sprintf("failed to update module in database: %v", err))
 	}
 
+	auditInstalled(ctx, grpcClient, m, progressHandler)
+
 	progressHandler("complete", fmt.Sprintf("Updated %s: %s -> %s", m.Name, installedVersion, latestVersion))
 	statusHandler(fmt.Sprintf("Updated %s@%s", m.Name, latestVersion))
 
-	return nil
+	return updateOutcome{status: batch.StatusUpdated, to: latestVersion}, nil
 }
 
-// isNewerVersion compares two versions and returns true if newVer is newer than oldVer
-func isNewerVersion(newVer, oldVer string) bool {
-	// Ensure versions have 'v' prefix for semver comparison
-	if newVer != "" && newVer[0] != 'v' {
-		newVer = "v" + newVer
+// checkUpdateConstraints refuses an install or update that would violate a
+// declared constraint against the currently installed modules
+func checkUpdateConstraints(ctx context.Context, grpcClient *client.Client, m *module.Module) error {
+	declared := constraints.GetStore().List()
+	if len(declared) == 0 {
+		return nil
 	}
 
-	if oldVer != "" && oldVer[0] != 'v' {
-		oldVer = "v" + oldVer
+	resp, err := grpcClient.ListModules(ctx, 0, 0, "")
+	if err != nil {
+		return fmt.Errorf("failed to list modules: %w", err)
 	}
 
-	// If versions are identical, no update needed
-	if newVer == oldVer {
-		return false
+	conflicts := constraints.CheckUpdate(declared, resp.GetModules(), m)
+	if len(conflicts) == 0 {
+		return nil
 	}
 
-	// Try semver comparison first
-	cmp := semver.Compare(newVer, oldVer)
-	if cmp != 0 {
-		return cmp > 0
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s@%s violates %d constraint(s):", m.Name, m.Version, len(conflicts))
+
+	for _, c := range conflicts {
+		fmt.Fprintf(&b, "\n  - %s", c)
 	}
 
-	// For pseudo-versions or non-standard versions, do string comparison
-	// Pseudo-versions like v0.0.0-20260108194045-146fb9cee2cb contain timestamps
-	return newVer > oldVer
+	return errors.New(b.String())
 }
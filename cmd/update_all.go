@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/inovacc/glix/internal/batch"
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/humanize"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/tui"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+var (
+	updateAll     bool
+	updateOrder   string
+	updateRestart bool
+)
+
+func init() {
+	updateCmd.Flags().BoolVar(&updateAll, "all", false, "Update every installed module, resuming an interrupted batch")
+	updateCmd.Flags().StringVar(&updateOrder, "order", batch.OrderSize, "Order of --all: size (smallest binaries first), deps (fewest dependencies first) or name")
+	updateCmd.Flags().BoolVar(&updateRestart, "restart", false, "Discard an interrupted --all batch and start over")
+}
+
+// runUpdateAll updates every installed module one after another, saving the
+// progress after each so an interrupted run resumes where it left off, and
+// prints a summary with the outcome and duration of each module
+func runUpdateAll(cmd *cobra.Command) error {
+	// Failures are reported per module; usage would only bury them
+	cmd.SilenceUsage = true
+
+	// An interrupt cancels ctx, which stops the batch after saving it
+	ctx := cmd.Context()
+
+	out := newPlainRenderer(cmd, false)
+
+	b, err := loadUpdateBatch(ctx, out)
+	if err != nil {
+		return err
+	}
+
+	if len(b.Items) == 0 {
+		out.Printf("No modules installed\n")
+		return batch.Clear()
+	}
+
+	total := len(b.Items)
+
+	for i := range b.Items {
+		item := &b.Items[i]
+		if item.Status != batch.StatusPending {
+			continue
+		}
+
+		out.Printf("[%d/%d] %s\n", i+1, total, item.Module)
+
+		if err := updateBatchItem(ctx, cmd, out, item); err != nil {
+			// Interrupted: the module stays pending and is retried on resume
+			if saveErr := batch.Save(b); saveErr != nil {
+				return saveErr
+			}
+
+			out.Printf("\nInterrupted; run 'glix update --all' to resume\n")
+
+			return err
+		}
+
+		if err := batch.Save(b); err != nil {
+			return err
+		}
+	}
+
+	err = printUpdateSummary(out, b)
+	if clearErr := batch.Clear(); err == nil {
+		err = clearErr
+	}
+
+	return out.Finish(err)
+}
+
+// loadUpdateBatch returns the interrupted batch to resume, or a new batch of
+// every installed module in the requested order
+func loadUpdateBatch(ctx context.Context, out *plainRenderer) (*batch.Batch, error) {
+	b, err := batch.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	if b != nil && !b.Done() && !updateRestart {
+		counts := b.Counts()
+		out.Printf("Resuming the update batch started %s: %d of %d module(s) done\n",
+			b.Started.Local().Format(time.DateTime), len(b.Items)-counts[batch.StatusPending], len(b.Items))
+
+		return b, nil
+	}
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	resp, err := grpcClient.ListModules(ctx, 0, 0, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	items := make([]batch.Item, 0, len(resp.GetModules()))
+	for _, mod := range resp.GetModules() {
+		item := batch.Item{
+			Module: mod.GetName(),
+			From:   mod.GetVersion(),
+			Weight: updateWeight(mod, updateOrder),
+		}
+
+		// Local installs have no newer version to fetch
+		if local := mod.GetLocalPath(); local != "" {
+			item.Status = batch.StatusSkipped
+			item.Detail = "installed from " + local
+		}
+
+		items = append(items, item)
+	}
+
+	b, err = batch.New(updateOrder, items)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := batch.Save(b); err != nil {
+		return nil, err
+	}
+
+	out.Printf("Updating %d module(s), ordered by %s\n", len(b.Items), b.Order)
+
+	return b, nil
+}
+
+// updateWeight returns what a module is ordered by: the size of its binary,
+// or its number of dependencies
+func updateWeight(mod *pb.ModuleProto, order string) int64 {
+	switch order {
+	case batch.OrderSize:
+		_, binPath := module.RecordedBinary(mod)
+
+		if info, err := os.Stat(binPath); err == nil {
+			return info.Size()
+		}
+	case batch.OrderDeps:
+		return int64(len(mod.GetDependencies()))
+	}
+
+	return 0
+}
+
+// updateBatchItem updates one module of a batch, recording its outcome in
+// item. It only fails when the batch was interrupted.
+func updateBatchItem(ctx context.Context, cmd *cobra.Command, out *plainRenderer, item *batch.Item) error {
+	start := time.Now()
+
+	var mu sync.Mutex
+
+	var output []string
+
+	outputHandler := func(stream, line string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		output = append(output, line)
+	}
+
+	// Only the start and the outcome of each update are worth a line
+	quietProgress := func(phase, message string) {
+		if phase == "update" || phase == "warning" || phase == "complete" {
+			out.Progress(phase, message)
+		}
+	}
+
+	outcome, err := updateModule(ctx, cmd, item.Module, quietProgress, outputHandler, func(string) {})
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	item.Duration = time.Since(start)
+
+	if err != nil {
+		item.Status = batch.StatusFailed
+		item.Detail = strings.ReplaceAll(err.Error(), "\n", " ")
+
+		out.Progress("failed", err.Error())
+
+		for _, line := range output {
+			out.Printf("  %s\n", line)
+		}
+
+		return nil
+	}
+
+	item.Status, item.To, item.Detail = outcome.status, outcome.to, outcome.detail
+
+	return nil
+}
+
+// printUpdateSummary prints the outcome and duration of every module of a
+// batch, and fails when any update failed
+func printUpdateSummary(out *plainRenderer, b *batch.Batch) error {
+	out.Printf("\n")
+
+	t := newTable(
+		column{Header: "STATUS", Style: batchStatusStyle},
+		column{Header: "MODULE", Shrink: true},
+		column{Header: "FROM"},
+		column{Header: "TO"},
+		column{Header: "TIME"},
+		column{Header: "DETAIL", Shrink: true, KeepStart: true},
+	)
+
+	var total time.Duration
+
+	for _, item := range b.Items {
+		total += item.Duration
+
+		duration := ""
+		if item.Duration > 0 {
+			duration = humanize.Duration(item.Duration)
+		}
+
+		t.addRow(string(item.Status), item.Module, item.From, item.To, duration, item.Detail)
+	}
+
+	if !quietOutput {
+		if err := t.write(out.cmd.OutOrStdout()); err != nil {
+			return err
+		}
+	}
+
+	counts := b.Counts()
+	summary := fmt.Sprintf("%d updated, %d up to date, %d skipped, %d failed in %s",
+		counts[batch.StatusUpdated], counts[batch.StatusCurrent], counts[batch.StatusSkipped], counts[batch.StatusFailed],
+		humanize.Duration(total))
+
+	out.Printf("\n%s\n", summary)
+
+	if counts[batch.StatusFailed] > 0 {
+		return fmt.Errorf("%d of %d module(s) failed to update", counts[batch.StatusFailed], len(b.Items))
+	}
+
+	out.Status(summary)
+
+	return nil
+}
+
+// batchStatusStyle colors a status cell of the update summary
+func batchStatusStyle(status string) lipgloss.Style {
+	switch batch.Status(status) {
+	case batch.StatusUpdated, batch.StatusCurrent:
+		return tui.SuccessStyle
+	case batch.StatusSkipped, batch.StatusPending:
+		return tui.WarningStyle
+	default:
+		return tui.ErrorStyle
+	}
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/module"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+)
+
+// useCmd represents the use command
+var useCmd = &cobra.Command{
+	Use:   "use <tool>[@version]",
+	Short: "Switch the active version of a tool installed with shims",
+	Long: `Make another installed version of a tool the one its shim runs.
+
+Tools installed with 'glix install --shim' keep every installed version side
+by side in the version store, and a small shim in GOBIN runs the active one.
+Switching rewrites the shim and the database record, without rebuilding or
+network access. Versions that are not stored yet are installed with
+'glix install --shim <module>@<version>', which makes them active too.
+
+Without a version, the stored versions are listed. The tool may be given by
+module path or by binary name.
+
+Examples:
+  glix use golangci-lint
+  glix use golangci-lint@v2.1.0
+  glix use github.com/golangci/golangci-lint/v2/cmd/golangci-lint@v2.3.0`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeInstalledModules,
+	SilenceUsage:      true,
+	RunE:              runUse,
+}
+
+func init() {
+	rootCmd.AddCommand(useCmd)
+}
+
+func runUse(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	tool, version := parseModulePath(args[0])
+
+	mod, err := findInstalledTool(ctx, grpcClient, tool)
+	if err != nil {
+		return err
+	}
+
+	if mod.GetShimPath() == "" {
+		return fmt.Errorf("%s was not installed with a shim; reinstall it with 'glix install --shim %s'", mod.GetName(), mod.GetName())
+	}
+
+	if version == "" {
+		return printStoredVersions(cmd, mod)
+	}
+
+	if version == mod.GetVersion() {
+		cmd.Printf("%s@%s is already active\n", mod.GetName(), version)
+		return nil
+	}
+
+	if reason := excludedReason(mod.GetName(), version, mod.GetBadVersions()); reason != "" {
+		return fmt.Errorf("%s@%s is %s", mod.GetName(), version, reason)
+	}
+
+	if _, ok := module.StoredBinary(mod.GetName(), version); !ok {
+		stored := module.StoredVersions(mod.GetName())
+
+		return fmt.Errorf("%s@%s is not installed (stored: %s); install it with 'glix install %s@%s'",
+			mod.GetName(), version, strings.Join(stored, ", "), mod.GetName(), version)
+	}
+
+	history, err := grpcClient.GetInstallHistory(ctx, mod.GetName())
+	if err != nil {
+		return err
+	}
+
+	if err := useVersion(ctx, cmd, grpcClient, mod, version, installRecord(history, version)); err != nil {
+		return err
+	}
+
+	cmd.Printf("Switched %s: %s -> %s\n", mod.GetName(), mod.GetVersion(), version)
+
+	return nil
+}
+
+// useVersion points the shim of mod at its stored binary of version and
+// records that version as installed, restoring its install record when
+// there is one
+func useVersion(ctx context.Context, cmd *cobra.Command, grpcClient *client.Client, mod *pb.ModuleProto, version string, record *pb.ModuleProto) error {
+	binPath, ok := module.StoredBinary(mod.GetName(), version)
+	if !ok {
+		return fmt.Errorf("%s@%s is not in the version store", mod.GetName(), version)
+	}
+
+	if record == nil {
+		// The install history lost the version; the binary tells how it was built
+		record = proto.Clone(mod).(*pb.ModuleProto)
+		record.Version = version
+		record.BinaryName = strings.TrimSuffix(filepath.Base(binPath), ".exe")
+		record.BinaryHash, _ = module.HashBinary(binPath, module.ConfiguredHashAlgorithm())
+		record.Provenance, _ = module.ReadProvenance(binPath)
+		record.Dependencies = nil
+		record.Sum = ""
+		record.GoModSum = ""
+	}
+
+	restored := restoreRecord(mod, record, binPath)
+
+	cmd.Printf("[shim] Pointing %s at %s\n", mod.GetShimPath(), binPath)
+
+	if err := module.WriteShim(mod.GetShimPath(), binPath); err != nil {
+		return err
+	}
+
+	writeReceipt(restored.GetName(), restored.GetVersion(), binPath, restored.GetShimPath(), serverNamespace(ctx, grpcClient), func(phase, message string) {
+		cmd.Printf("[%s] %s\n", phase, message)
+	})
+
+	return grpcClient.StoreModuleRecord(ctx, restored)
+}
+
+// printStoredVersions lists the versions of a shimmed module in the version
+// store, marking the active one
+func printStoredVersions(cmd *cobra.Command, mod *pb.ModuleProto) error {
+	versions := module.StoredVersions(mod.GetName())
+	if len(versions) == 0 {
+		cmd.Printf("No stored versions of %s\n", mod.GetName())
+		return nil
+	}
+
+	t := newTable(
+		column{Header: "ACTIVE"},
+		column{Header: "VERSION"},
+		column{Header: "BINARY", Shrink: true},
+	)
+
+	for _, v := range versions {
+		active := ""
+		if v == mod.GetVersion() {
+			active = "*"
+		}
+
+		binPath, _ := module.StoredBinary(mod.GetName(), v)
+		t.addRow(active, v, binPath)
+	}
+
+	return t.write(cmd.OutOrStdout())
+}
@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/tui"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// errBinariesChanged makes verify exit non-zero so it can gate CI jobs
+var errBinariesChanged = errors.New("installed binaries do not match their install records")
+
+// errSumsMismatch makes verify --sums exit non-zero on tampered modules
+var errSumsMismatch = errors.New("module checksums do not match their install records")
+
+// verifyCmd represents the verify command
+var verifyCmd = &cobra.Command{
+	Use:   "verify [module|binary...]",
+	Short: "Check installed binaries against the hashes recorded at install",
+	Long: `Hash the installed binaries in GOBIN and compare them with the hash
+recorded when glix installed them, to detect binaries that were tampered
+with or replaced outside glix. Binaries are hashed with SHA-256, or the
+algorithm GLIX_HASH_ALGORITHM names (sha256 or sha512); each binary is
+verified with the algorithm its hash was recorded with.
+
+  ok          The binary is unchanged
+  MODIFIED    A build of the same module and version with different bytes,
+              for example a patched binary
+  REPLACED    A build of another module or version, or not a Go binary,
+              for example after a plain 'go install'
+  MISSING     No binary where glix installed it
+  unrecorded  Installed before glix recorded hashes; run with --accept to
+              record the current binary
+
+The binaries are hashed by the glix server on its own machine, so with
+--server the binaries of that machine are verified.
+
+After an intended change, --accept records the current hashes as the
+expected ones. 'glix rebuild' does so when it replaces a binary.
+
+With --sums, the go.sum hashes of the module zip and go.mod recorded at
+install, which the go command verified against the checksum database, are
+also checked against the module cache the binary was built from and, for
+public modules, looked up again in the checksum database (GOSUMDB). A
+mismatch means the source was tampered with after the install or the
+module version was republished:
+
+  ok          Every source consulted agrees with the record
+  MISMATCH    The module cache or the checksum database disagrees
+  unrecorded  Installed from a local directory or before glix recorded
+              checksums; reinstall to record them
+  unchecked   Neither the module cache nor the checksum database could
+              be consulted
+
+The command exits with status 1 when a binary is modified, replaced, or
+missing, or a checksum mismatches.
+
+Examples:
+  glix verify
+  glix verify golangci-lint gopls
+  glix verify --sums                  # Also check the module checksums
+  glix verify --accept                # Record hashes for older installs`,
+	ValidArgsFunction: completeInstalledModules,
+	SilenceUsage:      true,
+	RunE:              runVerify,
+}
+
+var (
+	verifyAccept bool
+	verifySums   bool
+)
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+
+	verifyCmd.Flags().BoolVar(&verifyAccept, "accept", false, "Record the current hashes as the expected ones")
+	verifyCmd.Flags().BoolVar(&verifySums, "sums", false, "Also check the module checksums against the module cache and the checksum database")
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	names := make([]string, 0, len(args))
+
+	for _, arg := range args {
+		mod, err := findInstalledTool(ctx, grpcClient, arg)
+		if err != nil {
+			return err
+		}
+
+		names = append(names, mod.GetName())
+	}
+
+	results, err := grpcClient.VerifyBinaries(ctx, names, verifyAccept, verifySums)
+	if err != nil {
+		return err
+	}
+
+	if len(results) == 0 {
+		cmd.Println("No modules installed")
+		return nil
+	}
+
+	columns := []column{
+		{Header: "STATUS", Style: integrityStyle},
+		{Header: "MODULE", Shrink: true},
+		{Header: "VERSION"},
+		{Header: "BINARY", Shrink: true},
+		{Header: "DETAIL", Shrink: true, KeepStart: true},
+	}
+
+	if verifySums {
+		columns = slices.Insert(columns, 1, column{Header: "SUMS", Style: integrityStyle})
+	}
+
+	t := newTable(columns...)
+
+	counts := make(map[pb.BinaryIntegrity]int)
+	sumCounts := make(map[pb.SumIntegrity]int)
+	accepted := 0
+
+	for _, r := range results {
+		counts[r.GetIntegrity()]++
+		sumCounts[r.GetSumIntegrity()]++
+
+		status := integrityLabel(r.GetIntegrity())
+		if r.GetAccepted() {
+			status = "accepted"
+			accepted++
+		}
+
+		if !verifySums {
+			t.addRow(status, r.GetName(), r.GetVersion(), r.GetBinaryPath(), r.GetDetail())
+			continue
+		}
+
+		detail := r.GetDetail()
+		if r.GetSumDetail() != "" {
+			detail = strings.TrimPrefix(detail+"; "+r.GetSumDetail(), "; ")
+		}
+
+		t.addRow(status, sumIntegrityLabel(r.GetSumIntegrity()), r.GetName(), r.GetVersion(), r.GetBinaryPath(), detail)
+	}
+
+	if err := t.write(cmd.OutOrStdout()); err != nil {
+		return err
+	}
+
+	changed := counts[pb.BinaryIntegrity_BINARY_INTEGRITY_MODIFIED] +
+		counts[pb.BinaryIntegrity_BINARY_INTEGRITY_REPLACED] +
+		counts[pb.BinaryIntegrity_BINARY_INTEGRITY_MISSING]
+
+	cmd.Printf("\n%d binary(ies): %d ok, %d modified, %d replaced, %d missing, %d unrecorded\n",
+		len(results),
+		counts[pb.BinaryIntegrity_BINARY_INTEGRITY_OK],
+		counts[pb.BinaryIntegrity_BINARY_INTEGRITY_MODIFIED],
+		counts[pb.BinaryIntegrity_BINARY_INTEGRITY_REPLACED],
+		counts[pb.BinaryIntegrity_BINARY_INTEGRITY_MISSING],
+		counts[pb.BinaryIntegrity_BINARY_INTEGRITY_UNRECORDED],
+	)
+
+	if verifySums {
+		cmd.Printf("Checksums: %d ok, %d mismatched, %d unrecorded, %d unchecked\n",
+			sumCounts[pb.SumIntegrity_SUM_INTEGRITY_OK],
+			sumCounts[pb.SumIntegrity_SUM_INTEGRITY_MISMATCH],
+			sumCounts[pb.SumIntegrity_SUM_INTEGRITY_UNRECORDED],
+			sumCounts[pb.SumIntegrity_SUM_INTEGRITY_UNCHECKED],
+		)
+	}
+
+	if sumCounts[pb.SumIntegrity_SUM_INTEGRITY_MISMATCH] > 0 {
+		return errSumsMismatch
+	}
+
+	if verifyAccept {
+		cmd.Printf("Recorded the current hash of %d binary(ies)\n", accepted)
+
+		// Missing binaries have nothing to accept
+		if counts[pb.BinaryIntegrity_BINARY_INTEGRITY_MISSING] > 0 {
+			return errBinariesChanged
+		}
+
+		return nil
+	}
+
+	if changed > 0 {
+		return errBinariesChanged
+	}
+
+	return nil
+}
+
+// integrityLabel names an integrity result, in capitals when it needs
+// attention
+func integrityLabel(integrity pb.BinaryIntegrity) string {
+	switch integrity {
+	case pb.BinaryIntegrity_BINARY_INTEGRITY_OK:
+		return "ok"
+	case pb.BinaryIntegrity_BINARY_INTEGRITY_MODIFIED:
+		return "MODIFIED"
+	case pb.BinaryIntegrity_BINARY_INTEGRITY_REPLACED:
+		return "REPLACED"
+	case pb.BinaryIntegrity_BINARY_INTEGRITY_MISSING:
+		return "MISSING"
+	case pb.BinaryIntegrity_BINARY_INTEGRITY_UNRECORDED:
+		return "unrecorded"
+	default:
+		return "unknown"
+	}
+}
+
+// sumIntegrityLabel names a checksum result, in capitals when it needs
+// attention
+func sumIntegrityLabel(integrity pb.SumIntegrity) string {
+	switch integrity {
+	case pb.SumIntegrity_SUM_INTEGRITY_OK:
+		return "ok"
+	case pb.SumIntegrity_SUM_INTEGRITY_MISMATCH:
+		return "MISMATCH"
+	case pb.SumIntegrity_SUM_INTEGRITY_UNRECORDED:
+		return "unrecorded"
+	case pb.SumIntegrity_SUM_INTEGRITY_UNCHECKED:
+		return "unchecked"
+	default:
+		return "unknown"
+	}
+}
+
+// integrityStyle colors the verify status column
+func integrityStyle(status string) lipgloss.Style {
+	switch status {
+	case "ok", "accepted":
+		return tui.SuccessStyle
+	case "unrecorded", "unchecked":
+		return tui.WarningStyle
+	default:
+		return tui.ErrorStyle
+	}
+}
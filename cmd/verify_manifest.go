@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/manifest"
+	"github.com/inovacc/glix/internal/module"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// errManifestDrift makes verify-manifest exit non-zero so it can gate CI jobs
+var errManifestDrift = errors.New("installed modules do not match the manifest")
+
+// verifyManifestCmd represents the verify-manifest command
+var verifyManifestCmd = &cobra.Command{
+	Use:   "verify-manifest <manifest>",
+	Short: "Check that installed modules match a manifest",
+	Long: `Compare the installed modules with a committed manifest and print every
+difference as a diff line. Intended as a CI gate ensuring build images
+contain exactly the approved toolchain.
+
+The manifest is YAML (or JSON) listing each module with its version and,
+optionally, the SHA-256 of its installed binary:
+
+  version: 1
+  modules:
+    - name: golang.org/x/tools/cmd/goimports
+      version: v0.28.0
+    - name: github.com/golangci/golangci-lint/cmd/golangci-lint
+      version: v1.62.2
+      hash: sha256:3f1c...
+
+Binary hashes depend on the platform and Go toolchain, so only pin them for
+images built the same way. Modules installed but not listed count as drift
+unless --allow-extra is set.
+
+The command exits with status 1 when anything differs.
+
+Examples:
+  glix verify-manifest tools.yaml
+  glix verify-manifest tools.yaml --allow-extra
+  glix verify-manifest tools.yaml --json`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runVerifyManifest,
+}
+
+var (
+	verifyManifestAllowExtra bool
+	verifyManifestJSON       bool
+)
+
+func init() {
+	rootCmd.AddCommand(verifyManifestCmd)
+
+	verifyManifestCmd.Flags().BoolVar(&verifyManifestAllowExtra, "allow-extra", false, "Ignore installed modules missing from the manifest")
+	verifyManifestCmd.Flags().BoolVar(&verifyManifestJSON, "json", false, "Print differences as JSON")
+}
+
+func runVerifyManifest(cmd *cobra.Command, args []string) error {
+	m, err := manifest.Load(args[0])
+	if err != nil {
+		return err
+	}
+
+	grpcClient, err := client.GetClient(cmd.Context(), client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	resp, err := grpcClient.ListModules(cmd.Context(), 0, 0, "")
+	if err != nil {
+		return fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	// Only hash the binaries the manifest pins
+	hashed := make(map[string]bool)
+	for _, e := range m.Modules {
+		hashed[e.Name] = e.Hash != ""
+	}
+
+	drift := manifest.Compare(m, installedEntries(resp.GetModules(), hashed), !verifyManifestAllowExtra)
+
+	if verifyManifestJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+
+		if drift == nil {
+			drift = []manifest.Drift{}
+		}
+
+		if err := enc.Encode(drift); err != nil {
+			return fmt.Errorf("failed to encode results: %w", err)
+		}
+	} else if len(drift) == 0 {
+		cmd.Printf("%d module(s) match %s\n", len(m.Modules), args[0])
+	} else {
+		for _, d := range drift {
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), d)
+		}
+	}
+
+	if len(drift) > 0 {
+		return fmt.Errorf("%w: %d difference(s)", errManifestDrift, len(drift))
+	}
+
+	return nil
+}
+
+// installedEntries converts installed modules to manifest entries, hashing
+// the binaries of the modules selected by hash. A binary that cannot be read
+// leaves the hash empty, which never matches a pinned one.
+func installedEntries(modules []*pb.ModuleProto, hash map[string]bool) []manifest.Entry {
+	entries := make([]manifest.Entry, 0, len(modules))
+
+	for _, mod := range modules {
+		e := manifest.Entry{
+			Name:    mod.GetName(),
+			Version: mod.GetVersion(),
+		}
+
+		if hash[mod.GetName()] {
+			e.Hash, _ = manifest.HashFile(module.ManagedBinaryPath(mod.GetName(), mod.GetKubectlPlugin(), mod.GetAlias()))
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries
+}
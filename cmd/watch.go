@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/inovacc/glix/internal/autoupdate"
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/humanize"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/modver"
+	"github.com/inovacc/glix/internal/tui"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// watchCmd represents the watch command
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch library modules for new releases and vulnerabilities",
+	Long: `Watch library modules, such as the critical dependencies of your projects,
+for new releases and known vulnerabilities without installing anything.
+
+Every auto-update check also checks the watched libraries: a release newer
+than the version in use and new vulnerabilities affecting that version
+(from OSV.dev) are logged by the daemon once, and 'glix watch list' shows
+them. Enable auto-update, in notify-only mode to leave installed modules
+alone, for the checks to run; 'glix auto-update now' runs one now.
+
+Adding a watched library again records the version in use, e.g. after
+upgrading a project to the latest release.
+
+Examples:
+  glix watch add github.com/pkg/errors
+  glix watch add golang.org/x/net@v0.30.0
+  glix watch list
+  glix watch remove github.com/pkg/errors`,
+}
+
+var watchAddCmd = &cobra.Command{
+	Use:          "add <module>[@version]",
+	Short:        "Watch a library module, at the version in use or the latest",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runWatchAdd,
+}
+
+var watchListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List watched library modules with their latest release and vulnerabilities",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runWatchList,
+}
+
+var watchRemoveCmd = &cobra.Command{
+	Use:               "remove <module>",
+	Short:             "Stop watching a library module",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeLibraryWatches,
+	SilenceUsage:      true,
+	RunE:              runWatchRemove,
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+	watchCmd.AddCommand(watchAddCmd, watchListCmd, watchRemoveCmd)
+}
+
+func runWatchAdd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	path, version := parseModulePath(args[0])
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	workDir, err := module.NewWorkDir("watch")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = os.RemoveAll(workDir)
+	}()
+
+	m, err := module.NewModule(ctx, "go", workDir)
+	if err != nil {
+		return fmt.Errorf("failed to create module: %w", err)
+	}
+
+	m.SetOffline(offline())
+	m.SetVersionStore(grpcClient.VersionStore())
+
+	root, latest, err := m.ResolveLibrary(path)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case version == "" || version == "latest":
+		version = latest
+	case !modver.IsValid(version):
+		return fmt.Errorf("%q is not a version; give the version in use, such as v1.2.3", version)
+	}
+
+	if err := grpcClient.StoreLibraryWatch(ctx, &pb.LibraryWatchProto{
+		Path:          root,
+		Version:       version,
+		LatestVersion: latest,
+	}); err != nil {
+		return err
+	}
+
+	if root != path {
+		cmd.Printf("%s is provided by module %s\n", path, root)
+	}
+
+	cmd.Printf("Watching %s@%s (latest %s)\n", root, version, latest)
+
+	if cfg, err := autoupdate.LoadConfig(ctx, grpcClient); err == nil && !cfg.Enabled {
+		cmd.Println("Auto-update is disabled, so no checks run; enable it with 'glix auto-update enable', and 'glix auto-update config --notify-only' to leave installed modules alone")
+	}
+
+	return nil
+}
+
+func runWatchList(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	watches, err := grpcClient.ListLibraryWatches(ctx)
+	if err != nil {
+		return err
+	}
+
+	if structuredOutput() {
+		return printMessage(cmd, &pb.ListLibraryWatchesResponse{Watches: watches})
+	}
+
+	if len(watches) == 0 {
+		cmd.Println("No libraries watched; add one with 'glix watch add <module>'")
+		return nil
+	}
+
+	t := newTable(
+		column{Header: "MODULE", Shrink: true},
+		column{Header: "VERSION"},
+		column{Header: "LATEST", Style: watchLatestStyle},
+		column{Header: "VULNERABILITIES", Shrink: true, KeepStart: true, Style: watchVulnsStyle},
+		column{Header: "CHECKED"},
+	)
+
+	for _, w := range watches {
+		latest := w.GetLatestVersion()
+		if modver.Newer(latest, w.GetVersion()) {
+			latest += " (new)"
+		}
+
+		vulns := strings.Join(w.GetVulnerabilities(), ", ")
+		if vulns == "" {
+			vulns = "-"
+		}
+
+		checked := "never"
+		if at := w.GetCheckedUnixNano(); at > 0 {
+			checked = humanize.Since(time.Unix(0, at))
+		}
+
+		t.addRow(w.GetPath(), w.GetVersion(), latest, vulns, checked)
+	}
+
+	return t.write(cmd.OutOrStdout())
+}
+
+// watchLatestStyle highlights a latest release newer than the version in use
+func watchLatestStyle(latest string) lipgloss.Style {
+	if strings.HasSuffix(latest, " (new)") {
+		return tui.WarningStyle
+	}
+
+	return lipgloss.NewStyle()
+}
+
+// watchVulnsStyle highlights vulnerabilities affecting the version in use
+func watchVulnsStyle(vulns string) lipgloss.Style {
+	if vulns != "-" {
+		return tui.ErrorStyle
+	}
+
+	return lipgloss.NewStyle()
+}
+
+func runWatchRemove(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	path, _ := parseModulePath(args[0])
+
+	grpcClient, err := client.GetClient(ctx, client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	if err := grpcClient.RemoveLibraryWatch(ctx, path); err != nil {
+		return err
+	}
+
+	cmd.Printf("Stopped watching %s\n", path)
+
+	return nil
+}
+
+// completeLibraryWatches completes the paths of watched library modules
+func completeLibraryWatches(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), completionTimeout)
+	defer cancel()
+
+	// Log lines would end up in the shell's completion output
+	cfg := client.DefaultDiscoveryConfig()
+	cfg.Logger = nil
+
+	grpcClient, err := client.GetClient(ctx, cfg)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	watches, err := grpcClient.ListLibraryWatches(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var paths []string
+
+	for _, w := range watches {
+		if strings.HasPrefix(w.GetPath(), toComplete) {
+			paths = append(paths, w.GetPath()+"\t"+w.GetVersion())
+		}
+	}
+
+	return paths, cobra.ShellCompDirectiveNoFileComp
+}
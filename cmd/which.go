@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/manifest"
+	"github.com/inovacc/glix/internal/module"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// whichCmd represents the which command
+var whichCmd = &cobra.Command{
+	Use:   "which <binary>",
+	Short: "Show which installed module provides a binary",
+	Long: `Map a binary in GOBIN back to the module that produced it, showing the
+module version, binary path, SHA-256 of the binary, and install time.
+
+The binary can be given by name or by path.
+
+Examples:
+  glix which gopls
+  glix which ~/go/bin/golangci-lint`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runWhich,
+}
+
+func init() {
+	rootCmd.AddCommand(whichCmd)
+}
+
+func runWhich(cmd *cobra.Command, args []string) error {
+	grpcClient, err := client.GetClient(cmd.Context(), client.DefaultDiscoveryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	resp, err := grpcClient.ListModules(cmd.Context(), 0, 0, "")
+	if err != nil {
+		return fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	matches := modulesProvidingBinary(resp.GetModules(), args[0])
+	if len(matches) == 0 {
+		return fmt.Errorf("no installed module provides %s", args[0])
+	}
+
+	if len(matches) > 1 {
+		cmd.Printf("[warning] %d modules install a binary named %s; the last installed one is in GOBIN\n", len(matches), args[0])
+	}
+
+	for i, mod := range matches {
+		if i > 0 {
+			cmd.Println()
+		}
+
+		name, binPath := module.RecordedBinary(mod)
+
+		cmd.Printf("%s is provided by %s@%s\n", name, mod.GetName(), mod.GetVersion())
+		cmd.Printf("  Path:        %s\n", binPath)
+
+		if hash, err := manifest.HashFile(binPath); err != nil {
+			cmd.Printf("  SHA-256:     unavailable (%v)\n", err)
+		} else {
+			cmd.Printf("  SHA-256:     %s\n", strings.TrimPrefix(hash, "sha256:"))
+		}
+
+		if mod.GetHash() != "" {
+			cmd.Printf("  Module hash: %s\n", mod.GetHash())
+		}
+
+		cmd.Printf("  Installed:   %s\n", time.Unix(0, mod.GetTimestampUnixNano()).Format("2006-01-02 15:04:05"))
+
+		if mod.GetLocalPath() != "" {
+			cmd.Printf("  Source:      %s\n", mod.GetLocalPath())
+		}
+	}
+
+	return nil
+}
+
+// modulesProvidingBinary returns the modules whose binary matches query,
+// given as a binary name or a path
+func modulesProvidingBinary(modules []*pb.ModuleProto, query string) []*pb.ModuleProto {
+	byPath := strings.ContainsAny(query, `/\`)
+	if byPath {
+		if abs, err := filepath.Abs(query); err == nil {
+			query = abs
+		}
+	}
+
+	var matches []*pb.ModuleProto
+
+	for _, mod := range modules {
+		name, binPath := module.RecordedBinary(mod)
+
+		if byPath && filepath.Clean(binPath) == query ||
+			!byPath && name == strings.TrimSuffix(query, ".exe") {
+			matches = append(matches, mod)
+		}
+	}
+
+	return matches
+}
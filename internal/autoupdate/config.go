@@ -1,19 +1,24 @@
 package autoupdate
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"sync"
 	"time"
 
 	"github.com/inovacc/glix/internal/module"
+	pb "github.com/inovacc/glix/pkg/api/v1"
 )
 
 // DefaultInterval is the default interval between update checks
 const DefaultInterval = 24 * time.Hour
 
+// MinInterval is the shortest interval between update checks
+const MinInterval = time.Hour
+
 // Config holds auto-update configuration
 type Config struct {
 	Enabled       bool          `json:"enabled"`
@@ -24,162 +29,166 @@ type Config struct {
 	CheckedCount  int           `json:"checked_count"`
 	NotifyOnly    bool          `json:"notify_only"` // If true, only notify about updates, don't auto-install
 	IncludePrerel bool          `json:"include_prerelease"`
+	PendingCount  int           `json:"pending_count"` // Updates found but not installed by the last check
 }
 
-// configStore handles persistent storage of auto-update configuration
-type configStore struct {
-	mu       sync.RWMutex
-	config   Config
-	filePath string
-}
+// Store keeps the auto-update configuration and statistics: the daemon's
+// database, directly in the daemon and behind its API in clients
+type Store interface {
+	// GetAutoUpdateConfig returns the stored configuration, or nil before
+	// one is stored
+	GetAutoUpdateConfig(ctx context.Context) (*pb.AutoUpdateConfigProto, error)
 
-var (
-	store     *configStore
-	storeOnce sync.Once
-)
+	// SetAutoUpdateConfig applies a change and returns the configuration
+	// after it
+	SetAutoUpdateConfig(ctx context.Context, req *pb.SetAutoUpdateConfigRequest) (*pb.AutoUpdateConfigProto, error)
+}
 
-// getConfigPath returns the path to the auto-update config file
-func getConfigPath() string {
-	configDir, err := module.GetApplicationConfigDirectory()
+// LoadConfig returns the configuration kept by store
+func LoadConfig(ctx context.Context, store Store) (Config, error) {
+	p, err := store.GetAutoUpdateConfig(ctx)
 	if err != nil {
-		// Fallback to cache directory
-		configDir, _ = module.GetApplicationCacheDirectory()
+		return Config{}, err
 	}
 
-	return filepath.Join(configDir, "autoupdate.json")
-}
-
-// GetStore returns the singleton config store
-func GetStore() *configStore {
-	storeOnce.Do(func() {
-		store = &configStore{
-			filePath: getConfigPath(),
-			config: Config{
-				Enabled:  false,
-				Interval: DefaultInterval,
-			},
-		}
-		// Load existing config if available
-		_ = store.load()
-	})
-
-	return store
+	return ConfigFromProto(p), nil
 }
 
-// load reads the configuration from disk
-func (s *configStore) load() error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	data, err := os.ReadFile(s.filePath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil // Use defaults
-		}
+// ConfigFromProto converts a stored configuration, filling in the defaults
+// of one that was never stored
+func ConfigFromProto(p *pb.AutoUpdateConfigProto) Config {
+	cfg := Config{
+		Enabled:       p.GetEnabled(),
+		Interval:      time.Duration(p.GetIntervalNano()),
+		UpdatedCount:  int(p.GetUpdatedCount()),
+		CheckedCount:  int(p.GetCheckedCount()),
+		NotifyOnly:    p.GetNotifyOnly(),
+		IncludePrerel: p.GetIncludePrerelease(),
+		PendingCount:  int(p.GetPendingCount()),
+	}
 
-		return fmt.Errorf("failed to read config: %w", err)
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
 	}
 
-	var cfg Config
-	if err := json.Unmarshal(data, &cfg); err != nil {
-		return fmt.Errorf("failed to parse config: %w", err)
+	if n := p.GetLastCheckUnixNano(); n != 0 {
+		cfg.LastCheck = time.Unix(0, n)
 	}
 
-	s.config = cfg
+	if n := p.GetLastUpdateUnixNano(); n != 0 {
+		cfg.LastUpdate = time.Unix(0, n)
+	}
 
-	return nil
+	return cfg
 }
 
-// save writes the configuration to disk
-func (s *configStore) save() error {
-	// Ensure directory exists
-	dir := filepath.Dir(s.filePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create config directory: %w", err)
+// ToProto converts the configuration for storage
+func (c Config) ToProto() *pb.AutoUpdateConfigProto {
+	p := &pb.AutoUpdateConfigProto{
+		Enabled:           c.Enabled,
+		IntervalNano:      int64(c.Interval),
+		NotifyOnly:        c.NotifyOnly,
+		IncludePrerelease: c.IncludePrerel,
+		CheckedCount:      int64(c.CheckedCount),
+		UpdatedCount:      int64(c.UpdatedCount),
+		PendingCount:      int64(c.PendingCount),
 	}
 
-	data, err := json.MarshalIndent(s.config, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+	if !c.LastCheck.IsZero() {
+		p.LastCheckUnixNano = c.LastCheck.UnixNano()
 	}
 
-	if err := os.WriteFile(s.filePath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write config: %w", err)
+	if !c.LastUpdate.IsZero() {
+		p.LastUpdateUnixNano = c.LastUpdate.UnixNano()
 	}
 
-	return nil
+	return p
 }
 
-// Get returns a copy of the current configuration
-func (s *configStore) Get() Config {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+// Due reports whether an update check is due at now
+func (c Config) Due(now time.Time) bool {
+	if !c.Enabled {
+		return false
+	}
 
-	return s.config
+	return c.LastCheck.IsZero() || now.Sub(c.LastCheck) >= c.Interval
 }
 
-// SetEnabled enables or disables auto-update
-func (s *configStore) SetEnabled(enabled bool) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	s.config.Enabled = enabled
+// NextCheck returns when the next update check is due, which is in the past
+// when one is overdue, or the zero time when auto-update is disabled
+func (c Config) NextCheck() time.Time {
+	if !c.Enabled {
+		return time.Time{}
+	}
 
-	return s.save()
+	return c.LastCheck.Add(c.Interval)
 }
 
-// SetInterval sets the update check interval
-func (s *configStore) SetInterval(interval time.Duration) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// ApplyChange applies a change to a stored configuration at now, counting
+// the check it records, if any
+func ApplyChange(cfg *pb.AutoUpdateConfigProto, req *pb.SetAutoUpdateConfigRequest, now time.Time) error {
+	if req.IntervalNano != nil {
+		if interval := time.Duration(req.GetIntervalNano()); interval < MinInterval {
+			return fmt.Errorf("interval must be at least %s", MinInterval)
+		}
 
-	if interval < time.Hour {
-		return fmt.Errorf("interval must be at least 1 hour")
+		cfg.IntervalNano = req.GetIntervalNano()
 	}
 
-	s.config.Interval = interval
-
-	return s.save()
-}
+	if req.Enabled != nil {
+		cfg.Enabled = req.GetEnabled()
+	}
 
-// SetNotifyOnly sets whether to only notify about updates without installing
-func (s *configStore) SetNotifyOnly(notifyOnly bool) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	if req.NotifyOnly != nil {
+		cfg.NotifyOnly = req.GetNotifyOnly()
+	}
 
-	s.config.NotifyOnly = notifyOnly
+	if req.IncludePrerelease != nil {
+		cfg.IncludePrerelease = req.GetIncludePrerelease()
+	}
 
-	return s.save()
-}
+	if check := req.GetRecordCheck(); check != nil {
+		cfg.LastCheckUnixNano = now.UnixNano()
+		cfg.PendingCount = check.GetPending()
+		cfg.CheckedCount++
 
-// RecordCheck records that an update check was performed
-func (s *configStore) RecordCheck(updatedCount int) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+		if check.GetUpdated() > 0 {
+			cfg.LastUpdateUnixNano = now.UnixNano()
+			cfg.UpdatedCount += check.GetUpdated()
+		}
+	}
 
-	s.config.LastCheck = time.Now()
+	return nil
+}
 
-	s.config.CheckedCount++
-	if updatedCount > 0 {
-		s.config.LastUpdate = time.Now()
-		s.config.UpdatedCount += updatedCount
+// LegacyConfigPath returns the location of the JSON file auto-update was
+// configured in before the daemon kept its configuration
+func LegacyConfigPath() string {
+	configDir, err := module.GetApplicationConfigDirectory()
+	if err != nil {
+		// Fallback to cache directory
+		configDir, _ = module.GetApplicationCacheDirectory()
 	}
 
-	return s.save()
+	return filepath.Join(configDir, "autoupdate.json")
 }
 
-// ShouldCheck returns true if enough time has passed since the last check
-func (s *configStore) ShouldCheck() bool {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+// LoadLegacyConfig reads a configuration file written before the daemon
+// kept the configuration, or returns nil when there is none
+func LoadLegacyConfig(path string) (*pb.AutoUpdateConfigProto, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
 
-	if !s.config.Enabled {
-		return false
+		return nil, fmt.Errorf("failed to read config: %w", err)
 	}
 
-	if s.config.LastCheck.IsZero() {
-		return true
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
 
-	return time.Since(s.config.LastCheck) >= s.config.Interval
+	return cfg.ToProto(), nil
 }
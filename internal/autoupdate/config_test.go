@@ -0,0 +1,89 @@
+package autoupdate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestApplyChange(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	// A configuration that was never stored has the defaults
+	cfg := &pb.AutoUpdateConfigProto{}
+	if c := ConfigFromProto(cfg); c.Enabled || c.Interval != DefaultInterval || c.Due(now) {
+		t.Errorf("ConfigFromProto(empty) = %+v, want disabled with the default interval", c)
+	}
+
+	if err := ApplyChange(cfg, &pb.SetAutoUpdateConfigRequest{Enabled: proto.Bool(true), IntervalNano: proto.Int64(int64(12 * time.Hour))}, now); err != nil {
+		t.Fatal(err)
+	}
+
+	if c := ConfigFromProto(cfg); !c.Enabled || c.Interval != 12*time.Hour || c.NotifyOnly || !c.Due(now) {
+		t.Errorf("after enabling = %+v, want enabled every 12h and due", c)
+	}
+
+	// Unset fields are left alone
+	if err := ApplyChange(cfg, &pb.SetAutoUpdateConfigRequest{NotifyOnly: proto.Bool(true)}, now); err != nil {
+		t.Fatal(err)
+	}
+
+	if c := ConfigFromProto(cfg); !c.Enabled || c.Interval != 12*time.Hour || !c.NotifyOnly || c.IncludePrerel {
+		t.Errorf("after notify-only = %+v, want the other settings kept", c)
+	}
+
+	if err := ApplyChange(cfg, &pb.SetAutoUpdateConfigRequest{IncludePrerelease: proto.Bool(true)}, now); err != nil {
+		t.Fatal(err)
+	}
+
+	if c := ConfigFromProto(cfg); !c.IncludePrerel || !c.NotifyOnly {
+		t.Errorf("after including pre-releases = %+v", c)
+	}
+
+	if err := ApplyChange(cfg, &pb.SetAutoUpdateConfigRequest{IntervalNano: proto.Int64(int64(time.Minute))}, now); err == nil {
+		t.Error("ApplyChange accepted an interval under MinInterval")
+	}
+
+	// Checks are counted
+	for _, check := range []*pb.AutoUpdateCheck{{Updated: 2, Pending: 1}, {Pending: 3}} {
+		if err := ApplyChange(cfg, &pb.SetAutoUpdateConfigRequest{RecordCheck: check}, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := ConfigFromProto(cfg)
+	if c.CheckedCount != 2 || c.UpdatedCount != 2 || c.PendingCount != 3 || !c.LastCheck.Equal(now) || !c.LastUpdate.Equal(now) {
+		t.Errorf("after two checks = %+v", c)
+	}
+
+	if c.Due(now.Add(time.Hour)) || !c.Due(now.Add(12*time.Hour)) || !c.NextCheck().Equal(now.Add(12*time.Hour)) {
+		t.Errorf("next check of %+v is not 12h after the last", c)
+	}
+}
+
+func TestLoadLegacyConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	if cfg, err := LoadLegacyConfig(filepath.Join(dir, "missing.json")); err != nil || cfg != nil {
+		t.Fatalf("LoadLegacyConfig(missing) = %v, %v; want nil, nil", cfg, err)
+	}
+
+	path := filepath.Join(dir, "autoupdate.json")
+	if err := os.WriteFile(path, []byte(`{"enabled": true, "interval": 7200000000000, "last_check": "2024-01-02T03:04:05Z", "checked_count": 4, "notify_only": true}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := LoadLegacyConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := ConfigFromProto(p)
+	if !c.Enabled || c.Interval != 2*time.Hour || !c.NotifyOnly || c.CheckedCount != 4 || c.LastCheck.Year() != 2024 || !c.LastUpdate.IsZero() {
+		t.Errorf("legacy config = %+v", c)
+	}
+}
@@ -0,0 +1,136 @@
+package autoupdate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/modver"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// LibraryResult is the outcome of checking a watched library module
+type LibraryResult struct {
+	Path       string
+	Version    string   // Version in use
+	Latest     string   // Newest release
+	NewRelease bool     // Latest is newer than the version in use and was not reported before
+	NewVulns   []string // Vulnerabilities affecting the version in use not reported before
+	Error      error
+}
+
+// checkLibraries checks the watched library modules for new releases and
+// for vulnerabilities affecting the version in use, logging each finding
+// once
+func (s *Scheduler) checkLibraries(ctx context.Context, client pb.GlixServiceClient, result *CheckResult) {
+	resp, err := client.ListLibraryWatches(ctx, &emptypb.Empty{})
+	if err == nil && resp.GetErrorMessage() != "" {
+		err = errors.New(resp.GetErrorMessage())
+	}
+
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("failed to list library watches: %w", err))
+		return
+	}
+
+	for _, watch := range resp.GetWatches() {
+		lib := checkLibrary(ctx, client, watch)
+		result.Libraries = append(result.Libraries, lib)
+
+		if lib.Error != nil {
+			s.logger.Warn("library check failed", "module", lib.Path, "error", lib.Error)
+			result.Errors = append(result.Errors, lib.Error)
+		}
+
+		if lib.NewRelease {
+			s.logger.Info("library release available", "module", lib.Path, "current", lib.Version, "latest", lib.Latest)
+		}
+
+		if len(lib.NewVulns) > 0 {
+			s.logger.Warn("library version vulnerable", "module", lib.Path, "version", lib.Version, "vulnerabilities", lib.NewVulns)
+		}
+	}
+}
+
+// checkLibrary looks up the newest release of a watched library and the
+// vulnerabilities affecting the version in use, and records them
+func checkLibrary(ctx context.Context, client pb.GlixServiceClient, watch *pb.LibraryWatchProto) LibraryResult {
+	result := LibraryResult{Path: watch.GetPath(), Version: watch.GetVersion()}
+
+	workDir, err := module.NewWorkDir("libwatch")
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	defer func() {
+		_ = os.RemoveAll(workDir)
+	}()
+
+	m, err := module.NewModule(ctx, "go", workDir)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	m.SetVersionStore(module.RemoteVersionStore(client))
+
+	_, latest, err := m.ResolveLibrary(watch.GetPath())
+	if err != nil {
+		result.Error = fmt.Errorf("%s: %w", watch.GetPath(), err)
+		return result
+	}
+
+	// A failed lookup keeps the vulnerabilities known before, and private
+	// module paths are not sent to OSV.dev
+	vulns := watch.GetVulnerabilities()
+
+	if module.IsPrivate(watch.GetPath()) {
+		vulns = nil
+	} else if found, err := module.QueryVulnerabilities(ctx, watch.GetPath(), watch.GetVersion()); err != nil {
+		result.Error = fmt.Errorf("%s: %w", watch.GetPath(), err)
+	} else {
+		vulns = nil
+		for _, v := range found {
+			vulns = append(vulns, v.ID)
+		}
+	}
+
+	updated := recordLibraryCheck(watch, latest, vulns, time.Now(), &result)
+
+	if resp, err := client.StoreLibraryWatch(ctx, &pb.StoreLibraryWatchRequest{Watch: updated}); err != nil {
+		result.Error = fmt.Errorf("%s: failed to store check: %w", watch.GetPath(), err)
+	} else if !resp.GetSuccess() {
+		result.Error = fmt.Errorf("%s: failed to store check: %s", watch.GetPath(), resp.GetErrorMessage())
+	}
+
+	return result
+}
+
+// recordLibraryCheck returns watch updated with the newest release and the
+// vulnerabilities found at now, and fills in result what the previous check
+// had not found
+func recordLibraryCheck(watch *pb.LibraryWatchProto, latest string, vulns []string, now time.Time, result *LibraryResult) *pb.LibraryWatchProto {
+	result.Latest = latest
+	result.NewRelease = latest != watch.GetLatestVersion() && modver.Newer(latest, watch.GetVersion())
+
+	result.NewVulns = nil
+	for _, id := range vulns {
+		if !slices.Contains(watch.GetVulnerabilities(), id) {
+			result.NewVulns = append(result.NewVulns, id)
+		}
+	}
+
+	updated := proto.Clone(watch).(*pb.LibraryWatchProto)
+	updated.LatestVersion = latest
+	updated.Vulnerabilities = slices.Sorted(slices.Values(vulns))
+	updated.CheckedUnixNano = now.UnixNano()
+
+	return updated
+}
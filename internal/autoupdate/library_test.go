@@ -0,0 +1,48 @@
+package autoupdate
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+func TestRecordLibraryCheck(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	watch := &pb.LibraryWatchProto{Path: "example.com/errs", Version: "v0.9.0"}
+
+	var result LibraryResult
+
+	// The first check reports a newer release and the vulnerabilities found
+	watch = recordLibraryCheck(watch, "v0.9.1", []string{"GO-2024-2", "GO-2024-1"}, now, &result)
+
+	if !result.NewRelease || result.Latest != "v0.9.1" || !slices.Equal(result.NewVulns, []string{"GO-2024-2", "GO-2024-1"}) {
+		t.Errorf("first check = %+v, want the release and both vulnerabilities", result)
+	}
+
+	if watch.GetLatestVersion() != "v0.9.1" || watch.GetCheckedUnixNano() != now.UnixNano() ||
+		!slices.Equal(watch.GetVulnerabilities(), []string{"GO-2024-1", "GO-2024-2"}) {
+		t.Errorf("recorded watch = %v", watch)
+	}
+
+	if watch.GetVersion() != "v0.9.0" {
+		t.Errorf("check changed the version in use to %s", watch.GetVersion())
+	}
+
+	// Findings are reported once
+	watch = recordLibraryCheck(watch, "v0.9.1", []string{"GO-2024-1", "GO-2024-2", "GO-2024-3"}, now, &result)
+
+	if result.NewRelease || !slices.Equal(result.NewVulns, []string{"GO-2024-3"}) {
+		t.Errorf("second check = %+v, want only the new vulnerability", result)
+	}
+
+	// Releases not newer than the version in use are no news
+	watch.Version = "v1.0.0"
+	recordLibraryCheck(watch, "v1.0.0", nil, now, &result)
+
+	if result.NewRelease || len(result.NewVulns) > 0 {
+		t.Errorf("check of the version in use = %+v, want nothing new", result)
+	}
+}
@@ -5,13 +5,23 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
-	"path/filepath"
-	"sync"
+	"slices"
 	"time"
 
+	"github.com/inovacc/glix/internal/constraints"
+	"github.com/inovacc/glix/internal/denylist"
+	"github.com/inovacc/glix/internal/hold"
+	"github.com/inovacc/glix/internal/hooks"
 	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/modver"
+	"github.com/inovacc/glix/internal/owners"
+	"github.com/inovacc/glix/internal/policy"
+	"github.com/inovacc/glix/internal/profiles"
+	"github.com/inovacc/glix/internal/quarantine"
+	"github.com/inovacc/glix/internal/receipts"
 	pb "github.com/inovacc/glix/pkg/api/v1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
@@ -35,22 +45,48 @@ type CheckResult struct {
 	UpdatesFound int
 	UpdatesDone  int
 	Results      []UpdateResult
+	Libraries    []LibraryResult // Watched library modules
 	Errors       []error
 }
 
-// Scheduler handles periodic update checks
+// Summary describes the outcome of a check in one line
+func (r *CheckResult) Summary() string {
+	summary := fmt.Sprintf("checked %d module(s): %d update(s) found, %d installed",
+		r.ModulesCount, r.UpdatesFound, r.UpdatesDone)
+
+	if len(r.Libraries) > 0 {
+		releases, vulnerable := 0, 0
+
+		for _, lib := range r.Libraries {
+			if lib.NewRelease {
+				releases++
+			}
+
+			if len(lib.NewVulns) > 0 {
+				vulnerable++
+			}
+		}
+
+		summary += fmt.Sprintf("; %d watched librar(ies): %d new release(s), %d newly vulnerable",
+			len(r.Libraries), releases, vulnerable)
+	}
+
+	return summary + fmt.Sprintf(", %d error(s)", len(r.Errors))
+}
+
+// Scheduler performs update checks. The daemon's task scheduler runs them
+// through RunIfDue; see the tasks package.
 type Scheduler struct {
 	logger  *slog.Logger
-	store   *configStore
-	cancel  context.CancelFunc
-	wg      sync.WaitGroup
-	mu      sync.Mutex
-	running bool
+	store   Store
 	address string
+	creds   credentials.TransportCredentials
+	client  pb.GlixServiceClient // Used instead of dialing address when set
 }
 
-// NewScheduler creates a new auto-update scheduler
-func NewScheduler(logger *slog.Logger) *Scheduler {
+// NewScheduler creates a new auto-update scheduler whose configuration and
+// statistics are kept by store
+func NewScheduler(logger *slog.Logger, store Store) *Scheduler {
 	if logger == nil {
 		logger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 			Level: slog.LevelInfo,
@@ -59,8 +95,9 @@ func NewScheduler(logger *slog.Logger) *Scheduler {
 
 	return &Scheduler{
 		logger:  logger,
-		store:   GetStore(),
+		store:   store,
 		address: DefaultServerAddress,
+		creds:   insecure.NewCredentials(),
 	}
 }
 
@@ -69,87 +106,37 @@ func (s *Scheduler) SetAddress(address string) {
 	s.address = address
 }
 
-// Start begins the auto-update scheduler
-func (s *Scheduler) Start(ctx context.Context) {
-	s.mu.Lock()
-
-	if s.running {
-		s.mu.Unlock()
-		return
-	}
-
-	ctx, s.cancel = context.WithCancel(ctx)
-	s.running = true
-	s.mu.Unlock()
-
-	s.wg.Add(1)
-
-	go s.run(ctx)
-
-	s.logger.Info("auto-update scheduler started")
-}
-
-// Stop stops the auto-update scheduler
-func (s *Scheduler) Stop() {
-	s.mu.Lock()
-
-	if !s.running {
-		s.mu.Unlock()
-		return
-	}
-
-	if s.cancel != nil {
-		s.cancel()
-	}
-
-	s.running = false
-	s.mu.Unlock()
-
-	s.wg.Wait()
-	s.logger.Info("auto-update scheduler stopped")
+// SetTransportCredentials sets the credentials for connecting to the server,
+// which must match the ones it serves with
+func (s *Scheduler) SetTransportCredentials(creds credentials.TransportCredentials) {
+	s.creds = creds
 }
 
-// IsRunning returns whether the scheduler is running
-func (s *Scheduler) IsRunning() bool {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	return s.running
+// SetClient makes the scheduler use an established connection for module
+// calls instead of dialing its address, so a check run from the CLI works
+// against the same server, with the same credentials, as the rest of it
+func (s *Scheduler) SetClient(client pb.GlixServiceClient) {
+	s.client = client
 }
 
-// run is the main scheduler loop
-func (s *Scheduler) run(ctx context.Context) {
-	defer s.wg.Done()
-
-	// Check immediately if we should
-	if s.store.ShouldCheck() {
-		s.performCheck(ctx)
+// RunIfDue performs an update check when auto-update is enabled and the
+// configured interval has passed since the last one. It returns nil when no
+// check was due.
+func (s *Scheduler) RunIfDue(ctx context.Context) (*CheckResult, error) {
+	cfg, err := LoadConfig(ctx, s.store)
+	if err != nil {
+		return nil, err
 	}
 
-	// Then check periodically
-	ticker := time.NewTicker(time.Minute) // Check every minute if it's time
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			if s.store.ShouldCheck() {
-				s.performCheck(ctx)
-			}
-		}
+	if !cfg.Due(time.Now()) {
+		return nil, nil
 	}
-}
 
-// performCheck performs an update check cycle
-func (s *Scheduler) performCheck(ctx context.Context) {
 	s.logger.Info("starting auto-update check")
 
-	result, err := s.CheckAndUpdate(ctx)
+	result, err := s.RunOnce(ctx)
 	if err != nil {
-		s.logger.Error("auto-update check failed", "error", err)
-		return
+		return nil, err
 	}
 
 	s.logger.Info("auto-update check completed",
@@ -158,26 +145,40 @@ func (s *Scheduler) performCheck(ctx context.Context) {
 		"updates_done", result.UpdatesDone,
 	)
 
-	// Record the check
-	if err := s.store.RecordCheck(result.UpdatesDone); err != nil {
-		s.logger.Error("failed to record check", "error", err)
+	return result, nil
+}
+
+// NextCheck returns when the next update check is due, or the zero time
+// when auto-update is disabled or its configuration cannot be read
+func (s *Scheduler) NextCheck() time.Time {
+	cfg, err := LoadConfig(context.Background(), s.store)
+	if err != nil {
+		s.logger.Warn("failed to read auto-update config", "error", err)
+		return time.Time{}
 	}
+
+	return cfg.NextCheck()
 }
 
-// connectToServer creates a gRPC connection to the server
-func (s *Scheduler) connectToServer(ctx context.Context) (pb.GlixServiceClient, *grpc.ClientConn, error) {
+// connectToServer returns a client for the server and a function releasing
+// it. A client set with SetClient is returned as is.
+func (s *Scheduler) connectToServer(ctx context.Context) (pb.GlixServiceClient, func(), error) {
+	if s.client != nil {
+		return s.client, func() {}, nil
+	}
+
 	dialCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	conn, err := grpc.DialContext(dialCtx, s.address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(s.creds),
 		grpc.WithBlock(),
 	)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to connect to server at %s: %w", s.address, err)
 	}
 
-	return pb.NewGlixServiceClient(conn), conn, nil
+	return pb.NewGlixServiceClient(conn), func() { _ = conn.Close() }, nil
 }
 
 // CheckAndUpdate checks for updates and optionally applies them
@@ -188,18 +189,43 @@ func (s *Scheduler) CheckAndUpdate(ctx context.Context) (*CheckResult, error) {
 		Errors:    make([]error, 0),
 	}
 
-	// Get config
-	cfg := s.store.Get()
+	cfg, err := LoadConfig(ctx, s.store)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pick up holds, constraints and profiles changed by the CLI since the
+	// daemon started
+	if err := hold.GetStore().Reload(); err != nil {
+		s.logger.Warn("failed to reload holds", "error", err)
+	}
+
+	if err := constraints.GetStore().Reload(); err != nil {
+		s.logger.Warn("failed to reload constraints", "error", err)
+	}
+
+	if err := profiles.GetStore().Reload(); err != nil {
+		s.logger.Warn("failed to reload profiles", "error", err)
+	}
+
+	// Denied versions come from the local list and shared catalogs
+	if err := denylist.GetStore().Reload(); err != nil {
+		s.logger.Warn("failed to reload denylist", "error", err)
+	}
+
+	if len(denylist.GetStore().Catalogs()) > 0 {
+		if err := denylist.GetStore().Sync(ctx); err != nil {
+			s.logger.Warn("failed to sync denylist catalogs", "error", err)
+		}
+	}
 
 	// Connect to server
-	client, conn, err := s.connectToServer(ctx)
+	client, release, err := s.connectToServer(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	defer func() {
-		_ = conn.Close()
-	}()
+	defer release()
 
 	// List all installed modules
 	resp, err := client.ListModules(ctx, &pb.ListModulesRequest{})
@@ -210,13 +236,14 @@ func (s *Scheduler) CheckAndUpdate(ctx context.Context) (*CheckResult, error) {
 	modules := resp.GetModules()
 	result.ModulesCount = len(modules)
 
-	if len(modules) == 0 {
-		return result, nil
-	}
-
 	// Check each module
 	for _, mod := range modules {
-		modResult := s.checkModule(ctx, mod.GetName(), mod.GetVersion(), cfg.NotifyOnly, client)
+		// Local/dev installs are rebuilt from their working copy, not the proxy
+		if mod.GetLocalPath() != "" {
+			continue
+		}
+
+		modResult := checkModule(ctx, s.logger, client, mod, modules, cfg.NotifyOnly, cfg.IncludePrerel, nil)
 		result.Results = append(result.Results, modResult)
 
 		if modResult.Error != nil {
@@ -229,29 +256,43 @@ func (s *Scheduler) CheckAndUpdate(ctx context.Context) (*CheckResult, error) {
 		}
 	}
 
+	// Watched libraries have no binary; they are only reported on
+	s.checkLibraries(ctx, client, result)
+
 	return result, nil
 }
 
-// checkModule checks a single module for updates
-func (s *Scheduler) checkModule(ctx context.Context, name, installedVersion string, notifyOnly bool, client pb.GlixServiceClient) UpdateResult {
+// UpdateModule updates one installed module to its newest allowed version
+// with the same checks as unattended updates: denied and reported-broken
+// versions are skipped, and holds, constraints, and the install policy are
+// honored. Progress is reported to progress when it is not nil.
+func UpdateModule(ctx context.Context, logger *slog.Logger, client pb.GlixServiceClient, mod *pb.ModuleProto, installed []*pb.ModuleProto, progress module.ProgressHandler) UpdateResult {
+	return checkModule(ctx, logger, client, mod, installed, false, false, progress)
+}
+
+// checkModule checks a single module for updates, to pre-releases when the
+// module opted into them or includePrerel is set
+func checkModule(ctx context.Context, logger *slog.Logger, client pb.GlixServiceClient, mod *pb.ModuleProto, installed []*pb.ModuleProto, notifyOnly, includePrerel bool, progress module.ProgressHandler) UpdateResult {
+	name, installedVersion := mod.GetName(), mod.GetVersion()
+
+	report := func(phase, message string) {
+		if progress != nil {
+			progress(phase, message)
+		}
+	}
+
 	result := UpdateResult{
 		Name:            name,
 		PreviousVersion: installedVersion,
 	}
 
 	// Create working directory
-	cacheDir, err := module.GetApplicationCacheDirectory()
+	workDir, err := module.NewWorkDir("autoupdate")
 	if err != nil {
 		result.Error = err
 		return result
 	}
 
-	workDir := filepath.Join(cacheDir, fmt.Sprintf("autoupdate-%d", time.Now().UnixNano()))
-	if err := os.MkdirAll(workDir, 0755); err != nil {
-		result.Error = err
-		return result
-	}
-
 	defer func() {
 		_ = os.RemoveAll(workDir)
 	}()
@@ -263,6 +304,39 @@ func (s *Scheduler) checkModule(ctx context.Context, name, installedVersion stri
 		return result
 	}
 
+	m.SetProgressHandler(progress)
+	m.SetVersionStore(module.RemoteVersionStore(client))
+
+	// Aliased binaries stay under their alias, in their bin directory
+	m.Alias = mod.GetAlias()
+	m.SetBinDir(mod.GetBinDir())
+	m.SetRecordedPlatform(mod.GetPlatform())
+	m.SetBuildFlags(module.BuildFlagsFromProto(mod.GetBuildFlags()))
+	m.SetPreferBinary(mod.GetPreferBinary())
+	m.SetPrerelease(mod.GetPrerelease() || includePrerel)
+	m.SetShim(mod.GetShimPath() != "")
+	m.SetBuildStrategy(module.BuildStrategyFromRecord(mod.GetBuildStrategy()))
+	m.SetGoProxy(mod.GetGoproxy())
+	m.SetPrivate(mod.GetPrivate())
+	m.SetConstraint(mod.GetVersionConstraint())
+
+	if err := profiles.Apply(m, mod.GetProfile()); err != nil {
+		result.Error = err
+		return result
+	}
+
+	if err := quarantine.Apply(m); err != nil {
+		result.Error = err
+		return result
+	}
+
+	if err := hooks.Apply(m); err != nil {
+		result.Error = err
+		return result
+	}
+
+	m.SetPreviousVersion(installedVersion)
+
 	if err := m.FetchModuleInfo(name); err != nil {
 		result.Error = err
 		return result
@@ -270,41 +344,175 @@ func (s *Scheduler) checkModule(ctx context.Context, name, installedVersion stri
 
 	result.NewVersion = m.Version
 
-	// Check if update is available
-	if !isNewerVersion(m.Version, installedVersion) {
+	// Check if update is available; a retracted install moves to the latest
+	// version, even a lower one
+	retracted := m.RetractedInstall(installedVersion)
+	if retracted != "" {
+		logger.Info("installed version is retracted", "module", name, "version", installedVersion, "rationale", retracted)
+		report("versions", fmt.Sprintf("%s@%s is retracted by its author (%s); moving to %s", name, installedVersion, retracted, m.Version))
+	} else if !m.UpdatePolicy().IsUpdate(m.Version, installedVersion) {
 		return result // Already up to date
 	}
 
-	s.logger.Info("update available",
+	// Denied and reported-broken versions are never installed automatically;
+	// fall back to the newest allowed version that is still an update
+	excluded := func(v string) bool {
+		_, denied := denylist.GetStore().Denied(name, v)
+		return denied || slices.Contains(mod.GetBadVersions(), v)
+	}
+
+	if excluded(m.Version) {
+		policy := m.UpdatePolicy()
+
+		alt := modver.Newest(m.Versions, func(v string) bool {
+			return !excluded(v) && m.Allows(v) && policy.Allows(v, installedVersion) && (retracted != "" || modver.Newer(v, installedVersion))
+		})
+
+		logger.Info("skipping excluded version", "module", name, "version", m.Version, "fallback", alt)
+
+		if alt == "" {
+			result.NewVersion = installedVersion
+			return result
+		}
+
+		if err := m.FetchModuleInfo(fmt.Sprintf("%s@%s", name, alt)); err != nil {
+			result.Error = err
+			return result
+		}
+
+		result.NewVersion = m.Version
+	}
+
+	logger.Info("update available",
 		"module", name,
 		"current", installedVersion,
 		"latest", m.Version,
 	)
 
+	// Held modules are reported but not updated until the hold expires
+	if h, held := hold.GetStore().Get(name); held {
+		logger.Info("update held back", "module", name, "until", h.Until)
+		report("skip", fmt.Sprintf("%s is held until %s", name, h.Until.Format(time.RFC3339)))
+
+		return result
+	}
+
+	report("policy", fmt.Sprintf("Checking %s@%s against constraints and policy...", name, m.Version))
+
+	// Leave modules alone when the update would break a declared constraint
+	if conflicts := constraints.CheckUpdate(constraints.GetStore().List(), installed, m); len(conflicts) > 0 {
+		for _, c := range conflicts {
+			logger.Warn("update blocked by constraint", "module", name, "constraint", c.String())
+		}
+
+		result.Error = fmt.Errorf("update to %s violates %d constraint(s)", m.Version, len(conflicts))
+
+		return result
+	}
+
+	// The install policy applies to unattended updates as well
+	if p, err := policy.Load(policy.DefaultPath()); err != nil {
+		result.Error = err
+		return result
+	} else if p != nil {
+		in, warnings := policy.BuildInput(ctx, p, m)
+		for _, w := range warnings {
+			logger.Warn("policy input", "module", name, "warning", w)
+		}
+
+		d, err := p.Evaluate(ctx, in)
+		if err != nil {
+			result.Error = fmt.Errorf("policy evaluation failed: %w", err)
+			return result
+		}
+
+		switch d.Action {
+		case policy.ActionDeny:
+			result.Error = fmt.Errorf("update to %s %s", m.Version, d)
+			return result
+		case policy.ActionWarn:
+			logger.Warn("policy warning", "module", name, "version", m.Version, "decision", d.String())
+		}
+	}
+
 	// If notify only, don't install
 	if notifyOnly {
 		return result
 	}
 
+	// Binaries the server of another namespace took over are left to it
+	namespace := serverNamespace(ctx, client)
+	if owner, conflict := owners.GetStore().Conflict(m.InstallPath(), namespace); conflict {
+		result.Error = fmt.Errorf("%s is managed by %s", m.InstallPath(), owner)
+		return result
+	}
+
 	// Install the update
+	report("install", fmt.Sprintf("Installing %s@%s...", name, m.Version))
+
 	outputHandler := func(stream string, line string) {
 		// Silent - could log at debug level if needed
 	}
 
 	if err := m.InstallModuleWithStreaming(ctx, outputHandler); err != nil {
 		result.Error = fmt.Errorf("failed to install update: %w", err)
+
+		if _, err := client.RecordEvent(ctx, &pb.RecordEventRequest{Event: &pb.EventProto{
+			Action:       pb.EventAction_EVENT_ACTION_UPDATE,
+			Name:         name,
+			FromVersion:  installedVersion,
+			ToVersion:    m.Version,
+			ErrorMessage: result.Error.Error(),
+			Scan:         m.ScanResult(),
+		}}); err != nil {
+			logger.Warn("failed to record event", "module", name, "error", err)
+		}
+
 		return result
 	}
 
+	// Keep kubectl plugin registrations across updates
+	if mod.GetKubectlPlugin() != "" {
+		if _, err := m.RegisterKubectlPlugin(); err != nil {
+			logger.Warn("failed to register kubectl plugin", "module", name, "error", err)
+		}
+	}
+
 	// Store updated module info
-	if err := s.storeModule(ctx, client, m); err != nil {
+	report("store", "Saving to database...")
+
+	if err := storeModule(ctx, client, m); err != nil {
 		result.Error = fmt.Errorf("failed to store update: %w", err)
 		return result
 	}
 
+	if namespace != "" {
+		if err := owners.GetStore().Claim(owners.Owner{
+			Path:      m.BinaryPath,
+			Namespace: namespace,
+			Module:    m.Name,
+			Profile:   m.Profile(),
+		}); err != nil {
+			logger.Warn("failed to record binary owner", "module", name, "error", err)
+		}
+
+		if err := receipts.GetStore().Write(namespace, m.Name, m.Version, receipts.InstalledFiles(m.BinaryPath, m.ShimPath)...); err != nil {
+			logger.Warn("failed to write install receipt", "module", name, "error", err)
+		}
+	}
+
+	// Help captured for an earlier version is captured for the new one too
+	if module.HasHelp(m.Name) {
+		if _, err := m.CaptureHelp(ctx); err != nil {
+			logger.Warn("failed to capture help", "module", name, "error", err)
+		}
+	}
+
 	result.Updated = true
 
-	s.logger.Info("module updated",
+	report("complete", fmt.Sprintf("Updated %s: %s -> %s", name, installedVersion, m.Version))
+
+	logger.Info("module updated",
 		"module", name,
 		"from", installedVersion,
 		"to", m.Version,
@@ -314,29 +522,12 @@ func (s *Scheduler) checkModule(ctx context.Context, name, installedVersion stri
 }
 
 // storeModule stores the module in the database via gRPC
-func (s *Scheduler) storeModule(ctx context.Context, client pb.GlixServiceClient, m *module.Module) error {
+func storeModule(ctx context.Context, client pb.GlixServiceClient, m *module.Module) error {
 	// Convert module to proto
-	moduleProto := &pb.ModuleProto{
-		Name:              m.Name,
-		Version:           m.Version,
-		Versions:          m.Versions,
-		Hash:              m.Hash,
-		TimestampUnixNano: m.Time.UnixNano(),
-	}
-
-	// Convert dependencies
-	var deps []*pb.DependencyProto
-	for _, d := range m.Dependencies {
-		deps = append(deps, &pb.DependencyProto{
-			Name:     d.Name,
-			Version:  d.Version,
-			Versions: d.Versions,
-			Hash:     d.Hash,
-		})
-	}
+	moduleProto := m.ToProto()
 
 	depsProto := &pb.DependenciesProto{
-		Dependencies: deps,
+		Dependencies: moduleProto.GetDependencies(),
 	}
 
 	resp, err := client.StoreModule(ctx, &pb.StoreModuleRequest{
@@ -354,26 +545,6 @@ func (s *Scheduler) storeModule(ctx context.Context, client pb.GlixServiceClient
 	return nil
 }
 
-// isNewerVersion compares two versions and returns true if newVer is newer than oldVer
-func isNewerVersion(newVer, oldVer string) bool {
-	// Ensure versions have 'v' prefix for semver comparison
-	if newVer != "" && newVer[0] != 'v' {
-		newVer = "v" + newVer
-	}
-
-	if oldVer != "" && oldVer[0] != 'v' {
-		oldVer = "v" + oldVer
-	}
-
-	// If versions are identical, no update needed
-	if newVer == oldVer {
-		return false
-	}
-
-	// For pseudo-versions or non-standard versions, do string comparison
-	return newVer > oldVer
-}
-
 // RunOnce performs a single update check immediately
 func (s *Scheduler) RunOnce(ctx context.Context) (*CheckResult, error) {
 	result, err := s.CheckAndUpdate(ctx)
@@ -382,7 +553,12 @@ func (s *Scheduler) RunOnce(ctx context.Context) (*CheckResult, error) {
 	}
 
 	// Record the check
-	if err := s.store.RecordCheck(result.UpdatesDone); err != nil {
+	if _, err := s.store.SetAutoUpdateConfig(ctx, &pb.SetAutoUpdateConfigRequest{
+		RecordCheck: &pb.AutoUpdateCheck{
+			Updated: int64(result.UpdatesDone),
+			Pending: int64(result.UpdatesFound - result.UpdatesDone),
+		},
+	}); err != nil {
 		s.logger.Error("failed to record check", "error", err)
 	}
 
@@ -391,16 +567,25 @@ func (s *Scheduler) RunOnce(ctx context.Context) (*CheckResult, error) {
 
 // Ping checks if the server is available
 func (s *Scheduler) Ping(ctx context.Context) error {
-	client, conn, err := s.connectToServer(ctx)
+	client, release, err := s.connectToServer(ctx)
 	if err != nil {
 		return err
 	}
 
-	defer func() {
-		_ = conn.Close()
-	}()
+	defer release()
 
 	_, err = client.Ping(ctx, &emptypb.Empty{})
 
 	return err
 }
+
+// serverNamespace returns the namespace of the server, or "" when it cannot
+// tell, which leaves binary ownership unchecked
+func serverNamespace(ctx context.Context, client pb.GlixServiceClient) string {
+	status, err := client.GetStatus(ctx, &emptypb.Empty{})
+	if err != nil {
+		return ""
+	}
+
+	return status.GetNamespace()
+}
@@ -0,0 +1,177 @@
+// Package batch persists the progress of batch updates, so an interrupted
+// 'glix update --all' resumes where it left off.
+package batch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/inovacc/glix/internal/module"
+)
+
+// Status is the outcome of one module of a batch
+type Status string
+
+const (
+	StatusPending Status = "pending" // Not processed yet, or interrupted
+	StatusUpdated Status = "updated"
+	StatusCurrent Status = "current" // Already at the newest allowed version
+	StatusSkipped Status = "skipped" // Held, installed locally, or no allowed update
+	StatusFailed  Status = "failed"
+)
+
+// Orders of a batch
+const (
+	OrderSize = "size" // Smallest binaries first, as they tend to build fastest
+	OrderDeps = "deps" // Fewest dependencies first
+	OrderName = "name"
+)
+
+// Orders lists the supported orders
+var Orders = []string{OrderSize, OrderDeps, OrderName}
+
+// Item is one module of a batch
+type Item struct {
+	Module   string        `json:"module"`
+	From     string        `json:"from"`             // Version when the batch started
+	To       string        `json:"to,omitempty"`     // Version updated to
+	Weight   int64         `json:"weight,omitempty"` // Binary size or dependency count the order is by
+	Status   Status        `json:"status"`           // Pending until processed
+	Detail   string        `json:"detail,omitempty"` // Error, or why the module was skipped
+	Duration time.Duration `json:"duration,omitempty"`
+}
+
+// Batch is an ordered list of modules to update
+type Batch struct {
+	Started time.Time `json:"started"`
+	Order   string    `json:"order"` // How the items were ordered
+	Items   []Item    `json:"items"`
+}
+
+// New returns a batch of items sorted by order: by ascending weight for size
+// and deps, then by module name. Items without a status are pending.
+func New(order string, items []Item) (*Batch, error) {
+	if !slices.Contains(Orders, order) {
+		return nil, fmt.Errorf("invalid order %q: must be one of %s", order, strings.Join(Orders, ", "))
+	}
+
+	for i := range items {
+		if items[i].Status == "" {
+			items[i].Status = StatusPending
+		}
+	}
+
+	slices.SortStableFunc(items, func(a, b Item) int {
+		if order != OrderName && a.Weight != b.Weight {
+			return cmpInt(a.Weight, b.Weight)
+		}
+
+		return strings.Compare(a.Module, b.Module)
+	})
+
+	return &Batch{Started: time.Now(), Order: order, Items: items}, nil
+}
+
+func cmpInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+// Counts returns how many items have each status
+func (b *Batch) Counts() map[Status]int {
+	counts := make(map[Status]int)
+	for _, item := range b.Items {
+		counts[item.Status]++
+	}
+
+	return counts
+}
+
+// Done reports whether every item was processed
+func (b *Batch) Done() bool {
+	return b.Counts()[StatusPending] == 0
+}
+
+// storePath returns the path to the file of the current batch
+func storePath() string {
+	configDir, err := module.GetApplicationConfigDirectory()
+	if err != nil {
+		// Fallback to cache directory
+		configDir, _ = module.GetApplicationCacheDirectory()
+	}
+
+	return filepath.Join(configDir, "update-batch.json")
+}
+
+// Load returns the batch of an interrupted run, or nil when there is none
+func Load() (*Batch, error) {
+	return load(storePath())
+}
+
+func load(path string) (*Batch, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read update batch: %w", err)
+	}
+
+	var b Batch
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse update batch: %w", err)
+	}
+
+	return &b, nil
+}
+
+// Save writes the batch, after each item so an interruption loses at most
+// the item being processed
+func Save(b *Batch) error {
+	return save(storePath(), b)
+}
+
+func save(path string, b *Batch) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal update batch: %w", err)
+	}
+
+	// Written through a temporary file, so an interruption cannot leave a
+	// truncated batch behind
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write update batch: %w", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write update batch: %w", err)
+	}
+
+	return nil
+}
+
+// Clear removes the batch once it is finished or discarded
+func Clear() error {
+	if err := os.Remove(storePath()); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove update batch: %w", err)
+	}
+
+	return nil
+}
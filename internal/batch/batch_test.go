@@ -0,0 +1,107 @@
+package batch
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func modules(b *Batch) []string {
+	var names []string
+	for _, item := range b.Items {
+		names = append(names, item.Module)
+	}
+
+	return names
+}
+
+func TestNew(t *testing.T) {
+	items := func() []Item {
+		return []Item{
+			{Module: "example.com/c", Weight: 10},
+			{Module: "example.com/a", Weight: 30},
+			{Module: "example.com/b", Weight: 10},
+			{Module: "example.com/local", Status: StatusSkipped},
+		}
+	}
+
+	tests := []struct {
+		order string
+		want  []string
+	}{
+		{OrderSize, []string{"example.com/local", "example.com/b", "example.com/c", "example.com/a"}},
+		{OrderDeps, []string{"example.com/local", "example.com/b", "example.com/c", "example.com/a"}},
+		{OrderName, []string{"example.com/a", "example.com/b", "example.com/c", "example.com/local"}},
+	}
+
+	for _, tt := range tests {
+		b, err := New(tt.order, items())
+		if err != nil {
+			t.Fatalf("New(%s): %v", tt.order, err)
+		}
+
+		if got := modules(b); !slices.Equal(got, tt.want) {
+			t.Errorf("New(%s) order = %v, want %v", tt.order, got, tt.want)
+		}
+	}
+
+	if _, err := New("tag", items()); err == nil {
+		t.Error("New accepted an unknown order")
+	}
+
+	b, _ := New(OrderName, items())
+
+	counts := b.Counts()
+	if counts[StatusPending] != 3 || counts[StatusSkipped] != 1 {
+		t.Errorf("Counts = %v, want 3 pending and 1 skipped", counts)
+	}
+
+	if b.Done() {
+		t.Error("Done with pending items")
+	}
+
+	for i := range b.Items {
+		if b.Items[i].Status == StatusPending {
+			b.Items[i].Status = StatusUpdated
+		}
+	}
+
+	if !b.Done() {
+		t.Error("not Done once every item was processed")
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "update-batch.json")
+
+	if b, err := load(path); b != nil || err != nil {
+		t.Fatalf("load of a missing batch = %v, %v, want nil, nil", b, err)
+	}
+
+	b, _ := New(OrderSize, []Item{
+		{Module: "example.com/a", From: "v1.0.0"},
+		{Module: "example.com/b", From: "v2.0.0"},
+	})
+
+	b.Items[0].Status = StatusUpdated
+	b.Items[0].To = "v1.1.0"
+	b.Items[0].Duration = 3 * time.Second
+
+	if err := save(path, b); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	got, err := load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	if !got.Started.Equal(b.Started) || got.Order != b.Order || !slices.Equal(got.Items, b.Items) {
+		t.Errorf("load = %+v, want %+v", got, b)
+	}
+
+	if got.Done() {
+		t.Error("resumed batch is Done with a pending item")
+	}
+}
@@ -0,0 +1,492 @@
+// Package bundle packages modules for air-gapped installation. A bundle is a
+// zstd-compressed tar archive holding prebuilt binaries, the module cache
+// files needed to rebuild them (in GOPROXY layout), and a manifest with the
+// hashes every file is verified against before anything is installed.
+package bundle
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/pkg/exec"
+	"github.com/klauspost/compress/zstd"
+	"golang.org/x/mod/sumdb/dirhash"
+)
+
+// FormatVersion is the manifest format written by this version of glix
+const FormatVersion = 1
+
+// ManifestName is the manifest's path inside the archive
+const ManifestName = "manifest.json"
+
+// cacheDir holds module cache files inside the archive
+const cacheDir = "cache"
+
+// File is a file in the bundle and its expected hashes
+type File struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	Sum    string `json:"sum,omitempty"` // go.sum h1: hash, for module zips
+}
+
+// Entry is a bundled module
+type Entry struct {
+	Module   *module.Module `json:"module"`
+	Binaries []File         `json:"binaries"`
+}
+
+// Manifest describes the contents of a bundle
+type Manifest struct {
+	FormatVersion int       `json:"format_version"`
+	Created       time.Time `json:"created"`
+	GOOS          string    `json:"goos"`   // Platform the binaries were built for
+	GOARCH        string    `json:"goarch"` // Platform the binaries were built for
+	Modules       []Entry   `json:"modules"`
+	Files         []File    `json:"files"` // Module cache files
+}
+
+// MatchesPlatform reports whether the prebuilt binaries run on this machine
+func (m *Manifest) MatchesPlatform() bool {
+	return m.GOOS == runtime.GOOS && m.GOARCH == runtime.GOARCH
+}
+
+// Create builds every module with go install into a staging directory and
+// writes the bundle to out. Modules must already be resolved with
+// FetchModuleInfo. Only the go toolchain build path is used, so binaries
+// match what 'go install' produces.
+func Create(ctx context.Context, out string, modules []*module.Module, progress module.ProgressHandler) (*Manifest, error) {
+	staging, err := os.MkdirTemp("", "glix-bundle-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+
+	modCache := filepath.Join(staging, "modcache")
+
+	defer func() {
+		// The module cache is read-only; let go remove it
+		cmd := exec.CommandContext(context.Background(), "go", "clean", "-modcache")
+		cmd.Env = append(os.Environ(), "GOMODCACHE="+modCache)
+		_ = cmd.Run()
+		_ = os.RemoveAll(staging)
+	}()
+
+	root := filepath.Join(staging, "bundle")
+
+	manifest := &Manifest{
+		FormatVersion: FormatVersion,
+		Created:       time.Now(),
+		GOOS:          runtime.GOOS,
+		GOARCH:        runtime.GOARCH,
+	}
+
+	for i, m := range modules {
+		progress("build", fmt.Sprintf("Building %s@%s...", m.Name, m.Version))
+
+		binDir := path.Join("bin", fmt.Sprint(i))
+
+		cmd := exec.CommandContext(ctx, "go", "install", fmt.Sprintf("%s@%s", m.Name, m.Version))
+		cmd.Env = append(os.Environ(),
+			"GOBIN="+filepath.Join(root, filepath.FromSlash(binDir)),
+			"GOMODCACHE="+modCache,
+			"GOFLAGS=-mod=mod",
+		)
+
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return nil, fmt.Errorf("go install %s@%s failed: %w\n%s", m.Name, m.Version, err, strings.TrimSpace(string(output)))
+		}
+
+		binaries, err := hashTree(root, binDir, func(string) bool { return true })
+		if err != nil {
+			return nil, err
+		}
+
+		manifest.Modules = append(manifest.Modules, Entry{Module: m, Binaries: binaries})
+	}
+
+	progress("cache", "Collecting module cache files...")
+
+	// The staging cache's download directory is a complete GOPROXY tree for
+	// every module the builds needed
+	if err := os.CopyFS(filepath.Join(root, cacheDir), os.DirFS(filepath.Join(modCache, "cache", "download"))); err != nil {
+		return nil, fmt.Errorf("failed to copy module cache: %w", err)
+	}
+
+	manifest.Files, err = hashTree(root, cacheDir, isProxyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// Drop lock files, hash caches and sumdb tiles; they are not served
+	err = filepath.WalkDir(filepath.Join(root, cacheDir), func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || isProxyFile(d.Name()) {
+			return err
+		}
+
+		return os.Remove(p)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to prune module cache: %w", err)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, ManifestName), data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	progress("archive", fmt.Sprintf("Writing %s...", out))
+
+	if err := writeArchive(root, out); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// isProxyFile reports whether a module cache file is part of the GOPROXY
+// protocol
+func isProxyFile(name string) bool {
+	return name == "list" || strings.HasSuffix(name, ".info") || strings.HasSuffix(name, ".mod") || strings.HasSuffix(name, ".zip")
+}
+
+// hashTree hashes the files below root/dir accepted by keep. Module zips
+// also get their go.sum hash.
+func hashTree(root, dir string, keep func(name string) bool) ([]File, error) {
+	var files []File
+
+	err := filepath.WalkDir(filepath.Join(root, filepath.FromSlash(dir)), func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !keep(d.Name()) {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+
+		f := File{Path: filepath.ToSlash(rel)}
+
+		if f.SHA256, err = hashFile(p); err != nil {
+			return err
+		}
+
+		if strings.HasSuffix(p, ".zip") && strings.HasPrefix(f.Path, cacheDir+"/") {
+			if f.Sum, err = dirhash.HashZip(p, dirhash.Hash1); err != nil {
+				return fmt.Errorf("failed to hash %s: %w", f.Path, err)
+			}
+		}
+
+		files = append(files, f)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash bundle files: %w", err)
+	}
+
+	return files, nil
+}
+
+func hashFile(p string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeArchive writes the tree below root as a tar.zst archive
+func writeArchive(root, out string) (err error) {
+	f, err := os.Create(out)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle: %w", err)
+	}
+
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	zw, err := zstd.NewWriter(f)
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(zw)
+
+	if err := tw.AddFS(os.DirFS(root)); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// Bundle is an extracted and verified bundle
+type Bundle struct {
+	Dir      string
+	Manifest *Manifest
+}
+
+// Open extracts the bundle at path to a temporary directory and verifies
+// every file against the manifest. Close removes the extracted files.
+func Open(path string) (*Bundle, error) {
+	dir, err := os.MkdirTemp("", "glix-bundle-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create extraction directory: %w", err)
+	}
+
+	b := &Bundle{Dir: dir}
+
+	if err := extract(path, dir); err != nil {
+		_ = b.Close()
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ManifestName))
+	if err != nil {
+		_ = b.Close()
+		return nil, fmt.Errorf("bundle has no manifest: %w", err)
+	}
+
+	b.Manifest = &Manifest{}
+	if err := json.Unmarshal(data, b.Manifest); err != nil {
+		_ = b.Close()
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+
+	if b.Manifest.FormatVersion != FormatVersion {
+		_ = b.Close()
+		return nil, fmt.Errorf("unsupported bundle format version %d", b.Manifest.FormatVersion)
+	}
+
+	if err := b.verify(); err != nil {
+		_ = b.Close()
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Close removes the extracted bundle
+func (b *Bundle) Close() error {
+	return os.RemoveAll(b.Dir)
+}
+
+// verify checks every listed file's hashes and rejects unlisted files, so a
+// tampered archive is refused before anything is installed
+func (b *Bundle) verify() error {
+	expected := slices.Clone(b.Manifest.Files)
+	for _, e := range b.Manifest.Modules {
+		expected = append(expected, e.Binaries...)
+	}
+
+	listed := make(map[string]bool, len(expected))
+
+	var errs []error
+
+	for _, f := range expected {
+		listed[f.Path] = true
+		p := filepath.Join(b.Dir, filepath.FromSlash(f.Path))
+
+		sum, err := hashFile(p)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", f.Path, err))
+			continue
+		}
+
+		if sum != f.SHA256 {
+			errs = append(errs, fmt.Errorf("%s: sha256 mismatch", f.Path))
+			continue
+		}
+
+		if f.Sum != "" {
+			h1, err := dirhash.HashZip(p, dirhash.Hash1)
+			if err != nil || h1 != f.Sum {
+				errs = append(errs, fmt.Errorf("%s: module hash mismatch", f.Path))
+			}
+		}
+	}
+
+	err := filepath.WalkDir(b.Dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(b.Dir, p)
+		if err != nil {
+			return err
+		}
+
+		if rel = filepath.ToSlash(rel); rel != ManifestName && !listed[rel] {
+			errs = append(errs, fmt.Errorf("%s: not listed in manifest", rel))
+		}
+
+		return nil
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("bundle verification failed: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// extract unpacks a tar.zst archive into dir, refusing entries that would
+// escape it
+func extract(archive, dir string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return fmt.Errorf("failed to open bundle: %w", err)
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	zr, err := zstd.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("failed to open bundle: %w", err)
+	}
+
+	defer zr.Close()
+
+	tr := tar.NewReader(zr)
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read bundle: %w", err)
+		}
+
+		if !filepath.IsLocal(hdr.Name) {
+			return fmt.Errorf("invalid path in bundle: %s", hdr.Name)
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(hdr.Name))
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeFile(target, tr, hdr.FileInfo().Mode().Perm()); err != nil {
+				return fmt.Errorf("failed to extract %s: %w", hdr.Name, err)
+			}
+		default:
+			return fmt.Errorf("unsupported entry in bundle: %s", hdr.Name)
+		}
+	}
+}
+
+func writeFile(target string, r io.Reader, perm fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Install places the binaries of a bundled module in gobin. Prebuilt
+// binaries are copied when the platform matches; otherwise the module is
+// rebuilt from the bundled sources with the network disabled.
+func (b *Bundle) Install(ctx context.Context, e Entry, gobin string) error {
+	if err := os.MkdirAll(gobin, 0755); err != nil {
+		return fmt.Errorf("failed to create GOBIN directory: %w", err)
+	}
+
+	if b.Manifest.MatchesPlatform() {
+		for _, bin := range e.Binaries {
+			src, err := os.Open(filepath.Join(b.Dir, filepath.FromSlash(bin.Path)))
+			if err != nil {
+				return err
+			}
+
+			err = writeFile(filepath.Join(gobin, path.Base(bin.Path)), src, 0755)
+			_ = src.Close()
+
+			if err != nil {
+				return fmt.Errorf("failed to install %s: %w", path.Base(bin.Path), err)
+			}
+		}
+
+		return nil
+	}
+
+	proxy := fileURL(filepath.Join(b.Dir, cacheDir))
+
+	cmd := exec.CommandContext(ctx, "go", "install", fmt.Sprintf("%s@%s", e.Module.Name, e.Module.Version))
+	cmd.Env = append(os.Environ(),
+		"GOBIN="+gobin,
+		"GOPROXY="+proxy,
+		"GOSUMDB=off", // Zips were verified against the manifest's go.sum hashes
+		"GOTOOLCHAIN=local",
+		"GOFLAGS=-mod=mod",
+	)
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("offline build of %s@%s failed: %w\n%s", e.Module.Name, e.Module.Version, err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// fileURL returns the file:// URL GOPROXY accepts for a local directory
+func fileURL(dir string) string {
+	p := filepath.ToSlash(dir)
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p // Windows drive paths
+	}
+
+	return "file://" + p
+}
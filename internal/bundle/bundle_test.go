@@ -0,0 +1,160 @@
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/inovacc/glix/internal/module"
+	modversion "golang.org/x/mod/module"
+	modzip "golang.org/x/mod/zip"
+)
+
+// writeTree lays out a bundle tree by hand: one binary and one module zip
+func writeTree(t *testing.T, root string) {
+	t.Helper()
+
+	files := map[string]string{
+		"bin/0/tool":                           "#!/bin/sh\necho tool\n",
+		"cache/example.com/lib/@v/list":        "v1.0.0\n",
+		"cache/example.com/lib/@v/v1.0.0.mod":  "module example.com/lib\n",
+		"cache/example.com/lib/@v/v1.0.0.info": `{"Version":"v1.0.0"}`,
+	}
+
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(p, []byte(content), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	src := t.TempDir()
+	if err := os.WriteFile(filepath.Join(src, "go.mod"), []byte("module example.com/lib\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(src, "lib.go"), []byte("package lib\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	zf, err := os.Create(filepath.Join(root, "cache/example.com/lib/@v/v1.0.0.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := modzip.CreateFromDir(zf, modversion.Version{Path: "example.com/lib", Version: "v1.0.0"}, src); err != nil {
+		t.Fatal(err)
+	}
+
+	_ = zf.Close()
+
+	binaries, err := hashTree(root, "bin/0", func(string) bool { return true })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache, err := hashTree(root, cacheDir, isProxyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := Manifest{
+		FormatVersion: FormatVersion,
+		Created:       time.Now(),
+		GOOS:          runtime.GOOS,
+		GOARCH:        runtime.GOARCH,
+		Modules:       []Entry{{Module: &module.Module{Name: "example.com/tool", Version: "v1.0.0"}, Binaries: binaries}},
+		Files:         cache,
+	}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, ManifestName), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOpenAndInstall(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root)
+
+	archive := filepath.Join(t.TempDir(), "bundle.tar.zst")
+	if err := writeArchive(root, archive); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := Open(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = b.Close()
+	}()
+
+	var zip File
+
+	for _, f := range b.Manifest.Files {
+		if strings.HasSuffix(f.Path, ".zip") {
+			zip = f
+		}
+	}
+
+	if !strings.HasPrefix(zip.Sum, "h1:") {
+		t.Errorf("module zip has no go.sum hash: %+v", zip)
+	}
+
+	gobin := t.TempDir()
+	if err := b.Install(context.Background(), b.Manifest.Modules[0], gobin); err != nil {
+		t.Fatal(err)
+	}
+
+	if info, err := os.Stat(filepath.Join(gobin, "tool")); err != nil || info.Mode().Perm()&0100 == 0 {
+		t.Errorf("installed binary = %v, %v", info, err)
+	}
+}
+
+func TestOpen_Tampered(t *testing.T) {
+	for name, tamper := range map[string]func(root string) error{
+		"modified binary": func(root string) error {
+			return os.WriteFile(filepath.Join(root, "bin", "0", "tool"), []byte("#!/bin/sh\nrm -rf ~\n"), 0755)
+		},
+		"unlisted file": func(root string) error {
+			return os.WriteFile(filepath.Join(root, "bin", "0", "extra"), []byte("x"), 0755)
+		},
+		"missing file": func(root string) error {
+			return os.Remove(filepath.Join(root, "cache", "example.com", "lib", "@v", "v1.0.0.mod"))
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			writeTree(t, root)
+
+			if err := tamper(root); err != nil {
+				t.Fatal(err)
+			}
+
+			archive := filepath.Join(t.TempDir(), "bundle.tar.zst")
+			if err := writeArchive(root, archive); err != nil {
+				t.Fatal(err)
+			}
+
+			if b, err := Open(archive); err == nil {
+				_ = b.Close()
+				t.Fatal("expected verification error")
+			}
+		})
+	}
+}
@@ -6,9 +6,9 @@ import (
 	"time"
 
 	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/transport"
 	pb "github.com/inovacc/glix/pkg/api/v1"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -21,13 +21,18 @@ type Client struct {
 // Config holds client configuration
 type Config struct {
 	Address     string
+	TLS         transport.TLS
+	Token       string // API token sent with every request, over TLS only
 	DialTimeout time.Duration
 }
 
-// DefaultConfig returns the default client configuration
+// DefaultConfig returns the default client configuration, for the current
+// target
 func DefaultConfig() Config {
 	return Config{
-		Address:     "localhost:9742",
+		Address:     target.Address,
+		TLS:         target.TLS,
+		Token:       target.Token,
 		DialTimeout: 5 * time.Second,
 	}
 }
@@ -37,10 +42,25 @@ func New(cfg Config) (*Client, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.DialTimeout)
 	defer cancel()
 
-	conn, err := grpc.DialContext(ctx, cfg.Address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	creds, err := transport.ClientCredentials(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
 		grpc.WithBlock(),
-	)
+	}
+
+	if cfg.Token != "" {
+		if !cfg.TLS.Enabled {
+			return nil, fmt.Errorf("API tokens are only sent over TLS; connect to %s through a context with --tls", cfg.Address)
+		}
+
+		opts = append(opts, grpc.WithPerRPCCredentials(transport.TokenCredentials(cfg.Token)))
+	}
+
+	conn, err := grpc.DialContext(ctx, cfg.Address, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to server at %s: %w", cfg.Address, err)
 	}
@@ -73,28 +93,14 @@ func (c *Client) GetStatus(ctx context.Context) (*pb.ServerStatus, error) {
 
 // StoreModule stores module info in the database after local installation
 func (c *Client) StoreModule(ctx context.Context, m *module.Module) error {
-	// Convert module to proto
-	moduleProto := &pb.ModuleProto{
-		Name:              m.Name,
-		Version:           m.Version,
-		Versions:          m.Versions,
-		Hash:              m.Hash,
-		TimestampUnixNano: m.Time.UnixNano(),
-	}
-
-	// Convert dependencies
-	var deps []*pb.DependencyProto
-	for _, d := range m.Dependencies {
-		deps = append(deps, &pb.DependencyProto{
-			Name:     d.Name,
-			Version:  d.Version,
-			Versions: d.Versions,
-			Hash:     d.Hash,
-		})
-	}
+	return c.StoreModuleRecord(ctx, m.ToProto())
+}
 
+// StoreModuleRecord stores an existing module record, such as one taken from
+// the install history
+func (c *Client) StoreModuleRecord(ctx context.Context, moduleProto *pb.ModuleProto) error {
 	depsProto := &pb.DependenciesProto{
-		Dependencies: deps,
+		Dependencies: moduleProto.GetDependencies(),
 	}
 
 	resp, err := c.client.StoreModule(ctx, &pb.StoreModuleRequest{
@@ -112,6 +118,26 @@ func (c *Client) StoreModule(ctx context.Context, m *module.Module) error {
 	return nil
 }
 
+// RefreshModule stores the refreshed record of an installed module, under
+// its new name when its package moved from renamedFrom
+func (c *Client) RefreshModule(ctx context.Context, moduleProto *pb.ModuleProto, renamedFrom string) error {
+	resp, err := c.client.StoreModule(ctx, &pb.StoreModuleRequest{
+		Module:       moduleProto,
+		Dependencies: &pb.DependenciesProto{Dependencies: moduleProto.GetDependencies()},
+		Refresh:      true,
+		RenamedFrom:  renamedFrom,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store module: %w", err)
+	}
+
+	if !resp.GetSuccess() {
+		return fmt.Errorf("failed to store module: %s", resp.GetErrorMessage())
+	}
+
+	return nil
+}
+
 // Remove removes an installed module
 func (c *Client) Remove(ctx context.Context, modulePath, version string) (*pb.RemoveResponse, error) {
 	return c.client.Remove(ctx, &pb.RemoveRequest{
@@ -120,6 +146,326 @@ func (c *Client) Remove(ctx context.Context, modulePath, version string) (*pb.Re
 	})
 }
 
+// MarkBadVersion records a version of an installed module as broken
+func (c *Client) MarkBadVersion(ctx context.Context, name, version string) (*pb.ModuleProto, error) {
+	resp, err := c.client.MarkBadVersion(ctx, &pb.MarkBadVersionRequest{
+		Name:    name,
+		Version: version,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark bad version: %w", err)
+	}
+
+	if !resp.GetSuccess() {
+		return nil, fmt.Errorf("failed to mark bad version: %s", resp.GetErrorMessage())
+	}
+
+	return resp.GetModule(), nil
+}
+
+// SetAlias records the binary name of an installed module after its binary
+// was moved to binaryPath; an empty alias restores the default name
+func (c *Client) SetAlias(ctx context.Context, name, alias, binaryPath string) (*pb.ModuleProto, error) {
+	resp, err := c.client.SetAlias(ctx, &pb.SetAliasRequest{
+		Name:       name,
+		Alias:      alias,
+		BinaryPath: binaryPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set alias: %w", err)
+	}
+
+	if !resp.GetSuccess() {
+		return nil, fmt.Errorf("failed to set alias: %s", resp.GetErrorMessage())
+	}
+
+	return resp.GetModule(), nil
+}
+
+// VerifyBinaries compares the installed binaries of the named modules, or
+// of all modules, with the hashes recorded at install. With accept, the
+// current hashes are recorded instead. With sums, the recorded module
+// checksums are checked too.
+func (c *Client) VerifyBinaries(ctx context.Context, names []string, accept, sums bool) ([]*pb.BinaryVerification, error) {
+	resp, err := c.client.VerifyBinaries(ctx, &pb.VerifyBinariesRequest{
+		Names:  names,
+		Accept: accept,
+		Sums:   sums,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify binaries: %w", err)
+	}
+
+	if resp.GetErrorMessage() != "" {
+		return nil, fmt.Errorf("failed to verify binaries: %s", resp.GetErrorMessage())
+	}
+
+	return resp.GetResults(), nil
+}
+
+// GetInstallHistory returns the records of the versions a module has had
+// installed, oldest first
+func (c *Client) GetInstallHistory(ctx context.Context, name string) ([]*pb.ModuleProto, error) {
+	resp, err := c.client.GetInstallHistory(ctx, &pb.GetInstallHistoryRequest{
+		Name: name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get install history: %w", err)
+	}
+
+	if resp.GetErrorMessage() != "" {
+		return nil, fmt.Errorf("failed to get install history: %s", resp.GetErrorMessage())
+	}
+
+	return resp.GetInstalls(), nil
+}
+
+// RecordEvent adds an install, update, or remove to the event history
+func (c *Client) RecordEvent(ctx context.Context, event *pb.EventProto) error {
+	resp, err := c.client.RecordEvent(ctx, &pb.RecordEventRequest{
+		Event: event,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	if !resp.GetSuccess() {
+		return fmt.Errorf("failed to record event: %s", resp.GetErrorMessage())
+	}
+
+	return nil
+}
+
+// GetHistory returns recorded events, newest first. An empty name returns
+// the events of all modules; a limit of 0 returns every event.
+func (c *Client) GetHistory(ctx context.Context, name string, limit int32) ([]*pb.EventProto, error) {
+	resp, err := c.client.GetHistory(ctx, &pb.GetHistoryRequest{
+		Name:  name,
+		Limit: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history: %w", err)
+	}
+
+	if resp.GetErrorMessage() != "" {
+		return nil, fmt.Errorf("failed to get history: %s", resp.GetErrorMessage())
+	}
+
+	return resp.GetEvents(), nil
+}
+
+// VersionStore returns the server's cache of module versions, which modules
+// share their go list -m -versions answers through
+func (c *Client) VersionStore() module.VersionStore {
+	return module.RemoteVersionStore(c.client)
+}
+
+// Service returns the underlying gRPC client, for packages that call the
+// daemon API directly
+func (c *Client) Service() pb.GlixServiceClient {
+	return c.client
+}
+
+// StoreVulnReport stores the result of a govulncheck scan of an installed
+// module
+func (c *Client) StoreVulnReport(ctx context.Context, report *pb.VulnReportProto) error {
+	resp, err := c.client.StoreVulnReport(ctx, &pb.StoreVulnReportRequest{
+		Report: report,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store vulnerability report: %w", err)
+	}
+
+	if !resp.GetSuccess() {
+		return fmt.Errorf("failed to store vulnerability report: %s", resp.GetErrorMessage())
+	}
+
+	return nil
+}
+
+// StoreLibraryWatch starts watching a library module for new releases, or
+// replaces its entry
+func (c *Client) StoreLibraryWatch(ctx context.Context, watch *pb.LibraryWatchProto) error {
+	resp, err := c.client.StoreLibraryWatch(ctx, &pb.StoreLibraryWatchRequest{
+		Watch: watch,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch library: %w", err)
+	}
+
+	if !resp.GetSuccess() {
+		return fmt.Errorf("failed to watch library: %s", resp.GetErrorMessage())
+	}
+
+	return nil
+}
+
+// ListLibraryWatches returns the watched library modules ordered by path
+func (c *Client) ListLibraryWatches(ctx context.Context) ([]*pb.LibraryWatchProto, error) {
+	resp, err := c.client.ListLibraryWatches(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list library watches: %w", err)
+	}
+
+	if resp.GetErrorMessage() != "" {
+		return nil, fmt.Errorf("failed to list library watches: %s", resp.GetErrorMessage())
+	}
+
+	return resp.GetWatches(), nil
+}
+
+// RemoveLibraryWatch stops watching a library module
+func (c *Client) RemoveLibraryWatch(ctx context.Context, path string) error {
+	resp, err := c.client.RemoveLibraryWatch(ctx, &pb.RemoveLibraryWatchRequest{
+		Path: path,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove library watch: %w", err)
+	}
+
+	if !resp.GetSuccess() {
+		return fmt.Errorf("failed to remove library watch: %s", resp.GetErrorMessage())
+	}
+
+	return nil
+}
+
+// GetAutoUpdateConfig returns the auto-update configuration and statistics,
+// or nil before auto-update was ever configured
+func (c *Client) GetAutoUpdateConfig(ctx context.Context) (*pb.AutoUpdateConfigProto, error) {
+	resp, err := c.client.GetAutoUpdateConfig(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auto-update config: %w", err)
+	}
+
+	if resp.GetErrorMessage() != "" {
+		return nil, fmt.Errorf("failed to get auto-update config: %s", resp.GetErrorMessage())
+	}
+
+	return resp.GetConfig(), nil
+}
+
+// SetAutoUpdateConfig changes the auto-update configuration or records a
+// check, and returns the configuration after the change
+func (c *Client) SetAutoUpdateConfig(ctx context.Context, req *pb.SetAutoUpdateConfigRequest) (*pb.AutoUpdateConfigProto, error) {
+	resp, err := c.client.SetAutoUpdateConfig(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set auto-update config: %w", err)
+	}
+
+	if !resp.GetSuccess() {
+		return nil, fmt.Errorf("failed to set auto-update config: %s", resp.GetErrorMessage())
+	}
+
+	return resp.GetConfig(), nil
+}
+
+// CreateToken issues an API token of scope, expiring after ttl unless it is
+// 0, and returns it with the token itself, which is not shown again
+func (c *Client) CreateToken(ctx context.Context, name, scope string, ttl time.Duration) (*pb.CreateTokenResponse, error) {
+	resp, err := c.client.CreateToken(ctx, &pb.CreateTokenRequest{
+		Name:    name,
+		Scope:   scope,
+		TtlNano: int64(ttl),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token: %w", err)
+	}
+
+	if resp.GetErrorMessage() != "" {
+		return nil, fmt.Errorf("failed to create token: %s", resp.GetErrorMessage())
+	}
+
+	return resp, nil
+}
+
+// ListTokens returns the API tokens ordered by name
+func (c *Client) ListTokens(ctx context.Context) ([]*pb.TokenProto, error) {
+	resp, err := c.client.ListTokens(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tokens: %w", err)
+	}
+
+	if resp.GetErrorMessage() != "" {
+		return nil, fmt.Errorf("failed to list tokens: %s", resp.GetErrorMessage())
+	}
+
+	return resp.GetTokens(), nil
+}
+
+// RevokeToken deletes an API token
+func (c *Client) RevokeToken(ctx context.Context, name string) error {
+	resp, err := c.client.RevokeToken(ctx, &pb.RevokeTokenRequest{
+		Name: name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+
+	if !resp.GetSuccess() {
+		return fmt.Errorf("failed to revoke token: %s", resp.GetErrorMessage())
+	}
+
+	return nil
+}
+
+// ListVulnReports returns stored vulnerability reports ordered by module. An
+// empty name returns the reports of all modules.
+func (c *Client) ListVulnReports(ctx context.Context, name string) ([]*pb.VulnReportProto, error) {
+	resp, err := c.client.ListVulnReports(ctx, &pb.ListVulnReportsRequest{
+		Name: name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vulnerability reports: %w", err)
+	}
+
+	if resp.GetErrorMessage() != "" {
+		return nil, fmt.Errorf("failed to list vulnerability reports: %s", resp.GetErrorMessage())
+	}
+
+	return resp.GetReports(), nil
+}
+
+// GetStats returns weekly counts of the event history, oldest week first,
+// and the build metrics of those weeks. A weeks of 0 returns the server's
+// default of 12.
+func (c *Client) GetStats(ctx context.Context, weeks int32) (*pb.GetStatsResponse, error) {
+	resp, err := c.client.GetStats(ctx, &pb.GetStatsRequest{Weeks: weeks})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stats: %w", err)
+	}
+
+	if resp.GetErrorMessage() != "" {
+		return nil, fmt.Errorf("failed to get stats: %s", resp.GetErrorMessage())
+	}
+
+	return resp, nil
+}
+
+// ListTasks returns the daemon's scheduled tasks
+func (c *Client) ListTasks(ctx context.Context) ([]*pb.TaskProto, error) {
+	resp, err := c.client.ListTasks(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	return resp.GetTasks(), nil
+}
+
+// RunTask runs a scheduled task now and returns its summary
+func (c *Client) RunTask(ctx context.Context, name string) (string, error) {
+	resp, err := c.client.RunTask(ctx, &pb.RunTaskRequest{Name: name})
+	if err != nil {
+		return "", fmt.Errorf("failed to run task: %w", err)
+	}
+
+	if !resp.GetSuccess() {
+		return resp.GetResult(), fmt.Errorf("task %s failed: %s", name, resp.GetErrorMessage())
+	}
+
+	return resp.GetResult(), nil
+}
+
 // ListModules returns all installed modules
 func (c *Client) ListModules(ctx context.Context, limit, offset int32, nameFilter string) (*pb.ListModulesResponse, error) {
 	return c.client.ListModules(ctx, &pb.ListModulesRequest{
@@ -129,6 +475,17 @@ func (c *Client) ListModules(ctx context.Context, limit, offset int32, nameFilte
 	})
 }
 
+// ListModulesSecurity lists installed modules like ListModules, with the
+// server's roll-up of the vulnerabilities and flagged licenses of each
+func (c *Client) ListModulesSecurity(ctx context.Context, limit, offset int32, nameFilter string) (*pb.ListModulesResponse, error) {
+	return c.client.ListModules(ctx, &pb.ListModulesRequest{
+		Limit:      limit,
+		Offset:     offset,
+		NameFilter: nameFilter,
+		Security:   true,
+	})
+}
+
 // GetModule retrieves a specific module
 func (c *Client) GetModule(ctx context.Context, name, version string) (*pb.GetModuleResponse, error) {
 	return c.client.GetModule(ctx, &pb.GetModuleRequest{
@@ -144,3 +501,79 @@ func (c *Client) GetDependencies(ctx context.Context, name, version string) (*pb
 		Version: version,
 	})
 }
+
+// GetLatestVersions returns the latest available versions from the daemon's version cache
+func (c *Client) GetLatestVersions(ctx context.Context, names []string, refresh bool) (*pb.GetLatestVersionsResponse, error) {
+	return c.client.GetLatestVersions(ctx, &pb.GetLatestVersionsRequest{
+		Names:   names,
+		Refresh: refresh,
+	})
+}
+
+// Search queries the package index through the daemon
+func (c *Client) Search(ctx context.Context, query string, limit int32) ([]*pb.SearchResult, error) {
+	resp, err := c.client.Search(ctx, &pb.SearchRequest{
+		Query: query,
+		Limit: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+
+	if resp.GetErrorMessage() != "" {
+		return nil, fmt.Errorf("search failed: %s", resp.GetErrorMessage())
+	}
+
+	return resp.GetResults(), nil
+}
+
+// CreateSnapshot captures the installed module set under the given name
+func (c *Client) CreateSnapshot(ctx context.Context, name, description string, overwrite bool) (*pb.SnapshotProto, error) {
+	resp, err := c.client.CreateSnapshot(ctx, &pb.CreateSnapshotRequest{
+		Name:        name,
+		Description: description,
+		Overwrite:   overwrite,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot: %w", err)
+	}
+
+	if !resp.GetSuccess() {
+		return nil, fmt.Errorf("failed to create snapshot: %s", resp.GetErrorMessage())
+	}
+
+	return resp.GetSnapshot(), nil
+}
+
+// GetSnapshot retrieves a snapshot by name
+func (c *Client) GetSnapshot(ctx context.Context, name string) (*pb.GetSnapshotResponse, error) {
+	return c.client.GetSnapshot(ctx, &pb.GetSnapshotRequest{
+		Name: name,
+	})
+}
+
+// ListSnapshots returns all stored snapshots
+func (c *Client) ListSnapshots(ctx context.Context) (*pb.ListSnapshotsResponse, error) {
+	return c.client.ListSnapshots(ctx, &emptypb.Empty{})
+}
+
+// DeleteSnapshot removes a snapshot by name
+func (c *Client) DeleteSnapshot(ctx context.Context, name string) (*pb.DeleteSnapshotResponse, error) {
+	return c.client.DeleteSnapshot(ctx, &pb.DeleteSnapshotRequest{
+		Name: name,
+	})
+}
+
+// ListInventories returns the fleet inventories reported to the server,
+// optionally filtered by module path substring and host
+func (c *Client) ListInventories(ctx context.Context, moduleFilter, host string) ([]*pb.InventoryProto, error) {
+	resp, err := c.client.ListInventories(ctx, &pb.ListInventoriesRequest{
+		Module: moduleFilter,
+		Host:   host,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetInventories(), nil
+}
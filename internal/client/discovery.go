@@ -4,34 +4,63 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/inovacc/glix/internal/server"
+	"github.com/inovacc/glix/internal/transport"
 )
 
 // DefaultIdleTimeout is the default time the on-demand server stays alive after last activity
 const DefaultIdleTimeout = 5 * time.Minute
 
+// DefaultTaskWindow is how soon a scheduled task must be due for an idle
+// on-demand server to stay up and run it
+const DefaultTaskWindow = 10 * time.Minute
+
+// remoteDialTimeout bounds connecting to a remote server
+const remoteDialTimeout = 5 * time.Second
+
 // DiscoveryConfig holds configuration for server discovery
 type DiscoveryConfig struct {
 	Address         string
 	Port            int
+	TLS             transport.TLS
+	Token           string // API token sent with every request
+	Existing        bool   // Only connect to a running server, never start one
+	Executable      string // glix binary started for on-demand servers, the running one when empty
 	IdleTimeout     time.Duration
+	TaskWindow      time.Duration // An idle on-demand server stays up for tasks due within it
 	StartTimeout    time.Duration
 	ConnectionRetry int
 	RetryDelay      time.Duration
 	Logger          *slog.Logger
 }
 
-// DefaultDiscoveryConfig returns the default discovery configuration
+// DefaultDiscoveryConfig returns the default discovery configuration, for
+// the current target
 func DefaultDiscoveryConfig() DiscoveryConfig {
+	host, port := target.Address, server.DefaultPort
+
+	if h, p, err := net.SplitHostPort(target.Address); err == nil {
+		if n, err := strconv.Atoi(p); err == nil {
+			host, port = h, n
+		}
+	}
+
 	return DiscoveryConfig{
-		Address:         "localhost",
-		Port:            server.DefaultPort,
-		IdleTimeout:     DefaultIdleTimeout,
+		Address:         host,
+		Port:            port,
+		TLS:             target.TLS,
+		Token:           target.Token,
+		Existing:        !target.OnDemand,
+		IdleTimeout:     envDuration("GLIX_IDLE_TIMEOUT", DefaultIdleTimeout),
+		TaskWindow:      envDuration("GLIX_TASK_WINDOW", DefaultTaskWindow),
 		StartTimeout:    30 * time.Second,
 		ConnectionRetry: 10,
 		RetryDelay:      500 * time.Millisecond,
@@ -39,12 +68,26 @@ func DefaultDiscoveryConfig() DiscoveryConfig {
 	}
 }
 
+// envDuration returns the duration set in the environment variable name,
+// such as "15m" or "0", or def when it is unset or invalid
+func envDuration(name string, def time.Duration) time.Duration {
+	if d, err := time.ParseDuration(strings.TrimSpace(os.Getenv(name))); err == nil && d >= 0 {
+		return d
+	}
+
+	return def
+}
+
 // GetClient returns a connected client, starting an on-demand server if needed
 func GetClient(ctx context.Context, cfg DiscoveryConfig) (*Client, error) {
-	address := fmt.Sprintf("%s:%d", cfg.Address, cfg.Port)
+	address := net.JoinHostPort(cfg.Address, strconv.Itoa(cfg.Port))
+
+	if cfg.Existing {
+		return tryConnect(address, cfg.TLS, cfg.Token, remoteDialTimeout)
+	}
 
 	// First, try to connect to an existing server
-	client, err := tryConnect(address, cfg.RetryDelay)
+	client, err := tryConnect(address, cfg.TLS, cfg.Token, cfg.RetryDelay)
 	if err == nil {
 		// Server is already running
 		if cfg.Logger != nil {
@@ -73,9 +116,11 @@ func GetClient(ctx context.Context, cfg DiscoveryConfig) (*Client, error) {
 }
 
 // tryConnect attempts to connect to the server once
-func tryConnect(address string, timeout time.Duration) (*Client, error) {
+func tryConnect(address string, tls transport.TLS, token string, timeout time.Duration) (*Client, error) {
 	cfg := Config{
 		Address:     address,
+		TLS:         tls,
+		Token:       token,
 		DialTimeout: timeout,
 	}
 
@@ -97,7 +142,7 @@ func waitForServer(ctx context.Context, address string, cfg DiscoveryConfig) (*C
 		case <-time.After(cfg.RetryDelay):
 		}
 
-		client, err := tryConnect(address, cfg.RetryDelay*2)
+		client, err := tryConnect(address, cfg.TLS, cfg.Token, cfg.RetryDelay*2)
 		if err == nil {
 			// Verify server is responsive
 			pingCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
@@ -118,12 +163,17 @@ func waitForServer(ctx context.Context, address string, cfg DiscoveryConfig) (*C
 // startOnDemandServer starts the glix server as a background process with idle timeout
 func startOnDemandServer(ctx context.Context, cfg DiscoveryConfig) error {
 	// Get the path to the current executable
-	exePath, err := os.Executable()
-	if err != nil {
-		return fmt.Errorf("failed to get executable path: %w", err)
+	exePath := cfg.Executable
+	if exePath == "" {
+		var err error
+
+		exePath, err = os.Executable()
+		if err != nil {
+			return fmt.Errorf("failed to get executable path: %w", err)
+		}
 	}
 
-	exePath, err = filepath.Abs(exePath)
+	exePath, err := filepath.Abs(exePath)
 	if err != nil {
 		return fmt.Errorf("failed to get absolute path: %w", err)
 	}
@@ -134,6 +184,7 @@ func startOnDemandServer(ctx context.Context, cfg DiscoveryConfig) error {
 		"--port", fmt.Sprintf("%d", cfg.Port),
 		"--bind", cfg.Address,
 		"--idle-timeout", cfg.IdleTimeout.String(),
+		"--task-window", cfg.TaskWindow.String(),
 	}
 
 	// Start the server as a detached process
@@ -160,6 +211,7 @@ func startOnDemandServer(ctx context.Context, cfg DiscoveryConfig) error {
 		cfg.Logger.Info("started on-demand server",
 			"pid", cmd.Process.Pid,
 			"idle_timeout", cfg.IdleTimeout,
+			"task_window", cfg.TaskWindow,
 		)
 	}
 
@@ -168,7 +220,7 @@ func startOnDemandServer(ctx context.Context, cfg DiscoveryConfig) error {
 
 // IsServerRunning checks if a glix server is running at the given address
 func IsServerRunning(address string) bool {
-	client, err := tryConnect(address, time.Second)
+	client, err := tryConnect(address, transport.TLS{}, "", time.Second)
 	if err != nil {
 		return false
 	}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/inovacc/glix/internal/server"
+	"github.com/inovacc/glix/internal/transport"
+)
+
+// Target is the server that commands talk to
+type Target struct {
+	Address string // host:port
+	TLS     transport.TLS
+	Token   string // API token for servers that require one
+
+	// OnDemand targets are started when they are not running; others are
+	// reached as they are or not at all
+	OnDemand bool
+
+	// Remote targets run on another machine
+	Remote bool
+}
+
+// LocalTarget is the on-demand server on this machine
+func LocalTarget() Target {
+	return Target{Address: fmt.Sprintf("localhost:%d", server.DefaultPort), OnDemand: true}
+}
+
+// target is the server DefaultConfig and DefaultDiscoveryConfig point at
+var target = LocalTarget()
+
+// SetTarget points the default configurations at another server
+func SetTarget(t Target) {
+	target = t
+}
+
+// CurrentTarget returns the server the default configurations point at
+func CurrentTarget() Target {
+	return target
+}
@@ -0,0 +1,180 @@
+package completion
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"slices"
+	"testing"
+
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+func testIndex() *Index {
+	return NewIndex(
+		[]*pb.ModuleProto{
+			{Name: "github.com/spf13/cobra-cli", Version: "v1.3.0", Versions: []string{"v1.3.0", "v1.2.0"}},
+			{Name: "golang.org/x/tools/gopls", Version: "v0.16.1", Versions: []string{"v0.17.0", "v0.16.1"}},
+			{Name: "github.com/golangci/golangci-lint/cmd/golangci-lint", Version: "v1.61.0", Alias: "lint1"},
+		},
+		[]*pb.SnapshotProto{{Name: "before-upgrade", Description: "pre\nrelease"}},
+		[]string{"work", "home", "work", ""},
+	)
+}
+
+func values(candidates []Candidate) []string {
+	var v []string
+	for _, c := range candidates {
+		v = append(v, c.Value)
+	}
+
+	return v
+}
+
+func TestQuery(t *testing.T) {
+	ix := testIndex()
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"ping", []string{"pong"}},
+		{"modules github.com/", []string{"github.com/golangci/golangci-lint/cmd/golangci-lint", "github.com/spf13/cobra-cli"}},
+		{"modules", []string{"github.com/golangci/golangci-lint/cmd/golangci-lint", "github.com/spf13/cobra-cli", "golang.org/x/tools/gopls"}},
+		{"binaries go", []string{"gopls"}},
+		{"binaries l", []string{"lint1"}},
+		{"versions golang.org/x/tools/gopls", []string{"v0.17.0", "v0.16.1"}},
+		{"versions golang.org/x/tools/gopls v0.17", []string{"v0.17.0"}},
+		{"versions github.com/golangci/golangci-lint/cmd/golangci-lint", []string{"v1.61.0"}},
+		{"versions example.com/missing", nil},
+		{"tags b", []string{"before-upgrade"}},
+		{"namespaces", []string{"home", "work"}},
+	}
+
+	for _, tt := range tests {
+		got, err := ix.Query(tt.query)
+		if err != nil {
+			t.Errorf("Query(%q) error: %v", tt.query, err)
+			continue
+		}
+
+		if !slices.Equal(values(got), tt.want) {
+			t.Errorf("Query(%q) = %q, want %q", tt.query, values(got), tt.want)
+		}
+	}
+
+	for _, query := range []string{"versions", "bogus x"} {
+		if _, err := ix.Query(query); err == nil {
+			t.Errorf("Query(%q) succeeded, want error", query)
+		}
+	}
+}
+
+func TestIndexDescriptions(t *testing.T) {
+	ix := testIndex()
+
+	if got := ix.Versions["golang.org/x/tools/gopls"][1]; got.Description != "installed" {
+		t.Errorf("installed version described as %q", got.Description)
+	}
+
+	if got := ix.Tags[0].Description; got != "pre release" {
+		t.Errorf("tag description = %q, want it on one line", got)
+	}
+}
+
+func TestNewValidatesAddress(t *testing.T) {
+	load := func(context.Context) (*Index, error) { return testIndex(), nil }
+
+	for _, addr := range []string{"localhost:9744", "127.0.0.1:9744", "[::1]:9744"} {
+		if _, err := New(Config{Address: addr}, load); err != nil {
+			t.Errorf("New(%s) error: %v", addr, err)
+		}
+	}
+
+	for _, addr := range []string{"0.0.0.0:9744", "example.com:9744", "9744"} {
+		if _, err := New(Config{Address: addr}, load); err == nil {
+			t.Errorf("New(%s) succeeded, want error", addr)
+		}
+	}
+}
+
+func TestServeConn(t *testing.T) {
+	s, err := New(Config{Logger: slog.New(slog.DiscardHandler)}, func(context.Context) (*Index, error) {
+		return testIndex(), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.reload(context.Background())
+
+	client, server := net.Pipe()
+	go s.serveConn(server)
+
+	defer func() {
+		_ = client.Close()
+	}()
+
+	r := bufio.NewReader(client)
+
+	query := func(q string) []string {
+		t.Helper()
+
+		if _, err := fmt.Fprintln(client, q); err != nil {
+			t.Fatal(err)
+		}
+
+		var lines []string
+
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if line == "\n" {
+				return lines
+			}
+
+			lines = append(lines, line[:len(line)-1])
+		}
+	}
+
+	if got := query("modules golang"); !slices.Equal(got, []string{"golang.org/x/tools/gopls\tv0.16.1"}) {
+		t.Errorf("modules golang = %q", got)
+	}
+
+	if got := query("namespaces w"); !slices.Equal(got, []string{"work"}) {
+		t.Errorf("namespaces w = %q", got)
+	}
+
+	if got := query("nope"); len(got) != 1 || got[0] != `error: unknown query "nope"` {
+		t.Errorf("nope = %q", got)
+	}
+}
+
+func TestReloadKeepsIndexOnError(t *testing.T) {
+	calls := 0
+
+	s, err := New(Config{Logger: slog.New(slog.DiscardHandler)}, func(context.Context) (*Index, error) {
+		calls++
+		if calls > 1 {
+			return nil, errors.New("daemon unavailable")
+		}
+
+		return testIndex(), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.reload(context.Background())
+	s.reload(context.Background())
+
+	if got := len(s.index.Load().Modules); got != 3 {
+		t.Errorf("index has %d modules after a failed reload, want 3", got)
+	}
+}
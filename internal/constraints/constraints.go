@@ -0,0 +1,280 @@
+// Package constraints evaluates user-declared version relationships between
+// installed modules so updates that would break a toolchain are refused.
+package constraints
+
+import (
+	"fmt"
+	"go/version"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/inovacc/glix/internal/module"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+)
+
+// Kind identifies how a constraint relates a module to its target
+type Kind string
+
+const (
+	// KindMatch requires the module and target versions to agree at Level
+	KindMatch Kind = "match"
+	// KindMaxGo requires the module's go directive to be <= the target Go version
+	KindMaxGo Kind = "max-go"
+)
+
+// Match levels for KindMatch constraints
+const (
+	LevelMajor = "major"
+	LevelMinor = "minor"
+	LevelExact = "exact"
+)
+
+// Constraint is a declared relationship between a module and a target. For
+// KindMatch the target is another module path; for KindMaxGo it is a Go
+// version (1.22) or the path to a project go.mod whose go directive is used.
+type Constraint struct {
+	Module string `json:"module"`
+	Kind   Kind   `json:"kind"`
+	Target string `json:"target"`
+	Level  string `json:"level,omitempty"`
+}
+
+func (c Constraint) String() string {
+	switch c.Kind {
+	case KindMatch:
+		return fmt.Sprintf("%s must match %s (%s)", c.Module, c.Target, c.Level)
+	case KindMaxGo:
+		return fmt.Sprintf("%s must require Go <= %s", c.Module, c.Target)
+	default:
+		return fmt.Sprintf("%s %s %s", c.Module, c.Kind, c.Target)
+	}
+}
+
+// Validate checks that a constraint is well formed
+func (c Constraint) Validate() error {
+	if c.Module == "" || c.Target == "" {
+		return fmt.Errorf("constraint needs a module and a target")
+	}
+
+	switch c.Kind {
+	case KindMatch:
+		switch c.Level {
+		case LevelMajor, LevelMinor, LevelExact:
+			return nil
+		default:
+			return fmt.Errorf("invalid match level %q (use major, minor or exact)", c.Level)
+		}
+	case KindMaxGo:
+		_, err := c.maxGoVersion()
+		return err
+	default:
+		return fmt.Errorf("unknown constraint kind %q", c.Kind)
+	}
+}
+
+// Module is the state of one module as seen by the constraint checker
+type Module struct {
+	Name    string
+	Version string
+	// Dependencies maps dependency module paths to versions and is used to
+	// resolve match targets that are not installed themselves
+	Dependencies map[string]string
+	// GoVersion returns the module's go directive; it is only called for
+	// modules with a max-go constraint
+	GoVersion func() (string, error)
+}
+
+// Conflict is a constraint that would be violated
+type Conflict struct {
+	Constraint Constraint
+	Message    string
+}
+
+func (c Conflict) String() string {
+	return fmt.Sprintf("%s: %s", c.Constraint, c.Message)
+}
+
+// Matches reports whether a module name is covered by a constraint pattern:
+// the exact path or any package below it
+func Matches(name, pattern string) bool {
+	return name == pattern || strings.HasPrefix(name, pattern+"/")
+}
+
+// Check evaluates constraints against a set of modules. Only constraints
+// involving one of the names in changed are evaluated, so unrelated existing
+// violations do not block an update. Pass nil to check everything.
+func Check(constraints []Constraint, modules []Module, changed []string) []Conflict {
+	var conflicts []Conflict
+
+	for _, c := range constraints {
+		if changed != nil && !involves(c, changed) {
+			continue
+		}
+
+		for _, m := range modules {
+			if !Matches(m.Name, c.Module) {
+				continue
+			}
+
+			if msg := evaluate(c, m, modules); msg != "" {
+				conflicts = append(conflicts, Conflict{Constraint: c, Message: msg})
+			}
+		}
+	}
+
+	return conflicts
+}
+
+func involves(c Constraint, changed []string) bool {
+	for _, name := range changed {
+		if Matches(name, c.Module) || (c.Kind == KindMatch && Matches(name, c.Target)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// evaluate returns a description of the violation, or "" when satisfied or
+// when the target cannot be resolved
+func evaluate(c Constraint, m Module, modules []Module) string {
+	switch c.Kind {
+	case KindMatch:
+		target, ok := resolveTarget(c.Target, m, modules)
+		if !ok || !semver.IsValid(m.Version) || !semver.IsValid(target) {
+			return ""
+		}
+
+		var a, b string
+
+		switch c.Level {
+		case LevelMajor:
+			a, b = semver.Major(m.Version), semver.Major(target)
+		case LevelMinor:
+			a, b = semver.MajorMinor(m.Version), semver.MajorMinor(target)
+		default:
+			a, b = semver.Canonical(m.Version), semver.Canonical(target)
+		}
+
+		if a != b {
+			return fmt.Sprintf("%s@%s does not match %s@%s", m.Name, m.Version, c.Target, target)
+		}
+	case KindMaxGo:
+		if m.GoVersion == nil {
+			return ""
+		}
+
+		maxGo, err := c.maxGoVersion()
+		if err != nil {
+			return err.Error()
+		}
+
+		required, err := m.GoVersion()
+		if err != nil {
+			return fmt.Sprintf("cannot determine required Go version of %s@%s: %v", m.Name, m.Version, err)
+		}
+
+		if required != "" && version.Compare("go"+required, "go"+maxGo) > 0 {
+			return fmt.Sprintf("%s@%s requires Go %s, newer than %s", m.Name, m.Version, required, maxGo)
+		}
+	}
+
+	return ""
+}
+
+// resolveTarget finds the version of a match target: an installed module with
+// exactly that path, then the module's own dependencies, then an installed
+// package below the target path
+func resolveTarget(target string, m Module, modules []Module) (string, bool) {
+	for _, other := range modules {
+		if other.Name != m.Name && other.Name == target {
+			return other.Version, true
+		}
+	}
+
+	if v, ok := m.Dependencies[target]; ok {
+		return v, true
+	}
+
+	for _, other := range modules {
+		if other.Name != m.Name && Matches(other.Name, target) {
+			return other.Version, true
+		}
+	}
+
+	return "", false
+}
+
+// maxGoVersion returns the Go version limit, reading it from a go.mod
+// (or a directory containing one) when the target is a path
+func (c Constraint) maxGoVersion() (string, error) {
+	target := strings.TrimPrefix(c.Target, "go")
+
+	if version.IsValid("go" + target) {
+		return target, nil
+	}
+
+	path := c.Target
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, "go.mod")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid Go version or go.mod %q: %w", c.Target, err)
+	}
+
+	f, err := modfile.ParseLax(path, data, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if f.Go == nil {
+		return "", fmt.Errorf("%s has no go directive", path)
+	}
+
+	return f.Go.Version, nil
+}
+
+// FromProto converts an installed module record for constraint checking
+func FromProto(p *pb.ModuleProto) Module {
+	deps := make(map[string]string, len(p.GetDependencies()))
+	for _, d := range p.GetDependencies() {
+		deps[d.GetName()] = d.GetVersion()
+	}
+
+	return Module{Name: p.GetName(), Version: p.GetVersion(), Dependencies: deps}
+}
+
+// FromModule converts a module about to be installed for constraint checking
+func FromModule(m *module.Module) Module {
+	deps := make(map[string]string, len(m.Dependencies))
+	for _, d := range m.Dependencies {
+		deps[d.Name] = d.Version
+	}
+
+	return Module{Name: m.Name, Version: m.Version, Dependencies: deps, GoVersion: m.RequiredGoVersion}
+}
+
+// CheckUpdate evaluates the declared constraints against the installed set
+// with candidate replacing (or joining) the installed modules
+func CheckUpdate(constraints []Constraint, installed []*pb.ModuleProto, candidate *module.Module) []Conflict {
+	if len(constraints) == 0 {
+		return nil
+	}
+
+	modules := make([]Module, 0, len(installed)+1)
+
+	for _, p := range installed {
+		if p.GetName() != candidate.Name {
+			modules = append(modules, FromProto(p))
+		}
+	}
+
+	modules = append(modules, FromModule(candidate))
+
+	return Check(constraints, modules, []string{candidate.Name})
+}
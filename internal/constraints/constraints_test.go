@@ -0,0 +1,97 @@
+package constraints
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckMatch(t *testing.T) {
+	c := Constraint{Module: "google.golang.org/grpc/cmd/protoc-gen-go-grpc", Kind: KindMatch, Target: "google.golang.org/grpc", Level: LevelMajor}
+
+	modules := []Module{
+		{
+			Name:         "google.golang.org/grpc/cmd/protoc-gen-go-grpc",
+			Version:      "v1.5.1",
+			Dependencies: map[string]string{"google.golang.org/grpc": "v1.64.0"},
+		},
+	}
+
+	if got := Check([]Constraint{c}, modules, nil); len(got) != 0 {
+		t.Errorf("expected no conflicts, got %v", got)
+	}
+
+	modules[0].Dependencies["google.golang.org/grpc"] = "v2.0.0"
+
+	if got := Check([]Constraint{c}, modules, nil); len(got) != 1 {
+		t.Errorf("expected 1 conflict, got %v", got)
+	}
+
+	// Unrelated changes do not evaluate the constraint
+	if got := Check([]Constraint{c}, modules, []string{"github.com/other/tool"}); len(got) != 0 {
+		t.Errorf("expected unrelated change to be ignored, got %v", got)
+	}
+}
+
+func TestCheckMatchInstalledTarget(t *testing.T) {
+	c := Constraint{Module: "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2", Kind: KindMatch, Target: "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-grpc-gateway", Level: LevelExact}
+
+	modules := []Module{
+		{Name: c.Module, Version: "v2.20.0"},
+		{Name: c.Target, Version: "v2.26.3"},
+	}
+
+	// Updating the target side also evaluates the constraint
+	if got := Check([]Constraint{c}, modules, []string{c.Target}); len(got) != 1 {
+		t.Errorf("expected 1 conflict, got %v", got)
+	}
+}
+
+func TestCheckMaxGo(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/p\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := Constraint{Module: "github.com/golangci/golangci-lint/cmd/golangci-lint", Kind: KindMaxGo, Target: dir}
+	if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	goVersion := "1.23.0"
+	modules := []Module{{
+		Name:      c.Module,
+		Version:   "v1.64.0",
+		GoVersion: func() (string, error) { return goVersion, nil },
+	}}
+
+	if got := Check([]Constraint{c}, modules, nil); len(got) != 1 {
+		t.Errorf("expected 1 conflict, got %v", got)
+	}
+
+	goVersion = "1.21"
+
+	if got := Check([]Constraint{c}, modules, nil); len(got) != 0 {
+		t.Errorf("expected no conflicts, got %v", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		c       Constraint
+		wantErr bool
+	}{
+		{Constraint{Module: "a", Kind: KindMatch, Target: "b", Level: LevelMinor}, false},
+		{Constraint{Module: "a", Kind: KindMatch, Target: "b", Level: "patch"}, true},
+		{Constraint{Module: "a", Kind: KindMaxGo, Target: "1.22"}, false},
+		{Constraint{Module: "a", Kind: KindMaxGo, Target: "go1.22.3"}, false},
+		{Constraint{Module: "a", Kind: KindMaxGo, Target: "/nonexistent/go.mod"}, true},
+		{Constraint{Module: "a", Kind: "bogus", Target: "b"}, true},
+	}
+
+	for _, tt := range tests {
+		if err := tt.c.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%v) error = %v, wantErr %v", tt.c, err, tt.wantErr)
+		}
+	}
+}
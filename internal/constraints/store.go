@@ -0,0 +1,138 @@
+package constraints
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/inovacc/glix/internal/module"
+)
+
+// constraintStore handles persistent storage of declared constraints
+type constraintStore struct {
+	mu          sync.RWMutex
+	constraints []Constraint
+	filePath    string
+}
+
+var (
+	store     *constraintStore
+	storeOnce sync.Once
+)
+
+// getStorePath returns the path to the constraints file
+func getStorePath() string {
+	configDir, err := module.GetApplicationConfigDirectory()
+	if err != nil {
+		// Fallback to cache directory
+		configDir, _ = module.GetApplicationCacheDirectory()
+	}
+
+	return filepath.Join(configDir, "constraints.json")
+}
+
+// GetStore returns the singleton constraint store
+func GetStore() *constraintStore {
+	storeOnce.Do(func() {
+		store = &constraintStore{
+			filePath: getStorePath(),
+		}
+		// Load existing constraints if available
+		_ = store.load()
+	})
+
+	return store
+}
+
+// load reads the constraints from disk
+func (s *constraintStore) load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to read constraints: %w", err)
+	}
+
+	var constraints []Constraint
+	if err := json.Unmarshal(data, &constraints); err != nil {
+		return fmt.Errorf("failed to parse constraints: %w", err)
+	}
+
+	s.constraints = constraints
+
+	return nil
+}
+
+// Reload re-reads the constraints from disk, picking up changes made by
+// other glix processes (the daemon's scheduler calls this before each check)
+func (s *constraintStore) Reload() error {
+	return s.load()
+}
+
+// save writes the constraints to disk
+func (s *constraintStore) save() error {
+	dir := filepath.Dir(s.filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s.constraints, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal constraints: %w", err)
+	}
+
+	if err := os.WriteFile(s.filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write constraints: %w", err)
+	}
+
+	return nil
+}
+
+// List returns a copy of the declared constraints
+func (s *constraintStore) List() []Constraint {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return slices.Clone(s.constraints)
+}
+
+// Add validates and stores a constraint
+func (s *constraintStore) Add(c Constraint) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if slices.Contains(s.constraints, c) {
+		return fmt.Errorf("constraint already exists: %s", c)
+	}
+
+	s.constraints = append(s.constraints, c)
+
+	return s.save()
+}
+
+// Remove deletes the constraint at a 1-based index as shown by List
+func (s *constraintStore) Remove(index int) (Constraint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if index < 1 || index > len(s.constraints) {
+		return Constraint{}, fmt.Errorf("no constraint #%d", index)
+	}
+
+	removed := s.constraints[index-1]
+	s.constraints = slices.Delete(s.constraints, index-1, index)
+
+	return removed, s.save()
+}
@@ -0,0 +1,242 @@
+// Package contexts stores named glix servers the CLI can target, in the
+// manner of kubeconfig contexts.
+package contexts
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/transport"
+)
+
+// Local names the server on this machine, started on demand. It is always
+// available and cannot be redefined.
+const Local = "local"
+
+// Context is a named server
+type Context struct {
+	Name       string `json:"name"`
+	Server     string `json:"server"` // host:port
+	TLS        bool   `json:"tls,omitempty"`
+	CAFile     string `json:"ca_file,omitempty"`
+	ServerName string `json:"server_name,omitempty"`
+	TokenFile  string `json:"token_file,omitempty"` // File holding the API token for the server
+}
+
+// Transport returns the TLS settings for connecting to the context's server
+func (c Context) Transport() transport.TLS {
+	return transport.TLS{
+		Enabled:    c.TLS,
+		CAFile:     c.CAFile,
+		ServerName: c.ServerName,
+	}
+}
+
+// config is the file format of the context store
+type config struct {
+	Current  string    `json:"current,omitempty"`
+	Contexts []Context `json:"contexts"`
+}
+
+// contextStore handles persistent storage of contexts
+type contextStore struct {
+	mu       sync.RWMutex
+	current  string
+	contexts map[string]Context
+	filePath string
+}
+
+var (
+	store     *contextStore
+	storeOnce sync.Once
+)
+
+// getStorePath returns the path to the contexts file
+func getStorePath() string {
+	configDir, err := module.GetApplicationConfigDirectory()
+	if err != nil {
+		// Fallback to cache directory
+		configDir, _ = module.GetApplicationCacheDirectory()
+	}
+
+	return filepath.Join(configDir, "contexts.json")
+}
+
+// GetStore returns the singleton context store
+func GetStore() *contextStore {
+	storeOnce.Do(func() {
+		store = &contextStore{
+			filePath: getStorePath(),
+			contexts: make(map[string]Context),
+		}
+		// Load existing contexts if available
+		_ = store.load()
+	})
+
+	return store
+}
+
+// load reads the contexts from disk
+func (s *contextStore) load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to read contexts: %w", err)
+	}
+
+	var cfg config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("failed to parse contexts: %w", err)
+	}
+
+	s.current = cfg.Current
+	s.contexts = make(map[string]Context, len(cfg.Contexts))
+
+	for _, c := range cfg.Contexts {
+		s.contexts[c.Name] = c
+	}
+
+	return nil
+}
+
+// save writes the contexts to disk
+func (s *contextStore) save() error {
+	dir := filepath.Dir(s.filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(config{Current: s.current, Contexts: s.sorted()}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal contexts: %w", err)
+	}
+
+	if err := os.WriteFile(s.filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write contexts: %w", err)
+	}
+
+	return nil
+}
+
+// sorted returns the contexts ordered by name
+func (s *contextStore) sorted() []Context {
+	contexts := make([]Context, 0, len(s.contexts))
+	for _, c := range s.contexts {
+		contexts = append(contexts, c)
+	}
+
+	sort.Slice(contexts, func(i, j int) bool {
+		return contexts[i].Name < contexts[j].Name
+	})
+
+	return contexts
+}
+
+// ValidateName checks that a context name can be stored and told apart
+// from a server address
+func ValidateName(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("context name must not be empty")
+	case name == Local:
+		return fmt.Errorf("%q is reserved for the server on this machine", Local)
+	case strings.ContainsAny(name, ":/ \t"):
+		return fmt.Errorf("invalid context name %q: must not contain ':', '/' or spaces", name)
+	}
+
+	return nil
+}
+
+// Set adds or replaces a context
+func (s *contextStore) Set(c Context) error {
+	if err := ValidateName(c.Name); err != nil {
+		return err
+	}
+
+	if c.Server == "" {
+		return fmt.Errorf("context %s needs a server address", c.Name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.contexts[c.Name] = c
+
+	return s.save()
+}
+
+// Remove deletes a context. Removing the current context switches back to
+// the local server.
+func (s *contextStore) Remove(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.contexts[name]; !ok {
+		return fmt.Errorf("context %s not found", name)
+	}
+
+	delete(s.contexts, name)
+
+	if s.current == name {
+		s.current = ""
+	}
+
+	return s.save()
+}
+
+// Use makes a context the current one
+func (s *contextStore) Use(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if name == Local {
+		name = ""
+	} else if _, ok := s.contexts[name]; !ok {
+		return fmt.Errorf("context %s not found", name)
+	}
+
+	s.current = name
+
+	return s.save()
+}
+
+// Get returns a context by name
+func (s *contextStore) Get(name string) (Context, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.contexts[name]
+
+	return c, ok
+}
+
+// Current returns the current context, or false when commands target the
+// local server
+func (s *contextStore) Current() (Context, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.contexts[s.current]
+
+	return c, ok
+}
+
+// List returns the contexts ordered by name
+func (s *contextStore) List() []Context {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.sorted()
+}
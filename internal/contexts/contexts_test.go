@@ -0,0 +1,64 @@
+package contexts
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func newTestStore(t *testing.T) *contextStore {
+	t.Helper()
+
+	return &contextStore{
+		filePath: filepath.Join(t.TempDir(), "contexts.json"),
+		contexts: make(map[string]Context),
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	for _, name := range []string{"work-vm", "ci", "prod_1"} {
+		if err := ValidateName(name); err != nil {
+			t.Errorf("ValidateName(%q) = %v", name, err)
+		}
+	}
+
+	for _, name := range []string{"", Local, "host:9742", "a/b", "two words"} {
+		if err := ValidateName(name); err == nil {
+			t.Errorf("ValidateName(%q) should fail", name)
+		}
+	}
+}
+
+func TestStoreUseAndRemove(t *testing.T) {
+	s := newTestStore(t)
+
+	if err := s.Set(Context{Name: "work-vm", Server: "10.0.0.5:9742", TLS: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Use("missing"); err == nil {
+		t.Error("using an unknown context should fail")
+	}
+
+	if err := s.Use("work-vm"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reload from disk
+	loaded := &contextStore{filePath: s.filePath}
+	if err := loaded.load(); err != nil {
+		t.Fatal(err)
+	}
+
+	c, ok := loaded.Current()
+	if !ok || c.Server != "10.0.0.5:9742" || !c.Transport().Enabled {
+		t.Fatalf("Current() = %+v, %v", c, ok)
+	}
+
+	if err := loaded.Remove("work-vm"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := loaded.Current(); ok {
+		t.Error("removing the current context should switch back to local")
+	}
+}
@@ -0,0 +1,192 @@
+"use strict";
+
+// Actions must carry this header; see actionHeader in dashboard.go
+const actionHeader = "X-Glix-Dashboard";
+
+const jobs = new Map();
+
+function el(tag, attrs = {}, ...children) {
+  const node = document.createElement(tag);
+  for (const [key, value] of Object.entries(attrs)) {
+    if (key === "class") {
+      node.className = value;
+    } else if (key.startsWith("on")) {
+      node.addEventListener(key.slice(2), value);
+    } else {
+      node.setAttribute(key, value);
+    }
+  }
+  node.append(...children);
+  return node;
+}
+
+async function api(path, body) {
+  const init = body === undefined ? {} : {
+    method: "POST",
+    headers: { "Content-Type": "application/json", [actionHeader]: "1" },
+    body: JSON.stringify(body),
+  };
+
+  const resp = await fetch(path, init);
+  const data = await resp.json();
+  if (!resp.ok) {
+    throw new Error(data.error || resp.statusText);
+  }
+  return data;
+}
+
+function formatDate(value) {
+  return value ? new Date(value).toLocaleString() : "";
+}
+
+function formatElapsed(nanos) {
+  return nanos ? (Number(nanos) / 1e9).toFixed(1) + "s" : "";
+}
+
+async function runAction(action, mod, body = {}) {
+  try {
+    const job = await api("/api/" + action, { module: mod.name, ...body });
+    showJob(job);
+  } catch (err) {
+    alert(`${action} ${mod.name}: ${err.message}`);
+  }
+}
+
+function moduleRow(mod) {
+  const held = mod.held_until && new Date(mod.held_until) > new Date();
+
+  let latest = el("span", { class: "muted" }, mod.latest || "");
+  if (mod.local_path) {
+    latest = el("span", { class: "muted" }, "local");
+  } else if (mod.latest_error) {
+    latest = el("span", { class: "muted", title: mod.latest_error }, "unknown");
+  } else if (mod.update_available) {
+    latest = el("span", { class: "update" }, mod.latest);
+  }
+
+  const actions = el("td", { class: "actions" });
+
+  if (mod.update_available && !held) {
+    actions.append(el("button", {
+      class: "primary",
+      onclick: () => runAction("update", mod),
+    }, "Update"), " ");
+  }
+
+  if (held) {
+    actions.append(el("span", { class: "held", title: "Held until " + formatDate(mod.held_until) }, "held "),
+      el("button", { onclick: () => runAction("release", mod) }, "Unpin"), " ");
+  } else if (!mod.local_path) {
+    actions.append(el("button", {
+      title: "Hold updates for 30 days",
+      onclick: () => runAction("hold", mod),
+    }, "Pin"), " ");
+  }
+
+  actions.append(el("button", {
+    onclick: () => {
+      if (confirm(`Remove ${mod.name}?`)) {
+        runAction("remove", mod);
+      }
+    },
+  }, "Remove"));
+
+  return el("tr", {},
+    el("td", {}, el("code", {}, mod.name)),
+    el("td", {}, mod.version),
+    el("td", {}, latest),
+    el("td", { class: "muted" }, formatDate(mod.installed)),
+    actions);
+}
+
+async function loadModules() {
+  const modules = await api("/api/modules");
+  const body = document.querySelector("#modules tbody");
+  body.replaceChildren(...modules.map(moduleRow));
+
+  const updates = modules.filter((m) => m.update_available).length;
+  document.getElementById("summary").textContent =
+    `${modules.length} module(s), ${updates} update(s) available`;
+  document.getElementById("modules-empty").hidden = modules.length > 0;
+}
+
+// statsSeries are the weekly counts charted, with their labels
+const statsSeries = [
+  ["installs", "Installs"],
+  ["updates", "Updates"],
+  ["failures", "Failures"],
+];
+
+function statsChart(weeks, key) {
+  const max = Math.max(1, ...weeks.map((w) => w[key]));
+  return el("div", { class: "chart " + key }, ...weeks.map((w) => el("span", {
+    style: `height: ${(100 * w[key]) / max}%`,
+    title: `Week of ${new Date(w.week).toLocaleDateString()}: ${w[key]}`,
+  })));
+}
+
+async function loadStats() {
+  const weeks = await api("/api/stats?weeks=12");
+  document.getElementById("stats").replaceChildren(...statsSeries.map(([key, label]) =>
+    el("div", { class: "series" },
+      el("span", { class: "label" }, label),
+      statsChart(weeks, key),
+      el("span", { class: "muted" }, String(weeks.reduce((sum, w) => sum + w[key], 0))))));
+}
+
+function jobItem(job) {
+  let detail = job.result || job.error || "";
+  if (job.state === "running" && job.progress) {
+    const p = job.progress;
+    const step = p.step ? `[${p.step}/${p.totalSteps}] ` : "";
+    detail = `${step}${p.message || ""} ${formatElapsed(p.elapsedNano)}`;
+  }
+
+  return el("li", {},
+    el("span", { class: "state " + job.state }, job.state),
+    `${job.action} `,
+    el("code", {}, job.module),
+    el("span", { class: "muted" }, " " + detail));
+}
+
+function renderJobs() {
+  const sorted = [...jobs.values()].sort((a, b) => b.id - a.id);
+  document.getElementById("jobs").replaceChildren(...sorted.map(jobItem));
+  document.getElementById("jobs-empty").hidden = sorted.length > 0;
+}
+
+function showJob(job) {
+  const previous = jobs.get(job.id);
+  jobs.set(job.id, job);
+  renderJobs();
+
+  if (job.state !== "running" && (!previous || previous.state === "running")) {
+    loadModules().catch(console.error);
+    loadStats().catch(console.error);
+  }
+}
+
+async function loadJobs() {
+  for (const job of await api("/api/jobs")) {
+    jobs.set(job.id, job);
+  }
+  renderJobs();
+}
+
+function watchJobs() {
+  const events = new EventSource("/api/events");
+  events.addEventListener("job", (e) => showJob(JSON.parse(e.data)));
+  // EventSource reconnects by itself; catch up on changes missed meanwhile
+  events.addEventListener("open", () => loadJobs().catch(console.error));
+}
+
+document.getElementById("refresh").addEventListener("click", () => {
+  loadModules().catch((err) => alert(err.message));
+  loadStats().catch(console.error);
+});
+
+loadModules().catch((err) => {
+  document.getElementById("summary").textContent = err.message;
+});
+loadStats().catch(console.error);
+watchJobs();
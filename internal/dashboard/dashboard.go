@@ -0,0 +1,569 @@
+// Package dashboard serves an optional web UI from the daemon, showing
+// installed modules, pending updates, and running jobs, with buttons to
+// update, remove, and hold modules. Module data comes from the daemon's gRPC
+// API; actions share the code of the CLI commands, holds and install receipts
+// included. The UI assets are embedded in the binary.
+package dashboard
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/inovacc/glix/internal/autoupdate"
+	"github.com/inovacc/glix/internal/hold"
+	"github.com/inovacc/glix/internal/modver"
+	"github.com/inovacc/glix/internal/remove"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// DefaultAddress is where the dashboard listens unless configured otherwise
+const DefaultAddress = "localhost:9743"
+
+// DefaultHoldDuration is used when a hold is requested without a duration
+const DefaultHoldDuration = "30d"
+
+// actionHeader must be sent with every action request. Browsers only send
+// custom headers cross-origin after a CORS preflight, which the dashboard
+// never approves, so other sites cannot trigger actions.
+const actionHeader = "X-Glix-Dashboard"
+
+//go:embed assets
+var assets embed.FS
+
+// Config holds the dashboard configuration
+type Config struct {
+	Address     string // Loopback host:port to serve on
+	GRPCAddress string // Daemon gRPC address the dashboard reads from
+	Logger      *slog.Logger
+
+	// Credentials for the daemon connection; nil connects in plaintext
+	Credentials credentials.TransportCredentials
+}
+
+// Server serves the dashboard
+type Server struct {
+	config  Config
+	logger  *slog.Logger
+	conn    *grpc.ClientConn
+	client  pb.GlixServiceClient
+	jobs    *jobManager
+	httpSrv *http.Server
+}
+
+// New creates a dashboard backed by the daemon at cfg.GRPCAddress. The
+// address must be a loopback address since the dashboard can install and
+// remove binaries.
+func New(cfg Config) (*Server, error) {
+	if cfg.Address == "" {
+		cfg.Address = DefaultAddress
+	}
+
+	if err := ValidateAddress(cfg.Address); err != nil {
+		return nil, err
+	}
+
+	if cfg.Logger == nil {
+		cfg.Logger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+			Level: slog.LevelInfo,
+		}))
+	}
+
+	if cfg.Credentials == nil {
+		cfg.Credentials = insecure.NewCredentials()
+	}
+
+	conn, err := grpc.NewClient(cfg.GRPCAddress, grpc.WithTransportCredentials(cfg.Credentials))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client: %w", err)
+	}
+
+	return newServer(cfg, conn, pb.NewGlixServiceClient(conn)), nil
+}
+
+func newServer(cfg Config, conn *grpc.ClientConn, client pb.GlixServiceClient) *Server {
+	s := &Server{
+		config: cfg,
+		logger: cfg.Logger,
+		conn:   conn,
+		client: client,
+		jobs:   newJobManager(),
+	}
+
+	s.httpSrv = &http.Server{
+		Addr:              cfg.Address,
+		Handler:           s.routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	return s
+}
+
+// ValidateAddress rejects dashboard addresses reachable from other machines
+func ValidateAddress(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid dashboard address %q: %w", address, err)
+	}
+
+	if host == "localhost" {
+		return nil
+	}
+
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+
+	return fmt.Errorf("dashboard address %q is not a loopback address", address)
+}
+
+// URL returns the address to open in a browser
+func (s *Server) URL() string {
+	return "http://" + s.config.Address
+}
+
+// Start serves the dashboard until Stop is called or ctx is done
+func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.config.Address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.config.Address, err)
+	}
+
+	s.logger.Info("dashboard started", "url", s.URL())
+
+	go func() {
+		<-ctx.Done()
+		s.Stop()
+	}()
+
+	if err := s.httpSrv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("dashboard error: %w", err)
+	}
+
+	return nil
+}
+
+// Stop shuts the dashboard down
+func (s *Server) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_ = s.httpSrv.Shutdown(ctx)
+
+	if s.conn != nil {
+		_ = s.conn.Close()
+	}
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	static, _ := fs.Sub(assets, "assets")
+	mux.Handle("GET /", http.FileServerFS(static))
+
+	mux.HandleFunc("GET /api/modules", s.handleModules)
+	mux.HandleFunc("GET /api/jobs", s.handleJobs)
+	mux.HandleFunc("GET /api/events", s.handleEvents)
+	mux.HandleFunc("GET /api/stats", s.handleStats)
+	mux.HandleFunc("POST /api/update", s.action(s.updateJob))
+	mux.HandleFunc("POST /api/remove", s.action(s.removeJob))
+	mux.HandleFunc("POST /api/hold", s.action(s.holdJob))
+	mux.HandleFunc("POST /api/release", s.action(s.releaseJob))
+
+	return s.checkHost(mux)
+}
+
+// checkHost rejects requests whose Host header does not name the dashboard.
+// A DNS-rebinding page resolves its own hostname to the loopback address, so
+// its requests arrive with a foreign Host and pass the same-origin checks.
+func (s *Server) checkHost(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.allowedHost(r.Host) {
+			writeError(w, http.StatusForbidden, fmt.Errorf("host %q rejected", r.Host))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allowedHost reports whether host is the configured address, localhost or
+// a loopback IP
+func (s *Server) allowedHost(host string) bool {
+	if host == s.config.Address {
+		return true
+	}
+
+	name := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		name = h
+	}
+
+	if name == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(strings.Trim(name, "[]"))
+
+	return ip != nil && ip.IsLoopback()
+}
+
+// moduleView is one row of the module table
+type moduleView struct {
+	Name            string    `json:"name"`
+	Version         string    `json:"version"`
+	Latest          string    `json:"latest,omitempty"`
+	LatestError     string    `json:"latest_error,omitempty"`
+	UpdateAvailable bool      `json:"update_available"`
+	Installed       time.Time `json:"installed"`
+	LocalPath       string    `json:"local_path,omitempty"`
+	HeldUntil       time.Time `json:"held_until,omitzero"`
+	BadVersions     []string  `json:"bad_versions,omitempty"`
+}
+
+func (s *Server) handleModules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := s.client.ListModules(ctx, &pb.ListModulesRequest{})
+	if err != nil {
+		writeError(w, http.StatusBadGateway, fmt.Errorf("failed to list modules: %w", err))
+		return
+	}
+
+	var names []string
+
+	for _, mod := range resp.GetModules() {
+		if mod.GetLocalPath() == "" {
+			names = append(names, mod.GetName())
+		}
+	}
+
+	latest := make(map[string]*pb.LatestVersionInfo)
+
+	if len(names) > 0 {
+		versions, err := s.client.GetLatestVersions(ctx, &pb.GetLatestVersionsRequest{Names: names})
+		if err != nil {
+			s.logger.Warn("dashboard: failed to get latest versions", "error", err)
+		}
+
+		for _, info := range versions.GetVersions() {
+			latest[info.GetName()] = info
+		}
+	}
+
+	// Holds are set by the CLI as well
+	if err := hold.GetStore().Reload(); err != nil {
+		s.logger.Warn("dashboard: failed to reload holds", "error", err)
+	}
+
+	views := make([]moduleView, 0, len(resp.GetModules()))
+
+	for _, mod := range resp.GetModules() {
+		v := moduleView{
+			Name:        mod.GetName(),
+			Version:     mod.GetVersion(),
+			Installed:   time.Unix(0, mod.GetTimestampUnixNano()),
+			LocalPath:   mod.GetLocalPath(),
+			BadVersions: mod.GetBadVersions(),
+		}
+
+		if info, ok := latest[mod.GetName()]; ok {
+			v.Latest = info.GetLatestVersion()
+			v.LatestError = info.GetErrorMessage()
+			v.UpdateAvailable = v.LatestError == "" && modver.Default.IsUpdate(v.Latest, v.Version)
+		}
+
+		if h, held := hold.GetStore().Get(mod.GetName()); held {
+			v.HeldUntil = h.Until
+		}
+
+		views = append(views, v)
+	}
+
+	writeJSON(w, views)
+}
+
+// weekView is the event counts of one week of the trend charts
+type weekView struct {
+	Week     time.Time `json:"week"`
+	Installs int32     `json:"installs"`
+	Updates  int32     `json:"updates"`
+	Removes  int32     `json:"removes"`
+	Failures int32     `json:"failures"`
+}
+
+// handleStats returns weekly event counts, oldest first, for the number of
+// weeks in the weeks query parameter
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	var weeks int
+
+	if v := r.URL.Query().Get("weeks"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 104 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid weeks %q: want 1 to 104", v))
+			return
+		}
+
+		weeks = n
+	}
+
+	resp, err := s.client.GetStats(r.Context(), &pb.GetStatsRequest{Weeks: int32(weeks)})
+	if err != nil {
+		writeError(w, http.StatusBadGateway, fmt.Errorf("failed to get stats: %w", err))
+		return
+	}
+
+	if resp.GetErrorMessage() != "" {
+		writeError(w, http.StatusBadGateway, errors.New(resp.GetErrorMessage()))
+		return
+	}
+
+	views := make([]weekView, 0, len(resp.GetWeeks()))
+
+	for _, week := range resp.GetWeeks() {
+		views = append(views, weekView{
+			Week:     time.Unix(0, week.GetWeekStartUnixNano()),
+			Installs: week.GetInstalls(),
+			Updates:  week.GetUpdates(),
+			Removes:  week.GetRemoves(),
+			Failures: week.GetFailures(),
+		})
+	}
+
+	writeJSON(w, views)
+}
+
+func (s *Server) handleJobs(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, s.jobs.list())
+}
+
+// handleEvents streams job changes as server-sent events
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming unsupported"))
+		return
+	}
+
+	events, cancel := s.jobs.subscribe()
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(30 * time.Second)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			_, _ = fmt.Fprint(w, ": keep-alive\n\n")
+		case j := <-events:
+			data, err := json.Marshal(j)
+			if err != nil {
+				continue
+			}
+
+			_, _ = fmt.Fprintf(w, "event: job\ndata: %s\n\n", data)
+		}
+
+		flusher.Flush()
+	}
+}
+
+// actionRequest is the body of an action request
+type actionRequest struct {
+	Module string `json:"module"`
+	For    string `json:"for,omitempty"` // Hold duration, e.g. 30d
+}
+
+// actionFunc validates an action and returns the job to run
+type actionFunc func(r *http.Request, req actionRequest) (func(progress func(phase, message string)) (string, error), error)
+
+// action wraps an action in request checks and starts its job
+func (s *Server) action(fn actionFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := checkActionRequest(r); err != nil {
+			writeError(w, http.StatusForbidden, err)
+			return
+		}
+
+		var req actionRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request: %w", err))
+			return
+		}
+
+		if req.Module == "" {
+			writeError(w, http.StatusBadRequest, errors.New("module is required"))
+			return
+		}
+
+		run, err := fn(r, req)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		action := r.URL.Path[len("/api/"):]
+		j := s.jobs.start(action, req.Module, run)
+
+		s.logger.Info("dashboard job started", "job", j.String())
+
+		w.WriteHeader(http.StatusAccepted)
+		writeJSON(w, j)
+	}
+}
+
+// checkActionRequest rejects action requests that browsers could have sent
+// on behalf of other sites
+func checkActionRequest(r *http.Request) error {
+	if r.Header.Get(actionHeader) == "" {
+		return fmt.Errorf("missing %s header", actionHeader)
+	}
+
+	if origin := r.Header.Get("Origin"); origin != "" {
+		u, err := url.Parse(origin)
+		if err != nil || u.Host != r.Host {
+			return fmt.Errorf("cross-origin request from %q rejected", origin)
+		}
+	}
+
+	return nil
+}
+
+// installedModule looks up an installed module through the daemon
+func (s *Server) installedModule(ctx context.Context, name string) (*pb.ModuleProto, error) {
+	resp, err := s.client.GetModule(ctx, &pb.GetModuleRequest{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query module: %w", err)
+	}
+
+	if !resp.GetFound() {
+		return nil, fmt.Errorf("module %s is not installed", name)
+	}
+
+	return resp.GetModule(), nil
+}
+
+func (s *Server) updateJob(r *http.Request, req actionRequest) (func(func(string, string)) (string, error), error) {
+	mod, err := s.installedModule(r.Context(), req.Module)
+	if err != nil {
+		return nil, err
+	}
+
+	if mod.GetLocalPath() != "" {
+		return nil, fmt.Errorf("%s was installed from %s; rebuild it with 'glix install'", mod.GetName(), mod.GetLocalPath())
+	}
+
+	return func(progress func(phase, message string)) (string, error) {
+		ctx := context.Background()
+
+		list, err := s.client.ListModules(ctx, &pb.ListModulesRequest{})
+		if err != nil {
+			return "", fmt.Errorf("failed to list modules: %w", err)
+		}
+
+		result := autoupdate.UpdateModule(ctx, s.logger, s.client, mod, list.GetModules(), progress)
+
+		switch {
+		case result.Error != nil:
+			return "", result.Error
+		case result.Updated:
+			return fmt.Sprintf("updated %s -> %s", result.PreviousVersion, result.NewVersion), nil
+		case result.NewVersion != result.PreviousVersion:
+			return "", fmt.Errorf("update to %s is held back", result.NewVersion)
+		default:
+			return "already up to date", nil
+		}
+	}, nil
+}
+
+func (s *Server) removeJob(r *http.Request, req actionRequest) (func(func(string, string)) (string, error), error) {
+	mod, err := s.installedModule(r.Context(), req.Module)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(progress func(phase, message string)) (string, error) {
+		if err := remove.Run(context.Background(), s.client, remove.Options{Module: mod.GetName()}, progress, func(string) {}); err != nil {
+			return "", err
+		}
+
+		return "removed", nil
+	}, nil
+}
+
+func (s *Server) holdJob(r *http.Request, req actionRequest) (func(func(string, string)) (string, error), error) {
+	if _, err := s.installedModule(r.Context(), req.Module); err != nil {
+		return nil, err
+	}
+
+	value := req.For
+	if value == "" {
+		value = DefaultHoldDuration
+	}
+
+	d, err := hold.ParseFor(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(func(string, string)) (string, error) {
+		h := hold.Hold{
+			Module:  req.Module,
+			Until:   time.Now().Add(d),
+			Reason:  "held from the dashboard",
+			Created: time.Now(),
+		}
+
+		if err := hold.GetStore().Set(h); err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("held until %s", h.Until.Format("2006-01-02 15:04")), nil
+	}, nil
+}
+
+func (s *Server) releaseJob(_ *http.Request, req actionRequest) (func(func(string, string)) (string, error), error) {
+	return func(func(string, string)) (string, error) {
+		if err := hold.GetStore().Release(req.Module); err != nil {
+			return "", err
+		}
+
+		return "hold released", nil
+	}, nil
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Default().Warn("dashboard: failed to write response", "error", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
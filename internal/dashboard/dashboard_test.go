@@ -0,0 +1,290 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"google.golang.org/grpc"
+)
+
+// fakeClient serves a fixed module list. Unimplemented methods panic.
+type fakeClient struct {
+	pb.GlixServiceClient
+
+	modules []*pb.ModuleProto
+	latest  map[string]string
+}
+
+func (f *fakeClient) ListModules(context.Context, *pb.ListModulesRequest, ...grpc.CallOption) (*pb.ListModulesResponse, error) {
+	return &pb.ListModulesResponse{Modules: f.modules}, nil
+}
+
+func (f *fakeClient) GetLatestVersions(_ context.Context, req *pb.GetLatestVersionsRequest, _ ...grpc.CallOption) (*pb.GetLatestVersionsResponse, error) {
+	resp := &pb.GetLatestVersionsResponse{}
+	for _, name := range req.GetNames() {
+		resp.Versions = append(resp.Versions, &pb.LatestVersionInfo{Name: name, LatestVersion: f.latest[name]})
+	}
+
+	return resp, nil
+}
+
+func (f *fakeClient) GetModule(_ context.Context, req *pb.GetModuleRequest, _ ...grpc.CallOption) (*pb.GetModuleResponse, error) {
+	for _, mod := range f.modules {
+		if mod.GetName() == req.GetName() {
+			return &pb.GetModuleResponse{Found: true, Module: mod}, nil
+		}
+	}
+
+	return &pb.GetModuleResponse{}, nil
+}
+
+func (f *fakeClient) GetStats(_ context.Context, req *pb.GetStatsRequest, _ ...grpc.CallOption) (*pb.GetStatsResponse, error) {
+	resp := &pb.GetStatsResponse{}
+	for i := range req.GetWeeks() {
+		resp.Weeks = append(resp.Weeks, &pb.WeeklyStats{Installs: i})
+	}
+
+	return resp, nil
+}
+
+func newTestServer(client pb.GlixServiceClient) *Server {
+	return newServer(Config{
+		Address: DefaultAddress,
+		Logger:  slog.New(slog.DiscardHandler),
+	}, nil, client)
+}
+
+func TestValidateAddress(t *testing.T) {
+	for address, ok := range map[string]bool{
+		"localhost:9743": true,
+		"127.0.0.1:9743": true,
+		"[::1]:9743":     true,
+		"0.0.0.0:9743":   false,
+		":9743":          false,
+		"example.com:80": false,
+		"localhost":      false,
+	} {
+		if err := ValidateAddress(address); (err == nil) != ok {
+			t.Errorf("ValidateAddress(%q) = %v", address, err)
+		}
+	}
+}
+
+func TestHandleModules(t *testing.T) {
+	s := newTestServer(&fakeClient{
+		modules: []*pb.ModuleProto{
+			{Name: "example.com/old", Version: "v1.0.0"},
+			{Name: "example.com/current", Version: "v2.0.0"},
+			{Name: "example.com/local", Version: "v0.0.0", LocalPath: "/src/local"},
+		},
+		latest: map[string]string{
+			"example.com/old":     "v1.1.0",
+			"example.com/current": "v2.0.0",
+		},
+	})
+
+	rec := httptest.NewRecorder()
+	s.routes().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://localhost:9743/api/modules", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+
+	var views []moduleView
+	if err := json.Unmarshal(rec.Body.Bytes(), &views); err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]moduleView)
+	for _, v := range views {
+		got[v.Name] = v
+	}
+
+	if v := got["example.com/old"]; !v.UpdateAvailable || v.Latest != "v1.1.0" {
+		t.Errorf("old = %+v, want update to v1.1.0", v)
+	}
+
+	if v := got["example.com/current"]; v.UpdateAvailable {
+		t.Errorf("current = %+v, want no update", v)
+	}
+
+	if v := got["example.com/local"]; v.Latest != "" || v.LocalPath != "/src/local" {
+		t.Errorf("local = %+v, want no latest version lookup", v)
+	}
+}
+
+func TestHandleStats(t *testing.T) {
+	s := newTestServer(&fakeClient{})
+
+	rec := httptest.NewRecorder()
+	s.routes().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://localhost:9743/api/stats?weeks=4", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+
+	var views []weekView
+	if err := json.Unmarshal(rec.Body.Bytes(), &views); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(views) != 4 || views[3].Installs != 3 {
+		t.Errorf("views = %+v, want 4 weeks", views)
+	}
+
+	for _, weeks := range []string{"0", "105", "x"} {
+		rec := httptest.NewRecorder()
+		s.routes().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://localhost:9743/api/stats?weeks="+weeks, nil))
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("weeks=%s: status = %d, want %d", weeks, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestActionRequestChecks(t *testing.T) {
+	s := newTestServer(&fakeClient{
+		modules: []*pb.ModuleProto{{Name: "example.com/tool", Version: "v1.0.0"}},
+	})
+
+	tests := []struct {
+		name   string
+		header map[string]string
+		body   string
+		want   int
+	}{
+		{"missing header", nil, `{"module":"example.com/tool"}`, http.StatusForbidden},
+		{"cross origin", map[string]string{actionHeader: "1", "Origin": "http://evil.example"}, `{"module":"example.com/tool"}`, http.StatusForbidden},
+		{"missing module", map[string]string{actionHeader: "1"}, `{}`, http.StatusBadRequest},
+		{"unknown module", map[string]string{actionHeader: "1"}, `{"module":"example.com/other"}`, http.StatusBadRequest},
+		{"bad duration", map[string]string{actionHeader: "1"}, `{"module":"example.com/tool","for":"soon"}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "http://localhost:9743/api/hold", strings.NewReader(tt.body))
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+			s.routes().ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+
+	if jobs := s.jobs.list(); len(jobs) != 0 {
+		t.Errorf("rejected requests started %d job(s)", len(jobs))
+	}
+}
+
+func TestHostCheck(t *testing.T) {
+	s := newTestServer(&fakeClient{
+		modules: []*pb.ModuleProto{{Name: "example.com/tool", Version: "v1.0.0"}},
+	})
+
+	tests := []struct {
+		name   string
+		method string
+		host   string
+		want   int
+	}{
+		{"localhost", http.MethodGet, "localhost:9743", http.StatusOK},
+		{"loopback ip", http.MethodGet, "127.0.0.1:9743", http.StatusOK},
+		{"loopback ipv6", http.MethodGet, "[::1]:9743", http.StatusOK},
+		{"foreign host get", http.MethodGet, "evil.example:9743", http.StatusForbidden},
+		{"foreign host action", http.MethodPost, "evil.example:9743", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := "/api/modules"
+			if tt.method == http.MethodPost {
+				path = "/api/hold"
+			}
+
+			req := httptest.NewRequest(tt.method, "http://"+tt.host+path, strings.NewReader(`{"module":"example.com/tool"}`))
+			req.Header.Set(actionHeader, "1")
+			req.Header.Set("Origin", "http://"+tt.host)
+
+			rec := httptest.NewRecorder()
+			s.routes().ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+
+	if jobs := s.jobs.list(); len(jobs) != 0 {
+		t.Errorf("rejected requests started %d job(s)", len(jobs))
+	}
+}
+
+func TestJobManager(t *testing.T) {
+	m := newJobManager()
+
+	events, cancel := m.subscribe()
+	defer cancel()
+
+	release := make(chan struct{})
+
+	started := m.start("update", "example.com/tool", func(progress func(phase, message string)) (string, error) {
+		progress("install", "Installing...")
+		<-release
+
+		return "", errors.New("build failed")
+	})
+
+	if started.State != jobRunning {
+		t.Fatalf("started job state = %s", started.State)
+	}
+
+	next := func() job {
+		select {
+		case j := <-events:
+			return j
+		case <-time.After(5 * time.Second):
+			t.Fatal("no job event")
+			return job{}
+		}
+	}
+
+	next() // started
+
+	j := next()
+
+	var update struct {
+		Phase string `json:"phase"`
+		Step  int    `json:"step"`
+	}
+
+	if err := json.Unmarshal(j.Progress, &update); err != nil {
+		t.Fatal(err)
+	}
+
+	if update.Phase != "INSTALL_PHASE_BUILD" || update.Step != 3 {
+		t.Errorf("progress = %s", j.Progress)
+	}
+
+	close(release)
+
+	if j = next(); j.State != jobFailed || j.Error != "build failed" || j.Finished.IsZero() {
+		t.Errorf("finished job = %+v", j)
+	}
+
+	if jobs := m.list(); len(jobs) != 1 || jobs[0].State != jobFailed {
+		t.Errorf("list = %+v", jobs)
+	}
+}
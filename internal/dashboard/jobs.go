@@ -0,0 +1,179 @@
+package dashboard
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/inovacc/glix/internal/progress"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// maxJobs is the number of jobs kept for display, oldest dropped first
+const maxJobs = 50
+
+// Job states
+const (
+	jobRunning   = "running"
+	jobSucceeded = "succeeded"
+	jobFailed    = "failed"
+)
+
+// job is an action started from the dashboard
+type job struct {
+	ID       int       `json:"id"`
+	Action   string    `json:"action"`
+	Module   string    `json:"module"`
+	State    string    `json:"state"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished,omitzero"`
+	Result   string    `json:"result,omitempty"`
+	Error    string    `json:"error,omitempty"`
+
+	// Progress is the latest update, encoded with protojson so clients see
+	// the same field names as gRPC consumers
+	Progress jsonMessage `json:"progress,omitempty"`
+
+	tracker *progress.Tracker
+}
+
+// jsonMessage holds pre-encoded JSON
+type jsonMessage []byte
+
+// MarshalJSON returns the encoded message as-is
+func (m jsonMessage) MarshalJSON() ([]byte, error) {
+	if len(m) == 0 {
+		return []byte("null"), nil
+	}
+
+	return m, nil
+}
+
+// jobManager runs dashboard jobs and fans job changes out to event streams
+type jobManager struct {
+	mu          sync.Mutex
+	nextID      int
+	jobs        []*job
+	subscribers map[chan job]struct{}
+}
+
+func newJobManager() *jobManager {
+	return &jobManager{
+		nextID:      1,
+		subscribers: make(map[chan job]struct{}),
+	}
+}
+
+// start runs fn as a new job. fn reports progress through the handler it is
+// given and returns a result message or an error.
+func (m *jobManager) start(action, module string, fn func(progress func(phase, message string)) (string, error)) job {
+	m.mu.Lock()
+
+	j := &job{
+		ID:      m.nextID,
+		Action:  action,
+		Module:  module,
+		State:   jobRunning,
+		Started: time.Now(),
+		tracker: progress.NewTracker(),
+	}
+
+	m.nextID++
+
+	m.jobs = append(m.jobs, j)
+	if len(m.jobs) > maxJobs {
+		m.jobs = m.jobs[len(m.jobs)-maxJobs:]
+	}
+
+	snapshot := *j
+	m.publishLocked(snapshot)
+	m.mu.Unlock()
+
+	go func() {
+		result, err := fn(func(phase, message string) {
+			m.progress(j, phase, message)
+		})
+
+		m.finish(j, result, err)
+	}()
+
+	return snapshot
+}
+
+// progress records a progress update of a running job
+func (m *jobManager) progress(j *job, phase, message string) {
+	data, err := protojson.Marshal(j.tracker.Update(phase, message))
+	if err != nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j.Progress = data
+	m.publishLocked(*j)
+}
+
+// finish records the outcome of a job
+func (m *jobManager) finish(j *job, result string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j.Finished = time.Now()
+	j.Result = result
+
+	if err != nil {
+		j.State = jobFailed
+		j.Error = err.Error()
+	} else {
+		j.State = jobSucceeded
+	}
+
+	m.publishLocked(*j)
+}
+
+// list returns all kept jobs, newest first
+func (m *jobManager) list() []job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	jobs := make([]job, 0, len(m.jobs))
+	for i := len(m.jobs) - 1; i >= 0; i-- {
+		jobs = append(jobs, *m.jobs[i])
+	}
+
+	return jobs
+}
+
+// subscribe returns a channel receiving every job change until cancel is
+// called
+func (m *jobManager) subscribe() (<-chan job, func()) {
+	ch := make(chan job, 64)
+
+	m.mu.Lock()
+	m.subscribers[ch] = struct{}{}
+	m.mu.Unlock()
+
+	return ch, func() {
+		m.mu.Lock()
+		delete(m.subscribers, ch)
+		m.mu.Unlock()
+	}
+}
+
+// publishLocked sends a job change to all subscribers. Slow subscribers
+// miss updates rather than blocking jobs; the next change or a reload of the
+// job list brings them up to date.
+func (m *jobManager) publishLocked(j job) {
+	for ch := range m.subscribers {
+		select {
+		case ch <- j:
+		default:
+		}
+	}
+}
+
+// String describes the job for logs
+func (j job) String() string {
+	return fmt.Sprintf("#%d %s %s (%s)", j.ID, j.Action, j.Module, j.State)
+}
@@ -2,7 +2,10 @@ package database
 
 import (
 	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
+	"slices"
+	"sync"
 	"time"
 
 	pb "github.com/inovacc/glix/pkg/api/v1"
@@ -22,11 +25,36 @@ var (
 	dependenciesBucket = []byte("dependencies")
 	timeIndexBucket    = []byte("indexes_by_time")
 	nameIndexBucket    = []byte("indexes_by_name")
+	snapshotsBucket    = []byte("snapshots")
+	inventoriesBucket  = []byte("inventories")
+	historyBucket      = []byte("install_history")
+	eventsBucket       = []byte("events")
+	vulnsBucket        = []byte("vulnerabilities")
+	versionCacheBucket = []byte("version_cache")
+	libraryWatchBucket = []byte("library_watches")
+	tokensBucket       = []byte("tokens")
+	autoUpdateBucket   = []byte("autoupdate")
 )
 
+// autoUpdateKey is the key of the auto-update configuration in its bucket
+var autoUpdateKey = []byte("config")
+
+// maxInstallHistory is the number of install records kept per module
+const maxInstallHistory = 20
+
+// maxEvents is the number of events kept across all modules
+const maxEvents = 1000
+
 // Storage wraps BoltDB with module tracking functionality
 type Storage struct {
 	db *bolt.DB
+
+	// The module count is asked for on every status call, e.g. from shell
+	// prompts, so it is kept between writes
+	countMu    sync.Mutex
+	countValid bool
+	count      int64
+	countGen   uint64 // Incremented by every module write
 }
 
 // NewStorage initializes BoltDB connection and creates buckets
@@ -55,6 +83,14 @@ func (s *Storage) Close() error {
 	return s.db.Close()
 }
 
+// Backup writes a consistent copy of the database to path while it stays
+// open for reads and writes
+func (s *Storage) Backup(path string) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		return tx.CopyFile(path, 0600)
+	})
+}
+
 // initBuckets creates all required buckets if they don't exist
 func (s *Storage) initBuckets() error {
 	return s.db.Update(func(tx *bolt.Tx) error {
@@ -63,6 +99,15 @@ func (s *Storage) initBuckets() error {
 			dependenciesBucket,
 			timeIndexBucket,
 			nameIndexBucket,
+			snapshotsBucket,
+			inventoriesBucket,
+			historyBucket,
+			eventsBucket,
+			vulnsBucket,
+			versionCacheBucket,
+			libraryWatchBucket,
+			tokensBucket,
+			autoUpdateBucket,
 		}
 
 		for _, bucket := range buckets {
@@ -90,42 +135,49 @@ func (s *Storage) UpsertModules(module []*pb.ModuleProto) error {
 // Uses a hash of the module name (without version) as the primary key
 // This ensures only one entry per module, with the latest version stored
 func (s *Storage) UpsertModule(module *pb.ModuleProto) error {
-	return s.db.Update(func(tx *bolt.Tx) error {
-		// Use hash of module name as primary key (ensures one entry per module)
-		key := moduleKey(module.GetName())
+	defer s.invalidateCount()
 
-		// Check if the module already exists and remove the old time index entry
-		bucket := tx.Bucket(modulesBucket)
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return s.upsertModule(tx, module)
+	})
+}
 
-		existingData := bucket.Get(key)
-		if existingData != nil {
-			existingModule := &pb.ModuleProto{}
-			if err := proto.Unmarshal(existingData, existingModule); err == nil {
-				// Remove old time index entry
-				if err := s.deleteFromTimeIndex(tx, existingModule.GetTimestampUnixNano()); err != nil {
-					return fmt.Errorf("failed to delete old time index: %w", err)
-				}
+// upsertModule stores module and its time index entry within tx
+func (s *Storage) upsertModule(tx *bolt.Tx, module *pb.ModuleProto) error {
+	// Use hash of module name as primary key (ensures one entry per module)
+	key := moduleKey(module.GetName())
+
+	// Check if the module already exists and remove the old time index entry
+	bucket := tx.Bucket(modulesBucket)
+
+	existingData := bucket.Get(key)
+	if existingData != nil {
+		existingModule := &pb.ModuleProto{}
+		if err := proto.Unmarshal(existingData, existingModule); err == nil {
+			// Remove old time index entry
+			if err := s.deleteFromTimeIndex(tx, existingModule.GetTimestampUnixNano()); err != nil {
+				return fmt.Errorf("failed to delete old time index: %w", err)
 			}
 		}
+	}
 
-		// Serialize module to protobuf
-		data, err := proto.Marshal(module)
-		if err != nil {
-			return fmt.Errorf("failed to marshal module: %w", err)
-		}
+	// Serialize module to protobuf
+	data, err := proto.Marshal(module)
+	if err != nil {
+		return fmt.Errorf("failed to marshal module: %w", err)
+	}
 
-		// Store in modules bucket (using hash key)
-		if err := bucket.Put(key, data); err != nil {
-			return fmt.Errorf("failed to put module: %w", err)
-		}
+	// Store in modules bucket (using hash key)
+	if err := bucket.Put(key, data); err != nil {
+		return fmt.Errorf("failed to put module: %w", err)
+	}
 
-		// Update time index (use module name as value for lookup)
-		if err := s.updateTimeIndex(tx, module.GetTimestampUnixNano(), module.GetName()); err != nil {
-			return fmt.Errorf("failed to update time index: %w", err)
-		}
+	// Update time index (use module name as value for lookup)
+	if err := s.updateTimeIndex(tx, module.GetTimestampUnixNano(), module.GetName()); err != nil {
+		return fmt.Errorf("failed to update time index: %w", err)
+	}
 
-		return nil
-	})
+	return nil
 }
 
 // GetModule retrieves a module by name (version is optional, ignored since we store one version per module)
@@ -200,46 +252,104 @@ func (s *Storage) ListModules() ([]*pb.ModuleProto, error) {
 
 // DeleteModule removes a module and updates indexes (version is ignored since we store one version per module)
 func (s *Storage) DeleteModule(name, _ string) error {
+	defer s.invalidateCount()
+
 	return s.db.Update(func(tx *bolt.Tx) error {
-		key := moduleKey(name)
+		return s.deleteModule(tx, name)
+	})
+}
 
-		// Get module first to access timestamp
-		bucket := tx.Bucket(modulesBucket)
+// deleteModule removes a module with its indexes and related records
+// within tx
+func (s *Storage) deleteModule(tx *bolt.Tx, name string) error {
+	key := moduleKey(name)
 
-		data := bucket.Get(key)
-		if data == nil {
-			return fmt.Errorf("module not found: %s", name)
-		}
+	// Get module first to access timestamp
+	bucket := tx.Bucket(modulesBucket)
 
-		module := &pb.ModuleProto{}
-		if err := proto.Unmarshal(data, module); err != nil {
-			return fmt.Errorf("failed to unmarshal module: %w", err)
-		}
+	data := bucket.Get(key)
+	if data == nil {
+		return fmt.Errorf("module not found: %s", name)
+	}
 
-		// Delete from modules bucket
-		if err := bucket.Delete(key); err != nil {
-			return fmt.Errorf("failed to delete module: %w", err)
-		}
+	module := &pb.ModuleProto{}
+	if err := proto.Unmarshal(data, module); err != nil {
+		return fmt.Errorf("failed to unmarshal module: %w", err)
+	}
+
+	// Delete from modules bucket
+	if err := bucket.Delete(key); err != nil {
+		return fmt.Errorf("failed to delete module: %w", err)
+	}
 
-		// Delete from time index
-		if err := s.deleteFromTimeIndex(tx, module.GetTimestampUnixNano()); err != nil {
-			return fmt.Errorf("failed to delete from time index: %w", err)
+	// Delete from time index
+	if err := s.deleteFromTimeIndex(tx, module.GetTimestampUnixNano()); err != nil {
+		return fmt.Errorf("failed to delete from time index: %w", err)
+	}
+
+	// Delete dependencies
+	depKey := []byte(name)
+
+	depBucket := tx.Bucket(dependenciesBucket)
+	if err := depBucket.Delete(depKey); err != nil {
+		return fmt.Errorf("failed to delete dependencies: %w", err)
+	}
+
+	// Delete install history
+	if err := tx.Bucket(historyBucket).Delete(key); err != nil {
+		return fmt.Errorf("failed to delete install history: %w", err)
+	}
+
+	// Delete vulnerability report
+	if err := tx.Bucket(vulnsBucket).Delete([]byte(name)); err != nil {
+		return fmt.Errorf("failed to delete vulnerability report: %w", err)
+	}
+
+	return nil
+}
+
+// RenameModule stores module in place of the module stored as from, such as
+// after its package moved within its repository. The install history moves
+// with it; the dependencies and vulnerability report of from are dropped.
+func (s *Storage) RenameModule(from string, module *pb.ModuleProto) error {
+	defer s.invalidateCount()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		to := moduleKey(module.GetName())
+
+		if tx.Bucket(modulesBucket).Get(to) != nil {
+			return fmt.Errorf("cannot rename %s: %s is already stored", from, module.GetName())
 		}
 
-		// Delete dependencies
-		depKey := []byte(name)
+		// Read the history before deleteModule drops it
+		history := slices.Clone(tx.Bucket(historyBucket).Get(moduleKey(from)))
 
-		depBucket := tx.Bucket(dependenciesBucket)
-		if err := depBucket.Delete(depKey); err != nil {
-			return fmt.Errorf("failed to delete dependencies: %w", err)
+		if err := s.deleteModule(tx, from); err != nil {
+			return err
 		}
 
-		return nil
+		if history != nil {
+			if err := tx.Bucket(historyBucket).Put(to, history); err != nil {
+				return fmt.Errorf("failed to move install history: %w", err)
+			}
+		}
+
+		return s.upsertModule(tx, module)
 	})
 }
 
-// CountModules returns the total number of modules
+// CountModules returns the total number of modules. The count is cached
+// until the next module write.
 func (s *Storage) CountModules() (int64, error) {
+	s.countMu.Lock()
+	if s.countValid {
+		defer s.countMu.Unlock()
+		return s.count, nil
+	}
+
+	gen := s.countGen
+	s.countMu.Unlock()
+
 	var count int64
 
 	err := s.db.View(func(tx *bolt.Tx) error {
@@ -249,8 +359,27 @@ func (s *Storage) CountModules() (int64, error) {
 
 		return nil
 	})
+	if err != nil {
+		return 0, err
+	}
 
-	return count, err
+	// A write that committed while counting makes the count stale
+	s.countMu.Lock()
+	if s.countGen == gen {
+		s.count, s.countValid = count, true
+	}
+	s.countMu.Unlock()
+
+	return count, nil
+}
+
+// invalidateCount drops the cached module count after a module write
+func (s *Storage) invalidateCount() {
+	s.countMu.Lock()
+	defer s.countMu.Unlock()
+
+	s.countGen++
+	s.countValid = false
 }
 
 // UpsertDependencies stores dependencies for a module
@@ -311,6 +440,601 @@ func (s *Storage) CountDependencies() (int64, error) {
 	return count, err
 }
 
+// SaveSnapshot stores a named snapshot, replacing any existing one with the same name
+func (s *Storage) SaveSnapshot(snapshot *pb.SnapshotProto) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		data, err := proto.Marshal(snapshot)
+		if err != nil {
+			return fmt.Errorf("failed to marshal snapshot: %w", err)
+		}
+
+		bucket := tx.Bucket(snapshotsBucket)
+		if err := bucket.Put([]byte(snapshot.GetName()), data); err != nil {
+			return fmt.Errorf("failed to put snapshot: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetSnapshot retrieves a snapshot by name
+func (s *Storage) GetSnapshot(name string) (*pb.SnapshotProto, error) {
+	var snapshot *pb.SnapshotProto
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(snapshotsBucket)
+
+		data := bucket.Get([]byte(name))
+		if data == nil {
+			return fmt.Errorf("snapshot not found: %s", name)
+		}
+
+		snapshot = &pb.SnapshotProto{}
+		if err := proto.Unmarshal(data, snapshot); err != nil {
+			return fmt.Errorf("failed to unmarshal snapshot: %w", err)
+		}
+
+		return nil
+	})
+
+	return snapshot, err
+}
+
+// ListSnapshots retrieves all snapshots ordered by name
+func (s *Storage) ListSnapshots() ([]*pb.SnapshotProto, error) {
+	var snapshots []*pb.SnapshotProto
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(snapshotsBucket)
+
+		return bucket.ForEach(func(_, v []byte) error {
+			snapshot := &pb.SnapshotProto{}
+			if err := proto.Unmarshal(v, snapshot); err != nil {
+				return fmt.Errorf("failed to unmarshal snapshot: %w", err)
+			}
+
+			snapshots = append(snapshots, snapshot)
+
+			return nil
+		})
+	})
+
+	return snapshots, err
+}
+
+// DeleteSnapshot removes a snapshot by name
+func (s *Storage) DeleteSnapshot(name string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(snapshotsBucket)
+		key := []byte(name)
+
+		if bucket.Get(key) == nil {
+			return fmt.Errorf("snapshot not found: %s", name)
+		}
+
+		if err := bucket.Delete(key); err != nil {
+			return fmt.Errorf("failed to delete snapshot: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// SaveInventory stores the inventory reported by a host, replacing its
+// previous report
+func (s *Storage) SaveInventory(inventory *pb.InventoryProto) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		data, err := proto.Marshal(inventory)
+		if err != nil {
+			return fmt.Errorf("failed to marshal inventory: %w", err)
+		}
+
+		bucket := tx.Bucket(inventoriesBucket)
+		if err := bucket.Put([]byte(inventory.GetHost()), data); err != nil {
+			return fmt.Errorf("failed to put inventory: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ListInventories retrieves all reported inventories ordered by host
+func (s *Storage) ListInventories() ([]*pb.InventoryProto, error) {
+	var inventories []*pb.InventoryProto
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(inventoriesBucket)
+
+		return bucket.ForEach(func(_, v []byte) error {
+			inventory := &pb.InventoryProto{}
+			if err := proto.Unmarshal(v, inventory); err != nil {
+				return fmt.Errorf("failed to unmarshal inventory: %w", err)
+			}
+
+			inventories = append(inventories, inventory)
+
+			return nil
+		})
+	})
+
+	return inventories, err
+}
+
+// AppendInstallHistory records an install of a module. Reinstalling the
+// version of the latest record replaces it instead of adding a new one, and
+// only the newest maxInstallHistory records are kept.
+func (s *Storage) AppendInstallHistory(module *pb.ModuleProto) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(historyBucket)
+		key := moduleKey(module.GetName())
+
+		history := &pb.InstallHistoryProto{}
+		if data := bucket.Get(key); data != nil {
+			if err := proto.Unmarshal(data, history); err != nil {
+				return fmt.Errorf("failed to unmarshal install history: %w", err)
+			}
+		}
+
+		installs := history.GetInstalls()
+		if n := len(installs); n > 0 && installs[n-1].GetVersion() == module.GetVersion() {
+			installs = installs[:n-1]
+		}
+
+		installs = append(installs, module)
+		if len(installs) > maxInstallHistory {
+			installs = installs[len(installs)-maxInstallHistory:]
+		}
+
+		history.Installs = installs
+
+		data, err := proto.Marshal(history)
+		if err != nil {
+			return fmt.Errorf("failed to marshal install history: %w", err)
+		}
+
+		if err := bucket.Put(key, data); err != nil {
+			return fmt.Errorf("failed to put install history: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetInstallHistory retrieves the install records of a module, oldest first
+func (s *Storage) GetInstallHistory(name string) ([]*pb.ModuleProto, error) {
+	history := &pb.InstallHistoryProto{}
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(historyBucket).Get(moduleKey(name))
+		if data == nil {
+			return nil
+		}
+
+		if err := proto.Unmarshal(data, history); err != nil {
+			return fmt.Errorf("failed to unmarshal install history: %w", err)
+		}
+
+		return nil
+	})
+
+	return history.GetInstalls(), err
+}
+
+// RemoveInstallHistory removes the install records of a version of a module
+// and reports whether there were any
+func (s *Storage) RemoveInstallHistory(name, version string) (bool, error) {
+	removed := false
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(historyBucket)
+		key := moduleKey(name)
+
+		data := bucket.Get(key)
+		if data == nil {
+			return nil
+		}
+
+		history := &pb.InstallHistoryProto{}
+		if err := proto.Unmarshal(data, history); err != nil {
+			return fmt.Errorf("failed to unmarshal install history: %w", err)
+		}
+
+		kept := slices.DeleteFunc(history.GetInstalls(), func(m *pb.ModuleProto) bool {
+			return m.GetVersion() == version
+		})
+
+		if removed = len(kept) < len(history.GetInstalls()); !removed {
+			return nil
+		}
+
+		history.Installs = kept
+
+		data, err := proto.Marshal(history)
+		if err != nil {
+			return fmt.Errorf("failed to marshal install history: %w", err)
+		}
+
+		if err := bucket.Put(key, data); err != nil {
+			return fmt.Errorf("failed to put install history: %w", err)
+		}
+
+		return nil
+	})
+
+	return removed, err
+}
+
+// SaveVulnReport stores the vulnerability report of a module, replacing the
+// report of its previous scan
+func (s *Storage) SaveVulnReport(report *pb.VulnReportProto) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		data, err := proto.Marshal(report)
+		if err != nil {
+			return fmt.Errorf("failed to marshal vulnerability report: %w", err)
+		}
+
+		if err := tx.Bucket(vulnsBucket).Put([]byte(report.GetName()), data); err != nil {
+			return fmt.Errorf("failed to put vulnerability report: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ListVulnReports retrieves vulnerability reports ordered by module. A
+// non-empty name only returns the report of that module, if any.
+func (s *Storage) ListVulnReports(name string) ([]*pb.VulnReportProto, error) {
+	var reports []*pb.VulnReportProto
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(vulnsBucket)
+
+		return bucket.ForEach(func(k, v []byte) error {
+			if name != "" && string(k) != name {
+				return nil
+			}
+
+			report := &pb.VulnReportProto{}
+			if err := proto.Unmarshal(v, report); err != nil {
+				return fmt.Errorf("failed to unmarshal vulnerability report: %w", err)
+			}
+
+			reports = append(reports, report)
+
+			return nil
+		})
+	})
+
+	return reports, err
+}
+
+// SaveVersionCache stores the go list -m -versions answer for a module path,
+// replacing the previous one
+func (s *Storage) SaveVersionCache(entry *pb.VersionCacheProto) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		data, err := proto.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("failed to marshal version cache entry: %w", err)
+		}
+
+		if err := tx.Bucket(versionCacheBucket).Put([]byte(entry.GetPath()), data); err != nil {
+			return fmt.Errorf("failed to put version cache entry: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetVersionCache retrieves the cached versions of a module path, or nil
+// when none are cached. Entries are returned whatever their age; readers
+// decide whether they are fresh enough.
+func (s *Storage) GetVersionCache(path string) (*pb.VersionCacheProto, error) {
+	var entry *pb.VersionCacheProto
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(versionCacheBucket).Get([]byte(path))
+		if data == nil {
+			return nil
+		}
+
+		entry = &pb.VersionCacheProto{}
+		if err := proto.Unmarshal(data, entry); err != nil {
+			return fmt.Errorf("failed to unmarshal version cache entry: %w", err)
+		}
+
+		return nil
+	})
+
+	return entry, err
+}
+
+// SaveLibraryWatch stores a watched library module, replacing the entry of
+// the same path
+func (s *Storage) SaveLibraryWatch(watch *pb.LibraryWatchProto) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		data, err := proto.Marshal(watch)
+		if err != nil {
+			return fmt.Errorf("failed to marshal library watch: %w", err)
+		}
+
+		if err := tx.Bucket(libraryWatchBucket).Put([]byte(watch.GetPath()), data); err != nil {
+			return fmt.Errorf("failed to put library watch: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ListLibraryWatches retrieves all watched library modules ordered by path
+func (s *Storage) ListLibraryWatches() ([]*pb.LibraryWatchProto, error) {
+	var watches []*pb.LibraryWatchProto
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(libraryWatchBucket).ForEach(func(_, v []byte) error {
+			watch := &pb.LibraryWatchProto{}
+			if err := proto.Unmarshal(v, watch); err != nil {
+				return fmt.Errorf("failed to unmarshal library watch: %w", err)
+			}
+
+			watches = append(watches, watch)
+
+			return nil
+		})
+	})
+
+	return watches, err
+}
+
+// DeleteLibraryWatch stops watching a library module
+func (s *Storage) DeleteLibraryWatch(path string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(libraryWatchBucket)
+		key := []byte(path)
+
+		if bucket.Get(key) == nil {
+			return fmt.Errorf("library not watched: %s", path)
+		}
+
+		if err := bucket.Delete(key); err != nil {
+			return fmt.Errorf("failed to delete library watch: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// SaveToken stores an API token, replacing the token of the same name
+func (s *Storage) SaveToken(token *pb.TokenProto) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		data, err := proto.Marshal(token)
+		if err != nil {
+			return fmt.Errorf("failed to marshal token: %w", err)
+		}
+
+		if err := tx.Bucket(tokensBucket).Put([]byte(token.GetName()), data); err != nil {
+			return fmt.Errorf("failed to put token: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetToken retrieves an API token by name, nil if there is none
+func (s *Storage) GetToken(name string) (*pb.TokenProto, error) {
+	var token *pb.TokenProto
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(tokensBucket).Get([]byte(name))
+		if data == nil {
+			return nil
+		}
+
+		token = &pb.TokenProto{}
+		if err := proto.Unmarshal(data, token); err != nil {
+			return fmt.Errorf("failed to unmarshal token: %w", err)
+		}
+
+		return nil
+	})
+
+	return token, err
+}
+
+// ListTokens retrieves all API tokens ordered by name
+func (s *Storage) ListTokens() ([]*pb.TokenProto, error) {
+	var tokens []*pb.TokenProto
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(tokensBucket).ForEach(func(_, v []byte) error {
+			token := &pb.TokenProto{}
+			if err := proto.Unmarshal(v, token); err != nil {
+				return fmt.Errorf("failed to unmarshal token: %w", err)
+			}
+
+			tokens = append(tokens, token)
+
+			return nil
+		})
+	})
+
+	return tokens, err
+}
+
+// TouchToken records that an API token was used at t. Revoked tokens stay
+// revoked.
+func (s *Storage) TouchToken(name string, t time.Time) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(tokensBucket)
+
+		data := bucket.Get([]byte(name))
+		if data == nil {
+			return nil
+		}
+
+		token := &pb.TokenProto{}
+		if err := proto.Unmarshal(data, token); err != nil {
+			return fmt.Errorf("failed to unmarshal token: %w", err)
+		}
+
+		token.LastUsedUnixNano = t.UnixNano()
+
+		data, err := proto.Marshal(token)
+		if err != nil {
+			return fmt.Errorf("failed to marshal token: %w", err)
+		}
+
+		return bucket.Put([]byte(name), data)
+	})
+}
+
+// DeleteToken revokes an API token
+func (s *Storage) DeleteToken(name string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(tokensBucket)
+		key := []byte(name)
+
+		if bucket.Get(key) == nil {
+			return fmt.Errorf("token not found: %s", name)
+		}
+
+		if err := bucket.Delete(key); err != nil {
+			return fmt.Errorf("failed to delete token: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetAutoUpdateConfig retrieves the auto-update configuration, or nil before
+// one is stored
+func (s *Storage) GetAutoUpdateConfig() (*pb.AutoUpdateConfigProto, error) {
+	var cfg *pb.AutoUpdateConfigProto
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(autoUpdateBucket).Get(autoUpdateKey)
+		if data == nil {
+			return nil
+		}
+
+		cfg = &pb.AutoUpdateConfigProto{}
+		if err := proto.Unmarshal(data, cfg); err != nil {
+			return fmt.Errorf("failed to unmarshal auto-update config: %w", err)
+		}
+
+		return nil
+	})
+
+	return cfg, err
+}
+
+// UpdateAutoUpdateConfig changes the auto-update configuration with update,
+// which gets the stored configuration or an empty one before one is stored,
+// and returns the result. Changes run in one transaction each, so
+// concurrent ones are applied one after another; an error from update
+// leaves the configuration unchanged.
+func (s *Storage) UpdateAutoUpdateConfig(update func(cfg *pb.AutoUpdateConfigProto) error) (*pb.AutoUpdateConfigProto, error) {
+	cfg := &pb.AutoUpdateConfigProto{}
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(autoUpdateBucket)
+
+		if data := bucket.Get(autoUpdateKey); data != nil {
+			if err := proto.Unmarshal(data, cfg); err != nil {
+				return fmt.Errorf("failed to unmarshal auto-update config: %w", err)
+			}
+		}
+
+		if err := update(cfg); err != nil {
+			return err
+		}
+
+		data, err := proto.Marshal(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to marshal auto-update config: %w", err)
+		}
+
+		return bucket.Put(autoUpdateKey, data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// AppendEvent records an install, update, or remove. Events are keyed by a
+// sequence number so they stay in the order they were recorded; only the
+// newest maxEvents are kept.
+func (s *Storage) AppendEvent(event *pb.EventProto) error {
+	if event.GetTimestampUnixNano() == 0 {
+		event.TimestampUnixNano = time.Now().UnixNano()
+	}
+
+	data, err := proto.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(eventsBucket)
+
+		seq, err := bucket.NextSequence()
+		if err != nil {
+			return fmt.Errorf("failed to allocate event sequence: %w", err)
+		}
+
+		if err := bucket.Put(binary.BigEndian.AppendUint64(nil, seq), data); err != nil {
+			return fmt.Errorf("failed to put event: %w", err)
+		}
+
+		// Events are only ever removed from the front, so the sequence of
+		// the oldest kept event is known
+		if seq <= maxEvents {
+			return nil
+		}
+
+		c := bucket.Cursor()
+		for k, _ := c.First(); k != nil && binary.BigEndian.Uint64(k) <= seq-maxEvents; k, _ = c.Next() {
+			if err := c.Delete(); err != nil {
+				return fmt.Errorf("failed to prune events: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// ListEvents retrieves events newest first. A non-empty name only returns
+// the events of that module; a positive limit caps the number returned.
+func (s *Storage) ListEvents(name string, limit int) ([]*pb.EventProto, error) {
+	var events []*pb.EventProto
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(eventsBucket).Cursor()
+
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			if limit > 0 && len(events) >= limit {
+				break
+			}
+
+			event := &pb.EventProto{}
+			if err := proto.Unmarshal(v, event); err != nil {
+				return fmt.Errorf("failed to unmarshal event: %w", err)
+			}
+
+			if name != "" && event.GetName() != name {
+				continue
+			}
+
+			events = append(events, event)
+		}
+
+		return nil
+	})
+
+	return events, err
+}
+
 // updateTimeIndex adds/updates an entry in the time index
 func (s *Storage) updateTimeIndex(tx *bolt.Tx, timestamp int64, moduleName string) error {
 	bucket := tx.Bucket(timeIndexBucket)
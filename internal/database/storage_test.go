@@ -1,8 +1,11 @@
 package database
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sync"
 	"testing"
 	"time"
 
@@ -314,6 +317,49 @@ func TestCountModules(t *testing.T) {
 	}
 }
 
+func TestCountModules_Cached(t *testing.T) {
+	storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	count := func() int64 {
+		t.Helper()
+
+		n, err := storage.CountModules()
+		if err != nil {
+			t.Fatalf("CountModules failed: %v", err)
+		}
+
+		return n
+	}
+
+	mod := &pb.ModuleProto{Name: "github.com/test/module", Version: "v1.0.0", TimestampUnixNano: time.Now().UnixNano()}
+	if err := storage.UpsertModule(mod); err != nil {
+		t.Fatalf("UpsertModule failed: %v", err)
+	}
+
+	if n := count(); n != 1 {
+		t.Fatalf("Expected 1 module, got %d", n)
+	}
+
+	// Updating an existing module keeps the count
+	mod.Version = "v1.1.0"
+	if err := storage.UpsertModule(mod); err != nil {
+		t.Fatalf("UpsertModule failed: %v", err)
+	}
+
+	if n := count(); n != 1 {
+		t.Errorf("Expected 1 module after update, got %d", n)
+	}
+
+	if err := storage.DeleteModule(mod.GetName(), ""); err != nil {
+		t.Fatalf("DeleteModule failed: %v", err)
+	}
+
+	if n := count(); n != 0 {
+		t.Errorf("Expected 0 modules after delete, got %d", n)
+	}
+}
+
 func TestUpsertDependencies(t *testing.T) {
 	storage, cleanup := setupTestStorage(t)
 	defer cleanup()
@@ -661,3 +707,518 @@ func TestEmptyDatabase(t *testing.T) {
 		t.Error("Expected error for nonexistent module, got nil")
 	}
 }
+
+func TestSnapshots(t *testing.T) {
+	storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	snapshot := &pb.SnapshotProto{
+		Name:            "baseline",
+		CreatedUnixNano: time.Now().UnixNano(),
+		Modules: []*pb.ModuleProto{
+			{Name: "github.com/test/one", Version: "v1.0.0"},
+			{Name: "github.com/test/two", Version: "v2.1.0"},
+		},
+	}
+
+	if err := storage.SaveSnapshot(snapshot); err != nil {
+		t.Fatalf("SaveSnapshot failed: %v", err)
+	}
+
+	got, err := storage.GetSnapshot("baseline")
+	if err != nil {
+		t.Fatalf("GetSnapshot failed: %v", err)
+	}
+
+	if len(got.GetModules()) != 2 {
+		t.Errorf("Expected 2 modules in snapshot, got %d", len(got.GetModules()))
+	}
+
+	if err := storage.SaveSnapshot(&pb.SnapshotProto{Name: "empty"}); err != nil {
+		t.Fatalf("SaveSnapshot failed: %v", err)
+	}
+
+	snapshots, err := storage.ListSnapshots()
+	if err != nil {
+		t.Fatalf("ListSnapshots failed: %v", err)
+	}
+
+	if len(snapshots) != 2 {
+		t.Fatalf("Expected 2 snapshots, got %d", len(snapshots))
+	}
+
+	if snapshots[0].GetName() != "baseline" {
+		t.Errorf("Expected snapshots ordered by name, got %s first", snapshots[0].GetName())
+	}
+
+	if err := storage.DeleteSnapshot("baseline"); err != nil {
+		t.Fatalf("DeleteSnapshot failed: %v", err)
+	}
+
+	if _, err := storage.GetSnapshot("baseline"); err == nil {
+		t.Error("Expected error for deleted snapshot")
+	}
+
+	if err := storage.DeleteSnapshot("baseline"); err == nil {
+		t.Error("Expected error deleting nonexistent snapshot")
+	}
+}
+
+func TestInventories(t *testing.T) {
+	storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	for _, inv := range []*pb.InventoryProto{
+		{Host: "ws-2", Modules: []*pb.ModuleProto{{Name: "github.com/test/one", Version: "v1.0.0"}}},
+		{Host: "ws-1", Modules: []*pb.ModuleProto{{Name: "github.com/test/one", Version: "v1.1.0"}}},
+		{Host: "ws-2", Modules: []*pb.ModuleProto{{Name: "github.com/test/one", Version: "v1.2.0"}}},
+	} {
+		if err := storage.SaveInventory(inv); err != nil {
+			t.Fatalf("SaveInventory failed: %v", err)
+		}
+	}
+
+	inventories, err := storage.ListInventories()
+	if err != nil {
+		t.Fatalf("ListInventories failed: %v", err)
+	}
+
+	if len(inventories) != 2 {
+		t.Fatalf("Expected 2 inventories, got %d", len(inventories))
+	}
+
+	if inventories[0].GetHost() != "ws-1" {
+		t.Errorf("Expected inventories ordered by host, got %s first", inventories[0].GetHost())
+	}
+
+	if v := inventories[1].GetModules()[0].GetVersion(); v != "v1.2.0" {
+		t.Errorf("Expected latest report to replace the previous one, got %s", v)
+	}
+}
+
+func TestInstallHistory(t *testing.T) {
+	storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	const name = "github.com/test/tool"
+
+	for _, v := range []string{"v1.0.0", "v1.1.0", "v1.1.0", "v1.2.0"} {
+		mod := &pb.ModuleProto{Name: name, Version: v}
+
+		if err := storage.UpsertModule(mod); err != nil {
+			t.Fatalf("UpsertModule failed: %v", err)
+		}
+
+		if err := storage.AppendInstallHistory(mod); err != nil {
+			t.Fatalf("AppendInstallHistory failed: %v", err)
+		}
+	}
+
+	history, err := storage.GetInstallHistory(name)
+	if err != nil {
+		t.Fatalf("GetInstallHistory failed: %v", err)
+	}
+
+	var versions []string
+	for _, mod := range history {
+		versions = append(versions, mod.GetVersion())
+	}
+
+	if want := []string{"v1.0.0", "v1.1.0", "v1.2.0"}; !slices.Equal(versions, want) {
+		t.Errorf("Expected history %v, got %v", want, versions)
+	}
+
+	if removed, err := storage.RemoveInstallHistory(name, "v1.0.0"); err != nil || !removed {
+		t.Fatalf("RemoveInstallHistory(v1.0.0) = %v, %v; want removed", removed, err)
+	}
+
+	if removed, err := storage.RemoveInstallHistory(name, "v0.9.0"); err != nil || removed {
+		t.Errorf("RemoveInstallHistory(v0.9.0) = %v, %v; want nothing removed", removed, err)
+	}
+
+	if history, _ := storage.GetInstallHistory(name); len(history) != 2 || history[0].GetVersion() != "v1.1.0" {
+		t.Errorf("Expected v1.0.0 removed from the history, got %v", history)
+	}
+
+	for i := range maxInstallHistory + 5 {
+		if err := storage.AppendInstallHistory(&pb.ModuleProto{Name: name, Version: fmt.Sprintf("v2.0.%d", i)}); err != nil {
+			t.Fatalf("AppendInstallHistory failed: %v", err)
+		}
+	}
+
+	history, _ = storage.GetInstallHistory(name)
+	if len(history) != maxInstallHistory {
+		t.Errorf("Expected history capped at %d, got %d", maxInstallHistory, len(history))
+	}
+
+	if err := storage.DeleteModule(name, ""); err != nil {
+		t.Fatalf("DeleteModule failed: %v", err)
+	}
+
+	if history, _ = storage.GetInstallHistory(name); len(history) != 0 {
+		t.Errorf("Expected history removed with the module, got %d records", len(history))
+	}
+}
+
+func TestRenameModule(t *testing.T) {
+	storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	old := &pb.ModuleProto{Name: "github.com/test/tool", Version: "v1.0.0", TimestampUnixNano: time.Now().UnixNano()}
+
+	if err := storage.UpsertModule(old); err != nil {
+		t.Fatalf("UpsertModule failed: %v", err)
+	}
+
+	if err := storage.AppendInstallHistory(old); err != nil {
+		t.Fatalf("AppendInstallHistory failed: %v", err)
+	}
+
+	moved := &pb.ModuleProto{Name: "github.com/test/tool/cmd/tool", Version: "v1.0.0", TimestampUnixNano: old.GetTimestampUnixNano()}
+
+	if err := storage.RenameModule(old.GetName(), moved); err != nil {
+		t.Fatalf("RenameModule failed: %v", err)
+	}
+
+	if _, err := storage.GetModule(old.GetName(), ""); err == nil {
+		t.Error("Expected the old record removed")
+	}
+
+	modules, err := storage.ListModules()
+	if err != nil {
+		t.Fatalf("ListModules failed: %v", err)
+	}
+
+	if len(modules) != 1 || modules[0].GetName() != moved.GetName() {
+		t.Errorf("Expected only %s listed, got %v", moved.GetName(), modules)
+	}
+
+	if history, _ := storage.GetInstallHistory(moved.GetName()); len(history) != 1 {
+		t.Errorf("Expected the install history moved, got %d records", len(history))
+	}
+
+	if history, _ := storage.GetInstallHistory(old.GetName()); len(history) != 0 {
+		t.Errorf("Expected no history left under the old name, got %d records", len(history))
+	}
+}
+
+func TestRenameModuleExistingTarget(t *testing.T) {
+	storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	old := &pb.ModuleProto{Name: "github.com/test/tool", Version: "v1.0.0", TimestampUnixNano: time.Now().UnixNano()}
+	other := &pb.ModuleProto{Name: "github.com/test/tool/cmd/tool", Version: "v2.0.0", TimestampUnixNano: time.Now().UnixNano() + 1}
+
+	for _, m := range []*pb.ModuleProto{old, other} {
+		if err := storage.UpsertModule(m); err != nil {
+			t.Fatalf("UpsertModule failed: %v", err)
+		}
+
+		if err := storage.AppendInstallHistory(m); err != nil {
+			t.Fatalf("AppendInstallHistory failed: %v", err)
+		}
+	}
+
+	moved := &pb.ModuleProto{Name: other.GetName(), Version: "v1.0.0", TimestampUnixNano: old.GetTimestampUnixNano()}
+
+	if err := storage.RenameModule(old.GetName(), moved); err == nil {
+		t.Fatal("Expected renaming onto a stored module to fail")
+	}
+
+	if _, err := storage.GetModule(old.GetName(), ""); err != nil {
+		t.Errorf("Expected the old record kept: %v", err)
+	}
+
+	if got, err := storage.GetModule(other.GetName(), ""); err != nil || got.GetVersion() != "v2.0.0" {
+		t.Errorf("Expected the target record untouched, got %v, %v", got, err)
+	}
+
+	if history, _ := storage.GetInstallHistory(old.GetName()); len(history) != 1 {
+		t.Errorf("Expected the old history kept, got %d records", len(history))
+	}
+
+	if count, _ := storage.CountModules(); count != 2 {
+		t.Errorf("Expected 2 modules, got %d", count)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	events := []*pb.EventProto{
+		{Action: pb.EventAction_EVENT_ACTION_INSTALL, Name: "github.com/test/a", ToVersion: "v1.0.0", Success: true},
+		{Action: pb.EventAction_EVENT_ACTION_INSTALL, Name: "github.com/test/b", ToVersion: "v2.0.0", Success: true},
+		{Action: pb.EventAction_EVENT_ACTION_UPDATE, Name: "github.com/test/a", FromVersion: "v1.0.0", ToVersion: "v1.1.0", ErrorMessage: "build failed"},
+		{Action: pb.EventAction_EVENT_ACTION_REMOVE, Name: "github.com/test/a", FromVersion: "v1.0.0", Success: true},
+	}
+
+	for _, e := range events {
+		if err := storage.AppendEvent(e); err != nil {
+			t.Fatalf("AppendEvent failed: %v", err)
+		}
+
+		if e.GetTimestampUnixNano() == 0 {
+			t.Error("Expected AppendEvent to set the timestamp")
+		}
+	}
+
+	all, err := storage.ListEvents("", 0)
+	if err != nil {
+		t.Fatalf("ListEvents failed: %v", err)
+	}
+
+	if len(all) != len(events) || all[0].GetAction() != pb.EventAction_EVENT_ACTION_REMOVE {
+		t.Errorf("Expected %d events newest first, got %v", len(events), all)
+	}
+
+	a, _ := storage.ListEvents("github.com/test/a", 2)
+	if len(a) != 2 || a[1].GetErrorMessage() != "build failed" {
+		t.Errorf("Expected the 2 newest events of a, got %v", a)
+	}
+
+	for i := range maxEvents + 10 {
+		_ = storage.AppendEvent(&pb.EventProto{Name: "github.com/test/c", ToVersion: fmt.Sprintf("v0.0.%d", i)})
+	}
+
+	all, _ = storage.ListEvents("", 0)
+	if len(all) != maxEvents {
+		t.Errorf("Expected events capped at %d, got %d", maxEvents, len(all))
+	}
+
+	if v := all[0].GetToVersion(); v != fmt.Sprintf("v0.0.%d", maxEvents+9) {
+		t.Errorf("Expected the newest event to be kept, got %s", v)
+	}
+}
+
+func TestBackup(t *testing.T) {
+	storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	if err := storage.UpsertModule(&pb.ModuleProto{Name: "github.com/test/a", Version: "v1.0.0"}); err != nil {
+		t.Fatalf("UpsertModule failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "backup.bolt")
+	if err := storage.Backup(path); err != nil {
+		t.Fatalf("Backup failed: %v", err)
+	}
+
+	backup, err := NewStorage(path)
+	if err != nil {
+		t.Fatalf("Failed to open backup: %v", err)
+	}
+
+	defer func() {
+		_ = backup.Close()
+	}()
+
+	if m, err := backup.GetModule("github.com/test/a", ""); err != nil || m.GetVersion() != "v1.0.0" {
+		t.Errorf("Expected the module in the backup, got %v, %v", m, err)
+	}
+}
+
+func TestVulnReports(t *testing.T) {
+	storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	module := &pb.ModuleProto{Name: "github.com/test/tool", Version: "v1.0.0", TimestampUnixNano: time.Now().UnixNano()}
+	if err := storage.UpsertModule(module); err != nil {
+		t.Fatalf("UpsertModule failed: %v", err)
+	}
+
+	for _, report := range []*pb.VulnReportProto{
+		{Name: "github.com/test/tool", Version: "v1.0.0", Findings: []*pb.VulnFindingProto{{Id: "GO-2024-0001"}}},
+		{Name: "github.com/test/other", Version: "v0.1.0"},
+		// A new scan replaces the previous report
+		{Name: "github.com/test/tool", Version: "v1.0.0", Findings: []*pb.VulnFindingProto{{Id: "GO-2024-0002"}}},
+	} {
+		if err := storage.SaveVulnReport(report); err != nil {
+			t.Fatalf("SaveVulnReport failed: %v", err)
+		}
+	}
+
+	all, err := storage.ListVulnReports("")
+	if err != nil || len(all) != 2 || all[0].GetName() != "github.com/test/other" {
+		t.Fatalf("ListVulnReports() = %v, %v; want both reports by module", all, err)
+	}
+
+	tool, err := storage.ListVulnReports("github.com/test/tool")
+	if err != nil || len(tool) != 1 || tool[0].GetFindings()[0].GetId() != "GO-2024-0002" {
+		t.Fatalf("ListVulnReports(tool) = %v, %v; want the latest report", tool, err)
+	}
+
+	// Removing a module removes its report
+	if err := storage.DeleteModule("github.com/test/tool", ""); err != nil {
+		t.Fatalf("DeleteModule failed: %v", err)
+	}
+
+	if tool, err := storage.ListVulnReports("github.com/test/tool"); err != nil || len(tool) != 0 {
+		t.Errorf("ListVulnReports(tool) after delete = %v, %v; want none", tool, err)
+	}
+}
+
+func TestVersionCache(t *testing.T) {
+	storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	if entry, err := storage.GetVersionCache("github.com/test/tool"); err != nil || entry != nil {
+		t.Fatalf("GetVersionCache() on empty cache = %v, %v; want nil", entry, err)
+	}
+
+	for _, entry := range []*pb.VersionCacheProto{
+		{Path: "github.com/test/tool", Version: "v1.0.0", Versions: []string{"v1.0.0"}},
+		// A new answer replaces the previous one
+		{Path: "github.com/test/tool", RootModule: "github.com/test/tool", Version: "v1.1.0", Versions: []string{"v1.1.0", "v1.0.0"}, FetchedUnixNano: 42},
+	} {
+		if err := storage.SaveVersionCache(entry); err != nil {
+			t.Fatalf("SaveVersionCache failed: %v", err)
+		}
+	}
+
+	entry, err := storage.GetVersionCache("github.com/test/tool")
+	if err != nil || entry.GetVersion() != "v1.1.0" || !slices.Equal(entry.GetVersions(), []string{"v1.1.0", "v1.0.0"}) || entry.GetFetchedUnixNano() != 42 {
+		t.Errorf("GetVersionCache() = %v, %v; want the latest answer", entry, err)
+	}
+}
+
+func TestLibraryWatches(t *testing.T) {
+	storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	for _, watch := range []*pb.LibraryWatchProto{
+		{Path: "github.com/test/zlib", Version: "v1.0.0"},
+		{Path: "github.com/test/errors", Version: "v0.9.0"},
+		// Watching a library again replaces its entry
+		{Path: "github.com/test/errors", Version: "v0.9.1", AddedUnixNano: 42},
+	} {
+		if err := storage.SaveLibraryWatch(watch); err != nil {
+			t.Fatalf("SaveLibraryWatch failed: %v", err)
+		}
+	}
+
+	watches, err := storage.ListLibraryWatches()
+	if err != nil {
+		t.Fatalf("ListLibraryWatches failed: %v", err)
+	}
+
+	if len(watches) != 2 || watches[0].GetPath() != "github.com/test/errors" || watches[1].GetPath() != "github.com/test/zlib" {
+		t.Fatalf("ListLibraryWatches() = %v; want errors and zlib, ordered by path", watches)
+	}
+
+	if watches[0].GetVersion() != "v0.9.1" || watches[0].GetAddedUnixNano() != 42 {
+		t.Errorf("ListLibraryWatches()[0] = %v; want the latest entry", watches[0])
+	}
+
+	if err := storage.DeleteLibraryWatch("github.com/test/zlib"); err != nil {
+		t.Fatalf("DeleteLibraryWatch failed: %v", err)
+	}
+
+	if err := storage.DeleteLibraryWatch("github.com/test/zlib"); err == nil {
+		t.Error("DeleteLibraryWatch of an unwatched library succeeded")
+	}
+
+	if watches, _ := storage.ListLibraryWatches(); len(watches) != 1 {
+		t.Errorf("ListLibraryWatches() after delete = %v; want errors only", watches)
+	}
+}
+
+func TestTokens(t *testing.T) {
+	storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	for _, token := range []*pb.TokenProto{
+		{Name: "dashboard", Scope: "read", Hash: "aa"},
+		{Name: "ci", Scope: "read", Hash: "bb"},
+		// Creating a token again replaces it
+		{Name: "ci", Scope: "install", Hash: "cc"},
+	} {
+		if err := storage.SaveToken(token); err != nil {
+			t.Fatalf("SaveToken failed: %v", err)
+		}
+	}
+
+	tokens, err := storage.ListTokens()
+	if err != nil {
+		t.Fatalf("ListTokens failed: %v", err)
+	}
+
+	if len(tokens) != 2 || tokens[0].GetName() != "ci" || tokens[1].GetName() != "dashboard" {
+		t.Fatalf("ListTokens() = %v; want ci and dashboard, ordered by name", tokens)
+	}
+
+	if token, err := storage.GetToken("ci"); err != nil || token.GetHash() != "cc" {
+		t.Errorf("GetToken(ci) = %v, %v; want the latest token", token, err)
+	}
+
+	if token, err := storage.GetToken("missing"); err != nil || token != nil {
+		t.Errorf("GetToken(missing) = %v, %v; want nil", token, err)
+	}
+
+	if err := storage.TouchToken("ci", time.Unix(0, 42)); err != nil {
+		t.Fatalf("TouchToken failed: %v", err)
+	}
+
+	if token, _ := storage.GetToken("ci"); token.GetLastUsedUnixNano() != 42 || token.GetScope() != "install" {
+		t.Errorf("GetToken(ci) after TouchToken = %v; want last used 42", token)
+	}
+
+	if err := storage.DeleteToken("ci"); err != nil {
+		t.Fatalf("DeleteToken failed: %v", err)
+	}
+
+	// Recording the use of a revoked token does not restore it
+	if err := storage.TouchToken("ci", time.Now()); err != nil {
+		t.Fatalf("TouchToken of a revoked token failed: %v", err)
+	}
+
+	if err := storage.DeleteToken("ci"); err == nil {
+		t.Error("DeleteToken of a revoked token succeeded")
+	}
+
+	if tokens, _ := storage.ListTokens(); len(tokens) != 1 {
+		t.Errorf("ListTokens() after delete = %v; want dashboard only", tokens)
+	}
+}
+
+func TestAutoUpdateConfig(t *testing.T) {
+	storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	if cfg, err := storage.GetAutoUpdateConfig(); err != nil || cfg != nil {
+		t.Fatalf("GetAutoUpdateConfig() before any change = %v, %v; want nil", cfg, err)
+	}
+
+	// Concurrent changes are applied one after another
+	var wg sync.WaitGroup
+
+	for range 20 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := storage.UpdateAutoUpdateConfig(func(cfg *pb.AutoUpdateConfigProto) error {
+				cfg.CheckedCount++
+				return nil
+			}); err != nil {
+				t.Errorf("UpdateAutoUpdateConfig failed: %v", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// A failed change leaves the configuration alone
+	if _, err := storage.UpdateAutoUpdateConfig(func(cfg *pb.AutoUpdateConfigProto) error {
+		cfg.Enabled = true
+		return fmt.Errorf("invalid")
+	}); err == nil {
+		t.Error("UpdateAutoUpdateConfig with a failing change succeeded")
+	}
+
+	cfg, err := storage.GetAutoUpdateConfig()
+	if err != nil || cfg.GetCheckedCount() != 20 || cfg.GetEnabled() {
+		t.Errorf("GetAutoUpdateConfig() = %v, %v; want 20 checks, disabled", cfg, err)
+	}
+}
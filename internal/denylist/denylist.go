@@ -0,0 +1,408 @@
+// Package denylist tracks module versions that must not be installed, from a
+// local list and from shared remote catalogs.
+package denylist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/signing"
+)
+
+// SourceLocal marks entries added with 'glix denylist add'
+const SourceLocal = "local"
+
+// CatalogTTL is how long synced catalog entries are trusted before the CLI
+// syncs again on its own
+const CatalogTTL = 24 * time.Hour
+
+var httpClient = &http.Client{Timeout: 20 * time.Second}
+
+// Entry denies one version of a module
+type Entry struct {
+	Module  string `json:"module"`
+	Version string `json:"version"`
+	Reason  string `json:"reason,omitempty"`
+	Source  string `json:"source,omitempty"`
+}
+
+// String formats the entry as module@version
+func (e Entry) String() string {
+	return e.Module + "@" + e.Version
+}
+
+// Catalog is the document served by a shared catalog
+type Catalog struct {
+	Denied []Entry `json:"denied"`
+}
+
+// CatalogSource is a subscribed catalog. When PublicKey is set, the catalog
+// is only accepted with a valid detached signature from that key.
+type CatalogSource struct {
+	URL          string `json:"url"`
+	PublicKey    string `json:"public_key,omitempty"`    // Pinned minisign or cosign public key
+	SignatureURL string `json:"signature_url,omitempty"` // Defaults to URL plus .minisig or .sig
+}
+
+// UnmarshalJSON also accepts a bare URL, the format used before signed
+// catalogs were supported
+func (c *CatalogSource) UnmarshalJSON(data []byte) error {
+	var url string
+	if err := json.Unmarshal(data, &url); err == nil {
+		*c = CatalogSource{URL: url}
+		return nil
+	}
+
+	type plain CatalogSource
+
+	return json.Unmarshal(data, (*plain)(c))
+}
+
+// Signed reports whether the catalog is verified against a pinned key
+func (c CatalogSource) Signed() bool {
+	return c.PublicKey != ""
+}
+
+// state is the on-disk form of the store
+type state struct {
+	Entries  []Entry         `json:"entries"`
+	Catalogs []CatalogSource `json:"catalogs,omitempty"`
+	Remote   []Entry         `json:"remote,omitempty"`
+	Synced   time.Time       `json:"synced,omitzero"`
+}
+
+// denyStore handles persistent storage of the denylist
+type denyStore struct {
+	mu       sync.RWMutex
+	state    state
+	filePath string
+}
+
+var (
+	store     *denyStore
+	storeOnce sync.Once
+)
+
+// getStorePath returns the path to the denylist file
+func getStorePath() string {
+	configDir, err := module.GetApplicationConfigDirectory()
+	if err != nil {
+		// Fallback to cache directory
+		configDir, _ = module.GetApplicationCacheDirectory()
+	}
+
+	return filepath.Join(configDir, "denylist.json")
+}
+
+// GetStore returns the singleton denylist store
+func GetStore() *denyStore {
+	storeOnce.Do(func() {
+		store = &denyStore{
+			filePath: getStorePath(),
+		}
+		// Load existing entries if available
+		_ = store.load()
+	})
+
+	return store
+}
+
+// load reads the denylist from disk
+func (s *denyStore) load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to read denylist: %w", err)
+	}
+
+	var st state
+	if err := json.Unmarshal(data, &st); err != nil {
+		return fmt.Errorf("failed to parse denylist: %w", err)
+	}
+
+	s.state = st
+
+	return nil
+}
+
+// Reload re-reads the denylist from disk, picking up changes made by other
+// glix processes
+func (s *denyStore) Reload() error {
+	return s.load()
+}
+
+// save writes the denylist to disk
+func (s *denyStore) save() error {
+	dir := filepath.Dir(s.filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal denylist: %w", err)
+	}
+
+	if err := os.WriteFile(s.filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write denylist: %w", err)
+	}
+
+	return nil
+}
+
+// List returns local entries followed by entries from synced catalogs
+func (s *denyStore) List() []Entry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return slices.Concat(s.state.Entries, s.state.Remote)
+}
+
+// Add denies a version locally
+func (s *denyStore) Add(e Entry) error {
+	if e.Module == "" || e.Version == "" {
+		return errors.New("module and version are required")
+	}
+
+	e.Source = SourceLocal
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if slices.ContainsFunc(s.state.Entries, func(x Entry) bool { return x.Module == e.Module && x.Version == e.Version }) {
+		return fmt.Errorf("%s is already denied", e)
+	}
+
+	s.state.Entries = append(s.state.Entries, e)
+
+	return s.save()
+}
+
+// Remove deletes a local entry; catalog entries can only be removed upstream
+func (s *denyStore) Remove(moduleName, version string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := len(s.state.Entries)
+	s.state.Entries = slices.DeleteFunc(s.state.Entries, func(x Entry) bool {
+		return x.Module == moduleName && x.Version == version
+	})
+
+	if len(s.state.Entries) == n {
+		return fmt.Errorf("%s@%s is not on the local denylist", moduleName, version)
+	}
+
+	return s.save()
+}
+
+// Denied returns the entry denying a version of a module. Entries for a
+// module path also cover the packages below it.
+func (s *denyStore) Denied(moduleName, version string) (Entry, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, e := range slices.Concat(s.state.Entries, s.state.Remote) {
+		if e.Version == version && (moduleName == e.Module || strings.HasPrefix(moduleName, e.Module+"/")) {
+			return e, true
+		}
+	}
+
+	return Entry{}, false
+}
+
+// Catalogs returns the configured catalog sources
+func (s *denyStore) Catalogs() []CatalogSource {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return slices.Clone(s.state.Catalogs)
+}
+
+// LastSync returns when catalogs were last synced
+func (s *denyStore) LastSync() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.state.Synced
+}
+
+// AddCatalog subscribes to a catalog (an http(s) URL or a local file). A
+// public key, when given, must parse so bad pins are caught up front.
+func (s *denyStore) AddCatalog(c CatalogSource) error {
+	if c.Signed() {
+		if _, err := signing.ParseKey(c.PublicKey); err != nil {
+			return fmt.Errorf("invalid public key: %w", err)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if slices.ContainsFunc(s.state.Catalogs, func(x CatalogSource) bool { return x.URL == c.URL }) {
+		return fmt.Errorf("catalog already configured: %s", c.URL)
+	}
+
+	s.state.Catalogs = append(s.state.Catalogs, c)
+
+	return s.save()
+}
+
+// RemoveCatalog unsubscribes from a catalog and drops its cached entries
+func (s *denyStore) RemoveCatalog(url string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := len(s.state.Catalogs)
+	s.state.Catalogs = slices.DeleteFunc(s.state.Catalogs, func(c CatalogSource) bool { return c.URL == url })
+
+	if len(s.state.Catalogs) == n {
+		return fmt.Errorf("catalog not configured: %s", url)
+	}
+
+	s.state.Remote = slices.DeleteFunc(s.state.Remote, func(e Entry) bool { return e.Source == url })
+
+	return s.save()
+}
+
+// Sync fetches every configured catalog. A catalog that cannot be fetched
+// keeps its previously synced entries.
+func (s *denyStore) Sync(ctx context.Context) error {
+	catalogs := s.Catalogs()
+
+	fetched := make(map[string][]Entry, len(catalogs))
+
+	var errs []error
+
+	for _, c := range catalogs {
+		entries, err := fetchCatalog(ctx, c)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c.URL, err))
+			continue
+		}
+
+		fetched[c.URL] = entries
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	remote := slices.DeleteFunc(slices.Clone(s.state.Remote), func(e Entry) bool {
+		_, ok := fetched[e.Source]
+		return ok
+	})
+
+	for _, c := range catalogs {
+		remote = append(remote, fetched[c.URL]...)
+	}
+
+	s.state.Remote = remote
+	s.state.Synced = time.Now()
+
+	if err := s.save(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// SyncIfStale syncs catalogs when any are configured and the last sync is
+// older than CatalogTTL
+func (s *denyStore) SyncIfStale(ctx context.Context) error {
+	if len(s.Catalogs()) == 0 || time.Since(s.LastSync()) < CatalogTTL {
+		return nil
+	}
+
+	return s.Sync(ctx)
+}
+
+// fetchCatalog reads a catalog and, for signed catalogs, verifies its
+// detached signature before trusting any entry
+func fetchCatalog(ctx context.Context, c CatalogSource) ([]Entry, error) {
+	data, err := readSource(ctx, c.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Signed() {
+		key, err := signing.ParseKey(c.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key: %w", err)
+		}
+
+		sigURL := c.SignatureURL
+		if sigURL == "" {
+			sigURL = c.URL + key.SignatureSuffix()
+		}
+
+		sig, err := readSource(ctx, sigURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch signature: %w", err)
+		}
+
+		if err := key.Verify(data, sig); err != nil {
+			return nil, fmt.Errorf("rejected catalog: %w", err)
+		}
+	}
+
+	var catalog Catalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("invalid catalog: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(catalog.Denied))
+
+	for _, e := range catalog.Denied {
+		if e.Module == "" || e.Version == "" {
+			continue
+		}
+
+		e.Source = c.URL
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+// readSource reads an http(s) URL or a local file
+func readSource(ctx context.Context, source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return os.ReadFile(strings.TrimPrefix(source, "file://"))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch %s failed: %s", source, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
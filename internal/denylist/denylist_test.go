@@ -0,0 +1,196 @@
+package denylist
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTestStore(t *testing.T) *denyStore {
+	t.Helper()
+
+	return &denyStore{filePath: filepath.Join(t.TempDir(), "denylist.json")}
+}
+
+func TestDenied(t *testing.T) {
+	s := newTestStore(t)
+
+	if err := s.Add(Entry{Module: "github.com/example/tool", Version: "v1.2.0", Reason: "crashes"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Add(Entry{Module: "github.com/example/tool", Version: "v1.2.0"}); err == nil {
+		t.Error("duplicate entry should fail")
+	}
+
+	if e, ok := s.Denied("github.com/example/tool/cmd/tool", "v1.2.0"); !ok || e.Reason != "crashes" || e.Source != SourceLocal {
+		t.Errorf("Denied(package below module) = %+v, %v", e, ok)
+	}
+
+	if _, ok := s.Denied("github.com/example/toolbox", "v1.2.0"); ok {
+		t.Error("sibling module path must not match")
+	}
+
+	if _, ok := s.Denied("github.com/example/tool", "v1.2.1"); ok {
+		t.Error("other versions must not match")
+	}
+
+	if err := s.Remove("github.com/example/tool", "v1.2.0"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := s.Denied("github.com/example/tool", "v1.2.0"); ok {
+		t.Error("removed entry still denied")
+	}
+}
+
+func TestSync(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	fileCatalog := filepath.Join(t.TempDir(), "catalog.json")
+	if err := os.WriteFile(fileCatalog, []byte(`{"denied":[{"module":"example.com/a","version":"v1.0.0","reason":"regression"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	up := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !up {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"denied":[{"module":"example.com/b","version":"v2.0.0"}]}`))
+	}))
+	defer srv.Close()
+
+	for _, c := range []string{fileCatalog, srv.URL} {
+		if err := s.AddCatalog(CatalogSource{URL: c}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := s.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if e, ok := s.Denied("example.com/b", "v2.0.0"); !ok || e.Source != srv.URL {
+		t.Errorf("remote entry = %+v, %v", e, ok)
+	}
+
+	// A failing catalog keeps its previously synced entries
+	up = false
+
+	if err := s.Sync(ctx); err == nil {
+		t.Error("expected sync error")
+	}
+
+	if _, ok := s.Denied("example.com/b", "v2.0.0"); !ok {
+		t.Error("entries from unreachable catalog were dropped")
+	}
+
+	if _, ok := s.Denied("example.com/a", "v1.0.0"); !ok {
+		t.Error("file catalog entry missing")
+	}
+
+	if err := s.RemoveCatalog(srv.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := s.Denied("example.com/b", "v2.0.0"); ok {
+		t.Error("entries of removed catalog still denied")
+	}
+}
+
+func TestSync_Signed(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pubKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	dir := t.TempDir()
+	catalog := filepath.Join(dir, "catalog.json")
+	data := []byte(`{"denied":[{"module":"example.com/a","version":"v1.0.0"}]}`)
+
+	digest := sha256.Sum256(data)
+
+	sig, err := ecdsa.SignASN1(rand.Reader, priv, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range map[string][]byte{
+		"catalog.json":     data,
+		"catalog.json.sig": []byte(base64.StdEncoding.EncodeToString(sig)),
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := s.AddCatalog(CatalogSource{URL: catalog, PublicKey: "not a key"}); err == nil {
+		t.Error("invalid public key should be rejected")
+	}
+
+	if err := s.AddCatalog(CatalogSource{URL: catalog, PublicKey: pubKey}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := s.Denied("example.com/a", "v1.0.0"); !ok {
+		t.Error("signed catalog entry missing")
+	}
+
+	// A tampered catalog is rejected and the verified entries are kept
+	if err := os.WriteFile(catalog, []byte(`{"denied":[{"module":"example.com/b","version":"v1.0.0"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Sync(ctx); err == nil {
+		t.Error("expected signature error")
+	}
+
+	if _, ok := s.Denied("example.com/b", "v1.0.0"); ok {
+		t.Error("entry from tampered catalog was accepted")
+	}
+
+	if _, ok := s.Denied("example.com/a", "v1.0.0"); !ok {
+		t.Error("verified entries were dropped")
+	}
+}
+
+func TestCatalogSource_LegacyFormat(t *testing.T) {
+	var st state
+	if err := json.Unmarshal([]byte(`{"catalogs":["https://example.com/a.json",{"url":"b.json","public_key":"k"}]}`), &st); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(st.Catalogs) != 2 || st.Catalogs[0].URL != "https://example.com/a.json" || st.Catalogs[0].Signed() ||
+		st.Catalogs[1].URL != "b.json" || !st.Catalogs[1].Signed() {
+		t.Errorf("catalogs = %+v", st.Catalogs)
+	}
+}
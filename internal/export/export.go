@@ -0,0 +1,133 @@
+// Package export writes tables of the glix database as CSV or Parquet, so
+// install data can be analyzed without reading the Bolt database.
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Export formats
+const (
+	FormatCSV     = "csv"
+	FormatParquet = "parquet"
+)
+
+// Formats lists the supported export formats
+var Formats = []string{FormatCSV, FormatParquet}
+
+// Kind is the type of the values of a column
+type Kind int
+
+const (
+	String Kind = iota
+	Int
+	Bool
+	Time
+)
+
+// Column is a named, typed column of a table
+type Column struct {
+	Name string
+	Kind Kind
+}
+
+// Table is a flat table of rows. Values are string, int64, bool or
+// time.Time according to the kind of their column, or nil for null.
+type Table struct {
+	Name    string
+	Columns []Column
+	Rows    [][]any
+}
+
+// NewTable returns an empty table with columns
+func NewTable(name string, columns ...Column) *Table {
+	return &Table{Name: name, Columns: columns}
+}
+
+// Add appends a row, one value per column. Zero times are stored as null.
+func (t *Table) Add(values ...any) {
+	row := make([]any, len(t.Columns))
+
+	for i, v := range values {
+		if ts, ok := v.(time.Time); ok && ts.IsZero() {
+			v = nil
+		}
+
+		if i < len(row) {
+			row[i] = v
+		}
+	}
+
+	t.Rows = append(t.Rows, row)
+}
+
+// Write writes the table to w in format
+func Write(w io.Writer, t *Table, format string) error {
+	switch format {
+	case FormatCSV:
+		return WriteCSV(w, t)
+	case FormatParquet:
+		return WriteParquet(w, t)
+	}
+
+	return fmt.Errorf("unknown export format %q (want one of %v)", format, Formats)
+}
+
+// ValidFormat reports whether format is supported
+func ValidFormat(format string) bool {
+	return slices.Contains(Formats, format)
+}
+
+// WriteCSV writes the table as CSV with a header row. Times are RFC 3339 in
+// UTC and nulls are empty.
+func WriteCSV(w io.Writer, t *Table) error {
+	cw := csv.NewWriter(w)
+
+	header := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		header[i] = c.Name
+	}
+
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(t.Columns))
+
+	for _, row := range t.Rows {
+		for i, v := range row {
+			record[i] = formatValue(v)
+		}
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// formatValue formats a value for CSV
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	}
+
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,244 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func testTable() *Table {
+	t := NewTable("modules",
+		Column{Name: "name", Kind: String},
+		Column{Name: "size", Kind: Int},
+		Column{Name: "local", Kind: Bool},
+		Column{Name: "installed", Kind: Time},
+	)
+
+	t.Add("example.com/tool", int64(42), true, time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	t.Add("example.com/a,b", nil, false, time.Time{})
+	t.Add(nil, int64(-7), nil, time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC))
+
+	return t
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, testTable(), FormatCSV); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	want := `name,size,local,installed
+example.com/tool,42,true,2025-03-01T12:00:00Z
+"example.com/a,b",,false,
+,-7,,2024-01-02T03:04:05.000006Z
+`
+	if buf.String() != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, testTable(), "xlsx"); err == nil {
+		t.Error("Write succeeded for an unknown format")
+	}
+}
+
+func TestWriteParquet(t *testing.T) {
+	table := testTable()
+
+	var buf bytes.Buffer
+	if err := WriteParquet(&buf, table); err != nil {
+		t.Fatalf("WriteParquet: %v", err)
+	}
+
+	data := buf.Bytes()
+	if !bytes.HasPrefix(data, []byte(parquetMagic)) || !bytes.HasSuffix(data, []byte(parquetMagic)) {
+		t.Fatal("missing PAR1 magic")
+	}
+
+	size := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footer := data[len(data)-8-size : len(data)-8]
+
+	r := &thriftReader{data: footer}
+	meta := r.readStruct()
+
+	if r.pos != len(footer) {
+		t.Errorf("footer decoded %d of %d bytes", r.pos, len(footer))
+	}
+
+	if meta[3] != int64(3) {
+		t.Errorf("num_rows = %v, want 3", meta[3])
+	}
+
+	schema := meta[2].([]any)
+	if len(schema) != 5 || string(schema[0].(fields)[4].([]byte)) != "schema" || schema[0].(fields)[5] != int64(4) {
+		t.Fatalf("schema = %v, want a root with 4 columns", schema)
+	}
+
+	for i, col := range table.Columns {
+		el := schema[i+1].(fields)
+		typ, _ := physicalType(col.Kind)
+
+		if string(el[4].([]byte)) != col.Name || el[1] != int64(typ) || el[3] != int64(repetitionOptional) {
+			t.Errorf("schema element %d = %v", i+1, el)
+		}
+	}
+
+	group := meta[4].([]any)[0].(fields)
+	chunks := group[1].([]any)
+
+	if len(chunks) != 4 || group[3] != int64(3) {
+		t.Fatalf("row group = %v", group)
+	}
+
+	// Decode the pages back into the values of each column
+	for i, col := range table.Columns {
+		cm := chunks[i].(fields)[3].(fields)
+
+		page := &thriftReader{data: data, pos: int(cm[9].(int64))}
+		header := page.readStruct()
+
+		if header[5].(fields)[1] != int64(3) {
+			t.Fatalf("column %s: page header = %v", col.Name, header)
+		}
+
+		if int64(page.pos-int(cm[9].(int64)))+header[3].(int64) != cm[7].(int64) {
+			t.Errorf("column %s: chunk size %v does not match its page", col.Name, cm[7])
+		}
+
+		got := decodePage(t, data[page.pos:page.pos+int(header[3].(int64))], col.Kind, len(table.Rows))
+
+		for r, row := range table.Rows {
+			want := row[i]
+			if ts, ok := want.(time.Time); ok {
+				want = ts.UnixMicro()
+			}
+
+			if got[r] != want {
+				t.Errorf("column %s row %d = %v, want %v", col.Name, r, got[r], want)
+			}
+		}
+	}
+}
+
+// decodePage decodes a data page of n optional PLAIN encoded values
+func decodePage(t *testing.T, page []byte, kind Kind, n int) []any {
+	t.Helper()
+
+	levelsSize := int(binary.LittleEndian.Uint32(page))
+	levels := page[4 : 4+levelsSize]
+	values := page[4+levelsSize:]
+
+	header, k := binary.Uvarint(levels)
+	if header&1 != 1 {
+		t.Fatalf("definition levels are not bit-packed: %x", levels)
+	}
+
+	defined := levels[k:]
+	out := make([]any, n)
+	bit := 0
+
+	for i := range n {
+		if defined[i/8]&(1<<(i%8)) == 0 {
+			continue
+		}
+
+		switch kind {
+		case String:
+			l := int(binary.LittleEndian.Uint32(values))
+			out[i] = string(values[4 : 4+l])
+			values = values[4+l:]
+		case Int, Time:
+			out[i] = int64(binary.LittleEndian.Uint64(values))
+			values = values[8:]
+		case Bool:
+			out[i] = values[bit/8]&(1<<(bit%8)) != 0
+			bit++
+		}
+	}
+
+	return out
+}
+
+// fields are the decoded fields of a thrift struct by id
+type fields map[int16]any
+
+// thriftReader decodes the thrift compact protocol into fields, []any,
+// int64 and []byte values
+type thriftReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	r.pos += n
+
+	return v
+}
+
+func (r *thriftReader) varint() int64 {
+	u := r.uvarint()
+	return int64(u>>1) ^ -int64(u&1)
+}
+
+func (r *thriftReader) readValue(typ byte) any {
+	switch typ {
+	case 1, 2:
+		return typ == 1
+	case thriftI32, thriftI64, 4:
+		return r.varint()
+	case thriftBinary:
+		n := int(r.uvarint())
+		v := r.data[r.pos : r.pos+n]
+		r.pos += n
+
+		return v
+	case thriftList:
+		header := r.data[r.pos]
+		r.pos++
+
+		n, elem := int(header>>4), header&0x0f
+		if n == 15 {
+			n = int(r.uvarint())
+		}
+
+		list := make([]any, n)
+		for i := range list {
+			list[i] = r.readValue(elem)
+		}
+
+		return list
+	case thriftStruct:
+		return r.readStruct()
+	}
+
+	panic(fmt.Sprintf("unsupported thrift type %d", typ))
+}
+
+func (r *thriftReader) readStruct() fields {
+	f := fields{}
+
+	var last int16
+
+	for {
+		header := r.data[r.pos]
+		r.pos++
+
+		if header == 0 {
+			return f
+		}
+
+		typ := header & 0x0f
+
+		id := last + int16(header>>4)
+		if header>>4 == 0 {
+			id = int16(r.varint())
+		}
+
+		f[id] = r.readValue(typ)
+		last = id
+	}
+}
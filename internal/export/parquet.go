@@ -0,0 +1,374 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+)
+
+// The subset of the Parquet format the writer uses: one row group of
+// optional, PLAIN encoded, uncompressed columns with one data page each.
+// See https://github.com/apache/parquet-format.
+const (
+	parquetMagic = "PAR1"
+
+	// Physical types
+	typeBoolean   = 0
+	typeInt64     = 2
+	typeByteArray = 6
+
+	// Converted types
+	convertedUTF8            = 0
+	convertedTimestampMicros = 10
+
+	repetitionOptional = 1
+	encodingPlain      = 0
+	encodingRLE        = 3
+	codecUncompressed  = 0
+	pageTypeData       = 0
+)
+
+// Thrift compact protocol types
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// physicalType returns the Parquet type and converted type, -1 for none, a
+// column is stored as
+func physicalType(k Kind) (int32, int32) {
+	switch k {
+	case Int:
+		return typeInt64, -1
+	case Bool:
+		return typeBoolean, -1
+	case Time:
+		return typeInt64, convertedTimestampMicros
+	}
+
+	return typeByteArray, convertedUTF8
+}
+
+// columnChunk locates a written column in the file
+type columnChunk struct {
+	offset    int64
+	size      int64
+	numValues int64
+}
+
+// WriteParquet writes the table as a Parquet file with a single row group.
+// Times are stored as UTC timestamps in microseconds.
+func WriteParquet(w io.Writer, t *Table) error {
+	var file bytes.Buffer
+
+	file.WriteString(parquetMagic)
+
+	chunks := make([]columnChunk, len(t.Columns))
+
+	for i := range t.Columns {
+		data, err := encodeColumn(t, i)
+		if err != nil {
+			return err
+		}
+
+		header := pageHeader(len(t.Rows), len(data))
+
+		chunks[i] = columnChunk{
+			offset:    int64(file.Len()),
+			size:      int64(len(header) + len(data)),
+			numValues: int64(len(t.Rows)),
+		}
+
+		file.Write(header)
+		file.Write(data)
+	}
+
+	footer := fileMetaData(t, chunks)
+	file.Write(footer)
+
+	if err := binary.Write(&file, binary.LittleEndian, uint32(len(footer))); err != nil {
+		return err
+	}
+
+	file.WriteString(parquetMagic)
+
+	_, err := w.Write(file.Bytes())
+
+	return err
+}
+
+// encodeColumn returns the data page of column i: the definition levels,
+// 1 for values and 0 for nulls, then the PLAIN encoded values
+func encodeColumn(t *Table, i int) ([]byte, error) {
+	col := t.Columns[i]
+
+	levels := make([]bool, len(t.Rows))
+
+	var values bytes.Buffer
+
+	var bits []bool
+
+	for r, row := range t.Rows {
+		v := row[i]
+		if v == nil {
+			continue
+		}
+
+		levels[r] = true
+
+		switch col.Kind {
+		case String:
+			s, ok := v.(string)
+			if !ok {
+				return nil, typeError(t, col, v)
+			}
+
+			_ = binary.Write(&values, binary.LittleEndian, uint32(len(s)))
+			values.WriteString(s)
+		case Int:
+			n, ok := v.(int64)
+			if !ok {
+				return nil, typeError(t, col, v)
+			}
+
+			_ = binary.Write(&values, binary.LittleEndian, n)
+		case Time:
+			ts, ok := v.(time.Time)
+			if !ok {
+				return nil, typeError(t, col, v)
+			}
+
+			_ = binary.Write(&values, binary.LittleEndian, ts.UnixMicro())
+		case Bool:
+			b, ok := v.(bool)
+			if !ok {
+				return nil, typeError(t, col, v)
+			}
+
+			bits = append(bits, b)
+		}
+	}
+
+	if col.Kind == Bool {
+		values.Write(packBits(bits))
+	}
+
+	var page bytes.Buffer
+
+	encoded := encodeLevels(levels)
+	_ = binary.Write(&page, binary.LittleEndian, uint32(len(encoded)))
+	page.Write(encoded)
+	page.Write(values.Bytes())
+
+	return page.Bytes(), nil
+}
+
+func typeError(t *Table, col Column, v any) error {
+	return fmt.Errorf("table %s: column %s: unexpected value %v of type %T", t.Name, col.Name, v, v)
+}
+
+// encodeLevels encodes definition levels of bit width 1 with the
+// RLE/bit-packing hybrid, as a single bit-packed run
+func encodeLevels(levels []bool) []byte {
+	if len(levels) == 0 {
+		return nil
+	}
+
+	groups := (len(levels) + 7) / 8
+	buf := binary.AppendUvarint(nil, uint64(groups)<<1|1)
+
+	return append(buf, packBits(levels)...)
+}
+
+// packBits packs booleans into bytes, least significant bit first
+func packBits(bits []bool) []byte {
+	packed := make([]byte, (len(bits)+7)/8)
+
+	for i, b := range bits {
+		if b {
+			packed[i/8] |= 1 << (i % 8)
+		}
+	}
+
+	return packed
+}
+
+// pageHeader returns the thrift PageHeader of a data page
+func pageHeader(numValues, size int) []byte {
+	var w thriftWriter
+
+	w.i32(1, pageTypeData)
+	w.i32(2, int32(size))
+	w.i32(3, int32(size))
+	w.beginStruct(5)
+	w.i32(1, int32(numValues))
+	w.i32(2, encodingPlain)
+	w.i32(3, encodingRLE)
+	w.i32(4, encodingRLE)
+	w.endStruct()
+	w.stop()
+
+	return w.buf.Bytes()
+}
+
+// fileMetaData returns the thrift FileMetaData of the file
+func fileMetaData(t *Table, chunks []columnChunk) []byte {
+	var w thriftWriter
+
+	w.i32(1, 1)
+
+	// The schema is a root group followed by the columns
+	w.list(2, thriftStruct, len(t.Columns)+1)
+	w.beginElement()
+	w.binary(4, "schema")
+	w.i32(5, int32(len(t.Columns)))
+	w.endStruct()
+
+	for _, col := range t.Columns {
+		typ, converted := physicalType(col.Kind)
+
+		w.beginElement()
+		w.i32(1, typ)
+		w.i32(3, repetitionOptional)
+		w.binary(4, col.Name)
+
+		if converted >= 0 {
+			w.i32(6, converted)
+		}
+
+		w.endStruct()
+	}
+
+	w.i64(3, int64(len(t.Rows)))
+
+	var total int64
+	for _, c := range chunks {
+		total += c.size
+	}
+
+	w.list(4, thriftStruct, 1)
+	w.beginElement()
+	w.list(1, thriftStruct, len(chunks))
+
+	for i, c := range chunks {
+		typ, _ := physicalType(t.Columns[i].Kind)
+
+		w.beginElement()
+		w.i64(2, c.offset)
+		w.beginStruct(3)
+		w.i32(1, typ)
+		w.list(2, thriftI32, 2)
+		w.varint(encodingPlain)
+		w.varint(encodingRLE)
+		w.list(3, thriftBinary, 1)
+		w.str(t.Columns[i].Name)
+		w.i32(4, codecUncompressed)
+		w.i64(5, c.numValues)
+		w.i64(6, c.size)
+		w.i64(7, c.size)
+		w.i64(9, c.offset)
+		w.endStruct()
+		w.endStruct()
+	}
+
+	w.i64(2, total)
+	w.i64(3, int64(len(t.Rows)))
+	w.endStruct()
+
+	w.binary(6, "glix")
+	w.stop()
+
+	return w.buf.Bytes()
+}
+
+// thriftWriter encodes structs with the thrift compact protocol, tracking
+// the last field id of each nested struct for the delta encoded headers
+type thriftWriter struct {
+	buf  bytes.Buffer
+	last []int16
+}
+
+func (w *thriftWriter) lastID() int16 {
+	if len(w.last) == 0 {
+		w.last = []int16{0}
+	}
+
+	return w.last[len(w.last)-1]
+}
+
+func (w *thriftWriter) field(id int16, typ byte) {
+	if delta := id - w.lastID(); delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.varint(int64(id))
+	}
+
+	w.last[len(w.last)-1] = id
+}
+
+// varint writes a zigzag encoded varint, as i16, i32 and i64 values are
+func (w *thriftWriter) varint(v int64) {
+	w.buf.Write(binary.AppendUvarint(nil, uint64(v<<1)^uint64(v>>63)))
+}
+
+func (w *thriftWriter) i32(id int16, v int32) {
+	w.field(id, thriftI32)
+	w.varint(int64(v))
+}
+
+func (w *thriftWriter) i64(id int16, v int64) {
+	w.field(id, thriftI64)
+	w.varint(v)
+}
+
+func (w *thriftWriter) binary(id int16, s string) {
+	w.field(id, thriftBinary)
+	w.str(s)
+}
+
+// str writes a string with its length, a field value or list element
+func (w *thriftWriter) str(s string) {
+	w.buf.Write(binary.AppendUvarint(nil, uint64(len(s))))
+	w.buf.WriteString(s)
+}
+
+func (w *thriftWriter) list(id int16, elem byte, n int) {
+	w.field(id, thriftList)
+
+	if n < 15 {
+		w.buf.WriteByte(byte(n)<<4 | elem)
+		return
+	}
+
+	w.buf.WriteByte(0xf0 | elem)
+	w.buf.Write(binary.AppendUvarint(nil, uint64(n)))
+}
+
+// beginStruct starts a struct field
+func (w *thriftWriter) beginStruct(id int16) {
+	w.field(id, thriftStruct)
+	w.beginElement()
+}
+
+// beginElement starts a struct element of a list
+func (w *thriftWriter) beginElement() {
+	w.lastID()
+	w.last = append(w.last, 0)
+}
+
+// endStruct ends a struct started with beginStruct or beginElement
+func (w *thriftWriter) endStruct() {
+	w.stop()
+	w.last = w.last[:len(w.last)-1]
+}
+
+// stop ends the fields of the current struct
+func (w *thriftWriter) stop() {
+	w.buf.WriteByte(0)
+}
@@ -0,0 +1,252 @@
+// Package fleet implements fleet mode: workstation daemons periodically push
+// their module inventory to a central glix server, which aggregates them.
+package fleet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/transport"
+)
+
+// DefaultInterval is the default interval between inventory reports
+const DefaultInterval = time.Hour
+
+// MinInterval is the shortest allowed interval between reports
+const MinInterval = 5 * time.Minute
+
+// Config holds fleet reporting configuration
+type Config struct {
+	ReportTo   string        `json:"report_to,omitempty"` // Central server address (host:port); empty disables reporting
+	Interval   time.Duration `json:"interval"`
+	Host       string        `json:"host,omitempty"` // Name reported for this machine; defaults to the hostname
+	TLS        bool          `json:"tls,omitempty"`
+	CAFile     string        `json:"ca_file,omitempty"`
+	ServerName string        `json:"server_name,omitempty"`
+	TokenFile  string        `json:"token_file,omitempty"` // File holding the API token for the central server
+	LastReport time.Time     `json:"last_report"`
+	LastError  string        `json:"last_error,omitempty"`
+}
+
+// HostName returns the name this machine reports as
+func (c Config) HostName() string {
+	if c.Host != "" {
+		return c.Host
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "unknown"
+	}
+
+	return hostname
+}
+
+// Transport returns the TLS settings for connecting to the central server
+func (c Config) Transport() transport.TLS {
+	return transport.TLS{
+		Enabled:    c.TLS,
+		CAFile:     c.CAFile,
+		ServerName: c.ServerName,
+	}
+}
+
+// Token returns the API token sent to the central server, or "" when no
+// token file is configured
+func (c Config) Token() (string, error) {
+	if c.TokenFile == "" {
+		return "", nil
+	}
+
+	data, err := os.ReadFile(c.TokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read fleet token file: %w", err)
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// configStore handles persistent storage of fleet configuration
+type configStore struct {
+	mu       sync.RWMutex
+	config   Config
+	filePath string
+}
+
+var (
+	store     *configStore
+	storeOnce sync.Once
+)
+
+// getConfigPath returns the path to the fleet config file
+func getConfigPath() string {
+	configDir, err := module.GetApplicationConfigDirectory()
+	if err != nil {
+		// Fallback to cache directory
+		configDir, _ = module.GetApplicationCacheDirectory()
+	}
+
+	return filepath.Join(configDir, "fleet.json")
+}
+
+// GetStore returns the singleton config store
+func GetStore() *configStore {
+	storeOnce.Do(func() {
+		store = &configStore{
+			filePath: getConfigPath(),
+			config: Config{
+				Interval: DefaultInterval,
+			},
+		}
+		// Load existing config if available
+		_ = store.load()
+	})
+
+	return store
+}
+
+// load reads the configuration from disk
+func (s *configStore) load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil // Use defaults
+		}
+
+		return fmt.Errorf("failed to read fleet config: %w", err)
+	}
+
+	cfg := Config{Interval: DefaultInterval}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("failed to parse fleet config: %w", err)
+	}
+
+	s.config = cfg
+
+	return nil
+}
+
+// Reload re-reads the configuration from disk, picking up changes made by
+// other glix processes
+func (s *configStore) Reload() error {
+	return s.load()
+}
+
+// save writes the configuration to disk
+func (s *configStore) save() error {
+	dir := filepath.Dir(s.filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s.config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal fleet config: %w", err)
+	}
+
+	if err := os.WriteFile(s.filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write fleet config: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns a copy of the current configuration
+func (s *configStore) Get() Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.config
+}
+
+// SetReportTo sets the central server address; an empty address disables
+// reporting
+func (s *configStore) SetReportTo(address string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.config.ReportTo = address
+	s.config.LastReport = time.Time{}
+	s.config.LastError = ""
+
+	return s.save()
+}
+
+// SetInterval sets the interval between reports
+func (s *configStore) SetInterval(interval time.Duration) error {
+	if interval < MinInterval {
+		return fmt.Errorf("interval must be at least %s", MinInterval)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.config.Interval = interval
+
+	return s.save()
+}
+
+// SetHost overrides the name this machine reports as
+func (s *configStore) SetHost(host string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.config.Host = host
+
+	return s.save()
+}
+
+// SetTransport sets how the central server is reached. A token file requires
+// TLS since tokens are never sent in plaintext.
+func (s *configStore) SetTransport(t transport.TLS, tokenFile string) error {
+	if tokenFile != "" && !t.Enabled {
+		return fmt.Errorf("a token file needs TLS: API tokens are only sent over TLS")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.config.TLS = t.Enabled
+	s.config.CAFile = t.CAFile
+	s.config.ServerName = t.ServerName
+	s.config.TokenFile = tokenFile
+
+	return s.save()
+}
+
+// RecordReport records the outcome of a report
+func (s *configStore) RecordReport(reportErr error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.config.LastReport = time.Now()
+	s.config.LastError = ""
+
+	if reportErr != nil {
+		s.config.LastError = reportErr.Error()
+	}
+
+	return s.save()
+}
+
+// ShouldReport returns true if reporting is enabled and the interval has
+// passed since the last report
+func (s *configStore) ShouldReport() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.config.ReportTo == "" {
+		return false
+	}
+
+	return time.Since(s.config.LastReport) >= s.config.Interval
+}
@@ -0,0 +1,76 @@
+package fleet
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/inovacc/glix/internal/transport"
+)
+
+func TestShouldReport(t *testing.T) {
+	s := &configStore{
+		filePath: filepath.Join(t.TempDir(), "fleet.json"),
+		config:   Config{Interval: DefaultInterval},
+	}
+
+	if s.ShouldReport() {
+		t.Error("reporting must be disabled without report-to")
+	}
+
+	if err := s.SetReportTo("fleet.example.com:9742"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !s.ShouldReport() {
+		t.Error("first report should be due immediately")
+	}
+
+	if err := s.RecordReport(errors.New("connection refused")); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.ShouldReport() || s.Get().LastError != "connection refused" {
+		t.Errorf("after report: due=%v, config=%+v", s.ShouldReport(), s.Get())
+	}
+
+	if err := s.SetInterval(time.Minute); err == nil {
+		t.Error("interval below the minimum should be rejected")
+	}
+
+	if err := s.Reload(); err != nil || s.Get().ReportTo != "fleet.example.com:9742" {
+		t.Errorf("Reload = %v, config=%+v", err, s.Get())
+	}
+}
+
+func TestSetTransport(t *testing.T) {
+	dir := t.TempDir()
+	s := &configStore{
+		filePath: filepath.Join(dir, "fleet.json"),
+		config:   Config{Interval: DefaultInterval},
+	}
+
+	tokenFile := filepath.Join(dir, "fleet.token")
+	if err := os.WriteFile(tokenFile, []byte("glix_secret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.SetTransport(transport.TLS{}, tokenFile); err == nil {
+		t.Error("a token file without TLS should be rejected")
+	}
+
+	if err := s.SetTransport(transport.TLS{Enabled: true, ServerName: "fleet.example.com"}, tokenFile); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := s.Get()
+	if !cfg.Transport().Enabled || cfg.Transport().ServerName != "fleet.example.com" {
+		t.Errorf("Transport() = %+v", cfg.Transport())
+	}
+
+	if token, err := cfg.Token(); err != nil || token != "glix_secret" {
+		t.Errorf("Token() = %q, %v", token, err)
+	}
+}
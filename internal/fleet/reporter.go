@@ -0,0 +1,203 @@
+package fleet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/inovacc/glix/internal/transport"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"google.golang.org/grpc"
+)
+
+// pushTimeout bounds a single report to the central server
+const pushTimeout = 30 * time.Second
+
+// InventorySource returns the modules installed on this machine
+type InventorySource func() ([]*pb.ModuleProto, error)
+
+// NewInventory builds the report for this machine
+func NewInventory(host string, modules []*pb.ModuleProto) *pb.InventoryProto {
+	return &pb.InventoryProto{
+		Host:             host,
+		Goos:             runtime.GOOS,
+		Goarch:           runtime.GOARCH,
+		ReportedUnixNano: time.Now().UnixNano(),
+		Modules:          modules,
+	}
+}
+
+// Push sends an inventory to the central server configured in cfg, over TLS
+// and with its API token when configured
+func Push(ctx context.Context, cfg Config, inventory *pb.InventoryProto) error {
+	ctx, cancel := context.WithTimeout(ctx, pushTimeout)
+	defer cancel()
+
+	creds, err := transport.ClientCredentials(cfg.Transport())
+	if err != nil {
+		return err
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithBlock(),
+	}
+
+	token, err := cfg.Token()
+	if err != nil {
+		return err
+	}
+
+	if token != "" {
+		if !cfg.TLS {
+			return fmt.Errorf("API tokens are only sent over TLS; configure the fleet server with --tls")
+		}
+
+		opts = append(opts, grpc.WithPerRPCCredentials(transport.TokenCredentials(token)))
+	}
+
+	conn, err := grpc.DialContext(ctx, cfg.ReportTo, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to fleet server at %s: %w", cfg.ReportTo, err)
+	}
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	resp, err := pb.NewGlixServiceClient(conn).AggregateInventory(ctx, &pb.AggregateInventoryRequest{
+		Inventory: inventory,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to report inventory: %w", err)
+	}
+
+	if !resp.GetSuccess() {
+		return errors.New(resp.GetErrorMessage())
+	}
+
+	return nil
+}
+
+// Reporter periodically pushes this machine's inventory to the configured
+// central server
+type Reporter struct {
+	logger    *slog.Logger
+	store     *configStore
+	inventory InventorySource
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	mu        sync.Mutex
+	running   bool
+}
+
+// NewReporter creates a reporter that reads modules from inventory
+func NewReporter(logger *slog.Logger, inventory InventorySource) *Reporter {
+	if logger == nil {
+		logger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+			Level: slog.LevelInfo,
+		}))
+	}
+
+	return &Reporter{
+		logger:    logger,
+		store:     GetStore(),
+		inventory: inventory,
+	}
+}
+
+// Start begins periodic reporting
+func (r *Reporter) Start(ctx context.Context) {
+	r.mu.Lock()
+
+	if r.running {
+		r.mu.Unlock()
+		return
+	}
+
+	ctx, r.cancel = context.WithCancel(ctx)
+	r.running = true
+	r.mu.Unlock()
+
+	r.wg.Add(1)
+
+	go r.run(ctx)
+}
+
+// Stop stops periodic reporting
+func (r *Reporter) Stop() {
+	r.mu.Lock()
+
+	if !r.running {
+		r.mu.Unlock()
+		return
+	}
+
+	if r.cancel != nil {
+		r.cancel()
+	}
+
+	r.running = false
+	r.mu.Unlock()
+
+	r.wg.Wait()
+}
+
+// run is the main reporter loop
+func (r *Reporter) run(ctx context.Context) {
+	defer r.wg.Done()
+
+	r.reportIfDue(ctx)
+
+	ticker := time.NewTicker(time.Minute) // Check every minute if it's time
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.reportIfDue(ctx)
+		}
+	}
+}
+
+// reportIfDue pushes the inventory when reporting is enabled and due. The
+// config is reloaded first so 'glix fleet report-to' applies without a
+// daemon restart.
+func (r *Reporter) reportIfDue(ctx context.Context) {
+	if err := r.store.Reload(); err != nil {
+		r.logger.Error("failed to reload fleet config", "error", err)
+	}
+
+	if !r.store.ShouldReport() {
+		return
+	}
+
+	cfg := r.store.Get()
+
+	err := r.report(ctx, cfg)
+	if err != nil {
+		r.logger.Error("fleet report failed", "server", cfg.ReportTo, "error", err)
+	} else {
+		r.logger.Info("fleet inventory reported", "server", cfg.ReportTo, "host", cfg.HostName())
+	}
+
+	if err := r.store.RecordReport(err); err != nil {
+		r.logger.Error("failed to record fleet report", "error", err)
+	}
+}
+
+func (r *Reporter) report(ctx context.Context, cfg Config) error {
+	modules, err := r.inventory()
+	if err != nil {
+		return fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	return Push(ctx, cfg, NewInventory(cfg.HostName(), modules))
+}
@@ -0,0 +1,232 @@
+// Package hold tracks modules whose updates are temporarily suppressed.
+package hold
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/inovacc/glix/internal/module"
+)
+
+// Hold suppresses updates for a module until a point in time
+type Hold struct {
+	Module  string    `json:"module"`
+	Until   time.Time `json:"until"`
+	Reason  string    `json:"reason,omitempty"`
+	Created time.Time `json:"created"`
+}
+
+// Active reports whether the hold still applies at now
+func (h Hold) Active(now time.Time) bool {
+	return now.Before(h.Until)
+}
+
+// holdStore handles persistent storage of holds
+type holdStore struct {
+	mu       sync.RWMutex
+	holds    map[string]Hold
+	filePath string
+}
+
+var (
+	store     *holdStore
+	storeOnce sync.Once
+)
+
+// getStorePath returns the path to the holds file
+func getStorePath() string {
+	configDir, err := module.GetApplicationConfigDirectory()
+	if err != nil {
+		// Fallback to cache directory
+		configDir, _ = module.GetApplicationCacheDirectory()
+	}
+
+	return filepath.Join(configDir, "holds.json")
+}
+
+// GetStore returns the singleton hold store
+func GetStore() *holdStore {
+	storeOnce.Do(func() {
+		store = &holdStore{
+			filePath: getStorePath(),
+			holds:    make(map[string]Hold),
+		}
+		// Load existing holds if available
+		_ = store.load()
+	})
+
+	return store
+}
+
+// load reads the holds from disk; callers hold s.mu
+func (s *holdStore) load() error {
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to read holds: %w", err)
+	}
+
+	var holds []Hold
+	if err := json.Unmarshal(data, &holds); err != nil {
+		return fmt.Errorf("failed to parse holds: %w", err)
+	}
+
+	s.holds = make(map[string]Hold, len(holds))
+	for _, h := range holds {
+		s.holds[h.Module] = h
+	}
+
+	return nil
+}
+
+// Reload re-reads the holds from disk, picking up changes made by other
+// glix processes (the daemon's scheduler calls this before each check)
+func (s *holdStore) Reload() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.load()
+}
+
+// save writes the holds to disk, dropping expired ones
+func (s *holdStore) save() error {
+	dir := filepath.Dir(s.filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s.sorted(false), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal holds: %w", err)
+	}
+
+	if err := os.WriteFile(s.filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write holds: %w", err)
+	}
+
+	return nil
+}
+
+// sorted returns the holds ordered by module name, optionally with expired ones
+func (s *holdStore) sorted(includeExpired bool) []Hold {
+	now := time.Now()
+	holds := make([]Hold, 0, len(s.holds))
+
+	for _, h := range s.holds {
+		if includeExpired || h.Active(now) {
+			holds = append(holds, h)
+		}
+	}
+
+	sort.Slice(holds, func(i, j int) bool {
+		return holds[i].Module < holds[j].Module
+	})
+
+	return holds
+}
+
+// Set places or replaces a hold on a module
+func (s *holdStore) Set(h Hold) error {
+	if !h.Active(time.Now()) {
+		return fmt.Errorf("hold for %s would already be expired (%s)", h.Module, h.Until.Format(time.RFC3339))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The CLI and the daemon's dashboard both write the file
+	_ = s.load()
+
+	s.holds[h.Module] = h
+
+	return s.save()
+}
+
+// Release removes the hold on a module
+func (s *holdStore) Release(moduleName string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_ = s.load()
+
+	if _, ok := s.holds[moduleName]; !ok {
+		return fmt.Errorf("module %s is not held", moduleName)
+	}
+
+	delete(s.holds, moduleName)
+
+	return s.save()
+}
+
+// List returns the active holds ordered by module name
+func (s *holdStore) List() []Hold {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.sorted(false)
+}
+
+// Get returns the active hold for a module, if any. Expired holds are
+// ignored, so normal update policy resumes without any cleanup step.
+func (s *holdStore) Get(moduleName string) (Hold, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	h, ok := s.holds[moduleName]
+	if !ok || !h.Active(time.Now()) {
+		return Hold{}, false
+	}
+
+	return h, true
+}
+
+// ParseUntil parses an expiry date in YYYY-MM-DD or RFC 3339 form. A bare
+// date holds through the end of that day in local time.
+func ParseUntil(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (use YYYY-MM-DD or RFC 3339)", value)
+	}
+
+	return t.AddDate(0, 0, 1), nil
+}
+
+// ParseFor parses a hold length such as 30d, 2w or any Go duration (36h)
+func ParseFor(value string) (time.Duration, error) {
+	units := map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	}
+
+	for suffix, unit := range units {
+		if n, ok := strings.CutSuffix(value, suffix); ok {
+			count, err := strconv.Atoi(n)
+			if err != nil || count <= 0 {
+				return 0, fmt.Errorf("invalid hold length %q", value)
+			}
+
+			return time.Duration(count) * unit, nil
+		}
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid hold length %q (use e.g. 30d, 2w or 36h)", value)
+	}
+
+	return d, nil
+}
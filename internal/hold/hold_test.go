@@ -0,0 +1,88 @@
+package hold
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseFor(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"30d", 30 * 24 * time.Hour, false},
+		{"2w", 14 * 24 * time.Hour, false},
+		{"36h", 36 * time.Hour, false},
+		{"0d", 0, true},
+		{"-1h", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFor(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseFor(%q) = %v, %v; want %v, wantErr %v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseUntil(t *testing.T) {
+	got, err := ParseUntil("2025-03-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := time.Date(2025, 3, 2, 0, 0, 0, 0, time.Local)
+	if !got.Equal(want) {
+		t.Errorf("ParseUntil(2025-03-01) = %v, want %v", got, want)
+	}
+
+	if _, err := ParseUntil("March 1st"); err == nil {
+		t.Error("expected error for invalid date")
+	}
+}
+
+func TestHoldActive(t *testing.T) {
+	now := time.Now()
+	h := Hold{Module: "example.com/tool", Until: now.Add(time.Hour)}
+
+	if !h.Active(now) {
+		t.Error("hold should be active before expiry")
+	}
+
+	if h.Active(now.Add(2 * time.Hour)) {
+		t.Error("hold should expire after Until")
+	}
+}
+
+func TestSetKeepsHoldsOfOtherProcesses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holds.json")
+
+	// The CLI and the daemon each keep a store on the same file
+	cli := &holdStore{filePath: path, holds: make(map[string]Hold)}
+	daemon := &holdStore{filePath: path, holds: make(map[string]Hold)}
+
+	until := time.Now().Add(time.Hour)
+
+	if err := cli.Set(Hold{Module: "example.com/a", Until: until}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := daemon.Set(Hold{Module: "example.com/b", Until: until}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cli.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := len(cli.List()); got != 2 {
+		t.Errorf("holds = %d, want both", got)
+	}
+
+	if err := daemon.Release("example.com/a"); err != nil {
+		t.Errorf("Release() of a hold set by another process = %v", err)
+	}
+}
@@ -0,0 +1,233 @@
+// Package hooks runs user-defined commands before and after installs,
+// updates and removes, such as notifying a team, signing a binary or
+// syncing dotfiles. The module they act on is described to the commands in
+// GLIX_* environment variables.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/pkg/exec"
+	modpath "golang.org/x/mod/module"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultTimeout bounds a hook when it sets no timeout
+const DefaultTimeout = time.Minute
+
+// maxOutput is how much of what a failing hook printed is kept in its error
+const maxOutput = 1024
+
+// Hook is a command run on some events
+type Hook struct {
+	Name string             `yaml:"name,omitempty"` // Shown in progress and errors, the command name by default
+	On   []module.HookEvent `yaml:"on"`             // Events the hook runs on, e.g. [post-install, post-update]
+
+	// Command runs with the environment of glix and the GLIX_* variables,
+	// e.g. [sh, -c, 'notify-send "$GLIX_MODULE $GLIX_VERSION"']
+	Command []string `yaml:"command"`
+
+	// Modules limits the hook to modules matching these path prefix
+	// patterns, as GOPRIVATE matches them, e.g. [github.com/acme/*]
+	Modules []string `yaml:"modules,omitempty"`
+
+	Timeout time.Duration `yaml:"timeout,omitempty"` // Default DefaultTimeout
+
+	// IgnoreFailure lets the operation go on when a pre hook fails. Failing
+	// post hooks never undo the operation; they warn.
+	IgnoreFailure bool `yaml:"ignore_failure,omitempty"`
+}
+
+// Config is the parsed hooks file
+type Config struct {
+	Hooks []Hook `yaml:"hooks"`
+}
+
+// DefaultPath returns the location of the hooks file
+func DefaultPath() string {
+	configDir, err := module.GetApplicationConfigDirectory()
+	if err != nil {
+		// Fallback to cache directory
+		configDir, _ = module.GetApplicationCacheDirectory()
+	}
+
+	return filepath.Join(configDir, "hooks.yaml")
+}
+
+// Load reads and validates a hooks file. A missing file yields a nil
+// config: no hooks run.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read hooks: %w", err)
+	}
+
+	var c Config
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse hooks: %w", err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid hooks %s: %w", path, err)
+	}
+
+	return &c, nil
+}
+
+// Validate checks every hook, filling in defaults
+func (c *Config) Validate() error {
+	for i := range c.Hooks {
+		h := &c.Hooks[i]
+
+		if len(h.Command) == 0 {
+			return fmt.Errorf("hook %d: command is required", i+1)
+		}
+
+		if h.Name == "" {
+			h.Name = filepath.Base(h.Command[0])
+		}
+
+		if len(h.On) == 0 {
+			return fmt.Errorf("hook %s: on needs at least one event", h.Name)
+		}
+
+		for _, event := range h.On {
+			if !slices.Contains(module.HookEvents, event) {
+				return fmt.Errorf("hook %s: unknown event %q", h.Name, event)
+			}
+		}
+
+		if h.Timeout <= 0 {
+			h.Timeout = DefaultTimeout
+		}
+	}
+
+	return nil
+}
+
+// Matching returns the hooks that run on event for a module, in file order
+func (c *Config) Matching(event module.HookEvent, modulePath string) []Hook {
+	var hooks []Hook
+
+	for _, h := range c.Hooks {
+		if !slices.Contains(h.On, event) {
+			continue
+		}
+
+		if len(h.Modules) > 0 && !modpath.MatchPrefixPatterns(strings.Join(h.Modules, ","), modulePath) {
+			continue
+		}
+
+		hooks = append(hooks, h)
+	}
+
+	return hooks
+}
+
+// Run runs the hooks of event for a module one after another. It is a
+// module.HookRunner. A failing pre hook stops the others and is returned,
+// unless it ignores failures; failing post hooks are reported to progress.
+func (c *Config) Run(ctx context.Context, event module.HookEvent, info module.HookInfo, progress module.ProgressHandler) error {
+	for _, h := range c.Matching(event, info.Module) {
+		if progress != nil {
+			progress("hook", fmt.Sprintf("Running %s hook %s...", event, h.Name))
+		}
+
+		err := h.run(ctx, append(os.Environ(), info.Env(event)...))
+		if err != nil {
+			err = fmt.Errorf("%s %w", event, err)
+		}
+
+		switch {
+		case err == nil:
+		case event.Pre() && !h.IgnoreFailure:
+			return err
+		case progress != nil:
+			progress("warning", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// run runs the hook command with env
+func (h Hook) run(ctx context.Context, env []string) error {
+	ctx, cancel := context.WithTimeout(ctx, h.Timeout)
+	defer cancel()
+
+	var out bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, h.Command[0], h.Command[1:]...)
+	cmd.Env = env
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	err := cmd.Run()
+
+	if ctx.Err() != nil {
+		return fmt.Errorf("hook %s did not finish within %s", h.Name, h.Timeout)
+	}
+
+	var exitErr *exec.ExitError
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &exitErr):
+		return fmt.Errorf("hook %s exited with code %d: %s", h.Name, exitErr.ExitCode(), summarize(out.String()))
+	default:
+		return fmt.Errorf("failed to run hook %s: %w", h.Name, err)
+	}
+}
+
+// summarize trims what a failing hook printed to what its error keeps
+func summarize(output string) string {
+	output = strings.TrimSpace(output)
+	if len(output) > maxOutput {
+		output = output[:maxOutput] + "..."
+	}
+
+	return output
+}
+
+// Apply has installs of m run the configured hooks, or none when there is
+// no hooks file. An invalid file fails, so a broken file never skips a hook.
+func Apply(m *module.Module) error {
+	c, err := Load(DefaultPath())
+	if err != nil {
+		return err
+	}
+
+	if c == nil {
+		m.SetHooks(nil)
+		return nil
+	}
+
+	m.SetHooks(c.Run)
+
+	return nil
+}
+
+// Run runs the configured hooks of event for a module outside an install,
+// as removes do
+func Run(ctx context.Context, event module.HookEvent, info module.HookInfo, progress module.ProgressHandler) error {
+	c, err := Load(DefaultPath())
+	if err != nil || c == nil {
+		return err
+	}
+
+	return c.Run(ctx, event, info, progress)
+}
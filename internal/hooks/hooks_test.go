@@ -0,0 +1,163 @@
+package hooks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/inovacc/glix/internal/module"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	c, err := Load(filepath.Join(dir, "missing.yaml"))
+	if err != nil || c != nil {
+		t.Fatalf("Load(missing) = %v, %v; want nil, nil", c, err)
+	}
+
+	c, err = Load(writeFile(t, dir, "hooks.yaml", `hooks:
+  - on: [post-install, post-update]
+    command: [/usr/bin/notify-send, installed]
+  - name: sign
+    on: [post-install]
+    command: [cosign, sign-blob]
+    modules: [github.com/acme/*]
+    timeout: 2m
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(c.Hooks) != 2 || c.Hooks[0].Name != "notify-send" || c.Hooks[0].Timeout != DefaultTimeout || c.Hooks[1].Timeout != 2*time.Minute {
+		t.Errorf("defaults not applied: %+v", c.Hooks)
+	}
+
+	invalid := map[string]string{
+		"no command": "hooks:\n  - on: [post-install]\n",
+		"no events":  "hooks:\n  - command: [true]\n",
+		"bad event":  "hooks:\n  - on: [post-build]\n    command: [true]\n",
+	}
+
+	for name, content := range invalid {
+		if _, err := Load(writeFile(t, dir, "invalid.yaml", content)); err == nil {
+			t.Errorf("%s: Load succeeded, want error", name)
+		}
+	}
+}
+
+func TestMatching(t *testing.T) {
+	c := &Config{Hooks: []Hook{
+		{Name: "all", On: []module.HookEvent{module.HookPostInstall, module.HookPostUpdate}, Command: []string{"true"}},
+		{Name: "acme", On: []module.HookEvent{module.HookPostInstall}, Command: []string{"true"}, Modules: []string{"github.com/acme/*"}},
+	}}
+
+	names := func(hooks []Hook) string {
+		var s []string
+		for _, h := range hooks {
+			s = append(s, h.Name)
+		}
+
+		return strings.Join(s, ",")
+	}
+
+	if got := names(c.Matching(module.HookPostInstall, "github.com/acme/tool/cmd/tool")); got != "all,acme" {
+		t.Errorf("Matching(post-install, acme) = %s, want all,acme", got)
+	}
+
+	if got := names(c.Matching(module.HookPostInstall, "github.com/other/tool")); got != "all" {
+		t.Errorf("Matching(post-install, other) = %s, want all", got)
+	}
+
+	if got := names(c.Matching(module.HookPreRemove, "github.com/acme/tool")); got != "" {
+		t.Errorf("Matching(pre-remove) = %s, want none", got)
+	}
+}
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands need sh")
+	}
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "env")
+
+	c := &Config{Hooks: []Hook{
+		{Name: "record", On: []module.HookEvent{module.HookPreUpdate, module.HookPostUpdate},
+			Command: []string{"sh", "-c", `echo "$GLIX_HOOK_EVENT $GLIX_MODULE $GLIX_PREVIOUS_VERSION $GLIX_VERSION" >> ` + out}},
+		{Name: "fail", On: []module.HookEvent{module.HookPreUpdate, module.HookPostUpdate},
+			Command: []string{"sh", "-c", "echo denied; exit 3"}},
+	}}
+	if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	info := module.HookInfo{Module: "example.com/tool", Version: "v1.1.0", PreviousVersion: "v1.0.0"}
+
+	var warnings []string
+
+	progress := func(phase, message string) {
+		if phase == "warning" {
+			warnings = append(warnings, message)
+		}
+	}
+
+	// A failing pre hook aborts, after the hooks before it ran
+	err := c.Run(context.Background(), module.HookPreUpdate, info, progress)
+	if err == nil || !strings.Contains(err.Error(), "pre-update hook fail exited with code 3: denied") {
+		t.Errorf("Run(pre-update) = %v, want the failing hook", err)
+	}
+
+	// A failing post hook warns
+	if err := c.Run(context.Background(), module.HookPostUpdate, info, progress); err != nil {
+		t.Errorf("Run(post-update) = %v, want a warning", err)
+	}
+
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "post-update hook fail") {
+		t.Errorf("warnings = %q, want the failing post hook", warnings)
+	}
+
+	// Pre hooks that ignore failures let the operation go on
+	c.Hooks[1].IgnoreFailure = true
+	if err := c.Run(context.Background(), module.HookPreUpdate, info, progress); err != nil {
+		t.Errorf("Run(pre-update, ignore_failure) = %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "pre-update example.com/tool v1.0.0 v1.1.0\npost-update example.com/tool v1.0.0 v1.1.0\npre-update example.com/tool v1.0.0 v1.1.0\n"
+	if string(data) != want {
+		t.Errorf("hook environment:\n%s\nwant:\n%s", data, want)
+	}
+}
+
+func TestRun_Timeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands need sh")
+	}
+
+	c := &Config{Hooks: []Hook{{Name: "slow", On: []module.HookEvent{module.HookPreInstall}, Command: []string{"sleep", "5"}, Timeout: 50 * time.Millisecond}}}
+
+	err := c.Run(context.Background(), module.HookPreInstall, module.HookInfo{Module: "example.com/tool"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "did not finish within") {
+		t.Errorf("Run(slow) = %v, want a timeout", err)
+	}
+}
@@ -0,0 +1,139 @@
+// Package humanize formats durations, sizes and relative times for people,
+// compactly and the same way across commands, the dashboard and the TUI.
+// Decimals use the separator of the locale in LC_ALL, LC_NUMERIC or LANG;
+// machine-readable output never goes through this package.
+package humanize
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// commaLanguages are the languages whose locales write decimals with a comma
+var commaLanguages = map[string]bool{
+	"bg": true, "ca": true, "cs": true, "da": true, "de": true, "el": true, "es": true, "et": true,
+	"fi": true, "fr": true, "hr": true, "hu": true, "id": true, "it": true, "lt": true, "lv": true,
+	"nb": true, "nl": true, "nn": true, "no": true, "pl": true, "pt": true, "ro": true, "ru": true,
+	"sk": true, "sl": true, "sr": true, "sv": true, "tr": true, "uk": true, "vi": true,
+}
+
+// decimalSeparator returns the decimal separator of the locale numbers are
+// formatted in, as POSIX picks it: LC_ALL, then LC_NUMERIC, then LANG
+func decimalSeparator() string {
+	for _, name := range []string{"LC_ALL", "LC_NUMERIC", "LANG"} {
+		locale := os.Getenv(name)
+		if locale == "" {
+			continue
+		}
+
+		// e.g. de_DE.UTF-8, pt-BR
+		language, _, _ := strings.Cut(strings.ToLower(locale), "_")
+		language, _, _ = strings.Cut(language, "-")
+		language, _, _ = strings.Cut(language, ".")
+
+		if commaLanguages[language] {
+			return ","
+		}
+
+		return "."
+	}
+
+	return "."
+}
+
+// decimal formats v with one decimal in the separator of the locale
+func decimal(v float64) string {
+	return strings.Replace(fmt.Sprintf("%.1f", v), ".", decimalSeparator(), 1)
+}
+
+// Duration formats d compactly in its two largest units, e.g. 850ms, 1.2s,
+// 45s, 12m5s, 3h5m and 2d4h. Units that are zero are left out.
+func Duration(d time.Duration) string {
+	d = d.Abs()
+
+	switch {
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	case d < 10*time.Second:
+		return decimal(d.Seconds()) + "s"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return units(int(d.Minutes()), "m", int(d.Seconds())%60, "s")
+	case d < 24*time.Hour:
+		return units(int(d.Hours()), "h", int(d.Minutes())%60, "m")
+	default:
+		return units(int(d.Hours())/24, "d", int(d.Hours())%24, "h")
+	}
+}
+
+// units formats a count of a unit and of the next smaller one, leaving the
+// smaller one out when it is zero
+func units(n int, unit string, rest int, restUnit string) string {
+	if rest == 0 {
+		return fmt.Sprintf("%d%s", n, unit)
+	}
+
+	return fmt.Sprintf("%d%s%d%s", n, unit, rest, restUnit)
+}
+
+// Size formats a byte count with a binary unit, e.g. 512 B or 1.5 MiB
+func Size(size int64) string {
+	const unit = 1024
+
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%s %ciB", decimal(float64(size)/float64(div)), "KMGTPE"[exp])
+}
+
+// Ago describes t relative to now in its largest unit, e.g. "just now",
+// "5 minutes ago", "3 days ago" or, for times after now, "in 2 hours"
+func Ago(t, now time.Time) string {
+	d := now.Sub(t)
+	if d.Abs() < time.Minute {
+		return "just now"
+	}
+
+	var (
+		n    int
+		unit string
+	)
+
+	switch a := d.Abs(); {
+	case a < time.Hour:
+		n, unit = int(a.Minutes()), "minute"
+	case a < 24*time.Hour:
+		n, unit = int(a.Hours()), "hour"
+	case a < 30*24*time.Hour:
+		n, unit = int(a.Hours())/24, "day"
+	case a < 365*24*time.Hour:
+		n, unit = int(a.Hours())/(30*24), "month"
+	default:
+		n, unit = int(a.Hours())/(365*24), "year"
+	}
+
+	if n != 1 {
+		unit += "s"
+	}
+
+	if d < 0 {
+		return fmt.Sprintf("in %d %s", n, unit)
+	}
+
+	return fmt.Sprintf("%d %s ago", n, unit)
+}
+
+// Since describes t relative to the current time, as Ago does
+func Since(t time.Time) string {
+	return Ago(t, time.Now())
+}
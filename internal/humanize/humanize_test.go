@@ -0,0 +1,97 @@
+package humanize
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDuration(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_NUMERIC", "")
+	t.Setenv("LANG", "en_US.UTF-8")
+
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0ms"},
+		{850 * time.Millisecond, "850ms"},
+		{1250 * time.Millisecond, "1.2s"},
+		{45 * time.Second, "45s"},
+		{12*time.Minute + 5*time.Second, "12m5s"},
+		{12 * time.Minute, "12m"},
+		{3*time.Hour + 5*time.Minute + 30*time.Second, "3h5m"},
+		{50 * time.Hour, "2d2h"},
+		{48 * time.Hour, "2d"},
+		{-90 * time.Second, "1m30s"},
+	}
+
+	for _, tt := range tests {
+		if got := Duration(tt.d); got != tt.want {
+			t.Errorf("Duration(%s) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestSize(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_NUMERIC", "")
+	t.Setenv("LANG", "C")
+
+	tests := []struct {
+		size int64
+		want string
+	}{
+		{512, "512 B"},
+		{1536, "1.5 KiB"},
+		{5 * 1024 * 1024, "5.0 MiB"},
+		{3 << 30, "3.0 GiB"},
+	}
+
+	for _, tt := range tests {
+		if got := Size(tt.size); got != tt.want {
+			t.Errorf("Size(%d) = %q, want %q", tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestLocale(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_NUMERIC", "de_DE.UTF-8")
+	t.Setenv("LANG", "en_US.UTF-8")
+
+	if got := Size(1536); got != "1,5 KiB" {
+		t.Errorf("Size in de_DE = %q, want 1,5 KiB", got)
+	}
+
+	// LC_ALL overrides the other variables
+	t.Setenv("LC_ALL", "en_GB.UTF-8")
+
+	if got := Duration(1250 * time.Millisecond); got != "1.2s" {
+		t.Errorf("Duration in en_GB = %q, want 1.2s", got)
+	}
+}
+
+func TestAgo(t *testing.T) {
+	now := time.Date(2025, 6, 4, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		at   time.Time
+		want string
+	}{
+		{now.Add(-30 * time.Second), "just now"},
+		{now.Add(-time.Minute), "1 minute ago"},
+		{now.Add(-5 * time.Minute), "5 minutes ago"},
+		{now.Add(-3 * time.Hour), "3 hours ago"},
+		{now.AddDate(0, 0, -3), "3 days ago"},
+		{now.AddDate(0, -2, 0), "2 months ago"},
+		{now.AddDate(-1, 0, -1), "1 year ago"},
+		{now.Add(2 * time.Hour), "in 2 hours"},
+	}
+
+	for _, tt := range tests {
+		if got := Ago(tt.at, now); got != tt.want {
+			t.Errorf("Ago(%s) = %q, want %q", now.Sub(tt.at), got, tt.want)
+		}
+	}
+}
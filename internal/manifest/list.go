@@ -0,0 +1,49 @@
+package manifest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ReadList reads a plain list of modules, one module[@version] per line, as
+// kept in tools.txt files. '#' starts a comment and blank lines are skipped.
+// A module listed twice is an error, even at the same version.
+func ReadList(r io.Reader) ([]string, error) {
+	var modules []string
+
+	seen := make(map[string]int)
+	scanner := bufio.NewScanner(r)
+
+	for n := 1; scanner.Scan(); n++ {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if strings.ContainsAny(line, " \t") {
+			return nil, fmt.Errorf("line %d: expected one module[@version], got %q", n, line)
+		}
+
+		name := line
+		if i := strings.LastIndex(line, "@"); i > 0 {
+			name = line[:i]
+		}
+
+		if first, ok := seen[name]; ok {
+			return nil, fmt.Errorf("line %d: %s is already listed on line %d", n, name, first)
+		}
+
+		seen[name] = n
+		modules = append(modules, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read module list: %w", err)
+	}
+
+	return modules, nil
+}
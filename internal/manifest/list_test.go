@@ -0,0 +1,43 @@
+package manifest
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReadList(t *testing.T) {
+	input := `# Linters
+github.com/golangci/golangci-lint/cmd/golangci-lint@v1.62.2
+
+golang.org/x/tools/gopls   # language server
+  golang.org/x/tools/cmd/goimports@latest
+`
+
+	got, err := ReadList(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"github.com/golangci/golangci-lint/cmd/golangci-lint@v1.62.2",
+		"golang.org/x/tools/gopls",
+		"golang.org/x/tools/cmd/goimports@latest",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("ReadList() = %q, want %q", got, want)
+	}
+}
+
+func TestReadList_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"two modules on a line": "example.com/a example.com/b\n",
+		"listed twice":          "example.com/a@v1.0.0\nexample.com/a@v1.1.0\n",
+	}
+
+	for name, input := range tests {
+		if _, err := ReadList(strings.NewReader(input)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+// Package manifest reads and writes declarative lists of modules and
+// compares them with the installed set, so machines can be converged on, and
+// CI images checked against, an approved toolchain.
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FormatVersion is the manifest format written and understood by this build
+const FormatVersion = 1
+
+// hashPrefix marks hashes as SHA-256 digests of the installed binary
+const hashPrefix = "sha256:"
+
+// Entry pins one module
+type Entry struct {
+	Name    string `yaml:"name" json:"name"`
+	Version string `yaml:"version" json:"version"`
+	Hash    string `yaml:"hash,omitempty" json:"hash,omitempty"` // sha256:<hex> of the binary; unchecked when empty
+}
+
+// Manifest is a versioned list of pinned modules
+type Manifest struct {
+	Version int     `yaml:"version" json:"version"`
+	Modules []Entry `yaml:"modules" json:"modules"`
+}
+
+// Manifest file formats
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+)
+
+// New returns a manifest of the given entries ordered by module name
+func New(entries []Entry) *Manifest {
+	modules := slices.Clone(entries)
+	slices.SortFunc(modules, func(a, b Entry) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return &Manifest{Version: FormatVersion, Modules: modules}
+}
+
+// FormatFromPath picks the file format from a file extension, defaulting to
+// YAML
+func FormatFromPath(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return FormatJSON
+	}
+
+	return FormatYAML
+}
+
+// Encode writes the manifest in the given format
+func (m *Manifest) Encode(w io.Writer, format string) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(m)
+	case FormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+
+		if err := enc.Encode(m); err != nil {
+			return err
+		}
+
+		return enc.Close()
+	default:
+		return fmt.Errorf("unsupported manifest format %q (want %s or %s)", format, FormatYAML, FormatJSON)
+	}
+}
+
+// Load reads and validates a manifest. JSON manifests are accepted as well,
+// since JSON is valid YAML.
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+
+	if err := m.validate(); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+
+	return &m, nil
+}
+
+func (m *Manifest) validate() error {
+	if m.Version < 1 || m.Version > FormatVersion {
+		return fmt.Errorf("unsupported format version %d (want 1-%d)", m.Version, FormatVersion)
+	}
+
+	seen := make(map[string]bool, len(m.Modules))
+
+	for i, e := range m.Modules {
+		if e.Name == "" {
+			return fmt.Errorf("module %d: name is required", i+1)
+		}
+
+		if e.Version == "" {
+			return fmt.Errorf("module %s: version is required", e.Name)
+		}
+
+		if e.Hash != "" && !strings.HasPrefix(e.Hash, hashPrefix) {
+			return fmt.Errorf("module %s: unsupported hash %q (want %s<hex>)", e.Name, e.Hash, hashPrefix)
+		}
+
+		if seen[e.Name] {
+			return fmt.Errorf("module %s: listed more than once", e.Name)
+		}
+
+		seen[e.Name] = true
+	}
+
+	return nil
+}
+
+// HashFile returns the manifest hash of a binary
+func HashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hashPrefix + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// DriftKind classifies a difference between a manifest and the installed set
+type DriftKind string
+
+const (
+	DriftMissing    DriftKind = "missing"    // In the manifest, not installed
+	DriftUnexpected DriftKind = "unexpected" // Installed, not in the manifest
+	DriftVersion    DriftKind = "version"    // Installed at another version
+	DriftHash       DriftKind = "hash"       // Installed binary differs
+)
+
+// Drift is one difference between a manifest and the installed set. Want
+// and Got hold the manifest and installed values for version and hash
+// drift, and the version for missing and unexpected modules.
+type Drift struct {
+	Kind DriftKind `json:"kind"`
+	Name string    `json:"name"`
+	Want string    `json:"want,omitempty"`
+	Got  string    `json:"got,omitempty"`
+}
+
+// String renders the drift as a diff line
+func (d Drift) String() string {
+	switch d.Kind {
+	case DriftMissing:
+		return fmt.Sprintf("- %s@%s (not installed)", d.Name, d.Want)
+	case DriftUnexpected:
+		return fmt.Sprintf("+ %s@%s (not in manifest)", d.Name, d.Got)
+	case DriftVersion:
+		return fmt.Sprintf("~ %s: want %s, installed %s", d.Name, d.Want, d.Got)
+	default:
+		got := d.Got
+		if got == "" {
+			got = "binary not found"
+		}
+
+		return fmt.Sprintf("! %s: hash mismatch: want %s, got %s", d.Name, d.Want, got)
+	}
+}
+
+// Compare returns the drift between the manifest and the installed modules,
+// ordered by module name. Installed entries carry the hash of their binary,
+// or an empty hash when it could not be read. Unexpected modules are only
+// reported when strict is set.
+func Compare(m *Manifest, installed []Entry, strict bool) []Drift {
+	byName := make(map[string]Entry, len(installed))
+	for _, e := range installed {
+		byName[e.Name] = e
+	}
+
+	var drift []Drift
+
+	for _, want := range m.Modules {
+		got, ok := byName[want.Name]
+
+		switch {
+		case !ok:
+			drift = append(drift, Drift{Kind: DriftMissing, Name: want.Name, Want: want.Version})
+		case got.Version != want.Version:
+			drift = append(drift, Drift{Kind: DriftVersion, Name: want.Name, Want: want.Version, Got: got.Version})
+		case want.Hash != "" && got.Hash != want.Hash:
+			drift = append(drift, Drift{Kind: DriftHash, Name: want.Name, Want: want.Hash, Got: got.Hash})
+		}
+
+		delete(byName, want.Name)
+	}
+
+	if strict {
+		for _, got := range byName {
+			drift = append(drift, Drift{Kind: DriftUnexpected, Name: got.Name, Got: got.Version})
+		}
+	}
+
+	slices.SortFunc(drift, func(a, b Drift) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return drift
+}
@@ -0,0 +1,133 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeManifest(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	yamlPath := writeManifest(t, "tools.yaml", `version: 1
+modules:
+  - name: example.com/a
+    version: v1.0.0
+  - name: example.com/b
+    version: v2.1.0
+    hash: sha256:abcd
+`)
+
+	jsonPath := writeManifest(t, "tools.json", `{"version": 1, "modules": [{"name": "example.com/a", "version": "v1.0.0"}]}`)
+
+	for _, path := range []string{yamlPath, jsonPath} {
+		m, err := Load(path)
+		if err != nil {
+			t.Fatalf("Load(%s) failed: %v", filepath.Base(path), err)
+		}
+
+		if m.Modules[0].Name != "example.com/a" || m.Modules[0].Version != "v1.0.0" {
+			t.Errorf("Load(%s) = %+v", filepath.Base(path), m.Modules)
+		}
+	}
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	m := New([]Entry{
+		{Name: "example.com/b", Version: "v2.0.0", Hash: "sha256:abcd"},
+		{Name: "example.com/a", Version: "v1.0.0"},
+	})
+
+	for _, name := range []string{"tools.yaml", "tools.json"} {
+		path := filepath.Join(t.TempDir(), name)
+
+		f, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.Encode(f, FormatFromPath(path)); err != nil {
+			t.Fatalf("Encode(%s) failed: %v", name, err)
+		}
+
+		_ = f.Close()
+
+		loaded, err := Load(path)
+		if err != nil {
+			t.Fatalf("Load(%s) failed: %v", name, err)
+		}
+
+		if !slices.Equal(loaded.Modules, m.Modules) || loaded.Modules[0].Name != "example.com/a" {
+			t.Errorf("%s round trip = %+v, want %+v", name, loaded.Modules, m.Modules)
+		}
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"no version":      "modules: []\n",
+		"future version":  "version: 99\nmodules: []\n",
+		"missing version": "version: 1\nmodules:\n  - name: example.com/a\n",
+		"duplicate":       "version: 1\nmodules:\n  - {name: example.com/a, version: v1.0.0}\n  - {name: example.com/a, version: v1.1.0}\n",
+		"bad hash":        "version: 1\nmodules:\n  - {name: example.com/a, version: v1.0.0, hash: md5:abcd}\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Load(writeManifest(t, "tools.yaml", content)); err == nil {
+				t.Error("expected validation error")
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	m := &Manifest{
+		Version: FormatVersion,
+		Modules: []Entry{
+			{Name: "example.com/same", Version: "v1.0.0", Hash: "sha256:aa"},
+			{Name: "example.com/missing", Version: "v1.0.0"},
+			{Name: "example.com/older", Version: "v1.0.0"},
+			{Name: "example.com/rebuilt", Version: "v1.0.0", Hash: "sha256:aa"},
+			{Name: "example.com/unhashed", Version: "v1.0.0"},
+		},
+	}
+
+	installed := []Entry{
+		{Name: "example.com/same", Version: "v1.0.0", Hash: "sha256:aa"},
+		{Name: "example.com/older", Version: "v1.1.0"},
+		{Name: "example.com/rebuilt", Version: "v1.0.0", Hash: "sha256:bb"},
+		{Name: "example.com/unhashed", Version: "v1.0.0", Hash: "sha256:cc"},
+		{Name: "example.com/extra", Version: "v0.1.0"},
+	}
+
+	want := []Drift{
+		{Kind: DriftUnexpected, Name: "example.com/extra", Got: "v0.1.0"},
+		{Kind: DriftMissing, Name: "example.com/missing", Want: "v1.0.0"},
+		{Kind: DriftVersion, Name: "example.com/older", Want: "v1.0.0", Got: "v1.1.0"},
+		{Kind: DriftHash, Name: "example.com/rebuilt", Want: "sha256:aa", Got: "sha256:bb"},
+	}
+
+	got := Compare(m, installed, true)
+	if len(got) != len(want) {
+		t.Fatalf("Compare() = %v, want %v", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("drift %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if lenient := Compare(m, installed, false); len(lenient) != len(want)-1 {
+		t.Errorf("Compare(strict=false) = %v, expected unexpected modules to be ignored", lenient)
+	}
+}
@@ -0,0 +1,130 @@
+// Package metrics renders glix state in the Prometheus text exposition
+// format for the node_exporter textfile collector.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Module is a single installed module
+type Module struct {
+	Name    string
+	Version string
+	Local   bool
+}
+
+// Snapshot is the state exported as metrics
+type Snapshot struct {
+	Modules           []Module
+	PendingUpdates    int
+	UpdatesInstalled  int
+	LastCheck         time.Time
+	AutoUpdateEnabled bool
+	Now               time.Time
+}
+
+// Write renders the snapshot in the Prometheus text format
+func Write(w io.Writer, s Snapshot) error {
+	bw := bufio.NewWriter(w)
+
+	var local int
+
+	for _, m := range s.Modules {
+		if m.Local {
+			local++
+		}
+	}
+
+	gauge(bw, "glix_installed_modules", "Number of modules installed via glix.", len(s.Modules))
+	gauge(bw, "glix_local_modules", "Number of modules installed from a local directory.", local)
+	gauge(bw, "glix_pending_updates", "Updates found but not installed by the last update check.", s.PendingUpdates)
+
+	writeHeader(bw, "glix_updates_installed_total", "Updates installed by update checks.", "counter")
+	_, _ = fmt.Fprintf(bw, "glix_updates_installed_total %d\n", s.UpdatesInstalled)
+
+	gauge(bw, "glix_autoupdate_enabled", "Whether automatic updates are enabled.", boolValue(s.AutoUpdateEnabled))
+
+	// Without a recorded check the age is reported as -1 so alerts can tell
+	// "never checked" apart from "checked long ago"
+	lastCheck, age := int64(0), float64(-1)
+	if !s.LastCheck.IsZero() {
+		lastCheck = s.LastCheck.Unix()
+		age = s.Now.Sub(s.LastCheck).Seconds()
+	}
+
+	gauge(bw, "glix_last_check_timestamp_seconds", "Unix time of the last update check.", lastCheck)
+	writeHeader(bw, "glix_last_check_age_seconds", "Seconds since the last update check, -1 if never checked.", "gauge")
+	_, _ = fmt.Fprintf(bw, "glix_last_check_age_seconds %.0f\n", age)
+
+	writeHeader(bw, "glix_module_info", "Installed module and version.", "gauge")
+
+	for _, m := range s.Modules {
+		_, _ = fmt.Fprintf(bw, "glix_module_info{module=\"%s\",version=\"%s\"} 1\n", escapeLabel(m.Name), escapeLabel(m.Version))
+	}
+
+	return bw.Flush()
+}
+
+// WriteFile writes the metrics atomically so the textfile collector never
+// reads a partially written file
+func WriteFile(path string, s Snapshot) error {
+	dir := filepath.Dir(path)
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if err := Write(tmp, s); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+
+	if err := tmp.Chmod(0644); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to set permissions: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to move metrics into place: %w", err)
+	}
+
+	return nil
+}
+
+func gauge[T int | int64](w io.Writer, name, help string, value T) {
+	writeHeader(w, name, help, "gauge")
+	_, _ = fmt.Fprintf(w, "%s %d\n", name, value)
+}
+
+func writeHeader(w io.Writer, name, help, kind string) {
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func boolValue(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(v string) string {
+	return labelEscaper.Replace(v)
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWrite(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	s := Snapshot{
+		Modules: []Module{
+			{Name: "github.com/inovacc/twig", Version: "v1.0.0"},
+			{Name: "example.com/hello", Version: "(devel)", Local: true},
+		},
+		PendingUpdates:   3,
+		UpdatesInstalled: 7,
+		LastCheck:        now.Add(-90 * time.Second),
+		Now:              now,
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, s); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+
+	for _, want := range []string{
+		"glix_installed_modules 2\n",
+		"glix_local_modules 1\n",
+		"glix_pending_updates 3\n",
+		"glix_updates_installed_total 7\n",
+		"glix_autoupdate_enabled 0\n",
+		"glix_last_check_age_seconds 90\n",
+		"# TYPE glix_updates_installed_total counter\n",
+		`glix_module_info{module="github.com/inovacc/twig",version="v1.0.0"} 1` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteNeverChecked(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, Snapshot{Now: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), "glix_last_check_age_seconds -1\n") {
+		t.Errorf("expected -1 age when never checked:\n%s", buf.String())
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "glix.prom")
+
+	if err := WriteFile(path, Snapshot{Now: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 || entries[0].Name() != "glix.prom" {
+		t.Errorf("expected only glix.prom, got %v", entries)
+	}
+}
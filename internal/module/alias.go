@@ -0,0 +1,94 @@
+package module
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// ValidateAlias checks that an alias can be used as a binary name
+func ValidateAlias(alias string) error {
+	switch {
+	case strings.TrimSpace(alias) == "":
+		return fmt.Errorf("alias must not be empty")
+	case alias == "." || alias == ".." || strings.ContainsAny(alias, `/\`):
+		return fmt.Errorf("alias %q must be a file name, not a path", alias)
+	case strings.HasPrefix(alias, "-"):
+		return fmt.Errorf("alias %q must not start with a dash", alias)
+	case strings.HasSuffix(strings.ToLower(alias), ".exe"):
+		return fmt.Errorf("alias %q must not include the .exe extension", alias)
+	}
+
+	return nil
+}
+
+// aliasPathIn returns the path of a binary installed in dir under alias
+func aliasPathIn(dir, alias string) string {
+	if runtime.GOOS == "windows" {
+		alias += ".exe"
+	}
+
+	return filepath.Join(dir, alias)
+}
+
+// buildDirectory returns where the build places the binary. Aliased
+// binaries are built in a staging directory so the build never overwrites
+// a binary of the default name, e.g. another major version of the tool,
+// and so are binaries the quarantine scanner must pass first.
+func (m *Module) buildDirectory() string {
+	if m.Alias != "" || m.scanner != nil {
+		return filepath.Join(m.workingDir, "staging")
+	}
+
+	return m.binDirectory()
+}
+
+// InstallPath returns where an install of the module places its binary, or
+// its shim for installs with shims
+func (m *Module) InstallPath() string {
+	path := m.builtBinaryPath(m.binDirectory())
+	if m.Alias != "" {
+		path = aliasPathIn(m.binDirectory(), m.Alias)
+	}
+
+	if m.shim {
+		return ShimPathIn(m.shimDirectory(), strings.TrimSuffix(filepath.Base(path), ".exe"))
+	}
+
+	return path
+}
+
+// placeBinary records where the built binary is, first moving a staged
+// binary into place, under its alias if it has one, once the quarantine
+// scanner passed it
+func (m *Module) placeBinary() error {
+	built := m.builtBinaryPath(m.buildDirectory())
+
+	if m.buildDirectory() == m.binDirectory() {
+		m.SetBinaryPath(built)
+		return nil
+	}
+
+	if err := m.ScanBinary(m.ctx, built); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(m.binDirectory(), 0755); err != nil {
+		return fmt.Errorf("failed to create GOBIN directory: %w", err)
+	}
+
+	dest := m.builtBinaryPath(m.binDirectory())
+	if m.Alias != "" {
+		dest = aliasPathIn(m.binDirectory(), m.Alias)
+	}
+
+	if err := RestoreBinary(built, dest); err != nil {
+		return fmt.Errorf("failed to install %s as %s: %w", m.Name, dest, err)
+	}
+
+	m.SetBinaryPath(dest)
+
+	return nil
+}
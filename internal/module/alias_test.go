@@ -0,0 +1,70 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestValidateAlias(t *testing.T) {
+	tests := []struct {
+		alias string
+		ok    bool
+	}{
+		{"golangci-lint-v1", true},
+		{"lint", true},
+		{"", false},
+		{" ", false},
+		{"..", false},
+		{"bin/lint", false},
+		{`bin\lint`, false},
+		{"-lint", false},
+		{"lint.exe", false},
+	}
+
+	for _, tt := range tests {
+		if err := ValidateAlias(tt.alias); (err == nil) != tt.ok {
+			t.Errorf("ValidateAlias(%q) = %v, want ok %v", tt.alias, err, tt.ok)
+		}
+	}
+}
+
+func TestPlaceBinaryAlias(t *testing.T) {
+	work, gobin := t.TempDir(), t.TempDir()
+
+	m := &Module{Name: "example.com/tool/cmd/lint", Alias: "lint-v1", workingDir: work, binDir: gobin}
+
+	// A binary of the default name from another install stays untouched
+	other := binaryPathIn(gobin, m.Name)
+	if err := os.WriteFile(other, []byte("v2"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.MkdirAll(m.buildDirectory(), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(binaryPathIn(m.buildDirectory(), m.Name), []byte("v1"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.placeBinary(); err != nil {
+		t.Fatalf("placeBinary: %v", err)
+	}
+
+	want := filepath.Join(gobin, "lint-v1")
+	if runtime.GOOS == "windows" {
+		want += ".exe"
+	}
+
+	if m.BinaryPath != want || m.BinaryName != "lint-v1" {
+		t.Errorf("binary = %s (%s), want %s (lint-v1)", m.BinaryPath, m.BinaryName, want)
+	}
+
+	for path, content := range map[string]string{want: "v1", other: "v2"} {
+		if data, err := os.ReadFile(path); err != nil || string(data) != content {
+			t.Errorf("%s = %q, %v; want %q", path, data, err, content)
+		}
+	}
+}
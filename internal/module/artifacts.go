@@ -0,0 +1,82 @@
+package module
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/inovacc/glix/internal/manifest"
+)
+
+// ArtifactName returns the file name of a binary built for a platform in a
+// dist directory, <binary>_<version>_<goos>_<goarch>, with .exe for Windows
+func ArtifactName(binary, version, goos, goarch string) string {
+	// Local builds have versions like (devel)
+	version = strings.Trim(version, "()")
+
+	name := strings.Join([]string{binary, version, goos, goarch}, "_")
+	if goos == "windows" {
+		name += ".exe"
+	}
+
+	return name
+}
+
+// ChecksumsName returns the name of the checksums file of the artifacts of
+// a binary version, as GoReleaser names it
+func ChecksumsName(binary, version string) string {
+	return fmt.Sprintf("%s_%s_checksums.txt", binary, strings.Trim(version, "()"))
+}
+
+// BuildArtifact builds the binary of the module for its platform, the way
+// installs build it, and copies it to distDir named by ArtifactName. Nothing
+// is installed. It returns the path of the artifact.
+func (m *Module) BuildArtifact(ctx context.Context, distDir string, handler OutputHandler) (string, error) {
+	m.SetBinDir(filepath.Join(m.workingDir, "bin"))
+
+	if err := m.InstallModuleWithStreaming(ctx, handler); err != nil {
+		return "", err
+	}
+
+	dest := filepath.Join(distDir, ArtifactName(m.BinaryName, m.Version, m.goos(), m.goarch()))
+
+	if err := copyFile(m.BinaryPath, dest); err != nil {
+		return "", fmt.Errorf("failed to copy %s to %s: %w", m.BinaryName, distDir, err)
+	}
+
+	if err := os.Chmod(dest, 0755); err != nil {
+		return "", fmt.Errorf("failed to make %s executable: %w", dest, err)
+	}
+
+	return dest, nil
+}
+
+// WriteChecksums writes the SHA-256 of files to path in the format of
+// sha256sum, sorted by name, so 'sha256sum -c' run next to the files
+// verifies them
+func WriteChecksums(path string, files []string) error {
+	files = slices.Clone(files)
+	slices.SortFunc(files, func(a, b string) int {
+		return strings.Compare(filepath.Base(a), filepath.Base(b))
+	})
+
+	var sums strings.Builder
+
+	for _, file := range files {
+		hash, err := manifest.HashFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to hash %s: %w", file, err)
+		}
+
+		fmt.Fprintf(&sums, "%s  %s\n", strings.TrimPrefix(hash, "sha256:"), filepath.Base(file))
+	}
+
+	if err := os.WriteFile(path, []byte(sums.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write checksums: %w", err)
+	}
+
+	return nil
+}
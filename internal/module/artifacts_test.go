@@ -0,0 +1,59 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestArtifactName(t *testing.T) {
+	tests := []struct {
+		version, goos, goarch string
+		want                  string
+	}{
+		{"v1.2.0", "linux", "amd64", "tool_v1.2.0_linux_amd64"},
+		{"v1.2.0", "windows", "arm64", "tool_v1.2.0_windows_arm64.exe"},
+		{"(devel)", "darwin", "arm64", "tool_devel_darwin_arm64"},
+	}
+
+	for _, tt := range tests {
+		if got := ArtifactName("tool", tt.version, tt.goos, tt.goarch); got != tt.want {
+			t.Errorf("ArtifactName(%s, %s, %s) = %s, want %s", tt.version, tt.goos, tt.goarch, got, tt.want)
+		}
+	}
+
+	if got := ChecksumsName("tool", "(devel)"); got != "tool_devel_checksums.txt" {
+		t.Errorf("ChecksumsName() = %s", got)
+	}
+}
+
+func TestWriteChecksums(t *testing.T) {
+	dir := t.TempDir()
+
+	var files []string
+
+	for name, content := range map[string]string{"b_linux": "b", "a_darwin": "a"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		files = append(files, path)
+	}
+
+	sums := filepath.Join(dir, "checksums.txt")
+	if err := WriteChecksums(sums, files); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(sums)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb  a_darwin\n" +
+		"3e23e8160039594a33894f6564e1b1348bbd7a0088d42c4acb73eeaed59c009d  b_linux\n"
+	if string(data) != want {
+		t.Errorf("checksums = %q, want %q", data, want)
+	}
+}
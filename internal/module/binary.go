@@ -3,7 +3,11 @@ package module
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
 
+	pb "github.com/inovacc/glix/pkg/api/v1"
 	"github.com/inovacc/glix/pkg/exec"
 )
 
@@ -17,3 +21,86 @@ func validGoBinary(name string) error {
 
 	return nil
 }
+
+// RemoveInstalledBinaries deletes the binary of a module from GOBIN, or from
+// binDir when it was installed there with --bin-dir,
+// along with its kubectl-<name> binary when it was registered as a kubectl
+// plugin. An aliased module only owns the binary under its alias; the
+// default name may belong to another install. Each step is reported to
+// progress; it returns whether a binary was removed.
+func RemoveInstalledBinaries(name, kubectlPlugin, alias, binDir string, progress ProgressHandler) bool {
+	binaryNames := []string{BinaryName(name)}
+
+	switch {
+	case alias != "":
+		binaryNames = []string{alias}
+	case kubectlPlugin != "":
+		// Registered kubectl plugins may have been renamed to kubectl-<name>
+		binaryNames = append(binaryNames, KubectlPluginBinary(kubectlPlugin))
+	}
+
+	gobin := GetGoBinDirectory()
+	if binDir != "" {
+		gobin = binDir
+	}
+
+	// Try common binary extensions
+	removed := false
+
+	for _, binaryName := range binaryNames {
+		for _, ext := range []string{"", ".exe"} {
+			binaryPath := filepath.Join(gobin, binaryName+ext)
+			if _, err := os.Stat(binaryPath); err == nil {
+				if err := os.Remove(binaryPath); err != nil {
+					progress("warning", fmt.Sprintf("failed to remove binary %s: %v", binaryPath, err))
+				} else {
+					progress("binary", fmt.Sprintf("Removed: %s", binaryPath))
+
+					removed = true
+				}
+
+				break
+			}
+		}
+	}
+
+	return removed
+}
+
+// ManagedBinaryName returns the name of the binary glix placed in GOBIN
+func ManagedBinaryName(moduleName, kubectlPlugin, alias string) string {
+	if alias != "" {
+		return alias
+	}
+
+	binary := BinaryName(moduleName)
+
+	// Renamed kubectl plugins live under their kubectl-<name> binary
+	if _, ok := KubectlPluginName(binary); !ok && kubectlPlugin != "" {
+		return KubectlPluginBinary(kubectlPlugin)
+	}
+
+	return binary
+}
+
+// ManagedBinaryPath returns the path of the binary glix placed in GOBIN
+func ManagedBinaryPath(moduleName, kubectlPlugin, alias string) string {
+	binary := ManagedBinaryName(moduleName, kubectlPlugin, alias)
+	if runtime.GOOS == "windows" {
+		binary += ".exe"
+	}
+
+	return filepath.Join(GetGoBinDirectory(), binary)
+}
+
+// RecordedBinary returns the name and path of the binary a module installed.
+// Records stored before binaries were tracked fall back to the name glix
+// derives from the module path.
+func RecordedBinary(mod *pb.ModuleProto) (string, string) {
+	if mod.GetBinaryPath() != "" {
+		return mod.GetBinaryName(), mod.GetBinaryPath()
+	}
+
+	return ManagedBinaryName(mod.GetName(), mod.GetKubectlPlugin(), mod.GetAlias()),
+		ManagedBinaryPath(mod.GetName(), mod.GetKubectlPlugin(), mod.GetAlias())
+}
@@ -0,0 +1,338 @@
+package module
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/inovacc/glix/internal/manifest"
+	modpath "golang.org/x/mod/module"
+)
+
+// maxCachedVersions is the number of versions per module whose binaries are
+// kept so a rollback can restore them without rebuilding
+const maxCachedVersions = 3
+
+// binaryCacheRoot returns the directory holding cached binaries. It lives in
+// the application directory because the cache directory is per process.
+func binaryCacheRoot() string {
+	return filepath.Join(GetApplicationDirectory(), "binaries")
+}
+
+// binaryCacheDir returns the directory of a module's cached versions, laid
+// out like the module cache: <root>/<escaped module>/@v
+func binaryCacheDir(root, name string) (string, error) {
+	escaped, err := modpath.EscapePath(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid module path %q: %w", name, err)
+	}
+
+	return filepath.Join(root, filepath.FromSlash(escaped), "@v"), nil
+}
+
+// InstalledBinaryPath returns where go install places the binary of a module
+func InstalledBinaryPath(name string) string {
+	return binaryPathIn(GetGoBinDirectory(), name)
+}
+
+func binaryPathIn(dir, name string) string {
+	binary := BinaryName(name)
+	if runtime.GOOS == "windows" {
+		binary += ".exe"
+	}
+
+	return filepath.Join(dir, binary)
+}
+
+// SetBinDir makes installs place the binary in dir instead of GOBIN
+func (m *Module) SetBinDir(dir string) {
+	m.binDir = dir
+}
+
+// BinDir returns the directory set with SetBinDir, "" when installs use GOBIN
+func (m *Module) BinDir() string {
+	return m.binDir
+}
+
+// binDirectory returns where installs place the binary: the version store
+// for installs with shims, else the bin directory
+func (m *Module) binDirectory() string {
+	if m.shim {
+		return m.storeVersionDir()
+	}
+
+	if m.binDir != "" {
+		return m.binDir
+	}
+
+	return GetGoBinDirectory()
+}
+
+// SetBinaryPath records where the executable of the module was installed,
+// its hash, which 'glix verify' checks the binary against later, and how it
+// was built
+func (m *Module) SetBinaryPath(binPath string) {
+	m.BinaryPath = binPath
+	m.BinaryName = strings.TrimSuffix(filepath.Base(binPath), ".exe")
+	m.BinaryHash, _ = HashBinary(binPath, ConfiguredHashAlgorithm())
+	m.provenance, _ = ReadProvenance(binPath)
+}
+
+// CacheBinary keeps a copy of the installed binary of a module version and
+// drops the oldest cached versions beyond maxCachedVersions
+func CacheBinary(name, version, binPath string) error {
+	return cacheBinary(binaryCacheRoot(), name, version, binPath)
+}
+
+// CachedBinary returns the cached binary of a module version, if any
+func CachedBinary(name, version string) (string, bool) {
+	return cachedBinary(binaryCacheRoot(), name, version)
+}
+
+// UncacheBinary deletes the cached binary of a module version, if any
+func UncacheBinary(name, version string) error {
+	return uncacheBinary(binaryCacheRoot(), name, version)
+}
+
+func uncacheBinary(root, name, version string) error {
+	dir, err := binaryCacheDir(root, name)
+	if err != nil || version == "" {
+		return err
+	}
+
+	if err := os.RemoveAll(filepath.Join(dir, version)); err != nil {
+		return fmt.Errorf("failed to remove cached binary: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreBinary atomically replaces dest with the cached binary src, as a
+// hard link where the file system allows and a copy otherwise
+func RestoreBinary(src, dest string) error {
+	tmp := fmt.Sprintf("%s.glix-%d", dest, time.Now().UnixNano())
+
+	if err := linkOrCopy(src, tmp); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to copy cached binary: %w", err)
+	}
+
+	if err := os.Chmod(tmp, 0755); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to make binary executable: %w", err)
+	}
+
+	if err := os.Rename(tmp, dest); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to replace binary: %w", err)
+	}
+
+	return nil
+}
+
+// cacheBinary keeps binPath as the cached binary of a module version. The
+// cache entry is a hard link to binPath where the file system allows, so an
+// installed binary and its cached copy take the space of one. Writers that
+// unlink before writing, like go install and copyFile, leave the cached
+// bytes alone; the recorded hash catches any other writer.
+func cacheBinary(root, name, version, binPath string) error {
+	dir, err := binaryCacheDir(root, name)
+	if err != nil {
+		return err
+	}
+
+	versionDir := filepath.Join(dir, version)
+
+	hash, err := manifest.HashFile(binPath)
+	if err != nil {
+		return fmt.Errorf("failed to hash binary: %w", err)
+	}
+
+	// An identical build of the same version, e.g. installed under an alias
+	// and its default name, is linked to the cached one instead
+	if cached, ok := cachedBinaryIn(versionDir); ok && cachedHash(cached) == hash && intact(cached) {
+		if err := shareCachedBinary(cached, binPath); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := os.Chtimes(versionDir, now, now); err != nil {
+			return fmt.Errorf("failed to touch cached binary: %w", err)
+		}
+
+		return pruneBinaryCache(dir)
+	}
+
+	// Replace any earlier build of the same version
+	if err := os.RemoveAll(versionDir); err != nil {
+		return fmt.Errorf("failed to clear cached binary: %w", err)
+	}
+
+	if err := os.MkdirAll(versionDir, 0755); err != nil {
+		return fmt.Errorf("failed to create binary cache directory: %w", err)
+	}
+
+	dest := filepath.Join(versionDir, filepath.Base(binPath))
+	if err := linkOrCopy(binPath, dest); err != nil {
+		return fmt.Errorf("failed to cache binary: %w", err)
+	}
+
+	// Binaries kept by glix run are executed in place
+	if err := os.Chmod(dest, 0755); err != nil {
+		return fmt.Errorf("failed to make cached binary executable: %w", err)
+	}
+
+	if err := os.WriteFile(dest+hashSuffix, []byte(hash+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to record cached binary hash: %w", err)
+	}
+
+	return pruneBinaryCache(dir)
+}
+
+// hashSuffix names the file next to a cached binary that records its hash
+const hashSuffix = ".sha256"
+
+func cachedBinary(root, name, version string) (string, bool) {
+	dir, err := binaryCacheDir(root, name)
+	if err != nil || version == "" {
+		return "", false
+	}
+
+	cached, ok := cachedBinaryIn(filepath.Join(dir, version))
+	if !ok {
+		return "", false
+	}
+
+	if !intact(cached) {
+		return "", false
+	}
+
+	return cached, true
+}
+
+// intact reports whether a cached binary still has its recorded hash. A
+// binary changed by a writer that wrote through a hard link must not be
+// restored. Entries cached before hashes were recorded are trusted.
+func intact(cached string) bool {
+	recorded := cachedHash(cached)
+	if recorded == "" {
+		return true
+	}
+
+	actual, err := manifest.HashFile(cached)
+
+	return err == nil && actual == recorded
+}
+
+// cachedBinaryIn returns the binary in the cache directory of a version
+func cachedBinaryIn(versionDir string) (string, bool) {
+	entries, err := os.ReadDir(versionDir)
+	if err != nil {
+		return "", false
+	}
+
+	for _, e := range entries {
+		if e.Type().IsRegular() && !strings.HasSuffix(e.Name(), hashSuffix) {
+			return filepath.Join(versionDir, e.Name()), true
+		}
+	}
+
+	return "", false
+}
+
+// cachedHash returns the hash recorded for a cached binary, or ""
+func cachedHash(cached string) string {
+	data, err := os.ReadFile(cached + hashSuffix)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(data))
+}
+
+// shareCachedBinary replaces binPath, identical to the cached binary, with a
+// hard link to it
+func shareCachedBinary(cached, binPath string) error {
+	if same, err := sameFile(cached, binPath); err != nil || same {
+		return err
+	}
+
+	tmp := fmt.Sprintf("%s.glix-%d", binPath, time.Now().UnixNano())
+
+	// Where links are not supported, the binary stays a copy
+	if err := os.Link(cached, tmp); err != nil {
+		return nil
+	}
+
+	if err := os.Rename(tmp, binPath); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to link binary to the cached one: %w", err)
+	}
+
+	return nil
+}
+
+// linkOrCopy creates dst as a hard link to src, sharing the bytes of the
+// file, or as a copy where links are not supported, e.g. across file systems
+func linkOrCopy(src, dst string) error {
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+
+	return copyFile(src, dst)
+}
+
+// sameFile reports whether two paths are the same file, e.g. hard links
+func sameFile(a, b string) (bool, error) {
+	ai, err := os.Stat(a)
+	if err != nil {
+		return false, err
+	}
+
+	bi, err := os.Stat(b)
+	if err != nil {
+		return false, err
+	}
+
+	return os.SameFile(ai, bi), nil
+}
+
+// pruneBinaryCache removes all but the most recently cached versions
+func pruneBinaryCache(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read binary cache: %w", err)
+	}
+
+	type cached struct {
+		path    string
+		modTime time.Time
+	}
+
+	var versions []cached
+
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || !e.IsDir() {
+			continue
+		}
+
+		versions = append(versions, cached{filepath.Join(dir, e.Name()), info.ModTime()})
+	}
+
+	slices.SortFunc(versions, func(a, b cached) int {
+		return b.modTime.Compare(a.modTime)
+	})
+
+	for _, v := range versions[min(len(versions), maxCachedVersions):] {
+		if err := os.RemoveAll(v.path); err != nil {
+			return fmt.Errorf("failed to prune binary cache: %w", err)
+		}
+	}
+
+	return nil
+}
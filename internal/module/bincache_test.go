@@ -0,0 +1,151 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBinaryCache(t *testing.T) {
+	root := t.TempDir()
+	const name = "github.com/Example/tool"
+
+	bin := filepath.Join(t.TempDir(), "tool")
+
+	for i, v := range []string{"v1.0.0", "v1.1.0", "v1.2.0", "v1.3.0"} {
+		// Replace the binary like go install does; it is linked into the cache
+		_ = os.Remove(bin)
+
+		if err := os.WriteFile(bin, []byte(v), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := cacheBinary(root, name, v, bin); err != nil {
+			t.Fatalf("cacheBinary(%s) failed: %v", v, err)
+		}
+
+		// Order the versions explicitly; directory mtimes may tie
+		dir, _ := binaryCacheDir(root, name)
+		stamp := time.Now().Add(time.Duration(i-10) * time.Minute)
+		if err := os.Chtimes(filepath.Join(dir, v), stamp, stamp); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, ok := cachedBinary(root, name, "v1.0.0"); ok {
+		t.Error("expected the oldest version to be pruned")
+	}
+
+	cached, ok := cachedBinary(root, name, "v1.2.0")
+	if !ok {
+		t.Fatal("expected v1.2.0 to be cached")
+	}
+
+	dest := filepath.Join(t.TempDir(), "tool")
+	if err := os.WriteFile(dest, []byte("broken"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RestoreBinary(cached, dest); err != nil {
+		t.Fatalf("RestoreBinary failed: %v", err)
+	}
+
+	if data, _ := os.ReadFile(dest); string(data) != "v1.2.0" {
+		t.Errorf("restored binary = %q, want v1.2.0", data)
+	}
+
+	if err := uncacheBinary(root, name, "v1.2.0"); err != nil {
+		t.Fatalf("uncacheBinary failed: %v", err)
+	}
+
+	if _, ok := cachedBinary(root, name, "v1.2.0"); ok {
+		t.Error("expected v1.2.0 to be removed from the cache")
+	}
+
+	if _, ok := cachedBinary(root, name, "v1.3.0"); !ok {
+		t.Error("expected v1.3.0 to stay cached")
+	}
+}
+
+func TestBinaryCache_HardLinks(t *testing.T) {
+	root := t.TempDir()
+	gobin := t.TempDir()
+	const name = "github.com/example/tool"
+
+	bin := filepath.Join(gobin, "tool")
+	if err := os.WriteFile(bin, []byte("v1.0.0"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cacheBinary(root, name, "v1.0.0", bin); err != nil {
+		t.Fatal(err)
+	}
+
+	cached, ok := cachedBinary(root, name, "v1.0.0")
+	if !ok {
+		t.Fatal("expected v1.0.0 to be cached")
+	}
+
+	if same, _ := sameFile(bin, cached); !same {
+		t.Skip("file system does not support hard links")
+	}
+
+	// An identical build under an alias shares the cached binary
+	alias := filepath.Join(gobin, "tool-v1")
+	if err := os.WriteFile(alias, []byte("v1.0.0"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cacheBinary(root, name, "v1.0.0", alias); err != nil {
+		t.Fatal(err)
+	}
+
+	if same, _ := sameFile(alias, cached); !same {
+		t.Error("expected the identical alias binary to be linked to the cached one")
+	}
+
+	// Restoring links too
+	dest := filepath.Join(gobin, "restored")
+	if err := RestoreBinary(cached, dest); err != nil {
+		t.Fatal(err)
+	}
+
+	if same, _ := sameFile(dest, cached); !same {
+		t.Error("expected the restored binary to be linked to the cached one")
+	}
+
+	// copyFile breaks the link instead of writing through it
+	src := filepath.Join(t.TempDir(), "new")
+	if err := os.WriteFile(src, []byte("v1.1.0"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := copyFile(src, bin); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := cachedBinary(root, name, "v1.0.0"); !ok {
+		t.Error("copyFile over a linked binary should leave the cached one intact")
+	}
+
+	// Writing through a link is detected
+	if err := os.WriteFile(alias, []byte("patched"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := cachedBinary(root, name, "v1.0.0"); ok {
+		t.Error("a cached binary changed through a link should not be restored")
+	}
+
+	// and a new build replaces the cache entry
+	if err := cacheBinary(root, name, "v1.0.0", bin); err != nil {
+		t.Fatal(err)
+	}
+
+	if cached, ok := cachedBinary(root, name, "v1.0.0"); !ok {
+		t.Error("expected the rebuilt v1.0.0 to be cached")
+	} else if data, _ := os.ReadFile(cached); string(data) != "v1.1.0" {
+		t.Errorf("cached binary = %q, want v1.1.0", data)
+	}
+}
@@ -0,0 +1,103 @@
+package module
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// BinDirError reports a bin directory installs cannot place binaries in
+type BinDirError struct {
+	Dir    string
+	Reason string
+}
+
+func (e *BinDirError) Error() string {
+	return fmt.Sprintf("cannot install to %s: %s", e.Dir, e.Reason)
+}
+
+// CheckBinDir verifies that binaries can be written to dir, creating it if
+// needed, so an install fails before building rather than when the binary is
+// copied into place
+func CheckBinDir(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return &BinDirError{Dir: dir, Reason: describeFSError(dir, err)}
+	}
+
+	fs := mountInfo(dir)
+	if fs.readOnly {
+		reason := "read-only file system"
+		if fs.network != "" {
+			reason = fmt.Sprintf("read-only %s mount", fs.network)
+		}
+
+		return &BinDirError{Dir: dir, Reason: reason}
+	}
+
+	// Permissions, ACLs and root squashing on network mounts only show when
+	// writing
+	probe, err := os.CreateTemp(dir, ".glix-probe-*")
+	if err != nil {
+		return &BinDirError{Dir: dir, Reason: describeFSError(dir, err)}
+	}
+
+	_ = probe.Close()
+	_ = os.Remove(probe.Name())
+
+	return nil
+}
+
+// NetworkFileSystem returns the kind of network file system dir is on, such
+// as "nfs", or "" for local disks
+func NetworkFileSystem(dir string) string {
+	return mountInfo(dir).network
+}
+
+// CustomBinDir returns dir, or "" when it is GOBIN
+func CustomBinDir(dir string) string {
+	if filepath.Clean(dir) == filepath.Clean(GetGoBinDirectory()) {
+		return ""
+	}
+
+	return dir
+}
+
+// describeFSError names the cause of a failed write to dir, including the
+// network file system it is on
+func describeFSError(dir string, err error) string {
+	reason := err.Error()
+
+	var pathErr *os.PathError
+	if errors.As(err, &pathErr) {
+		reason = pathErr.Err.Error()
+	}
+
+	if network := mountInfo(existingParent(dir)).network; network != "" {
+		reason += fmt.Sprintf(" (%s mount)", network)
+	}
+
+	return reason
+}
+
+// fileSystem describes the mount a directory is on
+type fileSystem struct {
+	readOnly bool
+	network  string // Kind of network file system, "" when local or unknown
+}
+
+// existingParent returns dir or its closest ancestor that exists
+func existingParent(dir string) string {
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			return dir
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+
+		dir = parent
+	}
+}
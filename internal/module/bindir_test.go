@@ -0,0 +1,67 @@
+package module
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestCheckBinDir(t *testing.T) {
+	root := t.TempDir()
+
+	// Missing directories are created
+	dir := filepath.Join(root, "nested", "bin")
+	if err := CheckBinDir(dir); err != nil {
+		t.Fatalf("CheckBinDir(%s) = %v", dir, err)
+	}
+
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("CheckBinDir left %d file(s) behind", len(entries))
+	}
+
+	// A file where the directory should be
+	file := filepath.Join(root, "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var binDirErr *BinDirError
+	if err := CheckBinDir(file); !errors.As(err, &binDirErr) || binDirErr.Dir != file {
+		t.Errorf("CheckBinDir(file) = %v, want a BinDirError", err)
+	}
+
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("permissions are not enforced")
+	}
+
+	readOnly := filepath.Join(root, "ro")
+	if err := os.Mkdir(readOnly, 0555); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := CheckBinDir(readOnly); !errors.As(err, &binDirErr) {
+		t.Errorf("CheckBinDir(read-only) = %v, want a BinDirError", err)
+	}
+}
+
+func TestCustomBinDir(t *testing.T) {
+	gobin := GetGoBinDirectory()
+	custom := filepath.Join(t.TempDir(), "bin")
+
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{gobin, ""},
+		{gobin + string(filepath.Separator), ""},
+		{custom, custom},
+	}
+
+	for _, tt := range tests {
+		if got := CustomBinDir(tt.dir); got != tt.want {
+			t.Errorf("CustomBinDir(%q) = %q, want %q", tt.dir, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package module
+
+import (
+	"strings"
+
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+// BuildFlags are the go build flags of an install, recorded so updates build
+// the same way
+type BuildFlags struct {
+	LDFlags  string   // Value of -ldflags
+	Tags     []string // Build tags
+	TrimPath bool     // Build with -trimpath
+}
+
+// ParseTags splits build tags given as go build takes them, separated by
+// commas, or by spaces as older go versions did
+func ParseTags(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
+
+// BuildFlagsFromProto returns the build flags recorded for an install
+func BuildFlagsFromProto(p *pb.BuildFlagsProto) BuildFlags {
+	return BuildFlags{
+		LDFlags:  p.GetLdflags(),
+		Tags:     p.GetTags(),
+		TrimPath: p.GetTrimpath(),
+	}
+}
+
+// IsZero reports whether no build flags are set
+func (f BuildFlags) IsZero() bool {
+	return f.LDFlags == "" && len(f.Tags) == 0 && !f.TrimPath
+}
+
+// Proto returns the flags as recorded for an install, nil when none are set
+func (f BuildFlags) Proto() *pb.BuildFlagsProto {
+	if f.IsZero() {
+		return nil
+	}
+
+	return &pb.BuildFlagsProto{
+		Ldflags:  f.LDFlags,
+		Tags:     f.Tags,
+		Trimpath: f.TrimPath,
+	}
+}
+
+// Args returns the flags as go build arguments
+func (f BuildFlags) Args() []string {
+	var args []string
+
+	if f.LDFlags != "" {
+		args = append(args, "-ldflags="+f.LDFlags)
+	}
+
+	if len(f.Tags) > 0 {
+		args = append(args, "-tags="+strings.Join(f.Tags, ","))
+	}
+
+	if f.TrimPath {
+		args = append(args, "-trimpath")
+	}
+
+	return args
+}
+
+// String returns the flags as they would be passed to go build
+func (f BuildFlags) String() string {
+	args := f.Args()
+
+	// Quote ldflags with spaces as a shell would need them
+	for i, arg := range args {
+		if value, ok := strings.CutPrefix(arg, "-ldflags="); ok && strings.Contains(value, " ") {
+			args[i] = "-ldflags='" + value + "'"
+		}
+	}
+
+	return strings.Join(args, " ")
+}
+
+// SetBuildFlags makes installs build with flags
+func (m *Module) SetBuildFlags(flags BuildFlags) {
+	m.buildFlags = flags
+}
+
+// BuildFlags returns the build flags set with SetBuildFlags
+func (m *Module) BuildFlags() BuildFlags {
+	return m.buildFlags
+}
@@ -0,0 +1,48 @@
+package module
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"netgo", []string{"netgo"}},
+		{"netgo,osusergo", []string{"netgo", "osusergo"}},
+		{"netgo osusergo", []string{"netgo", "osusergo"}},
+		{" netgo, ,osusergo ", []string{"netgo", "osusergo"}},
+	}
+
+	for _, tt := range tests {
+		if got := ParseTags(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("ParseTags(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestBuildFlags(t *testing.T) {
+	var none BuildFlags
+	if !none.IsZero() || none.Proto() != nil || len(none.Args()) != 0 {
+		t.Errorf("zero flags: IsZero %v, Proto %v, Args %q", none.IsZero(), none.Proto(), none.Args())
+	}
+
+	flags := BuildFlags{LDFlags: "-s -w", Tags: []string{"netgo", "osusergo"}, TrimPath: true}
+
+	wantArgs := []string{"-ldflags=-s -w", "-tags=netgo,osusergo", "-trimpath"}
+	if got := flags.Args(); !slices.Equal(got, wantArgs) {
+		t.Errorf("Args() = %q, want %q", got, wantArgs)
+	}
+
+	if got, want := flags.String(), "-ldflags='-s -w' -tags=netgo,osusergo -trimpath"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	round := BuildFlagsFromProto(flags.Proto())
+	if round.LDFlags != flags.LDFlags || !slices.Equal(round.Tags, flags.Tags) || round.TrimPath != flags.TrimPath {
+		t.Errorf("round trip = %+v, want %+v", round, flags)
+	}
+}
@@ -0,0 +1,48 @@
+package module
+
+import (
+	"os"
+	"time"
+
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+// startResolving marks when resolving the install began. Module zips the go
+// command wrote after it were downloaded for the install.
+func (m *Module) startResolving() {
+	if m.resolveStarted.IsZero() {
+		m.resolveStarted = time.Now()
+	}
+}
+
+// noteSourceZip records whether the zip of the module source was in the
+// module cache before the install: go only writes zips it downloads
+func (m *Module) noteSourceZip(zip string) {
+	if m.resolveStarted.IsZero() {
+		return
+	}
+
+	info, err := os.Stat(zip)
+	m.sourceCached = err == nil && info.ModTime().Before(m.resolveStarted)
+}
+
+// recordBuildMetrics records how long the install started at start took
+// and the size of the binary it placed
+func (m *Module) recordBuildMetrics(start time.Time) {
+	metrics := &pb.BuildMetricsProto{
+		DurationNano: int64(time.Since(start)),
+		CacheHit:     m.sourceCached,
+	}
+
+	if info, err := os.Stat(m.BinaryPath); err == nil {
+		metrics.BinarySize = info.Size()
+	}
+
+	m.buildMetrics = metrics
+}
+
+// BuildMetrics returns how long the install took, whether its source was in
+// the module cache and the size of its binary, or nil before it finished
+func (m *Module) BuildMetrics() *pb.BuildMetricsProto {
+	return m.buildMetrics
+}
@@ -0,0 +1,48 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBuildMetrics(t *testing.T) {
+	dir := t.TempDir()
+
+	zip := filepath.Join(dir, "v1.0.0.zip")
+	if err := os.WriteFile(zip, []byte("zip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	binPath := filepath.Join(dir, "tool")
+	if err := os.WriteFile(binPath, make([]byte, 2048), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// A zip written before resolving began was in the module cache
+	if err := os.Chtimes(zip, time.Now().Add(-time.Hour), time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &Module{BinaryPath: binPath}
+	m.startResolving()
+	m.noteSourceZip(zip)
+	m.recordBuildMetrics(time.Now().Add(-3 * time.Second))
+
+	if b := m.BuildMetrics(); !b.GetCacheHit() || b.GetBinarySize() != 2048 || b.GetDurationNano() < int64(3*time.Second) {
+		t.Errorf("BuildMetrics() = %v, want a 2048 byte cache hit of at least 3s", b)
+	}
+
+	// A zip written since was downloaded
+	if err := os.Chtimes(zip, time.Now().Add(time.Second), time.Now().Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	m.noteSourceZip(zip)
+	m.recordBuildMetrics(time.Now())
+
+	if m.BuildMetrics().GetCacheHit() {
+		t.Error("BuildMetrics() of a downloaded zip is a cache hit")
+	}
+}
@@ -0,0 +1,351 @@
+package module
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	osExec "os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/inovacc/glix/pkg/exec"
+)
+
+// Kinds of build strategies
+const (
+	StrategyAuto       = "auto"       // GoReleaser with a config, else go install, falling back to make or mage
+	StrategyGo         = "go"         // go install
+	StrategyGoReleaser = "goreleaser" // goreleaser build
+	StrategyMake       = "make"       // A Makefile target
+	StrategyMage       = "mage"       // A magefile target
+)
+
+// defaultBuildTarget is the Makefile or magefile target built when none is
+// given
+const defaultBuildTarget = "build"
+
+// BuildStrategy is how the binary of a module is built from its source,
+// recorded so updates build the same way. The zero value is StrategyAuto.
+type BuildStrategy struct {
+	Kind   string // One of the Strategy kinds, StrategyAuto when empty
+	Target string // Target of make and mage, defaultBuildTarget when empty
+}
+
+// ParseBuildStrategy parses a strategy as --build-strategy takes it: a kind,
+// with a target for make and mage such as "make:cli"
+func ParseBuildStrategy(s string) (BuildStrategy, error) {
+	kind, target, hasTarget := strings.Cut(strings.TrimSpace(s), ":")
+
+	switch kind {
+	case "", StrategyAuto:
+		kind = ""
+	case StrategyGo, StrategyGoReleaser:
+	case StrategyMake, StrategyMage:
+		if hasTarget && target == "" {
+			return BuildStrategy{}, fmt.Errorf("build strategy %q has an empty target", s)
+		}
+
+		return BuildStrategy{Kind: kind, Target: target}, nil
+	default:
+		return BuildStrategy{}, fmt.Errorf("unknown build strategy %q: want auto, go, goreleaser, make[:target] or mage[:target]", s)
+	}
+
+	if hasTarget {
+		return BuildStrategy{}, fmt.Errorf("build strategy %s takes no target", cmp.Or(kind, StrategyAuto))
+	}
+
+	return BuildStrategy{Kind: kind}, nil
+}
+
+// BuildStrategyFromRecord returns the strategy recorded for an install, or
+// StrategyAuto for a strategy this version of glix does not know
+func BuildStrategyFromRecord(recorded string) BuildStrategy {
+	strategy, _ := ParseBuildStrategy(recorded)
+	return strategy
+}
+
+// IsZero reports whether the strategy is StrategyAuto
+func (s BuildStrategy) IsZero() bool {
+	return s.Kind == "" || s.Kind == StrategyAuto
+}
+
+// String returns the strategy as ParseBuildStrategy takes it, empty for
+// StrategyAuto as it is recorded
+func (s BuildStrategy) String() string {
+	if s.IsZero() {
+		return ""
+	}
+
+	if s.Target != "" {
+		return s.Kind + ":" + s.Target
+	}
+
+	return s.Kind
+}
+
+// target returns the make or mage target to build
+func (s BuildStrategy) target() string {
+	return cmp.Or(s.Target, defaultBuildTarget)
+}
+
+// SetBuildStrategy makes installs build the binary with strategy
+func (m *Module) SetBuildStrategy(strategy BuildStrategy) {
+	m.buildStrategy = strategy
+}
+
+// BuildStrategy returns the strategy set with SetBuildStrategy
+func (m *Module) BuildStrategy() BuildStrategy {
+	return m.buildStrategy
+}
+
+// sourceBuilder builds the binary of a module with a build tool of its own,
+// in a writable copy of its source
+type sourceBuilder interface {
+	// detect reports whether srcDir has target for the tool to build,
+	// returning the file it is defined in
+	detect(srcDir, target string) (string, bool)
+
+	// build builds target in buildDir
+	build(ctx context.Context, m *Module, buildDir, target string, handler OutputHandler) error
+}
+
+// sourceBuilders are the build tools a strategy can name besides go and
+// GoReleaser
+var sourceBuilders = map[string]sourceBuilder{
+	StrategyMake: makeBuilder{},
+	StrategyMage: mageBuilder{},
+}
+
+// fallbackStrategies are tried in order when go install fails under
+// StrategyAuto, building their default target
+var fallbackStrategies = []string{StrategyMake, StrategyMage}
+
+// fallbackStrategy returns the first strategy of fallbackStrategies whose
+// default target srcDir defines, and the file defining it
+func fallbackStrategy(srcDir string) (BuildStrategy, string, bool) {
+	for _, kind := range fallbackStrategies {
+		if file, ok := sourceBuilders[kind].detect(srcDir, defaultBuildTarget); ok {
+			return BuildStrategy{Kind: kind}, file, true
+		}
+	}
+
+	return BuildStrategy{}, "", false
+}
+
+// installWithBuilder builds the binary with the build tool of strategy in a
+// copy of moduleDir, and installs the binary the build produced
+func (m *Module) installWithBuilder(ctx context.Context, moduleDir string, strategy BuildStrategy, handler OutputHandler) error {
+	builder, ok := sourceBuilders[strategy.Kind]
+	if !ok {
+		return fmt.Errorf("no build tool for strategy %s", strategy.Kind)
+	}
+
+	buildDir, err := NewWorkDir("build")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = os.RemoveAll(buildDir)
+	}()
+
+	if err := copyDir(moduleDir, buildDir); err != nil {
+		return fmt.Errorf("failed to copy module source: %w", err)
+	}
+
+	if handler != nil {
+		handler("stdout", fmt.Sprintf("Building with %s %s...", strategy.Kind, strategy.target()))
+	}
+
+	started := time.Now()
+
+	if err := builder.build(ctx, m, buildDir, strategy.target(), handler); err != nil {
+		return fmt.Errorf("%s %s failed: %w", strategy.Kind, strategy.target(), err)
+	}
+
+	binaryPath, err := findProducedBinary(buildDir, BinaryName(m.Name), m.goos(), started)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", strategy.Kind, strategy.target(), err)
+	}
+
+	return m.installBuiltBinary(binaryPath, handler)
+}
+
+// installBuiltBinary copies a binary a build tool produced to where go
+// install would have placed it
+func (m *Module) installBuiltBinary(binaryPath string, handler OutputHandler) error {
+	if err := os.MkdirAll(m.buildDirectory(), 0755); err != nil {
+		return fmt.Errorf("failed to create GOBIN directory: %w", err)
+	}
+
+	destPath := m.builtBinaryPath(m.buildDirectory())
+
+	if err := copyFile(binaryPath, destPath); err != nil {
+		return fmt.Errorf("failed to copy binary to GOBIN: %w", err)
+	}
+
+	if err := os.Chmod(destPath, 0755); err != nil {
+		return fmt.Errorf("failed to make binary executable: %w", err)
+	}
+
+	if handler != nil {
+		handler("stdout", fmt.Sprintf("Binary installed to: %s", destPath))
+	}
+
+	return nil
+}
+
+// findProducedBinary returns the executable a build wrote below dir since
+// started: the one named name, or the only one. Copied sources are not
+// executable, so scripts of the module are not taken for binaries.
+func findProducedBinary(dir, name, goos string, started time.Time) (string, error) {
+	var built []string
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil || !info.Mode().IsRegular() || info.ModTime().Before(started.Truncate(time.Second)) {
+			return nil
+		}
+
+		base := d.Name()
+		if goos == "windows" {
+			var ok bool
+			if base, ok = strings.CutSuffix(base, ".exe"); !ok {
+				return nil
+			}
+		} else if info.Mode()&0111 == 0 {
+			return nil
+		}
+
+		if base == name {
+			built = []string{path}
+			return filepath.SkipAll
+		}
+
+		built = append(built, path)
+
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("error searching for binary: %w", err)
+	}
+
+	switch len(built) {
+	case 0:
+		return "", errors.New("the build produced no binary")
+	case 1:
+		return built[0], nil
+	}
+
+	for i, path := range built {
+		built[i], _ = filepath.Rel(dir, path)
+	}
+
+	slices.Sort(built)
+
+	return "", fmt.Errorf("the build produced several binaries, none named %s: %s", name, strings.Join(built, ", "))
+}
+
+// makeBuilder builds a target of the module's Makefile
+type makeBuilder struct{}
+
+// makefiles are the names GNU make looks for, in its order
+var makefiles = []string{"GNUmakefile", "makefile", "Makefile"}
+
+func (makeBuilder) detect(srcDir, target string) (string, bool) {
+	// A rule names its targets before the colon; := assigns a variable
+	rule := regexp.MustCompile(`(?m)^(?:[^:#=\s]+[ \t]+)*` + regexp.QuoteMeta(target) + `(?:[ \t]+[^:#=\s]+)*[ \t]*:(?:[^=]|$)`)
+
+	for _, name := range makefiles {
+		data, err := os.ReadFile(filepath.Join(srcDir, name))
+		if err != nil {
+			continue
+		}
+
+		if rule.Match(data) {
+			return name, true
+		}
+
+		return "", false // make only reads the first one
+	}
+
+	return "", false
+}
+
+func (makeBuilder) build(ctx context.Context, m *Module, buildDir, target string, handler OutputHandler) error {
+	if _, err := osExec.LookPath("make"); err != nil {
+		return errors.New("make not found on PATH")
+	}
+
+	cmd := exec.CommandContext(ctx, "make", target)
+	cmd.Dir = buildDir
+	cmd.Env = m.goEnv(m.platformEnv()...)
+
+	return runWithStreaming(cmd, handler, m.watchdog())
+}
+
+// mageBuilder builds a target of the module's magefile
+type mageBuilder struct{}
+
+func (mageBuilder) detect(srcDir, target string) (string, bool) {
+	files, _ := filepath.Glob(filepath.Join(srcDir, "magefiles", "*.go"))
+
+	roots, _ := filepath.Glob(filepath.Join(srcDir, "*.go"))
+	for _, path := range roots {
+		if data, err := os.ReadFile(path); err == nil && regexp.MustCompile(`(?m)^//(go:build|\s*\+build)\s+mage\b`).Match(data) {
+			files = append(files, path)
+		}
+	}
+
+	// Mage targets are exported functions, called case-insensitively
+	fn := regexp.MustCompile(`(?mi)^func\s+` + regexp.QuoteMeta(target) + `\s*\(`)
+
+	for _, path := range files {
+		if data, err := os.ReadFile(path); err == nil && fn.Match(data) {
+			rel, _ := filepath.Rel(srcDir, path)
+			return rel, true
+		}
+	}
+
+	return "", false
+}
+
+func (mageBuilder) build(ctx context.Context, m *Module, buildDir, target string, handler OutputHandler) error {
+	mage, err := osExec.LookPath("mage")
+	if err != nil {
+		if handler != nil {
+			handler("stdout", "mage not found, installing...")
+		}
+
+		cmd := m.goCommand(ctx, "install", "github.com/magefile/mage@latest")
+		if err := runWithStreaming(cmd, handler, m.watchdog()); err != nil {
+			return fmt.Errorf("failed to install mage: %w", err)
+		}
+
+		mage = filepath.Join(GetGoBinDirectory(), "mage")
+	}
+
+	cmd := exec.CommandContext(ctx, mage, target)
+	cmd.Dir = buildDir
+	cmd.Env = m.goEnv(m.platformEnv()...)
+
+	return runWithStreaming(cmd, handler, m.watchdog())
+}
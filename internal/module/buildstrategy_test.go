@@ -0,0 +1,174 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseBuildStrategy(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    BuildStrategy
+		wantErr bool
+	}{
+		{"", BuildStrategy{}, false},
+		{"auto", BuildStrategy{}, false},
+		{"go", BuildStrategy{Kind: StrategyGo}, false},
+		{"goreleaser", BuildStrategy{Kind: StrategyGoReleaser}, false},
+		{"make", BuildStrategy{Kind: StrategyMake}, false},
+		{"make:cli", BuildStrategy{Kind: StrategyMake, Target: "cli"}, false},
+		{"mage:Build", BuildStrategy{Kind: StrategyMage, Target: "Build"}, false},
+		{"make:", BuildStrategy{}, true},
+		{"go:build", BuildStrategy{}, true},
+		{"auto:x", BuildStrategy{}, true},
+		{"bazel", BuildStrategy{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseBuildStrategy(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseBuildStrategy(%q) = %+v, %v; want %+v, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+
+		if err == nil && got.String() != strings.TrimPrefix(tt.in, "auto") {
+			t.Errorf("ParseBuildStrategy(%q).String() = %q", tt.in, got.String())
+		}
+	}
+
+	if got := BuildStrategyFromRecord("bazel"); !got.IsZero() {
+		t.Errorf("unknown recorded strategy = %+v, want auto", got)
+	}
+}
+
+func TestMakeBuilderDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		makefile string
+		target   string
+		want     bool
+	}{
+		{"rule", ".PHONY: build\nbuild:\n\tgo build -o bin/tool .\n", "build", true},
+		{"rule with prerequisites", "build: generate\n\tgo build .\n", "build", true},
+		{"one of several targets", "all build: gen\n\tgo build .\n", "build", true},
+		{"variable assignment", "build := bin\nall:\n\tgo build .\n", "build", false},
+		{"other target", "build-docs:\n\tmkdocs build\n", "build", false},
+		{"named target", "cli:\n\tgo build ./cmd/cli\n", "cli", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "Makefile"), []byte(tt.makefile), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			file, ok := makeBuilder{}.detect(dir, tt.target)
+			if ok != tt.want || ok && file != "Makefile" {
+				t.Errorf("detect = %q, %v; want %v", file, ok, tt.want)
+			}
+		})
+	}
+
+	if _, ok := (makeBuilder{}).detect(t.TempDir(), "build"); ok {
+		t.Error("detected a target without a Makefile")
+	}
+}
+
+func TestMageBuilderDetect(t *testing.T) {
+	dir := t.TempDir()
+
+	magefile := "//go:build mage\n\npackage main\n\n// Build builds the tool\nfunc Build() error {\n\treturn nil\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "magefile.go"), []byte(magefile), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Regular sources defining a function of the same name are not magefiles
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc Install() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if file, ok := (mageBuilder{}).detect(dir, "build"); !ok || file != "magefile.go" {
+		t.Errorf("detect(build) = %q, %v; want magefile.go", file, ok)
+	}
+
+	if _, ok := (mageBuilder{}).detect(dir, "install"); ok {
+		t.Error("detected a target defined outside the magefile")
+	}
+
+	strategy, file, ok := fallbackStrategy(dir)
+	if !ok || strategy.Kind != StrategyMage || file != "magefile.go" {
+		t.Errorf("fallbackStrategy = %+v, %q, %v; want mage from magefile.go", strategy, file, ok)
+	}
+}
+
+func TestFindProducedBinary(t *testing.T) {
+	started := time.Now().Add(-time.Minute)
+
+	write := func(t *testing.T, dir, name string, mode os.FileMode) {
+		t.Helper()
+
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte("binary"), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("named binary", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, "main.go", 0644)
+		write(t, dir, "bin/helper", 0755)
+		write(t, dir, "bin/tool", 0755)
+
+		got, err := findProducedBinary(dir, "tool", "linux", started)
+		if err != nil || got != filepath.Join(dir, "bin", "tool") {
+			t.Errorf("findProducedBinary = %q, %v; want bin/tool", got, err)
+		}
+	})
+
+	t.Run("only binary", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, "build/tool-linux-amd64", 0755)
+
+		got, err := findProducedBinary(dir, "tool", "linux", started)
+		if err != nil || got != filepath.Join(dir, "build", "tool-linux-amd64") {
+			t.Errorf("findProducedBinary = %q, %v; want build/tool-linux-amd64", got, err)
+		}
+	})
+
+	t.Run("windows", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, "dist/tool.exe", 0644)
+		write(t, dir, "dist/tool.txt", 0644)
+
+		got, err := findProducedBinary(dir, "tool", "windows", started)
+		if err != nil || got != filepath.Join(dir, "dist", "tool.exe") {
+			t.Errorf("findProducedBinary = %q, %v; want dist/tool.exe", got, err)
+		}
+	})
+
+	t.Run("ambiguous", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, "bin/a", 0755)
+		write(t, dir, "bin/b", 0755)
+
+		if _, err := findProducedBinary(dir, "tool", "linux", started); err == nil || !strings.Contains(err.Error(), filepath.Join("bin", "a")+", "+filepath.Join("bin", "b")) {
+			t.Errorf("findProducedBinary error = %v, want the binaries listed", err)
+		}
+	})
+
+	t.Run("nothing built", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, "bin/tool", 0755)
+
+		if _, err := findProducedBinary(dir, "tool", "linux", time.Now().Add(time.Hour)); err == nil {
+			t.Error("found a binary older than the build")
+		}
+	})
+}
@@ -57,3 +57,19 @@ func GetApplicationConfigDirectory() (string, error) {
 
 	return configDir, nil
 }
+
+// GetGoBinDirectory returns the directory where installed binaries are placed.
+// It honors GOBIN, then falls back to GOPATH/bin and finally ~/go/bin.
+func GetGoBinDirectory() string {
+	if gobin := os.Getenv("GOBIN"); gobin != "" {
+		return gobin
+	}
+
+	gopath := os.Getenv("GOPATH")
+	if gopath == "" {
+		home, _ := os.UserHomeDir()
+		gopath = filepath.Join(home, "go")
+	}
+
+	return filepath.Join(gopath, "bin")
+}
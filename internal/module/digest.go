@@ -0,0 +1,118 @@
+package module
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/mod/sumdb/dirhash"
+)
+
+// HashAlgorithm names the digest installed binaries are recorded with
+type HashAlgorithm string
+
+const (
+	HashSHA256 HashAlgorithm = "sha256"
+	HashSHA512 HashAlgorithm = "sha512"
+)
+
+// DefaultHashAlgorithm is used when GLIX_HASH_ALGORITHM is unset
+const DefaultHashAlgorithm = HashSHA256
+
+// ParseHashAlgorithm parses the name of a hash algorithm
+func ParseHashAlgorithm(name string) (HashAlgorithm, error) {
+	switch alg := HashAlgorithm(strings.ToLower(strings.TrimSpace(name))); alg {
+	case HashSHA256, HashSHA512:
+		return alg, nil
+	default:
+		return "", fmt.Errorf("unsupported hash algorithm %q: use %s or %s", name, HashSHA256, HashSHA512)
+	}
+}
+
+// ConfiguredHashAlgorithm returns the algorithm new binary hashes are
+// recorded with, as GLIX_HASH_ALGORITHM names it
+func ConfiguredHashAlgorithm() HashAlgorithm {
+	if alg, err := ParseHashAlgorithm(os.Getenv("GLIX_HASH_ALGORITHM")); err == nil {
+		return alg
+	}
+
+	return DefaultHashAlgorithm
+}
+
+func (a HashAlgorithm) new() hash.Hash {
+	if a == HashSHA512 {
+		return sha512.New()
+	}
+
+	return sha256.New()
+}
+
+// HashBinary returns the digest of a file as <algorithm>:<hex>
+func HashBinary(path string, alg HashAlgorithm) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	h := alg.new()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return string(alg) + ":" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// RehashBinary returns the digest of a file with the algorithm of a recorded
+// digest, so the two compare even after GLIX_HASH_ALGORITHM changed. Without
+// a recorded digest the configured algorithm is used.
+func RehashBinary(path, recorded string) (string, error) {
+	alg := ConfiguredHashAlgorithm()
+
+	if name, _, ok := strings.Cut(recorded, ":"); ok {
+		parsed, err := ParseHashAlgorithm(name)
+		if err != nil {
+			return "", fmt.Errorf("recorded hash: %w", err)
+		}
+
+		alg = parsed
+	}
+
+	return HashBinary(path, alg)
+}
+
+// HashModuleZip returns the go.sum hash (h1:) of a downloaded module zip,
+// the digest go verifies it against the checksum database with
+func HashModuleZip(zip string) (string, error) {
+	return dirhash.HashZip(zip, dirhash.Hash1)
+}
+
+// readGoSum returns the zip hashes of a go.sum file by module@version,
+// leaving out the go.mod hashes. A missing file has none.
+func readGoSum(path string) map[string]string {
+	sums := make(map[string]string)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return sums
+	}
+
+	for line := range strings.SplitSeq(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 || strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+
+		sums[fields[0]+"@"+fields[1]] = fields[2]
+	}
+
+	return sums
+}
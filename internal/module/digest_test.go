@@ -0,0 +1,68 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHashBinary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tool")
+	if err := os.WriteFile(path, []byte("hello"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	sha256Hash, err := HashBinary(path, HashSHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"; sha256Hash != want {
+		t.Errorf("HashBinary(sha256) = %s, want %s", sha256Hash, want)
+	}
+
+	// Recorded hashes are recomputed with their own algorithm
+	t.Setenv("GLIX_HASH_ALGORITHM", "sha512")
+
+	if got, err := RehashBinary(path, sha256Hash); err != nil || got != sha256Hash {
+		t.Errorf("RehashBinary(sha256 record) = %s, %v; want %s", got, err, sha256Hash)
+	}
+
+	if got, err := RehashBinary(path, ""); err != nil || !strings.HasPrefix(got, "sha512:") || len(got) != len("sha512:")+128 {
+		t.Errorf("RehashBinary(unrecorded) = %s, %v; want a sha512 hash", got, err)
+	}
+
+	if _, err := RehashBinary(path, "md5:5d41402abc4b2a76b9719d911017c592"); err == nil {
+		t.Error("RehashBinary should refuse an unsupported recorded algorithm")
+	}
+}
+
+func TestConfiguredHashAlgorithm(t *testing.T) {
+	for value, want := range map[string]HashAlgorithm{"": HashSHA256, "SHA512": HashSHA512, "sha256": HashSHA256, "md5": HashSHA256} {
+		t.Setenv("GLIX_HASH_ALGORITHM", value)
+
+		if got := ConfiguredHashAlgorithm(); got != want {
+			t.Errorf("GLIX_HASH_ALGORITHM=%q: ConfiguredHashAlgorithm() = %s, want %s", value, got, want)
+		}
+	}
+}
+
+func TestReadGoSum(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "go.sum")
+	if err := os.WriteFile(path, []byte(`github.com/spf13/cobra v1.8.0 h1:7aJaZx1B85qltLMc546zn58BxxfZdR/W22ej9CFoEf0=
+github.com/spf13/cobra v1.8.0/go.mod h1:WXLWApfZ71AjXPya3WOlMsY9yMs7YeiHhFVlvLyhcho=
+golang.org/x/mod v0.31.0/go.mod h1:IXM97Txy2VM4PJ3gI61r1YEk/gAj6zAHN3AdZt6S9Ag=
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sums := readGoSum(path)
+	if len(sums) != 1 || sums["github.com/spf13/cobra@v1.8.0"] != "h1:7aJaZx1B85qltLMc546zn58BxxfZdR/W22ej9CFoEf0=" {
+		t.Errorf("readGoSum = %v, want the zip hash of cobra only", sums)
+	}
+
+	if sums := readGoSum(filepath.Join(t.TempDir(), "missing")); len(sums) != 0 {
+		t.Errorf("readGoSum(missing) = %v", sums)
+	}
+}
@@ -6,28 +6,113 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
+	"path"
+	"regexp"
+	"slices"
 	"strings"
-
-	"github.com/inovacc/glix/pkg/exec"
 )
 
+// DefaultDiscoveryDirs are the top-level directories of a module searched
+// for CLIs besides its root
+var DefaultDiscoveryDirs = []string{"cmd", "cli", "tools", "apps", "app", "bin"}
+
+// DiscoveryDirs returns the directories CLI discovery scans, set with
+// GLIX_DISCOVERY_DIRS as a comma-separated list such as "cmd,tools" or "*"
+// for every directory, or DefaultDiscoveryDirs
+func DiscoveryDirs() []string {
+	var dirs []string
+
+	for dir := range strings.SplitSeq(os.Getenv("GLIX_DISCOVERY_DIRS"), ",") {
+		if dir = strings.Trim(strings.TrimSpace(dir), "/"); dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	if len(dirs) == 0 {
+		return DefaultDiscoveryDirs
+	}
+
+	return dirs
+}
+
+// SetDiscoveryDirs sets the top-level directories CLI discovery scans, "*"
+// for every directory
+func (m *Module) SetDiscoveryDirs(dirs []string) {
+	m.discoveryDirs = dirs
+}
+
+// CLISelector picks the CLI to install among several discovered in a
+// module, given ranked best first
+type CLISelector func(candidates []string) (string, error)
+
+// SetCLISelector sets how the CLI to install is picked when discovery finds
+// several; the best ranked one is installed when none is set
+func (m *Module) SetCLISelector(selector CLISelector) {
+	m.cliSelector = selector
+}
+
+// SetAllBinaries makes installs discover every CLI of the root module, also
+// when the requested path is one, so they can all be installed
+func (m *Module) SetAllBinaries(all bool) {
+	m.allBinaries = all
+}
+
+// DiscoveredCLIs returns the CLIs discovery found when the requested path
+// had no main package, ranked best first. With SetAllBinaries, a requested
+// path that is a CLI comes first, followed by the other CLIs.
+func (m *Module) DiscoveredCLIs() []string {
+	return m.discovered
+}
+
+// siblingCLIs returns the CLI at path followed by the other CLIs discovery
+// finds in its root module
+func (m *Module) siblingCLIs(ctx context.Context, rootModule, path string) []string {
+	siblings := []string{path}
+
+	discovered, _, err := m.DiscoverCLIPaths(ctx, rootModule)
+	if err != nil {
+		return siblings
+	}
+
+	for _, p := range discovered {
+		if p != path {
+			siblings = append(siblings, p)
+		}
+	}
+
+	return siblings
+}
+
 // DiscoverCLIPaths attempts to find installable CLI paths when the root module fails
 // Returns: list of candidate paths, whether discovery was needed, error
+//
+// Candidates are ranked by how likely they are the tool the module is known
+// for: go install commands in the README first, then GoReleaser builds, then
+// packages named like the module, then those in cmd/ or cli/, shallower
+// packages before deeper ones.
 func (m *Module) DiscoverCLIPaths(ctx context.Context, rootModule string) ([]string, bool, error) {
-	var candidates []string
+	dirs := m.discoveryDirs
+	if len(dirs) == 0 {
+		dirs = DiscoveryDirs()
+	}
 
-	// Method 1: Check for cmd/ directory
-	cmdPaths := m.discoverFromCmdDir(ctx, m.workingDir, rootModule)
-	candidates = append(candidates, cmdPaths...)
+	// Method 1: Scan the main packages of the module in the discovery directories
+	candidates := m.discoverFromDirs(ctx, m.workingDir, rootModule, dirs)
 
-	// Method 2: Check for cli/ directory
-	cliPaths := m.discoverFromCliDir(ctx, m.workingDir, rootModule)
-	candidates = append(candidates, cliPaths...)
+	// Methods 2 and 3: GoReleaser builds and go install commands in the
+	// README, which also name CLIs in nested modules the module zip leaves out
+	srcDir := m.moduleSourceDir(ctx, rootModule)
 
-	// Method 3: Parse .goreleaser.yaml if exists
-	grPaths := m.discoverFromGoReleaser(ctx, rootModule)
-	candidates = append(candidates, grPaths...)
+	released := discoverFromGoReleaser(srcDir, rootModule)
+	candidates = append(candidates, released...)
+
+	// Packages of the module that were listed are only taken when they are
+	// commands; unlisted ones may be in nested modules
+	mentioned := slices.DeleteFunc(discoverFromReadme(srcDir, rootModule), func(p string) bool {
+		i := slices.IndexFunc(m.goListPackage, func(pkg GoListPackage) bool { return pkg.ImportPath == p })
+		return i >= 0 && m.goListPackage[i].Name != "main"
+	})
+	candidates = append(candidates, mentioned...)
 
 	// Remove duplicates
 	seen := make(map[string]bool)
@@ -41,15 +126,33 @@ func (m *Module) DiscoverCLIPaths(ctx context.Context, rootModule string) ([]str
 		}
 	}
 
+	rankCLIPaths(rootModule, unique, released, mentioned)
+
 	return unique, len(unique) > 0, nil
 }
 
 // discoverFromCmdDir checks for cmd/* subdirectories
 func (m *Module) discoverFromCmdDir(ctx context.Context, dir, rootModule string) []string {
+	return m.discoverFromDirs(ctx, dir, rootModule, []string{"cmd"})
+}
+
+// discoverFromCliDir checks for cli/* subdirectories
+func (m *Module) discoverFromCliDir(ctx context.Context, dir, rootModule string) []string {
+	return m.discoverFromDirs(ctx, dir, rootModule, []string{"cli"})
+}
+
+// discoverFromDirs lists the main packages of a module below the given
+// top-level directories, "*" for any. Packages that fail to load, such as
+// those for other platforms, are skipped rather than failing the scan.
+func (m *Module) discoverFromDirs(ctx context.Context, dir, rootModule string, dirs []string) []string {
 	var paths []string
 
-	// Try: go list -json rootModule/cmd/...
-	cmd := exec.CommandContext(ctx, m.goBinPath, "list", "-json", fmt.Sprintf("%s/cmd/...", rootModule))
+	pattern := rootModule + "/..."
+	if len(dirs) == 1 && dirs[0] != "*" {
+		pattern = fmt.Sprintf("%s/%s/...", rootModule, dirs[0])
+	}
+
+	cmd := m.goCommand(ctx, "list", "-e", "-json", pattern)
 	cmd.Dir = dir
 
 	var out bytes.Buffer
@@ -57,10 +160,10 @@ func (m *Module) discoverFromCmdDir(ctx context.Context, dir, rootModule string)
 	cmd.Stdout = &out
 
 	if err := cmd.Run(); err != nil {
-		return paths // cmd/ doesn't exist
+		return paths // Module not in the build list
 	}
 
-	// Parse JSON output (one JSON object per line)
+	// Parse JSON output (one JSON object per package)
 	decoder := json.NewDecoder(&out)
 
 	for {
@@ -72,8 +175,14 @@ func (m *Module) discoverFromCmdDir(ctx context.Context, dir, rootModule string)
 
 		m.goListPackage = append(m.goListPackage, pkg)
 
-		// Only include if it's a command (has package main)
-		if pkg.Name == "main" {
+		// Only include commands (package main) in the discovery directories
+		rel, ok := strings.CutPrefix(pkg.ImportPath, rootModule+"/")
+		if pkg.Name != "main" || pkg.Error != nil || !ok {
+			continue
+		}
+
+		top, _, _ := strings.Cut(rel, "/")
+		if slices.Contains(dirs, "*") || slices.Contains(dirs, top) {
 			paths = append(paths, pkg.ImportPath)
 		}
 	}
@@ -81,102 +190,119 @@ func (m *Module) discoverFromCmdDir(ctx context.Context, dir, rootModule string)
 	return paths
 }
 
-// discoverFromCliDir checks for cli/* subdirectories
-func (m *Module) discoverFromCliDir(ctx context.Context, dir, rootModule string) []string {
-	var paths []string
-
-	cmd := exec.CommandContext(ctx, m.goBinPath, "list", "-json", fmt.Sprintf("%s/cli/...", rootModule))
-	cmd.Dir = dir
+// moduleSourceDir returns the module cache directory of the latest version
+// of a module, or "" when it is not downloaded
+func (m *Module) moduleSourceDir(ctx context.Context, rootModule string) string {
+	cmd := m.goCommand(ctx, "list", "-m", "-json", fmt.Sprintf("%s@latest", rootModule))
 
 	var out bytes.Buffer
 
 	cmd.Stdout = &out
 
 	if err := cmd.Run(); err != nil {
-		return paths
+		return ""
 	}
 
-	decoder := json.NewDecoder(&out)
+	var modInfo struct {
+		Dir string `json:"Dir"`
+	}
 
-	for {
-		pkg := GoListPackage{}
+	if err := json.NewDecoder(&out).Decode(&modInfo); err != nil {
+		return ""
+	}
 
-		if err := decoder.Decode(&pkg); err != nil {
-			break
-		}
+	return modInfo.Dir
+}
 
-		m.goListPackage = append(m.goListPackage, pkg)
+// discoverFromGoReleaser returns the packages the Go builds of the
+// GoReleaser config of a module build
+func discoverFromGoReleaser(srcDir, rootModule string) []string {
+	var paths []string
 
-		if pkg.Name == "main" {
-			paths = append(paths, pkg.ImportPath)
+	if srcDir == "" {
+		return paths
+	}
+
+	cfg, err := loadGoReleaserConfig(srcDir)
+	if err != nil || cfg == nil || len(cfg.Builds) == 0 {
+		return paths
+	}
+
+	for _, b := range cfg.goBuilds() {
+		if pkg := b.pkg(rootModule); !slices.Contains(paths, pkg) {
+			paths = append(paths, pkg)
 		}
 	}
 
 	return paths
 }
 
-// discoverFromGoReleaser parses .goreleaser.yaml for build targets
-func (m *Module) discoverFromGoReleaser(ctx context.Context, rootModule string) []string {
-	var paths []string
-
-	// Use go list to get module cache location
-	cmd := exec.CommandContext(ctx, m.goBinPath, "list", "-m", "-json", fmt.Sprintf("%s@latest", rootModule))
-
-	var out bytes.Buffer
+// goInstallPattern matches the package of go install commands
+var goInstallPattern = regexp.MustCompile(`\bgo\s+install(?:\s+-\S+)*\s+([\w.~/-]+)@`)
 
-	cmd.Stdout = &out
+// discoverFromReadme returns the packages of a module that go install
+// commands in its README install
+func discoverFromReadme(srcDir, rootModule string) []string {
+	var paths []string
 
-	if err := cmd.Run(); err != nil {
+	if srcDir == "" {
 		return paths
 	}
 
-	var modInfo struct {
-		Dir string `json:"Dir"`
+	_, content, err := findReadme(srcDir, "")
+	if err != nil {
+		return paths
 	}
 
-	if err := json.NewDecoder(&out).Decode(&modInfo); err != nil {
-		return paths
+	for _, match := range goInstallPattern.FindAllStringSubmatch(content, -1) {
+		pkg := strings.TrimSuffix(match[1], "/...")
+		if strings.HasPrefix(pkg, rootModule+"/") && !slices.Contains(paths, pkg) {
+			paths = append(paths, pkg)
+		}
 	}
 
-	// Read .goreleaser.yaml from module directory
-	goreleaserPath := filepath.Join(modInfo.Dir, ".goreleaser.yaml")
+	return paths
+}
 
-	data, err := os.ReadFile(goreleaserPath)
-	if err != nil {
-		// Try .goreleaser.yml
-		goreleaserPath = filepath.Join(modInfo.Dir, ".goreleaser.yml")
+// rankCLIPaths sorts discovered CLIs, most likely first: those the README
+// installs, GoReleaser builds, packages named like the module, packages in
+// cmd/ or cli/, then shallower before deeper packages
+func rankCLIPaths(rootModule string, paths, released, mentioned []string) {
+	score := func(p string) int {
+		n := 0
 
-		data, err = os.ReadFile(goreleaserPath)
-		if err != nil {
-			return paths // No goreleaser config
+		if slices.Contains(mentioned, p) {
+			n += 8
 		}
-	}
 
-	// Simple YAML parsing for builds section
-	// Format: builds[].main or builds[].dir
-	lines := strings.SplitSeq(string(data), "\n")
-	for line := range lines {
-		line = strings.TrimSpace(line)
+		if slices.Contains(released, p) {
+			n += 4
+		}
 
-		// Look for main: ./cmd/toolname
-		if after, ok := strings.CutPrefix(line, "main:"); ok {
-			mainPath := strings.TrimSpace(after)
-			mainPath = strings.Trim(mainPath, `"'`)
-			mainPath = strings.TrimPrefix(mainPath, "./")
+		if BinaryName(p) == BinaryName(rootModule) {
+			n += 2
+		}
 
-			// Convert to full module path
-			fullPath := filepath.Join(rootModule, mainPath)
-			fullPath = strings.ReplaceAll(fullPath, "\\", "/")
-			paths = append(paths, fullPath)
+		if top, _, _ := strings.Cut(strings.TrimPrefix(p, rootModule+"/"), "/"); top == "cmd" || top == "cli" {
+			n++
 		}
+
+		return n
 	}
 
-	return paths
+	slices.SortStableFunc(paths, func(a, b string) int {
+		if sa, sb := score(a), score(b); sa != sb {
+			return sb - sa
+		}
+
+		return strings.Count(path.Clean(a), "/") - strings.Count(path.Clean(b), "/")
+	})
 }
 
-// hasPackageMain verifies a path contains package main
+// hasPackageMain verifies a path contains package main. Packages that do
+// not build as is, such as those generating sources with make, still count.
 func (m *Module) hasPackageMain(ctx context.Context, path string) bool {
-	cmd := exec.CommandContext(ctx, m.goBinPath, "list", "-json", path)
+	cmd := m.goCommand(ctx, "list", "-e", "-json", path)
 	cmd.Dir = m.workingDir
 
 	var out bytes.Buffer
@@ -3,6 +3,7 @@ package module
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"slices"
 	"testing"
 
@@ -298,3 +299,119 @@ func TestSmartDetection_BrdocExample(t *testing.T) {
 		t.Errorf("Expected module name to be %s, got %s", expectedPath, m.Name)
 	}
 }
+
+func TestDiscoverFromDirs(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod":                   "module example.com/tool\n\ngo 1.21\n",
+		"lib.go":                   "package tool\n",
+		"cmd/tool/main.go":         "package main\n\nfunc main() {}\n",
+		"tools/gen/main.go":        "package main\n\nfunc main() {}\n",
+		"tools/other/main.go":      "//go:build plan9 && !plan9\n\npackage main\n",
+		"internal/util/util.go":    "package util\n",
+		"examples/demo/main.go":    "package main\n\nfunc main() {}\n",
+		"apps/server/api/main.go":  "package main\n\nfunc main() {}\n",
+		"apps/server/api/types.go": "package main\n",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m, err := NewModule(context.Background(), "go", dir)
+	if err != nil {
+		t.Skipf("go unavailable: %v", err)
+	}
+
+	tests := []struct {
+		dirs []string
+		want []string
+	}{
+		{DefaultDiscoveryDirs, []string{"example.com/tool/apps/server/api", "example.com/tool/cmd/tool", "example.com/tool/tools/gen"}},
+		{[]string{"cmd"}, []string{"example.com/tool/cmd/tool"}},
+		{[]string{"*"}, []string{"example.com/tool/apps/server/api", "example.com/tool/cmd/tool", "example.com/tool/examples/demo", "example.com/tool/tools/gen"}},
+	}
+
+	for _, tt := range tests {
+		got := m.discoverFromDirs(context.Background(), dir, "example.com/tool", tt.dirs)
+		slices.Sort(got)
+
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("discoverFromDirs(%v) = %v, want %v", tt.dirs, got, tt.want)
+		}
+	}
+}
+
+func TestDiscoveryDirs(t *testing.T) {
+	t.Setenv("GLIX_DISCOVERY_DIRS", "")
+
+	if got := DiscoveryDirs(); !slices.Equal(got, DefaultDiscoveryDirs) {
+		t.Errorf("DiscoveryDirs() = %v, want the defaults", got)
+	}
+
+	t.Setenv("GLIX_DISCOVERY_DIRS", " cmd, /tools/ ,,")
+
+	if got := DiscoveryDirs(); !slices.Equal(got, []string{"cmd", "tools"}) {
+		t.Errorf("DiscoveryDirs() = %v, want [cmd tools]", got)
+	}
+}
+
+func TestDiscoverFromReadme(t *testing.T) {
+	dir := t.TempDir()
+
+	readme := "# Tool\n\n" +
+		"```sh\n" +
+		"go install example.com/tool/cmd/tool@latest\n" +
+		"go install -v example.com/tool/tools/nested/gen@v1.2.0\n" +
+		"go install example.com/tool/cmd/tool@v1.0.0\n" +
+		"go install example.com/other/cmd/x@latest\n" +
+		"go get example.com/tool/sdk@latest\n" +
+		"```\n"
+
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte(readme), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"example.com/tool/cmd/tool", "example.com/tool/tools/nested/gen"}
+	if got := discoverFromReadme(dir, "example.com/tool"); !slices.Equal(got, want) {
+		t.Errorf("discoverFromReadme() = %v, want %v", got, want)
+	}
+
+	if got := discoverFromReadme("", "example.com/tool"); len(got) != 0 {
+		t.Errorf("discoverFromReadme without a source directory = %v", got)
+	}
+}
+
+func TestRankCLIPaths(t *testing.T) {
+	paths := []string{
+		"example.com/tool/tools/gen",
+		"example.com/tool/apps/server/api",
+		"example.com/tool/cmd/helper",
+		"example.com/tool/apps/tool",
+		"example.com/tool/cmd/tool",
+		"example.com/tool/tools/release",
+	}
+
+	rankCLIPaths("example.com/tool", paths, []string{"example.com/tool/tools/release"}, []string{"example.com/tool/tools/gen"})
+
+	want := []string{
+		"example.com/tool/tools/gen",       // Installed by the README
+		"example.com/tool/tools/release",   // GoReleaser build
+		"example.com/tool/cmd/tool",        // Named like the module, in cmd/
+		"example.com/tool/apps/tool",       // Named like the module
+		"example.com/tool/cmd/helper",      // In cmd/
+		"example.com/tool/apps/server/api", // Deepest
+	}
+
+	if !slices.Equal(paths, want) {
+		t.Errorf("rankCLIPaths() = %v, want %v", paths, want)
+	}
+}
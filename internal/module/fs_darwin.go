@@ -0,0 +1,19 @@
+package module
+
+import "golang.org/x/sys/unix"
+
+// mountInfo describes the mount dir is on
+func mountInfo(dir string) fileSystem {
+	var st unix.Statfs_t
+	if err := unix.Statfs(dir, &st); err != nil {
+		return fileSystem{}
+	}
+
+	fs := fileSystem{readOnly: st.Flags&unix.MNT_RDONLY != 0}
+
+	if st.Flags&unix.MNT_LOCAL == 0 {
+		fs.network = unix.ByteSliceToString(st.Fstypename[:])
+	}
+
+	return fs
+}
@@ -0,0 +1,30 @@
+package module
+
+import "golang.org/x/sys/unix"
+
+// networkMagics names the network file systems by their statfs magic
+var networkMagics = map[uint32]string{
+	unix.NFS_SUPER_MAGIC:  "nfs",
+	unix.SMB_SUPER_MAGIC:  "smb",
+	unix.SMB2_SUPER_MAGIC: "smb",
+	unix.CIFS_SUPER_MAGIC: "cifs",
+	unix.CEPH_SUPER_MAGIC: "ceph",
+	unix.AFS_SUPER_MAGIC:  "afs",
+	unix.AFS_FS_MAGIC:     "afs",
+	unix.CODA_SUPER_MAGIC: "coda",
+	unix.NCP_SUPER_MAGIC:  "ncp",
+	unix.V9FS_MAGIC:       "9p",
+}
+
+// mountInfo describes the mount dir is on
+func mountInfo(dir string) fileSystem {
+	var st unix.Statfs_t
+	if err := unix.Statfs(dir, &st); err != nil {
+		return fileSystem{}
+	}
+
+	return fileSystem{
+		readOnly: int64(st.Flags)&unix.ST_RDONLY != 0,
+		network:  networkMagics[uint32(st.Type)],
+	}
+}
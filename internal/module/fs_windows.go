@@ -0,0 +1,23 @@
+package module
+
+import "golang.org/x/sys/windows"
+
+// mountInfo describes the volume dir is on. Read-only volumes are caught by
+// the write probe.
+func mountInfo(dir string) fileSystem {
+	path, err := windows.UTF16PtrFromString(dir)
+	if err != nil {
+		return fileSystem{}
+	}
+
+	volume := make([]uint16, windows.MAX_PATH+1)
+	if err := windows.GetVolumePathName(path, &volume[0], uint32(len(volume))); err != nil {
+		return fileSystem{}
+	}
+
+	if windows.GetDriveType(&volume[0]) == windows.DRIVE_REMOTE {
+		return fileSystem{network: "network drive"}
+	}
+
+	return fileSystem{}
+}
@@ -0,0 +1,84 @@
+package module
+
+import (
+	"cmp"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ProxySettings point the go commands of installs at a module proxy other
+// than the one of the environment, such as a company's Artifactory or
+// Athens proxy
+type ProxySettings struct {
+	GoProxy   string   // GOPROXY list, e.g. https://athens.corp,direct
+	GoNoSumDB []string // Module path patterns the checksum database does not know
+}
+
+// IsZero reports whether the settings leave the environment's proxy alone
+func (p ProxySettings) IsZero() bool {
+	return p.GoProxy == "" && len(p.GoNoSumDB) == 0
+}
+
+// ValidateGoProxy checks a GOPROXY list: proxy URLs and the direct and off
+// keywords separated by commas or pipes
+func ValidateGoProxy(goproxy string) error {
+	entries := strings.FieldsFunc(goproxy, func(r rune) bool { return r == ',' || r == '|' })
+	if len(entries) == 0 {
+		return fmt.Errorf("invalid GOPROXY %q: list proxy URLs, direct or off", goproxy)
+	}
+
+	for _, e := range entries {
+		if e == "direct" || e == "off" {
+			continue
+		}
+
+		u, err := url.Parse(e)
+		if err != nil || u.Host == "" && u.Scheme != "file" {
+			return fmt.Errorf("invalid GOPROXY entry %q: want a URL such as https://proxy.golang.org, direct or off", e)
+		}
+
+		switch u.Scheme {
+		case "https", "http", "file":
+		default:
+			return fmt.Errorf("invalid GOPROXY entry %q: proxies are served over https, http or file", e)
+		}
+	}
+
+	return nil
+}
+
+// SetGoProxy makes the go commands of the module download through goproxy,
+// a GOPROXY list, instead of the module proxy of its profile or the
+// environment. Empty leaves those in place.
+func (m *Module) SetGoProxy(goproxy string) {
+	m.goproxy = strings.TrimSpace(goproxy)
+}
+
+// GoProxy returns the GOPROXY set with SetGoProxy
+func (m *Module) GoProxy() string {
+	return m.goproxy
+}
+
+// SetProfileProxy makes the go commands of the module use the module proxy
+// of its install profile, unless SetGoProxy names another
+func (m *Module) SetProfileProxy(p ProxySettings) {
+	m.profileProxy = p
+}
+
+// EffectiveGoProxy returns the GOPROXY the go commands of the module use:
+// the one set for it, that of its profile, or "" for the environment's
+func (m *Module) EffectiveGoProxy() string {
+	return cmp.Or(m.goproxy, m.profileProxy.GoProxy)
+}
+
+// proxyEnv returns the variable pointing the go commands of the module at
+// its module proxy. The GONOSUMDB patterns of its profile are added with
+// those of the private module config.
+func (m *Module) proxyEnv() []string {
+	if goproxy := m.EffectiveGoProxy(); goproxy != "" {
+		return []string{"GOPROXY=" + goproxy}
+	}
+
+	return nil
+}
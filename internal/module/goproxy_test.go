@@ -0,0 +1,47 @@
+package module
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestValidateGoProxy(t *testing.T) {
+	for _, goproxy := range []string{"https://athens.corp", "https://artifactory.corp/api/go/go,https://proxy.golang.org|direct", "file:///srv/goproxy", "off"} {
+		if err := ValidateGoProxy(goproxy); err != nil {
+			t.Errorf("ValidateGoProxy(%q) = %v", goproxy, err)
+		}
+	}
+
+	for _, goproxy := range []string{"", ",", "athens.corp", "ftp://athens.corp", "https://"} {
+		if err := ValidateGoProxy(goproxy); err == nil {
+			t.Errorf("ValidateGoProxy(%q) should fail", goproxy)
+		}
+	}
+}
+
+func TestProxyEnv(t *testing.T) {
+	t.Setenv("GONOSUMDB", "example.org/*")
+
+	m := &Module{}
+	if env := m.proxyEnv(); env != nil {
+		t.Errorf("proxyEnv without a proxy = %v, want nil", env)
+	}
+
+	m.SetProfileProxy(ProxySettings{GoProxy: "https://athens.corp", GoNoSumDB: []string{"corp.example.com/*"}})
+
+	env := m.goEnv()
+	if !slices.Contains(env, "GOPROXY=https://athens.corp") {
+		t.Errorf("goEnv lacks the GOPROXY of the profile: %v", env)
+	}
+
+	if !slices.Contains(env, "GONOSUMDB=example.org/*,corp.example.com/*") {
+		t.Errorf("goEnv lacks the GONOSUMDB patterns of the environment and the profile: %v", env)
+	}
+
+	// The proxy of the install wins over the profile's
+	m.SetGoProxy("https://artifactory.corp/api/go/go")
+
+	if got := m.proxyEnv(); !slices.Equal(got, []string{"GOPROXY=https://artifactory.corp/api/go/go"}) {
+		t.Errorf("proxyEnv = %v, want the GOPROXY of the install", got)
+	}
+}
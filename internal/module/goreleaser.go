@@ -1,36 +1,326 @@
 package module
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
-	"runtime"
+	"slices"
 	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
 )
 
-// hasGoReleaserConfig checks if the module has a .goreleaser.yaml or .goreleaser.yml file
-func (m *Module) hasGoReleaserConfig(ctx context.Context, moduleDir string) (bool, string, error) {
-	configs := []string{".goreleaser.yaml", ".goreleaser.yml"}
+// goReleaserConfigs are the config file names GoReleaser looks for, in its
+// order
+var goReleaserConfigs = []string{
+	".config/goreleaser.yml",
+	".config/goreleaser.yaml",
+	".goreleaser.yml",
+	".goreleaser.yaml",
+	"goreleaser.yml",
+	"goreleaser.yaml",
+}
+
+// Platforms GoReleaser builds when a build lists none
+var (
+	goReleaserDefaultGOOS   = []string{"darwin", "linux", "windows"}
+	goReleaserDefaultGOARCH = []string{"386", "amd64", "arm64"}
+)
+
+// goReleaserConfig is the part of a GoReleaser config glix builds from
+type goReleaserConfig struct {
+	ProjectName string            `yaml:"project_name"`
+	Env         []string          `yaml:"env"`
+	Builds      []goReleaserBuild `yaml:"builds"`
+	Before      struct {
+		Hooks any `yaml:"hooks"`
+	} `yaml:"before"`
+}
+
+// goReleaserBuild is a build of a GoReleaser config
+type goReleaserBuild struct {
+	ID      string   `yaml:"id"`
+	Builder string   `yaml:"builder"` // go when empty; rust, zig and others are not Go packages
+	Dir     string   `yaml:"dir"`
+	Main    string   `yaml:"main"`
+	Binary  string   `yaml:"binary"`
+	Env     []string `yaml:"env"`
+	GOOS    []string `yaml:"goos"`
+	GOARCH  []string `yaml:"goarch"`
+	Targets []string `yaml:"targets"`
+	Ignore  []struct {
+		GOOS   string `yaml:"goos"`
+		GOARCH string `yaml:"goarch"`
+	} `yaml:"ignore"`
+	Skip  any `yaml:"skip"` // true, or a template rendering to true
+	Hooks struct {
+		Pre  any `yaml:"pre"`
+		Post any `yaml:"post"`
+	} `yaml:"hooks"`
+}
+
+// findGoReleaserConfig returns the path of the GoReleaser config of a
+// module source directory, empty without one
+func findGoReleaserConfig(moduleDir string) string {
+	for _, name := range goReleaserConfigs {
+		path := filepath.Join(moduleDir, filepath.FromSlash(name))
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+
+	return ""
+}
 
+// hasGoReleaserConfig checks if the module has a GoReleaser config
+func (m *Module) hasGoReleaserConfig(ctx context.Context, moduleDir string) (bool, string, error) {
 	_ = ctx // context is not used in this function but included for consistency
 
-	for _, config := range configs {
-		configPath := filepath.Join(moduleDir, config)
-		if _, err := os.Stat(configPath); err == nil {
-			return true, configPath, nil
+	path := findGoReleaserConfig(moduleDir)
+
+	return path != "", path, nil
+}
+
+// loadGoReleaserConfig parses the GoReleaser config of a module source
+// directory, returning nil without one
+func loadGoReleaserConfig(moduleDir string) (*goReleaserConfig, error) {
+	path := findGoReleaserConfig(moduleDir)
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GoReleaser config: %w", err)
+	}
+
+	var cfg goReleaserConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+
+	return &cfg, nil
+}
+
+// goBuilds returns the builds of Go packages that are not skipped. A config
+// without builds has a single one of the package at its root.
+func (c *goReleaserConfig) goBuilds() []goReleaserBuild {
+	if len(c.Builds) == 0 {
+		return []goReleaserBuild{{}}
+	}
+
+	var builds []goReleaserBuild
+
+	for _, b := range c.Builds {
+		if (b.Builder == "" || b.Builder == "go") && !b.skipped() {
+			builds = append(builds, b)
 		}
 	}
 
-	return false, "", nil
+	return builds
+}
+
+// skipped reports whether a build is skipped, also by a template that can
+// only render to true
+func (b goReleaserBuild) skipped() bool {
+	switch skip := b.Skip.(type) {
+	case bool:
+		return skip
+	case string:
+		return strings.TrimSpace(skip) == "true"
+	}
+
+	return false
 }
 
-// findBuiltBinary finds the built binary in the dist directory
-func (m *Module) findBuiltBinary(distDir string) (string, error) {
+// pkg returns the import path of the main package a build builds. main is
+// a package directory or a file of it, relative to dir.
+func (b goReleaserBuild) pkg(rootModule string) string {
+	main := filepath.ToSlash(path.Join(b.Dir, b.Main))
+	if strings.HasSuffix(main, ".go") {
+		main = path.Dir(main)
+	}
+
+	main = path.Clean(strings.TrimPrefix(main, "./"))
+	if main == "." {
+		return rootModule
+	}
+
+	return rootModule + "/" + main
+}
+
+// builds reports whether a build produces a binary for goos/goarch
+func (b goReleaserBuild) builds(goos, goarch string) bool {
+	for _, ignored := range b.Ignore {
+		if (ignored.GOOS == "" || ignored.GOOS == goos) && (ignored.GOARCH == "" || ignored.GOARCH == goarch) {
+			return false
+		}
+	}
+
+	if len(b.Targets) > 0 {
+		return slices.ContainsFunc(b.Targets, func(target string) bool {
+			// go_first_class and go_118_first_class include every common platform
+			return strings.HasPrefix(target, "go_") || target == goos+"_"+goarch || strings.HasPrefix(target, goos+"_"+goarch+"_")
+		})
+	}
+
+	goosList, goarchList := b.GOOS, b.GOARCH
+
+	if len(goosList) == 0 {
+		goosList = goReleaserDefaultGOOS
+	}
+
+	if len(goarchList) == 0 {
+		goarchList = goReleaserDefaultGOARCH
+	}
+
+	return slices.Contains(goosList, goos) && slices.Contains(goarchList, goarch)
+}
+
+// buildFor returns the build of pkg for goos/goarch, or the first build for
+// the platform when none builds pkg
+func (c *goReleaserConfig) buildFor(rootModule, pkg, goos, goarch string) (goReleaserBuild, bool) {
+	var first *goReleaserBuild
+
+	for _, b := range c.goBuilds() {
+		if !b.builds(goos, goarch) {
+			continue
+		}
+
+		if b.pkg(rootModule) == pkg {
+			return b, true
+		}
+
+		if first == nil {
+			first = &b
+		}
+	}
+
+	if first == nil {
+		return goReleaserBuild{}, false
+	}
+
+	return *first, true
+}
+
+// projectName returns the project name of the config, which GoReleaser
+// derives from the repository when it is not set
+func (c *goReleaserConfig) projectName(rootModule string) string {
+	return cmp.Or(c.ProjectName, BinaryName(rootModule))
+}
+
+// binaryName returns the name of the binary a build produces, rendering
+// its template with the project name, target platform and environment,
+// the environment of the config and build included. The name is empty when
+// the template uses more than that.
+func (c *goReleaserConfig) binaryName(b goReleaserBuild, rootModule, goos, goarch string) string {
+	project := c.projectName(rootModule)
+
+	if b.Binary == "" {
+		return project
+	}
+
+	env := make(map[string]string)
+
+	for _, kv := range os.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+
+	data := map[string]any{
+		"ProjectName": project,
+		"Os":          goos,
+		"Arch":        goarch,
+		"Env":         env,
+	}
+
+	// Entries of env are templates themselves and see the ones before them
+	for _, kv := range slices.Concat(c.Env, b.Env) {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = renderGoReleaserTemplate(v, data)
+		}
+	}
+
+	return renderGoReleaserTemplate(b.Binary, data)
+}
+
+// renderGoReleaserTemplate renders a GoReleaser template with data,
+// returning "" if it uses fields or functions data does not provide
+func renderGoReleaserTemplate(text string, data map[string]any) string {
+	if !strings.Contains(text, "{{") {
+		return text
+	}
+
+	tmpl, err := template.New("goreleaser").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return ""
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return ""
+	}
+
+	return out.String()
+}
+
+// preHooks returns the commands GoReleaser runs before building b: the
+// before hooks of the config and the pre hooks of b
+func (c *goReleaserConfig) preHooks(b goReleaserBuild) []string {
+	return append(hookCommands(c.Before.Hooks), hookCommands(b.Hooks.Pre)...)
+}
+
+// hookCommands returns the commands of a hooks entry: a command, or a list
+// of commands or of objects with a cmd
+func hookCommands(hooks any) []string {
+	var commands []string
+
+	switch h := hooks.(type) {
+	case string:
+		commands = append(commands, h)
+	case []any:
+		for _, entry := range h {
+			switch e := entry.(type) {
+			case string:
+				commands = append(commands, e)
+			case map[string]any:
+				if cmd, ok := e["cmd"].(string); ok {
+					commands = append(commands, cmd)
+				}
+			}
+		}
+	}
+
+	return commands
+}
+
+// findBuiltBinary finds the binary of build in the dist directory:
+// dist/<id>_<goos>_<goarch>[_<variant>]/<binary>. Without the id and
+// binary name, it takes the first executable for the platform.
+func (m *Module) findBuiltBinary(distDir, id, binary string) (string, error) {
 	// Determine the expected binary pattern based on OS/ARCH
-	goos := runtime.GOOS
-	goarch := runtime.GOARCH
+	goos := m.goos()
+	goarch := m.goarch()
+
+	if binary != "" {
+		if goos == "windows" && !strings.HasSuffix(binary, ".exe") {
+			binary += ".exe"
+		}
+
+		dirs, _ := filepath.Glob(filepath.Join(distDir, cmp.Or(id, "*")+"_"+goos+"_"+goarch+"*"))
+		for _, dir := range dirs {
+			path := filepath.Join(dir, filepath.FromSlash(binary))
+			if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+				return path, nil
+			}
+		}
+	}
 
 	// Common patterns for goreleaser output
 	patterns := []string{
@@ -51,7 +341,7 @@ func (m *Module) findBuiltBinary(distDir string) (string, error) {
 
 		// Skip checksum files, archives, etc.
 		ext := strings.ToLower(filepath.Ext(path))
-		if ext == ".txt" || ext == ".md" || ext == ".tar" || ext == ".gz" || ext == ".zip" {
+		if ext == ".txt" || ext == ".md" || ext == ".tar" || ext == ".gz" || ext == ".zip" || ext == ".json" || ext == ".yaml" {
 			return nil
 		}
 
@@ -89,7 +379,9 @@ func (m *Module) findBuiltBinary(distDir string) (string, error) {
 	return foundBinary, nil
 }
 
-// copyFile copies a file from src to dst
+// copyFile copies a file from src to dst. An existing dst is unlinked rather
+// than overwritten, so a binary hard-linked into the binary cache is never
+// written through.
 func copyFile(src, dst string) error {
 	sourceFile, err := os.Open(src)
 	if err != nil {
@@ -100,6 +392,10 @@ func copyFile(src, dst string) error {
 		_ = sourceFile.Close()
 	}()
 
+	if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
 	destFile, err := os.Create(dst)
 	if err != nil {
 		return err
@@ -124,8 +420,9 @@ func copyDir(src, dst string) error {
 		return fmt.Errorf("failed to stat source directory: %w", err)
 	}
 
-	// Create destination directory
-	if err := os.MkdirAll(dst, srcInfo.Mode()); err != nil {
+	// Create destination directory, writable for builds although the module
+	// cache is read-only
+	if err := os.MkdirAll(dst, srcInfo.Mode()|0700); err != nil {
 		return fmt.Errorf("failed to create destination directory: %w", err)
 	}
 
@@ -0,0 +1,159 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const testGoReleaserConfig = `version: 2
+project_name: tool
+env:
+  - SUFFIX=cli
+before:
+  hooks:
+    - go mod tidy
+    - go generate ./...
+builds:
+  - id: server
+    dir: server
+    main: ./cmd/server/main.go
+    binary: tool-server
+    goos: [linux]
+  - id: cli
+    main: ./cmd/tool
+    binary: "{{ .ProjectName }}-{{ .Env.SUFFIX }}"
+    env:
+      - CGO_ENABLED=0
+    goos: [linux, darwin, windows]
+    goarch: [amd64, arm64]
+    ignore:
+      - goos: windows
+        goarch: arm64
+    hooks:
+      pre:
+        - cmd: make assets
+  - id: docs
+    builder: prebuilt
+    main: ./docs
+  - id: legacy
+    main: ./cmd/legacy
+    skip: true
+`
+
+func writeGoReleaserConfig(t *testing.T, name, content string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func TestDiscoverFromGoReleaser(t *testing.T) {
+	dir := writeGoReleaserConfig(t, ".config/goreleaser.yml", testGoReleaserConfig)
+
+	got := discoverFromGoReleaser(dir, "example.com/tool")
+	want := []string{"example.com/tool/server/cmd/server", "example.com/tool/cmd/tool"}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("discoverFromGoReleaser() = %v, want %v", got, want)
+	}
+
+	// A config without builds builds the root package only
+	dir = writeGoReleaserConfig(t, ".goreleaser.yaml", "project_name: tool\n")
+	if got := discoverFromGoReleaser(dir, "example.com/tool"); len(got) != 0 {
+		t.Errorf("discoverFromGoReleaser() without builds = %v, want none", got)
+	}
+}
+
+func TestGoReleaserBuildFor(t *testing.T) {
+	cfg, err := loadGoReleaserConfig(writeGoReleaserConfig(t, "goreleaser.yaml", testGoReleaserConfig))
+	if err != nil || cfg == nil {
+		t.Fatalf("loadGoReleaserConfig() = %v, %v", cfg, err)
+	}
+
+	tests := []struct {
+		pkg, goos, goarch string
+		want              string
+		ok                bool
+	}{
+		{"example.com/tool/cmd/tool", "linux", "amd64", "cli", true},
+		{"example.com/tool/server/cmd/server", "linux", "amd64", "server", true},
+		{"example.com/tool/server/cmd/server", "darwin", "arm64", "cli", true}, // No server build for darwin
+		{"example.com/tool", "linux", "386", "server", true},                   // Default goarch
+		{"example.com/tool/cmd/tool", "windows", "arm64", "", false},           // Ignored
+		{"example.com/tool/cmd/legacy", "freebsd", "amd64", "", false},
+	}
+
+	for _, tt := range tests {
+		build, ok := cfg.buildFor("example.com/tool", tt.pkg, tt.goos, tt.goarch)
+		if ok != tt.ok || build.ID != tt.want {
+			t.Errorf("buildFor(%s, %s/%s) = %q, %v; want %q, %v", tt.pkg, tt.goos, tt.goarch, build.ID, ok, tt.want, tt.ok)
+		}
+	}
+
+	cli, _ := cfg.buildFor("example.com/tool", "example.com/tool/cmd/tool", "linux", "amd64")
+
+	if got := cfg.binaryName(cli, "example.com/tool", "linux", "amd64"); got != "tool-cli" {
+		t.Errorf("binaryName() = %q, want tool-cli", got)
+	}
+
+	if got := cfg.preHooks(cli); !slices.Equal(got, []string{"go mod tidy", "go generate ./...", "make assets"}) {
+		t.Errorf("preHooks() = %q", got)
+	}
+
+	// Templates using more than glix knows name no binary
+	cli.Binary = "{{ .Version }}"
+	if got := cfg.binaryName(cli, "example.com/tool", "linux", "amd64"); got != "" {
+		t.Errorf("binaryName() of an unknown field = %q, want empty", got)
+	}
+
+	// Targets replace goos and goarch
+	targeted := goReleaserBuild{Targets: []string{"linux_amd64_v1", "darwin_arm64"}}
+	if !targeted.builds("linux", "amd64") || !targeted.builds("darwin", "arm64") || targeted.builds("linux", "arm64") {
+		t.Error("builds() does not honor targets")
+	}
+}
+
+func TestFindBuiltBinary(t *testing.T) {
+	dist := t.TempDir()
+
+	for _, name := range []string{
+		"server_linux_amd64_v1/tool-server",
+		"cli_linux_amd64_v1/tool-cli",
+		"cli_darwin_arm64/tool-cli",
+	} {
+		path := filepath.Join(dist, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte("binary"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := &Module{}
+	m.SetPlatform("linux", "amd64")
+
+	got, err := m.findBuiltBinary(dist, "cli", "tool-cli")
+	if err != nil || got != filepath.Join(dist, "cli_linux_amd64_v1", "tool-cli") {
+		t.Errorf("findBuiltBinary() = %q, %v; want the cli build", got, err)
+	}
+
+	// Without an id, any build directory of the platform is searched
+	got, err = m.findBuiltBinary(dist, "", "tool-server")
+	if err != nil || got != filepath.Join(dist, "server_linux_amd64_v1", "tool-server") {
+		t.Errorf("findBuiltBinary() without id = %q, %v; want the server build", got, err)
+	}
+}
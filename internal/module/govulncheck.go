@@ -0,0 +1,151 @@
+package module
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	osExec "os/exec"
+	"sort"
+	"strings"
+
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/inovacc/glix/pkg/exec"
+)
+
+// GovulncheckModule is the package providing govulncheck
+const GovulncheckModule = "golang.org/x/vuln/cmd/govulncheck"
+
+// ErrNoGovulncheck means govulncheck is neither on PATH nor in GOBIN
+var ErrNoGovulncheck = fmt.Errorf("govulncheck not found; install it with 'glix install %s'", GovulncheckModule)
+
+// FindGovulncheck returns the path of govulncheck, looked up on PATH and
+// then in GOBIN
+func FindGovulncheck() (string, error) {
+	if path, err := osExec.LookPath("govulncheck"); err == nil {
+		return path, nil
+	}
+
+	path := InstalledBinaryPath(GovulncheckModule)
+	if _, err := os.Stat(path); err != nil {
+		return "", ErrNoGovulncheck
+	}
+
+	return path, nil
+}
+
+// ScanBinary runs govulncheck on a binary and returns the known
+// vulnerabilities of the modules and Go version it was built with, those
+// the binary calls first
+func ScanBinary(ctx context.Context, binPath string) ([]*pb.VulnFindingProto, error) {
+	tool, err := FindGovulncheck()
+	if err != nil {
+		return nil, err
+	}
+
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, tool, "-mode=binary", "-format=json", binPath)
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("govulncheck failed: %w: %s", err, msg)
+		}
+
+		return nil, fmt.Errorf("govulncheck failed: %w", err)
+	}
+
+	return parseGovulncheck(bytes.NewReader(out))
+}
+
+// govulncheckMessage is a message of the JSON stream govulncheck writes
+// with -format=json; messages of other kinds are ignored
+type govulncheckMessage struct {
+	OSV *struct {
+		ID      string   `json:"id"`
+		Summary string   `json:"summary"`
+		Aliases []string `json:"aliases"`
+	} `json:"osv"`
+	Finding *struct {
+		OSV          string `json:"osv"`
+		FixedVersion string `json:"fixed_version"`
+		Trace        []struct {
+			Module   string `json:"module"`
+			Version  string `json:"version"`
+			Function string `json:"function"`
+		} `json:"trace"`
+	} `json:"finding"`
+}
+
+// parseGovulncheck reads the findings of govulncheck's JSON stream, one per
+// vulnerability. govulncheck reports a vulnerability at the module, package
+// and symbol level; it counts as called when any finding names a function.
+func parseGovulncheck(r io.Reader) ([]*pb.VulnFindingProto, error) {
+	findings := make(map[string]*pb.VulnFindingProto)
+	dec := json.NewDecoder(r)
+
+	finding := func(id string) *pb.VulnFindingProto {
+		f, ok := findings[id]
+		if !ok {
+			f = &pb.VulnFindingProto{Id: id}
+			findings[id] = f
+		}
+
+		return f
+	}
+
+	for {
+		var msg govulncheckMessage
+
+		err := dec.Decode(&msg)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse govulncheck output: %w", err)
+		}
+
+		if msg.OSV != nil {
+			f := finding(msg.OSV.ID)
+			f.Summary = msg.OSV.Summary
+			f.Aliases = msg.OSV.Aliases
+		}
+
+		if msg.Finding != nil && msg.Finding.OSV != "" {
+			f := finding(msg.Finding.OSV)
+			f.FixedVersion = msg.Finding.FixedVersion
+
+			// The first frame is the vulnerable one
+			if trace := msg.Finding.Trace; len(trace) > 0 {
+				f.Module = trace[0].Module
+				f.FoundVersion = trace[0].Version
+				f.Called = f.Called || trace[0].Function != ""
+			}
+		}
+	}
+
+	// Entries of vulnerabilities without findings do not affect the binary
+	result := make([]*pb.VulnFindingProto, 0, len(findings))
+
+	for _, f := range findings {
+		if f.GetModule() != "" {
+			result = append(result, f)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].GetCalled() != result[j].GetCalled() {
+			return result[i].GetCalled()
+		}
+
+		return result[i].GetId() < result[j].GetId()
+	})
+
+	return result, nil
+}
@@ -0,0 +1,48 @@
+package module
+
+import (
+	"strings"
+	"testing"
+)
+
+// govulncheckStream is -format=json output of govulncheck -mode=binary: a
+// called vulnerability reported at every level, one only required, and an
+// entry without findings
+const govulncheckStream = `{"config":{"protocol_version":"v1.0.0","scanner_name":"govulncheck","scan_level":"symbol"}}
+{"progress":{"message":"Scanning your binary for known vulnerabilities..."}}
+{"osv":{"id":"GO-2024-2687","summary":"HTTP/2 CONTINUATION flood in net/http","aliases":["CVE-2023-45288","GHSA-4v7x-pqxf-cx7m"]}}
+{"osv":{"id":"GO-2023-1988","summary":"Improper rendering of text nodes in golang.org/x/net/html"}}
+{"osv":{"id":"GO-2022-0001","summary":"Not in this binary"}}
+{"finding":{"osv":"GO-2023-1988","fixed_version":"v0.13.0","trace":[{"module":"golang.org/x/net","version":"v0.10.0"}]}}
+{"finding":{"osv":"GO-2024-2687","fixed_version":"v0.23.0","trace":[{"module":"golang.org/x/net","version":"v0.10.0"}]}}
+{"finding":{"osv":"GO-2024-2687","fixed_version":"v0.23.0","trace":[{"module":"golang.org/x/net","version":"v0.10.0","package":"golang.org/x/net/http2"}]}}
+{"finding":{"osv":"GO-2024-2687","fixed_version":"v0.23.0","trace":[{"module":"golang.org/x/net","version":"v0.10.0","package":"golang.org/x/net/http2","function":"ReadFrame","receiver":"*Framer"},{"module":"example.com/tool","package":"main","function":"main"}]}}
+`
+
+func TestParseGovulncheck(t *testing.T) {
+	findings, err := parseGovulncheck(strings.NewReader(govulncheckStream))
+	if err != nil {
+		t.Fatalf("parseGovulncheck: %v", err)
+	}
+
+	if len(findings) != 2 {
+		t.Fatalf("got %d findings, want 2: %v", len(findings), findings)
+	}
+
+	called := findings[0]
+	if called.GetId() != "GO-2024-2687" || !called.GetCalled() || called.GetModule() != "golang.org/x/net" ||
+		called.GetFoundVersion() != "v0.10.0" || called.GetFixedVersion() != "v0.23.0" || len(called.GetAliases()) != 2 {
+		t.Errorf("called finding = %v", called)
+	}
+
+	required := findings[1]
+	if required.GetId() != "GO-2023-1988" || required.GetCalled() || required.GetSummary() == "" {
+		t.Errorf("required finding = %v", required)
+	}
+}
+
+func TestParseGovulncheckInvalid(t *testing.T) {
+	if _, err := parseGovulncheck(strings.NewReader(`{"osv":`)); err == nil {
+		t.Error("parseGovulncheck succeeded on truncated output")
+	}
+}
@@ -0,0 +1,103 @@
+package module
+
+import (
+	"fmt"
+	"os"
+	osExec "os/exec"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// HeartbeatInterval is how long a build may print nothing before a
+	// heartbeat reports that it is still running
+	HeartbeatInterval = time.Minute
+
+	// DefaultStallThreshold is how long a build may print nothing before it
+	// is reported as possibly stalled
+	DefaultStallThreshold = 10 * time.Minute
+)
+
+// StallThreshold returns the stall threshold set with GLIX_STALL_THRESHOLD,
+// such as "15m", or DefaultStallThreshold
+func StallThreshold() time.Duration {
+	if d, err := time.ParseDuration(os.Getenv("GLIX_STALL_THRESHOLD")); err == nil && d > 0 {
+		return d
+	}
+
+	return DefaultStallThreshold
+}
+
+// SetStallThreshold sets how long builds may print nothing before a warning
+// reports them as possibly stalled
+func (m *Module) SetStallThreshold(d time.Duration) {
+	m.stallThreshold = d
+}
+
+// watchdog reports a child process that has been silent for a while: a
+// heartbeat every interval, and a warning with the state of the process once
+// the silence reaches the stall threshold
+type watchdog struct {
+	interval time.Duration
+	stall    time.Duration
+	progress ProgressHandler
+	last     atomic.Int64 // Unix nanoseconds of the last output line
+}
+
+// watchdog returns the watchdog for the builds of m
+func (m *Module) watchdog() *watchdog {
+	stall := m.stallThreshold
+	if stall <= 0 {
+		stall = StallThreshold()
+	}
+
+	interval := m.heartbeat
+	if interval <= 0 {
+		interval = HeartbeatInterval
+	}
+
+	return &watchdog{interval: interval, stall: stall, progress: m.progress}
+}
+
+// touch records output from the child process
+func (w *watchdog) touch() {
+	w.last.Store(time.Now().UnixNano())
+}
+
+// watch checks cmd for silence until done is closed
+func (w *watchdog) watch(cmd *osExec.Cmd, done <-chan struct{}) {
+	name := filepath.Base(cmd.Path)
+	if len(cmd.Args) > 1 {
+		name += " " + cmd.Args[1]
+	}
+
+	started := time.Now()
+	w.last.Store(started.UnixNano())
+
+	ticker := time.NewTicker(min(w.interval, w.stall))
+	defer ticker.Stop()
+
+	var warned int64 // Last output time of the silence already warned about
+
+	for {
+		select {
+		case <-done:
+			return
+		case now := <-ticker.C:
+			last := w.last.Load()
+			silent := now.Sub(time.Unix(0, last))
+
+			switch {
+			case silent >= w.stall && warned != last:
+				warned = last
+
+				w.progress("warning", fmt.Sprintf("%s has printed nothing for %s and may be stalled (%s); press Ctrl+C to cancel",
+					name, silent.Round(time.Second), processState(cmd.Process.Pid)))
+			case silent >= w.interval:
+				w.progress("heartbeat", fmt.Sprintf("%s still running: %s elapsed, no output for %s",
+					name, now.Sub(started).Round(time.Second), silent.Round(time.Second)))
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package module
+
+import (
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRunWithStreaming_Watchdog(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	var (
+		mu     sync.Mutex
+		events []string
+	)
+
+	m := &Module{
+		heartbeat:      50 * time.Millisecond,
+		stallThreshold: 200 * time.Millisecond,
+		progressHandler: func(phase, message string) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			events = append(events, phase+": "+message)
+		},
+	}
+
+	// Silent past the stall threshold, then silent again for less than it
+	cmd := exec.Command("sh", "-c", "sleep 0.4; echo done; sleep 0.15")
+	if err := runWithStreaming(cmd, nil, m.watchdog()); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	var heartbeats, warnings int
+
+	for _, event := range events {
+		switch {
+		case strings.HasPrefix(event, "heartbeat: sh -c still running"):
+			heartbeats++
+		case strings.HasPrefix(event, "warning: sh -c has printed nothing"):
+			warnings++
+
+			if !strings.Contains(event, "pid ") {
+				t.Errorf("warning %q does not describe the process", event)
+			}
+		default:
+			t.Errorf("unexpected event %q", event)
+		}
+	}
+
+	if heartbeats == 0 || warnings != 1 {
+		t.Errorf("got %d heartbeats and %d warnings, want some heartbeats and one warning: %q", heartbeats, warnings, events)
+	}
+}
+
+func TestStallThreshold(t *testing.T) {
+	t.Setenv("GLIX_STALL_THRESHOLD", "")
+
+	if got := StallThreshold(); got != DefaultStallThreshold {
+		t.Errorf("StallThreshold() = %s, want the default", got)
+	}
+
+	t.Setenv("GLIX_STALL_THRESHOLD", "90s")
+
+	if got := StallThreshold(); got != 90*time.Second {
+		t.Errorf("StallThreshold() = %s, want 1m30s", got)
+	}
+}
@@ -0,0 +1,307 @@
+package module
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/inovacc/glix/pkg/exec"
+	modpath "golang.org/x/mod/module"
+)
+
+// Limits of what is captured of a tool, so a chatty or hanging binary or a
+// module full of generated pages cannot fill the disk or block an install
+const (
+	helpTimeout    = 10 * time.Second
+	maxHelpOutput  = 256 << 10
+	maxManPageSize = 1 << 20
+	maxManPages    = 50
+)
+
+// manPageFile matches roff man page names such as tool.1 or tool-sub.8x
+var manPageFile = regexp.MustCompile(`^[A-Za-z0-9][\w.+-]*\.[1-9][a-z]*$`)
+
+// HelpDoc is the --help output and man pages of an installed tool, captured
+// at install time to be read offline
+type HelpDoc struct {
+	Module   string    `json:"module"`
+	Version  string    `json:"version"`
+	Binary   string    `json:"binary"`
+	Help     string    `json:"help,omitempty"`
+	ManPages []ManPage `json:"man_pages,omitempty"`
+	Captured time.Time `json:"captured"`
+}
+
+// ManPage is a man page shipped in the source of a module
+type ManPage struct {
+	File    string `json:"file"` // Slash-separated path in the module
+	Content string `json:"content"`
+}
+
+// Name returns the file name of the man page, such as tool.1
+func (p ManPage) Name() string {
+	return filepath.Base(filepath.FromSlash(p.File))
+}
+
+// ManPage returns the man page of the doc named name, or without a name the
+// one of the binary, else the first
+func (d *HelpDoc) ManPage(name string) (ManPage, bool) {
+	for _, p := range d.ManPages {
+		if name != "" && (p.Name() == name || p.File == name) {
+			return p, true
+		}
+
+		if name == "" && strings.HasPrefix(p.Name(), d.Binary+".") {
+			return p, true
+		}
+	}
+
+	if name == "" && len(d.ManPages) > 0 {
+		return d.ManPages[0], true
+	}
+
+	return ManPage{}, false
+}
+
+// helpCacheRoot returns the directory holding captured help
+func helpCacheRoot() string {
+	return filepath.Join(GetApplicationDirectory(), "help")
+}
+
+// CaptureHelp runs the installed binary with --help and collects the man
+// pages in the module source, storing both for LoadHelp. Binaries built for
+// another platform cannot run here and only get their man pages captured.
+func (m *Module) CaptureHelp(ctx context.Context) (*HelpDoc, error) {
+	doc := &HelpDoc{
+		Module:   m.Name,
+		Version:  m.Version,
+		Binary:   strings.TrimSuffix(filepath.Base(m.BinaryPath), ".exe"),
+		Captured: time.Now(),
+	}
+
+	if m.Platform() == "" && m.BinaryPath != "" {
+		doc.Help = runHelp(ctx, m.BinaryPath)
+	}
+
+	dir := m.LocalPath
+	if dir == "" {
+		var err error
+		if dir, err = m.getModuleSourceDir(ctx); err != nil {
+			return nil, fmt.Errorf("failed to get module source: %w", err)
+		}
+	}
+
+	pages, err := findManPages(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	doc.ManPages = pages
+
+	if doc.Help == "" && len(doc.ManPages) == 0 {
+		return nil, fmt.Errorf("%s printed no help and ships no man pages", doc.Binary)
+	}
+
+	if err := saveHelp(helpCacheRoot(), doc); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// runHelp returns what a binary prints for --help, or for -h when --help
+// prints nothing. Tools exiting non-zero after printing usage are common,
+// so only the output counts.
+func runHelp(ctx context.Context, binary string) string {
+	for _, flag := range []string{"--help", "-h"} {
+		runCtx, cancel := context.WithTimeout(ctx, helpTimeout)
+
+		var out limitedBuffer
+
+		out.limit = maxHelpOutput
+
+		cmd := exec.CommandContext(runCtx, binary, flag)
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+		cmd.Env = append(os.Environ(), "NO_COLOR=1", "TERM=dumb")
+
+		_ = cmd.Run()
+
+		cancel()
+
+		if help := strings.TrimSpace(out.String()); help != "" {
+			return help + "\n"
+		}
+	}
+
+	return ""
+}
+
+// limitedBuffer keeps the first limit bytes written to it
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); room > 0 {
+		b.Buffer.Write(p[:min(len(p), room)])
+	}
+
+	return len(p), nil
+}
+
+// findManPages returns the roff man pages in a module source directory,
+// recognized by their name and a .TH or .Dd macro
+func findManPages(dir string) ([]ManPage, error) {
+	var pages []ManPage
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		if d.IsDir() {
+			if path != dir && (strings.HasPrefix(d.Name(), ".") || d.Name() == "vendor" || d.Name() == "testdata" || d.Name() == "node_modules") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !d.Type().IsRegular() || !manPageFile.MatchString(d.Name()) {
+			return nil
+		}
+
+		if info, err := d.Info(); err != nil || info.Size() > maxManPageSize {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil || !isRoff(data) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return nil
+		}
+
+		pages = append(pages, ManPage{File: filepath.ToSlash(rel), Content: string(data)})
+
+		if len(pages) == maxManPages {
+			return filepath.SkipAll
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search man pages: %w", err)
+	}
+
+	return pages, nil
+}
+
+// isRoff reports whether data is a man page, with a man(7) title or mdoc(7)
+// date macro on one of its lines
+func isRoff(data []byte) bool {
+	for line := range bytes.Lines(data) {
+		if bytes.HasPrefix(line, []byte(".TH ")) || bytes.HasPrefix(line, []byte(".Dd ")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func saveHelp(root string, doc *HelpDoc) error {
+	file, err := versionCacheFile(root, doc.Module, doc.Version)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to encode help: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return fmt.Errorf("failed to create help directory: %w", err)
+	}
+
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		return fmt.Errorf("failed to store help: %w", err)
+	}
+
+	return nil
+}
+
+// LoadHelp returns the help captured for a module version
+func LoadHelp(name, version string) (*HelpDoc, error) {
+	file, err := versionCacheFile(helpCacheRoot(), name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no help was captured for %s@%s; reinstall it with --capture-help", name, version)
+		}
+
+		return nil, fmt.Errorf("failed to read help: %w", err)
+	}
+
+	var doc HelpDoc
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse help: %w", err)
+	}
+
+	return &doc, nil
+}
+
+// helpVersionsDir returns the directory holding the help captured for the
+// versions of a module
+func helpVersionsDir(name string) (string, error) {
+	escaped, err := modpath.EscapePath(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid module path %q: %w", name, err)
+	}
+
+	return filepath.Join(helpCacheRoot(), filepath.FromSlash(escaped), "@v"), nil
+}
+
+// HasHelp reports whether help was captured for any version of a module,
+// which updates take as asking to capture it for the new version too
+func HasHelp(name string) bool {
+	dir, err := helpVersionsDir(name)
+	if err != nil {
+		return false
+	}
+
+	entries, err := os.ReadDir(dir)
+
+	return err == nil && len(entries) > 0
+}
+
+// RemoveHelp deletes the help captured for every version of a module.
+// Modules nested in its path keep theirs.
+func RemoveHelp(name string) error {
+	dir, err := helpVersionsDir(name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to remove captured help: %w", err)
+	}
+
+	return nil
+}
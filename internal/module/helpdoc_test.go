@@ -0,0 +1,100 @@
+package module
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestFindManPages(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"man/tool.1":             ".TH TOOL 1\n.SH NAME\ntool \\- does things\n",
+		"docs/tool-sub.8":        ".\\\" comment\n.Dd January 1, 2026\n.Dt TOOL-SUB 8\n",
+		"docs/notes.1":           "Not a man page\n",
+		"vendor/dep/dep.1":       ".TH DEP 1\n",
+		".github/workflow.1":     ".TH WORKFLOW 1\n",
+		"testdata/fixture.1":     ".TH FIXTURE 1\n",
+		"cmd/tool/main.go":       "package main\n",
+		"docs/tool.1.md":         "# tool(1)\n",
+		"docs/man/tool-extra.1x": ".TH TOOL-EXTRA 1x\n",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pages, err := findManPages(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, p := range pages {
+		got = append(got, p.File)
+	}
+
+	want := "docs/man/tool-extra.1x docs/tool-sub.8 man/tool.1"
+	if strings.Join(got, " ") != want {
+		t.Errorf("findManPages() = %v, want %s", got, want)
+	}
+
+	doc := &HelpDoc{Binary: "tool", ManPages: pages}
+
+	if p, ok := doc.ManPage(""); !ok || p.File != "man/tool.1" {
+		t.Errorf("ManPage() = %q, %v; want the page of the binary", p.File, ok)
+	}
+
+	if p, ok := doc.ManPage("tool-sub.8"); !ok || p.File != "docs/tool-sub.8" {
+		t.Errorf("ManPage(tool-sub.8) = %q, %v", p.File, ok)
+	}
+
+	if _, ok := doc.ManPage("missing.1"); ok {
+		t.Error("ManPage() found a missing page")
+	}
+}
+
+func TestRunHelp(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the tool")
+	}
+
+	dir := t.TempDir()
+
+	// Like many tools, this one only knows -h and exits non-zero after usage
+	script := filepath.Join(dir, "tool")
+	content := "#!/bin/sh\nif [ \"$1\" = \"-h\" ]; then echo 'Usage: tool [flags]' >&2; exit 2; fi\nexit 1\n"
+
+	if err := os.WriteFile(script, []byte(content), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := runHelp(context.Background(), script); got != "Usage: tool [flags]\n" {
+		t.Errorf("runHelp() = %q", got)
+	}
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	b := limitedBuffer{limit: 5}
+
+	for _, s := range []string{"abc", "defg", "h"} {
+		if n, err := b.Write([]byte(s)); n != len(s) || err != nil {
+			t.Errorf("Write(%q) = %d, %v", s, n, err)
+		}
+	}
+
+	if b.String() != "abcde" {
+		t.Errorf("kept %q, want abcde", b.String())
+	}
+}
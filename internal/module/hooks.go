@@ -0,0 +1,106 @@
+package module
+
+import "context"
+
+// HookEvent names when user-defined hooks run
+type HookEvent string
+
+const (
+	HookPreInstall  HookEvent = "pre-install"
+	HookPostInstall HookEvent = "post-install"
+	HookPreUpdate   HookEvent = "pre-update"
+	HookPostUpdate  HookEvent = "post-update"
+	HookPreRemove   HookEvent = "pre-remove"
+	HookPostRemove  HookEvent = "post-remove"
+)
+
+// HookEvents lists the events hooks can run on, in the order they happen
+var HookEvents = []HookEvent{HookPreInstall, HookPostInstall, HookPreUpdate, HookPostUpdate, HookPreRemove, HookPostRemove}
+
+// Pre reports whether the event happens before the operation, which a
+// failing hook aborts
+func (e HookEvent) Pre() bool {
+	return e == HookPreInstall || e == HookPreUpdate || e == HookPreRemove
+}
+
+// HookInfo describes the module a hook runs for
+type HookInfo struct {
+	Module          string
+	RootModule      string
+	Version         string
+	PreviousVersion string // Version an update replaces
+	Binary          string // Installed binary, or where it is about to be placed
+	BinaryHash      string // Hash of the installed binary, after it is placed
+}
+
+// Env returns the environment variables describing the module to a hook of
+// event. Variables without a value are left out.
+func (i HookInfo) Env(event HookEvent) []string {
+	env := []string{"GLIX_HOOK_EVENT=" + string(event)}
+
+	for _, v := range []struct{ name, value string }{
+		{"GLIX_MODULE", i.Module},
+		{"GLIX_ROOT_MODULE", i.RootModule},
+		{"GLIX_VERSION", i.Version},
+		{"GLIX_PREVIOUS_VERSION", i.PreviousVersion},
+		{"GLIX_BINARY", i.Binary},
+		{"GLIX_BINARY_HASH", i.BinaryHash},
+	} {
+		if v.value != "" {
+			env = append(env, v.name+"="+v.value)
+		}
+	}
+
+	return env
+}
+
+// HookRunner runs the hooks of an event for a module, reporting their
+// output to progress. An error of a pre hook aborts the operation.
+type HookRunner func(ctx context.Context, event HookEvent, info HookInfo, progress ProgressHandler) error
+
+// SetHooks makes installs run the hooks of run before they build and after
+// they placed the binary
+func (m *Module) SetHooks(run HookRunner) {
+	m.hooks = run
+}
+
+// SetPreviousVersion records the installed version an install replaces,
+// making it an update to its hooks
+func (m *Module) SetPreviousVersion(version string) {
+	m.previousVersion = version
+}
+
+// RunHooks runs the pre or post hooks of the install, which are update
+// hooks when it replaces an installed version. Installs run them on their
+// own; callers that place binaries themselves, as bundle installs do, run
+// them around that step.
+func (m *Module) RunHooks(ctx context.Context, pre bool) error {
+	if m.hooks == nil {
+		return nil
+	}
+
+	event := HookPostInstall
+
+	switch {
+	case pre && m.previousVersion != "":
+		event = HookPreUpdate
+	case pre:
+		event = HookPreInstall
+	case m.previousVersion != "":
+		event = HookPostUpdate
+	}
+
+	info := HookInfo{
+		Module:          m.Name,
+		RootModule:      m.RootModule,
+		Version:         m.Version,
+		PreviousVersion: m.previousVersion,
+		Binary:          m.InstallPath(),
+	}
+
+	if !pre {
+		info.Binary, info.BinaryHash = m.BinaryPath, m.BinaryHash
+	}
+
+	return m.hooks(ctx, event, info, m.progress)
+}
@@ -0,0 +1,55 @@
+package module
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestRunHooks(t *testing.T) {
+	m := &Module{Name: "example.com/tool", Version: "v1.1.0", BinaryPath: "/bin/tool", BinaryHash: "sha256:abc", binDir: "/bin"}
+
+	// No hooks, nothing to run
+	if err := m.RunHooks(context.Background(), true); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		events []HookEvent
+		infos  []HookInfo
+	)
+
+	m.SetHooks(func(_ context.Context, event HookEvent, info HookInfo, _ ProgressHandler) error {
+		events = append(events, event)
+		infos = append(infos, info)
+
+		return nil
+	})
+
+	for _, previous := range []string{"", "v1.0.0"} {
+		m.SetPreviousVersion(previous)
+
+		for _, pre := range []bool{true, false} {
+			if err := m.RunHooks(context.Background(), pre); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if want := []HookEvent{HookPreInstall, HookPostInstall, HookPreUpdate, HookPostUpdate}; !slices.Equal(events, want) {
+		t.Errorf("events = %v, want %v", events, want)
+	}
+
+	if infos[0].BinaryHash != "" || infos[1].BinaryHash != "sha256:abc" || infos[3].PreviousVersion != "v1.0.0" {
+		t.Errorf("hook infos = %+v", infos)
+	}
+}
+
+func TestHookInfoEnv(t *testing.T) {
+	env := HookInfo{Module: "example.com/tool", Version: "v1.1.0"}.Env(HookPostInstall)
+
+	want := []string{"GLIX_HOOK_EVENT=post-install", "GLIX_MODULE=example.com/tool", "GLIX_VERSION=v1.1.0"}
+	if !slices.Equal(env, want) {
+		t.Errorf("Env = %q, want %q", env, want)
+	}
+}
@@ -0,0 +1,67 @@
+package module
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Info describes a remote module as resolved through the module proxy
+type Info struct {
+	Path       string    `json:"path"`
+	RootModule string    `json:"root_module"`
+	Latest     string    `json:"latest"`
+	Published  time.Time `json:"published"`
+	Versions   []string  `json:"versions"` // Newest first
+	HasMain    bool      `json:"has_main"` // Path itself is a main package
+	CLIPaths   []string  `json:"cli_paths"`
+}
+
+// Inspect runs version resolution and CLI discovery for a module without
+// installing it. The module is downloaded into the module cache, but nothing
+// is built, written to GOBIN or recorded.
+func (m *Module) Inspect(path string) (*Info, error) {
+	path, _ = m.splitModuleVersion(m.normalizeModulePath(path))
+
+	ctx, cancel := context.WithTimeout(m.ctx, m.getTimeout())
+	defer cancel()
+
+	m.progress("init", "Initializing workspace...")
+
+	if err := m.setupTempModule(ctx); err != nil {
+		return nil, err
+	}
+
+	m.progress("versions", "Fetching available versions...")
+
+	result, err := m.fetchModuleVersions(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &Info{
+		Path:       path,
+		RootModule: result.RootModule,
+		Latest:     result.ListResp.Version,
+		Published:  result.ListResp.Time,
+		Versions:   result.ListResp.Versions,
+	}
+
+	m.progress("download", "Downloading module...")
+
+	if err := m.getModule(ctx, fmt.Sprintf("%s@latest", path)); err != nil {
+		return nil, fmt.Errorf("failed to download module: %w", err)
+	}
+
+	m.progress("check", "Checking for package main...")
+	info.HasMain = m.hasPackageMain(ctx, path)
+
+	m.progress("discover", "Searching for CLI binaries...")
+
+	info.CLIPaths, _, err = m.DiscoverCLIPaths(ctx, info.RootModule)
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
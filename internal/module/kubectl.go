@@ -0,0 +1,91 @@
+package module
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	modpath "golang.org/x/mod/module"
+)
+
+// KubectlPluginPrefix is the binary name prefix kubectl uses to discover plugins
+const KubectlPluginPrefix = "kubectl-"
+
+// BinaryName returns the executable name `go install` produces for a package
+// path: its last element, skipping a major version suffix such as /v2
+func BinaryName(pkgPath string) string {
+	elem := pkgPath
+	if idx := strings.LastIndex(pkgPath, "/"); idx != -1 {
+		elem = pkgPath[idx+1:]
+
+		if prefix, _, ok := modpath.SplitPathVersion(pkgPath); ok && prefix != pkgPath {
+			elem = path.Base(prefix)
+		}
+	}
+
+	return elem
+}
+
+// KubectlPluginName returns the plugin name kubectl derives from a binary
+// name (kubectl-view_secret -> view-secret), and whether it is a plugin at all
+func KubectlPluginName(binary string) (string, bool) {
+	name, ok := strings.CutPrefix(binary, KubectlPluginPrefix)
+	if !ok || name == "" {
+		return "", false
+	}
+
+	return strings.ReplaceAll(name, "_", "-"), true
+}
+
+// KubectlPluginBinary returns the binary name kubectl expects for a plugin
+// name. Dashes become underscores so `kubectl view-secret` resolves to
+// kubectl-view_secret rather than the nested command `kubectl view secret`.
+func KubectlPluginBinary(name string) string {
+	return KubectlPluginPrefix + strings.ReplaceAll(name, "-", "_")
+}
+
+// RegisterKubectlPlugin makes the installed binary discoverable by kubectl,
+// renaming it in its bin directory to kubectl-<name> when it lacks the
+// prefix. It returns the final binary path.
+func (m *Module) RegisterKubectlPlugin() (string, error) {
+	binary := BinaryName(m.Name)
+	gobin := m.binDirectory()
+
+	ext := ""
+	if runtime.GOOS == "windows" {
+		ext = ".exe"
+	}
+
+	if name, ok := KubectlPluginName(binary); ok {
+		m.KubectlPlugin = name
+		m.SetBinaryPath(filepath.Join(gobin, binary+ext))
+
+		return m.BinaryPath, nil
+	}
+
+	src := filepath.Join(gobin, binary+ext)
+	dst := filepath.Join(gobin, KubectlPluginBinary(binary)+ext)
+
+	if err := os.Rename(src, dst); err != nil {
+		return "", fmt.Errorf("failed to register kubectl plugin: %w", err)
+	}
+
+	m.KubectlPlugin = binary
+	m.SetBinaryPath(dst)
+
+	return dst, nil
+}
+
+// IsOnPath reports whether dir is listed in the PATH environment variable
+func IsOnPath(dir string) bool {
+	for entry := range strings.SplitSeq(os.Getenv("PATH"), string(os.PathListSeparator)) {
+		if entry != "" && filepath.Clean(entry) == filepath.Clean(dir) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,35 @@
+package module
+
+import "testing"
+
+func TestBinaryName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"github.com/inovacc/ksuid/cmd/ksuid", "ksuid"},
+		{"github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2", "protoc-gen-openapiv2"},
+		{"github.com/example/tool/v2", "tool"},
+		{"ksuid", "ksuid"},
+	}
+
+	for _, tt := range tests {
+		if got := BinaryName(tt.input); got != tt.want {
+			t.Errorf("BinaryName(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestKubectlPluginName(t *testing.T) {
+	if name, ok := KubectlPluginName("kubectl-view_secret"); !ok || name != "view-secret" {
+		t.Errorf("KubectlPluginName(kubectl-view_secret) = %q, %v", name, ok)
+	}
+
+	if _, ok := KubectlPluginName("ksuid"); ok {
+		t.Error("ksuid should not be a kubectl plugin")
+	}
+
+	if got := KubectlPluginBinary("view-secret"); got != "kubectl-view_secret" {
+		t.Errorf("KubectlPluginBinary(view-secret) = %q", got)
+	}
+}
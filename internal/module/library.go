@@ -0,0 +1,93 @@
+package module
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// LibraryModuleError reports a module without any CLI to install: no main
+// package at the requested path and none discovered in its root module
+type LibraryModuleError struct {
+	Module     string   // Path that was requested
+	RootModule string   // Module providing it
+	Version    string   // Version that was checked
+	Packages   []string // Importable packages of the root module
+}
+
+func (e *LibraryModuleError) Error() string {
+	return fmt.Sprintf("%s is a library with no CLI to install; add it to a Go project with 'go get %s'", e.Module, e.GoGet())
+}
+
+// GoGet returns the argument of go get adding the module to a project
+func (e *LibraryModuleError) GoGet() string {
+	if e.Version == "" {
+		return e.Module
+	}
+
+	return e.Module + "@" + e.Version
+}
+
+// ResolveLibrary returns the module providing path, such as a library to
+// watch for releases, and its newest version without downloading it
+func (m *Module) ResolveLibrary(path string) (string, string, error) {
+	ctx, cancel := context.WithTimeout(m.ctx, m.getTimeout())
+	defer cancel()
+
+	if err := m.setupTempModule(ctx); err != nil {
+		return "", "", err
+	}
+
+	result, err := m.fetchModuleVersions(ctx, path)
+	if err != nil {
+		return "", "", err
+	}
+
+	return result.RootModule, result.ListResp.Version, nil
+}
+
+// libraryPackages lists the packages of a module other modules can import,
+// leaving out internal packages and those that fail to load
+func (m *Module) libraryPackages(ctx context.Context, rootModule string) []string {
+	cmd := m.goCommand(ctx, "list", "-e", "-json", rootModule+"/...")
+	cmd.Dir = m.workingDir
+
+	var out bytes.Buffer
+
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		return nil
+	}
+
+	var packages []string
+
+	decoder := json.NewDecoder(&out)
+
+	for {
+		pkg := GoListPackage{}
+
+		if err := decoder.Decode(&pkg); err != nil {
+			break
+		}
+
+		if pkg.Error != nil || pkg.Name == "" || pkg.Name == "main" || isInternalPackage(pkg.ImportPath) {
+			continue
+		}
+
+		packages = append(packages, pkg.ImportPath)
+	}
+
+	slices.Sort(packages)
+
+	return packages
+}
+
+// isInternalPackage reports whether path is importable only from within its
+// module
+func isInternalPackage(path string) bool {
+	return strings.HasSuffix(path, "/internal") || strings.Contains(path, "/internal/")
+}
@@ -0,0 +1,64 @@
+package module
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLibraryPackages(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod":                "module example.com/errs\n\ngo 1.21\n",
+		"errors.go":             "package errs\n",
+		"stack/stack.go":        "package stack\n",
+		"internal/fmt/fmt.go":   "package fmt\n",
+		"stack/internal/pc.go":  "package internal\n",
+		"examples/demo/main.go": "package main\n\nfunc main() {}\n",
+		"testdata/fixture/f.go": "package fixture\n",
+		"unix/unix.go":          "//go:build plan9 && !plan9\n\npackage unix\n",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m, err := NewModule(context.Background(), "go", dir)
+	if err != nil {
+		t.Skipf("go unavailable: %v", err)
+	}
+
+	got := m.libraryPackages(context.Background(), "example.com/errs")
+	want := []string{"example.com/errs", "example.com/errs/stack"}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("libraryPackages() = %v, want %v", got, want)
+	}
+}
+
+func TestLibraryModuleError(t *testing.T) {
+	err := &LibraryModuleError{Module: "github.com/pkg/errors", RootModule: "github.com/pkg/errors", Version: "v0.9.1"}
+
+	if got := err.GoGet(); got != "github.com/pkg/errors@v0.9.1" {
+		t.Errorf("GoGet() = %q", got)
+	}
+
+	want := "github.com/pkg/errors is a library with no CLI to install; add it to a Go project with 'go get github.com/pkg/errors@v0.9.1'"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	if got := (&LibraryModuleError{Module: "example.com/lib"}).GoGet(); got != "example.com/lib" {
+		t.Errorf("GoGet() without a version = %q", got)
+	}
+}
@@ -0,0 +1,171 @@
+package module
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	// LicenseUnknown is reported for a license file that matches no known license
+	LicenseUnknown = "unknown"
+
+	// LicenseNone is reported for modules without a license file
+	LicenseNone = "none"
+)
+
+// licenseFilePattern matches the usual names of license files
+var licenseFilePattern = regexp.MustCompile(`(?i)^(licen[cs]e|copying)(\.(md|txt|rst))?$`)
+
+// licenseSignatures maps SPDX identifiers to phrases found in the license
+// text. Order matters: more specific licenses come before the ones whose
+// text they contain (AGPL before GPL, BSD-3 before BSD-2).
+var licenseSignatures = []struct {
+	id      string
+	phrases []string
+}{
+	{"AGPL-3.0", []string{"gnu affero general public license"}},
+	{"LGPL-3.0", []string{"gnu lesser general public license", "version 3"}},
+	{"LGPL-2.1", []string{"gnu lesser general public license"}},
+	{"GPL-3.0", []string{"gnu general public license", "version 3"}},
+	{"GPL-2.0", []string{"gnu general public license", "version 2"}},
+	{"MPL-2.0", []string{"mozilla public license", "2.0"}},
+	{"Apache-2.0", []string{"apache license", "version 2.0"}},
+	{"BSD-3-Clause", []string{"redistribution and use in source and binary forms", "neither the name"}},
+	{"BSD-2-Clause", []string{"redistribution and use in source and binary forms"}},
+	{"ISC", []string{"permission to use, copy, modify, and/or distribute this software for any purpose"}},
+	{"MIT", []string{"permission is hereby granted, free of charge"}},
+	{"Unlicense", []string{"this is free and unencumbered software released into the public domain"}},
+}
+
+// DetectLicense identifies the license of the source tree in dir from its
+// license file. It returns an SPDX identifier, LicenseUnknown for an
+// unrecognized license file, or "" when there is no license file.
+func DetectLicense(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for _, e := range entries {
+		if e.IsDir() || !licenseFilePattern.MatchString(e.Name()) {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return "", err
+		}
+
+		return ClassifyLicense(string(data)), nil
+	}
+
+	return "", nil
+}
+
+// ClassifyLicense returns the SPDX identifier of a license text, or
+// LicenseUnknown
+func ClassifyLicense(text string) string {
+	text = strings.Join(strings.Fields(strings.ToLower(text)), " ")
+
+	for _, sig := range licenseSignatures {
+		matched := true
+
+		for _, phrase := range sig.phrases {
+			if !strings.Contains(text, phrase) {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			return sig.id
+		}
+	}
+
+	return LicenseUnknown
+}
+
+// detectLicenses records the license of the module and of its dependencies
+// from their license files in the module cache, listing the modules required
+// from dir. Dependencies whose source was never downloaded keep no license.
+func (m *Module) detectLicenses(ctx context.Context, dir string) {
+	m.progress("licenses", "Detecting licenses...")
+
+	dirs, err := m.moduleDirs(ctx, dir)
+	if err != nil {
+		m.progress("warning", fmt.Sprintf("licenses unavailable: %v", err))
+		return
+	}
+
+	root := m.RootModule
+	if root == "" {
+		root = m.Name
+	}
+
+	m.License = licenseOf(dirs[root])
+
+	for i := range m.Dependencies {
+		m.Dependencies[i].License = licenseOf(dirs[m.Dependencies[i].Name])
+	}
+}
+
+// moduleDirs maps the modules in the build list of the module in dir to
+// their source directories, for the modules whose source is downloaded
+func (m *Module) moduleDirs(ctx context.Context, dir string) (map[string]string, error) {
+	cmd := m.goCommand(ctx, "list", "-m", "-json", "all")
+	cmd.Dir = dir
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go list -m all failed: %w", err)
+	}
+
+	dirs := make(map[string]string)
+
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for {
+		var mod struct {
+			Path string
+			Dir  string
+		}
+
+		if err := dec.Decode(&mod); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to decode go list output: %w", err)
+		}
+
+		if mod.Dir != "" {
+			dirs[mod.Path] = mod.Dir
+		}
+	}
+
+	return dirs, nil
+}
+
+// licenseOf returns the license of the source tree in dir, LicenseNone when
+// it has no license file, or "" when dir is unknown or unreadable
+func licenseOf(dir string) string {
+	if dir == "" {
+		return ""
+	}
+
+	license, err := DetectLicense(dir)
+	if err != nil {
+		return ""
+	}
+
+	if license == "" {
+		return LicenseNone
+	}
+
+	return license
+}
@@ -0,0 +1,60 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestClassifyLicense(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"MIT License\n\nPermission is hereby granted, free of charge, to any person", "MIT"},
+		{"Apache License\n   Version 2.0, January 2004", "Apache-2.0"},
+		{"Redistribution and use in source and binary forms ... Neither the name of Google", "BSD-3-Clause"},
+		{"Redistribution and use in source and binary forms, with or without", "BSD-2-Clause"},
+		{"GNU AFFERO GENERAL PUBLIC LICENSE\nVersion 3, 19 November 2007\nGNU General Public License", "AGPL-3.0"},
+		{"GNU GENERAL PUBLIC LICENSE\n Version 3, 29 June 2007", "GPL-3.0"},
+		{"All rights reserved.", LicenseUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyLicense(tt.text); got != tt.want {
+			t.Errorf("ClassifyLicense(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestDetectLicense(t *testing.T) {
+	dir := t.TempDir()
+
+	if got, err := DetectLicense(dir); err != nil || got != "" {
+		t.Errorf("DetectLicense(no file) = %q, %v", got, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "LICENSE.md"), []byte("Permission is hereby granted, free of charge"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := DetectLicense(dir); err != nil || got != "MIT" {
+		t.Errorf("DetectLicense = %q, %v; want MIT", got, err)
+	}
+}
+
+func TestLicenseOf(t *testing.T) {
+	dir := t.TempDir()
+
+	if got := licenseOf(""); got != "" {
+		t.Errorf("licenseOf(\"\") = %q, want nothing", got)
+	}
+
+	if got := licenseOf(filepath.Join(dir, "missing")); got != "" {
+		t.Errorf("licenseOf(missing) = %q, want nothing", got)
+	}
+
+	if got := licenseOf(dir); got != LicenseNone {
+		t.Errorf("licenseOf(no file) = %q, want %q", got, LicenseNone)
+	}
+}
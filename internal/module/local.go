@@ -0,0 +1,249 @@
+package module
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/modfile"
+)
+
+// LocalVersion is the version recorded for modules installed from a local directory
+const LocalVersion = "(devel)"
+
+// IsLocalPath reports whether the input refers to a local filesystem path
+// (./dir, ../dir, /abs/dir, ~/dir or a file:// URL) rather than a module path
+func IsLocalPath(input string) bool {
+	if strings.HasPrefix(input, "file://") {
+		return true
+	}
+
+	if input == "." || input == ".." ||
+		strings.HasPrefix(input, "./") || strings.HasPrefix(input, "../") ||
+		strings.HasPrefix(input, ".\\") || strings.HasPrefix(input, "..\\") ||
+		strings.HasPrefix(input, "~/") {
+		return true
+	}
+
+	return filepath.IsAbs(input)
+}
+
+// ResolveLocalPath converts a local path or file:// URL into an absolute directory
+func ResolveLocalPath(input string) (string, error) {
+	path := input
+
+	if strings.HasPrefix(input, "file://") {
+		u, err := url.Parse(input)
+		if err != nil {
+			return "", fmt.Errorf("invalid file URL %q: %w", input, err)
+		}
+
+		path = filepath.FromSlash(u.Path)
+	}
+
+	if after, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve home directory: %w", err)
+		}
+
+		path = filepath.Join(home, after)
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path %q: %w", input, err)
+	}
+
+	info, err := os.Stat(abs)
+	if err != nil {
+		return "", fmt.Errorf("local module path %q: %w", abs, err)
+	}
+
+	if !info.IsDir() {
+		return "", fmt.Errorf("local module path %q is not a directory", abs)
+	}
+
+	return abs, nil
+}
+
+// findModuleRoot walks up from dir until it finds a go.mod file
+func findModuleRoot(dir string) (string, error) {
+	for current := dir; ; {
+		if _, err := os.Stat(filepath.Join(current, "go.mod")); err == nil {
+			return current, nil
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", fmt.Errorf("no go.mod found in %s or any parent directory", dir)
+		}
+
+		current = parent
+	}
+}
+
+// FetchLocalModuleInfo resolves module information from a local working copy.
+// The CLI is built from the directory itself, so its go.mod (including any
+// replace directives) is honored exactly as in the working copy.
+func (m *Module) FetchLocalModuleInfo(dir string) error {
+	ctx, cancel := context.WithTimeout(m.ctx, m.getTimeout())
+	defer cancel()
+
+	m.progress("init", fmt.Sprintf("Reading local module at %s...", dir))
+
+	root, err := findModuleRoot(dir)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return fmt.Errorf("failed to read go.mod: %w", err)
+	}
+
+	modulePath := modfile.ModulePath(data)
+	if modulePath == "" {
+		return fmt.Errorf("no module directive found in %s", filepath.Join(root, "go.mod"))
+	}
+
+	m.RootModule = modulePath
+	m.LocalPath = dir
+
+	// Locate the main package: the directory itself, or the first command below it
+	m.progress("check", "Looking for main package...")
+
+	pkgs, err := m.listLocalPackages(ctx, dir, ".")
+	if err != nil {
+		return err
+	}
+
+	if len(pkgs) == 0 || pkgs[0].Name != "main" {
+		m.progress("discover", "Searching for CLI binaries...")
+
+		pkgs, err = m.listLocalPackages(ctx, dir, "./...")
+		if err != nil {
+			return err
+		}
+	}
+
+	var mains []string
+
+	for _, pkg := range pkgs {
+		if pkg.Name == "main" {
+			mains = append(mains, pkg.ImportPath)
+		}
+	}
+
+	if len(mains) == 0 {
+		return fmt.Errorf("no main package found in %s", dir)
+	}
+
+	if len(mains) > 1 {
+		m.progress("discover", fmt.Sprintf("Found %d installable CLIs, auto-selecting: %s", len(mains), mains[0]))
+	}
+
+	m.Name = mains[0]
+	m.Version = LocalVersion
+	m.Versions = []string{LocalVersion}
+	m.Time = time.Now()
+	m.Hash = "" // A working copy has no module zip to hash
+
+	// Record direct and transitive requirements as resolved by the working copy
+	m.progress("deps", "Resolving dependencies...")
+
+	m.Dependencies, err = m.extractLocalDependencies(ctx, dir, modulePath)
+	if err != nil {
+		return err
+	}
+
+	m.detectLicenses(ctx, dir)
+	m.progress("done", "Module info fetched successfully")
+
+	return nil
+}
+
+// listLocalPackages runs `go list -json` for a pattern inside a local module
+func (m *Module) listLocalPackages(ctx context.Context, dir, pattern string) ([]GoListPackage, error) {
+	cmd := m.goCommand(ctx, "list", "-json", pattern)
+	cmd.Dir = dir
+
+	var out, stderr bytes.Buffer
+
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("go list %s failed: %w: %s", pattern, err, strings.TrimSpace(stderr.String()))
+	}
+
+	var pkgs []GoListPackage
+
+	decoder := json.NewDecoder(&out)
+
+	for {
+		pkg := GoListPackage{}
+		if err := decoder.Decode(&pkg); err != nil {
+			break
+		}
+
+		pkgs = append(pkgs, pkg)
+	}
+
+	return pkgs, nil
+}
+
+// extractLocalDependencies lists the module graph of a local working copy
+// without querying the proxy for available versions
+func (m *Module) extractLocalDependencies(ctx context.Context, dir, self string) ([]Dependency, error) {
+	cmd := m.goCommand(ctx, "list", "-m", "all")
+	cmd.Dir = dir
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go list -m all failed: %w", err)
+	}
+
+	var deps []Dependency
+
+	root, _ := findModuleRoot(dir)
+	sums := readGoSum(filepath.Join(root, "go.sum"))
+
+	for line := range strings.SplitSeq(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] == self {
+			continue
+		}
+
+		deps = append(deps, Dependency{
+			Name:    fields[0],
+			Version: fields[1],
+			Hash:    sums[fields[0]+"@"+fields[1]],
+		})
+	}
+
+	return deps, nil
+}
+
+// installLocalWithStreaming builds and installs the CLI from the local working copy
+func (m *Module) installLocalWithStreaming(ctx context.Context, handler OutputHandler) error {
+	if handler != nil {
+		handler("stdout", fmt.Sprintf("Building %s from %s", m.Name, m.LocalPath))
+	}
+
+	if err := m.checkToolchain(ctx, m.LocalPath, m.Name); err != nil {
+		return err
+	}
+
+	if err := m.goInstall(ctx, m.LocalPath, m.Name, handler); err != nil {
+		return fmt.Errorf("go install failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package module
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsLocalPath(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"./tools/mycli", true},
+		{"../mycli", true},
+		{".", true},
+		{"file:///home/me/src/mycli", true},
+		{"~/src/mycli", true},
+		{"github.com/inovacc/twig", false},
+		{"https://github.com/inovacc/twig", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsLocalPath(tt.input); got != tt.want {
+			t.Errorf("IsLocalPath(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestFetchLocalModuleInfo(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod":                "module example.com/localtool\n\ngo 1.21\n",
+		"cmd/localtool/main.go": "package main\n\nfunc main() {}\n",
+		"lib.go":                "package localtool\n",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m, err := NewModule(context.TODO(), "go", t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.FetchLocalModuleInfo(dir); err != nil {
+		t.Fatalf("FetchLocalModuleInfo() error = %v", err)
+	}
+
+	if m.Name != "example.com/localtool/cmd/localtool" {
+		t.Errorf("Name = %q, want example.com/localtool/cmd/localtool", m.Name)
+	}
+
+	if m.RootModule != "example.com/localtool" {
+		t.Errorf("RootModule = %q, want example.com/localtool", m.RootModule)
+	}
+
+	if m.LocalPath != dir || m.Version != LocalVersion {
+		t.Errorf("LocalPath/Version = %q/%q, want %q/%q", m.LocalPath, m.Version, dir, LocalVersion)
+	}
+}
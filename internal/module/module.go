@@ -2,19 +2,22 @@ package module
 
 import (
 	"bytes"
+	"cmp"
 	"context"
-	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/inovacc/glix/internal/database"
+	"github.com/inovacc/glix/internal/modver"
 	pb "github.com/inovacc/glix/pkg/api/v1"
-	"github.com/inovacc/glix/pkg/exec"
+	"golang.org/x/mod/modfile"
 	"golang.org/x/mod/semver"
 )
 
@@ -32,23 +35,66 @@ type Module struct {
 	goBinPath       string
 	workingDir      string
 	timeout         time.Duration
+	stallThreshold  time.Duration // Silence before a build is reported as stalled, StallThreshold() when 0
+	heartbeat       time.Duration // Silence between heartbeats, HeartbeatInterval when 0
 	goListPackage   []GoListPackage
 	progressHandler ProgressHandler
-	Time            time.Time    `json:"time"`
-	Name            string       `json:"name"`
-	RootModule      string       `json:"root_module"` // The actual Go module path (e.g., github.com/sqlc-dev/sqlc)
-	Hash            string       `json:"hash"`
-	Version         string       `json:"version"`
-	Versions        []string     `json:"versions"`
-	Dependencies    []Dependency `json:"dependencies"`
+	binDir          string                   // Install destination instead of GOBIN
+	profile         string                   // Install profile with its own module cache
+	goflags         string                   // GOFLAGS of the install profile
+	goproxy         string                   // GOPROXY of the install instead of the environment's
+	profileProxy    ProxySettings            // Module proxy of the install profile
+	hooks           HookRunner               // User-defined hooks run around installs
+	previousVersion string                   // Installed version an update replaces
+	targetOS        string                   // GOOS of cross-compiled installs, runtime.GOOS when empty
+	targetArch      string                   // GOARCH of cross-compiled installs, runtime.GOARCH when empty
+	buildFlags      BuildFlags               // go build flags of the install
+	buildStrategy   BuildStrategy            // How the binary is built from source
+	provenance      *pb.BuildProvenanceProto // Build info of the installed binary
+	constraint      string                   // Version range installs stay within, e.g. ^1.2
+	preferBinary    bool                     // Install prebuilt GitHub release binaries when there are any
+	prerelease      bool                     // Install pre-releases newer than the latest release
+	private         bool                     // Fetch directly from the repository, without the checksum database
+	offline         bool                     // Resolve and download from the module cache only
+	versionStore    VersionStore             // Version cache shared across processes
+	refreshing      bool                     // Query the module proxy even within the version cache TTL
+	discoveryDirs   []string                 // Directories CLI discovery scans, DiscoveryDirs() when nil
+	cliSelector     CLISelector              // Picks among several discovered CLIs, the best ranked when nil
+	discovered      []string                 // CLIs discovered for a path without a main package, or all of them with allBinaries
+	allBinaries     bool                     // Discover the CLIs of the root module even when the path is one
+	shim            bool                     // Install into the version store behind a shim
+	scanner         BinaryScanner            // Quarantine scanner built binaries must pass before they are placed
+	scanResult      *pb.ScanResultProto      // Verdict of the scanner on the installed binary
+	allowRetracted  bool                     // Install versions their author retracted when requested explicitly
+	resolveStarted  time.Time                // When resolving the install began
+	sourceCached    bool                     // The module zip was in the module cache before resolving began
+	buildMetrics    *pb.BuildMetricsProto    // How long the install took and the size of its binary
+	Time            time.Time                `json:"time"`
+	Name            string                   `json:"name"`
+	RootModule      string                   `json:"root_module"` // The actual Go module path (e.g., github.com/sqlc-dev/sqlc)
+	Hash            string                   `json:"hash"`        // go.sum hash (h1:) of the module zip
+	Version         string                   `json:"version"`
+	Versions        []string                 `json:"versions"`
+	Dependencies    []Dependency             `json:"dependencies"`
+	LocalPath       string                   `json:"local_path,omitempty"`     // Source directory for local/dev installs
+	KubectlPlugin   string                   `json:"kubectl_plugin,omitempty"` // kubectl plugin name when registered as kubectl-<name>
+	BinaryName      string                   `json:"binary_name,omitempty"`    // Installed executable name, without extension
+	BinaryPath      string                   `json:"binary_path,omitempty"`    // Absolute path of the installed executable
+	BinaryHash      string                   `json:"binary_hash,omitempty"`    // <algorithm>:<hex> of the installed executable
+	ShimPath        string                   `json:"shim_path,omitempty"`      // Shim running the binary from the version store, see SetShim
+	Sum             string                   `json:"sum,omitempty"`            // go.sum h1: hash of the root module zip
+	GoModSum        string                   `json:"go_mod_sum,omitempty"`     // go.sum h1: hash of the root module go.mod
+	Alias           string                   `json:"alias,omitempty"`          // Binary name replacing the default, chosen with --as
+	License         string                   `json:"license,omitempty"`        // SPDX id of the module license, see DetectLicense
 }
 
 type Dependency struct {
 	Name         string       `json:"name"`
-	Hash         string       `json:"hash"`
+	Hash         string       `json:"hash"` // go.sum hash (h1:) of the dependency zip
 	Version      string       `json:"version"`
 	Versions     []string     `json:"versions"`
 	Dependencies []Dependency `json:"dependencies,omitempty"`
+	License      string       `json:"license,omitempty"`
 }
 
 type ListResp struct {
@@ -108,7 +154,7 @@ func (m *Module) setupTempModule(ctx context.Context) error {
 	}
 
 	if absWorkingDir != absCwd {
-		cmd := exec.CommandContext(ctx, m.goBinPath, "mod", "init", dummyModuleName)
+		cmd := m.goCommand(ctx, "mod", "init", dummyModuleName)
 		cmd.Dir = m.workingDir
 
 		return cmd.Run()
@@ -118,6 +164,8 @@ func (m *Module) setupTempModule(ctx context.Context) error {
 }
 
 func (m *Module) FetchModuleInfo(module string) error {
+	m.startResolving()
+
 	module = m.normalizeModulePath(module)
 
 	ctx, cancel := context.WithTimeout(m.ctx, m.getTimeout())
@@ -138,6 +186,10 @@ func (m *Module) FetchModuleInfo(module string) error {
 
 	result, err := m.fetchModuleVersions(ctx, module)
 	if err != nil {
+		if m.offline {
+			return fmt.Errorf("%s: %w; download it once online first", module, ErrNotCached)
+		}
+
 		return err
 	}
 
@@ -145,6 +197,40 @@ func (m *Module) FetchModuleInfo(module string) error {
 	rootModule := result.RootModule
 	m.RootModule = rootModule // Store the root module for later use (e.g., go mod download)
 
+	// Ranges such as ^1.2 install the newest release they allow and are kept,
+	// so updates stay within them; other versions replace them
+	if IsVersionRange(version) {
+		m.constraint = version
+	} else if version != "latest" {
+		m.constraint = ""
+	}
+
+	if m.constraint != "" {
+		if _, err := ParseVersionRange(m.constraint); err != nil {
+			return err
+		}
+
+		picked := m.pickVersion(m.constraint, lr.Versions)
+		if picked == "" {
+			return fmt.Errorf("no release of %s matches %s (latest is %s)", module, m.constraint, lr.Version)
+		}
+
+		m.progress("versions", fmt.Sprintf("Resolved %s to %s", m.constraint, picked))
+		version = picked
+	}
+
+	// Branches, commits and queries such as "v1.2" install as the version
+	// they resolve to, a pseudo-version for untagged commits
+	if version != "latest" && semver.Canonical(version) != version {
+		resolved, err := m.resolveVersion(ctx, rootModule, version)
+		if err != nil {
+			return err
+		}
+
+		m.progress("versions", fmt.Sprintf("Resolved %s to %s", version, resolved))
+		version = resolved
+	}
+
 	// Download the module first to check if it's installable
 	m.progress("download", "Downloading module...")
 
@@ -157,36 +243,83 @@ func (m *Module) FetchModuleInfo(module string) error {
 	m.progress("check", "Checking if module is installable...")
 
 	if !m.hasPackageMain(ctx, module) {
-		m.progress("discover", "Searching for CLI binaries...")
-		fmt.Printf("Module %q found but is not installable (no main package), searching for CLIs...\n", module)
+		m.progress("discover", fmt.Sprintf("Module %q found but is not installable (no main package), searching for CLIs...", module))
 
 		// Use root module for discovery, not the user-provided path
 		discovered, found, discErr := m.DiscoverCLIPaths(ctx, rootModule)
 		if discErr == nil && found && len(discovered) > 0 {
-			// Auto-select the first discovered CLI
+			m.discovered = discovered
+
+			// Auto-select the best ranked CLI unless asked to pick one
 			selectedCLI := discovered[0]
 
-			if len(discovered) > 1 {
-				fmt.Printf("Found %d installable CLIs, auto-selecting: %s\n", len(discovered), selectedCLI)
-			} else {
-				fmt.Printf("Found installable CLI: %s\n", selectedCLI)
+			switch {
+			case len(discovered) > 1 && m.allBinaries:
+				m.progress("discover", fmt.Sprintf("Found %d installable CLIs, installing all of them", len(discovered)))
+			case len(discovered) > 1 && m.cliSelector != nil:
+				m.progress("discover", fmt.Sprintf("Found %d installable CLIs", len(discovered)))
+
+				picked, err := m.cliSelector(discovered)
+				if err != nil {
+					return err
+				}
+
+				selectedCLI = picked
+				m.progress("discover", fmt.Sprintf("Selected %s", selectedCLI))
+			case len(discovered) > 1:
+				m.progress("discover", fmt.Sprintf("Found %d installable CLIs, auto-selecting: %s", len(discovered), selectedCLI))
+			default:
+				m.progress("discover", fmt.Sprintf("Found installable CLI: %s", selectedCLI))
 			}
 
 			module = selectedCLI
 			m.Name = selectedCLI
 		} else {
-			return fmt.Errorf("module %q is not installable and no CLI paths were discovered", module)
+			libVersion := version
+			if libVersion == "latest" {
+				libVersion = lr.Version
+			}
+
+			return &LibraryModuleError{
+				Module:     module,
+				RootModule: rootModule,
+				Version:    libVersion,
+				Packages:   m.libraryPackages(ctx, rootModule),
+			}
+		}
+	} else if m.allBinaries {
+		m.discovered = m.siblingCLIs(ctx, rootModule, module)
+
+		if n := len(m.discovered) - 1; n > 0 {
+			m.progress("discover", fmt.Sprintf("Found %d more CLI(s) in %s, installing all of them", n, rootModule))
 		}
 	}
 
 	if version == "latest" {
 		version = lr.Version
+
+		// go resolves latest to the newest release; modules opted into
+		// pre-releases take a newer pre-release over it
+		if m.prerelease {
+			if newest := modver.Newest(lr.Versions, modver.IsPrerelease); modver.Newer(newest, version) {
+				m.progress("versions", fmt.Sprintf("Using pre-release %s (latest release is %s)", newest, version))
+				version = newest
+			}
+		}
 	}
 
 	m.Versions = lr.Versions
 	m.Version = m.pickVersion(version, lr.Versions)
+
+	// go never resolves latest or a range to a retracted version, but
+	// installs one that is requested by name
+	if m.Version != lr.Version {
+		if err := m.checkRetracted(); err != nil {
+			return err
+		}
+	}
+
 	m.Time = time.Now()
-	m.Hash = m.hashModule(fmt.Sprintf("%s@%s", module, version))
 
 	// Install the target module in dummy with a specific version if different from the latest
 	// (we already downloaded @latest above for the installability check)
@@ -201,9 +334,22 @@ func (m *Module) FetchModuleInfo(module string) error {
 	// Extract dependencies
 	m.progress("deps", "Resolving dependencies...")
 	m.Dependencies, err = m.extractDependencies(ctx, module)
+	if err != nil {
+		return err
+	}
+
+	// Record the hashes of the module, which go verified against the
+	// checksum database unless the module is excluded from it
+	m.progress("download", "Recording module checksums...")
+
+	if _, err := m.getModuleSourceDir(ctx); err != nil {
+		return err
+	}
+
+	m.detectLicenses(ctx, m.workingDir)
 	m.progress("done", "Module info fetched successfully")
 
-	return err
+	return nil
 }
 
 // getModuleSourceDir downloads the module and returns its source directory
@@ -214,24 +360,53 @@ func (m *Module) getModuleSourceDir(ctx context.Context) (string, error) {
 		modulePath = m.Name // Fallback for backwards compatibility
 	}
 
-	cmd := exec.CommandContext(ctx, m.goBinPath, "mod", "download", "-json", fmt.Sprintf("%s@%s", modulePath, m.Version))
+	cmd := m.goCommand(ctx, "mod", "download", "-json", fmt.Sprintf("%s@%s", modulePath, m.Version))
 
-	var out bytes.Buffer
+	var out, stderr bytes.Buffer
 
 	cmd.Stdout = &out
+	cmd.Stderr = &stderr
 
 	if err := cmd.Run(); err != nil {
+		if denied := fetchError(modulePath, stderr.String()); denied != nil {
+			return "", denied
+		}
+
 		return "", fmt.Errorf("go mod download failed: %w", err)
 	}
 
 	var result struct {
-		Dir string `json:"Dir"`
+		Dir      string `json:"Dir"`
+		Zip      string `json:"Zip"`
+		Sum      string `json:"Sum"`
+		GoModSum string `json:"GoModSum"`
 	}
 
 	if err := json.NewDecoder(&out).Decode(&result); err != nil {
 		return "", fmt.Errorf("failed to decode download result: %w", err)
 	}
 
+	m.Sum = result.Sum
+	m.GoModSum = result.GoModSum
+
+	// The hash of the zip is taken from its bytes, not from what go says it
+	// verified, so a zip changed in the module cache since is caught here
+	m.Hash = result.Sum
+
+	if result.Zip != "" {
+		hash, err := HashModuleZip(result.Zip)
+		if err != nil {
+			return "", fmt.Errorf("failed to hash module zip: %w", err)
+		}
+
+		if result.Sum != "" && hash != result.Sum {
+			return "", fmt.Errorf("module zip %s hashes to %s, but go verified %s; run 'go clean -modcache' and retry", result.Zip, hash, result.Sum)
+		}
+
+		m.Hash = hash
+		m.noteSourceZip(result.Zip)
+	}
+
 	if result.Dir == "" {
 		return "", fmt.Errorf("module directory not found in download result")
 	}
@@ -239,6 +414,77 @@ func (m *Module) getModuleSourceDir(ctx context.Context) (string, error) {
 	return result.Dir, nil
 }
 
+// SourceDir returns the directory holding the module source: the working
+// copy root for local installs, the module cache directory otherwise
+func (m *Module) SourceDir() (string, error) {
+	if m.LocalPath != "" {
+		return findModuleRoot(m.LocalPath)
+	}
+
+	// Modules read back from a bundle manifest carry no context
+	parent := m.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	ctx, cancel := context.WithTimeout(parent, m.getTimeout())
+	defer cancel()
+
+	return m.getModuleSourceDir(ctx)
+}
+
+// RequiredGoVersion returns the go directive from the module's go.mod
+func (m *Module) RequiredGoVersion() (string, error) {
+	if m.LocalPath != "" {
+		root, err := findModuleRoot(m.LocalPath)
+		if err != nil {
+			return "", err
+		}
+
+		data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+		if err != nil {
+			return "", fmt.Errorf("failed to read go.mod: %w", err)
+		}
+
+		f, err := modfile.ParseLax("go.mod", data, nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse go.mod: %w", err)
+		}
+
+		if f.Go == nil {
+			return "", nil
+		}
+
+		return f.Go.Version, nil
+	}
+
+	ctx, cancel := context.WithTimeout(m.ctx, m.getTimeout())
+	defer cancel()
+
+	modulePath := m.RootModule
+	if modulePath == "" {
+		modulePath = m.Name
+	}
+
+	cmd := m.goCommand(ctx, "list", "-m", "-json", fmt.Sprintf("%s@%s", modulePath, m.Version))
+	cmd.Dir = m.workingDir
+
+	var out bytes.Buffer
+
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("go list -m failed: %w", err)
+	}
+
+	var info GoModule
+	if err := json.NewDecoder(&out).Decode(&info); err != nil {
+		return "", fmt.Errorf("failed to decode module info: %w", err)
+	}
+
+	return info.GoVersion, nil
+}
+
 func (m *Module) ToJSON() ([]byte, error) {
 	return json.MarshalIndent(m, "", "  ")
 }
@@ -252,16 +498,45 @@ func (m *Module) SaveToFile(path string) error {
 	return os.WriteFile(path, data, 0644)
 }
 
-func (m *Module) Report(db *database.Storage) error {
-	// Convert Module struct to Protocol Buffer
-	moduleProto := &pb.ModuleProto{
+// ToProto converts the module into its Protocol Buffer representation
+func (m *Module) ToProto() *pb.ModuleProto {
+	return &pb.ModuleProto{
 		Name:              m.Name,
 		Version:           m.Version,
 		Versions:          m.Versions,
 		Dependencies:      convertDependenciesToProto(m.Dependencies),
 		Hash:              m.Hash,
 		TimestampUnixNano: m.Time.UnixNano(),
+		LocalPath:         m.LocalPath,
+		KubectlPlugin:     m.KubectlPlugin,
+		BinaryName:        m.BinaryName,
+		BinaryPath:        m.BinaryPath,
+		BinaryHash:        m.BinaryHash,
+		BinDir:            m.binDir,
+		License:           m.License,
+		Profile:           m.profile,
+		Platform:          m.Platform(),
+		BuildFlags:        m.buildFlags.Proto(),
+		BuildStrategy:     m.buildStrategy.String(),
+		VersionConstraint: m.constraint,
+		PreferBinary:      m.preferBinary,
+		Prerelease:        m.prerelease,
+		Private:           m.private,
+		Goproxy:           m.goproxy,
+		RootModule:        m.RootModule,
+		Sum:               m.Sum,
+		GoModSum:          m.GoModSum,
+		Alias:             m.Alias,
+		Provenance:        m.provenance,
+		ShimPath:          m.ShimPath,
+		Scan:              m.scanResult,
+		Build:             m.buildMetrics,
 	}
+}
+
+func (m *Module) Report(db *database.Storage) error {
+	// Convert Module struct to Protocol Buffer
+	moduleProto := m.ToProto()
 
 	// Upsert module
 	if err := db.UpsertModule(moduleProto); err != nil {
@@ -296,12 +571,44 @@ func convertDependenciesToProto(deps []Dependency) []*pb.DependencyProto {
 			Versions:     dep.Versions,
 			Hash:         dep.Hash,
 			Dependencies: convertDependenciesToProto(dep.Dependencies),
+			License:      dep.License,
 		})
 	}
 
 	return result
 }
 
+// FlattenDependencies lists deps and their nested dependencies once each,
+// sorted by name
+func FlattenDependencies(deps []*pb.DependencyProto) []*pb.DependencyProto {
+	seen := make(map[string]bool)
+
+	var flat []*pb.DependencyProto
+
+	var walk func([]*pb.DependencyProto)
+	walk = func(deps []*pb.DependencyProto) {
+		for _, dep := range deps {
+			key := dep.GetName() + "@" + dep.GetVersion()
+			if seen[key] {
+				continue
+			}
+
+			seen[key] = true
+			flat = append(flat, dep)
+
+			walk(dep.GetDependencies())
+		}
+	}
+
+	walk(deps)
+
+	slices.SortFunc(flat, func(a, b *pb.DependencyProto) int {
+		return cmp.Compare(a.GetName(), b.GetName())
+	})
+
+	return flat
+}
+
 func LoadModuleFromFile(path string) (*Module, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -340,7 +647,6 @@ func (m *Module) dependency(module string) (*Dependency, error) {
 
 	return &Dependency{
 		Name:     name,
-		Hash:     m.hashModule(fmt.Sprintf("%s@%s", name, version)),
 		Version:  version,
 		Versions: result.ListResp.Versions,
 	}, nil
@@ -348,7 +654,7 @@ func (m *Module) dependency(module string) (*Dependency, error) {
 
 // tryFetchVersions attempts a single version fetch for a specific module path
 func (m *Module) tryFetchVersions(ctx context.Context, module string) (*ListResp, error) {
-	cmd := exec.CommandContext(ctx, m.goBinPath, "list", "-m", "-versions", "-json", fmt.Sprintf("%s@latest", module))
+	cmd := m.goCommand(ctx, "list", "-m", "-versions", "-json", fmt.Sprintf("%s@latest", module))
 	cmd.Dir = m.workingDir
 
 	var (
@@ -369,7 +675,7 @@ func (m *Module) tryFetchVersions(ctx context.Context, module string) (*ListResp
 	// For modules with tagged versions, sort them by semver
 	if !lr.EmptyVersions() {
 		sort.Slice(lr.Versions, func(i, j int) bool {
-			return semver.Compare(lr.Versions[i], lr.Versions[j]) > 0
+			return modver.Newer(lr.Versions[i], lr.Versions[j])
 		})
 
 		return &lr, nil
@@ -391,24 +697,43 @@ type fetchModuleVersionsResult struct {
 }
 
 func (m *Module) fetchModuleVersions(ctx context.Context, module string) (*fetchModuleVersionsResult, error) {
+	if cached := m.cachedVersions(ctx, module); cached != nil {
+		return cached, nil
+	}
+
 	original := module
 	attempts := 0
 
 	const maxAttempts = 5
 
+	// Access refused to the original path, as for private modules without
+	// credentials, fails every other path of the module as well. Otherwise
+	// the go error of the original path is the likely cause of a failure.
+	var (
+		denied error
+		cause  string
+	)
+
 	// PHASE 1: Try original path with backwards traversal
 	for {
-		cmd := exec.CommandContext(ctx, m.goBinPath, "list", "-m", "-versions", "-json", fmt.Sprintf("%s@latest", module))
+		cmd := m.goCommand(ctx, "list", "-m", "-versions", "-json", fmt.Sprintf("%s@latest", module))
 		cmd.Dir = m.workingDir
 
 		var (
-			lr  ListResp
-			out bytes.Buffer
+			lr          ListResp
+			out, stderr bytes.Buffer
 		)
 
 		cmd.Stdout = &out
+		cmd.Stderr = &stderr
 
-		if err := cmd.Run(); err == nil {
+		err := cmd.Run()
+		if err != nil && attempts == 0 {
+			denied = fetchError(module, stderr.String())
+			cause, _, _ = strings.Cut(strings.TrimSpace(stderr.String()), "\n")
+		}
+
+		if err == nil {
 			if err := json.NewDecoder(&out).Decode(&lr); err != nil {
 				return nil, fmt.Errorf("decoding list response failed: %w", err)
 			}
@@ -416,16 +741,23 @@ func (m *Module) fetchModuleVersions(ctx context.Context, module string) (*fetch
 			// For modules with tagged versions
 			if !lr.EmptyVersions() {
 				sort.Slice(lr.Versions, func(i, j int) bool {
-					return semver.Compare(lr.Versions[i], lr.Versions[j]) > 0
+					return modver.Newer(lr.Versions[i], lr.Versions[j])
 				})
 
-				return &fetchModuleVersionsResult{ListResp: &lr, RootModule: module}, nil
+				result := &fetchModuleVersionsResult{ListResp: &lr, RootModule: module}
+				m.cacheVersions(ctx, original, result)
+
+				return result, nil
 			}
 
 			// For modules without tags, use pseudo-version if available
 			if lr.Version != "" {
 				lr.Versions = []string{lr.Version}
-				return &fetchModuleVersionsResult{ListResp: &lr, RootModule: module}, nil
+
+				result := &fetchModuleVersionsResult{ListResp: &lr, RootModule: module}
+				m.cacheVersions(ctx, original, result)
+
+				return result, nil
 			}
 		}
 
@@ -439,21 +771,25 @@ func (m *Module) fetchModuleVersions(ctx context.Context, module string) (*fetch
 		attempts++
 	}
 
+	if denied != nil {
+		return nil, denied
+	}
+
 	// PHASE 2: Smart Detection - Discover CLI paths
 	// Only trigger discovery for the original user input, not for dependencies
 	// Check if the original path looks like a root module (not a deep import path)
 	if strings.Count(original, "/") <= 2 || strings.Contains(original, "/cmd/") || strings.Contains(original, "/cli/") {
-		fmt.Printf("Path %q not found, searching for installable CLIs...\n", original)
+		m.progress("discover", fmt.Sprintf("Path %q not found, searching for installable CLIs...", original))
 
 		discovered, found, err := m.DiscoverCLIPaths(ctx, original)
 		if err != nil || !found {
-			return nil, fmt.Errorf("failed to resolve module versions for %q (initially %q)", module, original)
+			return nil, unresolvedError(module, original, cause)
 		}
 
 		if len(discovered) > 1 {
-			fmt.Printf("Found %d installable CLIs, using first: %s\n", len(discovered), discovered[0])
+			m.progress("discover", fmt.Sprintf("Found %d installable CLIs, using first: %s", len(discovered), discovered[0]))
 		} else {
-			fmt.Printf("Found installable CLI: %s\n", discovered[0])
+			m.progress("discover", fmt.Sprintf("Found installable CLI: %s", discovered[0]))
 		}
 
 		// Try first discovered path to get versions
@@ -467,25 +803,70 @@ func (m *Module) fetchModuleVersions(ctx context.Context, module string) (*fetch
 		}
 	}
 
-	return nil, fmt.Errorf("failed to resolve module versions for %q (initially %q)", module, original)
+	return nil, unresolvedError(module, original, cause)
+}
+
+// unresolvedError reports that no path of a module resolved, with the go
+// error of the original path when there is one
+func unresolvedError(module, original, cause string) error {
+	if cause == "" {
+		return fmt.Errorf("failed to resolve module versions for %q (initially %q)", module, original)
+	}
+
+	return fmt.Errorf("failed to resolve module versions for %q (initially %q): %s", module, original, strings.TrimPrefix(cause, "go: "))
+}
+
+// resolveVersion returns the version a branch, commit or version query of a
+// module resolves to
+func (m *Module) resolveVersion(ctx context.Context, module, query string) (string, error) {
+	cmd := m.goCommand(ctx, "list", "-m", "-json", fmt.Sprintf("%s@%s", module, query))
+	cmd.Dir = m.workingDir
+
+	var out, stderr bytes.Buffer
+
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if denied := fetchError(module, stderr.String()); denied != nil {
+			return "", denied
+		}
+
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.New(strings.TrimPrefix(msg, "go: "))
+		}
+
+		return "", fmt.Errorf("failed to resolve %s@%s: %w", module, query, err)
+	}
+
+	var info GoModule
+	if err := json.NewDecoder(&out).Decode(&info); err != nil {
+		return "", fmt.Errorf("failed to decode module info: %w", err)
+	}
+
+	if info.Version == "" {
+		return "", fmt.Errorf("%s@%s resolved to no version", module, query)
+	}
+
+	return info.Version, nil
 }
 
 func (m *Module) getModule(ctx context.Context, moduleWithVersion string) error {
-	cmd := exec.CommandContext(ctx, m.goBinPath, "get", moduleWithVersion)
+	cmd := m.goCommand(ctx, "get", moduleWithVersion)
 	cmd.Dir = m.workingDir
 
 	return cmd.Run()
 }
 
 func (m *Module) getLatestModule(ctx context.Context, moduleName string) error {
-	cmd := exec.CommandContext(ctx, m.goBinPath, "get", fmt.Sprintf("%s@latest", moduleName))
+	cmd := m.goCommand(ctx, "get", fmt.Sprintf("%s@latest", moduleName))
 	cmd.Dir = m.workingDir
 
 	return cmd.Run()
 }
 
 func (m *Module) extractDependencies(ctx context.Context, self string) ([]Dependency, error) {
-	cmd := exec.CommandContext(ctx, m.goBinPath, "list", "-m", "all")
+	cmd := m.goCommand(ctx, "list", "-m", "all")
 	cmd.Dir = m.workingDir
 
 	out, err := cmd.Output()
@@ -495,6 +876,10 @@ func (m *Module) extractDependencies(ctx context.Context, self string) ([]Depend
 
 	seen := make(map[string]struct{}) // module name deduplication
 
+	// Dependencies providing packages to the build have their zip hash in
+	// go.sum; those only required for their go.mod have none
+	sums := readGoSum(filepath.Join(m.workingDir, "go.sum"))
+
 	var deps []Dependency
 
 	lines := strings.SplitSeq(string(out), "\n")
@@ -515,8 +900,23 @@ func (m *Module) extractDependencies(ctx context.Context, self string) ([]Depend
 
 		seen[name] = struct{}{}
 
+		// Offline, the version lists of the cache are not worth refreshing
+		if m.offline && len(fields) > 1 {
+			deps = append(deps, Dependency{
+				Name:    name,
+				Hash:    sums[name+"@"+fields[1]],
+				Version: fields[1],
+			})
+
+			continue
+		}
+
 		dep, err := m.dependency(name)
 		if err == nil {
+			if len(fields) > 1 {
+				dep.Hash = sums[name+"@"+fields[1]]
+			}
+
 			deps = append(deps, *dep)
 		}
 	}
@@ -541,19 +941,27 @@ func (m *Module) splitModuleVersion(full string) (string, string) {
 	return full, "latest"
 }
 
-func (m *Module) hashModule(input string) string {
-	return fmt.Sprintf("%x", sha256.Sum256([]byte(input)))
-}
-
+// pickVersion returns the explicitly requested version, the newest release
+// a requested range allows, or the highest available version when none (or
+// "latest") was requested
 func (m *Module) pickVersion(preferred string, versions []string) string {
-	if len(versions) > 0 {
-		return versions[0]
+	if IsVersionRange(preferred) {
+		r, err := ParseVersionRange(preferred)
+		if err != nil {
+			return ""
+		}
+
+		return r.Newest(versions)
 	}
 
-	if preferred != "" {
+	if preferred != "" && preferred != "latest" {
 		return preferred
 	}
 
+	if len(versions) > 0 {
+		return versions[0]
+	}
+
 	return ""
 }
 
@@ -2,6 +2,9 @@ package module
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -49,3 +52,54 @@ func TestModule_Check_Latest(t *testing.T) {
 		t.Fatalf("expected %s but got %s", mod.Name, mod1.Name)
 	}
 }
+
+func TestResolveVersion(t *testing.T) {
+	// A file proxy resolving the branch "main" to a pseudo-version
+	proxy := t.TempDir()
+	versions := filepath.Join(proxy, "example.com", "tool", "@v")
+
+	if err := os.MkdirAll(versions, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	const pseudo = "v1.2.1-0.20250601000000-0123456789ab"
+
+	info := fmt.Sprintf(`{"Version":%q,"Time":"2025-06-01T00:00:00Z"}`, pseudo)
+	for name, content := range map[string]string{
+		"main.info":      info,
+		pseudo + ".info": info,
+		pseudo + ".mod":  "module example.com/tool\n",
+		"v1.2.0.info":    `{"Version":"v1.2.0","Time":"2025-03-01T00:00:00Z"}`,
+		"v1.2.0.mod":     "module example.com/tool\n",
+		"list":           "v1.2.0\n",
+	} {
+		if err := os.WriteFile(filepath.Join(versions, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Setenv("GOPROXY", "file://"+filepath.ToSlash(proxy))
+	t.Setenv("GOSUMDB", "off")
+	t.Setenv("GOFLAGS", "-mod=mod")
+	t.Setenv("GOMODCACHE", t.TempDir())
+
+	mod, err := NewModule(context.TODO(), "go", t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mod.setupTempModule(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+
+	for query, want := range map[string]string{"main": pseudo, "v1.2": "v1.2.0"} {
+		got, err := mod.resolveVersion(context.TODO(), "example.com/tool", query)
+		if err != nil || got != want {
+			t.Errorf("resolveVersion(%q) = %q, %v; want %q", query, got, err, want)
+		}
+	}
+
+	if _, err := mod.resolveVersion(context.TODO(), "example.com/tool", "missing"); err == nil {
+		t.Error("resolving a missing branch should fail")
+	}
+}
@@ -0,0 +1,60 @@
+package module
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SetOffline makes the go commands of the module resolve and download
+// modules from the local module cache only, for air-gapped machines or a
+// flaky network. Queries such as latest see the versions in the cache.
+func (m *Module) SetOffline(offline bool) {
+	m.offline = offline
+}
+
+// Offline reports whether the module is fetched from the module cache only
+func (m *Module) Offline() bool {
+	return m.offline
+}
+
+// OfflineEnv reports whether the environment already rules out the network:
+// with GOPROXY=off go only builds what is cached, but cannot resolve queries
+// such as latest, which offline mode can
+func OfflineEnv() bool {
+	return strings.TrimSpace(os.Getenv("GOPROXY")) == "off"
+}
+
+// CacheProxyURL returns the GOPROXY URL serving the download directory of a
+// module cache, which holds the version lists, go.mod files and zips of
+// every cached module in the module proxy layout
+func CacheProxyURL(modCache string) string {
+	dir := filepath.ToSlash(filepath.Join(modCache, "cache", "download"))
+	if !strings.HasPrefix(dir, "/") {
+		dir = "/" + dir // Windows drive letters
+	}
+
+	return "file://" + dir
+}
+
+// offlineEnv returns the variables that point the go commands of an offline
+// module at its module cache. Private modules come from the cache too, and
+// the checksum database is not consulted: the cached modules were verified
+// when they were downloaded.
+func (m *Module) offlineEnv() []string {
+	if !m.offline {
+		return nil
+	}
+
+	modCache, err := ModCacheDir(context.Background(), m.goBinPath, m.profile)
+	if err != nil {
+		return []string{"GOPROXY=off"}
+	}
+
+	return []string{
+		"GOPROXY=" + CacheProxyURL(modCache),
+		"GONOPROXY=none",
+		"GOSUMDB=off",
+	}
+}
@@ -0,0 +1,35 @@
+package module
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOfflineEnv(t *testing.T) {
+	t.Setenv("GOFLAGS", "-buildvcs=false")
+
+	m := &Module{profile: "work"}
+	if env := m.offlineEnv(); env != nil {
+		t.Errorf("online module: offlineEnv = %v, want nil", env)
+	}
+
+	m.SetOffline(true)
+
+	env := m.goEnv()
+	for _, want := range []string{
+		"GOPROXY=" + CacheProxyURL(ProfileModCache("work")),
+		"GONOPROXY=none",
+		"GOSUMDB=off",
+		"GOFLAGS=-buildvcs=false -mod=mod",
+	} {
+		if !slices.Contains(env, want) {
+			t.Errorf("goEnv lacks %s", want)
+		}
+	}
+}
+
+func TestCacheProxyURL(t *testing.T) {
+	if got, want := CacheProxyURL("/home/dev/go/pkg/mod"), "file:///home/dev/go/pkg/mod/cache/download"; got != want {
+		t.Errorf("CacheProxyURL = %q, want %q", got, want)
+	}
+}
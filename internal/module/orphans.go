@@ -0,0 +1,113 @@
+package module
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	osExec "os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/inovacc/glix/pkg/exec"
+)
+
+// ProcessRecord is a build command glix started, kept while it runs
+type ProcessRecord struct {
+	PID     int       `json:"pid"`   // Leader of the command's process group
+	Owner   int       `json:"owner"` // The glix process that started it
+	Command string    `json:"command"`
+	Started time.Time `json:"started"`
+}
+
+// processesDir returns the directory of the records of running commands.
+// The cache directory is per process, so they are kept in the application
+// directory every glix process shares.
+func processesDir() string {
+	return filepath.Join(appDir, "processes")
+}
+
+// trackProcess records a started command until the returned function is
+// called, so one a glix process left behind when it died before it could
+// kill it is found by FindOrphans
+func trackProcess(cmd *osExec.Cmd) func() {
+	rec := ProcessRecord{
+		PID:     cmd.Process.Pid,
+		Owner:   os.Getpid(),
+		Command: strings.Join(cmd.Args, " "),
+		Started: time.Now(),
+	}
+
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return func() {}
+	}
+
+	path := filepath.Join(processesDir(), strconv.Itoa(rec.PID)+".json")
+	if err := writeFileAtomic(path, data); err != nil {
+		return func() {}
+	}
+
+	return func() {
+		_ = os.Remove(path)
+	}
+}
+
+// FindOrphans returns the commands still running although the glix process
+// that started them is gone, typically go builds that outlived an
+// interrupted install. Records of commands that exited are removed.
+func FindOrphans() ([]ProcessRecord, error) {
+	entries, err := os.ReadDir(processesDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read process records: %w", err)
+	}
+
+	var orphans []ProcessRecord
+
+	for _, entry := range entries {
+		path := filepath.Join(processesDir(), entry.Name())
+		if filepath.Ext(path) != ".json" {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		var rec ProcessRecord
+		if err := json.Unmarshal(data, &rec); err != nil || rec.PID <= 0 {
+			_ = os.Remove(path)
+			continue
+		}
+
+		switch {
+		case !exec.ProcessGroupAlive(rec.PID):
+			_ = os.Remove(path)
+		case !exec.ProcessAlive(rec.Owner):
+			orphans = append(orphans, rec)
+		}
+	}
+
+	return orphans, nil
+}
+
+// ReapOrphan kills an orphaned command with its process group and forgets it
+func ReapOrphan(rec ProcessRecord) error {
+	if err := exec.KillProcessGroup(rec.PID); err != nil {
+		return fmt.Errorf("failed to kill pid %d: %w", rec.PID, err)
+	}
+
+	err := os.Remove(filepath.Join(processesDir(), strconv.Itoa(rec.PID)+".json"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
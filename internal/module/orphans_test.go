@@ -0,0 +1,90 @@
+package module
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	osExec "os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/inovacc/glix/pkg/exec"
+)
+
+// writeProcessRecord records pid as a command started by owner
+func writeProcessRecord(t *testing.T, pid, owner int) {
+	t.Helper()
+
+	data, _ := json.Marshal(ProcessRecord{PID: pid, Owner: owner, Command: "sleep 30", Started: time.Now()})
+	if err := writeFileAtomic(filepath.Join(processesDir(), strconv.Itoa(pid)+".json"), data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindOrphans(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sleep and true")
+	}
+
+	useTempAppDir(t)
+
+	if orphans, err := FindOrphans(); err != nil || len(orphans) != 0 {
+		t.Fatalf("FindOrphans without records = %v, %v", orphans, err)
+	}
+
+	// A glix process that has exited
+	gone := exec.Command("true")
+	if err := gone.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	orphan := exec.CommandContext(ctx, "sleep", "30")
+	running := exec.CommandContext(ctx, "sleep", "30")
+
+	for _, cmd := range []*osExec.Cmd{orphan, running} {
+		if err := cmd.Start(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defer func() {
+		cancel()
+		_ = orphan.Wait()
+		_ = running.Wait()
+	}()
+
+	writeProcessRecord(t, orphan.Process.Pid, gone.Process.Pid)
+	writeProcessRecord(t, running.Process.Pid, os.Getpid())
+	writeProcessRecord(t, gone.Process.Pid, os.Getpid()) // Exited
+
+	orphans, err := FindOrphans()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(orphans) != 1 || orphans[0].PID != orphan.Process.Pid {
+		t.Fatalf("FindOrphans = %+v, want pid %d", orphans, orphan.Process.Pid)
+	}
+
+	if _, err := os.Stat(filepath.Join(processesDir(), strconv.Itoa(gone.Process.Pid)+".json")); !os.IsNotExist(err) {
+		t.Error("record of an exited command kept")
+	}
+
+	if err := ReapOrphan(orphans[0]); err != nil {
+		t.Fatalf("ReapOrphan: %v", err)
+	}
+
+	if err := orphan.Wait(); err == nil {
+		t.Error("reaped orphan exited cleanly, want killed")
+	}
+
+	if orphans, _ := FindOrphans(); len(orphans) != 0 {
+		t.Errorf("FindOrphans after reaping = %+v", orphans)
+	}
+}
@@ -0,0 +1,66 @@
+package module
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// OSVQueryURL is the OSV.dev endpoint used for vulnerability lookups
+var OSVQueryURL = "https://api.osv.dev/v1/query"
+
+// Vulnerability is a known vulnerability affecting a module version
+type Vulnerability struct {
+	ID      string   `json:"id"`
+	Summary string   `json:"summary"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// QueryVulnerabilities asks OSV.dev for known vulnerabilities affecting a
+// module version. modulePath must be the module root, not a package path.
+func QueryVulnerabilities(ctx context.Context, modulePath, version string) ([]Vulnerability, error) {
+	body, err := json.Marshal(map[string]any{
+		"package": map[string]string{
+			"name":      modulePath,
+			"ecosystem": "Go",
+		},
+		// OSV records Go versions without the leading v
+		"version": strings.TrimPrefix(version, "v"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, OSVQueryURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := proxyHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("vulnerability lookup failed: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vulnerability lookup failed: %s", resp.Status)
+	}
+
+	var result struct {
+		Vulns []Vulnerability `json:"vulns"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode vulnerability response: %w", err)
+	}
+
+	return result.Vulns, nil
+}
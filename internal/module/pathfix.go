@@ -0,0 +1,147 @@
+package module
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Shells PathFix knows the startup files of
+var Shells = []string{"bash", "zsh", "fish", "powershell", "sh"}
+
+// PathFix adds a directory to PATH in the startup file of a shell
+type PathFix struct {
+	Shell   string // One of Shells
+	Dir     string // Directory to add to PATH
+	Line    string // Shell line adding Dir to PATH
+	Profile string // Startup file the line belongs in
+}
+
+// DetectShell returns the shell of the user from $SHELL: PowerShell on
+// Windows without one, and sh for shells PathFix does not know
+func DetectShell() string {
+	shell := os.Getenv("SHELL")
+	if shell == "" && runtime.GOOS == "windows" {
+		return "powershell"
+	}
+
+	name := strings.TrimSuffix(filepath.Base(shell), ".exe")
+	switch name {
+	case "bash", "zsh", "fish":
+		return name
+	case "pwsh", "powershell":
+		return "powershell"
+	default:
+		return "sh"
+	}
+}
+
+// NewPathFix returns how to add dir to PATH in shell, one of Shells
+func NewPathFix(shell, dir string) (PathFix, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return PathFix{}, fmt.Errorf("failed to find home directory: %w", err)
+	}
+
+	dir = filepath.Clean(dir)
+	fix := PathFix{Shell: shell, Dir: dir}
+
+	switch shell {
+	case "bash":
+		fix.Line = fmt.Sprintf(`export PATH="$PATH:%s"`, quotePosix(dir))
+		fix.Profile = filepath.Join(home, ".bashrc")
+
+		// Terminals on macOS start login shells, which skip .bashrc
+		if runtime.GOOS == "darwin" {
+			fix.Profile = filepath.Join(home, ".bash_profile")
+		}
+	case "zsh":
+		fix.Line = fmt.Sprintf(`export PATH="$PATH:%s"`, quotePosix(dir))
+		fix.Profile = filepath.Join(envOr("ZDOTDIR", home), ".zshrc")
+	case "fish":
+		fix.Line = "fish_add_path '" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(dir) + "'"
+		fix.Profile = filepath.Join(envOr("XDG_CONFIG_HOME", filepath.Join(home, ".config")), "fish", "config.fish")
+	case "powershell":
+		fix.Line = fmt.Sprintf(`$env:Path += "%c%s"`, os.PathListSeparator, strings.NewReplacer("`", "``", `"`, "`\"", "$", "`$").Replace(dir))
+		fix.Profile = filepath.Join(home, "Documents", "PowerShell", "Microsoft.PowerShell_profile.ps1")
+	case "sh":
+		fix.Line = fmt.Sprintf(`export PATH="$PATH:%s"`, quotePosix(dir))
+		fix.Profile = filepath.Join(home, ".profile")
+	default:
+		return PathFix{}, fmt.Errorf("unknown shell %q (want one of %s)", shell, strings.Join(Shells, ", "))
+	}
+
+	return fix, nil
+}
+
+// quotePosix escapes s for use in a double-quoted POSIX shell string
+func quotePosix(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`").Replace(s)
+}
+
+// envOr returns the value of the environment variable key, or fallback when
+// it is unset
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+
+	return fallback
+}
+
+// Persisted reports whether the startup file mentions the directory
+// already, as the line of the fix or spelled with $HOME or ~, in which case
+// only shells started before it miss the directory
+func (f PathFix) Persisted() bool {
+	data, err := os.ReadFile(f.Profile)
+	if err != nil {
+		return false
+	}
+
+	content := string(data)
+	spellings := []string{f.Line, f.Dir}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		if rel, ok := strings.CutPrefix(f.Dir, home); ok && strings.HasPrefix(rel, string(filepath.Separator)) {
+			spellings = append(spellings, "$HOME"+rel, "${HOME}"+rel, "~"+rel)
+		}
+	}
+
+	for _, s := range spellings {
+		if strings.Contains(content, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Persist appends the line of the fix to the startup file unless it is
+// persisted already, and reports whether it did
+func (f PathFix) Persist() (bool, error) {
+	if f.Persisted() {
+		return false, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(f.Profile), 0755); err != nil {
+		return false, fmt.Errorf("failed to create %s: %w", filepath.Dir(f.Profile), err)
+	}
+
+	file, err := os.OpenFile(f.Profile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return false, fmt.Errorf("failed to open %s: %w", f.Profile, err)
+	}
+
+	if _, err := fmt.Fprintf(file, "\n# Added by glix\n%s\n", f.Line); err != nil {
+		_ = file.Close()
+		return false, fmt.Errorf("failed to write %s: %w", f.Profile, err)
+	}
+
+	if err := file.Close(); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", f.Profile, err)
+	}
+
+	return true, nil
+}
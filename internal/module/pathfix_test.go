@@ -0,0 +1,145 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestDetectShell(t *testing.T) {
+	tests := map[string]string{
+		"/bin/bash":           "bash",
+		"/usr/bin/zsh":        "zsh",
+		"/usr/local/bin/fish": "fish",
+		"/usr/bin/pwsh":       "powershell",
+		"/bin/dash":           "sh",
+		"/bin/ksh":            "sh",
+	}
+
+	for shell, want := range tests {
+		t.Setenv("SHELL", shell)
+
+		if got := DetectShell(); got != want {
+			t.Errorf("DetectShell() with SHELL=%s = %q, want %q", shell, got, want)
+		}
+	}
+}
+
+func TestNewPathFix(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("ZDOTDIR", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	dir := filepath.Join(home, "go", "bin")
+
+	bashrc := ".bashrc"
+	if runtime.GOOS == "darwin" {
+		bashrc = ".bash_profile"
+	}
+
+	tests := []struct {
+		shell   string
+		line    string
+		profile string
+	}{
+		{"bash", `export PATH="$PATH:` + dir + `"`, filepath.Join(home, bashrc)},
+		{"zsh", `export PATH="$PATH:` + dir + `"`, filepath.Join(home, ".zshrc")},
+		{"sh", `export PATH="$PATH:` + dir + `"`, filepath.Join(home, ".profile")},
+		{"fish", "fish_add_path '" + dir + "'", filepath.Join(home, ".config", "fish", "config.fish")},
+	}
+
+	for _, tt := range tests {
+		fix, err := NewPathFix(tt.shell, dir)
+		if err != nil {
+			t.Fatalf("NewPathFix(%s) error: %v", tt.shell, err)
+		}
+
+		if fix.Line != tt.line || fix.Profile != tt.profile {
+			t.Errorf("NewPathFix(%s) = %q in %s, want %q in %s", tt.shell, fix.Line, fix.Profile, tt.line, tt.profile)
+		}
+	}
+
+	if _, err := NewPathFix("tcsh", dir); err == nil {
+		t.Error("NewPathFix(tcsh) succeeded, want error")
+	}
+}
+
+func TestPathFixQuoting(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	fix, err := NewPathFix("bash", `/opt/a "b" $c`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `export PATH="$PATH:/opt/a \"b\" \$c"`; fix.Line != want {
+		t.Errorf("Line = %s, want %s", fix.Line, want)
+	}
+
+	fix, err = NewPathFix("fish", "/opt/it's")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `fish_add_path '/opt/it\'s'`; fix.Line != want {
+		t.Errorf("Line = %s, want %s", fix.Line, want)
+	}
+}
+
+func TestPathFixPersist(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	fix, err := NewPathFix("sh", filepath.Join(home, "go", "bin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fix.Persisted() {
+		t.Fatal("Persisted() before Persist")
+	}
+
+	for i, want := range []bool{true, false} {
+		added, err := fix.Persist()
+		if err != nil {
+			t.Fatalf("Persist() error: %v", err)
+		}
+
+		if added != want {
+			t.Errorf("Persist() call %d added = %v, want %v", i+1, added, want)
+		}
+	}
+
+	data, err := os.ReadFile(fix.Profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := strings.Count(string(data), fix.Line); n != 1 {
+		t.Errorf("profile has the line %d times, want once:\n%s", n, data)
+	}
+}
+
+func TestPathFixPersistedSpelledWithHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	if err := os.WriteFile(filepath.Join(home, ".profile"), []byte(`PATH="$HOME/go/bin:$PATH"`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fix, err := NewPathFix("sh", filepath.Join(home, "go", "bin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if runtime.GOOS != "windows" && !fix.Persisted() {
+		t.Error("a profile adding $HOME/go/bin should count as persisted")
+	}
+}
@@ -0,0 +1,148 @@
+package module
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// SetPlatform makes installs cross-compile for goos/goarch. Empty values
+// stand for those of this machine.
+func (m *Module) SetPlatform(goos, goarch string) {
+	m.targetOS = goos
+	m.targetArch = goarch
+}
+
+// Platform returns the target platform as goos/goarch, or "" when builds are
+// for this machine
+func (m *Module) Platform() string {
+	if !m.crossCompiling() {
+		return ""
+	}
+
+	return m.goos() + "/" + m.goarch()
+}
+
+// SetRecordedPlatform makes installs build for a platform as recorded for an
+// install, a goos/goarch pair or "" for this machine
+func (m *Module) SetRecordedPlatform(platform string) {
+	goos, goarch, err := ParsePlatform(platform)
+	if err != nil {
+		goos, goarch = "", ""
+	}
+
+	m.SetPlatform(goos, goarch)
+}
+
+// ParsePlatform splits a goos/goarch platform, as recorded for cross-compiled
+// installs
+func ParsePlatform(platform string) (string, string, error) {
+	goos, goarch, ok := strings.Cut(platform, "/")
+	if !ok || goos == "" || goarch == "" || strings.Contains(goarch, "/") {
+		return "", "", fmt.Errorf("invalid platform %q (want goos/goarch)", platform)
+	}
+
+	return goos, goarch, nil
+}
+
+// PlatformDir returns the directory binaries for a goos/goarch platform are
+// placed in under a bin directory, e.g. "linux_arm64", as go install does
+func PlatformDir(platform string) string {
+	return strings.ReplaceAll(platform, "/", "_")
+}
+
+func (m *Module) goos() string {
+	if m.targetOS != "" {
+		return m.targetOS
+	}
+
+	return runtime.GOOS
+}
+
+func (m *Module) goarch() string {
+	if m.targetArch != "" {
+		return m.targetArch
+	}
+
+	return runtime.GOARCH
+}
+
+func (m *Module) crossCompiling() bool {
+	return m.goos() != runtime.GOOS || m.goarch() != runtime.GOARCH
+}
+
+// platformEnv returns the environment selecting the target platform
+func (m *Module) platformEnv() []string {
+	if !m.crossCompiling() {
+		return nil
+	}
+
+	return []string{"GOOS=" + m.goos(), "GOARCH=" + m.goarch()}
+}
+
+// builtBinaryPath returns where a build of the module for its target
+// platform places the binary in dir
+func (m *Module) builtBinaryPath(dir string) string {
+	binary := BinaryName(m.Name)
+	if m.goos() == "windows" {
+		binary += ".exe"
+	}
+
+	return filepath.Join(dir, binary)
+}
+
+// goInstall runs go install for pkg in dir with the build flags of the
+// module, placing the binary in the build directory. go install refuses to
+// place cross-compiled binaries in GOBIN, so those are installed to a GOPATH
+// of their own, sharing the module cache, and moved from there.
+func (m *Module) goInstall(ctx context.Context, dir, pkg string, handler OutputHandler) error {
+	args := append(append([]string{"install"}, m.buildFlags.Args()...), pkg)
+
+	if !m.crossCompiling() {
+		cmd := m.goCommand(ctx, args...)
+		cmd.Dir = dir
+		cmd.Env = m.goEnv(fmt.Sprintf("GOBIN=%s", m.buildDirectory()))
+
+		return runWithStreaming(cmd, handler, m.watchdog())
+	}
+
+	modCache, err := m.goModCache(ctx)
+	if err != nil {
+		return err
+	}
+
+	gopath := filepath.Join(m.workingDir, "gopath")
+
+	cmd := m.goCommand(ctx, args...)
+	cmd.Dir = dir
+	cmd.Env = m.goEnv(append(m.platformEnv(), "GOBIN=", "GOPATH="+gopath, "GOMODCACHE="+modCache)...)
+
+	if err := runWithStreaming(cmd, handler, m.watchdog()); err != nil {
+		return err
+	}
+
+	built := m.builtBinaryPath(filepath.Join(gopath, "bin", PlatformDir(m.goos()+"/"+m.goarch())))
+	dest := m.builtBinaryPath(m.buildDirectory())
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(dest), err)
+	}
+
+	if err := os.Rename(built, dest); err != nil {
+		if err := copyFile(built, dest); err != nil {
+			return fmt.Errorf("failed to place %s: %w", dest, err)
+		}
+
+		return os.Chmod(dest, 0755)
+	}
+
+	return nil
+}
+
+// goModCache returns the module cache the go commands of the module use
+func (m *Module) goModCache(ctx context.Context) (string, error) {
+	return ModCacheDir(ctx, m.goBinPath, m.profile)
+}
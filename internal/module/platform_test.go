@@ -0,0 +1,62 @@
+package module
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestParsePlatform(t *testing.T) {
+	goos, goarch, err := ParsePlatform("linux/arm64")
+	if err != nil || goos != "linux" || goarch != "arm64" {
+		t.Errorf("ParsePlatform(linux/arm64) = %q, %q, %v", goos, goarch, err)
+	}
+
+	for _, platform := range []string{"", "linux", "linux/", "/arm64", "linux/arm/v7"} {
+		if _, _, err := ParsePlatform(platform); err == nil {
+			t.Errorf("ParsePlatform(%q) should fail", platform)
+		}
+	}
+}
+
+func TestPlatform(t *testing.T) {
+	m := &Module{Name: "example.com/tool"}
+
+	if m.Platform() != "" || m.crossCompiling() {
+		t.Errorf("a module without a platform builds for this machine, got %q", m.Platform())
+	}
+
+	// This machine's platform, given explicitly, is not a cross build
+	m.SetPlatform(runtime.GOOS, runtime.GOARCH)
+	if m.Platform() != "" {
+		t.Errorf("Platform() = %q for this machine", m.Platform())
+	}
+
+	goos := "windows"
+	if runtime.GOOS == goos {
+		goos = "linux"
+	}
+
+	m.SetRecordedPlatform(goos + "/" + runtime.GOARCH)
+	if want := goos + "/" + runtime.GOARCH; m.Platform() != want {
+		t.Errorf("Platform() = %q, want %q", m.Platform(), want)
+	}
+
+	if len(m.platformEnv()) != 2 {
+		t.Errorf("platformEnv() = %v, want GOOS and GOARCH", m.platformEnv())
+	}
+
+	if got, want := PlatformDir(m.Platform()), goos+"_"+runtime.GOARCH; got != want {
+		t.Errorf("PlatformDir() = %q, want %q", got, want)
+	}
+
+	m.SetRecordedPlatform("windows/amd64")
+	if got, want := m.builtBinaryPath("bin"), filepath.Join("bin", "tool.exe"); got != want {
+		t.Errorf("builtBinaryPath() = %q, want %q", got, want)
+	}
+
+	m.SetRecordedPlatform("")
+	if m.Platform() != "" {
+		t.Errorf("Platform() = %q after clearing it", m.Platform())
+	}
+}
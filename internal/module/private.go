@@ -0,0 +1,227 @@
+package module
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	modpath "golang.org/x/mod/module"
+)
+
+// PrivateConfig configures the go commands of every install for private
+// modules. It is read from the config directory by each go command, so the
+// daemon's updates use it as well.
+type PrivateConfig struct {
+	Patterns []string `json:"patterns,omitempty"`  // Added to GOPRIVATE: no proxy, no checksum database
+	NoSumDB  []string `json:"nosumdb,omitempty"`   // Added to GONOSUMDB: proxied, not checked against the checksum database
+	Netrc    string   `json:"netrc,omitempty"`     // netrc file with the credentials of go's HTTPS requests
+	SSHHosts []string `json:"ssh_hosts,omitempty"` // Hosts git fetches from over SSH instead of HTTPS
+}
+
+// IsZero reports whether the config changes nothing
+func (c PrivateConfig) IsZero() bool {
+	return len(c.Patterns) == 0 && len(c.NoSumDB) == 0 && c.Netrc == "" && len(c.SSHHosts) == 0
+}
+
+// privateConfigPath returns the path to the private module config
+func privateConfigPath() string {
+	configDir, err := GetApplicationConfigDirectory()
+	if err != nil {
+		// Fallback to cache directory
+		configDir, _ = GetApplicationCacheDirectory()
+	}
+
+	return filepath.Join(configDir, "private.json")
+}
+
+// LoadPrivateConfig reads the private module config, empty when there is none
+func LoadPrivateConfig() (PrivateConfig, error) {
+	var c PrivateConfig
+
+	data, err := os.ReadFile(privateConfigPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+
+		return c, fmt.Errorf("failed to read private module config: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, fmt.Errorf("failed to parse private module config: %w", err)
+	}
+
+	return c, nil
+}
+
+// SavePrivateConfig writes the private module config
+func SavePrivateConfig(c PrivateConfig) error {
+	path := privateConfigPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal private module config: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write private module config: %w", err)
+	}
+
+	return nil
+}
+
+// SetPrivate makes the go commands of the module treat it as private,
+// fetching it directly from its repository without the checksum database
+func (m *Module) SetPrivate(private bool) {
+	m.private = private
+}
+
+// Private reports whether the module is treated as private
+func (m *Module) Private() bool {
+	return m.private
+}
+
+// privateEnv returns the variables the private module config, the private
+// setting of the module and the checksum database exclusions of its profile
+// add to the environment of go commands. Patterns extend those of the
+// environment rather than replacing them.
+func (m *Module) privateEnv() []string {
+	c, _ := LoadPrivateConfig()
+
+	patterns := c.Patterns
+	if m.private {
+		patterns = append(slices.Clone(patterns), cmp.Or(m.RootModule, m.Name))
+	}
+
+	var env []string
+
+	if len(patterns) > 0 {
+		env = append(env, "GOPRIVATE="+joinPatterns(os.Getenv("GOPRIVATE"), patterns))
+	}
+
+	if noSumDB := slices.Concat(c.NoSumDB, m.profileProxy.GoNoSumDB); len(noSumDB) > 0 {
+		env = append(env, "GONOSUMDB="+joinPatterns(os.Getenv("GONOSUMDB"), noSumDB))
+	}
+
+	if c.Netrc != "" {
+		env = append(env, "NETRC="+c.Netrc)
+	}
+
+	return append(env, gitSSHEnv(c.SSHHosts)...)
+}
+
+// IsPrivate reports whether go fetches a module from its repository rather
+// than from the module proxy, as GONOPROXY or GOPRIVATE of the environment
+// or the patterns of the private module config say
+func IsPrivate(modulePath string) bool {
+	c, _ := LoadPrivateConfig()
+	patterns := joinPatterns(cmp.Or(os.Getenv("GONOPROXY"), os.Getenv("GOPRIVATE")), c.Patterns)
+
+	return modpath.MatchPrefixPatterns(patterns, modulePath)
+}
+
+// joinPatterns appends patterns to a comma-separated pattern list
+func joinPatterns(list string, patterns []string) string {
+	all := slices.DeleteFunc(strings.Split(list, ","), func(p string) bool {
+		return strings.TrimSpace(p) == ""
+	})
+
+	for _, p := range patterns {
+		if !slices.Contains(all, p) {
+			all = append(all, p)
+		}
+	}
+
+	return strings.Join(all, ",")
+}
+
+// gitSSHEnv returns the git config, passed through GIT_CONFIG_* after any of
+// the environment, that makes git fetch from hosts over SSH, authenticating
+// with the user's SSH keys and agent instead of prompting for a password
+func gitSSHEnv(hosts []string) []string {
+	if len(hosts) == 0 {
+		return nil
+	}
+
+	count, _ := strconv.Atoi(os.Getenv("GIT_CONFIG_COUNT"))
+	env := make([]string, 0, 2*len(hosts)+1)
+
+	for i, host := range hosts {
+		n := count + i
+		env = append(env,
+			fmt.Sprintf("GIT_CONFIG_KEY_%d=url.ssh://git@%s/.insteadOf", n, host),
+			fmt.Sprintf("GIT_CONFIG_VALUE_%d=https://%s/", n, host),
+		)
+	}
+
+	return append(env, fmt.Sprintf("GIT_CONFIG_COUNT=%d", count+len(hosts)))
+}
+
+// ErrAuth means fetching a module failed because its host refused access
+var ErrAuth = errors.New("authentication failed")
+
+// authFailures are what go and git print when a host refuses access
+var authFailures = []string{
+	"terminal prompts disabled",
+	"could not read Username",
+	"could not read Password",
+	"Authentication failed",
+	"Permission denied (publickey",
+	"Host key verification failed",
+	"401 Unauthorized",
+	"403 Forbidden",
+	"Repository not found",
+}
+
+// sumDBFailures are what go prints when the checksum database does not know
+// a module, which it cannot for private ones
+var sumDBFailures = []string{
+	"verifying module",
+	"verifying go.mod",
+	"sum.golang.org/lookup",
+}
+
+// fetchError explains why go failed to fetch module from its stderr, and
+// how to install private modules when access was refused or the checksum
+// database does not know the module. It returns nil for other failures.
+func fetchError(module, stderr string) error {
+	msg := strings.TrimSpace(stderr)
+
+	hint := fmt.Sprintf("if %s is private, install it with --private or add it with 'glix private add', "+
+		"and provide credentials with a netrc file ('glix private netrc') or over SSH ('glix private ssh')", module)
+
+	for _, s := range authFailures {
+		if strings.Contains(msg, s) {
+			return fmt.Errorf("%w fetching %s: %s; %s", ErrAuth, module, lineWith(msg, s), hint)
+		}
+	}
+
+	for _, s := range sumDBFailures {
+		if strings.Contains(msg, s) && (strings.Contains(msg, "404 Not Found") || strings.Contains(msg, "410 Gone")) {
+			return fmt.Errorf("the checksum database does not know %s: %s; %s", module, lineWith(msg, s), hint)
+		}
+	}
+
+	return nil
+}
+
+// lineWith returns the line of go's output naming the cause of a failure
+func lineWith(msg, cause string) string {
+	for line := range strings.SplitSeq(msg, "\n") {
+		if strings.Contains(line, cause) {
+			line = strings.TrimPrefix(strings.TrimSpace(line), "go: ")
+			return strings.TrimPrefix(line, "fatal: ")
+		}
+	}
+
+	return cause
+}
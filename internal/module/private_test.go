@@ -0,0 +1,95 @@
+package module
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFetchError(t *testing.T) {
+	tests := []struct {
+		name, stderr string
+		auth, sumdb  bool
+	}{
+		{
+			name: "https without credentials",
+			stderr: `go: github.com/acme/tool@latest: module github.com/acme/tool: git ls-remote -q origin in /tmp/x: exit status 128:
+	fatal: could not read Username for 'https://github.com': terminal prompts disabled
+Confirm the import path was typed correctly.
+If this is a private repository, see https://golang.org/doc/faq#git_https for additional information.`,
+			auth: true,
+		},
+		{
+			name:   "ssh key refused",
+			stderr: "go: github.com/acme/tool@latest: git@github.com: Permission denied (publickey).",
+			auth:   true,
+		},
+		{
+			name: "unknown to the checksum database",
+			stderr: `go: go.acme.dev/tool@v1.0.0: verifying module: go.acme.dev/tool@v1.0.0: reading https://sum.golang.org/lookup/go.acme.dev/tool@v1.0.0: 404 Not Found
+	server response: not found`,
+			sumdb: true,
+		},
+		{
+			name:   "unknown revision",
+			stderr: "go: github.com/acme/tool@v9.9.9: invalid version: unknown revision v9.9.9",
+		},
+	}
+
+	for _, tt := range tests {
+		err := fetchError("github.com/acme/tool", tt.stderr)
+
+		switch {
+		case tt.auth:
+			if !errors.Is(err, ErrAuth) || !strings.Contains(err.Error(), "--private") || strings.Contains(err.Error(), "golang.org/doc/faq") {
+				t.Errorf("%s: fetchError = %v, want an authentication error naming the cause", tt.name, err)
+			}
+		case tt.sumdb:
+			if err == nil || errors.Is(err, ErrAuth) || !strings.Contains(err.Error(), "checksum database") {
+				t.Errorf("%s: fetchError = %v, want a checksum database error", tt.name, err)
+			}
+		case err != nil:
+			t.Errorf("%s: fetchError = %v, want nil", tt.name, err)
+		}
+	}
+}
+
+func TestJoinPatterns(t *testing.T) {
+	tests := []struct {
+		list     string
+		patterns []string
+		want     string
+	}{
+		{"", []string{"github.com/acme"}, "github.com/acme"},
+		{"go.corp.dev,,", []string{"github.com/acme"}, "go.corp.dev,github.com/acme"},
+		{"github.com/acme", []string{"github.com/acme", "go.corp.dev"}, "github.com/acme,go.corp.dev"},
+	}
+
+	for _, tt := range tests {
+		if got := joinPatterns(tt.list, tt.patterns); got != tt.want {
+			t.Errorf("joinPatterns(%q, %v) = %q, want %q", tt.list, tt.patterns, got, tt.want)
+		}
+	}
+}
+
+func TestGitSSHEnv(t *testing.T) {
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+
+	got := gitSSHEnv([]string{"github.com", "gitlab.acme.dev"})
+	want := []string{
+		"GIT_CONFIG_KEY_1=url.ssh://git@github.com/.insteadOf",
+		"GIT_CONFIG_VALUE_1=https://github.com/",
+		"GIT_CONFIG_KEY_2=url.ssh://git@gitlab.acme.dev/.insteadOf",
+		"GIT_CONFIG_VALUE_2=https://gitlab.acme.dev/",
+		"GIT_CONFIG_COUNT=3",
+	}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("gitSSHEnv = %v, want %v", got, want)
+	}
+
+	if env := gitSSHEnv(nil); env != nil {
+		t.Errorf("gitSSHEnv(nil) = %v, want nil", env)
+	}
+}
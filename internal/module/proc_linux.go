@@ -0,0 +1,73 @@
+package module
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// processStates names the states of /proc/<pid>/stat
+var processStates = map[string]string{
+	"R": "running",
+	"S": "sleeping",
+	"D": "waiting on I/O",
+	"T": "stopped",
+	"t": "stopped",
+	"Z": "zombie",
+}
+
+// clockTicks is the USER_HZ the CPU times in /proc are counted in
+const clockTicks = 100
+
+// processState describes what the process pid is doing: its state, the CPU
+// time it used, and the commands it is running
+func processState(pid int) string {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return fmt.Sprintf("pid %d", pid)
+	}
+
+	// The command name is parenthesized and may contain spaces
+	stat := string(data)
+	fields := strings.Fields(stat[strings.LastIndexByte(stat, ')')+1:])
+	if len(fields) < 13 {
+		return fmt.Sprintf("pid %d", pid)
+	}
+
+	state := processStates[fields[0]]
+	if state == "" {
+		state = fields[0]
+	}
+
+	utime, _ := strconv.ParseInt(fields[11], 10, 64)
+	stime, _ := strconv.ParseInt(fields[12], 10, 64)
+	cpu := time.Duration(utime+stime) * time.Second / clockTicks
+
+	desc := fmt.Sprintf("pid %d %s, %s CPU", pid, state, cpu.Round(time.Second/10))
+
+	if children := childCommands(pid); len(children) > 0 {
+		desc += ", running " + strings.Join(children, ", ")
+	}
+
+	return desc
+}
+
+// childCommands returns the command names of the children of pid
+func childCommands(pid int) []string {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/task/%d/children", pid, pid))
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+
+	for _, child := range strings.Fields(string(data)) {
+		if comm, err := os.ReadFile(fmt.Sprintf("/proc/%s/comm", child)); err == nil {
+			names = append(names, strings.TrimSpace(string(comm)))
+		}
+	}
+
+	return names
+}
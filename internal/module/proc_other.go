@@ -0,0 +1,10 @@
+//go:build !linux
+
+package module
+
+import "fmt"
+
+// processState describes the process pid; only Linux exposes more
+func processState(pid int) string {
+	return fmt.Sprintf("pid %d", pid)
+}
@@ -0,0 +1,109 @@
+package module
+
+import (
+	"context"
+	"fmt"
+	"os"
+	osExec "os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/inovacc/glix/pkg/exec"
+)
+
+// GetProfileDirectory returns the directory holding the files of an install
+// profile, such as its module cache
+func GetProfileDirectory(name string) string {
+	return filepath.Join(appDir, "profiles", name)
+}
+
+// ProfileModCache returns the module cache of an install profile
+func ProfileModCache(name string) string {
+	return filepath.Join(GetProfileDirectory(name), "modcache")
+}
+
+// SetProfile makes the go commands of the module download into the module
+// cache of the named install profile, adding goflags to GOFLAGS. An empty
+// name uses the shared module cache.
+func (m *Module) SetProfile(name, goflags string) {
+	m.profile = name
+	m.goflags = goflags
+}
+
+// Profile returns the install profile set with SetProfile
+func (m *Module) Profile() string {
+	return m.profile
+}
+
+// goEnv returns the environment of the go commands the module runs, with the
+// module cache of its profile, its module proxy, the private module and
+// offline settings and extra variables
+func (m *Module) goEnv(extra ...string) []string {
+	env := os.Environ()
+
+	if m.profile != "" {
+		env = append(env, "GOMODCACHE="+ProfileModCache(m.profile))
+	}
+
+	// GOFLAGS of the environment still apply
+	goflags := m.goflags
+	if m.offline {
+		goflags = strings.TrimSpace(goflags + " -mod=mod")
+	}
+
+	if goflags != "" {
+		env = append(env, "GOFLAGS="+strings.TrimSpace(os.Getenv("GOFLAGS")+" "+goflags))
+	}
+
+	env = append(env, m.proxyEnv()...)
+	env = append(env, m.privateEnv()...)
+	env = append(env, m.offlineEnv()...)
+
+	return append(env, extra...)
+}
+
+// goCommand returns a go command with the environment of the module
+func (m *Module) goCommand(ctx context.Context, args ...string) *osExec.Cmd {
+	cmd := exec.CommandContext(ctx, m.goBinPath, args...)
+	cmd.Env = m.goEnv()
+
+	return cmd
+}
+
+// ModCacheDir returns the module cache of an install profile, the shared
+// GOMODCACHE for none
+func ModCacheDir(ctx context.Context, goBinPath, profile string) (string, error) {
+	if profile != "" {
+		return ProfileModCache(profile), nil
+	}
+
+	out, err := exec.CommandContext(ctx, goBinPath, "env", "GOMODCACHE").Output()
+	if err != nil {
+		return "", fmt.Errorf("go env GOMODCACHE failed: %w", err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// RemoveModCache deletes a module cache. go makes its files read-only, so
+// it is cleaned with go clean -modcache first.
+func RemoveModCache(ctx context.Context, goBinPath, dir string) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	cmd := exec.CommandContext(ctx, goBinPath, "clean", "-modcache")
+	cmd.Env = append(os.Environ(), "GOMODCACHE="+dir)
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("go clean -modcache failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	return os.RemoveAll(dir)
+}
+
+// ProfileCacheSize returns the size in bytes of the module cache of an
+// install profile
+func ProfileCacheSize(name string) int64 {
+	return dirSize(ProfileModCache(name))
+}
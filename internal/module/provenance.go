@@ -0,0 +1,53 @@
+package module
+
+import (
+	"debug/buildinfo"
+	"fmt"
+	"os"
+
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+// ReadProvenance returns the build information embedded in a Go binary, as
+// 'go version -m' shows it: the toolchain, the VCS revision the source was
+// stamped with, and the build settings, along with the size of the binary
+func ReadProvenance(binPath string) (*pb.BuildProvenanceProto, error) {
+	info, err := buildinfo.ReadFile(binPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read build info of %s: %w", binPath, err)
+	}
+
+	stat, err := os.Stat(binPath)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &pb.BuildProvenanceProto{
+		GoVersion:  info.GoVersion,
+		BinarySize: stat.Size(),
+		Settings:   make(map[string]string, len(info.Settings)),
+	}
+
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs":
+			p.Vcs = s.Value
+		case "vcs.revision":
+			p.VcsRevision = s.Value
+		case "vcs.time":
+			p.VcsTime = s.Value
+		case "vcs.modified":
+			p.VcsModified = s.Value == "true"
+		default:
+			p.Settings[s.Key] = s.Value
+		}
+	}
+
+	return p, nil
+}
+
+// Provenance returns the build information of the installed binary, nil
+// when it could not be read
+func (m *Module) Provenance() *pb.BuildProvenanceProto {
+	return m.provenance
+}
@@ -0,0 +1,42 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestReadProvenance(t *testing.T) {
+	// The test binary is a Go binary like any installed one
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := ReadProvenance(exe)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.GetGoVersion() != runtime.Version() {
+		t.Errorf("GoVersion = %q, want %q", p.GetGoVersion(), runtime.Version())
+	}
+
+	if p.GetSettings()["GOOS"] != runtime.GOOS || p.GetBinarySize() <= 0 {
+		t.Errorf("provenance = %v, want the GOOS setting and the size", p)
+	}
+
+	if _, ok := p.GetSettings()["vcs.revision"]; ok {
+		t.Error("VCS settings are kept among the other settings")
+	}
+
+	script := filepath.Join(t.TempDir(), "tool")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ReadProvenance(script); err == nil {
+		t.Error("ReadProvenance() of a script succeeded")
+	}
+}
@@ -0,0 +1,223 @@
+package module
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/modfile"
+	modpath "golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// DefaultGoProxy is used when GOPROXY is unset
+const DefaultGoProxy = "https://proxy.golang.org"
+
+// errNotOnProxy means the proxy has no module at the queried path
+var errNotOnProxy = errors.New("not found on proxy")
+
+// errPrivate means a module is private, so the proxy is not asked about it
+var errPrivate = errors.New("private module, not looked up on the proxy")
+
+// proxyHTTPClient is shared by lightweight proxy queries
+var proxyHTTPClient = &http.Client{Timeout: 15 * time.Second}
+
+// goProxies returns the HTTP proxies of a GOPROXY list, in order, those of
+// the environment when it is empty
+func goProxies(value string) []string {
+	if value == "" {
+		value = os.Getenv("GOPROXY")
+	}
+
+	if value == "" {
+		value = DefaultGoProxy
+	}
+
+	var proxies []string
+
+	for _, p := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '|' }) {
+		if p == "direct" || p == "off" || p == "" {
+			continue
+		}
+
+		proxies = append(proxies, strings.TrimSuffix(p, "/"))
+	}
+
+	return proxies
+}
+
+// LatestFromProxy queries the module proxy's @latest endpoint for a package
+// path without downloading or resolving anything. Package paths below the
+// module root (e.g. .../cmd/tool) are walked up until a module is found.
+func LatestFromProxy(ctx context.Context, pkgPath string) (string, error) {
+	return LatestFromGoProxy(ctx, "", pkgPath)
+}
+
+// LatestFromGoProxy is LatestFromProxy asking the proxies of a GOPROXY
+// list, such as the one a module was installed through, rather than those
+// of the environment when it is not empty
+func LatestFromGoProxy(ctx context.Context, goproxy, pkgPath string) (string, error) {
+	// Asking would leak the path of the module to a proxy that cannot serve it
+	if IsPrivate(pkgPath) {
+		return "", fmt.Errorf("latest version of %s: %w", pkgPath, errPrivate)
+	}
+
+	proxies := goProxies(goproxy)
+	if len(proxies) == 0 {
+		return "", fmt.Errorf("no HTTP module proxy configured (GOPROXY=%q)", cmp.Or(goproxy, os.Getenv("GOPROXY")))
+	}
+
+	for candidate := pkgPath; strings.Contains(candidate, "/"); candidate = path.Dir(candidate) {
+		for _, proxy := range proxies {
+			version, err := queryProxyLatest(ctx, proxy, candidate)
+			if err == nil {
+				return version, nil
+			}
+
+			// A missing module falls back to the next proxy and then the parent
+			// path; any other failure would repeat for every candidate
+			if !errors.Is(err, errNotOnProxy) {
+				return "", fmt.Errorf("latest version of %s: %w", pkgPath, err)
+			}
+		}
+	}
+
+	return "", fmt.Errorf("latest version of %s: %w", pkgPath, errNotOnProxy)
+}
+
+// queryProxyLatest returns the version the proxy reports as latest or,
+// when the author retracted it, the newest release that is not retracted.
+// The proxy knows nothing of retractions; go reads them from the go.mod of
+// the latest version.
+func queryProxyLatest(ctx context.Context, proxy, modulePath string) (string, error) {
+	escaped, err := modpath.EscapePath(modulePath)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := proxyGet(ctx, proxy, fmt.Sprintf("%s/%s/@latest", proxy, escaped))
+	if err != nil {
+		return "", err
+	}
+
+	var info struct {
+		Version string `json:"Version"`
+	}
+
+	if err := json.Unmarshal(body, &info); err != nil {
+		return "", fmt.Errorf("failed to decode proxy response: %w", err)
+	}
+
+	if info.Version == "" {
+		return "", fmt.Errorf("%s returned no version for %s", proxy, modulePath)
+	}
+
+	escapedVersion, err := modpath.EscapeVersion(info.Version)
+	if err != nil {
+		return "", err
+	}
+
+	// Without a readable go.mod the reported version is the best answer
+	gomod, err := proxyGet(ctx, proxy, fmt.Sprintf("%s/%s/@v/%s.mod", proxy, escaped, escapedVersion))
+	if err != nil {
+		return info.Version, nil
+	}
+
+	retracted := retractions(gomod)
+	if !retracted(info.Version) {
+		return info.Version, nil
+	}
+
+	list, err := proxyGet(ctx, proxy, fmt.Sprintf("%s/%s/@v/list", proxy, escaped))
+	if err != nil {
+		return "", err
+	}
+
+	if version := newestNotRetracted(strings.Fields(string(list)), retracted); version != "" {
+		return version, nil
+	}
+
+	return "", fmt.Errorf("every release of %s is retracted", modulePath)
+}
+
+// proxyGet fetches a proxy URL; a missing module is errNotOnProxy
+func proxyGet(ctx context.Context, proxy, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := proxyHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusGone:
+		return nil, errNotOnProxy
+	default:
+		return nil, fmt.Errorf("%s returned %s", proxy, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// retractions returns whether a version falls in one of the retract
+// directives of a go.mod. A go.mod that does not parse retracts nothing.
+func retractions(gomod []byte) func(version string) bool {
+	f, err := modfile.ParseLax("go.mod", gomod, nil)
+	if err != nil {
+		return func(string) bool { return false }
+	}
+
+	return func(version string) bool {
+		for _, r := range f.Retract {
+			if semver.Compare(r.Low, version) <= 0 && semver.Compare(version, r.High) <= 0 {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// newestNotRetracted returns the newest of versions that is not retracted,
+// preferring releases over pre-releases as go does
+func newestNotRetracted(versions []string, retracted func(string) bool) string {
+	var newest string
+
+	for _, v := range versions {
+		if !semver.IsValid(v) || retracted(v) {
+			continue
+		}
+
+		if newest == "" || preferVersion(v, newest) {
+			newest = v
+		}
+	}
+
+	return newest
+}
+
+// preferVersion reports whether a is a better latest version than b
+func preferVersion(a, b string) bool {
+	aPre, bPre := semver.Prerelease(a) != "", semver.Prerelease(b) != ""
+	if aPre != bPre {
+		return bPre
+	}
+
+	return semver.Compare(a, b) > 0
+}
@@ -0,0 +1,78 @@
+package module
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// serveRetractingProxy serves example.com/tool whose latest version,
+// v1.3.0, retracts itself and v1.2.0
+func serveRetractingProxy(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	files := map[string]string{
+		"/example.com/tool/@latest":       `{"Version":"v1.3.0"}`,
+		"/example.com/tool/@v/list":       "v1.0.0\nv1.1.0\nv1.2.0\nv1.3.0\nv1.4.0-rc.1\n",
+		"/example.com/tool/@v/v1.3.0.mod": "module example.com/tool\n\nretract (\n\tv1.2.0 // Deletes files\n\t[v1.3.0, v1.4.0-rc.1] // Only publishes the retraction\n)\n",
+		"/example.com/clean/@latest":      `{"Version":"v2.0.0"}`,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		_, _ = w.Write([]byte(body))
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestLatestFromProxy_Retracted(t *testing.T) {
+	srv := serveRetractingProxy(t)
+
+	t.Setenv("GOPROXY", srv.URL)
+	t.Setenv("GOPRIVATE", "")
+	t.Setenv("GONOPROXY", "")
+
+	version, err := LatestFromProxy(context.Background(), "example.com/tool/cmd/tool")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if version != "v1.1.0" {
+		t.Errorf("LatestFromProxy(retracted latest) = %q, want v1.1.0", version)
+	}
+
+	// A version without a go.mod on the proxy is taken as reported
+	version, err = LatestFromProxy(context.Background(), "example.com/clean")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if version != "v2.0.0" {
+		t.Errorf("LatestFromProxy(clean) = %q, want v2.0.0", version)
+	}
+}
+
+func TestNewestNotRetracted(t *testing.T) {
+	retracted := retractions([]byte("module example.com/tool\n\nretract [v1.0.0, v1.9.9]\n"))
+
+	if got := newestNotRetracted([]string{"v1.0.0", "v1.5.0", "v2.0.0-beta.1", "v0.9.0"}, retracted); got != "v0.9.0" {
+		t.Errorf("newestNotRetracted = %q, want the v0.9.0 release over the pre-release", got)
+	}
+
+	if got := newestNotRetracted([]string{"v1.0.0", "v2.0.0-beta.1"}, retracted); got != "v2.0.0-beta.1" {
+		t.Errorf("newestNotRetracted = %q, want v2.0.0-beta.1", got)
+	}
+
+	if got := newestNotRetracted([]string{"v1.2.0"}, retracted); got != "" {
+		t.Errorf("newestNotRetracted(all retracted) = %q, want none", got)
+	}
+}
@@ -0,0 +1,210 @@
+package module
+
+import (
+	"debug/buildinfo"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// workDirPrefixes are the names of the work directories commands create in
+// the cache directory, suffixed with -<unix nanoseconds>
+var workDirPrefixes = []string{"install", "update", "monitor", "autoupdate", "bundle", "info", "policy", "readme", "rebuild", "run", "prefetch", "watch", "libwatch", "refresh", "build"}
+
+// NewWorkDir creates a work directory named <prefix>-<unix nanoseconds> in
+// the cache directory. Concurrent callers never share one.
+func NewWorkDir(prefix string) (string, error) {
+	cacheDir, err := GetApplicationCacheDirectory()
+	if err != nil {
+		return "", fmt.Errorf("failed to get cache directory: %w", err)
+	}
+
+	return newWorkDirIn(cacheDir, prefix)
+}
+
+func newWorkDirIn(cacheDir, prefix string) (string, error) {
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	for {
+		dir := filepath.Join(cacheDir, fmt.Sprintf("%s-%d", prefix, time.Now().UnixNano()))
+
+		err := os.Mkdir(dir, 0755)
+		if err == nil {
+			return dir, nil
+		}
+
+		// Another install started in the same clock tick
+		if !os.IsExist(err) {
+			return "", fmt.Errorf("failed to create working directory: %w", err)
+		}
+	}
+}
+
+// Orphan is a file or directory left behind that prune can delete
+type Orphan struct {
+	Path   string
+	Reason string
+	Size   int64
+}
+
+// FindOrphanedBinaries returns binaries in GOBIN built from a package glix
+// has managed (known) that is no longer installed. Binaries of packages glix
+// never managed, e.g. those from a plain go install, are left alone.
+func FindOrphanedBinaries(installed, known map[string]bool) ([]Orphan, error) {
+	return findOrphanedBinaries(GetGoBinDirectory(), installed, known)
+}
+
+func findOrphanedBinaries(gobin string, installed, known map[string]bool) ([]Orphan, error) {
+	entries, err := os.ReadDir(gobin)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read %s: %w", gobin, err)
+	}
+
+	var orphans []Orphan
+
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+
+		path := filepath.Join(gobin, e.Name())
+
+		// The main package path recorded by the go toolchain identifies the
+		// module regardless of what the binary was renamed to
+		info, err := buildinfo.ReadFile(path)
+		if err != nil || !known[info.Path] || installed[info.Path] {
+			continue
+		}
+
+		orphans = append(orphans, Orphan{
+			Path:   path,
+			Reason: fmt.Sprintf("%s is no longer installed", info.Path),
+			Size:   fileSize(path),
+		})
+	}
+
+	return orphans, nil
+}
+
+// FindStaleWorkDirs returns work directories left in the cache by runs that
+// started more than olderThan ago, and the per-process cache directories of
+// other runs once they hold nothing else. Runs clean up after themselves, so
+// these remain only after a crash or an interrupt.
+func FindStaleWorkDirs(olderThan time.Duration) ([]Orphan, error) {
+	return findStaleWorkDirs(filepath.Dir(cacheDir), cacheDir, olderThan, time.Now())
+}
+
+func findStaleWorkDirs(root, current string, olderThan time.Duration, now time.Time) ([]Orphan, error) {
+	processDirs, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read %s: %w", root, err)
+	}
+
+	var orphans []Orphan
+
+	for _, p := range processDirs {
+		dir := filepath.Join(root, p.Name())
+		if !p.IsDir() || dir == filepath.Clean(current) {
+			continue
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		stale := 0
+
+		for _, e := range entries {
+			started, ok := workDirStart(e)
+			if !ok || now.Sub(started) < olderThan {
+				continue
+			}
+
+			path := filepath.Join(dir, e.Name())
+			orphans = append(orphans, Orphan{
+				Path:   path,
+				Reason: fmt.Sprintf("work directory from %s", started.Format("2006-01-02 15:04")),
+				Size:   dirSize(path),
+			})
+
+			stale++
+		}
+
+		if stale == len(entries) {
+			if info, err := p.Info(); err == nil && now.Sub(info.ModTime()) >= olderThan {
+				orphans = append(orphans, Orphan{Path: dir, Reason: "unused cache directory"})
+			}
+		}
+	}
+
+	return orphans, nil
+}
+
+// workDirStart parses the start time from a work directory name
+func workDirStart(e fs.DirEntry) (time.Time, bool) {
+	if !e.IsDir() {
+		return time.Time{}, false
+	}
+
+	prefix, stamp, ok := strings.Cut(e.Name(), "-")
+	if !ok || !slices.Contains(workDirPrefixes, prefix) {
+		return time.Time{}, false
+	}
+
+	nanos, err := strconv.ParseInt(stamp, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(0, nanos), true
+}
+
+// RemoveOrphan deletes an orphaned file or directory
+func RemoveOrphan(o Orphan) error {
+	if err := os.RemoveAll(o.Path); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", o.Path, err)
+	}
+
+	return nil
+}
+
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+
+	return info.Size()
+}
+
+func dirSize(dir string) int64 {
+	var size int64
+
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err == nil && d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+
+		return nil
+	})
+
+	return size
+}
@@ -0,0 +1,161 @@
+package module
+
+import (
+	"debug/buildinfo"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFindOrphanedBinaries(t *testing.T) {
+	// The test binary itself is a Go binary with build info to match on
+	self, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := buildinfo.ReadFile(self)
+	if err != nil {
+		t.Skipf("test binary has no build info: %v", err)
+	}
+
+	data, err := os.ReadFile(self)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gobin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(gobin, "renamed"), data, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(gobin, "script"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	known := map[string]bool{info.Path: true}
+
+	orphans, err := findOrphanedBinaries(gobin, map[string]bool{}, known)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(orphans) != 1 || orphans[0].Path != filepath.Join(gobin, "renamed") {
+		t.Errorf("orphans = %+v, want only the removed module's binary", orphans)
+	}
+
+	if orphans, _ := findOrphanedBinaries(gobin, known, known); len(orphans) != 0 {
+		t.Errorf("installed module reported orphaned: %+v", orphans)
+	}
+
+	if orphans, _ := findOrphanedBinaries(gobin, map[string]bool{}, map[string]bool{}); len(orphans) != 0 {
+		t.Errorf("binary of an unmanaged package reported orphaned: %+v", orphans)
+	}
+}
+
+func TestFindStaleWorkDirs(t *testing.T) {
+	root := t.TempDir()
+	now := time.Now()
+	old := now.Add(-2 * time.Hour)
+
+	mkdir := func(parts ...string) string {
+		dir := filepath.Join(append([]string{root}, parts...)...)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		return dir
+	}
+
+	workDir := func(prefix string, started time.Time) string {
+		return fmt.Sprintf("%s-%d", prefix, started.UnixNano())
+	}
+
+	current := mkdir("current")
+	mkdir("current", workDir("install", old))
+
+	staleInstall := mkdir("crashed", workDir("install", old))
+	staleMonitor := mkdir("crashed", workDir("monitor", old))
+	mkdir("running", workDir("update", now))
+	mkdir("running", workDir("update", old))
+	mkdir("other", "build")
+
+	for _, dir := range []string{"crashed", "running", "other"} {
+		if err := os.Chtimes(filepath.Join(root, dir), old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	orphans, err := findStaleWorkDirs(root, current, time.Hour, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]bool)
+	for _, o := range orphans {
+		got[o.Path] = true
+	}
+
+	want := []string{staleInstall, staleMonitor, filepath.Join(root, "crashed"), filepath.Join(root, "running", workDir("update", old))}
+
+	if len(got) != len(want) {
+		t.Errorf("found %d orphans, want %d: %+v", len(got), len(want), orphans)
+	}
+
+	for _, path := range want {
+		if !got[path] {
+			t.Errorf("expected %s to be stale", path)
+		}
+	}
+}
+
+func TestNewWorkDir_Unique(t *testing.T) {
+	root := t.TempDir()
+
+	dirs := make(chan string, 20)
+
+	var wg sync.WaitGroup
+
+	for range cap(dirs) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			dir, err := newWorkDirIn(root, "install")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			dirs <- dir
+		}()
+	}
+
+	wg.Wait()
+	close(dirs)
+
+	seen := make(map[string]bool)
+
+	for dir := range dirs {
+		if seen[dir] {
+			t.Errorf("work directory %s handed out twice", dir)
+		}
+
+		seen[dir] = true
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, e := range entries {
+		if _, ok := workDirStart(e); !ok {
+			t.Errorf("%s is not recognized as a work directory", e.Name())
+		}
+	}
+}
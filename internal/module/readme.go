@@ -0,0 +1,188 @@
+package module
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	modpath "golang.org/x/mod/module"
+)
+
+// readmeNames are the README file names looked up, case-insensitively, in
+// order of preference
+var readmeNames = []string{"README.md", "README.markdown", "README.txt", "README"}
+
+// Readme is the README of a module version
+type Readme struct {
+	Path    string `json:"path"` // Module or package path that was looked up
+	Version string `json:"version"`
+	File    string `json:"file"` // Slash-separated path of the README in the module
+	Content string `json:"content"`
+}
+
+// readmeCacheRoot returns the directory holding cached READMEs. Versions are
+// immutable, so cached READMEs never expire.
+func readmeCacheRoot() string {
+	return filepath.Join(GetApplicationDirectory(), "readme")
+}
+
+// FetchReadme returns the README closest to a module or package path,
+// optionally suffixed with @version (latest otherwise): the package
+// directory's own README, else that of the nearest parent up to the module
+// root. READMEs are cached per version.
+func (m *Module) FetchReadme(pkgPath string) (*Readme, error) {
+	pkgPath, version := m.splitModuleVersion(m.normalizeModulePath(pkgPath))
+	if version == "latest" {
+		version = ""
+	}
+
+	root := readmeCacheRoot()
+
+	if r, ok := cachedReadme(root, pkgPath, version); ok {
+		return r, nil
+	}
+
+	ctx, cancel := context.WithTimeout(m.ctx, m.getTimeout())
+	defer cancel()
+
+	m.progress("init", "Initializing workspace...")
+
+	if err := m.setupTempModule(ctx); err != nil {
+		return nil, err
+	}
+
+	m.progress("versions", "Resolving module version...")
+
+	result, err := m.fetchModuleVersions(ctx, pkgPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if version == "" {
+		version = result.ListResp.Version
+
+		if r, ok := cachedReadme(root, pkgPath, version); ok {
+			return r, nil
+		}
+	}
+
+	m.RootModule = result.RootModule
+	m.Version = version
+
+	m.progress("download", fmt.Sprintf("Downloading %s@%s...", result.RootModule, version))
+
+	dir, err := m.getModuleSourceDir(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rel := strings.TrimPrefix(strings.TrimPrefix(pkgPath, result.RootModule), "/")
+
+	file, content, err := findReadme(dir, rel)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Readme{Path: pkgPath, Version: version, File: file, Content: content}
+
+	if err := cacheReadme(root, r); err != nil {
+		m.progress("warning", err.Error())
+	}
+
+	return r, nil
+}
+
+// findReadme looks for a README in dir/rel and its parents up to dir
+func findReadme(dir, rel string) (string, string, error) {
+	for {
+		entries, err := os.ReadDir(filepath.Join(dir, filepath.FromSlash(rel)))
+		if err == nil {
+			for _, name := range readmeNames {
+				for _, e := range entries {
+					if e.Type().IsRegular() && strings.EqualFold(e.Name(), name) {
+						data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel), e.Name()))
+						if err != nil {
+							return "", "", fmt.Errorf("failed to read README: %w", err)
+						}
+
+						return path.Join(rel, e.Name()), string(data), nil
+					}
+				}
+			}
+		}
+
+		if rel == "" {
+			return "", "", fmt.Errorf("module has no README")
+		}
+
+		if rel = path.Dir(rel); rel == "." {
+			rel = ""
+		}
+	}
+}
+
+// versionCacheFile returns where the README or captured help of a path
+// version is cached, laid out like the module cache:
+// <root>/<escaped path>/@v/<version>.json
+func versionCacheFile(root, pkgPath, version string) (string, error) {
+	escaped, err := modpath.EscapePath(pkgPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid module path %q: %w", pkgPath, err)
+	}
+
+	escapedVersion, err := modpath.EscapeVersion(version)
+	if err != nil {
+		return "", fmt.Errorf("invalid version %q: %w", version, err)
+	}
+
+	return filepath.Join(root, filepath.FromSlash(escaped), "@v", escapedVersion+".json"), nil
+}
+
+func cachedReadme(root, pkgPath, version string) (*Readme, bool) {
+	if version == "" {
+		return nil, false
+	}
+
+	file, err := versionCacheFile(root, pkgPath, version)
+	if err != nil {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, false
+	}
+
+	var r Readme
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, false
+	}
+
+	return &r, true
+}
+
+func cacheReadme(root string, r *Readme) error {
+	file, err := versionCacheFile(root, r.Path, r.Version)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to encode README: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return fmt.Errorf("failed to create README cache directory: %w", err)
+	}
+
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		return fmt.Errorf("failed to cache README: %w", err)
+	}
+
+	return nil
+}
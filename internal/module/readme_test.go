@@ -0,0 +1,72 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindReadme(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, content := range map[string]string{
+		"Readme.md":         "root",
+		"cmd/tool/main.go":  "package main",
+		"cmd/other/README":  "other",
+		"cmd/other/main.go": "package main",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		rel, file, content string
+	}{
+		{"", "Readme.md", "root"},
+		{"cmd/tool", "Readme.md", "root"},
+		{"cmd/other", "cmd/other/README", "other"},
+	}
+
+	for _, tt := range tests {
+		file, content, err := findReadme(dir, tt.rel)
+		if err != nil {
+			t.Fatalf("findReadme(%q) failed: %v", tt.rel, err)
+		}
+
+		if file != tt.file || content != tt.content {
+			t.Errorf("findReadme(%q) = %s %q, want %s %q", tt.rel, file, content, tt.file, tt.content)
+		}
+	}
+
+	if _, _, err := findReadme(t.TempDir(), "cmd/tool"); err == nil {
+		t.Error("expected an error for a module without README")
+	}
+}
+
+func TestReadmeCache(t *testing.T) {
+	root := t.TempDir()
+
+	if _, ok := cachedReadme(root, "github.com/Example/tool", "v1.0.0"); ok {
+		t.Fatal("expected an empty cache")
+	}
+
+	r := &Readme{Path: "github.com/Example/tool", Version: "v1.0.0", File: "README.md", Content: "# Tool"}
+	if err := cacheReadme(root, r); err != nil {
+		t.Fatalf("cacheReadme failed: %v", err)
+	}
+
+	cached, ok := cachedReadme(root, r.Path, r.Version)
+	if !ok || *cached != *r {
+		t.Errorf("cachedReadme = %+v, want %+v", cached, r)
+	}
+
+	if _, ok := cachedReadme(root, r.Path, ""); ok {
+		t.Error("expected no cache hit without a version")
+	}
+}
@@ -0,0 +1,217 @@
+package module
+
+import (
+	"context"
+	"debug/buildinfo"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
+
+	"github.com/inovacc/glix/internal/manifest"
+)
+
+// rebuildFlags are the build settings passed back to go install as flags
+var rebuildFlags = []string{"-asmflags", "-buildmode", "-gcflags", "-ldflags", "-tags"}
+
+// rebuildBoolFlags are the boolean build settings passed back as flags
+var rebuildBoolFlags = []string{"-asan", "-msan", "-race", "-trimpath"}
+
+// RebuildResult compares an installed binary with one rebuilt from source
+type RebuildResult struct {
+	Package       string // Main package path
+	Version       string
+	GoVersion     string // Toolchain recorded in the installed binary
+	BinaryPath    string // Installed binary
+	RebuiltPath   string
+	InstalledHash string
+	RebuiltHash   string
+	Reproducible  bool     // The binaries are identical
+	Differences   []string // Build info that differs, when they are not
+}
+
+// Rebuild builds the package of an installed Go binary again, at the
+// version and with the toolchain, flags, and build environment recorded in
+// it, into dir. Go builds are reproducible, so a binary that was built from
+// the published source comes out identical.
+func (m *Module) Rebuild(ctx context.Context, binPath, dir string, handler OutputHandler) (*RebuildResult, error) {
+	info, err := buildinfo.ReadFile(binPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read build info of %s: %w", binPath, err)
+	}
+
+	args, env, err := rebuildCommand(info)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RebuildResult{
+		Package:    info.Path,
+		Version:    info.Main.Version,
+		GoVersion:  info.GoVersion,
+		BinaryPath: binPath,
+	}
+
+	m.progress("rebuild", fmt.Sprintf("Building %s@%s with %s...", info.Path, info.Main.Version, info.GoVersion))
+
+	cmd := m.goCommand(ctx, args...)
+	cmd.Dir = m.workingDir
+	cmd.Env = m.goEnv(append(env, "GOBIN="+dir)...)
+
+	if err := runWithStreaming(cmd, handler, m.watchdog()); err != nil {
+		return nil, fmt.Errorf("go install failed: %w", err)
+	}
+
+	result.RebuiltPath = filepath.Join(dir, BinaryName(info.Path))
+	if goos(info) == "windows" {
+		result.RebuiltPath += ".exe"
+	}
+
+	m.progress("verify", "Comparing binaries...")
+
+	if result.InstalledHash, err = manifest.HashFile(binPath); err != nil {
+		return nil, fmt.Errorf("failed to hash installed binary: %w", err)
+	}
+
+	if result.RebuiltHash, err = manifest.HashFile(result.RebuiltPath); err != nil {
+		return nil, fmt.Errorf("failed to hash rebuilt binary: %w", err)
+	}
+
+	result.Reproducible = result.InstalledHash == result.RebuiltHash
+
+	if !result.Reproducible {
+		rebuilt, err := buildinfo.ReadFile(result.RebuiltPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read build info of rebuilt binary: %w", err)
+		}
+
+		result.Differences = buildInfoDifferences(info, rebuilt)
+	}
+
+	return result, nil
+}
+
+// rebuildCommand returns the go arguments and environment that reproduce
+// the build recorded in info
+func rebuildCommand(info *debug.BuildInfo) ([]string, []string, error) {
+	version := info.Main.Version
+	if version == "" || version == "(devel)" {
+		return nil, nil, fmt.Errorf("%s was not built from a published module version; local, GoReleaser, make and mage builds cannot be rebuilt", info.Path)
+	}
+
+	settings := make(map[string]string, len(info.Settings))
+	for _, s := range info.Settings {
+		settings[s.Key] = s.Value
+	}
+
+	if c := settings["-compiler"]; c != "" && c != "gc" {
+		return nil, nil, fmt.Errorf("%s was built with the %s compiler; only gc builds can be rebuilt", info.Path, c)
+	}
+
+	args := []string{"install"}
+
+	for _, flag := range rebuildFlags {
+		if v, ok := settings[flag]; ok && !(flag == "-buildmode" && v == "exe") {
+			args = append(args, flag+"="+v)
+		}
+	}
+
+	for _, flag := range rebuildBoolFlags {
+		if settings[flag] == "true" {
+			args = append(args, flag)
+		}
+	}
+
+	args = append(args, info.Path+"@"+version)
+
+	// The exact toolchain, with no GOFLAGS of this environment on top of
+	// the recorded flags
+	toolchain, _, _ := strings.Cut(info.GoVersion, " ")
+	env := []string{"GOTOOLCHAIN=" + toolchain, "GOFLAGS="}
+
+	// Environment settings are recorded under their variable names, e.g.
+	// CGO_ENABLED, GOARCH, GOAMD64
+	for _, s := range info.Settings {
+		if s.Key == strings.ToUpper(s.Key) && (strings.HasPrefix(s.Key, "GO") || strings.HasPrefix(s.Key, "CGO_")) {
+			env = append(env, s.Key+"="+s.Value)
+		}
+	}
+
+	return args, env, nil
+}
+
+// goos returns the target OS recorded in info
+func goos(info *debug.BuildInfo) string {
+	for _, s := range info.Settings {
+		if s.Key == "GOOS" {
+			return s.Value
+		}
+	}
+
+	return runtime.GOOS
+}
+
+// buildInfoDifferences describes how the build info of two binaries differs
+func buildInfoDifferences(installed, rebuilt *debug.BuildInfo) []string {
+	var diffs []string
+
+	differ := func(what, a, b string) {
+		if a != b {
+			diffs = append(diffs, fmt.Sprintf("%s: installed %q, rebuilt %q", what, a, b))
+		}
+	}
+
+	differ("go version", installed.GoVersion, rebuilt.GoVersion)
+	differ("module sum", installed.Main.Sum, rebuilt.Main.Sum)
+
+	a, b := moduleSums(installed.Deps), moduleSums(rebuilt.Deps)
+	for path, sum := range a {
+		differ("dependency "+path, sum, b[path])
+	}
+
+	for path, sum := range b {
+		if _, ok := a[path]; !ok {
+			differ("dependency "+path, "", sum)
+		}
+	}
+
+	a, b = buildSettings(installed.Settings), buildSettings(rebuilt.Settings)
+	for key, v := range a {
+		differ("setting "+key, v, b[key])
+	}
+
+	for key, v := range b {
+		if _, ok := a[key]; !ok {
+			differ("setting "+key, "", v)
+		}
+	}
+
+	slices.Sort(diffs)
+
+	return diffs
+}
+
+func moduleSums(deps []*debug.Module) map[string]string {
+	sums := make(map[string]string, len(deps))
+
+	for _, d := range deps {
+		if d.Replace != nil {
+			d = d.Replace
+		}
+
+		sums[d.Path] = d.Version + " " + d.Sum
+	}
+
+	return sums
+}
+
+func buildSettings(settings []debug.BuildSetting) map[string]string {
+	m := make(map[string]string, len(settings))
+	for _, s := range settings {
+		m[s.Key] = s.Value
+	}
+
+	return m
+}
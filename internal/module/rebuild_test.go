@@ -0,0 +1,78 @@
+package module
+
+import (
+	"runtime/debug"
+	"slices"
+	"testing"
+)
+
+func TestRebuildCommand(t *testing.T) {
+	info := &debug.BuildInfo{
+		GoVersion: "go1.24.2 X:nocoverageredesign",
+		Path:      "example.com/tool/cmd/tool",
+		Main:      debug.Module{Path: "example.com/tool", Version: "v1.2.3"},
+		Settings: []debug.BuildSetting{
+			{Key: "-buildmode", Value: "exe"},
+			{Key: "-compiler", Value: "gc"},
+			{Key: "-ldflags", Value: "-s -w"},
+			{Key: "-tags", Value: "netgo"},
+			{Key: "-trimpath", Value: "true"},
+			{Key: "DefaultGODEBUG", Value: "panicnil=1"},
+			{Key: "CGO_ENABLED", Value: "0"},
+			{Key: "GOARCH", Value: "amd64"},
+			{Key: "GOOS", Value: "linux"},
+			{Key: "GOAMD64", Value: "v3"},
+			{Key: "vcs", Value: "git"},
+		},
+	}
+
+	args, env, err := rebuildCommand(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantArgs := []string{"install", "-ldflags=-s -w", "-tags=netgo", "-trimpath", "example.com/tool/cmd/tool@v1.2.3"}
+	if !slices.Equal(args, wantArgs) {
+		t.Errorf("args = %q, want %q", args, wantArgs)
+	}
+
+	wantEnv := []string{"GOTOOLCHAIN=go1.24.2", "GOFLAGS=", "CGO_ENABLED=0", "GOARCH=amd64", "GOOS=linux", "GOAMD64=v3"}
+	if !slices.Equal(env, wantEnv) {
+		t.Errorf("env = %q, want %q", env, wantEnv)
+	}
+
+	info.Main.Version = "(devel)"
+	if _, _, err := rebuildCommand(info); err == nil {
+		t.Error("expected an error for a binary without a module version")
+	}
+}
+
+func TestBuildInfoDifferences(t *testing.T) {
+	installed := &debug.BuildInfo{
+		GoVersion: "go1.24.2",
+		Main:      debug.Module{Sum: "h1:main"},
+		Deps: []*debug.Module{
+			{Path: "example.com/a", Version: "v1.0.0", Sum: "h1:a"},
+			{Path: "example.com/b", Version: "v1.0.0", Sum: "h1:b"},
+		},
+		Settings: []debug.BuildSetting{{Key: "-trimpath", Value: "true"}},
+	}
+
+	if diffs := buildInfoDifferences(installed, installed); len(diffs) != 0 {
+		t.Errorf("identical build info differs: %q", diffs)
+	}
+
+	rebuilt := &debug.BuildInfo{
+		GoVersion: "go1.24.2",
+		Main:      debug.Module{Sum: "h1:main"},
+		Deps: []*debug.Module{
+			{Path: "example.com/a", Version: "v1.0.0", Sum: "h1:a"},
+			{Path: "example.com/b", Version: "v1.1.0", Sum: "h1:b2"},
+		},
+	}
+
+	diffs := buildInfoDifferences(installed, rebuilt)
+	if len(diffs) != 2 {
+		t.Errorf("differences = %q, want dependency b and -trimpath", diffs)
+	}
+}
@@ -0,0 +1,227 @@
+package module
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	modpath "golang.org/x/mod/module"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxMajorProbes bounds how many major versions beyond the installed one a
+// refresh looks for
+const maxMajorProbes = 5
+
+// RefreshResult is what a refresh found for an installed module
+type RefreshResult struct {
+	Record     *pb.ModuleProto // Refreshed record, still at the installed version
+	Changes    []string        // Differences from the stored record
+	MovedFrom  string          // Package path the record was stored under, when the package moved
+	NewerMajor string          // Package path@version in the newest major version, when newer
+	Warnings   []string
+}
+
+// Refresh resolves the metadata of an installed module again without
+// building it: the available versions and root module, the dependencies,
+// checksums and license of the installed version, and the package providing
+// the binary at the latest version, which moves when a repository renames
+// its cmd directory. A newer major version is reported but not recorded, as
+// it is a module of its own.
+func (m *Module) Refresh(record *pb.ModuleProto) (*RefreshResult, error) {
+	ctx, cancel := context.WithTimeout(m.ctx, m.getTimeout())
+	defer cancel()
+
+	name := record.GetName()
+
+	m.Name = name
+	m.Version = record.GetVersion()
+	m.refreshing = true
+
+	m.progress("init", "Initializing workspace...")
+
+	if err := m.setupTempModule(ctx); err != nil {
+		return nil, err
+	}
+
+	m.progress("versions", "Fetching available versions...")
+
+	result, err := m.fetchModuleVersions(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	m.RootModule = result.RootModule
+	m.Versions = result.ListResp.Versions
+	latest := result.ListResp.Version
+
+	m.progress("download", fmt.Sprintf("Downloading %s@%s...", name, m.Version))
+
+	if err := m.getModule(ctx, fmt.Sprintf("%s@%s", name, m.Version)); err != nil {
+		return nil, fmt.Errorf("failed to download %s@%s: %w", name, m.Version, err)
+	}
+
+	m.progress("deps", "Resolving dependencies...")
+
+	if m.Dependencies, err = m.extractDependencies(ctx, name); err != nil {
+		return nil, err
+	}
+
+	m.progress("download", "Recording module checksums...")
+
+	if _, err := m.getModuleSourceDir(ctx); err != nil {
+		return nil, err
+	}
+
+	m.detectLicenses(ctx, m.workingDir)
+
+	updated := proto.Clone(record).(*pb.ModuleProto)
+	updated.Versions = m.Versions
+	updated.RootModule = m.RootModule
+	updated.Dependencies = convertDependenciesToProto(m.Dependencies)
+	updated.Sum = m.Sum
+	updated.GoModSum = m.GoModSum
+	updated.Hash = m.Hash
+	updated.License = m.License
+
+	res := &RefreshResult{Record: updated}
+
+	if latest != "" && latest != m.Version {
+		m.progress("check", fmt.Sprintf("Checking %s at %s...", name, latest))
+
+		if err := m.getModule(ctx, fmt.Sprintf("%s@%s", m.RootModule, latest)); err == nil && !m.hasPackageMain(ctx, name) {
+			if moved := m.movedPackage(ctx, name); moved != "" {
+				updated.Name = moved
+				res.MovedFrom = name
+			} else {
+				res.Warnings = append(res.Warnings, fmt.Sprintf("%s has no main package at %s and no CLI replacing it was found", name, latest))
+			}
+		}
+	}
+
+	if major, version := m.newerMajor(ctx, m.RootModule); major != "" {
+		res.NewerMajor = fmt.Sprintf("%s%s@%s", major, strings.TrimPrefix(updated.GetName(), m.RootModule), version)
+	}
+
+	res.Changes = recordChanges(record, updated)
+
+	return res, nil
+}
+
+// movedPackage returns the package that replaced name at the latest version
+// of its root module: the discovered CLI building a binary of the same name,
+// or the only CLI discovered
+func (m *Module) movedPackage(ctx context.Context, name string) string {
+	m.progress("discover", fmt.Sprintf("%s no longer builds a binary, searching for CLIs...", name))
+
+	discovered, found, err := m.DiscoverCLIPaths(ctx, m.RootModule)
+	if err != nil || !found {
+		return ""
+	}
+
+	for _, path := range discovered {
+		if BinaryName(path) == BinaryName(name) {
+			return path
+		}
+	}
+
+	if len(discovered) == 1 {
+		return discovered[0]
+	}
+
+	return ""
+}
+
+// newerMajor returns the newest major version of a module above its own,
+// such as example.com/tool/v3, with its latest version
+func (m *Module) newerMajor(ctx context.Context, root string) (string, string) {
+	prefix, pathMajor, ok := modpath.SplitPathVersion(root)
+	if !ok || strings.HasPrefix(pathMajor, ".") {
+		return "", "" // gopkg.in paths
+	}
+
+	major := 1
+	if pathMajor != "" {
+		n, err := strconv.Atoi(strings.TrimPrefix(pathMajor, "/v"))
+		if err != nil {
+			return "", ""
+		}
+
+		major = n
+	}
+
+	var newest, version string
+
+	for next := major + 1; next <= major+maxMajorProbes; next++ {
+		path := fmt.Sprintf("%s/v%d", prefix, next)
+
+		lr, err := m.tryFetchVersions(ctx, path)
+		if err != nil {
+			break
+		}
+
+		newest, version = path, lr.Version
+	}
+
+	return newest, version
+}
+
+// recordChanges describes what a refresh changed in the record of a module
+func recordChanges(old, updated *pb.ModuleProto) []string {
+	var changes []string
+
+	change := func(what, from, to string) {
+		if from != to {
+			if from == "" {
+				from = "(none)"
+			}
+
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", what, from, to))
+		}
+	}
+
+	change("package", old.GetName(), updated.GetName())
+	change("root module", old.GetRootModule(), updated.GetRootModule())
+	change("license", old.GetLicense(), updated.GetLicense())
+	change("sum", old.GetSum(), updated.GetSum())
+	change("go.mod sum", old.GetGoModSum(), updated.GetGoModSum())
+
+	if !slices.Equal(old.GetVersions(), updated.GetVersions()) {
+		latest := ""
+		if versions := updated.GetVersions(); len(versions) > 0 {
+			latest = versions[0]
+		}
+
+		changes = append(changes, fmt.Sprintf("versions: %d -> %d, newest %s", len(old.GetVersions()), len(updated.GetVersions()), latest))
+	}
+
+	dependencies := func(deps []*pb.DependencyProto) []string {
+		var list []string
+		for _, d := range deps {
+			list = append(list, d.GetName()+"@"+d.GetVersion()+" "+d.GetLicense())
+		}
+
+		return list
+	}
+
+	before, after := dependencies(old.GetDependencies()), dependencies(updated.GetDependencies())
+
+	switch {
+	case len(before) != len(after):
+		changes = append(changes, fmt.Sprintf("dependencies: %d -> %d", len(before), len(after)))
+	case !slices.Equal(before, after):
+		n := 0
+		for _, d := range after {
+			if !slices.Contains(before, d) {
+				n++
+			}
+		}
+
+		changes = append(changes, fmt.Sprintf("dependencies: %d of %d changed", n, len(after)))
+	}
+
+	return changes
+}
@@ -0,0 +1,40 @@
+package module
+
+import (
+	"slices"
+	"testing"
+
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+func TestRecordChanges(t *testing.T) {
+	old := &pb.ModuleProto{
+		Name:         "example.com/tool",
+		Versions:     []string{"v1.1.0", "v1.0.0"},
+		Dependencies: []*pb.DependencyProto{{Name: "example.com/dep", Version: "v0.1.0"}},
+		License:      "MIT",
+	}
+
+	if got := recordChanges(old, old); len(got) != 0 {
+		t.Errorf("recordChanges of an unchanged record = %v", got)
+	}
+
+	updated := &pb.ModuleProto{
+		Name:         "example.com/tool/cmd/tool",
+		RootModule:   "example.com/tool",
+		Versions:     []string{"v1.2.0", "v1.1.0", "v1.0.0"},
+		Dependencies: []*pb.DependencyProto{{Name: "example.com/dep", Version: "v0.1.0", License: "BSD-3-Clause"}},
+		License:      "MIT",
+	}
+
+	want := []string{
+		"package: example.com/tool -> example.com/tool/cmd/tool",
+		"root module: (none) -> example.com/tool",
+		"versions: 2 -> 3, newest v1.2.0",
+		"dependencies: 1 of 1 changed",
+	}
+
+	if got := recordChanges(old, updated); !slices.Equal(got, want) {
+		t.Errorf("recordChanges = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,542 @@
+package module
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"cmp"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+)
+
+// DefaultGitHubAPI is the GitHub API prebuilt binaries are looked up in
+// unless GLIX_GITHUB_API names another, e.g. of GitHub Enterprise
+const DefaultGitHubAPI = "https://api.github.com"
+
+// maxReleaseBinary bounds the size of an extracted binary, so a malformed
+// archive cannot fill the disk
+const maxReleaseBinary = 1 << 30
+
+// releaseHTTPClient downloads release metadata and assets
+var releaseHTTPClient = &http.Client{Timeout: 5 * time.Minute}
+
+// errNoRelease means the version has no GitHub release
+var errNoRelease = errors.New("no GitHub release")
+
+// releaseAsset is a file attached to a GitHub release
+type releaseAsset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// SetPreferBinary makes installs use the prebuilt binary of a GitHub release
+// when there is one for the target platform, building from source otherwise
+func (m *Module) SetPreferBinary(prefer bool) {
+	m.preferBinary = prefer
+}
+
+// PreferBinary reports whether installs use prebuilt release binaries
+func (m *Module) PreferBinary() bool {
+	return m.preferBinary
+}
+
+func githubAPI() string {
+	return strings.TrimSuffix(envOr("GLIX_GITHUB_API", DefaultGitHubAPI), "/")
+}
+
+// githubRelease returns the repository and release tag of a module version
+// hosted on GitHub. Modules in a subdirectory of their repository are tagged
+// with the directory as prefix, as the go command expects.
+func githubRelease(rootModule, version string) (string, string, bool) {
+	parts := strings.Split(rootModule, "/")
+	if len(parts) < 3 || parts[0] != "github.com" {
+		return "", "", false
+	}
+
+	dir := parts[3:]
+	if n := len(dir); n > 0 && semver.Major(version) == dir[n-1] {
+		dir = dir[:n-1]
+	}
+
+	tag := version
+	if len(dir) > 0 {
+		tag = strings.Join(dir, "/") + "/" + version
+	}
+
+	return parts[1] + "/" + parts[2], tag, true
+}
+
+// installReleaseBinary installs the prebuilt binary of the module version
+// from its GitHub release, verified against the checksum file of the release
+func (m *Module) installReleaseBinary(ctx context.Context) error {
+	if !m.buildFlags.IsZero() {
+		return errors.New("prebuilt binaries are not built with the chosen build flags")
+	}
+
+	root := cmp.Or(m.RootModule, m.Name)
+
+	repo, tag, ok := githubRelease(root, m.Version)
+	if !ok {
+		return fmt.Errorf("%s is not hosted on GitHub", root)
+	}
+
+	// Binaries of a release belong to its module, not to other packages in it
+	if m.Name != root && !strings.HasPrefix(m.Name, root+"/cmd/") {
+		return fmt.Errorf("%s is not the main package of %s", m.Name, root)
+	}
+
+	assets, err := fetchReleaseAssets(ctx, repo, tag)
+	if err != nil {
+		return err
+	}
+
+	binary := BinaryName(m.Name)
+
+	asset, ok := pickReleaseAsset(assets, m.goos(), m.goarch(), binary)
+	if !ok {
+		return fmt.Errorf("release %s of %s has no asset for %s/%s", tag, repo, m.goos(), m.goarch())
+	}
+
+	sums, ok := checksumAsset(assets, asset.Name)
+	if !ok {
+		return fmt.Errorf("release %s of %s has no checksum file", tag, repo)
+	}
+
+	m.progress("binary", fmt.Sprintf("Downloading %s from release %s of %s...", asset.Name, tag, repo))
+
+	dir, err := os.MkdirTemp(m.workingDir, "release-")
+	if err != nil {
+		return fmt.Errorf("failed to create download directory: %w", err)
+	}
+
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	sumsPath := filepath.Join(dir, "checksums")
+	if err := download(ctx, sums.URL, sumsPath); err != nil {
+		return err
+	}
+
+	want, err := checksumOf(sumsPath, asset.Name)
+	if err != nil {
+		return err
+	}
+
+	archive := filepath.Join(dir, asset.Name)
+	if err := download(ctx, asset.URL, archive); err != nil {
+		return err
+	}
+
+	got, err := fileSHA256(archive)
+	if err != nil {
+		return err
+	}
+
+	if got != want {
+		return fmt.Errorf("checksum mismatch for %s: got %s, want %s", asset.Name, got, want)
+	}
+
+	m.progress("binary", fmt.Sprintf("Verified %s against %s", asset.Name, sums.Name))
+
+	extracted := filepath.Join(dir, "binary")
+	if err := extractReleaseBinary(archive, binary, m.goos() == "windows", extracted); err != nil {
+		return err
+	}
+
+	dest := m.builtBinaryPath(m.buildDirectory())
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(dest), err)
+	}
+
+	// Replaced by rename, so cached copies linked to the old binary stay intact
+	if err := RestoreBinary(extracted, dest); err != nil {
+		return err
+	}
+
+	m.progress("binary", fmt.Sprintf("Installed prebuilt binary %s", dest))
+
+	return nil
+}
+
+// fetchReleaseAssets lists the assets of the release tagged tag
+func fetchReleaseAssets(ctx context.Context, repo, tag string) ([]releaseAsset, error) {
+	url := fmt.Sprintf("%s/repos/%s/releases/tags/%s", githubAPI(), repo, tag)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := releaseHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up release %s of %s: %w", tag, repo, err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w %s for %s", errNoRelease, tag, repo)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("failed to look up release %s of %s: %s", tag, repo, resp.Status)
+	}
+
+	var release struct {
+		Assets []releaseAsset `json:"assets"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("failed to parse release %s of %s: %w", tag, repo, err)
+	}
+
+	return release.Assets, nil
+}
+
+// Names platforms go by in release assets
+var (
+	osAliases = map[string][]string{
+		"darwin":  {"darwin", "macos", "osx", "mac"},
+		"windows": {"windows", "win"},
+	}
+	archAliases = map[string][]string{
+		"amd64": {"amd64", "x86_64", "x64", "64bit"},
+		"386":   {"386", "i386", "i686", "32bit"},
+		"arm64": {"arm64", "aarch64"},
+		"arm":   {"armv7", "armv6", "arm"},
+	}
+)
+
+// hasToken reports whether name contains one of the words, separated from
+// the rest of the name by -, _, . or its ends
+func hasToken(name string, words []string) bool {
+	for _, w := range words {
+		if regexp.MustCompile(`(^|[-_. ])` + regexp.QuoteMeta(w) + `($|[-_. ])`).MatchString(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func aliases(m map[string][]string, key string) []string {
+	if a, ok := m[key]; ok {
+		return a
+	}
+
+	return []string{key}
+}
+
+// releaseArchive returns the kind of a release asset: an archive format, a
+// bare binary, or "" for assets that hold no binary such as checksums,
+// signatures and packages
+func releaseArchive(name string) string {
+	lower := strings.ToLower(name)
+
+	switch {
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return "tar.gz"
+	case strings.HasSuffix(lower, ".zip"):
+		return "zip"
+	case strings.HasSuffix(lower, ".exe"):
+		return "binary"
+	case strings.Contains(lower, "checksum") || strings.Contains(lower, "sha256"):
+		return ""
+	}
+
+	switch path.Ext(lower) {
+	case ".txt", ".md", ".sig", ".asc", ".pem", ".sbom", ".json", ".jsonl", ".yaml", ".yml",
+		".deb", ".rpm", ".apk", ".msi", ".pkg", ".dmg", ".tar", ".gz", ".xz", ".bz2", ".zst", ".7z":
+		return ""
+	default:
+		// Including dots of versions, as in tool_1.2.0_linux_amd64
+		return "binary"
+	}
+}
+
+// pickReleaseAsset picks the asset holding the binary for goos/goarch,
+// preferring those named after the binary and archives over bare binaries
+func pickReleaseAsset(assets []releaseAsset, goos, goarch, binary string) (releaseAsset, bool) {
+	archs := aliases(archAliases, goarch)
+
+	// Universal macOS binaries run on either architecture
+	if goos == "darwin" {
+		archs = append(archs, "all", "universal")
+	}
+
+	var candidates []releaseAsset
+
+	for _, a := range assets {
+		lower := strings.ToLower(a.Name)
+		if releaseArchive(a.Name) != "" && hasToken(lower, aliases(osAliases, goos)) && hasToken(lower, archs) {
+			candidates = append(candidates, a)
+		}
+	}
+
+	score := func(a releaseAsset) int {
+		s := 0
+		if strings.HasPrefix(strings.ToLower(a.Name), strings.ToLower(binary)) {
+			s += 2
+		}
+
+		if releaseArchive(a.Name) != "binary" {
+			s++
+		}
+
+		return s
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return score(candidates[i]) > score(candidates[j])
+	})
+
+	if len(candidates) == 0 {
+		return releaseAsset{}, false
+	}
+
+	return candidates[0], true
+}
+
+// checksumAsset returns the checksum file of a release: <asset>.sha256 or a
+// file listing the checksums of all assets, as GoReleaser publishes
+func checksumAsset(assets []releaseAsset, asset string) (releaseAsset, bool) {
+	for _, a := range assets {
+		if a.Name == asset+".sha256" {
+			return a, true
+		}
+	}
+
+	for _, a := range assets {
+		lower := strings.ToLower(a.Name)
+		if strings.HasSuffix(lower, "checksums.txt") || lower == "sha256sums" || lower == "sha256sums.txt" {
+			return a, true
+		}
+	}
+
+	return releaseAsset{}, false
+}
+
+// checksumOf returns the SHA-256 listed for asset in a checksum file, lines
+// of "<hex>  <name>" as sha256sum writes them. A file of a single checksum
+// without name applies to the asset it was published for.
+func checksumOf(sumsPath, asset string) (string, error) {
+	file, err := os.Open(sumsPath)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var lines [][]string
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) > 0 {
+			lines = append(lines, fields)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read checksums: %w", err)
+	}
+
+	for _, fields := range lines {
+		if len(fields) >= 2 && strings.TrimPrefix(fields[1], "*") == asset {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+
+	if len(lines) == 1 && len(lines[0]) == 1 {
+		return strings.ToLower(lines[0][0]), nil
+	}
+
+	return "", fmt.Errorf("no checksum listed for %s", asset)
+}
+
+// download saves the file at url to dest
+func download(ctx context.Context, url, dest string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := releaseHTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", url, err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+
+	file, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to download %s: %w", url, err)
+	}
+
+	return file.Close()
+}
+
+func fileSHA256(p string) (string, error) {
+	file, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// extractReleaseBinary places the binary named binary from a release asset,
+// an archive or the bare binary, at dest
+func extractReleaseBinary(asset, binary string, windows bool, dest string) error {
+	want := binary
+	if windows {
+		want += ".exe"
+	}
+
+	var err error
+
+	switch releaseArchive(filepath.Base(asset)) {
+	case "tar.gz":
+		err = extractFromTarGz(asset, want, dest)
+	case "zip":
+		err = extractFromZip(asset, want, dest)
+	default:
+		err = copyFile(asset, dest)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return os.Chmod(dest, 0755)
+}
+
+func extractFromTarGz(archive, want, dest string) error {
+	file, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filepath.Base(archive), err)
+	}
+
+	tr := tar.NewReader(gz)
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("%s holds no %s", filepath.Base(archive), want)
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", filepath.Base(archive), err)
+		}
+
+		if hdr.Typeflag == tar.TypeReg && path.Base(hdr.Name) == want {
+			return writeBinary(tr, dest)
+		}
+	}
+}
+
+func extractFromZip(archive, want, dest string) error {
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filepath.Base(archive), err)
+	}
+
+	defer func() {
+		_ = zr.Close()
+	}()
+
+	for _, f := range zr.File {
+		if f.FileInfo().Mode().IsRegular() && path.Base(f.Name) == want {
+			r, err := f.Open()
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", filepath.Base(archive), err)
+			}
+
+			defer func() {
+				_ = r.Close()
+			}()
+
+			return writeBinary(r, dest)
+		}
+	}
+
+	return fmt.Errorf("%s holds no %s", filepath.Base(archive), want)
+}
+
+// writeBinary writes an extracted binary to dest, refusing ones beyond
+// maxReleaseBinary
+func writeBinary(r io.Reader, dest string) error {
+	file, err := os.OpenFile(dest, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0755)
+	if err != nil {
+		return err
+	}
+
+	n, err := io.Copy(file, io.LimitReader(r, maxReleaseBinary+1))
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to extract %s: %w", filepath.Base(dest), err)
+	}
+
+	if n > maxReleaseBinary {
+		_ = file.Close()
+		_ = os.Remove(dest)
+
+		return fmt.Errorf("%s is larger than %d bytes", filepath.Base(dest), maxReleaseBinary)
+	}
+
+	return file.Close()
+}
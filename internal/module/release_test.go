@@ -0,0 +1,205 @@
+package module
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGithubRelease(t *testing.T) {
+	tests := []struct {
+		root, version string
+		repo, tag     string
+		ok            bool
+	}{
+		{"github.com/user/tool", "v1.2.0", "user/tool", "v1.2.0", true},
+		{"github.com/user/tool/v2", "v2.1.0", "user/tool", "v2.1.0", true},
+		{"github.com/user/mono/tools/lint", "v0.3.0", "user/mono", "tools/lint/v0.3.0", true},
+		{"github.com/user/mono/tools/lint/v3", "v3.0.0", "user/mono", "tools/lint/v3.0.0", true},
+		{"gitlab.com/user/tool", "v1.0.0", "", "", false},
+		{"github.com/user", "v1.0.0", "", "", false},
+	}
+
+	for _, tt := range tests {
+		repo, tag, ok := githubRelease(tt.root, tt.version)
+		if repo != tt.repo || tag != tt.tag || ok != tt.ok {
+			t.Errorf("githubRelease(%q, %q) = %q, %q, %v; want %q, %q, %v", tt.root, tt.version, repo, tag, ok, tt.repo, tt.tag, tt.ok)
+		}
+	}
+}
+
+func TestPickReleaseAsset(t *testing.T) {
+	var assets []releaseAsset
+	for _, name := range []string{
+		"checksums.txt",
+		"tool_1.2.0_Linux_x86_64.tar.gz",
+		"tool_1.2.0_Linux_x86_64.tar.gz.sig",
+		"tool_1.2.0_linux_amd64.deb",
+		"tool_1.2.0_Linux_arm64.tar.gz",
+		"tool_1.2.0_Linux_i386.tar.gz",
+		"tool_1.2.0_Darwin_all.tar.gz",
+		"tool_1.2.0_Windows_x86_64.zip",
+		"tool-linux-arm64",
+		"tool_1.2.0_freebsd_amd64",
+	} {
+		assets = append(assets, releaseAsset{Name: name})
+	}
+
+	tests := []struct {
+		goos, goarch, want string
+	}{
+		{"linux", "amd64", "tool_1.2.0_Linux_x86_64.tar.gz"},
+		{"linux", "arm64", "tool_1.2.0_Linux_arm64.tar.gz"},
+		{"linux", "386", "tool_1.2.0_Linux_i386.tar.gz"},
+		{"darwin", "arm64", "tool_1.2.0_Darwin_all.tar.gz"},
+		{"windows", "amd64", "tool_1.2.0_Windows_x86_64.zip"},
+		{"freebsd", "amd64", "tool_1.2.0_freebsd_amd64"},
+		{"linux", "riscv64", ""},
+	}
+
+	for _, tt := range tests {
+		got, ok := pickReleaseAsset(assets, tt.goos, tt.goarch, "tool")
+		if got.Name != tt.want || ok != (tt.want != "") {
+			t.Errorf("pickReleaseAsset(%s/%s) = %q, %v; want %q", tt.goos, tt.goarch, got.Name, ok, tt.want)
+		}
+	}
+}
+
+func TestChecksumOf(t *testing.T) {
+	dir := t.TempDir()
+
+	sums := filepath.Join(dir, "checksums.txt")
+	content := "aaaa  tool_linux_amd64.tar.gz\nBBBB *tool_windows_amd64.zip\n"
+
+	if err := os.WriteFile(sums, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := checksumOf(sums, "tool_linux_amd64.tar.gz"); err != nil || got != "aaaa" {
+		t.Errorf("checksumOf(linux) = %q, %v; want aaaa", got, err)
+	}
+
+	if got, err := checksumOf(sums, "tool_windows_amd64.zip"); err != nil || got != "bbbb" {
+		t.Errorf("checksumOf(windows) = %q, %v; want bbbb", got, err)
+	}
+
+	if _, err := checksumOf(sums, "tool_darwin_arm64.tar.gz"); err == nil {
+		t.Error("checksumOf(darwin) succeeded, want error for an unlisted asset")
+	}
+
+	single := filepath.Join(dir, "tool.sha256")
+	if err := os.WriteFile(single, []byte("cccc\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := checksumOf(single, "tool"); err != nil || got != "cccc" {
+		t.Errorf("checksumOf(single) = %q, %v; want cccc", got, err)
+	}
+}
+
+// releaseServer serves a GitHub release of example tool v1.2.0 for linux
+// amd64 holding binary, listed in checksums.txt with sum
+func releaseServer(t *testing.T, binary []byte, sum func(archive []byte) string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	for name, data := range map[string][]byte{"README.md": []byte("docs"), "tool": binary} {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(data)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := tw.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	archive := buf.Bytes()
+	name := "tool_1.2.0_linux_amd64.tar.gz"
+
+	var srv *httptest.Server
+
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/user/tool/releases/tags/v1.2.0":
+			_ = json.NewEncoder(w).Encode(map[string]any{"assets": []releaseAsset{
+				{Name: "checksums.txt", URL: srv.URL + "/download/checksums.txt"},
+				{Name: name, URL: srv.URL + "/download/" + name},
+			}})
+		case "/download/checksums.txt":
+			_, _ = fmt.Fprintf(w, "%s  %s\n", sum(archive), name)
+		case "/download/" + name:
+			_, _ = w.Write(archive)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	t.Setenv("GLIX_GITHUB_API", srv.URL)
+}
+
+func TestInstallReleaseBinary(t *testing.T) {
+	releaseServer(t, []byte("prebuilt"), func(archive []byte) string {
+		h := sha256.Sum256(archive)
+		return hex.EncodeToString(h[:])
+	})
+
+	m := &Module{Name: "github.com/user/tool", Version: "v1.2.0", workingDir: t.TempDir(), binDir: t.TempDir()}
+	m.SetPlatform("linux", "amd64")
+
+	if err := m.installReleaseBinary(context.Background()); err != nil {
+		t.Fatalf("installReleaseBinary: %v", err)
+	}
+
+	data, err := os.ReadFile(m.builtBinaryPath(m.buildDirectory()))
+	if err != nil || string(data) != "prebuilt" {
+		t.Errorf("installed binary = %q, %v; want prebuilt", data, err)
+	}
+
+	// Versions without a release are built from source
+	m.Version = "v1.3.0"
+	if err := m.installReleaseBinary(context.Background()); err == nil {
+		t.Error("installReleaseBinary succeeded for a version without release")
+	}
+}
+
+func TestInstallReleaseBinaryChecksumMismatch(t *testing.T) {
+	releaseServer(t, []byte("tampered"), func([]byte) string {
+		return strings.Repeat("0", 64)
+	})
+
+	m := &Module{Name: "github.com/user/tool", Version: "v1.2.0", workingDir: t.TempDir(), binDir: t.TempDir()}
+	m.SetPlatform("linux", "amd64")
+
+	err := m.installReleaseBinary(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("installReleaseBinary = %v, want checksum mismatch", err)
+	}
+
+	if _, err := os.Stat(m.builtBinaryPath(m.buildDirectory())); !os.IsNotExist(err) {
+		t.Errorf("binary installed despite checksum mismatch: %v", err)
+	}
+}
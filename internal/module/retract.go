@@ -0,0 +1,112 @@
+package module
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/inovacc/glix/internal/modver"
+)
+
+// RetractedError is returned for explicitly requested versions their author
+// retracted, unless retracted versions are allowed
+type RetractedError struct {
+	Module    string
+	Version   string
+	Rationale string
+}
+
+func (e *RetractedError) Error() string {
+	return fmt.Sprintf("%s@%s is retracted by its author (%s); install it anyway with --allow-retracted", e.Module, e.Version, e.Rationale)
+}
+
+// SetAllowRetracted makes explicitly requested versions install even when
+// their author retracted them. Unpinned installs never pick retracted
+// versions: go leaves them out of the versions it lists.
+func (m *Module) SetAllowRetracted(allow bool) {
+	m.allowRetracted = allow
+}
+
+// AllowRetracted reports whether retracted versions install when requested
+func (m *Module) AllowRetracted() bool {
+	return m.allowRetracted
+}
+
+// Retraction returns why the author retracted version of the module, as
+// retract directives in the go.mod of its latest version say, or "" when
+// it is not retracted. It runs in the workspace of a fetched module.
+func (m *Module) Retraction(version string) (string, error) {
+	modulePath := m.RootModule
+	if modulePath == "" {
+		modulePath = m.Name
+	}
+
+	ctx, cancel := context.WithTimeout(m.ctx, m.getTimeout())
+	defer cancel()
+
+	cmd := m.goCommand(ctx, "list", "-m", "-retracted", "-json", fmt.Sprintf("%s@%s", modulePath, version))
+	cmd.Dir = m.workingDir
+
+	var out, stderr bytes.Buffer
+
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("failed to check retractions of %s@%s: %s", modulePath, version, strings.TrimPrefix(msg, "go: "))
+		}
+
+		return "", fmt.Errorf("failed to check retractions of %s@%s: %w", modulePath, version, err)
+	}
+
+	var info GoModule
+	if err := json.NewDecoder(&out).Decode(&info); err != nil {
+		return "", fmt.Errorf("failed to decode module info: %w", err)
+	}
+
+	return strings.Join(info.Retracted, "; "), nil
+}
+
+// checkRetracted refuses the resolved version when its author retracted it,
+// unless retracted versions are allowed. A failed check only warns: the
+// version was resolved, and the module cache may lack the latest go.mod.
+func (m *Module) checkRetracted() error {
+	rationale, err := m.Retraction(m.Version)
+	if err != nil {
+		m.progress("warning", err.Error())
+		return nil
+	}
+
+	if rationale == "" {
+		return nil
+	}
+
+	if !m.allowRetracted {
+		return &RetractedError{Module: m.Name, Version: m.Version, Rationale: rationale}
+	}
+
+	m.progress("warning", fmt.Sprintf("%s@%s is retracted by its author (%s); installing it because retracted versions are allowed", m.Name, m.Version, rationale))
+
+	return nil
+}
+
+// RetractedInstall returns why the author retracted the installed version
+// when the resolved version moves off it, or "" when there is nothing to
+// move off: it is not retracted, retracted versions are allowed or the
+// resolved version is an update anyway
+func (m *Module) RetractedInstall(installed string) string {
+	if m.allowRetracted || installed == "" || installed == m.Version || modver.Newer(m.Version, installed) {
+		return ""
+	}
+
+	rationale, err := m.Retraction(installed)
+	if err != nil {
+		m.progress("warning", err.Error())
+		return ""
+	}
+
+	return rationale
+}
@@ -0,0 +1,22 @@
+package module
+
+import (
+	"path/filepath"
+)
+
+// runCacheRoot returns the directory holding the binaries glix run keeps for
+// reuse, apart from GOBIN and the rollback cache
+func runCacheRoot() string {
+	return filepath.Join(GetApplicationDirectory(), "run")
+}
+
+// KeepRunBinary keeps the binary of a module version for later runs, dropping
+// the oldest kept versions of the module beyond maxCachedVersions
+func KeepRunBinary(name, version, binPath string) error {
+	return cacheBinary(runCacheRoot(), name, version, binPath)
+}
+
+// KeptRunBinary returns the binary of a module version kept by glix run, if any
+func KeptRunBinary(name, version string) (string, bool) {
+	return cachedBinary(runCacheRoot(), name, version)
+}
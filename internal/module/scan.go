@@ -0,0 +1,88 @@
+package module
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/inovacc/glix/internal/manifest"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+// BinaryScanner gives the verdict of a quarantine scanner on a built binary,
+// filling in the scanner, whether the binary is clean and what was found.
+// An error means no verdict was reached.
+type BinaryScanner func(ctx context.Context, binPath string) (*pb.ScanResultProto, error)
+
+// QuarantineError is returned by installs whose binary did not pass the
+// quarantine scan, or could not be scanned. The binary is never placed.
+type QuarantineError struct {
+	Binary string
+	Result *pb.ScanResultProto // Verdict, nil when the scan failed
+	Err    error               // Why the scan failed
+}
+
+func (e *QuarantineError) Error() string {
+	binary := filepath.Base(e.Binary)
+
+	if e.Result == nil {
+		return fmt.Sprintf("quarantine scan of %s failed: %v", binary, e.Err)
+	}
+
+	msg := fmt.Sprintf("%s was rejected by %s", binary, e.Result.GetScanner())
+	if e.Result.GetDetail() != "" {
+		msg += ": " + e.Result.GetDetail()
+	}
+
+	return msg
+}
+
+func (e *QuarantineError) Unwrap() error {
+	return e.Err
+}
+
+// SetScanner makes installs stage the built binary and have scanner pass it
+// before it is placed in the bin directory
+func (m *Module) SetScanner(scanner BinaryScanner) {
+	m.scanner = scanner
+}
+
+// ScanResult returns the verdict of the quarantine scan of the installed
+// binary, or nil when no scanner is set
+func (m *Module) ScanResult() *pb.ScanResultProto {
+	return m.scanResult
+}
+
+// ScanBinary has the quarantine scanner check binPath, returning a
+// *QuarantineError unless it is clean. Without a scanner every binary
+// passes.
+func (m *Module) ScanBinary(ctx context.Context, binPath string) error {
+	if m.scanner == nil {
+		return nil
+	}
+
+	hash, err := manifest.HashFile(binPath)
+	if err != nil {
+		return &QuarantineError{Binary: binPath, Err: err}
+	}
+
+	m.progress("quarantine", "Scanning the built binary...")
+
+	result, err := m.scanner(ctx, binPath)
+	if err != nil {
+		return &QuarantineError{Binary: binPath, Err: err}
+	}
+
+	result.Sha256 = hash
+	result.ScannedUnixNano = time.Now().UnixNano()
+	m.scanResult = result
+
+	if !result.GetClean() {
+		return &QuarantineError{Binary: binPath, Result: result}
+	}
+
+	m.progress("quarantine", fmt.Sprintf("Clean according to %s", result.GetScanner()))
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// PathBinary is an executable found while resolving a name through PATH
+type PathBinary struct {
+	Path   string // Full path of the executable
+	Source string // Package manager or origin that likely installed it
+	Glix   bool   // Whether it lives in the glix-managed GOBIN
+}
+
+// FindOnPath returns every executable named binary in PATH resolution order.
+// Duplicate PATH entries are reported once.
+func FindOnPath(binary string) []PathBinary {
+	gobin := filepath.Clean(GetGoBinDirectory())
+	seen := make(map[string]bool)
+
+	names := []string{binary}
+	if runtime.GOOS == "windows" {
+		names = []string{binary + ".exe", binary + ".cmd", binary + ".bat", binary}
+	}
+
+	var found []PathBinary
+
+	for dir := range strings.SplitSeq(os.Getenv("PATH"), string(os.PathListSeparator)) {
+		if dir == "" {
+			continue
+		}
+
+		dir = filepath.Clean(dir)
+		if seen[dir] {
+			continue
+		}
+
+		seen[dir] = true
+
+		for _, name := range names {
+			path := filepath.Join(dir, name)
+
+			info, err := os.Stat(path)
+			if err != nil || info.IsDir() || (runtime.GOOS != "windows" && info.Mode().Perm()&0111 == 0) {
+				continue
+			}
+
+			found = append(found, PathBinary{
+				Path:   path,
+				Source: binarySource(dir, path, gobin),
+				Glix:   dir == gobin,
+			})
+
+			break
+		}
+	}
+
+	return found
+}
+
+// binarySource guesses which package manager owns an executable from its
+// location, following symlinks (Homebrew links into its Cellar)
+func binarySource(dir, path, gobin string) string {
+	if dir == gobin {
+		return "glix"
+	}
+
+	resolved := path
+	if r, err := filepath.EvalSymlinks(path); err == nil {
+		resolved = r
+	}
+
+	p := filepath.ToSlash(strings.ToLower(resolved))
+	d := filepath.ToSlash(strings.ToLower(dir))
+
+	switch {
+	case strings.Contains(p, "/cellar/") || strings.HasPrefix(d, "/opt/homebrew") || strings.Contains(d, "linuxbrew"):
+		return "brew"
+	case strings.Contains(p, "/scoop/"):
+		return "scoop"
+	case strings.Contains(p, "/chocolatey/"):
+		return "chocolatey"
+	case strings.Contains(p, "/winget/"):
+		return "winget"
+	case strings.HasPrefix(d, "/snap/"):
+		return "snap"
+	case strings.HasPrefix(d, "/nix/") || strings.Contains(d, "/.nix-profile/"):
+		return "nix"
+	case d == "/usr/bin" || d == "/bin" || d == "/usr/sbin" || d == "/sbin":
+		return "system package manager (apt/dnf/pacman)"
+	case d == "/usr/local/bin":
+		return "manual or /usr/local install"
+	case strings.HasSuffix(d, "/go/bin"):
+		return "go install"
+	default:
+		return "other"
+	}
+}
+
+// ShadowConflict describes a glix-managed binary that shares its name with
+// other executables on PATH
+type ShadowConflict struct {
+	Binary  string
+	Order   []PathBinary // All matches in PATH resolution order
+	Managed PathBinary   // The glix-managed binary
+}
+
+// Shadowed reports whether another executable wins over the glix binary
+func (c ShadowConflict) Shadowed() bool {
+	return len(c.Order) > 0 && !c.Order[0].Glix
+}
+
+// FindShadowConflict returns the PATH conflicts for a glix-managed binary, or
+// nil when the name resolves only to GOBIN (or GOBIN is not on PATH at all)
+func FindShadowConflict(binary string) *ShadowConflict {
+	order := FindOnPath(binary)
+	if len(order) < 2 {
+		return nil
+	}
+
+	for _, b := range order {
+		if b.Glix {
+			return &ShadowConflict{Binary: binary, Order: order, Managed: b}
+		}
+	}
+
+	return nil
+}
+
+// SameCommand reports whether two installed executables are the same
+// command: the same name in the same directory, whatever their .exe or .cmd
+// extension, and ignoring case on Windows
+func SameCommand(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+
+	command := func(path string) string {
+		path = filepath.Clean(path)
+		if ext := filepath.Ext(path); strings.EqualFold(ext, ".exe") || strings.EqualFold(ext, ".cmd") {
+			path = strings.TrimSuffix(path, ext)
+		}
+
+		return path
+	}
+
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(command(a), command(b))
+	}
+
+	return command(a) == command(b)
+}
@@ -0,0 +1,63 @@
+//go:build !windows
+
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindShadowConflict(t *testing.T) {
+	gobin := t.TempDir()
+	other := t.TempDir()
+
+	for _, dir := range []string{gobin, other} {
+		if err := os.WriteFile(filepath.Join(dir, "tool"), []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Setenv("GOBIN", gobin)
+	t.Setenv("PATH", other+string(os.PathListSeparator)+gobin)
+
+	conflict := FindShadowConflict("tool")
+	if conflict == nil {
+		t.Fatal("expected a conflict")
+	}
+
+	if !conflict.Shadowed() || conflict.Order[0].Path != filepath.Join(other, "tool") {
+		t.Errorf("expected %s to win, got %+v", other, conflict.Order)
+	}
+
+	t.Setenv("PATH", gobin+string(os.PathListSeparator)+other)
+
+	if conflict := FindShadowConflict("tool"); conflict == nil || conflict.Shadowed() {
+		t.Errorf("expected glix binary to win, got %+v", conflict)
+	}
+
+	if conflict := FindShadowConflict("missing"); conflict != nil {
+		t.Errorf("expected no conflict, got %+v", conflict)
+	}
+}
+
+func TestSameCommand(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"/go/bin/server", "/go/bin/server", true},
+		{"/go/bin/server", "/go/bin/../bin/server", true},
+		{"/go/bin/server.exe", "/go/bin/server.cmd", true},
+		{"/go/bin/server", "/go/bin/Server", false},
+		{"/go/bin/server", "/opt/bin/server", false},
+		{"/go/bin/server", "/go/bin/server-v2", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		if got := SameCommand(tt.a, tt.b); got != tt.want {
+			t.Errorf("SameCommand(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
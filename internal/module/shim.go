@@ -0,0 +1,247 @@
+package module
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/inovacc/glix/internal/modver"
+	modpath "golang.org/x/mod/module"
+)
+
+// storeRoot returns the directory holding the versions of modules installed
+// with shims, <root>/<escaped module>/<version>/<binary>
+func storeRoot() string {
+	return filepath.Join(GetApplicationDirectory(), "store")
+}
+
+// storeDir returns the directory of a module in the version store
+func storeDir(root, name string) (string, error) {
+	escaped, err := modpath.EscapePath(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid module path %q: %w", name, err)
+	}
+
+	return filepath.Join(root, filepath.FromSlash(escaped)), nil
+}
+
+// SetShim makes installs place the binary in the version store, next to the
+// other installed versions of the module, and write a shim in the bin
+// directory that runs it. 'glix use' points the shim at another version.
+func (m *Module) SetShim(shim bool) {
+	m.shim = shim
+}
+
+// Shim reports whether installs go to the version store behind a shim
+func (m *Module) Shim() bool {
+	return m.shim
+}
+
+// storeVersionDir returns the directory of the installed version in the
+// version store
+func (m *Module) storeVersionDir() string {
+	dir, err := storeDir(storeRoot(), m.Name)
+	if err != nil {
+		// Names were validated when the module was fetched
+		return filepath.Join(storeRoot(), "invalid")
+	}
+
+	return filepath.Join(dir, m.Version)
+}
+
+// shimDirectory returns where installs with shims place the shim
+func (m *Module) shimDirectory() string {
+	if m.binDir != "" {
+		return m.binDir
+	}
+
+	return GetGoBinDirectory()
+}
+
+// placeShim points the shim of the installed binary at it
+func (m *Module) placeShim() error {
+	binary := strings.TrimSuffix(filepath.Base(m.BinaryPath), ".exe")
+	shim := ShimPathIn(m.shimDirectory(), binary)
+
+	if err := WriteShim(shim, m.BinaryPath); err != nil {
+		return err
+	}
+
+	m.ShimPath = shim
+
+	return nil
+}
+
+// ShimPathIn returns the path of the shim of binary in dir: a script named
+// like the binary, or a .cmd file on Windows
+func ShimPathIn(dir, binary string) string {
+	if runtime.GOOS == "windows" {
+		binary += ".cmd"
+	}
+
+	return filepath.Join(dir, binary)
+}
+
+// WriteShim atomically writes a shim at path that runs target with the
+// arguments it is given. Replacing it switches versions without touching
+// the binaries.
+func WriteShim(path, target string) error {
+	script := fmt.Sprintf("#!/bin/sh\n# Written by glix; switch versions with 'glix use'\nexec '%s' \"$@\"\n",
+		strings.ReplaceAll(target, "'", `'\''`))
+
+	if runtime.GOOS == "windows" {
+		script = fmt.Sprintf("@echo off\r\nrem Written by glix; switch versions with 'glix use'\r\n\"%s\" %%*\r\n", target)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create shim directory: %w", err)
+	}
+
+	tmp := fmt.Sprintf("%s.glix-%d", path, time.Now().UnixNano())
+
+	if err := os.WriteFile(tmp, []byte(script), 0755); err != nil {
+		return fmt.Errorf("failed to write shim: %w", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to replace shim: %w", err)
+	}
+
+	return nil
+}
+
+// StoredVersions returns the versions of a module in the version store,
+// newest first
+func StoredVersions(name string) []string {
+	return storedVersions(storeRoot(), name)
+}
+
+func storedVersions(root, name string) []string {
+	dir, err := storeDir(root, name)
+	if err != nil {
+		return nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var versions []string
+
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+
+		if _, ok := storedBinary(root, name, e.Name()); ok {
+			versions = append(versions, e.Name())
+		}
+	}
+
+	slices.SortFunc(versions, func(a, b string) int {
+		return modver.Compare(b, a)
+	})
+
+	return versions
+}
+
+// StoredBinary returns the binary of a module version in the version store
+func StoredBinary(name, version string) (string, bool) {
+	return storedBinary(storeRoot(), name, version)
+}
+
+func storedBinary(root, name, version string) (string, bool) {
+	dir, err := storeDir(root, name)
+	if err != nil || version == "" || strings.ContainsAny(version, `/\`) {
+		return "", false
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, version))
+	if err != nil {
+		return "", false
+	}
+
+	for _, e := range entries {
+		if e.Type().IsRegular() {
+			return filepath.Join(dir, version, e.Name()), true
+		}
+	}
+
+	return "", false
+}
+
+// RemoveStoredVersion deletes a version of a module from the version store,
+// if it is there
+func RemoveStoredVersion(name, version string) error {
+	binPath, ok := StoredBinary(name, version)
+	if !ok {
+		return nil
+	}
+
+	if err := os.RemoveAll(filepath.Dir(binPath)); err != nil {
+		return fmt.Errorf("failed to remove stored version: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveStore deletes every version of a module in the version store.
+// Modules nested in its path keep theirs.
+func RemoveStore(name string) error {
+	return removeStore(storeRoot(), name)
+}
+
+func removeStore(root, name string) error {
+	dir, err := storeDir(root, name)
+	if err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to read version store: %w", err)
+	}
+
+	for _, e := range entries {
+		// Version directories hold a binary, or nothing once the receipt
+		// removed it; nested modules hold version directories
+		if !e.IsDir() || !isVersionDir(filepath.Join(dir, e.Name())) {
+			continue
+		}
+
+		if err := os.RemoveAll(filepath.Join(dir, e.Name())); err != nil {
+			return fmt.Errorf("failed to remove stored version: %w", err)
+		}
+	}
+
+	_ = os.Remove(dir)
+
+	return nil
+}
+
+// isVersionDir reports whether a directory of the version store is the
+// directory of a version, holding no directories
+func isVersionDir(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+
+	for _, e := range entries {
+		if e.IsDir() {
+			return false
+		}
+	}
+
+	return true
+}
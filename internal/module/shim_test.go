@@ -0,0 +1,88 @@
+package module
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+func TestWriteShim(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("runs the shim with sh")
+	}
+
+	dir := t.TempDir()
+
+	// The target path needs quoting in the shim
+	target := filepath.Join(dir, "it's v1", "tool")
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(target, []byte("#!/bin/sh\necho \"v1 $*\"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	shim := ShimPathIn(filepath.Join(dir, "bin"), "tool")
+	if err := WriteShim(shim, target); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := exec.Command(shim, "a b", "c").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(out) != "v1 a b c\n" {
+		t.Errorf("shim printed %q", out)
+	}
+}
+
+func TestVersionStore(t *testing.T) {
+	root := t.TempDir()
+
+	store := func(name, version string) {
+		dir, err := storeDir(root, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dir = filepath.Join(dir, version)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, "tool"), []byte("bin"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	store("example.com/tool", "v1.2.0")
+	store("example.com/tool", "v1.10.0")
+	store("example.com/tool", "v1.9.1")
+	store("example.com/tool/v2", "v2.0.0")
+
+	want := []string{"v1.10.0", "v1.9.1", "v1.2.0"}
+	if got := storedVersions(root, "example.com/tool"); !slices.Equal(got, want) {
+		t.Errorf("storedVersions() = %v, want %v", got, want)
+	}
+
+	if _, ok := storedBinary(root, "example.com/tool", "v1.3.0"); ok {
+		t.Error("storedBinary() found a version that is not stored")
+	}
+
+	if err := removeStore(root, "example.com/tool"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := storedVersions(root, "example.com/tool"); len(got) != 0 {
+		t.Errorf("versions left after removeStore: %v", got)
+	}
+
+	if _, ok := storedBinary(root, "example.com/tool/v2", "v2.0.0"); !ok {
+		t.Error("removeStore() removed a nested module")
+	}
+}
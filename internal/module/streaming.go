@@ -8,9 +8,9 @@ import (
 	"os"
 	osExec "os/exec"
 	"path/filepath"
-	"runtime"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/inovacc/glix/pkg/exec"
 )
@@ -22,6 +22,17 @@ type OutputHandler func(stream string, line string)
 func ExecuteWithStreaming(ctx context.Context, handler OutputHandler, name string, args ...string) error {
 	cmd := exec.CommandContext(ctx, name, args...)
 
+	if err := runWithStreaming(cmd, handler, nil); err != nil {
+		return fmt.Errorf("command failed: %w", err)
+	}
+
+	return nil
+}
+
+// runWithStreaming starts cmd, streams stdout and stderr line by line to the
+// handler and waits for it to exit. A watchdog, if given, reports the
+// command while it prints nothing.
+func runWithStreaming(cmd *osExec.Cmd, handler OutputHandler, wd *watchdog) error {
 	stdoutPipe, err := cmd.StdoutPipe()
 	if err != nil {
 		return fmt.Errorf("failed to create stdout pipe: %w", err)
@@ -33,7 +44,25 @@ func ExecuteWithStreaming(ctx context.Context, handler OutputHandler, name strin
 	}
 
 	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("failed to start command: %w", err)
+		return fmt.Errorf("failed to start %s: %w", filepath.Base(cmd.Path), err)
+	}
+
+	defer trackProcess(cmd)()
+
+	if wd != nil {
+		done := make(chan struct{})
+		defer close(done)
+
+		go wd.watch(cmd, done)
+
+		next := handler
+		handler = func(stream, line string) {
+			wd.touch()
+
+			if next != nil {
+				next(stream, line)
+			}
+		}
 	}
 
 	var wg sync.WaitGroup
@@ -53,11 +82,7 @@ func ExecuteWithStreaming(ctx context.Context, handler OutputHandler, name strin
 
 	wg.Wait()
 
-	if err := cmd.Wait(); err != nil {
-		return fmt.Errorf("command failed: %w", err)
-	}
-
-	return nil
+	return cmd.Wait()
 }
 
 func streamLines(r io.Reader, stream string, handler OutputHandler) {
@@ -80,118 +105,273 @@ func DefaultOutputHandler(stream string, line string) {
 
 // InstallModuleWithStreaming installs a module with real-time output streaming
 func (m *Module) InstallModuleWithStreaming(ctx context.Context, handler OutputHandler) error {
+	// Fail before building when the binary cannot be placed
+	if err := CheckBinDir(m.binDirectory()); err != nil {
+		return err
+	}
+
+	if m.shim {
+		if err := CheckBinDir(m.shimDirectory()); err != nil {
+			return err
+		}
+	}
+
+	if network := NetworkFileSystem(m.binDirectory()); network != "" {
+		m.progress("warning", fmt.Sprintf("%s is on a %s mount; installs may be slow and binaries are copied rather than linked into the cache", m.binDirectory(), network))
+	}
+
+	if err := m.RunHooks(ctx, true); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	m.startResolving()
+
+	// Local working copies are built in place, bypassing the proxy and GoReleaser
+	if m.LocalPath != "" {
+		if err := m.installLocalWithStreaming(ctx, handler); err != nil {
+			return err
+		}
+
+		if err := m.placeInstalled(); err != nil {
+			return err
+		}
+
+		m.recordBuildMetrics(start)
+
+		return m.RunHooks(ctx, false)
+	}
+
+	if err := m.installRemoteWithStreaming(ctx, handler); err != nil {
+		return err
+	}
+
+	if err := m.placeInstalled(); err != nil {
+		return err
+	}
+
+	// Keep the binary so a rollback to this version needs no rebuild. Installs
+	// outside GOBIN, by glix run or with --bin-dir, are not cached; rollbacks
+	// reinstall them. The version store keeps the versions of shimmed ones.
+	if m.binDir == "" && !m.shim {
+		if err := CacheBinary(m.Name, m.Version, m.BinaryPath); err != nil && handler != nil {
+			handler("stderr", fmt.Sprintf("warning: %v", err))
+		}
+	}
+
+	m.recordBuildMetrics(start)
+
+	return m.RunHooks(ctx, false)
+}
+
+// placeInstalled records the built binary, behind its shim for installs
+// with shims
+func (m *Module) placeInstalled() error {
+	if err := m.placeBinary(); err != nil {
+		return err
+	}
+
+	if !m.shim {
+		return nil
+	}
+
+	return m.placeShim()
+}
+
+// installRemoteWithStreaming installs a module from the proxy, built as its
+// build strategy says: by default via GoReleaser when it has a config and go
+// install otherwise, falling back to a build target of its Makefile or
+// magefile when go install fails. Modules preferring binaries install the
+// prebuilt binary of their GitHub release instead when there is one.
+func (m *Module) installRemoteWithStreaming(ctx context.Context, handler OutputHandler) error {
+	// Release binaries are downloaded from GitHub, out of reach offline
+	if m.preferBinary && !m.offline {
+		err := m.installReleaseBinary(ctx)
+		if err == nil {
+			return nil
+		}
+
+		m.progress("binary", fmt.Sprintf("No prebuilt binary: %v; building from source", err))
+	}
+
 	// Download the module to check for .goreleaser.yaml
 	moduleDir, err := m.getModuleSourceDir(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get module source: %w", err)
 	}
 
-	// Check if the module has a .goreleaser.yaml file
+	// Check if the module has a GoReleaser config, and a build in it for
+	// the target platform
 	hasGR, configPath, err := m.hasGoReleaserConfig(ctx, moduleDir)
 	if err != nil {
 		return fmt.Errorf("failed to check for goreleaser config: %w", err)
 	}
 
+	var (
+		release *goReleaserConfig
+		build   goReleaserBuild
+		noBuild error
+	)
+
 	if hasGR {
-		if handler != nil {
-			handler("stdout", fmt.Sprintf("Found GoReleaser config: %s", configPath))
-		}
+		release, build, noBuild = m.goReleaserBuild(moduleDir)
+	}
+
+	strategy := m.buildStrategy
 
-		return m.installViaGoReleaserWithStreaming(ctx, moduleDir, handler)
+	// Other build tools build with the flags of their own config, not those
+	// chosen
+	if !strategy.IsZero() && strategy.Kind != StrategyGo && !m.buildFlags.IsZero() {
+		return fmt.Errorf("build flags apply to go install and cannot be combined with the %s build strategy", strategy.Kind)
 	}
 
-	// Standard go install with streaming
-	modulePath := fmt.Sprintf("%s@%s", m.Name, m.Version)
+	switch strategy.Kind {
+	case StrategyGoReleaser:
+		if !hasGR {
+			return fmt.Errorf("%s has no GoReleaser config to build with", m.RootModule)
+		}
 
-	gopath := os.Getenv("GOPATH")
-	if gopath == "" {
-		home, _ := os.UserHomeDir()
-		gopath = fmt.Sprintf("%s/go", home)
+		if noBuild != nil {
+			return noBuild
+		}
+
+		return m.installViaGoReleaserWithStreaming(ctx, moduleDir, release, build, handler)
+	case StrategyMake, StrategyMage:
+		return m.installWithBuilder(ctx, moduleDir, strategy, handler)
+	case StrategyGo:
+		hasGR = false
 	}
 
-	// Set GOBIN environment variable
-	gobin := fmt.Sprintf("%s/bin", gopath)
+	// GoReleaser builds with the flags of its config, not those chosen
+	if hasGR && !m.buildFlags.IsZero() {
+		if handler != nil {
+			handler("stdout", fmt.Sprintf("Found GoReleaser config: %s; building with go install for the custom build flags", configPath))
+		}
 
-	cmd := exec.CommandContext(ctx, m.goBinPath, "install", modulePath)
+		hasGR = false
+	}
 
-	cmd.Env = append(os.Environ(), fmt.Sprintf("GOBIN=%s", gobin))
+	if hasGR && noBuild != nil {
+		if handler != nil {
+			handler("stdout", fmt.Sprintf("Found GoReleaser config: %s; %v, building with go install", configPath, noBuild))
+		}
 
-	stdoutPipe, err := cmd.StdoutPipe()
-	if err != nil {
-		return fmt.Errorf("failed to create stdout pipe: %w", err)
+		hasGR = false
 	}
 
-	stderrPipe, err := cmd.StderrPipe()
-	if err != nil {
-		return fmt.Errorf("failed to create stderr pipe: %w", err)
+	if hasGR {
+		if handler != nil {
+			handler("stdout", fmt.Sprintf("Found GoReleaser config: %s", configPath))
+		}
+
+		return m.installViaGoReleaserWithStreaming(ctx, moduleDir, release, build, handler)
 	}
 
-	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("failed to start go install: %w", err)
+	// Sources GoReleaser would generate first are missing from go install
+	if release != nil && handler != nil {
+		if hooks := release.preHooks(build); len(hooks) > 0 {
+			handler("stdout", fmt.Sprintf("Not running the GoReleaser hooks go install skips: %s", strings.Join(hooks, "; ")))
+		}
 	}
 
-	var wg sync.WaitGroup
-	wg.Add(2)
+	// Fail before building what needs cgo when cgo cannot build it
+	if err := m.checkToolchain(ctx, m.workingDir, m.Name); err != nil {
+		return err
+	}
 
-	go func() {
-		defer wg.Done()
+	// Standard go install with streaming
+	modulePath := fmt.Sprintf("%s@%s", m.Name, m.Version)
 
-		streamLines(stdoutPipe, "stdout", handler)
-	}()
+	installErr := m.goInstall(ctx, "", modulePath, handler)
+	if installErr == nil {
+		return nil
+	}
 
-	go func() {
-		defer wg.Done()
+	// Tools that only build through their own build files get another try,
+	// unless the install was cancelled or asked for go install
+	if !strategy.IsZero() || !m.buildFlags.IsZero() || ctx.Err() != nil {
+		return fmt.Errorf("go install failed: %w", installErr)
+	}
 
-		streamLines(stderrPipe, "stderr", handler)
-	}()
+	fallback, file, ok := fallbackStrategy(moduleDir)
+	if !ok {
+		return fmt.Errorf("go install failed: %w", installErr)
+	}
 
-	wg.Wait()
+	m.progress("build", fmt.Sprintf("go install failed; building with %s %s from %s", fallback.Kind, fallback.target(), file))
 
-	if err := cmd.Wait(); err != nil {
-		return fmt.Errorf("go install failed: %w", err)
+	if err := m.installWithBuilder(ctx, moduleDir, fallback, handler); err != nil {
+		return fmt.Errorf("go install failed: %w; %w", installErr, err)
 	}
 
 	return nil
 }
 
+// goReleaserBuild returns the GoReleaser config of the module and the build
+// of it for the package and platform being installed, or an error saying
+// why GoReleaser cannot build them
+func (m *Module) goReleaserBuild(moduleDir string) (*goReleaserConfig, goReleaserBuild, error) {
+	cfg, err := loadGoReleaserConfig(moduleDir)
+	if err != nil {
+		return nil, goReleaserBuild{}, err
+	}
+
+	build, ok := cfg.buildFor(m.RootModule, m.Name, m.goos(), m.goarch())
+	if !ok {
+		return cfg, goReleaserBuild{}, fmt.Errorf("the GoReleaser config has no Go build for %s/%s", m.goos(), m.goarch())
+	}
+
+	return cfg, build, nil
+}
+
 // installViaGoReleaserWithStreaming builds and installs using GoReleaser with output streaming
-func (m *Module) installViaGoReleaserWithStreaming(ctx context.Context, moduleDir string, handler OutputHandler) error {
+func (m *Module) installViaGoReleaserWithStreaming(ctx context.Context, moduleDir string, cfg *goReleaserConfig, build goReleaserBuild, handler OutputHandler) error {
 	// Check if goreleaser is installed
 	if _, err := osExec.LookPath("goreleaser"); err != nil {
 		if handler != nil {
 			handler("stdout", "GoReleaser not found, installing...")
 		}
 
-		if err := ExecuteWithStreaming(ctx, handler, m.goBinPath, "install", "github.com/goreleaser/goreleaser/v2@latest"); err != nil {
+		cmd := m.goCommand(ctx, "install", "github.com/goreleaser/goreleaser/v2@latest")
+		if err := runWithStreaming(cmd, handler, m.watchdog()); err != nil {
 			return fmt.Errorf("failed to install goreleaser: %w", err)
 		}
 	}
 
-	// Create a temporary build directory to avoid polluting the cache
-	cacheDir, err := GetApplicationCacheDirectory()
+	// Build in a copy to leave the module cache untouched
+	buildDir, err := NewWorkDir("build")
 	if err != nil {
-		return fmt.Errorf("failed to get cache directory: %w", err)
-	}
-
-	buildDir := filepath.Join(cacheDir, "build")
-	if err := copyDir(moduleDir, buildDir); err != nil {
-		return fmt.Errorf("failed to copy module source: %w", err)
+		return err
 	}
 
 	defer func() {
 		_ = os.RemoveAll(buildDir)
 	}()
 
+	if err := copyDir(moduleDir, buildDir); err != nil {
+		return fmt.Errorf("failed to copy module source: %w", err)
+	}
+
 	if handler != nil {
-		handler("stdout", "Building with GoReleaser...")
+		if build.ID != "" {
+			handler("stdout", fmt.Sprintf("Building with GoReleaser (build %s)...", build.ID))
+		} else {
+			handler("stdout", "Building with GoReleaser...")
+		}
 	}
 
-	// Build with goreleaser in the build directory
-	cmd := exec.CommandContext(ctx, "goreleaser", "build", "--snapshot", "--clean")
+	// Build with goreleaser in the build directory, only the build and
+	// platform being installed
+	args := []string{"build", "--snapshot", "--clean", "--single-target"}
+	if build.ID != "" {
+		args = append(args, "--id", build.ID)
+	}
+
+	cmd := exec.CommandContext(ctx, "goreleaser", args...)
 	cmd.Dir = buildDir
 
 	// Set environment variables
-	env := os.Environ()
+	env := m.goEnv(m.platformEnv()...)
 
 	parts := strings.Split(m.Name, "/")
 	if len(parts) >= 2 {
@@ -201,38 +381,7 @@ func (m *Module) installViaGoReleaserWithStreaming(ctx context.Context, moduleDi
 
 	cmd.Env = env
 
-	stdoutPipe, err := cmd.StdoutPipe()
-	if err != nil {
-		return fmt.Errorf("failed to create stdout pipe: %w", err)
-	}
-
-	stderrPipe, err := cmd.StderrPipe()
-	if err != nil {
-		return fmt.Errorf("failed to create stderr pipe: %w", err)
-	}
-
-	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("failed to start goreleaser: %w", err)
-	}
-
-	var wg sync.WaitGroup
-	wg.Add(2)
-
-	go func() {
-		defer wg.Done()
-
-		streamLines(stdoutPipe, "stdout", handler)
-	}()
-
-	go func() {
-		defer wg.Done()
-
-		streamLines(stderrPipe, "stderr", handler)
-	}()
-
-	wg.Wait()
-
-	if err := cmd.Wait(); err != nil {
+	if err := runWithStreaming(cmd, handler, m.watchdog()); err != nil {
 		return fmt.Errorf("goreleaser build failed: %w", err)
 	}
 
@@ -243,48 +392,10 @@ func (m *Module) installViaGoReleaserWithStreaming(ctx context.Context, moduleDi
 	// Find the built binary in the dist directory
 	distDir := filepath.Join(buildDir, "dist")
 
-	binaryPath, err := m.findBuiltBinary(distDir)
+	binaryPath, err := m.findBuiltBinary(distDir, build.ID, cfg.binaryName(build, m.RootModule, m.goos(), m.goarch()))
 	if err != nil {
 		return fmt.Errorf("failed to find built binary: %w", err)
 	}
 
-	// Copy binary to GOBIN
-	gopath := os.Getenv("GOPATH")
-	if gopath == "" {
-		home, _ := os.UserHomeDir()
-		gopath = filepath.Join(home, "go")
-	}
-
-	gobin := filepath.Join(gopath, "bin")
-
-	// Ensure GOBIN directory exists
-	if err := os.MkdirAll(gobin, 0755); err != nil {
-		return fmt.Errorf("failed to create GOBIN directory: %w", err)
-	}
-
-	// Determine binary name from the module name
-	binaryName := filepath.Base(m.Name)
-	if runtime.GOOS == "windows" && !strings.HasSuffix(binaryName, ".exe") {
-		binaryName += ".exe"
-	}
-
-	destPath := filepath.Join(gobin, binaryName)
-
-	// Copy the binary to GOBIN
-	if err := copyFile(binaryPath, destPath); err != nil {
-		return fmt.Errorf("failed to copy binary to GOBIN: %w", err)
-	}
-
-	// Make it executable (Unix only)
-	if runtime.GOOS != "windows" {
-		if err := os.Chmod(destPath, 0755); err != nil {
-			return fmt.Errorf("failed to make binary executable: %w", err)
-		}
-	}
-
-	if handler != nil {
-		handler("stdout", fmt.Sprintf("Binary installed to: %s", destPath))
-	}
-
-	return nil
+	return m.installBuiltBinary(binaryPath, handler)
 }
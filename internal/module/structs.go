@@ -14,10 +14,13 @@ type GoListPackage struct {
 	Incomplete  bool          `json:"Incomplete,omitempty"`
 	Stale       bool          `json:"Stale,omitempty"`
 	StaleReason string        `json:"StaleReason,omitempty"`
+	Standard    bool          `json:"Standard,omitempty"`
 	GoFiles     []string      `json:"GoFiles,omitempty"`
+	CgoFiles    []string      `json:"CgoFiles,omitempty"` // Files importing "C", when cgo is enabled
 	Imports     []string      `json:"Imports,omitempty"`
 	Deps        []string      `json:"Deps,omitempty"`
 	DepsErrors  []GoDepsError `json:"DepsErrors,omitempty"`
+	Error       *GoDepsError  `json:"Error,omitempty"` // Set by go list -e for packages that failed to load
 }
 
 type GoModule struct {
@@ -30,6 +33,7 @@ type GoModule struct {
 	GoVersion string    `json:"GoVersion,omitempty"`
 	Sum       string    `json:"Sum,omitempty"`
 	GoModSum  string    `json:"GoModSum,omitempty"`
+	Retracted []string  `json:"Retracted,omitempty"` // Rationales of a retracted version, with go list -retracted
 }
 
 type GoDepsError struct {
@@ -0,0 +1,327 @@
+package module
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	modpath "golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb"
+	"golang.org/x/mod/sumdb/dirhash"
+)
+
+// DefaultSumDB is the checksum database used when GOSUMDB is unset
+const DefaultSumDB = "sum.golang.org"
+
+// sumGolangOrgKey is the verifier key of sum.golang.org and its mirror
+// sum.golang.google.cn, as the go command has it built in
+const sumGolangOrgKey = "sum.golang.org+033de0ae+Ac4zctda0e5eza+HJyk9SxEdh+s3Ze6nAXiHfthWCVgQ"
+
+var (
+	// ErrSumDBOff means GOSUMDB=off disables the checksum database
+	ErrSumDBOff = errors.New("checksum database disabled (GOSUMDB=off)")
+
+	// ErrNoSumDB means GONOSUMDB, GOPRIVATE or the private module config
+	// exclude a module from the checksum database
+	ErrNoSumDB = errors.New("not in the checksum database (GONOSUMDB or GOPRIVATE)")
+
+	// ErrNotCached means a module version is not in the module cache
+	ErrNotCached = errors.New("not in the module cache")
+)
+
+// Sums are the go.sum hashes of a module version: h1: hashes of its zip
+// and of its go.mod
+type Sums struct {
+	Sum      string
+	GoModSum string
+}
+
+// IsZero reports whether no hash is known
+func (s Sums) IsZero() bool {
+	return s.Sum == "" && s.GoModSum == ""
+}
+
+// sumDBServer returns the verifier key and URL of the checksum database
+// GOSUMDB names: "off", a known database, or "name+key [url]"
+func sumDBServer(gosumdb string) (string, string, error) {
+	fields := strings.Fields(cmp.Or(gosumdb, DefaultSumDB))
+	if fields[0] == "off" {
+		return "", "", ErrSumDBOff
+	}
+
+	name, key := fields[0], fields[0]
+
+	switch name {
+	case "sum.golang.org", "sum.golang.google.cn":
+		key = sumGolangOrgKey
+	default:
+		var ok bool
+
+		name, _, ok = strings.Cut(name, "+")
+		if !ok {
+			return "", "", fmt.Errorf("unknown checksum database %q: set GOSUMDB to name+key", gosumdb)
+		}
+	}
+
+	url := "https://" + name
+
+	if len(fields) > 1 {
+		url = fields[1]
+		if !strings.Contains(url, "://") {
+			url = "https://" + url
+		}
+	}
+
+	return key, strings.TrimSuffix(url, "/"), nil
+}
+
+// sumDBOps lets the sumdb client fetch over HTTP, keep the latest signed
+// tree head in the config directory, so a database that rewrites its
+// history is detected, and cache tiles in the cache directory
+type sumDBOps struct {
+	ctx      context.Context
+	key      string
+	url      string
+	configMu sync.Mutex
+	security string // Message of the security error the client reported
+}
+
+func (o *sumDBOps) ReadRemote(path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(o.ctx, http.MethodGet, o.url+path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := proxyHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s%s: %s", o.url, path, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+func (o *sumDBOps) ReadConfig(file string) ([]byte, error) {
+	if file == "key" {
+		return []byte(o.key), nil
+	}
+
+	data, err := os.ReadFile(sumDBPath(GetApplicationConfigDirectory, file))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	return data, err
+}
+
+func (o *sumDBOps) WriteConfig(file string, old, new []byte) error {
+	o.configMu.Lock()
+	defer o.configMu.Unlock()
+
+	current, err := o.ReadConfig(file)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(current, old) {
+		return sumdb.ErrWriteConflict
+	}
+
+	return writeFileAtomic(sumDBPath(GetApplicationConfigDirectory, file), new)
+}
+
+func (o *sumDBOps) ReadCache(file string) ([]byte, error) {
+	return os.ReadFile(sumDBPath(GetApplicationCacheDirectory, file))
+}
+
+func (o *sumDBOps) WriteCache(file string, data []byte) {
+	_ = writeFileAtomic(sumDBPath(GetApplicationCacheDirectory, file), data)
+}
+
+func (o *sumDBOps) Log(string) {}
+
+func (o *sumDBOps) SecurityError(msg string) {
+	o.security = msg
+}
+
+// sumDBPath returns the path of a sumdb config or cache file
+func sumDBPath(dir func() (string, error), file string) string {
+	base, err := dir()
+	if err != nil {
+		base = os.TempDir()
+	}
+
+	return filepath.Join(base, "sumdb", filepath.FromSlash(file))
+}
+
+// writeFileAtomic replaces a file with data through a temporary file
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// LookupSums asks the checksum database of GOSUMDB for the hashes of a
+// module version. The answer is verified like the go command does: the
+// signed tree head against the database key, and the record against the
+// tree with its inclusion proof.
+func LookupSums(ctx context.Context, modulePath, version string) (Sums, error) {
+	key, url, err := sumDBServer(os.Getenv("GOSUMDB"))
+	if err != nil {
+		return Sums{}, err
+	}
+
+	c, _ := LoadPrivateConfig()
+	nosumdb := joinPatterns(cmp.Or(os.Getenv("GONOSUMDB"), os.Getenv("GOPRIVATE")), append(c.Patterns, c.NoSumDB...))
+
+	ops := &sumDBOps{ctx: ctx, key: key, url: url}
+
+	client := sumdb.NewClient(ops)
+	client.SetGONOSUMDB(nosumdb)
+
+	var sums Sums
+
+	// Both hashes are in the same record, which the client fetches once
+	for _, query := range []string{version, version + "/go.mod"} {
+		lines, err := client.Lookup(modulePath, query)
+		if err != nil {
+			switch {
+			case errors.Is(err, sumdb.ErrGONOSUMDB):
+				return Sums{}, ErrNoSumDB
+			case errors.Is(err, sumdb.ErrSecurity) && ops.security != "":
+				return Sums{}, fmt.Errorf("%w: %s", sumdb.ErrSecurity, ops.security)
+			}
+
+			return Sums{}, err
+		}
+
+		for _, line := range lines {
+			fields := strings.Fields(line)
+			if len(fields) != 3 {
+				continue
+			}
+
+			if query == version {
+				sums.Sum = fields[2]
+			} else {
+				sums.GoModSum = fields[2]
+			}
+		}
+	}
+
+	return sums, nil
+}
+
+// VerifyCachedSums hashes a module version in a module cache as go mod
+// verify does, its zip, its extracted directory and its go.mod, and
+// compares the hashes with the recorded ones. It returns ErrNotCached when
+// none of them are in the cache.
+func VerifyCachedSums(modCache, modulePath, version string, want Sums) error {
+	escPath, err := modpath.EscapePath(modulePath)
+	if err != nil {
+		return err
+	}
+
+	escVersion, err := modpath.EscapeVersion(version)
+	if err != nil {
+		return err
+	}
+
+	download := filepath.Join(modCache, "cache", "download", filepath.FromSlash(escPath), "@v", escVersion)
+	checked := false
+
+	check := func(file, want string, hash func() (string, error)) error {
+		if want == "" {
+			return nil
+		}
+
+		got, err := hash()
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to hash %s: %w", file, err)
+		}
+
+		checked = true
+
+		if got != want {
+			return fmt.Errorf("%s hashes to %s, recorded %s", file, got, want)
+		}
+
+		return nil
+	}
+
+	zip := download + ".zip"
+	if err := check(zip, want.Sum, func() (string, error) {
+		return dirhash.HashZip(zip, dirhash.Hash1)
+	}); err != nil {
+		return err
+	}
+
+	dir := filepath.Join(modCache, filepath.FromSlash(escPath)+"@"+escVersion)
+	if err := check(dir, want.Sum, func() (string, error) {
+		if _, err := os.Stat(dir); err != nil {
+			return "", err
+		}
+
+		return dirhash.HashDir(dir, modulePath+"@"+version, dirhash.Hash1)
+	}); err != nil {
+		return err
+	}
+
+	gomod := download + ".mod"
+	if err := check(gomod, want.GoModSum, func() (string, error) {
+		if _, err := os.Stat(gomod); err != nil {
+			return "", err
+		}
+
+		return dirhash.Hash1([]string{"go.mod"}, func(string) (io.ReadCloser, error) {
+			return os.Open(gomod)
+		})
+	}); err != nil {
+		return err
+	}
+
+	if !checked {
+		return ErrNotCached
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package module
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/mod/sumdb"
+	"golang.org/x/mod/sumdb/dirhash"
+	"golang.org/x/mod/sumdb/note"
+)
+
+// useTempAppDir points the config and cache directories at a temporary
+// directory for the test
+func useTempAppDir(t *testing.T) {
+	t.Helper()
+
+	oldApp, oldCache := appDir, cacheDir
+	appDir, cacheDir = t.TempDir(), t.TempDir()
+
+	t.Cleanup(func() {
+		appDir, cacheDir = oldApp, oldCache
+	})
+}
+
+func TestSumDBServer(t *testing.T) {
+	tests := []struct {
+		gosumdb, key, url string
+		err               bool
+	}{
+		{"", sumGolangOrgKey, "https://sum.golang.org", false},
+		{"sum.golang.google.cn", sumGolangOrgKey, "https://sum.golang.google.cn", false},
+		{"sum.golang.org https://proxy.acme.dev/sumdb/sum.golang.org/", sumGolangOrgKey, "https://proxy.acme.dev/sumdb/sum.golang.org", false},
+		{"sum.acme.dev+1234+key", "sum.acme.dev+1234+key", "https://sum.acme.dev", false},
+		{"sum.acme.dev+1234+key sums.acme.dev", "sum.acme.dev+1234+key", "https://sums.acme.dev", false},
+		{"sum.acme.dev", "", "", true},
+	}
+
+	for _, tt := range tests {
+		key, url, err := sumDBServer(tt.gosumdb)
+		if (err != nil) != tt.err || key != tt.key || url != tt.url {
+			t.Errorf("sumDBServer(%q) = %q, %q, %v", tt.gosumdb, key, url, err)
+		}
+	}
+
+	if _, _, err := sumDBServer("off"); !errors.Is(err, ErrSumDBOff) {
+		t.Errorf("sumDBServer(off) = %v, want ErrSumDBOff", err)
+	}
+}
+
+func TestLookupSums(t *testing.T) {
+	useTempAppDir(t)
+
+	signer, verifier, err := note.GenerateKey(rand.Reader, "sum.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gosum := func(path, version string) ([]byte, error) {
+		return fmt.Appendf(nil, "%s %s h1:zip-%s=\n%s %s/go.mod h1:mod-%s=\n", path, version, version, path, version, version), nil
+	}
+
+	srv := httptest.NewServer(sumdb.NewServer(sumdb.NewTestServer(signer, gosum)))
+	defer srv.Close()
+
+	t.Setenv("GOSUMDB", verifier+" "+srv.URL)
+	t.Setenv("GONOSUMDB", "example.com/private")
+	t.Setenv("GOPRIVATE", "")
+
+	sums, err := LookupSums(context.Background(), "example.com/tool", "v1.2.0")
+	if err != nil {
+		t.Fatalf("LookupSums: %v", err)
+	}
+
+	if want := (Sums{Sum: "h1:zip-v1.2.0=", GoModSum: "h1:mod-v1.2.0="}); sums != want {
+		t.Errorf("LookupSums = %+v, want %+v", sums, want)
+	}
+
+	// The verified tree head is kept to check the next answers against
+	if _, err := os.Stat(sumDBPath(GetApplicationConfigDirectory, "sum.test/latest")); err != nil {
+		t.Errorf("latest tree not saved: %v", err)
+	}
+
+	if _, err := LookupSums(context.Background(), "example.com/private/tool", "v1.0.0"); !errors.Is(err, ErrNoSumDB) {
+		t.Errorf("LookupSums of an excluded module = %v, want ErrNoSumDB", err)
+	}
+
+	// Answers signed by another key are rejected
+	_, other, _ := note.GenerateKey(rand.Reader, "sum.test")
+	t.Setenv("GOSUMDB", other+" "+srv.URL)
+
+	if _, err := LookupSums(context.Background(), "example.com/tool", "v1.3.0"); err == nil {
+		t.Error("LookupSums accepted a tree signed by another key")
+	}
+}
+
+func TestVerifyCachedSums(t *testing.T) {
+	const (
+		path    = "example.com/Tool"
+		version = "v1.0.0"
+	)
+
+	files := map[string]string{
+		"go.mod":  "module example.com/Tool\n",
+		"main.go": "package main\n\nfunc main() {}\n",
+	}
+
+	modCache := t.TempDir()
+	download := filepath.Join(modCache, "cache", "download", "example.com", "!tool", "@v")
+	dir := filepath.Join(modCache, "example.com", "!tool@v1.0.0")
+
+	if err := VerifyCachedSums(modCache, path, version, Sums{Sum: "h1:x=", GoModSum: "h1:y="}); !errors.Is(err, ErrNotCached) {
+		t.Fatalf("empty cache: %v, want ErrNotCached", err)
+	}
+
+	for _, d := range []string{download, dir} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f, err := os.Create(filepath.Join(download, version+".zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zw := zip.NewWriter(f)
+
+	for name, content := range files {
+		w, err := zw.Create(path + "@" + version + "/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, _ = w.Write([]byte(content))
+
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	_ = f.Close()
+
+	if err := os.WriteFile(filepath.Join(download, version+".mod"), []byte(files["go.mod"]), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sum, err := dirhash.HashDir(dir, path+"@"+version, dirhash.Hash1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	goModSum, err := dirhash.Hash1([]string{"go.mod"}, func(string) (io.ReadCloser, error) {
+		return os.Open(filepath.Join(download, version+".mod"))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorded := Sums{Sum: sum, GoModSum: goModSum}
+
+	if err := VerifyCachedSums(modCache, path, version, recorded); err != nil {
+		t.Fatalf("untouched cache: %v", err)
+	}
+
+	if err := VerifyCachedSums(modCache, path, version, Sums{Sum: sum, GoModSum: "h1:other="}); err == nil || !strings.Contains(err.Error(), ".mod") {
+		t.Errorf("other go.mod hash: %v, want a go.mod mismatch", err)
+	}
+
+	// Source patched after the download
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() { evil() }\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := VerifyCachedSums(modCache, path, version, recorded); err == nil || !strings.Contains(err.Error(), dir) {
+		t.Errorf("patched source: %v, want a mismatch of %s", err, dir)
+	}
+}
@@ -0,0 +1,223 @@
+package module
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	osExec "os/exec"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// ToolchainError is returned before building a module that needs cgo when
+// cgo cannot build it: no C compiler is installed, cgo is disabled, or the
+// install cross-compiles
+type ToolchainError struct {
+	Module   string
+	Packages []string // Packages needing cgo, of the module and its dependencies
+	Reason   string   // Why cgo cannot build them
+	Remedy   string   // What to do about it
+}
+
+func (e *ToolchainError) Error() string {
+	return fmt.Sprintf("%s needs cgo to build %s, but %s; %s", e.Module, describePackages(e.Packages), e.Reason, e.Remedy)
+}
+
+// describePackages lists the first few packages, counting the others
+func describePackages(pkgs []string) string {
+	const shown = 3
+
+	if len(pkgs) <= shown {
+		return strings.Join(pkgs, ", ")
+	}
+
+	return fmt.Sprintf("%s and %d more", strings.Join(pkgs[:shown], ", "), len(pkgs)-shown)
+}
+
+// cgoSettings is what go env says about cgo for the install
+type cgoSettings struct {
+	Enabled string `json:"CGO_ENABLED"`
+	CC      string `json:"CC"`
+}
+
+// checkToolchain fails before go install builds pkg in dir when the build
+// needs cgo and cgo cannot build it, saying which packages need it and
+// what to do, instead of leaving it to the compiler errors. When the check
+// itself fails the build goes ahead.
+func (m *Module) checkToolchain(ctx context.Context, dir, pkg string) error {
+	env := m.goEnv(m.platformEnv()...)
+
+	cmd := m.goCommand(ctx, "env", "-json", "CGO_ENABLED", "CC")
+	cmd.Dir = dir
+	cmd.Env = env
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+
+	var settings cgoSettings
+	if err := json.Unmarshal(out, &settings); err != nil {
+		return nil
+	}
+
+	compiler := "gcc"
+	if fields := strings.Fields(settings.CC); len(fields) > 0 {
+		compiler = fields[0]
+	}
+
+	_, lookErr := osExec.LookPath(compiler)
+	haveCompiler := lookErr == nil
+
+	if settings.Enabled == "1" && haveCompiler {
+		return nil
+	}
+
+	toolchainErr := &ToolchainError{Module: m.Name}
+
+	switch {
+	case settings.Enabled == "1":
+		// Every package with cgo files, standard ones included, is built
+		// with the missing compiler
+		toolchainErr.Packages = m.cgoPackages(ctx, dir, pkg, env, false)
+		toolchainErr.Reason = fmt.Sprintf("its C compiler %s is not installed", compiler)
+		toolchainErr.Remedy = installCompiler() + ", or set CGO_ENABLED=0 if it builds without cgo"
+	case m.crossCompiling():
+		toolchainErr.Packages = m.cgoPackages(ctx, dir, pkg, env, true)
+		toolchainErr.Reason = fmt.Sprintf("cgo is disabled when cross-compiling to %s/%s", m.goos(), m.goarch())
+		toolchainErr.Remedy = fmt.Sprintf("set CGO_ENABLED=1 and CC to a C cross compiler for %s/%s, or install it on a %s/%s machine", m.goos(), m.goarch(), m.goos(), m.goarch())
+	case lookupEnv(env, "CGO_ENABLED") == "0":
+		toolchainErr.Packages = m.cgoPackages(ctx, dir, pkg, env, true)
+		toolchainErr.Reason = "CGO_ENABLED=0 disables cgo"
+		toolchainErr.Remedy = "unset CGO_ENABLED"
+
+		if !haveCompiler {
+			toolchainErr.Remedy += " and " + installCompiler()
+		}
+	default:
+		// go disables cgo by default when the compiler is missing
+		toolchainErr.Packages = m.cgoPackages(ctx, dir, pkg, env, true)
+		toolchainErr.Reason = fmt.Sprintf("no C compiler is installed (go looks for %s)", compiler)
+		toolchainErr.Remedy = installCompiler()
+	}
+
+	if len(toolchainErr.Packages) == 0 {
+		return nil
+	}
+
+	if !m.preferBinary {
+		toolchainErr.Remedy += ", or install its prebuilt release binary with --prefer-binary"
+	}
+
+	return toolchainErr
+}
+
+// cgoPackages returns the packages pkg builds with that have cgo files,
+// standard ones included, or with only and excluding standard ones those
+// that have nothing but cgo files, which fail to build without cgo. Other
+// packages with cgo files build without it. Packages go cannot list are
+// left out.
+func (m *Module) cgoPackages(ctx context.Context, dir, pkg string, env []string, only bool) []string {
+	args := append(append([]string{"list", "-e", "-deps", "-json=ImportPath,Standard,CgoFiles,Error"}, m.buildFlags.Args()...), pkg)
+
+	if !only {
+		var pkgs []string
+
+		for _, p := range m.listPackages(ctx, dir, env, args) {
+			if len(p.CgoFiles) > 0 {
+				pkgs = append(pkgs, p.ImportPath)
+			}
+		}
+
+		return pkgs
+	}
+
+	// Without cgo, files importing "C" are excluded by build constraints
+	var excluded []string
+
+	for _, p := range m.listPackages(ctx, dir, env, args) {
+		if !p.Standard && p.Error != nil && strings.Contains(p.Error.Err, "build constraints exclude all Go files") {
+			excluded = append(excluded, p.ImportPath)
+		}
+	}
+
+	if len(excluded) == 0 {
+		return nil
+	}
+
+	// Other constraints than cgo may exclude them, such as the platform
+	args = append(append([]string{"list", "-e", "-json=ImportPath,CgoFiles"}, m.buildFlags.Args()...), excluded...)
+
+	var pkgs []string
+
+	for _, p := range m.listPackages(ctx, dir, append(slices.Clone(env), "CGO_ENABLED=1"), args) {
+		if len(p.CgoFiles) > 0 {
+			pkgs = append(pkgs, p.ImportPath)
+		}
+	}
+
+	return pkgs
+}
+
+// listPackages runs go list with args in dir, returning the packages it
+// printed, or none when it fails
+func (m *Module) listPackages(ctx context.Context, dir string, env []string, args []string) []GoListPackage {
+	cmd := m.goCommand(ctx, args...)
+	cmd.Dir = dir
+	cmd.Env = env
+
+	var out bytes.Buffer
+
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		return nil
+	}
+
+	var pkgs []GoListPackage
+
+	dec := json.NewDecoder(&out)
+
+	for {
+		var p GoListPackage
+		if err := dec.Decode(&p); err != nil {
+			if !errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return pkgs
+		}
+
+		pkgs = append(pkgs, p)
+	}
+}
+
+// lookupEnv returns the value of name in env, the last one set winning as
+// it does for commands
+func lookupEnv(env []string, name string) string {
+	for _, kv := range slices.Backward(env) {
+		if value, ok := strings.CutPrefix(kv, name+"="); ok {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// installCompiler says how to install a C compiler for cgo on this system
+func installCompiler() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "install the Xcode command line tools with 'xcode-select --install'"
+	case "windows":
+		return "install gcc from MinGW-w64, e.g. with MSYS2's 'pacman -S mingw-w64-ucrt-x86_64-gcc' (MSVC cannot build cgo)"
+	case "linux":
+		return "install gcc, e.g. with 'sudo apt install gcc' or 'sudo dnf install gcc'"
+	default:
+		return "install gcc or clang"
+	}
+}
@@ -0,0 +1,114 @@
+package module
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCheckToolchain(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod":          "module example.com/cgotool\n\ngo 1.21\n",
+		"main.go":         "package main\n\nimport _ \"example.com/cgotool/native\"\n\nfunc main() {}\n",
+		"native/c.go":     "package native\n\n// int answer() { return 42; }\nimport \"C\"\n",
+		"pure/main.go":    "package main\n\nfunc main() {}\n",
+		"mixed/main.go":   "package main\n\nimport _ \"example.com/cgotool/mixed/lib\"\n\nfunc main() {}\n",
+		"mixed/lib/c.go":  "package lib\n\nimport \"C\"\n",
+		"mixed/lib/go.go": "//go:build !cgo\n\npackage lib\n",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m, err := NewModule(context.Background(), "go", dir)
+	if err != nil {
+		t.Skipf("go unavailable: %v", err)
+	}
+
+	m.Name = "example.com/cgotool"
+
+	t.Setenv("CGO_ENABLED", "0")
+
+	err = m.checkToolchain(context.Background(), dir, "example.com/cgotool")
+
+	var toolchainErr *ToolchainError
+	if !errors.As(err, &toolchainErr) {
+		t.Fatalf("checkToolchain() with CGO_ENABLED=0 = %v, want a ToolchainError", err)
+	}
+
+	if !slices.Equal(toolchainErr.Packages, []string{"example.com/cgotool/native"}) {
+		t.Errorf("Packages = %v, want the cgo-only package", toolchainErr.Packages)
+	}
+
+	if !strings.Contains(err.Error(), "CGO_ENABLED=0 disables cgo") {
+		t.Errorf("Error() = %q, want the reason", err)
+	}
+
+	// Packages that also build without cgo are fine
+	for _, pkg := range []string{"example.com/cgotool/pure", "example.com/cgotool/mixed"} {
+		if err := m.checkToolchain(context.Background(), dir, pkg); err != nil {
+			t.Errorf("checkToolchain(%s) = %v, want nil", pkg, err)
+		}
+	}
+
+	// A missing compiler fails every cgo package, runtime/cgo included
+	t.Setenv("CGO_ENABLED", "1")
+	t.Setenv("CC", "glix-missing-cc")
+
+	err = m.checkToolchain(context.Background(), dir, "example.com/cgotool/mixed")
+	if !errors.As(err, &toolchainErr) {
+		t.Fatalf("checkToolchain() with a missing CC = %v, want a ToolchainError", err)
+	}
+
+	if !slices.Contains(toolchainErr.Packages, "example.com/cgotool/mixed/lib") {
+		t.Errorf("Packages = %v, want the package with cgo files", toolchainErr.Packages)
+	}
+
+	if !strings.Contains(toolchainErr.Reason, "glix-missing-cc") {
+		t.Errorf("Reason = %q, want the compiler", toolchainErr.Reason)
+	}
+}
+
+func TestToolchainError(t *testing.T) {
+	err := &ToolchainError{
+		Module:   "example.com/tool",
+		Packages: []string{"a", "b", "c", "d", "e"},
+		Reason:   "CGO_ENABLED=0 disables cgo",
+		Remedy:   "unset CGO_ENABLED",
+	}
+
+	want := "example.com/tool needs cgo to build a, b, c and 2 more, but CGO_ENABLED=0 disables cgo; unset CGO_ENABLED"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	if got := describePackages([]string{"a", "b"}); got != "a, b" {
+		t.Errorf("describePackages() = %q, want a, b", got)
+	}
+}
+
+func TestLookupEnv(t *testing.T) {
+	env := []string{"CGO_ENABLED=1", "CC=gcc", "CGO_ENABLED=0"}
+
+	if got := lookupEnv(env, "CGO_ENABLED"); got != "0" {
+		t.Errorf("lookupEnv(CGO_ENABLED) = %q, want the last value 0", got)
+	}
+
+	if got := lookupEnv(env, "CGO"); got != "" {
+		t.Errorf("lookupEnv(CGO) = %q, want none", got)
+	}
+}
@@ -0,0 +1,29 @@
+package module
+
+import (
+	"debug/buildinfo"
+	"fmt"
+
+	"golang.org/x/mod/semver"
+)
+
+// ReplacedBy describes what an installed binary was built from when it is
+// not a build of the module name at version, and returns "" when it is.
+// Local, GoReleaser, make and mage builds record no module version, so only
+// the module is compared for them.
+func ReplacedBy(binPath, name, version string) string {
+	info, err := buildinfo.ReadFile(binPath)
+	if err != nil {
+		return "not a Go binary"
+	}
+
+	if info.Path != name && info.Main.Path != name {
+		return fmt.Sprintf("built from %s", info.Path)
+	}
+
+	if semver.IsValid(info.Main.Version) && semver.IsValid(version) && info.Main.Version != version {
+		return fmt.Sprintf("built from %s@%s", info.Path, info.Main.Version)
+	}
+
+	return ""
+}
@@ -0,0 +1,32 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReplacedBy(t *testing.T) {
+	// The test binary is a Go binary of this module
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := ReplacedBy(exe, "github.com/inovacc/glix", ""); got != "" {
+		t.Errorf("ReplacedBy(own module) = %q, want empty", got)
+	}
+
+	if got := ReplacedBy(exe, "example.com/other/cmd/other", "v1.0.0"); got == "" {
+		t.Error("a binary of another module should be reported as replaced")
+	}
+
+	script := filepath.Join(t.TempDir(), "tool")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := ReplacedBy(script, "example.com/tool", "v1.0.0"); got != "not a Go binary" {
+		t.Errorf("ReplacedBy(script) = %q, want %q", got, "not a Go binary")
+	}
+}
@@ -0,0 +1,157 @@
+package module
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+// DefaultVersionCacheTTL is how long the versions of a module are reused
+// before the module proxy is queried again
+const DefaultVersionCacheTTL = 10 * time.Minute
+
+// VersionCacheTTL returns the version cache TTL set with
+// GLIX_VERSION_CACHE_TTL, such as "1h" or "0" to always query the module
+// proxy, or DefaultVersionCacheTTL
+func VersionCacheTTL() time.Duration {
+	if d, err := time.ParseDuration(strings.TrimSpace(os.Getenv("GLIX_VERSION_CACHE_TTL"))); err == nil && d >= 0 {
+		return d
+	}
+
+	return DefaultVersionCacheTTL
+}
+
+// VersionStore keeps go list -m -versions answers across processes, the
+// daemon's database behind its API
+type VersionStore interface {
+	// GetVersionCache returns the cached answer for a module path, or nil
+	GetVersionCache(ctx context.Context, path string) (*pb.VersionCacheProto, error)
+	StoreVersionCache(ctx context.Context, entry *pb.VersionCacheProto) error
+}
+
+// SetVersionStore makes the module share the versions it fetches through
+// store, besides the in-memory cache of the process
+func (m *Module) SetVersionStore(store VersionStore) {
+	m.versionStore = store
+}
+
+// versionCache is the in-memory layer of the version cache, shared by the
+// modules of a process
+var versionCache = struct {
+	sync.Mutex
+	entries map[string]*pb.VersionCacheProto
+}{entries: make(map[string]*pb.VersionCacheProto)}
+
+// cachedVersions returns the versions of a module path fetched within the
+// TTL, from memory or else the version store. Offline modules resolve from
+// the module cache instead and are never served from the version cache, and
+// refreshes query the proxy to renew it.
+func (m *Module) cachedVersions(ctx context.Context, path string) *fetchModuleVersionsResult {
+	ttl := VersionCacheTTL()
+	if ttl <= 0 || m.offline || m.refreshing {
+		return nil
+	}
+
+	fresh := func(e *pb.VersionCacheProto) bool {
+		return e != nil && time.Since(time.Unix(0, e.GetFetchedUnixNano())) < ttl
+	}
+
+	versionCache.Lock()
+	entry := versionCache.entries[path]
+	versionCache.Unlock()
+
+	if !fresh(entry) && m.versionStore != nil {
+		stored, err := m.versionStore.GetVersionCache(ctx, path)
+		if err != nil || !fresh(stored) {
+			return nil
+		}
+
+		entry = stored
+
+		versionCache.Lock()
+		versionCache.entries[path] = entry
+		versionCache.Unlock()
+	}
+
+	if !fresh(entry) {
+		return nil
+	}
+
+	return &fetchModuleVersionsResult{
+		ListResp: &ListResp{
+			Time:     time.Unix(0, entry.GetTimeUnixNano()),
+			Path:     entry.GetRootModule(),
+			Version:  entry.GetVersion(),
+			Versions: slices.Clone(entry.GetVersions()),
+		},
+		RootModule: entry.GetRootModule(),
+	}
+}
+
+// cacheVersions keeps the versions fetched for a module path in memory and
+// in the version store
+func (m *Module) cacheVersions(ctx context.Context, path string, result *fetchModuleVersionsResult) {
+	if VersionCacheTTL() <= 0 || m.offline {
+		return
+	}
+
+	entry := &pb.VersionCacheProto{
+		Path:            path,
+		RootModule:      result.RootModule,
+		Version:         result.ListResp.Version,
+		Versions:        slices.Clone(result.ListResp.Versions),
+		TimeUnixNano:    result.ListResp.Time.UnixNano(),
+		FetchedUnixNano: time.Now().UnixNano(),
+	}
+
+	versionCache.Lock()
+	versionCache.entries[path] = entry
+	versionCache.Unlock()
+
+	// The cache only saves queries; failing to share an answer costs one
+	if m.versionStore != nil {
+		_ = m.versionStore.StoreVersionCache(ctx, entry)
+	}
+}
+
+// remoteVersionStore is the version cache of a daemon
+type remoteVersionStore struct {
+	client pb.GlixServiceClient
+}
+
+// RemoteVersionStore returns the version cache of the daemon behind client
+func RemoteVersionStore(client pb.GlixServiceClient) VersionStore {
+	return remoteVersionStore{client: client}
+}
+
+func (r remoteVersionStore) GetVersionCache(ctx context.Context, path string) (*pb.VersionCacheProto, error) {
+	resp, err := r.client.GetVersionCache(ctx, &pb.GetVersionCacheRequest{Path: path})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.GetErrorMessage() != "" {
+		return nil, errors.New(resp.GetErrorMessage())
+	}
+
+	return resp.GetEntry(), nil
+}
+
+func (r remoteVersionStore) StoreVersionCache(ctx context.Context, entry *pb.VersionCacheProto) error {
+	resp, err := r.client.StoreVersionCache(ctx, &pb.StoreVersionCacheRequest{Entry: entry})
+	if err != nil {
+		return err
+	}
+
+	if !resp.GetSuccess() {
+		return errors.New(resp.GetErrorMessage())
+	}
+
+	return nil
+}
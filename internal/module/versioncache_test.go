@@ -0,0 +1,122 @@
+package module
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+// memoryVersionStore is a version store kept in a map
+type memoryVersionStore map[string]*pb.VersionCacheProto
+
+func (s memoryVersionStore) GetVersionCache(_ context.Context, path string) (*pb.VersionCacheProto, error) {
+	return s[path], nil
+}
+
+func (s memoryVersionStore) StoreVersionCache(_ context.Context, entry *pb.VersionCacheProto) error {
+	s[entry.GetPath()] = entry
+	return nil
+}
+
+// resetVersionCache empties the in-memory version cache for the test
+func resetVersionCache(t *testing.T) {
+	t.Helper()
+
+	clear := func() {
+		versionCache.Lock()
+		versionCache.entries = make(map[string]*pb.VersionCacheProto)
+		versionCache.Unlock()
+	}
+
+	clear()
+	t.Cleanup(clear)
+}
+
+func TestVersionCache(t *testing.T) {
+	resetVersionCache(t)
+	t.Setenv("GLIX_VERSION_CACHE_TTL", "")
+
+	ctx := context.Background()
+	store := memoryVersionStore{}
+
+	m := &Module{}
+	m.SetVersionStore(store)
+
+	if got := m.cachedVersions(ctx, "example.com/tool/cmd/tool"); got != nil {
+		t.Fatalf("empty cache returned %+v", got)
+	}
+
+	m.cacheVersions(ctx, "example.com/tool/cmd/tool", &fetchModuleVersionsResult{
+		ListResp:   &ListResp{Path: "example.com/tool", Version: "v1.1.0", Versions: []string{"v1.1.0", "v1.0.0"}},
+		RootModule: "example.com/tool",
+	})
+
+	if store["example.com/tool/cmd/tool"] == nil {
+		t.Fatal("versions not shared through the store")
+	}
+
+	got := m.cachedVersions(ctx, "example.com/tool/cmd/tool")
+	if got == nil || got.RootModule != "example.com/tool" || !slices.Equal(got.ListResp.Versions, []string{"v1.1.0", "v1.0.0"}) {
+		t.Fatalf("cachedVersions = %+v, want the cached answer", got)
+	}
+
+	// Another process only has the store
+	resetVersionCache(t)
+
+	other := &Module{}
+	if got := other.cachedVersions(ctx, "example.com/tool/cmd/tool"); got != nil {
+		t.Error("module without a store found versions cached by another process")
+	}
+
+	other.SetVersionStore(store)
+
+	if got := other.cachedVersions(ctx, "example.com/tool/cmd/tool"); got == nil || got.ListResp.Version != "v1.1.0" {
+		t.Errorf("cachedVersions from the store = %+v", got)
+	}
+
+	// Expired answers are queried again
+	store["example.com/tool/cmd/tool"].FetchedUnixNano = time.Now().Add(-2 * DefaultVersionCacheTTL).UnixNano()
+	resetVersionCache(t)
+
+	if got := other.cachedVersions(ctx, "example.com/tool/cmd/tool"); got != nil {
+		t.Errorf("expired answer served: %+v", got)
+	}
+
+	// Offline modules resolve from the module cache
+	m.cacheVersions(ctx, "example.com/tool/cmd/tool", &fetchModuleVersionsResult{ListResp: &ListResp{Version: "v1.2.0"}})
+	m.SetOffline(true)
+
+	if got := m.cachedVersions(ctx, "example.com/tool/cmd/tool"); got != nil {
+		t.Error("offline module served from the version cache")
+	}
+
+	m.SetOffline(false)
+
+	t.Setenv("GLIX_VERSION_CACHE_TTL", "0")
+
+	if got := m.cachedVersions(ctx, "example.com/tool/cmd/tool"); got != nil {
+		t.Error("version cache used with a TTL of 0")
+	}
+}
+
+func TestVersionCacheTTL(t *testing.T) {
+	for _, tt := range []struct {
+		env  string
+		want time.Duration
+	}{
+		{"", DefaultVersionCacheTTL},
+		{"1h", time.Hour},
+		{"0", 0},
+		{"-5m", DefaultVersionCacheTTL},
+		{"soon", DefaultVersionCacheTTL},
+	} {
+		t.Setenv("GLIX_VERSION_CACHE_TTL", tt.env)
+
+		if got := VersionCacheTTL(); got != tt.want {
+			t.Errorf("VersionCacheTTL(%q) = %s, want %s", tt.env, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package module
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/inovacc/glix/internal/modver"
+)
+
+// VersionRange is a caret (^1.2) or tilde (~1.4) range of releases, as
+// installs may request instead of a version
+type VersionRange struct {
+	Min string // Lowest allowed version
+	Max string // First version beyond the range
+}
+
+// IsVersionRange reports whether version is a caret or tilde range rather
+// than a version or query
+func IsVersionRange(version string) bool {
+	return strings.HasPrefix(version, "^") || strings.HasPrefix(version, "~")
+}
+
+// ParseVersionRange parses a caret or tilde range. ^1.2 allows v1.2.0 up to
+// v2.0.0, and ^0.3 v0.3.0 up to v0.4.0, as releases before v1 may break
+// their API with every minor version. ~1.4 allows v1.4.0 up to v1.5.0, and
+// ~1 v1.0.0 up to v2.0.0.
+func ParseVersionRange(s string) (VersionRange, error) {
+	if !IsVersionRange(s) {
+		return VersionRange{}, fmt.Errorf("invalid version range %q: want ^<version> or ~<version>", s)
+	}
+
+	fields := strings.Split(strings.TrimPrefix(s[1:], "v"), ".")
+	if len(fields) > 3 {
+		return VersionRange{}, fmt.Errorf("invalid version range %q: want at most major.minor.patch", s)
+	}
+
+	parts := make([]int, 3)
+
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 || strings.HasPrefix(f, "+") {
+			return VersionRange{}, fmt.Errorf("invalid version range %q: %q is not a number", s, f)
+		}
+
+		parts[i] = n
+	}
+
+	// The part of the version the range allows to grow past
+	bump := 0
+
+	if s[0] == '^' {
+		// The leftmost non-zero part, or the last one given for ^0 and ^0.0
+		bump = len(fields) - 1
+
+		for i := range fields {
+			if parts[i] != 0 {
+				bump = i
+				break
+			}
+		}
+	} else if len(fields) > 1 {
+		bump = 1
+	}
+
+	upper := make([]int, 3)
+	copy(upper, parts[:bump])
+	upper[bump] = parts[bump] + 1
+
+	return VersionRange{Min: versionString(parts), Max: versionString(upper)}, nil
+}
+
+func versionString(parts []int) string {
+	return fmt.Sprintf("v%d.%d.%d", parts[0], parts[1], parts[2])
+}
+
+// Allows reports whether v is a release in the range; pre-releases and
+// pseudo-versions never are
+func (r VersionRange) Allows(v string) bool {
+	if !modver.IsRelease(v) {
+		return false
+	}
+
+	return modver.Compare(v, r.Min) >= 0 && modver.Compare(v, r.Max) < 0
+}
+
+// Newest returns the newest version the range allows, "" when none does
+func (r VersionRange) Newest(versions []string) string {
+	return modver.Newest(versions, r.Allows)
+}
+
+// SetConstraint keeps installs of the module within a version range, as
+// recorded for an install; "" allows any version
+func (m *Module) SetConstraint(constraint string) {
+	m.constraint = constraint
+}
+
+// Constraint returns the version range installs of the module are kept
+// within, "" when there is none
+func (m *Module) Constraint() string {
+	return m.constraint
+}
+
+// SetPrerelease makes installs of the latest version pick a pre-release,
+// such as v2.0.0-rc.1, when it is newer than the latest release, and lets
+// updates move to one, as recorded for an install. Ranges never match
+// pre-releases.
+func (m *Module) SetPrerelease(prerelease bool) {
+	m.prerelease = prerelease
+}
+
+// Prerelease reports whether installs of the module pick pre-releases
+func (m *Module) Prerelease() bool {
+	return m.prerelease
+}
+
+// UpdatePolicy returns which versions may replace an installed version of
+// the module
+func (m *Module) UpdatePolicy() modver.Policy {
+	return modver.Policy{Prereleases: m.prerelease}
+}
+
+// Allows reports whether the version range of the module allows v
+func (m *Module) Allows(v string) bool {
+	return InRange(m.constraint, v)
+}
+
+// InRange reports whether a version range as recorded for an install allows
+// v; an empty range allows any version
+func InRange(constraint, v string) bool {
+	if constraint == "" {
+		return true
+	}
+
+	r, err := ParseVersionRange(constraint)
+
+	return err == nil && r.Allows(v)
+}
@@ -0,0 +1,124 @@
+package module
+
+import "testing"
+
+func TestParseVersionRange(t *testing.T) {
+	tests := []struct {
+		in       string
+		min, max string
+	}{
+		{"^1.2", "v1.2.0", "v2.0.0"},
+		{"^1.2.3", "v1.2.3", "v2.0.0"},
+		{"^v1", "v1.0.0", "v2.0.0"},
+		{"^0.3", "v0.3.0", "v0.4.0"},
+		{"^0.3.1", "v0.3.1", "v0.4.0"},
+		{"^0.0.3", "v0.0.3", "v0.0.4"},
+		{"^0.0", "v0.0.0", "v0.1.0"},
+		{"^0", "v0.0.0", "v1.0.0"},
+		{"~1.4", "v1.4.0", "v1.5.0"},
+		{"~1.4.2", "v1.4.2", "v1.5.0"},
+		{"~1", "v1.0.0", "v2.0.0"},
+		{"~0.3", "v0.3.0", "v0.4.0"},
+	}
+
+	for _, tt := range tests {
+		r, err := ParseVersionRange(tt.in)
+		if err != nil {
+			t.Errorf("ParseVersionRange(%q) error: %v", tt.in, err)
+			continue
+		}
+
+		if r.Min != tt.min || r.Max != tt.max {
+			t.Errorf("ParseVersionRange(%q) = [%s, %s), want [%s, %s)", tt.in, r.Min, r.Max, tt.min, tt.max)
+		}
+	}
+
+	for _, in := range []string{"1.2", "v1.2", "latest", "^", "~", "^1.x", "^1.2.3.4", "^-1", "^1.2.3-rc.1"} {
+		if _, err := ParseVersionRange(in); err == nil {
+			t.Errorf("ParseVersionRange(%q) succeeded, want error", in)
+		}
+	}
+}
+
+func TestVersionRangeNewest(t *testing.T) {
+	versions := []string{"v2.1.0", "v2.0.0", "v1.5.0-rc.1", "v1.4.3", "v1.4.0", "v1.2.0", "v0.4.1", "v0.3.2", "v0.3.0"}
+
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{"^1.2", "v1.4.3"},
+		{"^1.4.1", "v1.4.3"},
+		{"~1.4", "v1.4.3"},
+		{"^0.3", "v0.3.2"},
+		{"^2", "v2.1.0"},
+		{"^1.5", ""},
+		{"^3", ""},
+	}
+
+	for _, tt := range tests {
+		r, err := ParseVersionRange(tt.constraint)
+		if err != nil {
+			t.Fatalf("ParseVersionRange(%q) error: %v", tt.constraint, err)
+		}
+
+		if got := r.Newest(versions); got != tt.want {
+			t.Errorf("%s: Newest() = %q, want %q", tt.constraint, got, tt.want)
+		}
+	}
+}
+
+func TestVersionRangeAllows(t *testing.T) {
+	r, err := ParseVersionRange("^1.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for v, want := range map[string]bool{
+		"v1.2.0":                               true,
+		"v1.9.9":                               true,
+		"v1.1.9":                               false,
+		"v2.0.0":                               false,
+		"v1.3.0-rc.1":                          false,
+		"v1.3.1-0.20240101000000-abcdefabcdef": false,
+		"main":                                 false,
+	} {
+		if got := r.Allows(v); got != want {
+			t.Errorf("^1.2 allows %s = %v, want %v", v, got, want)
+		}
+	}
+}
+
+func TestPickVersionRange(t *testing.T) {
+	m := &Module{}
+	versions := []string{"v2.0.0", "v1.3.0", "v1.2.0"}
+
+	if got := m.pickVersion("^1.2", versions); got != "v1.3.0" {
+		t.Errorf("pickVersion(^1.2) = %q, want v1.3.0", got)
+	}
+
+	if got := m.pickVersion("~1.2", versions); got != "v1.2.0" {
+		t.Errorf("pickVersion(~1.2) = %q, want v1.2.0", got)
+	}
+
+	if got := m.pickVersion("^1.x", versions); got != "" {
+		t.Errorf("pickVersion(^1.x) = %q, want none", got)
+	}
+}
+
+func TestModuleAllows(t *testing.T) {
+	m := &Module{}
+	if !m.Allows("v9.0.0") {
+		t.Error("a module without a range should allow any version")
+	}
+
+	m.SetConstraint("~1.4")
+
+	if !m.Allows("v1.4.7") || m.Allows("v1.5.0") {
+		t.Error("~1.4 should allow v1.4.7 but not v1.5.0")
+	}
+
+	if InRange("^bad", "v1.0.0") {
+		t.Error("an invalid range should allow nothing")
+	}
+}
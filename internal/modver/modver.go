@@ -0,0 +1,109 @@
+// Package modver compares module versions the way the go command orders
+// them, so every command agrees on whether a version is an update.
+//
+// Versions are semantic versions. Pseudo-versions of untagged commits are
+// pre-releases of the version after their base, ordered by commit time, and
+// build metadata such as +incompatible is ignored. Versions recorded without
+// their "v" prefix compare as if they had it; invalid versions, such as
+// (devel), are older than any valid one.
+package modver
+
+import (
+	"strings"
+
+	modpath "golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// normalize adds the "v" prefix versions are sometimes recorded without
+func normalize(v string) string {
+	if v != "" && !strings.HasPrefix(v, "v") {
+		return "v" + v
+	}
+
+	return v
+}
+
+// Compare returns -1, 0 or +1 as a is older than, the same as or newer than
+// b. Invalid versions are equal to each other.
+func Compare(a, b string) int {
+	return semver.Compare(normalize(a), normalize(b))
+}
+
+// Newer reports whether candidate is newer than current
+func Newer(candidate, current string) bool {
+	return Compare(candidate, current) > 0
+}
+
+// IsValid reports whether v is a semantic version
+func IsValid(v string) bool {
+	return semver.IsValid(normalize(v))
+}
+
+// IsPseudo reports whether v is the pseudo-version of an untagged commit
+func IsPseudo(v string) bool {
+	return modpath.IsPseudoVersion(normalize(v))
+}
+
+// IsPrerelease reports whether v is a pre-release such as v2.0.0-rc.1.
+// Pseudo-versions are not counted as pre-releases.
+func IsPrerelease(v string) bool {
+	v = normalize(v)
+	return semver.Prerelease(v) != "" && !modpath.IsPseudoVersion(v)
+}
+
+// IsRelease reports whether v is a release: a valid version that is neither
+// a pre-release nor a pseudo-version
+func IsRelease(v string) bool {
+	v = normalize(v)
+	return semver.IsValid(v) && semver.Prerelease(v) == ""
+}
+
+// Newest returns the newest valid version accepted by keep (any when keep is
+// nil), or "" when there is none
+func Newest(versions []string, keep func(version string) bool) string {
+	var newest string
+
+	for _, v := range versions {
+		if !IsValid(v) || (keep != nil && !keep(v)) {
+			continue
+		}
+
+		if newest == "" || Newer(v, newest) {
+			newest = v
+		}
+	}
+
+	return newest
+}
+
+// Policy decides which versions may replace an installed version
+type Policy struct {
+	Prereleases bool // Accept pre-releases such as v2.0.0-rc.1 over releases
+}
+
+// Default is the policy of modules that did not opt into pre-releases
+var Default = Policy{}
+
+// Allows reports whether v may replace current. Releases always may.
+// Pre-releases may with Prereleases, or replace a pre-release the user
+// already opted into. Pseudo-versions only replace pseudo-versions, as for
+// modules without tags. Any valid version replaces an invalid one.
+func (p Policy) Allows(v, current string) bool {
+	switch {
+	case !IsValid(v):
+		return false
+	case IsRelease(v), !IsValid(current):
+		return true
+	case IsPseudo(v):
+		return IsPseudo(current)
+	default:
+		return p.Prereleases || IsPrerelease(current)
+	}
+}
+
+// IsUpdate reports whether candidate is newer than current and allowed to
+// replace it
+func (p Policy) IsUpdate(candidate, current string) bool {
+	return Newer(candidate, current) && p.Allows(candidate, current)
+}
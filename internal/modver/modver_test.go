@@ -0,0 +1,107 @@
+package modver
+
+import "testing"
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.10.0", "v1.9.0", 1},
+		{"1.2.0", "v1.2.0", 0},
+		{"v1.2.0", "v1.2.0-rc.1", 1},
+		{"v1.2.0-rc.2", "v1.2.0-rc.1", 1},
+		{"v2.0.0+incompatible", "v2.0.0", 0},
+		{"v2.0.0+incompatible", "v1.9.0", 1},
+		// Pseudo-versions sort by commit time, before the version after their base
+		{"v0.0.0-20260108194045-146fb9cee2cb", "v0.0.0-20251231000000-0123456789ab", 1},
+		{"v1.2.1-0.20250601000000-0123456789ab", "v1.2.0", 1},
+		{"v1.2.1-0.20250601000000-0123456789ab", "v1.2.1", -1},
+		{"v0.1.0", "(devel)", 1},
+		{"(devel)", "", 0},
+	}
+
+	for _, tt := range tests {
+		if got := Compare(tt.a, tt.b); got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+
+		if got := Compare(tt.b, tt.a); got != -tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
+
+func TestKinds(t *testing.T) {
+	tests := []struct {
+		v                           string
+		release, prerelease, pseudo bool
+	}{
+		{"v1.2.0", true, false, false},
+		{"1.2.0", true, false, false},
+		{"v2.0.0+incompatible", true, false, false},
+		{"v2.0.0-rc.1", false, true, false},
+		{"v0.0.0-20260108194045-146fb9cee2cb", false, false, true},
+		{"v1.2.1-0.20250601000000-0123456789ab", false, false, true},
+		{"(devel)", false, false, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsRelease(tt.v); got != tt.release {
+			t.Errorf("IsRelease(%q) = %v", tt.v, got)
+		}
+
+		if got := IsPrerelease(tt.v); got != tt.prerelease {
+			t.Errorf("IsPrerelease(%q) = %v", tt.v, got)
+		}
+
+		if got := IsPseudo(tt.v); got != tt.pseudo {
+			t.Errorf("IsPseudo(%q) = %v", tt.v, got)
+		}
+	}
+}
+
+func TestNewest(t *testing.T) {
+	versions := []string{"v1.9.0", "v1.10.0", "v1.11.0-rc.1", "bogus"}
+
+	if got := Newest(versions, nil); got != "v1.11.0-rc.1" {
+		t.Errorf("Newest = %q, want v1.11.0-rc.1", got)
+	}
+
+	if got := Newest(versions, IsRelease); got != "v1.10.0" {
+		t.Errorf("Newest release = %q, want v1.10.0", got)
+	}
+
+	if got := Newest(nil, nil); got != "" {
+		t.Errorf("Newest of no versions = %q", got)
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	const pseudo = "v0.0.0-20260108194045-146fb9cee2cb"
+
+	tests := []struct {
+		policy             Policy
+		candidate, current string
+		want               bool
+	}{
+		{Policy{}, "v1.10.0", "v1.9.0", true},
+		{Policy{}, "v1.9.0", "v1.9.0", false},
+		{Policy{}, "v2.0.0-rc.1", "v1.9.0", false},
+		{Policy{Prereleases: true}, "v2.0.0-rc.1", "v1.9.0", true},
+		{Policy{}, "v2.0.0-rc.2", "v2.0.0-rc.1", true},
+		{Policy{}, "v2.0.0", "v2.0.0-rc.1", true},
+		{Policy{}, pseudo, "v0.0.0-20251231000000-0123456789ab", true},
+		{Policy{}, "v1.2.1-0.20250601000000-0123456789ab", "v1.2.0", false},
+		{Policy{Prereleases: true}, "v1.2.1-0.20250601000000-0123456789ab", "v1.2.0", false},
+		{Policy{}, "v0.1.0", pseudo, true},
+		{Policy{}, "v0.1.0", "", true},
+		{Policy{}, "(devel)", "v0.1.0", false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.policy.IsUpdate(tt.candidate, tt.current); got != tt.want {
+			t.Errorf("%+v.IsUpdate(%q, %q) = %v, want %v", tt.policy, tt.candidate, tt.current, got, tt.want)
+		}
+	}
+}
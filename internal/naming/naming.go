@@ -0,0 +1,283 @@
+// Package naming expands the templates installed binaries are named by, so
+// tools can live next to distro-installed binaries of the same name.
+package naming
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/inovacc/glix/internal/module"
+	"golang.org/x/mod/semver"
+)
+
+// Placeholders lists the placeholders a template can use
+var Placeholders = []string{"{name}", "{owner}", "{repo}", "{host}", "{major}"}
+
+// Expand names the binary of a module version by template. {name} is the
+// default binary name, {host}, {owner} and {repo} are the first three
+// elements of the module path and {major} is the major version, e.g. v1.
+func Expand(template, modulePath, version string) (string, error) {
+	elems := strings.Split(modulePath, "/")
+	elem := func(i int) string {
+		if i < len(elems) {
+			return elems[i]
+		}
+
+		return ""
+	}
+
+	values := map[string]string{
+		"name":  module.BinaryName(modulePath),
+		"host":  elem(0),
+		"owner": elem(1),
+		"repo":  elem(2),
+		"major": semver.Major(version),
+	}
+
+	var b strings.Builder
+
+	rest := template
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start == -1 {
+			b.WriteString(rest)
+			break
+		}
+
+		end := strings.IndexByte(rest[start:], '}')
+		if end == -1 {
+			return "", fmt.Errorf("template %q has an unclosed {", template)
+		}
+
+		key := rest[start+1 : start+end]
+
+		value, ok := values[key]
+		if !ok {
+			return "", fmt.Errorf("template %q has unknown placeholder {%s} (want one of %s)", template, key, strings.Join(Placeholders, ", "))
+		}
+
+		if value == "" {
+			return "", fmt.Errorf("template %q: {%s} is empty for %s@%s", template, key, modulePath, version)
+		}
+
+		b.WriteString(rest[:start])
+		b.WriteString(value)
+		rest = rest[start+end+1:]
+	}
+
+	name := b.String()
+	if err := module.ValidateAlias(name); err != nil {
+		return "", fmt.Errorf("template %q: %w", template, err)
+	}
+
+	return name, nil
+}
+
+// Validate checks that a template expands for a typical module
+func Validate(template string) error {
+	_, err := Expand(template, "github.com/owner/repo/cmd/tool", "v1.0.0")
+	return err
+}
+
+// Rule names the binaries of the modules matching a pattern, a module path
+// or a path.Match pattern such as github.com/acme/*. The rule without a
+// pattern is the default for every module.
+type Rule struct {
+	Pattern  string `json:"pattern,omitempty"`
+	Template string `json:"template"`
+}
+
+// Matches reports whether the rule applies to a module
+func (r Rule) Matches(modulePath string) bool {
+	if r.Pattern == "" || r.Pattern == modulePath {
+		return true
+	}
+
+	ok, _ := path.Match(r.Pattern, modulePath)
+
+	return ok
+}
+
+// namingStore handles persistent storage of naming rules
+type namingStore struct {
+	mu       sync.Mutex
+	rules    map[string]Rule
+	filePath string
+}
+
+var (
+	store     *namingStore
+	storeOnce sync.Once
+)
+
+// getStorePath returns the path to the naming rules file
+func getStorePath() string {
+	configDir, err := module.GetApplicationConfigDirectory()
+	if err != nil {
+		// Fallback to cache directory
+		configDir, _ = module.GetApplicationCacheDirectory()
+	}
+
+	return filepath.Join(configDir, "naming.json")
+}
+
+// GetStore returns the singleton naming store
+func GetStore() *namingStore {
+	storeOnce.Do(func() {
+		store = &namingStore{
+			filePath: getStorePath(),
+			rules:    make(map[string]Rule),
+		}
+		// Load existing rules if available
+		_ = store.load()
+	})
+
+	return store
+}
+
+// load reads the rules from disk; callers hold s.mu
+func (s *namingStore) load() error {
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to read naming rules: %w", err)
+	}
+
+	var rules []Rule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return fmt.Errorf("failed to parse naming rules: %w", err)
+	}
+
+	s.rules = make(map[string]Rule, len(rules))
+	for _, r := range rules {
+		s.rules[r.Pattern] = r
+	}
+
+	return nil
+}
+
+// save writes the rules to disk; callers hold s.mu
+func (s *namingStore) save() error {
+	dir := filepath.Dir(s.filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s.sorted(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal naming rules: %w", err)
+	}
+
+	if err := os.WriteFile(s.filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write naming rules: %w", err)
+	}
+
+	return nil
+}
+
+// sorted returns the rules, the default first and then by pattern; callers
+// hold s.mu
+func (s *namingStore) sorted() []Rule {
+	rules := make([]Rule, 0, len(s.rules))
+	for _, r := range s.rules {
+		rules = append(rules, r)
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Pattern < rules[j].Pattern
+	})
+
+	return rules
+}
+
+// List returns the rules, the default first
+func (s *namingStore) List() []Rule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_ = s.load()
+
+	return s.sorted()
+}
+
+// Set stores a rule, replacing the one of the same pattern
+func (s *namingStore) Set(r Rule) error {
+	if err := Validate(r.Template); err != nil {
+		return err
+	}
+
+	if r.Pattern != "" {
+		if _, err := path.Match(r.Pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", r.Pattern, err)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_ = s.load()
+
+	s.rules[r.Pattern] = r
+
+	return s.save()
+}
+
+// Unset removes the rule of a pattern, "" for the default
+func (s *namingStore) Unset(pattern string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_ = s.load()
+
+	if _, ok := s.rules[pattern]; !ok {
+		if pattern == "" {
+			return fmt.Errorf("no default naming template is set")
+		}
+
+		return fmt.Errorf("no naming template is set for %s", pattern)
+	}
+
+	delete(s.rules, pattern)
+
+	return s.save()
+}
+
+// Lookup returns the rule naming the binary of a module: the rule of its
+// exact path, then the matching pattern that is longest, then the default
+func (s *namingStore) Lookup(modulePath string) (Rule, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_ = s.load()
+
+	if r, ok := s.rules[modulePath]; ok {
+		return r, true
+	}
+
+	var best Rule
+
+	found := false
+
+	for _, r := range s.rules {
+		if r.Pattern != "" && r.Matches(modulePath) && (!found || len(r.Pattern) > len(best.Pattern)) {
+			best, found = r, true
+		}
+	}
+
+	if found {
+		return best, true
+	}
+
+	r, ok := s.rules[""]
+
+	return r, ok
+}
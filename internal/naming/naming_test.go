@@ -0,0 +1,98 @@
+package naming
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func newTestStore(t *testing.T) *namingStore {
+	t.Helper()
+
+	return &namingStore{
+		filePath: filepath.Join(t.TempDir(), "naming.json"),
+		rules:    make(map[string]Rule),
+	}
+}
+
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		template, module, version string
+		want                      string
+		wantErr                   bool
+	}{
+		{"{name}-{major}", "github.com/golangci/golangci-lint/cmd/golangci-lint", "v1.64.8", "golangci-lint-v1", false},
+		{"{name}-{major}", "github.com/golangci/golangci-lint/v2/cmd/golangci-lint", "v2.1.0", "golangci-lint-v2", false},
+		{"{owner}-{name}", "github.com/inovacc/twig", "v0.3.0", "inovacc-twig", false},
+		{"go-{repo}", "golang.org/x/tools/gopls", "v0.16.0", "go-tools", false},
+		{"{name}", "github.com/inovacc/twig", "v0.3.0", "twig", false},
+		{"{name}-{version}", "github.com/inovacc/twig", "v0.3.0", "", true},
+		{"{name", "github.com/inovacc/twig", "v0.3.0", "", true},
+		{"{repo}-{name}", "example.com/tool", "v1.0.0", "", true},
+		{"{owner}/{name}", "github.com/inovacc/twig", "v0.3.0", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := Expand(tt.template, tt.module, tt.version)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("Expand(%q, %q, %q) = %q, %v; want %q, error %v", tt.template, tt.module, tt.version, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	s := newTestStore(t)
+
+	if _, ok := s.Lookup("github.com/acme/tool"); ok {
+		t.Error("Lookup found a rule in an empty store")
+	}
+
+	for _, r := range []Rule{
+		{Template: "{name}-go"},
+		{Pattern: "github.com/acme/*", Template: "acme-{name}"},
+		{Pattern: "github.com/acme/tool", Template: "{name}-{major}"},
+	} {
+		if err := s.Set(r); err != nil {
+			t.Fatalf("Set(%+v): %v", r, err)
+		}
+	}
+
+	if err := s.Set(Rule{Template: "{unknown}"}); err == nil {
+		t.Error("Set accepted a template with an unknown placeholder")
+	}
+
+	if err := s.Set(Rule{Pattern: "github.com/[", Template: "{name}"}); err == nil {
+		t.Error("Set accepted an invalid pattern")
+	}
+
+	tests := []struct {
+		module, want string
+	}{
+		{"github.com/acme/tool", "{name}-{major}"},
+		{"github.com/acme/lint", "acme-{name}"},
+		{"github.com/other/tool", "{name}-go"},
+	}
+
+	for _, tt := range tests {
+		if r, ok := s.Lookup(tt.module); !ok || r.Template != tt.want {
+			t.Errorf("Lookup(%s) = %+v, %v; want %s", tt.module, r, ok, tt.want)
+		}
+	}
+
+	// Other processes see the rules through the file
+	other := &namingStore{filePath: s.filePath, rules: make(map[string]Rule)}
+	if rules := other.List(); len(rules) != 3 || rules[0].Pattern != "" {
+		t.Errorf("List() = %+v, want 3 rules with the default first", rules)
+	}
+
+	if err := s.Unset(""); err != nil {
+		t.Fatalf("Unset default: %v", err)
+	}
+
+	if _, ok := s.Lookup("github.com/other/tool"); ok {
+		t.Error("Lookup found the removed default")
+	}
+
+	if err := s.Unset(""); err == nil {
+		t.Error("Unset succeeded without a default")
+	}
+}
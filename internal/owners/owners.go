@@ -0,0 +1,208 @@
+// Package owners records which glix namespace manages each installed binary,
+// so servers of different namespaces sharing a bin directory do not
+// overwrite each other's binaries.
+package owners
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/inovacc/glix/internal/module"
+)
+
+// Owner is the namespace managing an installed binary
+type Owner struct {
+	Path      string    `json:"path"`
+	Namespace string    `json:"namespace"`
+	Module    string    `json:"module"`
+	Profile   string    `json:"profile,omitempty"`
+	Updated   time.Time `json:"updated"`
+}
+
+// String describes the owner for conflict messages
+func (o Owner) String() string {
+	if o.Profile != "" {
+		return fmt.Sprintf("%s (profile %s) in namespace %s", o.Module, o.Profile, o.Namespace)
+	}
+
+	return fmt.Sprintf("%s in namespace %s", o.Module, o.Namespace)
+}
+
+// ownerStore handles persistent storage of binary owners
+type ownerStore struct {
+	mu       sync.Mutex
+	owners   map[string]Owner
+	filePath string
+}
+
+var (
+	store     *ownerStore
+	storeOnce sync.Once
+)
+
+// getStorePath returns the path to the owners file, shared by the servers of
+// every namespace of the user
+func getStorePath() string {
+	configDir, err := module.GetApplicationConfigDirectory()
+	if err != nil {
+		// Fallback to cache directory
+		configDir, _ = module.GetApplicationCacheDirectory()
+	}
+
+	return filepath.Join(configDir, "owners.json")
+}
+
+// GetStore returns the singleton owner store
+func GetStore() *ownerStore {
+	storeOnce.Do(func() {
+		store = &ownerStore{
+			filePath: getStorePath(),
+			owners:   make(map[string]Owner),
+		}
+		// Load existing owners if available
+		_ = store.load()
+	})
+
+	return store
+}
+
+// load reads the owners from disk; callers hold s.mu
+func (s *ownerStore) load() error {
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to read binary owners: %w", err)
+	}
+
+	var owners []Owner
+	if err := json.Unmarshal(data, &owners); err != nil {
+		return fmt.Errorf("failed to parse binary owners: %w", err)
+	}
+
+	s.owners = make(map[string]Owner, len(owners))
+	for _, o := range owners {
+		s.owners[o.Path] = o
+	}
+
+	return nil
+}
+
+// save writes the owners to disk; callers hold s.mu
+func (s *ownerStore) save() error {
+	dir := filepath.Dir(s.filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	owners := make([]Owner, 0, len(s.owners))
+	for _, o := range s.owners {
+		owners = append(owners, o)
+	}
+
+	sort.Slice(owners, func(i, j int) bool {
+		return owners[i].Path < owners[j].Path
+	})
+
+	data, err := json.MarshalIndent(owners, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal binary owners: %w", err)
+	}
+
+	if err := os.WriteFile(s.filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write binary owners: %w", err)
+	}
+
+	return nil
+}
+
+// Conflict returns the owner of the binary at path when another namespace
+// than namespace manages it. Binaries that no longer exist conflict with
+// nothing, whoever recorded them, and neither does an unknown namespace.
+func (s *ownerStore) Conflict(path, namespace string) (Owner, bool) {
+	if path == "" || namespace == "" {
+		return Owner{}, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Servers of other namespaces write the file too
+	_ = s.load()
+
+	o, ok := s.owners[filepath.Clean(path)]
+	if !ok || o.Namespace == namespace {
+		return Owner{}, false
+	}
+
+	if _, err := os.Stat(o.Path); err != nil {
+		return Owner{}, false
+	}
+
+	return o, true
+}
+
+// Claim records o as the owner of its binary, replacing any other owner
+func (s *ownerStore) Claim(o Owner) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_ = s.load()
+
+	o.Path = filepath.Clean(o.Path)
+	if o.Updated.IsZero() {
+		o.Updated = time.Now()
+	}
+
+	s.owners[o.Path] = o
+
+	return s.save()
+}
+
+// Release forgets the owner of the binary at path if it is namespace
+func (s *ownerStore) Release(path, namespace string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_ = s.load()
+
+	path = filepath.Clean(path)
+	if o, ok := s.owners[path]; !ok || o.Namespace != namespace {
+		return nil
+	}
+
+	delete(s.owners, path)
+
+	return s.save()
+}
+
+// Namespaces returns the namespaces owning a binary, sorted
+func (s *ownerStore) Namespaces() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_ = s.load()
+
+	seen := make(map[string]bool)
+
+	var namespaces []string
+
+	for _, o := range s.owners {
+		if !seen[o.Namespace] {
+			seen[o.Namespace] = true
+
+			namespaces = append(namespaces, o.Namespace)
+		}
+	}
+
+	sort.Strings(namespaces)
+
+	return namespaces
+}
@@ -0,0 +1,119 @@
+package owners
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func newTestStore(t *testing.T) *ownerStore {
+	t.Helper()
+
+	return &ownerStore{
+		filePath: filepath.Join(t.TempDir(), "owners.json"),
+		owners:   make(map[string]Owner),
+	}
+}
+
+func TestConflict(t *testing.T) {
+	s := newTestStore(t)
+
+	binary := filepath.Join(t.TempDir(), "tool")
+	if err := os.WriteFile(binary, []byte("bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, conflict := s.Conflict(binary, "work"); conflict {
+		t.Error("unowned binary should not conflict")
+	}
+
+	if err := s.Claim(Owner{Path: binary, Namespace: "home", Module: "example.com/tool"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, conflict := s.Conflict(binary, "home"); conflict {
+		t.Error("owning namespace should not conflict")
+	}
+
+	if _, conflict := s.Conflict(binary, ""); conflict {
+		t.Error("unknown namespace should not conflict")
+	}
+
+	owner, conflict := s.Conflict(binary, "work")
+	if !conflict || owner.Namespace != "home" || owner.Module != "example.com/tool" {
+		t.Errorf("Conflict(work) = %+v, %v; want owner home", owner, conflict)
+	}
+
+	// Other processes see the owner through the file
+	other := &ownerStore{filePath: s.filePath, owners: make(map[string]Owner)}
+	if _, conflict := other.Conflict(binary, "work"); !conflict {
+		t.Error("owner should be read from the file")
+	}
+
+	// Releasing from another namespace keeps the owner
+	if err := s.Release(binary, "work"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, conflict := s.Conflict(binary, "work"); !conflict {
+		t.Error("release by another namespace should keep the owner")
+	}
+
+	if err := os.Remove(binary); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, conflict := s.Conflict(binary, "work"); conflict {
+		t.Error("missing binary should not conflict")
+	}
+}
+
+func TestClaimAndRelease(t *testing.T) {
+	s := newTestStore(t)
+
+	binary := filepath.Join(t.TempDir(), "tool")
+	if err := os.WriteFile(binary, []byte("bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Claim(Owner{Path: binary, Namespace: "home", Module: "example.com/tool"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Taking over replaces the owner
+	if err := s.Claim(Owner{Path: binary, Namespace: "work", Module: "example.com/tool", Profile: "acme"}); err != nil {
+		t.Fatal(err)
+	}
+
+	owner, conflict := s.Conflict(binary, "home")
+	if !conflict || owner.Namespace != "work" || owner.Profile != "acme" {
+		t.Errorf("Conflict(home) = %+v, %v; want owner work", owner, conflict)
+	}
+
+	if err := s.Release(binary, "work"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, conflict := s.Conflict(binary, "home"); conflict {
+		t.Error("released binary should not conflict")
+	}
+}
+
+func TestNamespaces(t *testing.T) {
+	s := newTestStore(t)
+
+	for _, o := range []Owner{
+		{Path: "/bin/a", Namespace: "work", Module: "example.com/a"},
+		{Path: "/bin/b", Namespace: "home", Module: "example.com/b"},
+		{Path: "/bin/c", Namespace: "work", Module: "example.com/c"},
+	} {
+		if err := s.Claim(o); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := s.Namespaces(); !slices.Equal(got, []string{"home", "work"}) {
+		t.Errorf("Namespaces() = %q, want [home work]", got)
+	}
+}
@@ -0,0 +1,503 @@
+// Package policy evaluates install-time guardrails: simple YAML rules and,
+// optionally, an external OPA policy, deciding whether a module version may
+// be installed.
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/modver"
+	"github.com/inovacc/glix/pkg/exec"
+	"gopkg.in/yaml.v3"
+)
+
+// Action is the outcome of a policy decision
+type Action string
+
+const (
+	ActionAllow Action = "allow"
+	ActionWarn  Action = "warn"
+	ActionDeny  Action = "deny"
+)
+
+// severity orders actions so the strictest decision wins
+func (a Action) severity() int {
+	switch a {
+	case ActionDeny:
+		return 2
+	case ActionWarn:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// DefaultOPAQuery is evaluated against OPA bundles when no query is set
+const DefaultOPAQuery = "data.glix.decision"
+
+// LicenseNone is the license input for modules without a license file
+const LicenseNone = module.LicenseNone
+
+var httpClient = &http.Client{Timeout: 15 * time.Second}
+
+// Input describes the module version being installed
+type Input struct {
+	Module     string `json:"module"`
+	Version    string `json:"version"`
+	License    string `json:"license"`
+	Vulns      int    `json:"vulns"` // -1 when unknown
+	SourceHost string `json:"source_host"`
+}
+
+// Rule matches module versions and decides their fate. Every condition that
+// is set must match; a list condition matches when any entry does.
+type Rule struct {
+	Name       string   `yaml:"name"`
+	Action     Action   `yaml:"action"`
+	Module     []string `yaml:"module,omitempty"`
+	Version    []string `yaml:"version,omitempty"`
+	License    []string `yaml:"license,omitempty"`
+	SourceHost []string `yaml:"source_host,omitempty"`
+	MinVulns   *int     `yaml:"min_vulns,omitempty"`
+	Message    string   `yaml:"message,omitempty"`
+}
+
+// OPA points at an external Open Policy Agent decision
+type OPA struct {
+	URL    string `yaml:"url,omitempty"`    // Data API endpoint, e.g. http://localhost:8181/v1/data/glix/decision
+	Bundle string `yaml:"bundle,omitempty"` // Bundle evaluated locally with 'opa eval'
+	Query  string `yaml:"query,omitempty"`  // Query for bundles (default data.glix.decision)
+}
+
+// Policy is the parsed policy file
+type Policy struct {
+	Default Action `yaml:"default,omitempty"`
+	Rules   []Rule `yaml:"rules"`
+	OPA     *OPA   `yaml:"opa,omitempty"`
+}
+
+// Decision is the result of evaluating a policy
+type Decision struct {
+	Action  Action
+	Rule    string // Rule name, "opa" or "default"
+	Message string
+}
+
+// String describes the decision for users
+func (d Decision) String() string {
+	s := fmt.Sprintf("%s by %s", d.Action, d.Rule)
+	if d.Message != "" {
+		s += ": " + d.Message
+	}
+
+	return s
+}
+
+// DefaultPath returns the location of the policy file
+func DefaultPath() string {
+	configDir, err := module.GetApplicationConfigDirectory()
+	if err != nil {
+		// Fallback to cache directory
+		configDir, _ = module.GetApplicationCacheDirectory()
+	}
+
+	return filepath.Join(configDir, "policy.yaml")
+}
+
+// Load reads and validates a policy file. A missing file yields a nil
+// policy, which allows everything.
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy %s: %w", path, err)
+	}
+
+	return &p, nil
+}
+
+// Validate checks actions and rule names
+func (p *Policy) Validate() error {
+	if p.Default == "" {
+		p.Default = ActionAllow
+	}
+
+	if !validAction(p.Default) {
+		return fmt.Errorf("unknown default action %q", p.Default)
+	}
+
+	for i, r := range p.Rules {
+		if r.Name == "" {
+			return fmt.Errorf("rule #%d has no name", i+1)
+		}
+
+		if !validAction(r.Action) {
+			return fmt.Errorf("rule %q has unknown action %q (want allow, warn or deny)", r.Name, r.Action)
+		}
+	}
+
+	if p.OPA != nil && (p.OPA.URL == "") == (p.OPA.Bundle == "") {
+		return errors.New("opa needs exactly one of url or bundle")
+	}
+
+	return nil
+}
+
+func validAction(a Action) bool {
+	return a == ActionAllow || a == ActionWarn || a == ActionDeny
+}
+
+// NeedsLicense reports whether evaluating the policy uses the license input
+func (p *Policy) NeedsLicense() bool {
+	if p.OPA != nil {
+		return true
+	}
+
+	for _, r := range p.Rules {
+		if len(r.License) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NeedsVulns reports whether evaluating the policy uses the vulnerability count
+func (p *Policy) NeedsVulns() bool {
+	if p.OPA != nil {
+		return true
+	}
+
+	for _, r := range p.Rules {
+		if r.MinVulns != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Evaluate decides on an input. Rules are tried in order and the first match
+// decides, falling back to the default action. When OPA is configured its
+// decision is combined with the rules' and the stricter one wins.
+func (p *Policy) Evaluate(ctx context.Context, in Input) (Decision, error) {
+	decision := Decision{Action: p.Default, Rule: "default"}
+
+	for _, r := range p.Rules {
+		if r.matches(in) {
+			decision = Decision{Action: r.Action, Rule: r.Name, Message: r.Message}
+			break
+		}
+	}
+
+	if p.OPA == nil {
+		return decision, nil
+	}
+
+	opaDecision, err := p.OPA.evaluate(ctx, in)
+	if err != nil {
+		return Decision{}, fmt.Errorf("opa: %w", err)
+	}
+
+	if opaDecision.Action.severity() > decision.Action.severity() {
+		return opaDecision, nil
+	}
+
+	return decision, nil
+}
+
+// FlagsLicense reports whether the rules warn about or deny in because of its
+// license, such as for a dependency of an installed module: the first rule
+// matching decides as in Evaluate, and only rules with a license condition
+// flag. OPA is not asked, so flagging never waits on the network.
+func (p *Policy) FlagsLicense(in Input) bool {
+	for _, r := range p.Rules {
+		if r.matches(in) {
+			return len(r.License) > 0 && r.Action.severity() > ActionAllow.severity()
+		}
+	}
+
+	return false
+}
+
+func (r Rule) matches(in Input) bool {
+	if len(r.Module) > 0 && !anyMatch(r.Module, func(p string) bool {
+		return globMatch(p, in.Module) || strings.HasPrefix(in.Module, p+"/")
+	}) {
+		return false
+	}
+
+	if len(r.Version) > 0 && !anyMatch(r.Version, func(p string) bool { return versionMatch(p, in.Version) }) {
+		return false
+	}
+
+	if len(r.License) > 0 && !anyMatch(r.License, func(p string) bool {
+		return globMatch(strings.ToLower(p), strings.ToLower(in.License))
+	}) {
+		return false
+	}
+
+	if len(r.SourceHost) > 0 && !anyMatch(r.SourceHost, func(p string) bool { return globMatch(p, in.SourceHost) }) {
+		return false
+	}
+
+	if r.MinVulns != nil && (in.Vulns < 0 || in.Vulns < *r.MinVulns) {
+		return false
+	}
+
+	return true
+}
+
+func anyMatch(patterns []string, match func(string) bool) bool {
+	for _, p := range patterns {
+		if match(p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// globMatch matches s against a pattern where * matches any run of
+// characters, including slashes
+func globMatch(pattern, s string) bool {
+	expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+	return regexp.MustCompile(expr).MatchString(s)
+}
+
+// versionMatch accepts comparisons (<v1.0.0, >=v2.1.0) and globs (v0.*)
+func versionMatch(pattern, version string) bool {
+	for _, op := range []string{"<=", ">=", "<", ">"} {
+		if target, ok := strings.CutPrefix(pattern, op); ok {
+			target = strings.TrimSpace(target)
+			if !modver.IsValid(version) || !modver.IsValid(target) {
+				return false
+			}
+
+			c := modver.Compare(version, target)
+
+			switch op {
+			case "<=":
+				return c <= 0
+			case ">=":
+				return c >= 0
+			case "<":
+				return c < 0
+			default:
+				return c > 0
+			}
+		}
+	}
+
+	return globMatch(pattern, version)
+}
+
+// evaluate asks OPA for a decision on the input. An undefined decision allows.
+func (o *OPA) evaluate(ctx context.Context, in Input) (Decision, error) {
+	var (
+		value json.RawMessage
+		err   error
+	)
+
+	if o.URL != "" {
+		value, err = o.queryServer(ctx, in)
+	} else {
+		value, err = o.evalBundle(ctx, in)
+	}
+
+	if err != nil {
+		return Decision{}, err
+	}
+
+	return parseOPADecision(value)
+}
+
+func (o *OPA) queryServer(ctx context.Context, in Input) (json.RawMessage, error) {
+	body, err := json.Marshal(map[string]Input{"input": in})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s", o.URL, resp.Status)
+	}
+
+	var result struct {
+		Result json.RawMessage `json:"result"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Result, nil
+}
+
+func (o *OPA) evalBundle(ctx context.Context, in Input) (json.RawMessage, error) {
+	query := o.Query
+	if query == "" {
+		query = DefaultOPAQuery
+	}
+
+	input, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, "opa", "eval", "--format", "json", "--stdin-input", "--bundle", o.Bundle, query)
+	cmd.Stdin = bytes.NewReader(input)
+
+	var stderr bytes.Buffer
+
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("opa eval failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var result struct {
+		Result []struct {
+			Expressions []struct {
+				Value json.RawMessage `json:"value"`
+			} `json:"expressions"`
+		} `json:"result"`
+	}
+
+	if err := json.Unmarshal(out, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode opa output: %w", err)
+	}
+
+	if len(result.Result) == 0 || len(result.Result[0].Expressions) == 0 {
+		return nil, nil
+	}
+
+	return result.Result[0].Expressions[0].Value, nil
+}
+
+// parseOPADecision accepts a bare action ("deny") or an object with action
+// and message fields
+func parseOPADecision(value json.RawMessage) (Decision, error) {
+	decision := Decision{Action: ActionAllow, Rule: "opa"}
+
+	if len(value) == 0 || string(value) == "null" {
+		return decision, nil
+	}
+
+	var action string
+	if err := json.Unmarshal(value, &action); err == nil {
+		decision.Action = Action(action)
+	} else {
+		var obj struct {
+			Action  string `json:"action"`
+			Message string `json:"message"`
+		}
+
+		if err := json.Unmarshal(value, &obj); err != nil {
+			return Decision{}, fmt.Errorf("unexpected decision %s", value)
+		}
+
+		decision.Action, decision.Message = Action(obj.Action), obj.Message
+	}
+
+	if !validAction(decision.Action) {
+		return Decision{}, fmt.Errorf("unknown action %q", decision.Action)
+	}
+
+	return decision, nil
+}
+
+// BuildInput gathers the inputs the policy needs for a resolved module. The
+// license and vulnerability count are only looked up when the policy uses
+// them; lookups that fail are reported as warnings and leave the input
+// unknown.
+func BuildInput(ctx context.Context, p *Policy, m *module.Module) (Input, []string) {
+	root := m.RootModule
+	if root == "" {
+		root = m.Name
+	}
+
+	in := Input{
+		Module:     m.Name,
+		Version:    m.Version,
+		Vulns:      -1,
+		SourceHost: strings.SplitN(root, "/", 2)[0],
+	}
+
+	var warnings []string
+
+	switch {
+	case p.NeedsLicense() && m.License != "":
+		// Detected while fetching the module info
+		in.License = m.License
+	case p.NeedsLicense():
+		in.License = module.LicenseUnknown
+
+		dir, err := m.SourceDir()
+		if err == nil {
+			var license string
+
+			license, err = module.DetectLicense(dir)
+			if err == nil {
+				in.License = license
+				if license == "" {
+					in.License = LicenseNone
+				}
+			}
+		}
+
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("license unavailable: %v", err))
+		}
+	}
+
+	if p.NeedsVulns() {
+		vulns, err := module.QueryVulnerabilities(ctx, root, m.Version)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("vulnerability count unavailable: %v", err))
+		} else {
+			in.Vulns = len(vulns)
+		}
+	}
+
+	return in, warnings
+}
@@ -0,0 +1,157 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testPolicy = `
+default: allow
+rules:
+  - name: trusted-internal
+    action: allow
+    module: [git.example.com/platform]
+  - name: no-copyleft
+    action: deny
+    license: [GPL-*, AGPL-*]
+    message: copyleft licenses need legal review
+  - name: vulnerable
+    action: deny
+    min_vulns: 1
+  - name: unstable
+    action: warn
+    version: ["<v1.0.0"]
+  - name: unknown-hosts
+    action: warn
+    source_host: ["*.example.org"]
+`
+
+func loadTestPolicy(t *testing.T, content string) *Policy {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return p
+}
+
+func TestEvaluate(t *testing.T) {
+	p := loadTestPolicy(t, testPolicy)
+
+	tests := []struct {
+		name string
+		in   Input
+		want Action
+		rule string
+	}{
+		{"allowed", Input{Module: "github.com/a/b", Version: "v1.2.0", License: "MIT", Vulns: 0, SourceHost: "github.com"}, ActionAllow, "default"},
+		{"copyleft", Input{Module: "github.com/a/b", Version: "v1.2.0", License: "GPL-3.0", Vulns: 0}, ActionDeny, "no-copyleft"},
+		{"vulnerable", Input{Module: "github.com/a/b", Version: "v1.2.0", License: "MIT", Vulns: 2}, ActionDeny, "vulnerable"},
+		{"unknown vulns do not match", Input{Module: "github.com/a/b", Version: "v1.2.0", License: "MIT", Vulns: -1}, ActionAllow, "default"},
+		{"prerelease major", Input{Module: "github.com/a/b", Version: "v0.3.0", License: "MIT"}, ActionWarn, "unstable"},
+		{"first match wins", Input{Module: "git.example.com/platform/cmd/tool", Version: "v0.1.0", License: "GPL-3.0"}, ActionAllow, "trusted-internal"},
+		{"host glob", Input{Module: "git.example.org/x", Version: "v1.0.0", License: "MIT", SourceHost: "git.example.org"}, ActionWarn, "unknown-hosts"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := p.Evaluate(context.Background(), tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if d.Action != tt.want || d.Rule != tt.rule {
+				t.Errorf("Evaluate = %v, want %s by %s", d, tt.want, tt.rule)
+			}
+		})
+	}
+}
+
+func TestFlagsLicense(t *testing.T) {
+	p := loadTestPolicy(t, testPolicy)
+
+	tests := []struct {
+		name string
+		in   Input
+		want bool
+	}{
+		{"allowed license", Input{Module: "github.com/a/b", Version: "v1.2.0", License: "MIT", Vulns: -1}, false},
+		{"denied license", Input{Module: "github.com/a/b", Version: "v1.2.0", License: "AGPL-3.0", Vulns: -1}, true},
+		{"denied license before version rule", Input{Module: "github.com/a/b", Version: "v0.3.0", License: "GPL-2.0", Vulns: -1}, true},
+		{"version rule without license condition", Input{Module: "github.com/a/b", Version: "v0.3.0", License: "MIT", Vulns: -1}, false},
+		{"allowed by earlier rule", Input{Module: "git.example.com/platform/lib", Version: "v1.0.0", License: "GPL-3.0", Vulns: -1}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.FlagsLicense(tt.in); got != tt.want {
+				t.Errorf("FlagsLicense(%+v) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(path, []byte("rules:\n  - name: x\n    action: block\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(path); err == nil {
+		t.Error("unknown action should fail")
+	}
+
+	if p, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); p != nil || err != nil {
+		t.Errorf("missing file = %v, %v; want nil, nil", p, err)
+	}
+}
+
+func TestEvaluate_OPAServer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input Input `json:"input"`
+		}
+
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		if req.Input.SourceHost == "github.com" {
+			_, _ = w.Write([]byte(`{"result": "allow"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"result": {"action": "deny", "message": "host not approved"}}`))
+	}))
+	defer srv.Close()
+
+	p := loadTestPolicy(t, "opa:\n  url: "+srv.URL+"\n")
+
+	d, err := p.Evaluate(context.Background(), Input{Module: "gitlab.com/a/b", SourceHost: "gitlab.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Action != ActionDeny || d.Rule != "opa" || d.Message != "host not approved" {
+		t.Errorf("Evaluate = %v", d)
+	}
+
+	d, err = p.Evaluate(context.Background(), Input{Module: "github.com/a/b", SourceHost: "github.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Action != ActionAllow {
+		t.Errorf("Evaluate = %v, want allow", d)
+	}
+}
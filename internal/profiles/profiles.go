@@ -0,0 +1,225 @@
+// Package profiles stores install profiles: named module caches that keep
+// the downloads of some installs, such as those of a company's modules,
+// apart from the shared module cache, optionally fetched through a module
+// proxy of their own.
+package profiles
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/inovacc/glix/internal/module"
+)
+
+// Profile is an install profile
+type Profile struct {
+	Name      string    `json:"name"`
+	GoFlags   string    `json:"goflags,omitempty"`   // Added to GOFLAGS for the go commands of the profile
+	GoProxy   string    `json:"goproxy,omitempty"`   // GOPROXY of the go commands of the profile, the environment's when empty
+	GoNoSumDB []string  `json:"gonosumdb,omitempty"` // Added to GONOSUMDB for modules the proxy serves but the checksum database does not know
+	Created   time.Time `json:"created"`
+}
+
+// namePattern matches profile names, which name directories
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// profileStore handles persistent storage of profiles
+type profileStore struct {
+	mu       sync.RWMutex
+	profiles map[string]Profile
+	filePath string
+}
+
+var (
+	store     *profileStore
+	storeOnce sync.Once
+)
+
+// getStorePath returns the path to the profiles file
+func getStorePath() string {
+	configDir, err := module.GetApplicationConfigDirectory()
+	if err != nil {
+		// Fallback to cache directory
+		configDir, _ = module.GetApplicationCacheDirectory()
+	}
+
+	return filepath.Join(configDir, "profiles.json")
+}
+
+// GetStore returns the singleton profile store
+func GetStore() *profileStore {
+	storeOnce.Do(func() {
+		store = &profileStore{
+			filePath: getStorePath(),
+			profiles: make(map[string]Profile),
+		}
+		// Load existing profiles if available
+		_ = store.load()
+	})
+
+	return store
+}
+
+// load reads the profiles from disk
+func (s *profileStore) load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to read profiles: %w", err)
+	}
+
+	var profiles []Profile
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return fmt.Errorf("failed to parse profiles: %w", err)
+	}
+
+	s.profiles = make(map[string]Profile, len(profiles))
+	for _, p := range profiles {
+		s.profiles[p.Name] = p
+	}
+
+	return nil
+}
+
+// Reload re-reads the profiles from disk, picking up changes made by other
+// glix processes
+func (s *profileStore) Reload() error {
+	return s.load()
+}
+
+// save writes the profiles to disk
+func (s *profileStore) save() error {
+	dir := filepath.Dir(s.filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s.sorted(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal profiles: %w", err)
+	}
+
+	if err := os.WriteFile(s.filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write profiles: %w", err)
+	}
+
+	return nil
+}
+
+// sorted returns the profiles ordered by name
+func (s *profileStore) sorted() []Profile {
+	profiles := make([]Profile, 0, len(s.profiles))
+	for _, p := range s.profiles {
+		profiles = append(profiles, p)
+	}
+
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Name < profiles[j].Name
+	})
+
+	return profiles
+}
+
+// ValidateName checks that a profile name can name its directory
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use letters, digits, '.', '_' and '-'", name)
+	}
+
+	return nil
+}
+
+// Set adds or replaces a profile, keeping the creation time of one it
+// replaces
+func (s *profileStore) Set(p Profile) error {
+	if err := ValidateName(p.Name); err != nil {
+		return err
+	}
+
+	if p.GoProxy != "" {
+		if err := module.ValidateGoProxy(p.GoProxy); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.profiles[p.Name]; ok {
+		p.Created = existing.Created
+	}
+
+	s.profiles[p.Name] = p
+
+	return s.save()
+}
+
+// Remove deletes a profile. Its module cache is left to the caller.
+func (s *profileStore) Remove(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.profiles[name]; !ok {
+		return fmt.Errorf("profile %s not found", name)
+	}
+
+	delete(s.profiles, name)
+
+	return s.save()
+}
+
+// Get returns a profile by name
+func (s *profileStore) Get(name string) (Profile, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.profiles[name]
+
+	return p, ok
+}
+
+// List returns the profiles ordered by name
+func (s *profileStore) List() []Profile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.sorted()
+}
+
+// Proxy returns the module proxy settings of the profile
+func (p Profile) Proxy() module.ProxySettings {
+	return module.ProxySettings{GoProxy: p.GoProxy, GoNoSumDB: p.GoNoSumDB}
+}
+
+// Apply makes the go commands of m use the named profile, or the shared
+// module cache and the environment's module proxy when name is empty
+func Apply(m *module.Module, name string) error {
+	if name == "" {
+		m.SetProfile("", "")
+		m.SetProfileProxy(module.ProxySettings{})
+
+		return nil
+	}
+
+	p, ok := GetStore().Get(name)
+	if !ok {
+		return fmt.Errorf("profile %s does not exist; create it with 'glix profile add %s'", name, name)
+	}
+
+	m.SetProfile(p.Name, p.GoFlags)
+	m.SetProfileProxy(p.Proxy())
+
+	return nil
+}
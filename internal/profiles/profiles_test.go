@@ -0,0 +1,78 @@
+package profiles
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestStore(t *testing.T) *profileStore {
+	t.Helper()
+
+	return &profileStore{
+		filePath: filepath.Join(t.TempDir(), "profiles.json"),
+		profiles: make(map[string]Profile),
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	for _, name := range []string{"work", "acme.corp", "ci_1", "a-b"} {
+		if err := ValidateName(name); err != nil {
+			t.Errorf("ValidateName(%q) = %v", name, err)
+		}
+	}
+
+	for _, name := range []string{"", ".", "..", "-x", "a/b", "two words"} {
+		if err := ValidateName(name); err == nil {
+			t.Errorf("ValidateName(%q) should fail", name)
+		}
+	}
+}
+
+func TestStore(t *testing.T) {
+	s := newTestStore(t)
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if err := s.Set(Profile{Name: "work", GoFlags: "-mod=mod", Created: created}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Updating a profile keeps its creation time
+	if err := s.Set(Profile{Name: "work", GoFlags: "-tags=corp", Created: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded := &profileStore{filePath: s.filePath, profiles: make(map[string]Profile)}
+	if err := reloaded.load(); err != nil {
+		t.Fatal(err)
+	}
+
+	p, ok := reloaded.Get("work")
+	if !ok || p.GoFlags != "-tags=corp" || !p.Created.Equal(created) {
+		t.Errorf("Get(work) = %+v, %v", p, ok)
+	}
+
+	if err := reloaded.Remove("work"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := reloaded.Remove("work"); err == nil {
+		t.Error("removing a missing profile should fail")
+	}
+
+	if err := s.Set(Profile{Name: "corp", GoProxy: "https://athens.corp,direct", GoNoSumDB: []string{"corp.example.com/*"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if p, _ := s.Get("corp"); p.Proxy().GoProxy != "https://athens.corp,direct" || len(p.Proxy().GoNoSumDB) != 1 {
+		t.Errorf("Get(corp).Proxy() = %+v", p.Proxy())
+	}
+
+	if err := s.Set(Profile{Name: "bad", GoProxy: "athens.corp"}); err == nil {
+		t.Error("a GOPROXY without a scheme should be refused")
+	}
+
+	if len(reloaded.List()) != 0 {
+		t.Errorf("List() = %v, want none", reloaded.List())
+	}
+}
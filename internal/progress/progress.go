@@ -0,0 +1,105 @@
+// Package progress turns the phase labels reported during installs into
+// structured ProgressUpdate messages with a well-known phase, phase timing,
+// and step counters.
+package progress
+
+import (
+	"sync"
+	"time"
+
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+// TotalSteps is the number of install phases, from resolve to complete
+const TotalSteps = int32(pb.InstallPhase_INSTALL_PHASE_COMPLETE)
+
+// phases maps the labels used by progress handlers to install phases.
+// Labels not listed here (warnings, errors, skips) keep the current phase.
+var phases = map[string]pb.InstallPhase{
+	"init":        pb.InstallPhase_INSTALL_PHASE_RESOLVE,
+	"versions":    pb.InstallPhase_INSTALL_PHASE_RESOLVE,
+	"discover":    pb.InstallPhase_INSTALL_PHASE_RESOLVE,
+	"download":    pb.InstallPhase_INSTALL_PHASE_RESOLVE,
+	"deps":        pb.InstallPhase_INSTALL_PHASE_RESOLVE,
+	"licenses":    pb.InstallPhase_INSTALL_PHASE_RESOLVE,
+	"fetch":       pb.InstallPhase_INSTALL_PHASE_RESOLVE,
+	"check":       pb.InstallPhase_INSTALL_PHASE_RESOLVE,
+	"done":        pb.InstallPhase_INSTALL_PHASE_RESOLVE,
+	"resolve":     pb.InstallPhase_INSTALL_PHASE_POLICY,
+	"constraints": pb.InstallPhase_INSTALL_PHASE_POLICY,
+	"policy":      pb.InstallPhase_INSTALL_PHASE_POLICY,
+	"install":     pb.InstallPhase_INSTALL_PHASE_BUILD,
+	"update":      pb.InstallPhase_INSTALL_PHASE_BUILD,
+	"build":       pb.InstallPhase_INSTALL_PHASE_BUILD,
+	"kubectl":     pb.InstallPhase_INSTALL_PHASE_BUILD,
+	"store":       pb.InstallPhase_INSTALL_PHASE_STORE,
+	"complete":    pb.InstallPhase_INSTALL_PHASE_COMPLETE,
+}
+
+// PhaseOf returns the install phase of a progress label, or
+// INSTALL_PHASE_UNSPECIFIED when the label does not start a phase
+func PhaseOf(label string) pb.InstallPhase {
+	return phases[label]
+}
+
+// Tracker follows the phases of one install and timestamps them
+type Tracker struct {
+	mu      sync.Mutex
+	phase   pb.InstallPhase
+	started time.Time
+	now     func() time.Time
+}
+
+// NewTracker creates a tracker for a new install
+func NewTracker() *Tracker {
+	return &Tracker{now: time.Now}
+}
+
+// Update records a progress label and message and returns the update to
+// send to clients. A label that starts another phase restarts the phase
+// timer; other labels report the current phase.
+func (t *Tracker) Update(label, message string) *pb.ProgressUpdate {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+
+	if phase := PhaseOf(label); phase != pb.InstallPhase_INSTALL_PHASE_UNSPECIFIED && phase != t.phase {
+		t.phase = phase
+		t.started = now
+	}
+
+	update := &pb.ProgressUpdate{
+		Message:         message,
+		PercentComplete: -1,
+		Phase:           t.phase,
+		Label:           label,
+		TotalSteps:      TotalSteps,
+	}
+
+	if t.phase != pb.InstallPhase_INSTALL_PHASE_UNSPECIFIED {
+		update.StartedAtUnixNano = t.started.UnixNano()
+		update.ElapsedNano = now.Sub(t.started).Nanoseconds()
+		update.Step = int32(t.phase)
+	}
+
+	return update
+}
+
+// PhaseName returns a short lowercase name for a phase, e.g. "build"
+func PhaseName(phase pb.InstallPhase) string {
+	switch phase {
+	case pb.InstallPhase_INSTALL_PHASE_RESOLVE:
+		return "resolve"
+	case pb.InstallPhase_INSTALL_PHASE_POLICY:
+		return "policy"
+	case pb.InstallPhase_INSTALL_PHASE_BUILD:
+		return "build"
+	case pb.InstallPhase_INSTALL_PHASE_STORE:
+		return "store"
+	case pb.InstallPhase_INSTALL_PHASE_COMPLETE:
+		return "complete"
+	default:
+		return ""
+	}
+}
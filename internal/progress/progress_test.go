@@ -0,0 +1,47 @@
+package progress
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+func TestTracker(t *testing.T) {
+	clock := time.Unix(1000, 0)
+
+	tr := NewTracker()
+	tr.now = func() time.Time { return clock }
+
+	if u := tr.Update("warning", "before any phase"); u.GetPhase() != pb.InstallPhase_INSTALL_PHASE_UNSPECIFIED || u.GetStep() != 0 {
+		t.Errorf("update before any phase = %v", u)
+	}
+
+	first := tr.Update("versions", "Fetching versions...")
+	if first.GetPhase() != pb.InstallPhase_INSTALL_PHASE_RESOLVE || first.GetStep() != 1 || first.GetTotalSteps() != TotalSteps {
+		t.Errorf("resolve update = %v", first)
+	}
+
+	clock = clock.Add(2 * time.Second)
+
+	// Labels of the same phase, and labels without one, keep the phase timer
+	for _, label := range []string{"deps", "warning"} {
+		u := tr.Update(label, "")
+		if u.GetPhase() != pb.InstallPhase_INSTALL_PHASE_RESOLVE || u.GetElapsedNano() != (2*time.Second).Nanoseconds() {
+			t.Errorf("%s update = %v", label, u)
+		}
+
+		if u.GetStartedAtUnixNano() != first.GetStartedAtUnixNano() {
+			t.Errorf("%s update restarted the phase", label)
+		}
+	}
+
+	build := tr.Update("install", "Installing...")
+	if build.GetPhase() != pb.InstallPhase_INSTALL_PHASE_BUILD || build.GetStep() != 3 || build.GetElapsedNano() != 0 {
+		t.Errorf("build update = %v", build)
+	}
+
+	if build.GetStartedAtUnixNano() != clock.UnixNano() || build.GetLabel() != "install" {
+		t.Errorf("build update = %v", build)
+	}
+}
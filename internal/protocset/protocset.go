@@ -0,0 +1,172 @@
+// Package protocset provides curated, mutually compatible sets of protoc
+// code generator plugins backed by an embedded version matrix.
+package protocset
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// LatestSet selects the newest set in the matrix
+const LatestSet = "latest"
+
+//go:embed matrix.json
+var matrixData []byte
+
+// Set is a named combination of plugin versions known to work together
+type Set struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Versions    map[string]string `json:"versions"`
+}
+
+// Constraint describes a cross-version requirement between two plugins.
+// When Tool is installed at Min or newer, Requires must be at RequiresMin or
+// newer, or exactly the same version as Tool when SameVersion is set.
+type Constraint struct {
+	Tool        string `json:"tool"`
+	Min         string `json:"min"`
+	Requires    string `json:"requires"`
+	RequiresMin string `json:"requires_min,omitempty"`
+	SameVersion bool   `json:"same_version,omitempty"`
+	Reason      string `json:"reason"`
+}
+
+// Matrix is the embedded compatibility matrix
+type Matrix struct {
+	Modules     map[string]string `json:"tools"`
+	Sets        []Set             `json:"sets"`
+	Constraints []Constraint      `json:"constraints"`
+}
+
+// Tool is a single plugin pinned to a version
+type Tool struct {
+	Name    string
+	Module  string
+	Version string
+}
+
+// Violation is a broken constraint between two installed plugins
+type Violation struct {
+	Constraint Constraint
+	Version    string
+	Found      string
+}
+
+func (v Violation) String() string {
+	c := v.Constraint
+
+	want := fmt.Sprintf(">= %s", c.RequiresMin)
+	if c.SameVersion {
+		want = v.Version
+	}
+
+	return fmt.Sprintf("%s@%s requires %s %s (found %s): %s", c.Tool, v.Version, c.Requires, want, v.Found, c.Reason)
+}
+
+// Load parses the embedded compatibility matrix
+func Load() (*Matrix, error) {
+	var m Matrix
+	if err := json.Unmarshal(matrixData, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse protoc set matrix: %w", err)
+	}
+
+	return &m, nil
+}
+
+// SetNames returns the names of all sets, newest first
+func (m *Matrix) SetNames() []string {
+	names := make([]string, 0, len(m.Sets))
+	for _, s := range m.Sets {
+		names = append(names, s.Name)
+	}
+
+	return names
+}
+
+// Lookup returns the set with the given name; "latest" selects the first set
+func (m *Matrix) Lookup(name string) (*Set, error) {
+	if len(m.Sets) == 0 {
+		return nil, fmt.Errorf("protoc set matrix is empty")
+	}
+
+	if name == "" || name == LatestSet {
+		return &m.Sets[0], nil
+	}
+
+	for i := range m.Sets {
+		if m.Sets[i].Name == name {
+			return &m.Sets[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown protoc set %q (available: latest, %s)", name, strings.Join(m.SetNames(), ", "))
+}
+
+// Tools resolves the module paths for every plugin in a set, ordered by name
+func (m *Matrix) Tools(set *Set) ([]Tool, error) {
+	tools := make([]Tool, 0, len(set.Versions))
+
+	for name, version := range set.Versions {
+		modulePath, ok := m.Modules[name]
+		if !ok {
+			return nil, fmt.Errorf("protoc set %s references unknown tool %s", set.Name, name)
+		}
+
+		tools = append(tools, Tool{Name: name, Module: modulePath, Version: version})
+	}
+
+	sort.Slice(tools, func(i, j int) bool {
+		return tools[i].Name < tools[j].Name
+	})
+
+	return tools, nil
+}
+
+// ToolByModule returns the plugin name for a module path, if it is a known plugin
+func (m *Matrix) ToolByModule(modulePath string) (string, bool) {
+	for name, p := range m.Modules {
+		if p == modulePath {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// Check verifies installed plugin versions (keyed by tool name) against the
+// matrix constraints. Constraints only apply when both plugins are installed.
+func (m *Matrix) Check(installed map[string]string) []Violation {
+	var violations []Violation
+
+	for _, c := range m.Constraints {
+		version, ok := installed[c.Tool]
+		if !ok || !semver.IsValid(version) || semver.Compare(version, c.Min) < 0 {
+			continue
+		}
+
+		found, ok := installed[c.Requires]
+		if !ok {
+			continue
+		}
+
+		var satisfied bool
+
+		if c.SameVersion {
+			satisfied = found == version
+		} else {
+			satisfied = semver.IsValid(found) && semver.Compare(found, c.RequiresMin) >= 0
+		}
+
+		if !satisfied {
+			violations = append(violations, Violation{Constraint: c, Version: version, Found: found})
+		}
+	}
+
+	return violations
+}
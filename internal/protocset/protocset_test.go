@@ -0,0 +1,71 @@
+package protocset
+
+import "testing"
+
+func TestEmbeddedSetsAreCompatible(t *testing.T) {
+	m, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range m.SetNames() {
+		set, err := m.Lookup(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := m.Tools(set); err != nil {
+			t.Errorf("set %s: %v", name, err)
+		}
+
+		for _, v := range m.Check(set.Versions) {
+			t.Errorf("set %s: %s", name, v)
+		}
+	}
+}
+
+func TestLookupLatest(t *testing.T) {
+	m, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	set, err := m.Lookup(LatestSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if set.Name != m.Sets[0].Name {
+		t.Errorf("latest = %s, want %s", set.Name, m.Sets[0].Name)
+	}
+
+	if _, err := m.Lookup("1999.1"); err == nil {
+		t.Error("expected error for unknown set")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	m, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		installed map[string]string
+		want      int
+	}{
+		{"grpc too new for go", map[string]string{"protoc-gen-go": "v1.31.0", "protoc-gen-go-grpc": "v1.5.1"}, 2},
+		{"gateway mismatch", map[string]string{"protoc-gen-grpc-gateway": "v2.26.3", "protoc-gen-openapiv2": "v2.20.0"}, 1},
+		{"missing dependency ignored", map[string]string{"protoc-gen-go-grpc": "v1.5.1"}, 0},
+		{"old versions unconstrained", map[string]string{"protoc-gen-go": "v1.28.0", "protoc-gen-go-grpc": "v1.2.0"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.Check(tt.installed); len(got) != tt.want {
+				t.Errorf("Check() = %v, want %d violation(s)", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package quarantine
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// icapDefaultPort is the port of ICAP URLs that name none
+const icapDefaultPort = "1344"
+
+// icapChunkSize is the size of the chunks the binary is sent in
+const icapChunkSize = 64 << 10
+
+// threatPattern finds the threat in an X-Infection-Found header, such as
+// "Type=0; Resolution=2; Threat=Eicar-Test-Signature;"
+var threatPattern = regexp.MustCompile(`Threat=([^;]+)`)
+
+// scanICAP submits the binary to an ICAP service as the body of an HTTP
+// response (RESPMOD, RFC 3507). 204 No Content means the service passed it
+// unchanged; a modified response (200) or 403 means it was blocked.
+func scanICAP(ctx context.Context, endpoint, binPath string) (bool, string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return false, "", fmt.Errorf("invalid ICAP URL %q: %w", endpoint, err)
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), icapDefaultPort)
+	}
+
+	f, err := os.Open(binPath)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to open binary: %w", err)
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	info, err := f.Stat()
+	if err != nil {
+		return false, "", fmt.Errorf("failed to stat binary: %w", err)
+	}
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", host)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to connect to ICAP service: %w", err)
+	}
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	reqHdr := fmt.Sprintf("GET /%s HTTP/1.1\r\nHost: glix\r\n\r\n", url.PathEscape(filepath.Base(binPath)))
+	resHdr := fmt.Sprintf("HTTP/1.1 200 OK\r\nContent-Type: application/octet-stream\r\nContent-Length: %d\r\n\r\n", info.Size())
+
+	w := bufio.NewWriter(conn)
+
+	_, _ = fmt.Fprintf(w, "RESPMOD %s ICAP/1.0\r\n", endpoint)
+	_, _ = fmt.Fprintf(w, "Host: %s\r\n", u.Hostname())
+	_, _ = fmt.Fprintf(w, "User-Agent: glix\r\n")
+	_, _ = fmt.Fprintf(w, "Allow: 204\r\n")
+	_, _ = fmt.Fprintf(w, "Encapsulated: req-hdr=0, res-hdr=%d, res-body=%d\r\n\r\n", len(reqHdr), len(reqHdr)+len(resHdr))
+	_, _ = w.WriteString(reqHdr)
+	_, _ = w.WriteString(resHdr)
+
+	if err := writeChunked(w, f); err != nil {
+		return false, "", fmt.Errorf("failed to send binary to ICAP service: %w", err)
+	}
+
+	if err := w.Flush(); err != nil {
+		return false, "", fmt.Errorf("failed to send binary to ICAP service: %w", err)
+	}
+
+	code, header, err := readICAPResponse(bufio.NewReader(conn))
+	if err != nil {
+		return false, "", err
+	}
+
+	switch code {
+	case 204:
+		return true, "", nil
+	case 200, 403:
+		return false, icapThreat(header), nil
+	default:
+		return false, "", fmt.Errorf("ICAP service answered %d", code)
+	}
+}
+
+// writeChunked writes r in HTTP chunked encoding, ending with the last chunk
+func writeChunked(w *bufio.Writer, r io.Reader) error {
+	buf := make([]byte, icapChunkSize)
+
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			_, _ = fmt.Fprintf(w, "%x\r\n", n)
+			_, _ = w.Write(buf[:n])
+			_, _ = w.WriteString("\r\n")
+		}
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := w.WriteString("0\r\n\r\n")
+
+	return err
+}
+
+// readICAPResponse reads the status code and headers of an ICAP response;
+// the encapsulated content that may follow is not needed
+func readICAPResponse(r *bufio.Reader) (int, textproto.MIMEHeader, error) {
+	tp := textproto.NewReader(r)
+
+	status, err := tp.ReadLine()
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read ICAP response: %w", err)
+	}
+
+	proto, rest, _ := strings.Cut(status, " ")
+	codeText, _, _ := strings.Cut(rest, " ")
+
+	code, err := strconv.Atoi(codeText)
+	if !strings.HasPrefix(proto, "ICAP/") || err != nil {
+		return 0, nil, fmt.Errorf("malformed ICAP status line %q", status)
+	}
+
+	header, err := tp.ReadMIMEHeader()
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read ICAP response headers: %w", err)
+	}
+
+	return code, header, nil
+}
+
+// icapThreat describes what an ICAP service blocked, from the headers
+// scanners report findings in
+func icapThreat(header textproto.MIMEHeader) string {
+	if found := header.Get("X-Infection-Found"); found != "" {
+		if m := threatPattern.FindStringSubmatch(found); m != nil {
+			return strings.TrimSpace(m[1])
+		}
+
+		return found
+	}
+
+	for _, name := range []string{"X-Virus-Id", "X-Violations-Found", "X-Blocked-Reason"} {
+		if v := header.Get(name); v != "" {
+			return v
+		}
+	}
+
+	return "blocked by the ICAP service"
+}
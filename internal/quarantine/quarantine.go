@@ -0,0 +1,219 @@
+// Package quarantine scans built binaries with a local scanner command or an
+// ICAP service before installs place them in the bin directory, as some
+// regulated environments require.
+package quarantine
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/inovacc/glix/internal/module"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"github.com/inovacc/glix/pkg/exec"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultTimeout bounds a scan when the config sets no timeout
+const DefaultTimeout = 5 * time.Minute
+
+// BinaryPlaceholder is replaced by the path of the binary in the arguments
+// of a scanner command
+const BinaryPlaceholder = "{binary}"
+
+// maxDetail is how much of what a scanner prints is kept as its verdict
+const maxDetail = 1024
+
+// Config is the parsed quarantine file. Exactly one of Command and ICAP is
+// set.
+type Config struct {
+	// Command runs a scanner on the binary, e.g. [clamscan, --no-summary,
+	// "{binary}"]; without the placeholder the path is the last argument.
+	// Exit code 0 is clean and InfectedExitCodes are rejections; any other
+	// exit code means the scan failed.
+	Command           []string `yaml:"command,omitempty"`
+	InfectedExitCodes []int    `yaml:"infected_exit_codes,omitempty"` // Default [1], as clamscan exits
+
+	// ICAP submits the binary to an ICAP service with RESPMOD, e.g.
+	// icap://scanner.example.com:1344/avscan. 204 No Content is clean.
+	ICAP string `yaml:"icap,omitempty"`
+
+	Timeout time.Duration `yaml:"timeout,omitempty"` // Default DefaultTimeout
+}
+
+// DefaultPath returns the location of the quarantine file
+func DefaultPath() string {
+	configDir, err := module.GetApplicationConfigDirectory()
+	if err != nil {
+		// Fallback to cache directory
+		configDir, _ = module.GetApplicationCacheDirectory()
+	}
+
+	return filepath.Join(configDir, "quarantine.yaml")
+}
+
+// Load reads and validates a quarantine file. A missing file yields a nil
+// config: binaries are installed without a scan.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read quarantine config: %w", err)
+	}
+
+	var c Config
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse quarantine config: %w", err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid quarantine config %s: %w", path, err)
+	}
+
+	return &c, nil
+}
+
+// Validate checks that one scanner is configured, filling in defaults
+func (c *Config) Validate() error {
+	if (len(c.Command) == 0) == (c.ICAP == "") {
+		return errors.New("quarantine needs exactly one of command or icap")
+	}
+
+	if c.ICAP != "" {
+		u, err := url.Parse(c.ICAP)
+		if err != nil || u.Scheme != "icap" || u.Host == "" {
+			return fmt.Errorf("icap %q must be an icap://host[:port]/service URL", c.ICAP)
+		}
+	}
+
+	if len(c.InfectedExitCodes) == 0 {
+		c.InfectedExitCodes = []int{1}
+	}
+
+	if slices.Contains(c.InfectedExitCodes, 0) {
+		return errors.New("exit code 0 means clean and cannot be an infected exit code")
+	}
+
+	if c.Timeout <= 0 {
+		c.Timeout = DefaultTimeout
+	}
+
+	return nil
+}
+
+// Name describes the scanner: the command name or the ICAP URL
+func (c *Config) Name() string {
+	if c.ICAP != "" {
+		return c.ICAP
+	}
+
+	return filepath.Base(c.Command[0])
+}
+
+// Scan gives the verdict of the scanner on the binary at binPath. It is a
+// module.BinaryScanner.
+func (c *Config) Scan(ctx context.Context, binPath string) (*pb.ScanResultProto, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	var (
+		clean  bool
+		detail string
+		err    error
+	)
+
+	if c.ICAP != "" {
+		clean, detail, err = scanICAP(ctx, c.ICAP, binPath)
+	} else {
+		clean, detail, err = c.scanCommand(ctx, binPath)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ScanResultProto{Scanner: c.Name(), Clean: clean, Detail: detail}, nil
+}
+
+// scanCommand runs the scanner command on the binary
+func (c *Config) scanCommand(ctx context.Context, binPath string) (bool, string, error) {
+	args := slices.Clone(c.Command[1:])
+
+	placed := false
+
+	for i, arg := range args {
+		if strings.Contains(arg, BinaryPlaceholder) {
+			args[i] = strings.ReplaceAll(arg, BinaryPlaceholder, binPath)
+			placed = true
+		}
+	}
+
+	if !placed {
+		args = append(args, binPath)
+	}
+
+	var out bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, c.Command[0], args...)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	err := cmd.Run()
+	detail := summarize(out.String())
+
+	if ctx.Err() != nil {
+		return false, "", fmt.Errorf("%s did not finish within %s", c.Name(), c.Timeout)
+	}
+
+	var exitErr *exec.ExitError
+
+	switch {
+	case err == nil:
+		return true, detail, nil
+	case errors.As(err, &exitErr) && slices.Contains(c.InfectedExitCodes, exitErr.ExitCode()):
+		return false, detail, nil
+	case errors.As(err, &exitErr):
+		return false, "", fmt.Errorf("%s exited with code %d: %s", c.Name(), exitErr.ExitCode(), detail)
+	default:
+		return false, "", fmt.Errorf("failed to run %s: %w", c.Name(), err)
+	}
+}
+
+// summarize trims what a scanner printed to what is kept as its verdict
+func summarize(output string) string {
+	output = strings.TrimSpace(output)
+	if len(output) > maxDetail {
+		output = output[:maxDetail] + "..."
+	}
+
+	return output
+}
+
+// Apply has installs of m scanned by the configured scanner before the
+// binary is placed, or placed right away when none is configured. An
+// invalid config fails, so a broken config never skips the scan.
+func Apply(m *module.Module) error {
+	c, err := Load(DefaultPath())
+	if err != nil {
+		return err
+	}
+
+	if c == nil {
+		m.SetScanner(nil)
+		return nil
+	}
+
+	m.SetScanner(c.Scan)
+
+	return nil
+}
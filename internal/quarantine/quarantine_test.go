@@ -0,0 +1,192 @@
+package quarantine
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, dir, name, content string, perm os.FileMode) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), perm); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	c, err := Load(filepath.Join(dir, "missing.yaml"))
+	if err != nil || c != nil {
+		t.Fatalf("Load(missing) = %v, %v; want nil, nil", c, err)
+	}
+
+	c, err = Load(writeFile(t, dir, "cmd.yaml", "command: [clamscan, --no-summary, \"{binary}\"]\n", 0644))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name() != "clamscan" || c.Timeout != DefaultTimeout || len(c.InfectedExitCodes) != 1 || c.InfectedExitCodes[0] != 1 {
+		t.Errorf("defaults not applied: %+v", c)
+	}
+
+	invalid := map[string]string{
+		"none":      "timeout: 1m\n",
+		"both":      "command: [scan]\nicap: icap://localhost/avscan\n",
+		"scheme":    "icap: http://localhost/avscan\n",
+		"zero exit": "command: [scan]\ninfected_exit_codes: [0]\n",
+	}
+
+	for name, content := range invalid {
+		if _, err := Load(writeFile(t, dir, "invalid.yaml", content, 0644)); err == nil {
+			t.Errorf("%s: Load succeeded, want error", name)
+		}
+	}
+}
+
+func TestCommandScan(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("scanner scripts need sh")
+	}
+
+	dir := t.TempDir()
+	binary := writeFile(t, dir, "tool", "binary", 0755)
+	infected := writeFile(t, dir, "infected", "EICAR", 0755)
+
+	scanner := writeFile(t, dir, "scan.sh", `#!/bin/sh
+case "$2" in
+*infected) echo "$2: Eicar-Test-Signature FOUND"; exit 1 ;;
+*missing) echo "$2: no such file"; exit 2 ;;
+esac
+echo "$2: OK"
+`, 0755)
+
+	c := &Config{Command: []string{scanner, "--no-summary", "{binary}"}}
+	if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := c.Scan(context.Background(), binary)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !result.GetClean() || result.GetScanner() != "scan.sh" || !strings.HasSuffix(result.GetDetail(), "OK") {
+		t.Errorf("clean scan = %+v", result)
+	}
+
+	result, err = c.Scan(context.Background(), infected)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.GetClean() || !strings.Contains(result.GetDetail(), "FOUND") {
+		t.Errorf("infected scan = %+v", result)
+	}
+
+	if _, err := c.Scan(context.Background(), filepath.Join(dir, "missing")); err == nil {
+		t.Error("scan with unexpected exit code succeeded, want error")
+	}
+}
+
+// serveICAP answers one RESPMOD request per connection, rejecting bodies
+// that contain EICAR
+func serveICAP(t *testing.T) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = ln.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer func() {
+					_ = conn.Close()
+				}()
+
+				tp := textproto.NewReader(bufio.NewReader(conn))
+				if _, err := tp.ReadLine(); err != nil {
+					return
+				}
+
+				if _, err := tp.ReadMIMEHeader(); err != nil {
+					return
+				}
+
+				// The encapsulated headers and the chunked body, up to the last chunk
+				var body bytes.Buffer
+
+				for !bytes.HasSuffix(body.Bytes(), []byte("0\r\n\r\n")) {
+					b, err := tp.R.ReadByte()
+					if err != nil {
+						return
+					}
+
+					body.WriteByte(b)
+				}
+
+				if bytes.Contains(body.Bytes(), []byte("EICAR")) {
+					_, _ = io.WriteString(conn, "ICAP/1.0 200 OK\r\nX-Infection-Found: Type=0; Resolution=2; Threat=Eicar-Test-Signature;\r\nEncapsulated: null-body=0\r\n\r\n")
+					return
+				}
+
+				_, _ = io.WriteString(conn, "ICAP/1.0 204 No Content\r\nEncapsulated: null-body=0\r\n\r\n")
+			}()
+		}
+	}()
+
+	return "icap://" + ln.Addr().String() + "/avscan"
+}
+
+func TestICAPScan(t *testing.T) {
+	dir := t.TempDir()
+	binary := writeFile(t, dir, "tool", strings.Repeat("binary", 20000), 0755)
+	infected := writeFile(t, dir, "infected", "X5O!P%@AP EICAR-STANDARD-ANTIVIRUS-TEST-FILE", 0755)
+
+	c := &Config{ICAP: serveICAP(t), Timeout: 10 * time.Second}
+	if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := c.Scan(context.Background(), binary)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !result.GetClean() {
+		t.Errorf("clean scan = %+v", result)
+	}
+
+	result, err = c.Scan(context.Background(), infected)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.GetClean() || result.GetDetail() != "Eicar-Test-Signature" {
+		t.Errorf("infected scan = %+v", result)
+	}
+}
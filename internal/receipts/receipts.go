@@ -0,0 +1,211 @@
+// Package receipts writes a receipt for every tool glix installs, listing
+// the binary and shim it installed for the tool, so a tool can be
+// uninstalled cleanly even when the database no longer knows it. Captured
+// help is kept in the shared help cache and not listed.
+package receipts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/inovacc/glix/internal/manifest"
+	"github.com/inovacc/glix/internal/module"
+)
+
+// Version is the version of the receipt format
+const Version = 1
+
+// Kinds of the files a receipt lists
+const (
+	KindBinary = "binary"
+	KindShim   = "shim"
+)
+
+// File is a file glix created for a tool
+type File struct {
+	Path   string `json:"path"`
+	Kind   string `json:"kind"`
+	SHA256 string `json:"sha256,omitempty"` // sha256:<hex> when it was written
+}
+
+// Receipt lists the files of an installed tool
+type Receipt struct {
+	Version     int       `json:"receipt_version"`
+	Module      string    `json:"module"`
+	ModVersion  string    `json:"version"`
+	Namespace   string    `json:"namespace"`
+	InstalledBy string    `json:"installed_by"`
+	Installed   time.Time `json:"installed"`
+	Files       []File    `json:"files"`
+}
+
+// InstalledFiles returns the files of an install: its binary, and its shim
+// when it was installed with one
+func InstalledFiles(binaryPath, shimPath string) []File {
+	files := []File{{Path: binaryPath, Kind: KindBinary}}
+	if shimPath != "" {
+		files = append(files, File{Path: shimPath, Kind: KindShim})
+	}
+
+	return files
+}
+
+// receiptStore reads and writes the receipts of the namespaces of the user
+type receiptStore struct {
+	dir string
+}
+
+// GetStore returns the store of receipts in the config directory
+func GetStore() *receiptStore {
+	configDir, err := module.GetApplicationConfigDirectory()
+	if err != nil {
+		// Fallback to cache directory
+		configDir, _ = module.GetApplicationCacheDirectory()
+	}
+
+	return &receiptStore{dir: filepath.Join(configDir, "receipts")}
+}
+
+// path returns the receipt file of a module installed by the server of
+// namespace; the servers of several namespaces may install the same module
+func (s *receiptStore) path(namespace, name string) string {
+	return filepath.Join(s.dir, url.PathEscape(namespace), url.PathEscape(name)+".json")
+}
+
+// Write records the files of a module installed by namespace, replacing
+// its earlier receipt. Files are hashed as they are now.
+func (s *receiptStore) Write(namespace, name, version string, files ...File) error {
+	r := Receipt{
+		Version:     Version,
+		Module:      name,
+		ModVersion:  version,
+		Namespace:   namespace,
+		InstalledBy: "glix",
+		Installed:   time.Now(),
+	}
+
+	for _, f := range files {
+		if f.Path == "" {
+			continue
+		}
+
+		f.Path = filepath.Clean(f.Path)
+		if f.SHA256 == "" {
+			f.SHA256, _ = manifest.HashFile(f.Path)
+		}
+
+		r.Files = append(r.Files, f)
+	}
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal receipt: %w", err)
+	}
+
+	path := s.path(namespace, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create receipts directory: %w", err)
+	}
+
+	// Write a whole receipt or none, even when interrupted
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write receipt: %w", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write receipt: %w", err)
+	}
+
+	return nil
+}
+
+// Read returns the receipt of a module installed by namespace, and false
+// when there is none
+func (s *receiptStore) Read(namespace, name string) (Receipt, bool, error) {
+	data, err := os.ReadFile(s.path(namespace, name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Receipt{}, false, nil
+		}
+
+		return Receipt{}, false, fmt.Errorf("failed to read receipt of %s: %w", name, err)
+	}
+
+	var r Receipt
+	if err := json.Unmarshal(data, &r); err != nil {
+		return Receipt{}, false, fmt.Errorf("failed to parse receipt of %s: %w", name, err)
+	}
+
+	return r, true, nil
+}
+
+// Delete removes the receipt of a module installed by namespace
+func (s *receiptStore) Delete(namespace, name string) error {
+	if err := os.Remove(s.path(namespace, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete receipt of %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Binary returns the path of the binary the receipt lists, or "" when it
+// lists none
+func (r Receipt) Binary() string {
+	return r.path(KindBinary)
+}
+
+// Shim returns the path of the shim the receipt lists, or "" when the tool
+// was installed without one
+func (r Receipt) Shim() string {
+	return r.path(KindShim)
+}
+
+func (r Receipt) path(kind string) string {
+	for _, f := range r.Files {
+		if f.Kind == kind {
+			return f.Path
+		}
+	}
+
+	return ""
+}
+
+// Uninstall deletes the files of the receipt. Files changed since they were
+// written belong to someone else now and are kept unless force is set.
+// Each step is reported to progress; it returns whether the binary was
+// deleted.
+func (r Receipt) Uninstall(force bool, progress func(phase, message string)) bool {
+	removed := false
+
+	for _, f := range r.Files {
+		if _, err := os.Stat(f.Path); err != nil {
+			progress(f.Kind, fmt.Sprintf("Already gone: %s", f.Path))
+			continue
+		}
+
+		if hash, err := manifest.HashFile(f.Path); !force && err == nil && f.SHA256 != "" && hash != f.SHA256 {
+			progress("warning", fmt.Sprintf("%s changed since glix installed it; leaving it in place", f.Path))
+			continue
+		}
+
+		if err := os.Remove(f.Path); err != nil {
+			progress("warning", fmt.Sprintf("failed to remove %s: %v", f.Path, err))
+			continue
+		}
+
+		progress(f.Kind, fmt.Sprintf("Removed: %s", f.Path))
+
+		if f.Kind == KindBinary {
+			removed = true
+		}
+	}
+
+	return removed
+}
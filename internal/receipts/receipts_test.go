@@ -0,0 +1,114 @@
+package receipts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTestStore(t *testing.T) *receiptStore {
+	t.Helper()
+
+	return &receiptStore{dir: filepath.Join(t.TempDir(), "receipts")}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	s := newTestStore(t)
+
+	binary := filepath.Join(t.TempDir(), "tool")
+	writeFile(t, binary, "bin")
+
+	if _, ok, err := s.Read("home", "example.com/tool"); ok || err != nil {
+		t.Fatalf("Read() before Write = %v, %v; want no receipt", ok, err)
+	}
+
+	if err := s.Write("home", "example.com/tool", "v1.0.0", File{Path: binary, Kind: KindBinary}, File{Kind: KindShim}); err != nil {
+		t.Fatal(err)
+	}
+
+	r, ok, err := s.Read("home", "example.com/tool")
+	if !ok || err != nil {
+		t.Fatalf("Read() = %v, %v", ok, err)
+	}
+
+	if r.Version != Version || r.ModVersion != "v1.0.0" || r.InstalledBy != "glix" || len(r.Files) != 1 {
+		t.Errorf("Read() = %+v", r)
+	}
+
+	if r.Binary() != binary || r.Files[0].SHA256 == "" {
+		t.Errorf("binary = %q, %q; want it hashed", r.Binary(), r.Files[0].SHA256)
+	}
+
+	// Receipts are kept per namespace
+	if _, ok, _ := s.Read("work", "example.com/tool"); ok {
+		t.Error("Read() found the receipt of another namespace")
+	}
+
+	if err := s.Delete("home", "example.com/tool"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok, _ := s.Read("home", "example.com/tool"); ok {
+		t.Error("Read() found a deleted receipt")
+	}
+
+	if err := s.Delete("home", "example.com/tool"); err != nil {
+		t.Errorf("Delete() of a missing receipt = %v", err)
+	}
+}
+
+func TestUninstall(t *testing.T) {
+	s := newTestStore(t)
+	dir := t.TempDir()
+
+	binary := filepath.Join(dir, "tool")
+	shim := filepath.Join(dir, "tool-shim")
+	missing := filepath.Join(dir, "tool-old")
+
+	writeFile(t, binary, "bin")
+	writeFile(t, shim, "shim")
+	writeFile(t, missing, "old")
+
+	err := s.Write("home", "example.com/tool", "v1.0.0",
+		File{Path: binary, Kind: KindBinary},
+		File{Path: shim, Kind: KindShim},
+		File{Path: missing, Kind: KindBinary})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, _, _ := s.Read("home", "example.com/tool")
+
+	// A shim rewritten by someone else is left in place
+	writeFile(t, shim, "other")
+
+	if err := os.Remove(missing); err != nil {
+		t.Fatal(err)
+	}
+
+	if !r.Uninstall(false, func(string, string) {}) {
+		t.Error("Uninstall() did not delete the binary")
+	}
+
+	if _, err := os.Stat(binary); !os.IsNotExist(err) {
+		t.Error("binary still exists")
+	}
+
+	if _, err := os.Stat(shim); err != nil {
+		t.Error("Uninstall() deleted a changed file")
+	}
+
+	r.Uninstall(true, func(string, string) {})
+
+	if _, err := os.Stat(shim); !os.IsNotExist(err) {
+		t.Error("Uninstall(force) kept a changed file")
+	}
+}
@@ -0,0 +1,216 @@
+// Package remove uninstalls modules. The CLI and the dashboard both remove
+// through Run, so hooks, binary owners and install receipts are honored
+// whichever one asks.
+package remove
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/inovacc/glix/internal/hooks"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/owners"
+	"github.com/inovacc/glix/internal/receipts"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Options selects what to remove
+type Options struct {
+	Module  string
+	Version string // Empty removes the installed version
+
+	// Force deletes the binary even if it is not a build of Version or
+	// changed since it was installed
+	Force bool
+}
+
+// Run removes a module: its binary or shim, its install receipt, captured
+// help, stored versions and database entry, running the pre- and
+// post-remove hooks around it. A version other than the installed one is
+// only dropped from the install history. Steps are reported to progress and
+// a one-line summary to status.
+func Run(ctx context.Context, client pb.GlixServiceClient, opts Options, progress module.ProgressHandler, status func(text string)) error {
+	modulePath, version := opts.Module, opts.Version
+
+	var namespace string
+	if st, err := client.GetStatus(ctx, &emptypb.Empty{}); err == nil {
+		namespace = st.GetNamespace()
+	}
+
+	var (
+		kubectlPlugin, alias, binDir, binPath, shimPath string
+		unknown                                         bool
+		hook                                            = module.HookInfo{Module: modulePath}
+	)
+
+	if resp, err := client.GetModule(ctx, &pb.GetModuleRequest{Name: modulePath, Version: version}); err == nil {
+		unknown = !resp.GetFound()
+		kubectlPlugin = resp.GetModule().GetKubectlPlugin()
+		alias = resp.GetModule().GetAlias()
+		binDir = resp.GetModule().GetBinDir()
+		shimPath = resp.GetModule().GetShimPath()
+
+		if resp.GetFound() {
+			_, binPath = module.RecordedBinary(resp.GetModule())
+			hook.RootModule = resp.GetModule().GetRootModule()
+			hook.Version = resp.GetModule().GetVersion()
+			hook.BinaryHash = resp.GetModule().GetBinaryHash()
+
+			if installed := resp.GetModule().GetVersion(); version != "" && installed != version {
+				return removeTrackedVersion(ctx, client, modulePath, version, installed, progress, status)
+			}
+		}
+	}
+
+	// The receipt lists the files installed for the module, and is all that
+	// is left of it when the database lost it
+	receipt, hasReceipt, err := receipts.GetStore().Read(namespace, modulePath)
+	if err != nil {
+		progress("warning", err.Error())
+	}
+
+	if hasReceipt && binPath == "" {
+		binPath = receipt.Binary()
+		shimPath = receipt.Shim()
+	}
+
+	// The shim of a shimmed module is what sits in GOBIN
+	owned := cmp.Or(shimPath, binPath)
+
+	if unknown && hasReceipt && version != "" && receipt.ModVersion != version {
+		return fmt.Errorf("%s@%s is not installed; its install receipt is of %s", modulePath, version, receipt.ModVersion)
+	}
+
+	// The binary must be a build of the version asked for, not of one
+	// installed since by other means
+	if _, err := os.Stat(binPath); err == nil && version != "" && !opts.Force {
+		if reason := module.ReplacedBy(binPath, modulePath, version); reason != "" {
+			return fmt.Errorf("%s is not %s@%s (%s); remove %s without a version, or pass --force to delete it anyway",
+				binPath, modulePath, version, reason, modulePath)
+		}
+	}
+
+	hook.Binary = binPath
+	if hook.Version == "" && hasReceipt {
+		hook.Version = receipt.ModVersion
+	}
+
+	// Hooks run for modules there is something of to remove
+	runHooks := !unknown || hasReceipt
+
+	if runHooks {
+		if err := hooks.Run(ctx, module.HookPreRemove, hook, progress); err != nil {
+			return err
+		}
+	}
+
+	// Try to remove binary from GOBIN
+	progress("binary", "Removing binary from GOBIN...")
+
+	// A binary another namespace took over is left to that namespace
+	if owner, conflict := owners.GetStore().Conflict(owned, namespace); conflict {
+		progress("warning", fmt.Sprintf("%s is managed by %s now; leaving it installed", owned, owner))
+	} else if hasReceipt {
+		receipt.Uninstall(opts.Force, progress)
+
+		if owned != "" {
+			_ = owners.GetStore().Release(owned, namespace)
+		}
+	} else {
+		var binaryRemoved bool
+		if shimPath != "" {
+			binaryRemoved = os.Remove(shimPath) == nil
+		} else {
+			binaryRemoved = module.RemoveInstalledBinaries(modulePath, kubectlPlugin, alias, binDir, progress)
+		}
+
+		if !binaryRemoved {
+			progress("binary", "Binary not found in GOBIN")
+		}
+
+		if owned != "" {
+			_ = owners.GetStore().Release(owned, namespace)
+		}
+	}
+
+	if unknown && hasReceipt {
+		progress("database", "Not in the database; removed the files of its install receipt")
+	} else {
+		// Remove from database
+		progress("database", "Removing from database...")
+
+		resp, err := client.Remove(ctx, &pb.RemoveRequest{ModulePath: modulePath, Version: version})
+		if err != nil {
+			return fmt.Errorf("failed to remove module from database: %w", err)
+		}
+
+		if !resp.GetSuccess() {
+			return fmt.Errorf("failed to remove module: %s", resp.GetErrorMessage())
+		}
+	}
+
+	if hasReceipt {
+		if err := receipts.GetStore().Delete(namespace, modulePath); err != nil {
+			progress("warning", err.Error())
+		}
+	}
+
+	if err := module.RemoveHelp(modulePath); err != nil {
+		progress("warning", err.Error())
+	}
+
+	if err := module.RemoveStore(modulePath); err != nil {
+		progress("warning", err.Error())
+	}
+
+	if runHooks {
+		if err := hooks.Run(ctx, module.HookPostRemove, hook, progress); err != nil {
+			progress("warning", err.Error())
+		}
+	}
+
+	progress("complete", "Module removed successfully")
+	status(fmt.Sprintf("Removed %s", modulePath))
+
+	return nil
+}
+
+// removeTrackedVersion removes a version of a module other than the
+// installed one from the install history, with its cached binary. The binary
+// on disk is a build of the installed version and stays.
+func removeTrackedVersion(
+	ctx context.Context,
+	client pb.GlixServiceClient,
+	modulePath, version, installed string,
+	progress module.ProgressHandler,
+	status func(text string),
+) error {
+	progress("binary", fmt.Sprintf("%s is installed at %s; keeping its binary", modulePath, installed))
+
+	if err := module.UncacheBinary(modulePath, version); err != nil {
+		progress("warning", err.Error())
+	}
+
+	if err := module.RemoveStoredVersion(modulePath, version); err != nil {
+		progress("warning", err.Error())
+	}
+
+	progress("database", fmt.Sprintf("Removing %s from the install history...", version))
+
+	resp, err := client.Remove(ctx, &pb.RemoveRequest{ModulePath: modulePath, Version: version})
+	if err != nil {
+		return fmt.Errorf("failed to remove module from database: %w", err)
+	}
+
+	if !resp.GetSuccess() {
+		return fmt.Errorf("failed to remove module: %s", resp.GetErrorMessage())
+	}
+
+	progress("complete", fmt.Sprintf("Removed %s@%s from the install history", modulePath, version))
+	status(fmt.Sprintf("Removed %s@%s", modulePath, version))
+
+	return nil
+}
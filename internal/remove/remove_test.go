@@ -0,0 +1,72 @@
+package remove
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// fakeClient serves one installed module and records removals.
+// Unimplemented methods panic.
+type fakeClient struct {
+	pb.GlixServiceClient
+
+	module  *pb.ModuleProto
+	removed []*pb.RemoveRequest
+}
+
+func (f *fakeClient) GetStatus(context.Context, *emptypb.Empty, ...grpc.CallOption) (*pb.ServerStatus, error) {
+	return &pb.ServerStatus{Namespace: "test"}, nil
+}
+
+func (f *fakeClient) GetModule(_ context.Context, req *pb.GetModuleRequest, _ ...grpc.CallOption) (*pb.GetModuleResponse, error) {
+	if req.GetName() != f.module.GetName() {
+		return &pb.GetModuleResponse{}, nil
+	}
+
+	return &pb.GetModuleResponse{Found: true, Module: f.module}, nil
+}
+
+func (f *fakeClient) Remove(_ context.Context, req *pb.RemoveRequest, _ ...grpc.CallOption) (*pb.RemoveResponse, error) {
+	f.removed = append(f.removed, req)
+
+	return &pb.RemoveResponse{Success: true}, nil
+}
+
+func TestRunTrackedVersion(t *testing.T) {
+	binary := filepath.Join(t.TempDir(), "tool")
+	if err := os.WriteFile(binary, []byte("bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	client := &fakeClient{module: &pb.ModuleProto{
+		Name:       "example.invalid/glix-remove-test/tool",
+		Version:    "v1.1.0",
+		BinaryName: "tool",
+		BinaryPath: binary,
+	}}
+
+	var status string
+
+	opts := Options{Module: "example.invalid/glix-remove-test/tool", Version: "v1.0.0"}
+	if err := Run(context.Background(), client, opts, func(string, string) {}, func(text string) { status = text }); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(client.removed) != 1 || client.removed[0].GetVersion() != "v1.0.0" {
+		t.Errorf("removed = %v, want only v1.0.0", client.removed)
+	}
+
+	if _, err := os.Stat(binary); err != nil {
+		t.Errorf("binary of the installed version was deleted: %v", err)
+	}
+
+	if want := "Removed example.invalid/glix-remove-test/tool@v1.0.0"; status != want {
+		t.Errorf("status = %q, want %q", status, want)
+	}
+}
@@ -0,0 +1,166 @@
+// Package search finds Go packages by keyword using the pkg.go.dev search
+// index, so tools can be discovered without knowing their import path.
+package search
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// DefaultBaseURL is the package index queried by Search
+const DefaultBaseURL = "https://pkg.go.dev"
+
+// DefaultLimit is the number of results returned when no limit is given
+const DefaultLimit = 10
+
+// maxLimit is the largest page pkg.go.dev serves
+const maxLimit = 100
+
+// BaseURL can be overridden to point at a mirror of pkg.go.dev
+var BaseURL = DefaultBaseURL
+
+var httpClient = &http.Client{Timeout: 20 * time.Second}
+
+// Result is a single search hit
+type Result struct {
+	Path     string
+	Synopsis string
+	Version  string
+}
+
+// Search queries the package index for packages matching query
+func Search(ctx context.Context, query string, limit int) ([]Result, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("search query is empty")
+	}
+
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	limit = min(limit, maxLimit)
+
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("m", "package")
+	params.Set("limit", strconv.Itoa(limit))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(BaseURL, "/")+"/search?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "text/html")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("search request failed: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("search request failed: %s", resp.Status)
+	}
+
+	results, err := parseResults(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
+
+// parseResults extracts results from a pkg.go.dev search page. Each hit is a
+// "SearchSnippet" element holding a title link, a synopsis and an info line
+// with the latest version.
+func parseResults(r io.Reader) ([]Result, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse search results: %w", err)
+	}
+
+	var results []Result
+
+	for n := range doc.Descendants() {
+		if n.Type != html.ElementNode || !hasClass(n, "SearchSnippet") {
+			continue
+		}
+
+		if res, ok := parseSnippet(n); ok {
+			results = append(results, res)
+		}
+	}
+
+	return results, nil
+}
+
+func parseSnippet(snippet *html.Node) (Result, bool) {
+	var res Result
+
+	for n := range snippet.Descendants() {
+		if n.Type != html.ElementNode {
+			continue
+		}
+
+		switch {
+		case n.Data == "a" && attr(n, "data-test-id") == "snippet-title":
+			res.Path = strings.TrimPrefix(attr(n, "href"), "/")
+		case n.Data == "p" && attr(n, "data-test-id") == "snippet-synopsis":
+			res.Synopsis = collapseSpace(text(n))
+		case n.Data == "strong" && res.Version == "":
+			// The info line shows the version as the first bolded "vX.Y.Z"
+			if t := strings.TrimSpace(text(n)); strings.HasPrefix(t, "v") && strings.Contains(t, ".") {
+				res.Version = t
+			}
+		}
+	}
+
+	return res, res.Path != ""
+}
+
+func hasClass(n *html.Node, class string) bool {
+	return slices.Contains(strings.Fields(attr(n, "class")), class)
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+
+	return ""
+}
+
+func text(n *html.Node) string {
+	var b strings.Builder
+
+	for d := range n.Descendants() {
+		if d.Type == html.TextNode {
+			b.WriteString(d.Data)
+		}
+	}
+
+	return b.String()
+}
+
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,90 @@
+package search
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const searchPage = `<!DOCTYPE html>
+<html><body>
+<div class="SearchResults">
+  <div class="SearchSnippet">
+    <div class="SearchSnippet-headerContainer">
+      <h2>
+        <a href="/github.com/spf13/cobra/cobra" data-gtmc="search result" data-test-id="snippet-title">
+          cobra
+          <span class="SearchSnippet-header-path">(github.com/spf13/cobra/cobra)</span>
+        </a>
+      </h2>
+    </div>
+    <p class="SearchSnippet-synopsis" data-test-id="snippet-synopsis">
+      Cobra CLI generator
+      for applications.
+    </p>
+    <div class="SearchSnippet-infoLabel">
+      <a href="/github.com/spf13/cobra/cobra?tab=importedby"><span>Imported by </span><strong>12</strong></a>
+      <span><strong>v1.8.1</strong> published on Jun 1, 2024</span>
+    </div>
+  </div>
+  <div class="SearchSnippet">
+    <h2><a href="/example.com/tool" data-test-id="snippet-title">tool</a></h2>
+  </div>
+</div>
+</body></html>`
+
+func TestParseResults(t *testing.T) {
+	results, err := parseResults(strings.NewReader(searchPage))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2: %+v", len(results), results)
+	}
+
+	want := Result{
+		Path:     "github.com/spf13/cobra/cobra",
+		Synopsis: "Cobra CLI generator for applications.",
+		Version:  "v1.8.1",
+	}
+	if results[0] != want {
+		t.Errorf("results[0] = %+v, want %+v", results[0], want)
+	}
+
+	if results[1].Path != "example.com/tool" || results[1].Synopsis != "" {
+		t.Errorf("results[1] = %+v", results[1])
+	}
+}
+
+func TestSearch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search" || r.URL.Query().Get("q") != "cobra" || r.URL.Query().Get("m") != "package" {
+			http.NotFound(w, r)
+			return
+		}
+
+		_, _ = w.Write([]byte(searchPage))
+	}))
+	defer srv.Close()
+
+	old := BaseURL
+	BaseURL = srv.URL
+
+	defer func() { BaseURL = old }()
+
+	results, err := Search(context.Background(), "cobra", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 1 || results[0].Path != "github.com/spf13/cobra/cobra" {
+		t.Errorf("results = %+v", results)
+	}
+
+	if _, err := Search(context.Background(), "  ", 0); err == nil {
+		t.Error("empty query should fail")
+	}
+}
@@ -0,0 +1,272 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Scopes of API tokens, each allowing the methods of the ones before it
+const (
+	ScopeRead    = "read"    // Queries: list, info, history, status
+	ScopeInstall = "install" // Recording installs, updates and removes
+	ScopeAdmin   = "admin"   // Everything, including tasks and tokens
+)
+
+// Scopes lists the token scopes from least to most privileged
+var Scopes = []string{ScopeRead, ScopeInstall, ScopeAdmin}
+
+// tokenPrefix starts every API token, so leaked tokens are easy to find
+const tokenPrefix = "glix_"
+
+// tokenUseInterval is how often the last use of a token is recorded
+const tokenUseInterval = time.Minute
+
+// methodScopes are the scopes methods need. Methods not listed, such as
+// those of server reflection, need ScopeAdmin.
+var methodScopes = map[string]string{
+	pb.GlixService_ListModules_FullMethodName:         ScopeRead,
+	pb.GlixService_GetModule_FullMethodName:           ScopeRead,
+	pb.GlixService_GetDependencies_FullMethodName:     ScopeRead,
+	pb.GlixService_GetLatestVersions_FullMethodName:   ScopeRead,
+	pb.GlixService_Search_FullMethodName:              ScopeRead,
+	pb.GlixService_VerifyBinaries_FullMethodName:      ScopeRead,
+	pb.GlixService_GetInstallHistory_FullMethodName:   ScopeRead,
+	pb.GlixService_GetHistory_FullMethodName:          ScopeRead,
+	pb.GlixService_ListVulnReports_FullMethodName:     ScopeRead,
+	pb.GlixService_GetVersionCache_FullMethodName:     ScopeRead,
+	pb.GlixService_StoreVersionCache_FullMethodName:   ScopeRead, // Caches answers of public proxies
+	pb.GlixService_ListLibraryWatches_FullMethodName:  ScopeRead,
+	pb.GlixService_GetAutoUpdateConfig_FullMethodName: ScopeRead,
+	pb.GlixService_GetStats_FullMethodName:            ScopeRead,
+	pb.GlixService_GetSnapshot_FullMethodName:         ScopeRead,
+	pb.GlixService_ListSnapshots_FullMethodName:       ScopeRead,
+	pb.GlixService_ListInventories_FullMethodName:     ScopeRead,
+	pb.GlixService_ListTasks_FullMethodName:           ScopeRead,
+	pb.GlixService_GetStatus_FullMethodName:           ScopeRead,
+	pb.GlixService_Ping_FullMethodName:                ScopeRead,
+
+	pb.GlixService_StoreModule_FullMethodName:         ScopeInstall,
+	pb.GlixService_Remove_FullMethodName:              ScopeInstall,
+	pb.GlixService_MarkBadVersion_FullMethodName:      ScopeInstall,
+	pb.GlixService_SetAlias_FullMethodName:            ScopeInstall,
+	pb.GlixService_RecordEvent_FullMethodName:         ScopeInstall,
+	pb.GlixService_StoreVulnReport_FullMethodName:     ScopeInstall,
+	pb.GlixService_StoreLibraryWatch_FullMethodName:   ScopeInstall,
+	pb.GlixService_RemoveLibraryWatch_FullMethodName:  ScopeInstall,
+	pb.GlixService_CreateSnapshot_FullMethodName:      ScopeInstall,
+	pb.GlixService_AggregateInventory_FullMethodName:  ScopeInstall,
+	pb.GlixService_SetAutoUpdateConfig_FullMethodName: ScopeInstall, // Auto-updates install what install tokens may
+}
+
+// tokenMethods manage tokens, which clients on other machines may only do
+// with an admin token, even while no token exists
+var tokenMethods = map[string]bool{
+	pb.GlixService_CreateToken_FullMethodName: true,
+	pb.GlixService_ListTokens_FullMethodName:  true,
+	pb.GlixService_RevokeToken_FullMethodName: true,
+}
+
+// ValidScope reports whether scope is one of Scopes
+func ValidScope(scope string) bool {
+	return slices.Contains(Scopes, scope)
+}
+
+// scopeAllows reports whether a token of scope may call a method needing
+// required
+func scopeAllows(scope, required string) bool {
+	granted := slices.Index(Scopes, scope)
+	return granted >= 0 && granted >= slices.Index(Scopes, required)
+}
+
+// newToken returns a random API token and the hash it is stored by
+func newToken() (string, string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+
+	token := tokenPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	return token, hashToken(token), nil
+}
+
+// hashToken returns the hex SHA-256 of a token. Tokens are random, so a
+// fast hash protects them as well as a password hash would.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// bearerToken returns the token of the authorization header of a request
+func bearerToken(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	for _, value := range md.Get("authorization") {
+		if token, ok := strings.CutPrefix(value, "Bearer "); ok {
+			return strings.TrimSpace(token)
+		}
+	}
+
+	return ""
+}
+
+// localPeer reports whether a request comes from this machine: over
+// loopback, or from the address the server was reached at, as the daemon's
+// own auto-updates and dashboard do on a non-loopback bind address
+func localPeer(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return false
+	}
+
+	remote := addrIP(p.Addr)
+	if remote == nil {
+		return false
+	}
+
+	if remote.IsLoopback() {
+		return true
+	}
+
+	local := addrIP(p.LocalAddr)
+
+	return local != nil && local.Equal(remote)
+}
+
+// addrIP returns the IP of a TCP address
+func addrIP(addr net.Addr) net.IP {
+	if addr == nil {
+		return nil
+	}
+
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return nil
+	}
+
+	return net.ParseIP(host)
+}
+
+// authorize checks that a request may call method. A request with a token
+// is held to the token's scope. Without one, requests from this machine may
+// call everything, and those from other machines may too until the first
+// token is created, except managing tokens.
+func (s *Server) authorize(ctx context.Context, method string) error {
+	required, ok := methodScopes[method]
+	if !ok {
+		required = ScopeAdmin
+	}
+
+	value := bearerToken(ctx)
+
+	if value == "" {
+		if localPeer(ctx) {
+			return nil
+		}
+
+		if tokenMethods[method] {
+			return status.Error(codes.Unauthenticated, "managing tokens from another machine takes an admin token")
+		}
+
+		tokens, err := s.db.ListTokens()
+		if err != nil {
+			s.logger.Error("failed to list tokens", "error", err)
+			return status.Error(codes.Internal, "failed to check API tokens")
+		}
+
+		if len(tokens) > 0 {
+			return status.Error(codes.Unauthenticated, "this server requires an API token; set GLIX_TOKEN or the token file of the context")
+		}
+
+		return nil
+	}
+
+	token, err := s.lookupToken(value)
+	if err != nil {
+		return err
+	}
+
+	if !scopeAllows(token.GetScope(), required) {
+		return status.Errorf(codes.PermissionDenied, "token %q has scope %s; %s needs %s",
+			token.GetName(), token.GetScope(), method, required)
+	}
+
+	return nil
+}
+
+// lookupToken returns the unexpired stored token with the hash of value,
+// recording that it was used
+func (s *Server) lookupToken(value string) (*pb.TokenProto, error) {
+	tokens, err := s.db.ListTokens()
+	if err != nil {
+		s.logger.Error("failed to list tokens", "error", err)
+		return nil, status.Error(codes.Internal, "failed to check API tokens")
+	}
+
+	hash := hashToken(value)
+
+	i := slices.IndexFunc(tokens, func(t *pb.TokenProto) bool {
+		return subtle.ConstantTimeCompare([]byte(t.GetHash()), []byte(hash)) == 1
+	})
+	if i < 0 {
+		return nil, status.Error(codes.Unauthenticated, "invalid API token")
+	}
+
+	token := tokens[i]
+	now := time.Now()
+
+	if expires := token.GetExpiresUnixNano(); expires > 0 && now.UnixNano() >= expires {
+		return nil, status.Errorf(codes.Unauthenticated, "API token %q expired", token.GetName())
+	}
+
+	if now.Sub(time.Unix(0, token.GetLastUsedUnixNano())) >= tokenUseInterval {
+		if err := s.db.TouchToken(token.GetName(), now); err != nil {
+			s.logger.Warn("failed to record token use", "token", token.GetName(), "error", err)
+		}
+	}
+
+	return token, nil
+}
+
+// authInterceptor rejects unary RPCs the caller may not make
+func (s *Server) authInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if err := s.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// streamAuthInterceptor rejects streaming RPCs the caller may not make
+func (s *Server) streamAuthInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if err := s.authorize(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+
+	return handler(srv, ss)
+}
@@ -0,0 +1,111 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/inovacc/glix/internal/database"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthorize(t *testing.T) {
+	db, err := database.NewStorage(filepath.Join(t.TempDir(), "glix.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = db.Close()
+	}()
+
+	s := &Server{db: db, logger: slog.New(slog.DiscardHandler)}
+
+	serverAddr := &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: DefaultPort}
+
+	request := func(remote, token string) context.Context {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{
+			Addr:      &net.TCPAddr{IP: net.ParseIP(remote), Port: 50000},
+			LocalAddr: serverAddr,
+		})
+
+		if token != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+		}
+
+		return ctx
+	}
+
+	check := func(t *testing.T, ctx context.Context, method string, want codes.Code) {
+		t.Helper()
+
+		if got := status.Code(s.authorize(ctx, method)); got != want {
+			t.Errorf("authorize(%s) = %v, want %v", method, got, want)
+		}
+	}
+
+	// Until a token exists, remote clients may do anything but manage tokens
+	check(t, request("10.0.0.9", ""), pb.GlixService_RunTask_FullMethodName, codes.OK)
+	check(t, request("10.0.0.9", ""), pb.GlixService_CreateToken_FullMethodName, codes.Unauthenticated)
+
+	resp, err := s.CreateToken(context.Background(), &pb.CreateTokenRequest{Name: "ci", Scope: ScopeInstall})
+	if err != nil || resp.GetErrorMessage() != "" {
+		t.Fatalf("CreateToken = %v, %v", resp, err)
+	}
+
+	if resp.GetToken().GetHash() != "" {
+		t.Error("CreateToken returned the hash of the token")
+	}
+
+	ci := resp.GetValue()
+
+	// Clients on this machine need no token, on loopback or the bind address
+	check(t, request("127.0.0.1", ""), pb.GlixService_RevokeToken_FullMethodName, codes.OK)
+	check(t, request("10.0.0.5", ""), pb.GlixService_RunTask_FullMethodName, codes.OK)
+
+	check(t, request("10.0.0.9", ""), pb.GlixService_ListModules_FullMethodName, codes.Unauthenticated)
+	check(t, request("10.0.0.9", "glix_wrong"), pb.GlixService_ListModules_FullMethodName, codes.Unauthenticated)
+	check(t, request("10.0.0.9", ci), pb.GlixService_ListModules_FullMethodName, codes.OK)
+	check(t, request("10.0.0.9", ci), pb.GlixService_StoreModule_FullMethodName, codes.OK)
+	check(t, request("10.0.0.9", ci), pb.GlixService_RunTask_FullMethodName, codes.PermissionDenied)
+	check(t, request("10.0.0.9", ci), "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo", codes.PermissionDenied)
+
+	// A token is held to its scope on this machine too
+	check(t, request("127.0.0.1", ci), pb.GlixService_CreateToken_FullMethodName, codes.PermissionDenied)
+
+	if token, _ := db.GetToken("ci"); token.GetLastUsedUnixNano() == 0 {
+		t.Error("the use of the token was not recorded")
+	}
+
+	// Expired and revoked tokens are rejected
+	resp, _ = s.CreateToken(context.Background(), &pb.CreateTokenRequest{Name: "old", Scope: ScopeAdmin, TtlNano: int64(time.Hour)})
+	old := resp.GetValue()
+
+	check(t, request("10.0.0.9", old), pb.GlixService_CreateToken_FullMethodName, codes.OK)
+
+	token, _ := db.GetToken("old")
+	token.ExpiresUnixNano = time.Now().Add(-time.Second).UnixNano()
+
+	if err := db.SaveToken(token); err != nil {
+		t.Fatal(err)
+	}
+
+	check(t, request("10.0.0.9", old), pb.GlixService_ListModules_FullMethodName, codes.Unauthenticated)
+
+	if _, err := s.RevokeToken(context.Background(), &pb.RevokeTokenRequest{Name: "ci"}); err != nil {
+		t.Fatal(err)
+	}
+
+	check(t, request("10.0.0.9", ci), pb.GlixService_ListModules_FullMethodName, codes.Unauthenticated)
+
+	if resp, _ := s.CreateToken(context.Background(), &pb.CreateTokenRequest{Name: "x", Scope: "root"}); resp.GetErrorMessage() == "" {
+		t.Error("CreateToken accepted an unknown scope")
+	}
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/inovacc/glix/internal/autoupdate"
+	"github.com/inovacc/glix/internal/database"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// autoUpdateStore keeps the auto-update configuration in the database; the
+// daemon's scheduler and the auto-update RPCs share it
+type autoUpdateStore struct {
+	db *database.Storage
+}
+
+func (a autoUpdateStore) GetAutoUpdateConfig(_ context.Context) (*pb.AutoUpdateConfigProto, error) {
+	return a.db.GetAutoUpdateConfig()
+}
+
+func (a autoUpdateStore) SetAutoUpdateConfig(_ context.Context, req *pb.SetAutoUpdateConfigRequest) (*pb.AutoUpdateConfigProto, error) {
+	return a.db.UpdateAutoUpdateConfig(func(cfg *pb.AutoUpdateConfigProto) error {
+		return autoupdate.ApplyChange(cfg, req, time.Now())
+	})
+}
+
+// importLegacyAutoUpdateConfig moves the configuration of the JSON file
+// auto-update was configured in before into the database, once, and
+// removes the file so it cannot be mistaken for the configuration in use
+func importLegacyAutoUpdateConfig(db *database.Storage, logger *slog.Logger) {
+	if stored, err := db.GetAutoUpdateConfig(); err != nil || stored != nil {
+		return
+	}
+
+	path := autoupdate.LegacyConfigPath()
+
+	legacy, err := autoupdate.LoadLegacyConfig(path)
+	if err != nil {
+		logger.Warn("failed to import auto-update config", "path", path, "error", err)
+		return
+	}
+
+	if legacy == nil {
+		return
+	}
+
+	if _, err := db.UpdateAutoUpdateConfig(func(cfg *pb.AutoUpdateConfigProto) error {
+		proto.Merge(cfg, legacy)
+		return nil
+	}); err != nil {
+		logger.Warn("failed to import auto-update config", "path", path, "error", err)
+		return
+	}
+
+	if err := os.Remove(path); err != nil {
+		logger.Warn("failed to remove imported auto-update config", "path", path, "error", err)
+	}
+
+	logger.Info("imported auto-update config into the database", "path", path)
+}
+
+// GetAutoUpdateConfig returns the auto-update configuration and statistics
+func (s *Server) GetAutoUpdateConfig(ctx context.Context, _ *emptypb.Empty) (*pb.GetAutoUpdateConfigResponse, error) {
+	cfg, err := autoUpdateStore{db: s.db}.GetAutoUpdateConfig(ctx)
+	if err != nil {
+		return &pb.GetAutoUpdateConfigResponse{
+			ErrorMessage: err.Error(),
+		}, nil
+	}
+
+	return &pb.GetAutoUpdateConfigResponse{
+		Config: cfg,
+	}, nil
+}
+
+// SetAutoUpdateConfig changes the auto-update configuration or records a
+// check. Changes are applied one at a time.
+func (s *Server) SetAutoUpdateConfig(ctx context.Context, req *pb.SetAutoUpdateConfigRequest) (*pb.SetAutoUpdateConfigResponse, error) {
+	cfg, err := autoUpdateStore{db: s.db}.SetAutoUpdateConfig(ctx, req)
+	if err != nil {
+		return &pb.SetAutoUpdateConfigResponse{
+			ErrorMessage: err.Error(),
+		}, nil
+	}
+
+	if req.Enabled != nil {
+		s.logger.Info("auto-update configured", "enabled", cfg.GetEnabled())
+	}
+
+	return &pb.SetAutoUpdateConfigResponse{
+		Success: true,
+		Config:  cfg,
+	}, nil
+}
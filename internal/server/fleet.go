@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+// AggregateInventory stores the module inventory pushed by a workstation
+func (s *Server) AggregateInventory(ctx context.Context, req *pb.AggregateInventoryRequest) (*pb.AggregateInventoryResponse, error) {
+	inventory := req.GetInventory()
+
+	s.logger.Info("aggregate inventory request",
+		"host", inventory.GetHost(),
+		"modules", len(inventory.GetModules()),
+	)
+
+	if inventory.GetHost() == "" {
+		return &pb.AggregateInventoryResponse{
+			Success:      false,
+			ErrorMessage: "inventory host is required",
+		}, nil
+	}
+
+	if inventory.GetReportedUnixNano() == 0 {
+		inventory.ReportedUnixNano = time.Now().UnixNano()
+	}
+
+	if err := s.db.SaveInventory(inventory); err != nil {
+		return &pb.AggregateInventoryResponse{
+			Success:      false,
+			ErrorMessage: err.Error(),
+		}, nil
+	}
+
+	return &pb.AggregateInventoryResponse{
+		Success: true,
+	}, nil
+}
+
+// ListInventories returns the inventories reported to this server
+func (s *Server) ListInventories(ctx context.Context, req *pb.ListInventoriesRequest) (*pb.ListInventoriesResponse, error) {
+	s.logger.Debug("list inventories request",
+		"module", req.GetModule(),
+		"host", req.GetHost(),
+	)
+
+	inventories, err := s.db.ListInventories()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inventories: %w", err)
+	}
+
+	return &pb.ListInventoriesResponse{
+		Inventories: filterInventories(inventories, req.GetModule(), req.GetHost()),
+	}, nil
+}
+
+// filterInventories keeps inventories of host (any when empty) and, when
+// moduleFilter is set, only their modules whose path contains it; hosts
+// without a matching module are dropped
+func filterInventories(inventories []*pb.InventoryProto, moduleFilter, host string) []*pb.InventoryProto {
+	var filtered []*pb.InventoryProto
+
+	for _, inv := range inventories {
+		if host != "" && inv.GetHost() != host {
+			continue
+		}
+
+		if moduleFilter == "" {
+			filtered = append(filtered, inv)
+			continue
+		}
+
+		var modules []*pb.ModuleProto
+
+		for _, mod := range inv.GetModules() {
+			if strings.Contains(mod.GetName(), moduleFilter) {
+				modules = append(modules, mod)
+			}
+		}
+
+		if len(modules) > 0 {
+			filtered = append(filtered, &pb.InventoryProto{
+				Host:             inv.GetHost(),
+				Goos:             inv.GetGoos(),
+				Goarch:           inv.GetGoarch(),
+				ReportedUnixNano: inv.GetReportedUnixNano(),
+				Modules:          modules,
+			})
+		}
+	}
+
+	return filtered
+}
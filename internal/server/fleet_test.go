@@ -0,0 +1,36 @@
+package server
+
+import (
+	"testing"
+
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+func TestFilterInventories(t *testing.T) {
+	inventories := []*pb.InventoryProto{
+		{Host: "ws-1", Modules: []*pb.ModuleProto{
+			{Name: "github.com/golangci/golangci-lint/cmd/golangci-lint", Version: "v1.55.0"},
+			{Name: "github.com/spf13/cobra-cli", Version: "v1.3.0"},
+		}},
+		{Host: "ws-2", Modules: []*pb.ModuleProto{
+			{Name: "github.com/spf13/cobra-cli", Version: "v1.3.0"},
+		}},
+	}
+
+	if got := filterInventories(inventories, "", ""); len(got) != 2 {
+		t.Errorf("no filter: got %d inventories, want 2", len(got))
+	}
+
+	got := filterInventories(inventories, "golangci-lint", "")
+	if len(got) != 1 || got[0].GetHost() != "ws-1" || len(got[0].GetModules()) != 1 {
+		t.Errorf("module filter: got %v", got)
+	}
+
+	if len(inventories[0].GetModules()) != 2 {
+		t.Error("filtering must not modify the stored inventory")
+	}
+
+	if got := filterInventories(inventories, "cobra-cli", "ws-2"); len(got) != 1 || got[0].GetHost() != "ws-2" {
+		t.Errorf("host filter: got %v", got)
+	}
+}
@@ -3,7 +3,13 @@ package server
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/inovacc/glix/internal/search"
 	pb "github.com/inovacc/glix/pkg/api/v1"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
@@ -15,14 +21,59 @@ func (s *Server) StoreModule(ctx context.Context, req *pb.StoreModuleRequest) (*
 		"version", req.GetModule().GetVersion(),
 	)
 
+	mod := req.GetModule()
+
+	event := &pb.EventProto{
+		Action:    pb.EventAction_EVENT_ACTION_INSTALL,
+		Name:      mod.GetName(),
+		ToVersion: mod.GetVersion(),
+		Success:   true,
+		Scan:      mod.GetScan(),
+		Build:     mod.GetBuild(),
+	}
+
+	// A refreshed module whose package moved is stored under its new name
+	stored := mod.GetName()
+	renamed := req.GetRenamedFrom() != "" && req.GetRenamedFrom() != stored
+
+	if renamed {
+		stored = req.GetRenamedFrom()
+	}
+
+	// The CLI sends freshly built records; keep what only the server tracks
+	if existing, err := s.db.GetModule(stored, ""); err == nil {
+		carryOverHistory(existing, mod)
+
+		if existing.GetVersion() != mod.GetVersion() {
+			event.Action = pb.EventAction_EVENT_ACTION_UPDATE
+			event.FromVersion = existing.GetVersion()
+		}
+	}
+
 	// Store module
-	if err := s.db.UpsertModule(req.GetModule()); err != nil {
+	var err error
+	if renamed {
+		err = s.db.RenameModule(stored, mod)
+	} else {
+		err = s.db.UpsertModule(mod)
+	}
+
+	if err != nil {
 		return &pb.StoreModuleResponse{
 			Success:      false,
 			ErrorMessage: fmt.Sprintf("failed to store module: %v", err),
 		}, nil
 	}
 
+	if err := s.db.AppendInstallHistory(mod); err != nil {
+		s.logger.Warn("failed to record install history", "error", err)
+	}
+
+	// A refresh installs nothing
+	if !req.GetRefresh() {
+		s.recordEvent(event)
+	}
+
 	// Store dependencies if provided
 	if req.GetDependencies() != nil && len(req.GetDependencies().GetDependencies()) > 0 {
 		if err := s.db.UpsertDependencies(req.GetModule().GetName(), req.GetDependencies()); err != nil {
@@ -35,6 +86,373 @@ func (s *Server) StoreModule(ctx context.Context, req *pb.StoreModuleRequest) (*
 	}, nil
 }
 
+// carryOverHistory copies server-maintained fields from the stored record to
+// an incoming one: the previous version and the versions reported broken
+func carryOverHistory(existing, incoming *pb.ModuleProto) {
+	if existing.GetVersion() != incoming.GetVersion() {
+		incoming.PreviousVersion = existing.GetVersion()
+	} else if incoming.GetPreviousVersion() == "" {
+		incoming.PreviousVersion = existing.GetPreviousVersion()
+	}
+
+	for _, v := range existing.GetBadVersions() {
+		if !slices.Contains(incoming.GetBadVersions(), v) {
+			incoming.BadVersions = append(incoming.BadVersions, v)
+		}
+	}
+}
+
+// MarkBadVersion records a version of an installed module as broken so it is
+// never picked by updates again
+func (s *Server) MarkBadVersion(ctx context.Context, req *pb.MarkBadVersionRequest) (*pb.MarkBadVersionResponse, error) {
+	s.logger.Info("mark bad version request",
+		"name", req.GetName(),
+		"version", req.GetVersion(),
+	)
+
+	mod, err := s.db.GetModule(req.GetName(), "")
+	if err != nil {
+		return &pb.MarkBadVersionResponse{
+			Success:      false,
+			ErrorMessage: fmt.Sprintf("module not found: %s", req.GetName()),
+		}, nil
+	}
+
+	if req.GetVersion() == "" {
+		return &pb.MarkBadVersionResponse{
+			Success:      false,
+			ErrorMessage: "version is required",
+		}, nil
+	}
+
+	if !slices.Contains(mod.GetBadVersions(), req.GetVersion()) {
+		mod.BadVersions = append(mod.BadVersions, req.GetVersion())
+
+		if err := s.db.UpsertModule(mod); err != nil {
+			return &pb.MarkBadVersionResponse{
+				Success:      false,
+				ErrorMessage: fmt.Sprintf("failed to store module: %v", err),
+			}, nil
+		}
+	}
+
+	return &pb.MarkBadVersionResponse{
+		Module:  mod,
+		Success: true,
+	}, nil
+}
+
+// SetAlias records the binary name of an installed module after the CLI
+// renamed its binary. It is not an install, so no event or install history
+// is recorded.
+func (s *Server) SetAlias(ctx context.Context, req *pb.SetAliasRequest) (*pb.SetAliasResponse, error) {
+	s.logger.Info("set alias request",
+		"name", req.GetName(),
+		"alias", req.GetAlias(),
+	)
+
+	mod, err := s.db.GetModule(req.GetName(), "")
+	if err != nil {
+		return &pb.SetAliasResponse{
+			Success:      false,
+			ErrorMessage: fmt.Sprintf("module not found: %s", req.GetName()),
+		}, nil
+	}
+
+	if req.GetBinaryPath() == "" {
+		return &pb.SetAliasResponse{
+			Success:      false,
+			ErrorMessage: "binary path is required",
+		}, nil
+	}
+
+	// An alias replaces a kubectl-<name> registration
+	mod.Alias = req.GetAlias()
+	mod.KubectlPlugin = ""
+	mod.BinaryPath = req.GetBinaryPath()
+	mod.BinaryName = strings.TrimSuffix(filepath.Base(req.GetBinaryPath()), ".exe")
+
+	if err := s.db.UpsertModule(mod); err != nil {
+		return &pb.SetAliasResponse{
+			Success:      false,
+			ErrorMessage: fmt.Sprintf("failed to store module: %v", err),
+		}, nil
+	}
+
+	return &pb.SetAliasResponse{
+		Module:  mod,
+		Success: true,
+	}, nil
+}
+
+// GetInstallHistory returns the records of the versions a module has had
+// installed, oldest first
+func (s *Server) GetInstallHistory(ctx context.Context, req *pb.GetInstallHistoryRequest) (*pb.GetInstallHistoryResponse, error) {
+	installs, err := s.db.GetInstallHistory(req.GetName())
+	if err != nil {
+		return &pb.GetInstallHistoryResponse{
+			ErrorMessage: fmt.Sprintf("failed to get install history: %v", err),
+		}, nil
+	}
+
+	return &pb.GetInstallHistoryResponse{
+		Installs: installs,
+	}, nil
+}
+
+// RecordEvent records a change made by a client, typically one that failed
+// before anything was stored
+func (s *Server) RecordEvent(ctx context.Context, req *pb.RecordEventRequest) (*pb.RecordEventResponse, error) {
+	event := req.GetEvent()
+
+	if event.GetName() == "" || event.GetAction() == pb.EventAction_EVENT_ACTION_UNSPECIFIED {
+		return &pb.RecordEventResponse{
+			ErrorMessage: "event requires a module name and an action",
+		}, nil
+	}
+
+	if err := s.db.AppendEvent(event); err != nil {
+		return &pb.RecordEventResponse{
+			ErrorMessage: fmt.Sprintf("failed to record event: %v", err),
+		}, nil
+	}
+
+	return &pb.RecordEventResponse{
+		Success: true,
+	}, nil
+}
+
+// GetHistory returns recorded events, newest first
+func (s *Server) GetHistory(ctx context.Context, req *pb.GetHistoryRequest) (*pb.GetHistoryResponse, error) {
+	events, err := s.db.ListEvents(req.GetName(), int(req.GetLimit()))
+	if err != nil {
+		return &pb.GetHistoryResponse{
+			ErrorMessage: fmt.Sprintf("failed to get history: %v", err),
+		}, nil
+	}
+
+	return &pb.GetHistoryResponse{
+		Events: events,
+	}, nil
+}
+
+// StoreVulnReport stores the result of a govulncheck scan of an installed
+// module, replacing the result of its previous scan
+func (s *Server) StoreVulnReport(ctx context.Context, req *pb.StoreVulnReportRequest) (*pb.StoreVulnReportResponse, error) {
+	report := req.GetReport()
+
+	if report.GetName() == "" {
+		return &pb.StoreVulnReportResponse{
+			ErrorMessage: "vulnerability report requires a module name",
+		}, nil
+	}
+
+	if err := s.db.SaveVulnReport(report); err != nil {
+		return &pb.StoreVulnReportResponse{
+			ErrorMessage: fmt.Sprintf("failed to store vulnerability report: %v", err),
+		}, nil
+	}
+
+	return &pb.StoreVulnReportResponse{
+		Success: true,
+	}, nil
+}
+
+// ListVulnReports returns stored vulnerability reports ordered by module
+func (s *Server) ListVulnReports(ctx context.Context, req *pb.ListVulnReportsRequest) (*pb.ListVulnReportsResponse, error) {
+	reports, err := s.db.ListVulnReports(req.GetName())
+	if err != nil {
+		return &pb.ListVulnReportsResponse{
+			ErrorMessage: fmt.Sprintf("failed to list vulnerability reports: %v", err),
+		}, nil
+	}
+
+	return &pb.ListVulnReportsResponse{
+		Reports: reports,
+	}, nil
+}
+
+// GetVersionCache returns the cached go list -m -versions answer for a
+// module path, whatever its age
+func (s *Server) GetVersionCache(ctx context.Context, req *pb.GetVersionCacheRequest) (*pb.GetVersionCacheResponse, error) {
+	entry, err := s.db.GetVersionCache(req.GetPath())
+	if err != nil {
+		return &pb.GetVersionCacheResponse{
+			ErrorMessage: fmt.Sprintf("failed to get cached versions: %v", err),
+		}, nil
+	}
+
+	return &pb.GetVersionCacheResponse{
+		Found: entry != nil,
+		Entry: entry,
+	}, nil
+}
+
+// StoreVersionCache stores a go list -m -versions answer for a module path
+func (s *Server) StoreVersionCache(ctx context.Context, req *pb.StoreVersionCacheRequest) (*pb.StoreVersionCacheResponse, error) {
+	entry := req.GetEntry()
+
+	if entry.GetPath() == "" {
+		return &pb.StoreVersionCacheResponse{
+			ErrorMessage: "version cache entry requires a module path",
+		}, nil
+	}
+
+	if err := s.db.SaveVersionCache(entry); err != nil {
+		return &pb.StoreVersionCacheResponse{
+			ErrorMessage: fmt.Sprintf("failed to store cached versions: %v", err),
+		}, nil
+	}
+
+	return &pb.StoreVersionCacheResponse{
+		Success: true,
+	}, nil
+}
+
+// StoreLibraryWatch starts watching a library module, or records the
+// outcome of checking one
+func (s *Server) StoreLibraryWatch(ctx context.Context, req *pb.StoreLibraryWatchRequest) (*pb.StoreLibraryWatchResponse, error) {
+	watch := req.GetWatch()
+
+	if watch.GetPath() == "" {
+		return &pb.StoreLibraryWatchResponse{
+			ErrorMessage: "library watch requires a module path",
+		}, nil
+	}
+
+	if watch.GetAddedUnixNano() == 0 {
+		watch.AddedUnixNano = time.Now().UnixNano()
+	}
+
+	if err := s.db.SaveLibraryWatch(watch); err != nil {
+		return &pb.StoreLibraryWatchResponse{
+			ErrorMessage: fmt.Sprintf("failed to store library watch: %v", err),
+		}, nil
+	}
+
+	return &pb.StoreLibraryWatchResponse{
+		Success: true,
+	}, nil
+}
+
+// ListLibraryWatches returns the watched library modules ordered by path
+func (s *Server) ListLibraryWatches(ctx context.Context, _ *emptypb.Empty) (*pb.ListLibraryWatchesResponse, error) {
+	watches, err := s.db.ListLibraryWatches()
+	if err != nil {
+		return &pb.ListLibraryWatchesResponse{
+			ErrorMessage: fmt.Sprintf("failed to list library watches: %v", err),
+		}, nil
+	}
+
+	return &pb.ListLibraryWatchesResponse{
+		Watches: watches,
+	}, nil
+}
+
+// RemoveLibraryWatch stops watching a library module
+func (s *Server) RemoveLibraryWatch(ctx context.Context, req *pb.RemoveLibraryWatchRequest) (*pb.RemoveLibraryWatchResponse, error) {
+	if err := s.db.DeleteLibraryWatch(req.GetPath()); err != nil {
+		return &pb.RemoveLibraryWatchResponse{
+			ErrorMessage: err.Error(),
+		}, nil
+	}
+
+	return &pb.RemoveLibraryWatchResponse{
+		Success: true,
+	}, nil
+}
+
+// CreateToken issues an API token, replacing the token of the same name.
+// The token is returned once; only its hash is stored.
+func (s *Server) CreateToken(ctx context.Context, req *pb.CreateTokenRequest) (*pb.CreateTokenResponse, error) {
+	if req.GetName() == "" {
+		return &pb.CreateTokenResponse{
+			ErrorMessage: "token requires a name",
+		}, nil
+	}
+
+	if !ValidScope(req.GetScope()) {
+		return &pb.CreateTokenResponse{
+			ErrorMessage: fmt.Sprintf("unknown scope %q: want %s", req.GetScope(), strings.Join(Scopes, ", ")),
+		}, nil
+	}
+
+	value, hash, err := newToken()
+	if err != nil {
+		return &pb.CreateTokenResponse{
+			ErrorMessage: fmt.Sprintf("failed to generate token: %v", err),
+		}, nil
+	}
+
+	now := time.Now()
+	token := &pb.TokenProto{
+		Name:            req.GetName(),
+		Scope:           req.GetScope(),
+		Hash:            hash,
+		CreatedUnixNano: now.UnixNano(),
+	}
+
+	if ttl := req.GetTtlNano(); ttl > 0 {
+		token.ExpiresUnixNano = now.Add(time.Duration(ttl)).UnixNano()
+	}
+
+	if err := s.db.SaveToken(token); err != nil {
+		return &pb.CreateTokenResponse{
+			ErrorMessage: fmt.Sprintf("failed to store token: %v", err),
+		}, nil
+	}
+
+	s.logger.Info("API token created", "name", token.GetName(), "scope", token.GetScope())
+
+	token.Hash = ""
+
+	return &pb.CreateTokenResponse{
+		Token: token,
+		Value: value,
+	}, nil
+}
+
+// ListTokens returns the API tokens ordered by name, without their hashes
+func (s *Server) ListTokens(ctx context.Context, _ *emptypb.Empty) (*pb.ListTokensResponse, error) {
+	tokens, err := s.db.ListTokens()
+	if err != nil {
+		return &pb.ListTokensResponse{
+			ErrorMessage: fmt.Sprintf("failed to list tokens: %v", err),
+		}, nil
+	}
+
+	for _, token := range tokens {
+		token.Hash = ""
+	}
+
+	return &pb.ListTokensResponse{
+		Tokens: tokens,
+	}, nil
+}
+
+// RevokeToken deletes an API token, rejecting it from then on
+func (s *Server) RevokeToken(ctx context.Context, req *pb.RevokeTokenRequest) (*pb.RevokeTokenResponse, error) {
+	if err := s.db.DeleteToken(req.GetName()); err != nil {
+		return &pb.RevokeTokenResponse{
+			ErrorMessage: err.Error(),
+		}, nil
+	}
+
+	s.logger.Info("API token revoked", "name", req.GetName())
+
+	return &pb.RevokeTokenResponse{
+		Success: true,
+	}, nil
+}
+
+// recordEvent appends an event, logging rather than failing the request
+// that made the change
+func (s *Server) recordEvent(event *pb.EventProto) {
+	if err := s.db.AppendEvent(event); err != nil {
+		s.logger.Warn("failed to record event", "module", event.GetName(), "error", err)
+	}
+}
+
 // Remove removes an installed module from the database
 func (s *Server) Remove(ctx context.Context, req *pb.RemoveRequest) (*pb.RemoveResponse, error) {
 	s.logger.Info("remove request",
@@ -64,6 +482,13 @@ func (s *Server) Remove(ctx context.Context, req *pb.RemoveRequest) (*pb.RemoveR
 				lastErr = err
 			} else {
 				removed++
+
+				s.recordEvent(&pb.EventProto{
+					Action:      pb.EventAction_EVENT_ACTION_REMOVE,
+					Name:        mod.GetName(),
+					FromVersion: mod.GetVersion(),
+					Success:     true,
+				})
 			}
 		}
 
@@ -79,6 +504,37 @@ func (s *Server) Remove(ctx context.Context, req *pb.RemoveRequest) (*pb.RemoveR
 		}, nil
 	}
 
+	mod, err := s.db.GetModule(req.GetModulePath(), "")
+	if err != nil {
+		return &pb.RemoveResponse{
+			Success:      false,
+			ErrorMessage: fmt.Sprintf("module not found: %s", req.GetModulePath()),
+		}, nil
+	}
+
+	// Versions other than the installed one are only tracked in the install
+	// history, so removing one leaves the module installed
+	if mod.GetVersion() != version {
+		removed, err := s.db.RemoveInstallHistory(req.GetModulePath(), version)
+		if err != nil {
+			return &pb.RemoveResponse{
+				Success:      false,
+				ErrorMessage: fmt.Sprintf("failed to remove %s from the install history: %v", version, err),
+			}, nil
+		}
+
+		if !removed {
+			return &pb.RemoveResponse{
+				Success:      false,
+				ErrorMessage: fmt.Sprintf("%s is installed at %s and has no %s in its install history", req.GetModulePath(), mod.GetVersion(), version),
+			}, nil
+		}
+
+		return &pb.RemoveResponse{
+			Success: true,
+		}, nil
+	}
+
 	if err := s.db.DeleteModule(req.GetModulePath(), version); err != nil {
 		return &pb.RemoveResponse{
 			Success:      false,
@@ -86,6 +542,13 @@ func (s *Server) Remove(ctx context.Context, req *pb.RemoveRequest) (*pb.RemoveR
 		}, nil
 	}
 
+	s.recordEvent(&pb.EventProto{
+		Action:      pb.EventAction_EVENT_ACTION_REMOVE,
+		Name:        req.GetModulePath(),
+		FromVersion: version,
+		Success:     true,
+	})
+
 	return &pb.RemoveResponse{
 		Success: true,
 	}, nil
@@ -133,10 +596,16 @@ func (s *Server) ListModules(ctx context.Context, req *pb.ListModulesRequest) (*
 		}
 	}
 
-	return &pb.ListModulesResponse{
+	resp := &pb.ListModulesResponse{
 		Modules:    filteredModules,
 		TotalCount: totalCount,
-	}, nil
+	}
+
+	if req.GetSecurity() {
+		resp.Security = s.securitySummaries(filteredModules)
+	}
+
+	return resp, nil
 }
 
 // GetModule retrieves a specific module
@@ -203,6 +672,141 @@ func (s *Server) GetDependencies(ctx context.Context, req *pb.GetModuleRequest)
 	}, nil
 }
 
+// Search finds installable packages matching a query in the package index
+func (s *Server) Search(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error) {
+	s.logger.Debug("search request",
+		"query", req.GetQuery(),
+		"limit", req.GetLimit(),
+	)
+
+	results, err := search.Search(ctx, req.GetQuery(), int(req.GetLimit()))
+	if err != nil {
+		return &pb.SearchResponse{
+			ErrorMessage: err.Error(),
+		}, nil
+	}
+
+	resp := &pb.SearchResponse{
+		Results: make([]*pb.SearchResult, 0, len(results)),
+	}
+
+	for _, r := range results {
+		resp.Results = append(resp.Results, &pb.SearchResult{
+			Path:     r.Path,
+			Synopsis: r.Synopsis,
+			Version:  r.Version,
+		})
+	}
+
+	return resp, nil
+}
+
+// CurrentSnapshotName refers to the installed modules wherever a snapshot
+// name is expected, so no snapshot may be stored under it
+const CurrentSnapshotName = "current"
+
+// CreateSnapshot captures the currently installed module set under a name
+func (s *Server) CreateSnapshot(ctx context.Context, req *pb.CreateSnapshotRequest) (*pb.CreateSnapshotResponse, error) {
+	s.logger.Info("create snapshot request",
+		"name", req.GetName(),
+		"overwrite", req.GetOverwrite(),
+	)
+
+	if req.GetName() == "" {
+		return &pb.CreateSnapshotResponse{
+			Success:      false,
+			ErrorMessage: "snapshot name is required",
+		}, nil
+	}
+
+	if req.GetName() == CurrentSnapshotName {
+		return &pb.CreateSnapshotResponse{
+			Success:      false,
+			ErrorMessage: fmt.Sprintf("snapshot name %q is reserved for the installed modules; choose another name", CurrentSnapshotName),
+		}, nil
+	}
+
+	if !req.GetOverwrite() {
+		if _, err := s.db.GetSnapshot(req.GetName()); err == nil {
+			return &pb.CreateSnapshotResponse{
+				Success:      false,
+				ErrorMessage: fmt.Sprintf("snapshot already exists: %s", req.GetName()),
+			}, nil
+		}
+	}
+
+	modules, err := s.db.ListModules()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	snapshot := &pb.SnapshotProto{
+		Name:            req.GetName(),
+		Description:     req.GetDescription(),
+		CreatedUnixNano: time.Now().UnixNano(),
+		Modules:         modules,
+	}
+
+	if err := s.db.SaveSnapshot(snapshot); err != nil {
+		return &pb.CreateSnapshotResponse{
+			Success:      false,
+			ErrorMessage: fmt.Sprintf("failed to save snapshot: %v", err),
+		}, nil
+	}
+
+	return &pb.CreateSnapshotResponse{
+		Snapshot: snapshot,
+		Success:  true,
+	}, nil
+}
+
+// GetSnapshot retrieves a snapshot by name
+func (s *Server) GetSnapshot(ctx context.Context, req *pb.GetSnapshotRequest) (*pb.GetSnapshotResponse, error) {
+	s.logger.Debug("get snapshot request", "name", req.GetName())
+
+	snapshot, err := s.db.GetSnapshot(req.GetName())
+	if err != nil {
+		return &pb.GetSnapshotResponse{
+			Found: false,
+		}, nil
+	}
+
+	return &pb.GetSnapshotResponse{
+		Snapshot: snapshot,
+		Found:    true,
+	}, nil
+}
+
+// ListSnapshots returns all stored snapshots
+func (s *Server) ListSnapshots(ctx context.Context, _ *emptypb.Empty) (*pb.ListSnapshotsResponse, error) {
+	s.logger.Debug("list snapshots request")
+
+	snapshots, err := s.db.ListSnapshots()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	return &pb.ListSnapshotsResponse{
+		Snapshots: snapshots,
+	}, nil
+}
+
+// DeleteSnapshot removes a snapshot by name
+func (s *Server) DeleteSnapshot(ctx context.Context, req *pb.DeleteSnapshotRequest) (*pb.DeleteSnapshotResponse, error) {
+	s.logger.Info("delete snapshot request", "name", req.GetName())
+
+	if err := s.db.DeleteSnapshot(req.GetName()); err != nil {
+		return &pb.DeleteSnapshotResponse{
+			Success:      false,
+			ErrorMessage: err.Error(),
+		}, nil
+	}
+
+	return &pb.DeleteSnapshotResponse{
+		Success: true,
+	}, nil
+}
+
 // GetStatus returns the server status
 func (s *Server) GetStatus(ctx context.Context, _ *emptypb.Empty) (*pb.ServerStatus, error) {
 	moduleCount, err := s.db.CountModules()
@@ -210,14 +814,27 @@ func (s *Server) GetStatus(ctx context.Context, _ *emptypb.Empty) (*pb.ServerSta
 		moduleCount = 0
 	}
 
-	return &pb.ServerStatus{
+	status := &pb.ServerStatus{
 		Running:       s.IsRunning(),
 		Namespace:     s.config.Namespace,
 		DatabasePath:  s.config.DatabasePath,
 		Address:       s.Address(),
 		UptimeSeconds: s.Uptime(),
 		ModuleCount:   moduleCount,
-	}, nil
+	}
+
+	status.Hostname, _ = os.Hostname()
+
+	au, err := s.db.GetAutoUpdateConfig()
+	if err != nil {
+		s.logger.Warn("failed to get auto-update config", "error", err)
+	}
+
+	status.AutoupdateEnabled = au.GetEnabled()
+	status.PendingUpdateCount = au.GetPendingCount()
+	status.LastAutoupdateCheckUnixNano = au.GetLastCheckUnixNano()
+
+	return status, nil
 }
 
 // Ping is a health check endpoint
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/inovacc/glix/internal/database"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+func TestCarryOverHistory(t *testing.T) {
+	existing := &pb.ModuleProto{
+		Name:        "example.com/tool",
+		Version:     "v1.1.0",
+		BadVersions: []string{"v1.2.0"},
+	}
+
+	upgraded := &pb.ModuleProto{Name: "example.com/tool", Version: "v1.3.0"}
+	carryOverHistory(existing, upgraded)
+
+	if upgraded.GetPreviousVersion() != "v1.1.0" {
+		t.Errorf("previous version = %q, want v1.1.0", upgraded.GetPreviousVersion())
+	}
+
+	if !slices.Equal(upgraded.GetBadVersions(), []string{"v1.2.0"}) {
+		t.Errorf("bad versions = %v", upgraded.GetBadVersions())
+	}
+
+	// Reinstalling the same version keeps the earlier previous version
+	reinstalled := &pb.ModuleProto{Name: "example.com/tool", Version: "v1.3.0"}
+	carryOverHistory(upgraded, reinstalled)
+
+	if reinstalled.GetPreviousVersion() != "v1.1.0" {
+		t.Errorf("previous version after reinstall = %q, want v1.1.0", reinstalled.GetPreviousVersion())
+	}
+}
+
+func TestCreateSnapshotReservedName(t *testing.T) {
+	db, err := database.NewStorage(filepath.Join(t.TempDir(), "glix.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = db.Close()
+	}()
+
+	s := &Server{db: db, logger: slog.New(slog.DiscardHandler)}
+
+	resp, err := s.CreateSnapshot(context.Background(), &pb.CreateSnapshotRequest{Name: CurrentSnapshotName, Overwrite: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.GetSuccess() || !strings.Contains(resp.GetErrorMessage(), "reserved") {
+		t.Errorf("CreateSnapshot(%q) = %+v, want a reserved-name error", CurrentSnapshotName, resp)
+	}
+
+	if _, err := db.GetSnapshot(CurrentSnapshotName); err == nil {
+		t.Error("snapshot stored under the reserved name")
+	}
+
+	resp, err = s.CreateSnapshot(context.Background(), &pb.CreateSnapshotRequest{Name: "before-upgrade"})
+	if err != nil || !resp.GetSuccess() {
+		t.Errorf("CreateSnapshot(before-upgrade) = %+v, %v", resp, err)
+	}
+}
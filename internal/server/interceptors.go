@@ -2,14 +2,22 @@ package server
 
 import (
 	"context"
+	"log/slog"
 	"runtime/debug"
 	"time"
 
+	pb "github.com/inovacc/glix/pkg/api/v1"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// quietMethods are logged at debug level when they succeed
+var quietMethods = map[string]bool{
+	pb.GlixService_Ping_FullMethodName:      true,
+	pb.GlixService_GetStatus_FullMethodName: true,
+}
+
 // activityInterceptor updates the last activity timestamp for unary RPCs
 func (s *Server) activityInterceptor(
 	ctx context.Context,
@@ -52,7 +60,13 @@ func (s *Server) loggingInterceptor(
 			"error", err,
 		)
 	} else {
-		s.logger.Info("unary RPC",
+		// Shell prompts poll these many times a second
+		level := slog.LevelInfo
+		if quietMethods[info.FullMethod] {
+			level = slog.LevelDebug
+		}
+
+		s.logger.Log(ctx, level, "unary RPC",
 			"method", info.FullMethod,
 			"duration", duration,
 		)
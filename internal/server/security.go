@@ -0,0 +1,100 @@
+package server
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/policy"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+// securitySummaries rolls up the stored vulnerability reports and detected
+// licenses of modules. Without reports or a policy the summaries say less,
+// rather than failing the list.
+func (s *Server) securitySummaries(modules []*pb.ModuleProto) []*pb.SecuritySummary {
+	reports := make(map[string]*pb.VulnReportProto)
+
+	stored, err := s.db.ListVulnReports("")
+	if err != nil {
+		s.logger.Warn("failed to list vulnerability reports", "error", err)
+	}
+
+	for _, r := range stored {
+		reports[r.GetName()] = r
+	}
+
+	pol, err := policy.Load(policy.DefaultPath())
+	if err != nil {
+		s.logger.Warn("failed to load policy, only flagging missing licenses", "error", err)
+	}
+
+	summaries := make([]*pb.SecuritySummary, 0, len(modules))
+	for _, mod := range modules {
+		summaries = append(summaries, securitySummary(mod, reports[mod.GetName()], pol))
+	}
+
+	return summaries
+}
+
+// securitySummary counts the vulnerabilities of the last scan of mod, when it
+// scanned the installed version, and the modules of its dependency tree,
+// itself included, whose license is missing or one the policy warns about or
+// denies
+func securitySummary(mod *pb.ModuleProto, report *pb.VulnReportProto, pol *policy.Policy) *pb.SecuritySummary {
+	summary := &pb.SecuritySummary{Name: mod.GetName()}
+
+	if report != nil && report.GetVersion() == mod.GetVersion() {
+		summary.Scanned = true
+
+		vulns := make(map[string]bool)
+		for _, f := range report.GetFindings() {
+			vulns[f.GetId()] = vulns[f.GetId()] || f.GetCalled()
+		}
+
+		for _, called := range vulns {
+			summary.Vulnerabilities++
+
+			if called {
+				summary.CalledVulnerabilities++
+			}
+		}
+	}
+
+	deps := module.FlattenDependencies(mod.GetDependencies())
+	summary.Dependencies = int32(len(deps))
+
+	tree := append([]*pb.DependencyProto{{
+		Name:    mod.GetName(),
+		Version: mod.GetVersion(),
+		License: mod.GetLicense(),
+	}}, deps...)
+
+	counts := make(map[string]int)
+
+	for _, dep := range tree {
+		license := dep.GetLicense()
+
+		flagged := license == module.LicenseNone || license != "" && pol != nil && pol.FlagsLicense(policy.Input{
+			Module:  dep.GetName(),
+			Version: dep.GetVersion(),
+			License: license,
+			Vulns:   -1,
+		})
+
+		if flagged {
+			summary.FlaggedLicenses++
+			counts[license]++
+		}
+	}
+
+	for license := range counts {
+		summary.Flagged = append(summary.Flagged, license)
+	}
+
+	slices.SortFunc(summary.Flagged, func(a, b string) int {
+		return cmp.Or(cmp.Compare(counts[b], counts[a]), cmp.Compare(a, b))
+	})
+
+	return summary
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/inovacc/glix/internal/policy"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+func TestSecuritySummary(t *testing.T) {
+	mod := &pb.ModuleProto{
+		Name:    "example.com/tool",
+		Version: "v1.2.0",
+		License: "MIT",
+		Dependencies: []*pb.DependencyProto{
+			{Name: "example.com/a", Version: "v1.0.0", License: "GPL-3.0", Dependencies: []*pb.DependencyProto{
+				{Name: "example.com/c", Version: "v0.1.0", License: "none"},
+			}},
+			{Name: "example.com/b", Version: "v2.0.0", License: "Apache-2.0"},
+			{Name: "example.com/c", Version: "v0.1.0", License: "none"}, // Listed twice in the tree
+			{Name: "example.com/d", Version: "v0.2.0", License: "GPL-2.0"},
+			{Name: "example.com/e", Version: "v0.3.0"}, // License not detected
+		},
+	}
+
+	pol := &policy.Policy{Rules: []policy.Rule{
+		{Name: "no-gpl", Action: policy.ActionWarn, License: []string{"GPL-*"}},
+	}}
+
+	report := &pb.VulnReportProto{
+		Name:    "example.com/tool",
+		Version: "v1.2.0",
+		Findings: []*pb.VulnFindingProto{
+			{Id: "GO-2025-0001", Module: "example.com/a", Called: true},
+			{Id: "GO-2025-0001", Module: "example.com/a"},
+			{Id: "GO-2025-0002", Module: "stdlib"},
+		},
+	}
+
+	got := securitySummary(mod, report, pol)
+
+	if !got.GetScanned() || got.GetVulnerabilities() != 2 || got.GetCalledVulnerabilities() != 1 {
+		t.Errorf("vulnerabilities = scanned %v, %d, %d called; want scanned, 2, 1 called",
+			got.GetScanned(), got.GetVulnerabilities(), got.GetCalledVulnerabilities())
+	}
+
+	if got.GetDependencies() != 5 {
+		t.Errorf("dependencies = %d, want 5", got.GetDependencies())
+	}
+
+	if got.GetFlaggedLicenses() != 3 || !slices.Equal(got.GetFlagged(), []string{"GPL-2.0", "GPL-3.0", "none"}) {
+		t.Errorf("flagged = %d %v, want 3 [GPL-2.0 GPL-3.0 none]", got.GetFlaggedLicenses(), got.GetFlagged())
+	}
+
+	// A scan of another version says nothing about the installed one, and
+	// without a policy only missing licenses are flagged
+	report.Version = "v1.1.0"
+
+	got = securitySummary(mod, report, nil)
+
+	if got.GetScanned() || got.GetVulnerabilities() != 0 {
+		t.Errorf("stale scan counted: scanned %v, %d vulnerabilities", got.GetScanned(), got.GetVulnerabilities())
+	}
+
+	if got.GetFlaggedLicenses() != 1 || !slices.Equal(got.GetFlagged(), []string{"none"}) {
+		t.Errorf("flagged without policy = %d %v, want 1 [none]", got.GetFlaggedLicenses(), got.GetFlagged())
+	}
+}
@@ -6,14 +6,21 @@ import (
 	"log/slog"
 	"net"
 	"os"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/inovacc/glix/internal/autoupdate"
+	"github.com/inovacc/glix/internal/dashboard"
 	"github.com/inovacc/glix/internal/database"
+	"github.com/inovacc/glix/internal/fleet"
 	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/tasks"
+	"github.com/inovacc/glix/internal/transport"
 	pb "github.com/inovacc/glix/pkg/api/v1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -28,6 +35,19 @@ type Config struct {
 	BindAddress  string
 	IdleTimeout  time.Duration // If > 0, server shuts down after this duration of inactivity
 	Logger       *slog.Logger
+
+	// TaskWindow keeps an idle server up while a scheduled task is running
+	// or due within this duration, so a shutdown does not skip it
+	TaskWindow time.Duration
+
+	// DashboardAddress, if set, serves the web dashboard on this loopback
+	// host:port
+	DashboardAddress string
+
+	// TLSCertFile and TLSKeyFile, if set, serve TLS so CLIs on other
+	// machines can manage this server
+	TLSCertFile string
+	TLSKeyFile  string
 }
 
 // Server represents the gRPC server for glix
@@ -43,6 +63,11 @@ type Server struct {
 	logger       *slog.Logger
 	cancelIdle   context.CancelFunc
 	autoUpdater  *autoupdate.Scheduler
+	scheduler    *tasks.Scheduler
+	reporter     *fleet.Reporter
+	versions     *versionCache
+	dashboard    *dashboard.Server
+	creds        credentials.TransportCredentials // Served with
 
 	mu      sync.RWMutex
 	running bool
@@ -78,18 +103,61 @@ func New(cfg Config) (*Server, error) {
 		}))
 	}
 
+	// The server connects to itself for auto-updates and the dashboard
+	creds, selfCreds := insecure.NewCredentials(), insecure.NewCredentials()
+
+	if cfg.TLSCertFile != "" {
+		var err error
+
+		if creds, err = transport.ServerCredentials(cfg.TLSCertFile, cfg.TLSKeyFile); err != nil {
+			return nil, err
+		}
+
+		if selfCreds, err = transport.PinnedCredentials(cfg.TLSCertFile); err != nil {
+			return nil, err
+		}
+	}
+
+	var dash *dashboard.Server
+
+	if cfg.DashboardAddress != "" {
+		d, err := dashboard.New(dashboard.Config{
+			Address:     cfg.DashboardAddress,
+			GRPCAddress: cfg.address(),
+			Logger:      cfg.Logger,
+			Credentials: selfCreds,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create dashboard: %w", err)
+		}
+
+		dash = d
+	}
+
 	// Open database
 	db, err := database.NewStorage(cfg.DatabasePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	return &Server{
+	importLegacyAutoUpdateConfig(db, cfg.Logger)
+
+	s := &Server{
 		config:      cfg,
 		db:          db,
 		logger:      cfg.Logger,
-		autoUpdater: autoupdate.NewScheduler(cfg.Logger),
-	}, nil
+		autoUpdater: autoupdate.NewScheduler(cfg.Logger, autoUpdateStore{db: db}),
+		reporter:    fleet.NewReporter(cfg.Logger, db.ListModules),
+		versions:    newVersionCache(versionCacheTTL, installedLatestLookup(db)),
+		dashboard:   dash,
+		creds:       creds,
+	}
+
+	s.autoUpdater.SetAddress(cfg.address())
+	s.autoUpdater.SetTransportCredentials(selfCreds)
+	s.scheduler = tasks.NewScheduler(cfg.Logger, s.builtinTasks()...)
+
+	return s, nil
 }
 
 // Start starts the gRPC server
@@ -101,7 +169,7 @@ func (s *Server) Start(ctx context.Context) error {
 		return fmt.Errorf("server is already running")
 	}
 
-	addr := fmt.Sprintf("%s:%d", s.config.BindAddress, s.config.Port)
+	addr := s.config.address()
 
 	listener, err := net.Listen("tcp", addr)
 	if err != nil {
@@ -111,15 +179,20 @@ func (s *Server) Start(ctx context.Context) error {
 
 	s.listener = listener
 	s.grpcSrv = grpc.NewServer(
+		grpc.Creds(s.creds),
+		// Auth runs before activity tracking, so rejected calls never keep
+		// the daemon from shutting down when idle
 		grpc.ChainUnaryInterceptor(
+			s.recoveryInterceptor,
+			s.authInterceptor,
 			s.activityInterceptor,
 			s.loggingInterceptor,
-			s.recoveryInterceptor,
 		),
 		grpc.ChainStreamInterceptor(
+			s.streamRecoveryInterceptor,
+			s.streamAuthInterceptor,
 			s.streamActivityInterceptor,
 			s.streamLoggingInterceptor,
-			s.streamRecoveryInterceptor,
 		),
 	)
 
@@ -139,6 +212,8 @@ func (s *Server) Start(ctx context.Context) error {
 		"namespace", s.config.Namespace,
 		"database", s.config.DatabasePath,
 		"idle_timeout", s.config.IdleTimeout,
+		"task_window", s.config.TaskWindow,
+		"tls", s.config.TLSCertFile != "",
 	)
 
 	// Handle context cancellation
@@ -155,9 +230,23 @@ func (s *Server) Start(ctx context.Context) error {
 		go s.monitorIdle(idleCtx)
 	}
 
-	// Start auto-update scheduler
-	if s.autoUpdater != nil {
-		s.autoUpdater.Start(ctx)
+	// Start scheduled tasks, including auto-update checks
+	if s.scheduler != nil {
+		s.scheduler.Start(ctx)
+	}
+
+	// Start fleet reporting (a no-op until report-to is configured)
+	if s.reporter != nil {
+		s.reporter.Start(ctx)
+	}
+
+	// Start the web dashboard
+	if s.dashboard != nil {
+		go func() {
+			if err := s.dashboard.Start(ctx); err != nil {
+				s.logger.Error("dashboard failed", "error", err)
+			}
+		}()
 	}
 
 	// Serve requests
@@ -180,6 +269,9 @@ func (s *Server) monitorIdle(ctx context.Context) {
 	ticker := time.NewTicker(30 * time.Second)
 	defer ticker.Stop()
 
+	// The task the server stays up for, logged once
+	var waitingFor string
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -189,19 +281,51 @@ func (s *Server) monitorIdle(ctx context.Context) {
 			idle := time.Since(s.lastActivity)
 			s.mu.RUnlock()
 
-			if idle >= s.config.IdleTimeout {
-				s.logger.Info("idle timeout reached, shutting down",
-					"idle_duration", idle,
-					"timeout", s.config.IdleTimeout,
-				)
-				s.Stop()
+			if idle < s.config.IdleTimeout {
+				continue
+			}
+
+			if task, due, ok := s.taskDueSoon(); ok {
+				if task != waitingFor {
+					s.logger.Info("idle timeout reached, staying up for a scheduled task",
+						"task", task,
+						"due", due,
+					)
+
+					waitingFor = task
+				}
 
-				return
+				continue
 			}
+
+			s.logger.Info("idle timeout reached, shutting down",
+				"idle_duration", idle,
+				"timeout", s.config.IdleTimeout,
+			)
+			s.Stop()
+
+			return
 		}
 	}
 }
 
+// taskDueSoon returns the scheduled task that is running or due within the
+// task window, if there is one
+func (s *Server) taskDueSoon() (string, time.Time, bool) {
+	if s.config.TaskWindow <= 0 || s.scheduler == nil {
+		return "", time.Time{}, false
+	}
+
+	now := time.Now()
+
+	task, due := s.scheduler.NextDue(now)
+	if task == "" || due.Sub(now) > s.config.TaskWindow {
+		return "", time.Time{}, false
+	}
+
+	return task, due, true
+}
+
 // Stop gracefully stops the gRPC server
 func (s *Server) Stop() {
 	s.mu.Lock()
@@ -213,9 +337,17 @@ func (s *Server) Stop() {
 
 	s.logger.Info("stopping gRPC server")
 
-	// Stop auto-update scheduler
-	if s.autoUpdater != nil {
-		s.autoUpdater.Stop()
+	// Stop scheduled tasks
+	if s.scheduler != nil {
+		s.scheduler.Stop()
+	}
+
+	if s.reporter != nil {
+		s.reporter.Stop()
+	}
+
+	if s.dashboard != nil {
+		s.dashboard.Stop()
 	}
 
 	if s.grpcSrv != nil {
@@ -240,9 +372,15 @@ func (s *Server) IsRunning() bool {
 	return s.running
 }
 
+// address returns the host:port the server listens on, bracketing IPv6
+// bind addresses such as ::1
+func (c Config) address() string {
+	return net.JoinHostPort(c.BindAddress, strconv.Itoa(c.Port))
+}
+
 // Address returns the server address
 func (s *Server) Address() string {
-	return fmt.Sprintf("%s:%d", s.config.BindAddress, s.config.Port)
+	return s.config.address()
 }
 
 // Uptime returns the server uptime in seconds
@@ -0,0 +1,155 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+// defaultStatsWeeks is the number of weeks GetStats aggregates by default
+const defaultStatsWeeks = 12
+
+// GetStats returns weekly counts of installs, updates, removes, and failures
+// from the event history, oldest week first, and the build metrics of the
+// installs and updates of those weeks
+func (s *Server) GetStats(ctx context.Context, req *pb.GetStatsRequest) (*pb.GetStatsResponse, error) {
+	weeks := int(req.GetWeeks())
+	if weeks <= 0 {
+		weeks = defaultStatsWeeks
+	}
+
+	events, err := s.db.ListEvents("", 0)
+	if err != nil {
+		return &pb.GetStatsResponse{
+			ErrorMessage: fmt.Sprintf("failed to get history: %v", err),
+		}, nil
+	}
+
+	now := time.Now()
+
+	return &pb.GetStatsResponse{
+		Weeks:  weeklyStats(events, weeks, now),
+		Builds: buildStats(events, firstWeek(weeks, now), now),
+	}, nil
+}
+
+// firstWeek returns the start of the first of the weeks that end with the
+// week of now
+func firstWeek(weeks int, now time.Time) time.Time {
+	return weekStart(now).AddDate(0, 0, -7*(weeks-1))
+}
+
+// weeklyStats buckets events into the weeks, starting on Monday, that end
+// with the week of now. Events older than the first week are left out.
+func weeklyStats(events []*pb.EventProto, weeks int, now time.Time) []*pb.WeeklyStats {
+	first := firstWeek(weeks, now)
+
+	stats := make([]*pb.WeeklyStats, weeks)
+	for i := range stats {
+		stats[i] = &pb.WeeklyStats{
+			WeekStartUnixNano: first.AddDate(0, 0, 7*i).UnixNano(),
+		}
+	}
+
+	for _, e := range events {
+		at := time.Unix(0, e.GetTimestampUnixNano()).In(now.Location())
+		if at.Before(first) || at.After(now) {
+			continue
+		}
+
+		// Counted in days, as weeks across a DST change are not 7*24 hours
+		week := stats[int(weekStart(at).Sub(first).Round(24*time.Hour)/(7*24*time.Hour))]
+
+		switch {
+		case !e.GetSuccess():
+			week.Failures++
+		case e.GetAction() == pb.EventAction_EVENT_ACTION_INSTALL:
+			week.Installs++
+		case e.GetAction() == pb.EventAction_EVENT_ACTION_UPDATE:
+			week.Updates++
+		case e.GetAction() == pb.EventAction_EVENT_ACTION_REMOVE:
+			week.Removes++
+		}
+	}
+
+	return stats
+}
+
+// weekStart returns Monday 00:00 of the week of t, in the location of t
+func weekStart(t time.Time) time.Time {
+	days := (int(t.Weekday()) + 6) % 7
+
+	return time.Date(t.Year(), t.Month(), t.Day()-days, 0, 0, 0, 0, t.Location())
+}
+
+// buildStats aggregates the build metrics of the events between since and
+// now, or returns nil when none of them has any
+func buildStats(events []*pb.EventProto, since, now time.Time) *pb.BuildStats {
+	var builds []*pb.EventProto
+
+	for _, e := range events {
+		at := time.Unix(0, e.GetTimestampUnixNano())
+		if e.GetBuild() == nil || !e.GetSuccess() || at.Before(since) || at.After(now) {
+			continue
+		}
+
+		builds = append(builds, e)
+	}
+
+	if len(builds) == 0 {
+		return nil
+	}
+
+	stats := &pb.BuildStats{Builds: int32(len(builds))}
+
+	var durations, cold, cached, sizes []int64
+
+	for _, e := range builds {
+		b := e.GetBuild()
+
+		durations = append(durations, b.GetDurationNano())
+		sizes = append(sizes, b.GetBinarySize())
+		stats.TotalBinarySize += b.GetBinarySize()
+
+		if b.GetCacheHit() {
+			stats.CacheHits++
+			cached = append(cached, b.GetDurationNano())
+		} else {
+			cold = append(cold, b.GetDurationNano())
+		}
+
+		if b.GetDurationNano() > stats.SlowestDurationNano {
+			stats.Slowest, stats.SlowestDurationNano = e.GetName(), b.GetDurationNano()
+		}
+
+		if b.GetBinarySize() > stats.LargestBinarySize {
+			stats.Largest, stats.LargestBinarySize = e.GetName(), b.GetBinarySize()
+		}
+	}
+
+	stats.MedianDurationNano = percentile(durations, 50)
+	stats.P95DurationNano = percentile(durations, 95)
+	stats.MedianColdDurationNano = percentile(cold, 50)
+	stats.MedianCachedDurationNano = percentile(cached, 50)
+	stats.MedianBinarySize = percentile(sizes, 50)
+
+	return stats
+}
+
+// percentile returns the p-th percentile of values by the nearest rank, or
+// 0 for none
+func percentile(values []int64, p int) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	rank := (p*len(sorted) + 99) / 100
+
+	return sorted[max(rank, 1)-1]
+}
@@ -0,0 +1,111 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+func TestWeekStart(t *testing.T) {
+	tests := []struct {
+		day  string
+		want string
+	}{
+		{"2025-06-02", "2025-06-02"}, // Monday
+		{"2025-06-04", "2025-06-02"},
+		{"2025-06-08", "2025-06-02"}, // Sunday
+		{"2025-06-01", "2025-05-26"},
+	}
+
+	for _, tt := range tests {
+		day, _ := time.Parse(time.DateOnly, tt.day)
+
+		if got := weekStart(day.Add(15 * time.Hour)).Format(time.DateOnly); got != tt.want {
+			t.Errorf("weekStart(%s) = %s, want %s", tt.day, got, tt.want)
+		}
+	}
+}
+
+func TestWeeklyStats(t *testing.T) {
+	now := time.Date(2025, 6, 4, 12, 0, 0, 0, time.UTC) // Wednesday
+
+	event := func(at time.Time, action pb.EventAction, success bool) *pb.EventProto {
+		return &pb.EventProto{TimestampUnixNano: at.UnixNano(), Action: action, Success: success}
+	}
+
+	events := []*pb.EventProto{
+		event(now.Add(-time.Hour), pb.EventAction_EVENT_ACTION_INSTALL, true),
+		event(now.Add(-2*time.Hour), pb.EventAction_EVENT_ACTION_UPDATE, true),
+		event(now.Add(-3*time.Hour), pb.EventAction_EVENT_ACTION_UPDATE, false),
+		event(now.AddDate(0, 0, -7), pb.EventAction_EVENT_ACTION_REMOVE, true),
+		event(now.AddDate(0, 0, -7), pb.EventAction_EVENT_ACTION_INSTALL, true),
+		event(now.AddDate(0, 0, -30), pb.EventAction_EVENT_ACTION_INSTALL, true), // Before the first week
+	}
+
+	stats := weeklyStats(events, 3, now)
+	if len(stats) != 3 {
+		t.Fatalf("got %d weeks, want 3", len(stats))
+	}
+
+	wantStarts := []string{"2025-05-19", "2025-05-26", "2025-06-02"}
+	for i, want := range wantStarts {
+		if got := time.Unix(0, stats[i].GetWeekStartUnixNano()).UTC().Format(time.DateOnly); got != want {
+			t.Errorf("week %d starts %s, want %s", i, got, want)
+		}
+	}
+
+	if w := stats[0]; w.GetInstalls()+w.GetUpdates()+w.GetRemoves()+w.GetFailures() != 0 {
+		t.Errorf("first week = %v, want empty", w)
+	}
+
+	if w := stats[1]; w.GetInstalls() != 1 || w.GetRemoves() != 1 || w.GetFailures() != 0 {
+		t.Errorf("last week = %v, want 1 install and 1 remove", w)
+	}
+
+	if w := stats[2]; w.GetInstalls() != 1 || w.GetUpdates() != 1 || w.GetFailures() != 1 {
+		t.Errorf("this week = %v, want 1 install, 1 update, and 1 failure", w)
+	}
+}
+
+func TestBuildStats(t *testing.T) {
+	now := time.Date(2025, 6, 4, 12, 0, 0, 0, time.UTC)
+
+	event := func(name string, seconds int, cached bool, size int64, success bool) *pb.EventProto {
+		return &pb.EventProto{
+			TimestampUnixNano: now.Add(-time.Hour).UnixNano(),
+			Action:            pb.EventAction_EVENT_ACTION_INSTALL,
+			Name:              name,
+			Success:           success,
+			Build:             &pb.BuildMetricsProto{DurationNano: int64(time.Duration(seconds) * time.Second), CacheHit: cached, BinarySize: size},
+		}
+	}
+
+	if stats := buildStats([]*pb.EventProto{{Success: true, TimestampUnixNano: now.UnixNano()}}, now.AddDate(0, 0, -7), now); stats != nil {
+		t.Errorf("buildStats(no metrics) = %v, want nil", stats)
+	}
+
+	old := event("example.com/old", 100, false, 100, true)
+	old.TimestampUnixNano = now.AddDate(0, 0, -30).UnixNano()
+
+	stats := buildStats([]*pb.EventProto{
+		event("example.com/a", 2, true, 10, true),
+		event("example.com/b", 4, true, 30, true),
+		event("example.com/c", 30, false, 20, true),
+		event("example.com/failed", 60, false, 90, false),
+		old,
+	}, now.AddDate(0, 0, -7), now)
+
+	if stats.GetBuilds() != 3 || stats.GetCacheHits() != 2 || stats.GetTotalBinarySize() != 60 {
+		t.Errorf("counts = %v, want 3 builds, 2 cache hits, 60 bytes", stats)
+	}
+
+	if stats.GetMedianDurationNano() != int64(4*time.Second) || stats.GetP95DurationNano() != int64(30*time.Second) ||
+		stats.GetMedianCachedDurationNano() != int64(2*time.Second) || stats.GetMedianColdDurationNano() != int64(30*time.Second) {
+		t.Errorf("durations = %v", stats)
+	}
+
+	if stats.GetMedianBinarySize() != 20 || stats.GetSlowest() != "example.com/c" || stats.GetLargest() != "example.com/b" {
+		t.Errorf("sizes = %v", stats)
+	}
+}
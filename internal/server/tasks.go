@@ -0,0 +1,340 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/inovacc/glix/internal/denylist"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/modver"
+	"github.com/inovacc/glix/internal/tasks"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// maxBackups is the number of database backups the backup task keeps
+const maxBackups = 7
+
+// staleWorkDirAge is how old work directories must be for cache GC
+const staleWorkDirAge = time.Hour
+
+// builtinTasks returns the tasks the daemon runs on its own
+func (s *Server) builtinTasks() []tasks.Task {
+	return []tasks.Task{
+		{
+			Name:        "autoupdate",
+			Description: "Check for and install updates when auto-update is enabled and due",
+			Schedule:    "* * * * *",
+			Run:         s.runAutoUpdate,
+			Due:         s.autoUpdater.NextCheck,
+		},
+		{
+			Name:        "cache-gc",
+			Description: "Delete work directories left by crashed or interrupted runs",
+			Schedule:    "0 3 * * *",
+			Run:         s.runCacheGC,
+		},
+		{
+			Name:        "reconcile",
+			Description: "Report missing and orphaned binaries in GOBIN",
+			Schedule:    "30 3 * * *",
+			Run:         s.runReconcile,
+		},
+		{
+			Name:        "backup",
+			Description: fmt.Sprintf("Back up the database, keeping the newest %d copies", maxBackups),
+			Schedule:    "0 2 * * *",
+			Run:         s.runBackup,
+		},
+		{
+			Name:          "catalog-refresh",
+			Description:   "Sync the shared denylist catalogs",
+			Schedule:      "0 */6 * * *",
+			Run:           s.runCatalogRefresh,
+			Opportunistic: true,
+		},
+		{
+			Name:          "version-refresh",
+			Description:   "Check the latest versions of installed modules for outdated and list --check",
+			Schedule:      "*/30 * * * *",
+			Run:           s.runVersionRefresh,
+			Opportunistic: true,
+		},
+	}
+}
+
+// runAutoUpdate runs an update check; scheduled runs skip it until the
+// configured auto-update interval has passed
+func (s *Server) runAutoUpdate(ctx context.Context, manual bool) (string, error) {
+	if manual {
+		result, err := s.autoUpdater.RunOnce(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		return result.Summary(), nil
+	}
+
+	result, err := s.autoUpdater.RunIfDue(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if result == nil {
+		return "", tasks.ErrSkipped
+	}
+
+	return result.Summary(), nil
+}
+
+// runCacheGC deletes stale work directories from the cache
+func (s *Server) runCacheGC(_ context.Context, _ bool) (string, error) {
+	orphans, err := module.FindStaleWorkDirs(staleWorkDirAge)
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		removed int
+		freed   int64
+	)
+
+	for _, o := range orphans {
+		if err := module.RemoveOrphan(o); err != nil {
+			s.logger.Warn("failed to remove stale work directory", "path", o.Path, "error", err)
+			continue
+		}
+
+		removed++
+		freed += o.Size
+	}
+
+	return fmt.Sprintf("removed %d stale item(s), %d bytes", removed, freed), nil
+}
+
+// runReconcile compares GOBIN with the database. Nothing is deleted;
+// 'glix prune' removes orphaned binaries and 'glix install' restores
+// missing ones.
+func (s *Server) runReconcile(_ context.Context, _ bool) (string, error) {
+	modules, err := s.db.ListModules()
+	if err != nil {
+		return "", fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	events, err := s.db.ListEvents("", 0)
+	if err != nil {
+		return "", fmt.Errorf("failed to list events: %w", err)
+	}
+
+	installed := make(map[string]bool, len(modules))
+	missing := 0
+
+	for _, mod := range modules {
+		installed[mod.GetName()] = true
+
+		if !slices.ContainsFunc(binaryCandidates(mod), fileExists) {
+			s.logger.Warn("installed binary is missing", "module", mod.GetName())
+
+			missing++
+		}
+	}
+
+	known := make(map[string]bool, len(events))
+	for _, e := range events {
+		known[e.GetName()] = true
+	}
+
+	orphans, err := module.FindOrphanedBinaries(installed, known)
+	if err != nil {
+		return "", err
+	}
+
+	for _, o := range orphans {
+		s.logger.Warn("orphaned binary", "path", o.Path, "reason", o.Reason)
+	}
+
+	return fmt.Sprintf("%d module(s): %d missing binary(ies), %d orphaned binary(ies)", len(modules), missing, len(orphans)), nil
+}
+
+// binaryCandidates returns where the binary of an installed module may be
+func binaryCandidates(mod *pb.ModuleProto) []string {
+	if mod.GetBinaryPath() != "" {
+		return []string{mod.GetBinaryPath()}
+	}
+
+	candidates := []string{module.InstalledBinaryPath(mod.GetName())}
+
+	// Records from before binary paths were stored: renamed kubectl plugins
+	// live under their kubectl-<name> binary
+	if plugin := mod.GetKubectlPlugin(); plugin != "" {
+		binary := module.KubectlPluginBinary(plugin)
+		if runtime.GOOS == "windows" {
+			binary += ".exe"
+		}
+
+		candidates = append(candidates, filepath.Join(module.GetGoBinDirectory(), binary))
+	}
+
+	return candidates
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// backupDirectory returns where database backups are written
+func backupDirectory() string {
+	return filepath.Join(module.GetApplicationDirectory(), "backups")
+}
+
+// runBackup copies the database to the backup directory and deletes the
+// oldest backups beyond maxBackups
+func (s *Server) runBackup(_ context.Context, _ bool) (string, error) {
+	dir := backupDirectory()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("glix-%s.bolt", time.Now().Format("20060102-150405")))
+	if err := s.db.Backup(path); err != nil {
+		return "", fmt.Errorf("failed to back up database: %w", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read backup directory: %w", err)
+	}
+
+	// Timestamped names sort oldest first
+	var backups []string
+
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), "glix-") && strings.HasSuffix(e.Name(), ".bolt") {
+			backups = append(backups, e.Name())
+		}
+	}
+
+	for len(backups) > maxBackups {
+		if err := os.Remove(filepath.Join(dir, backups[0])); err != nil {
+			s.logger.Warn("failed to remove old backup", "path", backups[0], "error", err)
+		}
+
+		backups = backups[1:]
+	}
+
+	return fmt.Sprintf("wrote %s", path), nil
+}
+
+// runCatalogRefresh syncs the denylist catalogs
+func (s *Server) runCatalogRefresh(ctx context.Context, _ bool) (string, error) {
+	store := denylist.GetStore()
+	if err := store.Reload(); err != nil {
+		return "", err
+	}
+
+	catalogs := store.Catalogs()
+	if len(catalogs) == 0 {
+		return "no catalogs configured", nil
+	}
+
+	if err := store.Sync(ctx); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("synced %d catalog(s)", len(catalogs)), nil
+}
+
+// runVersionRefresh re-checks the latest version of every installed module,
+// so the version cache answers 'glix outdated' without querying the proxy.
+// Refreshing more often than versionCacheTTL keeps every read a cache hit.
+func (s *Server) runVersionRefresh(ctx context.Context, _ bool) (string, error) {
+	modules, err := s.db.ListModules()
+	if err != nil {
+		return "", fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	installed := make(map[string]string, len(modules))
+	names := make([]string, 0, len(modules))
+
+	for _, mod := range modules {
+		// Local installs have no upstream versions
+		if mod.GetLocalPath() == "" {
+			installed[mod.GetName()] = mod.GetVersion()
+			names = append(names, mod.GetName())
+		}
+	}
+
+	if len(names) == 0 {
+		return "no modules to check", nil
+	}
+
+	updates, failed := 0, 0
+
+	for _, info := range s.versions.Get(ctx, names, true) {
+		switch {
+		case info.GetErrorMessage() != "":
+			failed++
+		case modver.Default.IsUpdate(info.GetLatestVersion(), installed[info.GetName()]):
+			updates++
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("checked %d module(s): %d update(s) available, %d failed", len(names), updates, failed), nil
+}
+
+// ListTasks returns the daemon's scheduled tasks and their last runs
+func (s *Server) ListTasks(ctx context.Context, _ *emptypb.Empty) (*pb.ListTasksResponse, error) {
+	statuses := s.scheduler.List()
+	resp := &pb.ListTasksResponse{Tasks: make([]*pb.TaskProto, 0, len(statuses))}
+
+	for _, st := range statuses {
+		t := &pb.TaskProto{
+			Name:             st.Name,
+			Description:      st.Description,
+			Schedule:         st.Schedule,
+			Enabled:          st.Enabled,
+			Builtin:          st.Builtin,
+			Running:          st.Running,
+			LastDurationNano: int64(st.LastDuration),
+			LastSuccess:      st.LastSuccess,
+			LastResult:       st.LastResult,
+		}
+
+		if !st.Next.IsZero() {
+			t.NextRunUnixNano = st.Next.UnixNano()
+		}
+
+		if !st.LastRun.IsZero() {
+			t.LastRunUnixNano = st.LastRun.UnixNano()
+		}
+
+		resp.Tasks = append(resp.Tasks, t)
+	}
+
+	return resp, nil
+}
+
+// RunTask runs a task now and waits for it to finish
+func (s *Server) RunTask(ctx context.Context, req *pb.RunTaskRequest) (*pb.RunTaskResponse, error) {
+	if req.GetName() == "" {
+		return &pb.RunTaskResponse{ErrorMessage: "task name is required"}, nil
+	}
+
+	result, err := s.scheduler.Run(ctx, req.GetName())
+	if err != nil {
+		return &pb.RunTaskResponse{Result: result, ErrorMessage: err.Error()}, nil
+	}
+
+	return &pb.RunTaskResponse{Success: true, Result: result}, nil
+}
@@ -0,0 +1,178 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/inovacc/glix/internal/module"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+	"golang.org/x/mod/sumdb"
+)
+
+// VerifyBinaries hashes the installed binaries of modules and compares them
+// with the hashes recorded at install. With accept, the current hashes are
+// recorded instead, after an intended change such as a manual rebuild. With
+// sums, the module checksums recorded at install are also checked against
+// the module cache and the checksum database.
+func (s *Server) VerifyBinaries(ctx context.Context, req *pb.VerifyBinariesRequest) (*pb.VerifyBinariesResponse, error) {
+	s.logger.Info("verify binaries request",
+		"names", req.GetNames(),
+		"accept", req.GetAccept(),
+		"sums", req.GetSums(),
+	)
+
+	modules, err := s.db.ListModules()
+	if err != nil {
+		return &pb.VerifyBinariesResponse{
+			ErrorMessage: fmt.Sprintf("failed to list modules: %v", err),
+		}, nil
+	}
+
+	if names := req.GetNames(); len(names) > 0 {
+		for _, name := range names {
+			if !slices.ContainsFunc(modules, func(mod *pb.ModuleProto) bool { return mod.GetName() == name }) {
+				return &pb.VerifyBinariesResponse{
+					ErrorMessage: fmt.Sprintf("module not found: %s", name),
+				}, nil
+			}
+		}
+
+		modules = slices.DeleteFunc(modules, func(mod *pb.ModuleProto) bool {
+			return !slices.Contains(names, mod.GetName())
+		})
+	}
+
+	results := make([]*pb.BinaryVerification, 0, len(modules))
+	modCaches := make(map[string]string) // Module cache of each profile
+
+	for _, mod := range modules {
+		result := verifyBinary(mod)
+
+		if req.GetSums() {
+			modCache, ok := modCaches[mod.GetProfile()]
+			if !ok {
+				modCache, _ = module.ModCacheDir(ctx, "go", mod.GetProfile())
+				modCaches[mod.GetProfile()] = modCache
+			}
+
+			result.SumIntegrity, result.SumDetail = verifySums(ctx, mod, modCache)
+		}
+
+		if req.GetAccept() && result.GetActualHash() != "" && result.GetIntegrity() != pb.BinaryIntegrity_BINARY_INTEGRITY_OK {
+			mod.BinaryHash = result.GetActualHash()
+
+			if err := s.db.UpsertModule(mod); err != nil {
+				return &pb.VerifyBinariesResponse{
+					ErrorMessage: fmt.Sprintf("failed to store module: %v", err),
+				}, nil
+			}
+
+			result.Accepted = true
+		}
+
+		results = append(results, result)
+	}
+
+	return &pb.VerifyBinariesResponse{
+		Results: results,
+	}, nil
+}
+
+// verifyBinary compares the installed binary of a module with its record
+func verifyBinary(mod *pb.ModuleProto) *pb.BinaryVerification {
+	candidates := binaryCandidates(mod)
+
+	result := &pb.BinaryVerification{
+		Name:         mod.GetName(),
+		Version:      mod.GetVersion(),
+		BinaryPath:   candidates[0],
+		RecordedHash: mod.GetBinaryHash(),
+	}
+
+	if i := slices.IndexFunc(candidates, fileExists); i >= 0 {
+		result.BinaryPath = candidates[i]
+	} else {
+		result.Integrity = pb.BinaryIntegrity_BINARY_INTEGRITY_MISSING
+		return result
+	}
+
+	hash, err := module.RehashBinary(result.GetBinaryPath(), mod.GetBinaryHash())
+	if err != nil {
+		result.Integrity = pb.BinaryIntegrity_BINARY_INTEGRITY_MISSING
+		result.Detail = err.Error()
+
+		return result
+	}
+
+	result.ActualHash = hash
+
+	switch {
+	case mod.GetBinaryHash() == "":
+		result.Integrity = pb.BinaryIntegrity_BINARY_INTEGRITY_UNRECORDED
+	case hash == mod.GetBinaryHash():
+		result.Integrity = pb.BinaryIntegrity_BINARY_INTEGRITY_OK
+	default:
+		result.Integrity = pb.BinaryIntegrity_BINARY_INTEGRITY_MODIFIED
+
+		// A different build of the same module and version was modified;
+		// anything else was put in its place
+		if by := module.ReplacedBy(result.GetBinaryPath(), mod.GetName(), mod.GetVersion()); by != "" {
+			result.Integrity = pb.BinaryIntegrity_BINARY_INTEGRITY_REPLACED
+			result.Detail = by
+		}
+	}
+
+	return result
+}
+
+// verifySums compares the module checksums recorded at install with the
+// module cache and the checksum database. A source that cannot be consulted
+// is named in the detail; only a source that disagrees is a mismatch.
+func verifySums(ctx context.Context, mod *pb.ModuleProto, modCache string) (pb.SumIntegrity, string) {
+	if mod.GetSum() == "" && mod.GetGoModSum() == "" {
+		return pb.SumIntegrity_SUM_INTEGRITY_UNRECORDED, ""
+	}
+
+	path := cmp.Or(mod.GetRootModule(), mod.GetName())
+	recorded := module.Sums{Sum: mod.GetSum(), GoModSum: mod.GetGoModSum()}
+
+	var unchecked []string
+
+	switch err := module.VerifyCachedSums(modCache, path, mod.GetVersion(), recorded); {
+	case modCache == "":
+		unchecked = append(unchecked, "module cache not found")
+	case errors.Is(err, module.ErrNotCached):
+		unchecked = append(unchecked, err.Error())
+	case err != nil:
+		return pb.SumIntegrity_SUM_INTEGRITY_MISMATCH, err.Error()
+	}
+
+	if mod.GetPrivate() {
+		unchecked = append(unchecked, "private module, not in the checksum database")
+	} else {
+		sums, err := module.LookupSums(ctx, path, mod.GetVersion())
+
+		switch {
+		case errors.Is(err, sumdb.ErrSecurity):
+			return pb.SumIntegrity_SUM_INTEGRITY_MISMATCH, err.Error()
+		case err != nil:
+			unchecked = append(unchecked, err.Error())
+		case sums.Sum != "" && recorded.Sum != "" && sums.Sum != recorded.Sum:
+			return pb.SumIntegrity_SUM_INTEGRITY_MISMATCH,
+				fmt.Sprintf("checksum database has %s for %s@%s, recorded %s", sums.Sum, path, mod.GetVersion(), recorded.Sum)
+		case sums.GoModSum != "" && recorded.GoModSum != "" && sums.GoModSum != recorded.GoModSum:
+			return pb.SumIntegrity_SUM_INTEGRITY_MISMATCH,
+				fmt.Sprintf("checksum database has %s for %s@%s/go.mod, recorded %s", sums.GoModSum, path, mod.GetVersion(), recorded.GoModSum)
+		}
+	}
+
+	if len(unchecked) == 2 {
+		return pb.SumIntegrity_SUM_INTEGRITY_UNCHECKED, strings.Join(unchecked, "; ")
+	}
+
+	return pb.SumIntegrity_SUM_INTEGRITY_OK, strings.Join(unchecked, "; ")
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/inovacc/glix/internal/manifest"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+func TestVerifyBinary(t *testing.T) {
+	binPath := filepath.Join(t.TempDir(), "tool")
+	if err := os.WriteFile(binPath, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := manifest.HashFile(binPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod := &pb.ModuleProto{Name: "example.com/tool", Version: "v1.0.0", BinaryPath: binPath}
+
+	if got := verifyBinary(mod).GetIntegrity(); got != pb.BinaryIntegrity_BINARY_INTEGRITY_UNRECORDED {
+		t.Errorf("without a recorded hash: %v", got)
+	}
+
+	mod.BinaryHash = hash
+	if got := verifyBinary(mod).GetIntegrity(); got != pb.BinaryIntegrity_BINARY_INTEGRITY_OK {
+		t.Errorf("unchanged binary: %v", got)
+	}
+
+	// Not a Go binary, so it cannot be a modified build of the module
+	mod.BinaryHash = "sha256:0000"
+
+	result := verifyBinary(mod)
+	if result.GetIntegrity() != pb.BinaryIntegrity_BINARY_INTEGRITY_REPLACED || result.GetActualHash() != hash {
+		t.Errorf("changed binary: %v, actual hash %q", result.GetIntegrity(), result.GetActualHash())
+	}
+
+	if err := os.Remove(binPath); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := verifyBinary(mod).GetIntegrity(); got != pb.BinaryIntegrity_BINARY_INTEGRITY_MISSING {
+		t.Errorf("removed binary: %v", got)
+	}
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/inovacc/glix/internal/database"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/profiles"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+// versionCacheTTL is how long a proxy answer is reused before re-querying.
+// The version-refresh task re-checks installed modules well within it.
+const versionCacheTTL = time.Hour
+
+// maxConcurrentLookups bounds parallel proxy queries per request
+const maxConcurrentLookups = 8
+
+// latestLookup resolves the latest version of a module path
+type latestLookup func(ctx context.Context, name string) (string, error)
+
+// versionEntry is a cached proxy answer
+type versionEntry struct {
+	version string
+	err     string
+	checked time.Time
+}
+
+// versionCache is a read-through cache of latest module versions kept by the
+// daemon, so list-style commands avoid repeated proxy queries
+type versionCache struct {
+	mu      sync.Mutex
+	entries map[string]versionEntry
+	ttl     time.Duration
+	lookup  latestLookup
+}
+
+func newVersionCache(ttl time.Duration, lookup latestLookup) *versionCache {
+	return &versionCache{
+		entries: make(map[string]versionEntry),
+		ttl:     ttl,
+		lookup:  lookup,
+	}
+}
+
+// Get returns the latest version info for each name, querying the proxy for
+// entries that are missing, expired, or when refresh is set
+func (c *versionCache) Get(ctx context.Context, names []string, refresh bool) []*pb.LatestVersionInfo {
+	results := make([]*pb.LatestVersionInfo, len(names))
+
+	var wg sync.WaitGroup
+
+	sem := make(chan struct{}, maxConcurrentLookups)
+
+	for i, name := range names {
+		c.mu.Lock()
+		entry, ok := c.entries[name]
+		c.mu.Unlock()
+
+		if ok && !refresh && time.Since(entry.checked) < c.ttl {
+			results[i] = entry.toProto(name, true)
+			continue
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			entry := versionEntry{checked: time.Now()}
+
+			version, err := c.lookup(ctx, name)
+			if err != nil {
+				entry.err = err.Error()
+			} else {
+				entry.version = version
+			}
+
+			// Cancelled requests must not poison the cache
+			if ctx.Err() == nil {
+				c.mu.Lock()
+				c.entries[name] = entry
+				c.mu.Unlock()
+			}
+
+			results[i] = entry.toProto(name, false)
+		}()
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+func (e versionEntry) toProto(name string, cached bool) *pb.LatestVersionInfo {
+	return &pb.LatestVersionInfo{
+		Name:            name,
+		LatestVersion:   e.version,
+		CheckedUnixNano: e.checked.UnixNano(),
+		ErrorMessage:    e.err,
+		Cached:          cached,
+	}
+}
+
+// GetLatestVersions returns the latest available version of each module from
+// the daemon's version cache, querying the module proxy on a miss
+func (s *Server) GetLatestVersions(ctx context.Context, req *pb.GetLatestVersionsRequest) (*pb.GetLatestVersionsResponse, error) {
+	return &pb.GetLatestVersionsResponse{
+		Versions: s.versions.Get(ctx, req.GetNames(), req.GetRefresh()),
+	}, nil
+}
+
+// installedLatestLookup queries the module proxy directly: the one installed
+// modules are downloaded through, as given with --goproxy or by their
+// profile, or the environment's
+func installedLatestLookup(db *database.Storage) latestLookup {
+	return func(ctx context.Context, name string) (string, error) {
+		var goproxy string
+
+		if mod, err := db.GetModule(name, ""); err == nil {
+			goproxy = mod.GetGoproxy()
+
+			if p, ok := profiles.GetStore().Get(mod.GetProfile()); ok && goproxy == "" {
+				goproxy = p.GoProxy
+			}
+		}
+
+		return module.LatestFromGoProxy(ctx, goproxy, name)
+	}
+}
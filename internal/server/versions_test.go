@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/inovacc/glix/internal/database"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+func TestVersionCache(t *testing.T) {
+	var calls atomic.Int32
+
+	cache := newVersionCache(time.Hour, func(_ context.Context, name string) (string, error) {
+		calls.Add(1)
+
+		if name == "example.com/missing" {
+			return "", errors.New("not found")
+		}
+
+		return "v1.2.3", nil
+	})
+
+	ctx := context.Background()
+	names := []string{"example.com/a", "example.com/missing"}
+
+	first := cache.Get(ctx, names, false)
+	if first[0].GetLatestVersion() != "v1.2.3" || first[0].GetCached() {
+		t.Errorf("first lookup = %v", first[0])
+	}
+
+	if first[1].GetErrorMessage() == "" {
+		t.Errorf("expected error for missing module, got %v", first[1])
+	}
+
+	second := cache.Get(ctx, names, false)
+	if !second[0].GetCached() || calls.Load() != 2 {
+		t.Errorf("expected cached result without new lookups, calls=%d", calls.Load())
+	}
+
+	cache.Get(ctx, names[:1], true)
+
+	if calls.Load() != 3 {
+		t.Errorf("refresh should bypass the cache, calls=%d", calls.Load())
+	}
+}
+
+func TestRunVersionRefresh(t *testing.T) {
+	db, err := database.NewStorage(filepath.Join(t.TempDir(), "glix.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	if err := db.UpsertModules([]*pb.ModuleProto{
+		{Name: "example.com/old", Version: "v1.0.0", TimestampUnixNano: 1},
+		{Name: "example.com/current", Version: "v1.2.3", TimestampUnixNano: 2},
+		{Name: "example.com/local", Version: "v0.0.0", LocalPath: "/src/local", TimestampUnixNano: 3},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{
+		db:     db,
+		logger: slog.New(slog.DiscardHandler),
+		versions: newVersionCache(time.Hour, func(context.Context, string) (string, error) {
+			return "v1.2.3", nil
+		}),
+	}
+
+	result, err := s.runVersionRefresh(context.Background(), false)
+	if err != nil {
+		t.Fatalf("runVersionRefresh: %v", err)
+	}
+
+	if want := "checked 2 module(s): 1 update(s) available, 0 failed"; result != want {
+		t.Errorf("result = %q, want %q", result, want)
+	}
+
+	// Reads after a refresh are cache hits
+	for _, info := range s.versions.Get(context.Background(), []string{"example.com/old", "example.com/current"}, false) {
+		if !info.GetCached() {
+			t.Errorf("%s not cached after refresh", info.GetName())
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 // Config holds the service configuration
@@ -13,6 +14,11 @@ type Config struct {
 	DatabasePath string
 	Port         int
 	BindAddress  string
+	Dashboard    string // Web dashboard address, empty to disable
+	TLSCertFile  string // Serve TLS with this certificate, for remote CLIs
+	TLSKeyFile   string
+	IdleTimeout  time.Duration // Stop after this long without requests, 0 to run until stopped
+	TaskWindow   time.Duration // Stay up past the idle timeout for tasks due within it
 }
 
 // Status represents the service status
@@ -87,5 +93,21 @@ func BuildServiceArgs(cfg Config) []string {
 		args = append(args, "--bind", cfg.BindAddress)
 	}
 
+	if cfg.Dashboard != "" {
+		args = append(args, "--dashboard", cfg.Dashboard)
+	}
+
+	if cfg.TLSCertFile != "" {
+		args = append(args, "--tls-cert", cfg.TLSCertFile, "--tls-key", cfg.TLSKeyFile)
+	}
+
+	if cfg.IdleTimeout > 0 {
+		args = append(args, "--idle-timeout", cfg.IdleTimeout.String())
+
+		if cfg.TaskWindow > 0 {
+			args = append(args, "--task-window", cfg.TaskWindow.String())
+		}
+	}
+
 	return args
 }
@@ -28,7 +28,14 @@ const launchdPlistTemplate = `<?xml version="1.0" encoding="UTF-8"?>
     <key>RunAtLoad</key>
     <true/>
     <key>KeepAlive</key>
+{{- if .StopWhenIdle}}
+    <dict>
+        <key>SuccessfulExit</key>
+        <false/>
+    </dict>
+{{- else}}
     <true/>
+{{- end}}
     <key>StandardOutPath</key>
     <string>{{.LogPath}}/stdout.log</string>
     <key>StandardErrorPath</key>
@@ -99,16 +106,19 @@ func (m *darwinManager) Install(ctx context.Context, cfg Config) error {
 		_ = f.Close()
 	}()
 
+	// A service stopping when idle is restarted only after failures
 	data := struct {
-		Label   string
-		Args    []string
-		LogPath string
-		WorkDir string
+		Label        string
+		Args         []string
+		LogPath      string
+		WorkDir      string
+		StopWhenIdle bool
 	}{
-		Label:   m.label,
-		Args:    args,
-		LogPath: logPath,
-		WorkDir: home,
+		Label:        m.label,
+		Args:         args,
+		LogPath:      logPath,
+		WorkDir:      home,
+		StopWhenIdle: cfg.IdleTimeout > 0,
 	}
 
 	if err := tmpl.Execute(f, data); err != nil {
@@ -19,7 +19,7 @@ After=network.target
 [Service]
 Type=simple
 ExecStart={{.ExecStart}}
-Restart=always
+Restart={{.Restart}}
 RestartSec=5
 User={{.User}}
 Environment=HOME={{.Home}}
@@ -98,14 +98,22 @@ func (m *linuxManager) Install(ctx context.Context, cfg Config) error {
 		_ = f.Close()
 	}()
 
+	// A service stopping when idle must not be restarted right away
+	restart := "always"
+	if cfg.IdleTimeout > 0 {
+		restart = "on-failure"
+	}
+
 	data := struct {
 		Description string
 		ExecStart   string
+		Restart     string
 		User        string
 		Home        string
 	}{
 		Description: ServiceDescription,
 		ExecStart:   execStart,
+		Restart:     restart,
 		User:        user,
 		Home:        home,
 	}
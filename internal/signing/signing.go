@@ -0,0 +1,176 @@
+// Package signing verifies detached signatures on documents glix fetches
+// from remote URLs, against a public key pinned in local configuration.
+// minisign and cosign (sign-blob with an ECDSA key) signatures are supported.
+package signing
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// Scheme identifies a signature format
+type Scheme string
+
+const (
+	SchemeMinisign Scheme = "minisign"
+	SchemeCosign   Scheme = "cosign"
+)
+
+// ErrBadSignature is returned when a signature does not verify
+var ErrBadSignature = errors.New("signature verification failed")
+
+// minisign algorithm identifiers
+const (
+	minisignLegacy    = "Ed" // Signature over the message
+	minisignPrehashed = "ED" // Signature over the BLAKE2b-512 hash of the message
+)
+
+// Key is a pinned public key
+type Key struct {
+	scheme   Scheme
+	keyID    []byte
+	ed25519  ed25519.PublicKey
+	ecdsaKey *ecdsa.PublicKey
+}
+
+// Scheme reports which signature format the key verifies
+func (k *Key) Scheme() Scheme {
+	return k.scheme
+}
+
+// SignatureSuffix is the conventional extension of detached signatures for
+// the key's scheme
+func (k *Key) SignatureSuffix() string {
+	if k.scheme == SchemeMinisign {
+		return ".minisig"
+	}
+
+	return ".sig"
+}
+
+// ParseKey reads a minisign public key (the base64 line, optionally preceded
+// by its "untrusted comment" line) or a PEM-encoded cosign public key
+func ParseKey(data string) (*Key, error) {
+	data = strings.TrimSpace(data)
+
+	if block, _ := pem.Decode([]byte(data)); block != nil {
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cosign public key: %w", err)
+		}
+
+		ecKey, ok := pub.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("unsupported cosign key type %T (want ECDSA)", pub)
+		}
+
+		return &Key{scheme: SchemeCosign, ecdsaKey: ecKey}, nil
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(lastLine(data))
+	if err != nil || len(raw) != 2+8+ed25519.PublicKeySize || string(raw[:2]) != minisignLegacy {
+		return nil, errors.New("not a minisign public key or PEM cosign public key")
+	}
+
+	return &Key{
+		scheme:  SchemeMinisign,
+		keyID:   raw[2:10],
+		ed25519: ed25519.PublicKey(raw[10:]),
+	}, nil
+}
+
+// Verify checks a detached signature over data
+func (k *Key) Verify(data, signature []byte) error {
+	if k.scheme == SchemeMinisign {
+		return k.verifyMinisign(data, signature)
+	}
+
+	return k.verifyCosign(data, signature)
+}
+
+// verifyCosign checks the base64 ASN.1 ECDSA signature written by
+// 'cosign sign-blob' over the SHA-256 digest of data
+func (k *Key) verifyCosign(data, signature []byte) error {
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return fmt.Errorf("invalid cosign signature encoding: %w", err)
+	}
+
+	digest := sha256.Sum256(data)
+	if !ecdsa.VerifyASN1(k.ecdsaKey, digest[:], sig) {
+		return ErrBadSignature
+	}
+
+	return nil
+}
+
+// verifyMinisign checks a .minisig file: the signature line and the global
+// signature binding the trusted comment
+func (k *Key) verifyMinisign(data, signature []byte) error {
+	lines := strings.Split(strings.ReplaceAll(strings.TrimSpace(string(signature)), "\r\n", "\n"), "\n")
+	if len(lines) < 4 || !strings.HasPrefix(lines[2], "trusted comment: ") {
+		return errors.New("malformed minisign signature")
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil || len(sig) != 2+8+ed25519.SignatureSize {
+		return errors.New("malformed minisign signature")
+	}
+
+	alg, keyID, edSig := string(sig[:2]), sig[2:10], sig[10:]
+
+	if !bytes.Equal(keyID, k.keyID) {
+		return fmt.Errorf("signed by key %X, pinned key is %X", reverse(keyID), reverse(k.keyID))
+	}
+
+	message := data
+
+	switch alg {
+	case minisignLegacy:
+	case minisignPrehashed:
+		sum := blake2b.Sum512(data)
+		message = sum[:]
+	default:
+		return fmt.Errorf("unsupported minisign algorithm %q", alg)
+	}
+
+	if !ed25519.Verify(k.ed25519, message, edSig) {
+		return ErrBadSignature
+	}
+
+	globalSig, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil || len(globalSig) != ed25519.SignatureSize {
+		return errors.New("malformed minisign global signature")
+	}
+
+	trusted := strings.TrimPrefix(lines[2], "trusted comment: ")
+	if !ed25519.Verify(k.ed25519, append(slices.Clone(edSig), trusted...), globalSig) {
+		return fmt.Errorf("%w: trusted comment was altered", ErrBadSignature)
+	}
+
+	return nil
+}
+
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// reverse returns a reversed copy of b; minisign prints key IDs little-endian
+func reverse(b []byte) []byte {
+	out := slices.Clone(b)
+	slices.Reverse(out)
+
+	return out
+}
@@ -0,0 +1,135 @@
+package signing
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// minisignFixture builds a minisign public key and a signature over data
+func minisignFixture(t *testing.T, data []byte, alg string) (pubKey, signature string, priv ed25519.PrivateKey) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyID := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+
+	pubKey = "untrusted comment: minisign public key\n" +
+		base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), keyID...), pub...))
+
+	message := data
+	if alg == "ED" {
+		sum := blake2b.Sum512(data)
+		message = sum[:]
+	}
+
+	sig := ed25519.Sign(priv, message)
+	trusted := "timestamp:1700000000\tfile:catalog.json"
+	global := ed25519.Sign(priv, append(append([]byte(nil), sig...), trusted...))
+
+	signature = fmt.Sprintf("untrusted comment: signature\n%s\ntrusted comment: %s\n%s\n",
+		base64.StdEncoding.EncodeToString(append(append([]byte(alg), keyID...), sig...)),
+		trusted,
+		base64.StdEncoding.EncodeToString(global))
+
+	return pubKey, signature, priv
+}
+
+func TestMinisign(t *testing.T) {
+	data := []byte(`{"denied":[]}`)
+
+	for _, alg := range []string{"Ed", "ED"} {
+		pubKey, signature, _ := minisignFixture(t, data, alg)
+
+		key, err := ParseKey(pubKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if key.Scheme() != SchemeMinisign || key.SignatureSuffix() != ".minisig" {
+			t.Errorf("scheme = %s, suffix = %s", key.Scheme(), key.SignatureSuffix())
+		}
+
+		if err := key.Verify(data, []byte(signature)); err != nil {
+			t.Errorf("%s: Verify = %v", alg, err)
+		}
+
+		if err := key.Verify([]byte(`{"denied":[{}]}`), []byte(signature)); !errors.Is(err, ErrBadSignature) {
+			t.Errorf("%s: tampered data Verify = %v, want ErrBadSignature", alg, err)
+		}
+	}
+
+	// A signature from another key is rejected
+	pubKey, _, _ := minisignFixture(t, data, "ED")
+	_, otherSig, _ := minisignFixture(t, data, "ED")
+
+	key, err := ParseKey(pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := key.Verify(data, []byte(otherSig)); err == nil {
+		t.Error("signature from another key verified")
+	}
+}
+
+func TestCosign(t *testing.T) {
+	data := []byte(`{"denied":[]}`)
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+	digest := sha256.Sum256(data)
+
+	sig, err := ecdsa.SignASN1(rand.Reader, priv, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ParseKey(string(pubPEM))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if key.Scheme() != SchemeCosign || key.SignatureSuffix() != ".sig" {
+		t.Errorf("scheme = %s, suffix = %s", key.Scheme(), key.SignatureSuffix())
+	}
+
+	encoded := []byte(base64.StdEncoding.EncodeToString(sig) + "\n")
+
+	if err := key.Verify(data, encoded); err != nil {
+		t.Errorf("Verify = %v", err)
+	}
+
+	if err := key.Verify([]byte("tampered"), encoded); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("tampered Verify = %v, want ErrBadSignature", err)
+	}
+}
+
+func TestParseKey_Invalid(t *testing.T) {
+	if _, err := ParseKey("not a key"); err == nil {
+		t.Error("ParseKey accepted garbage")
+	}
+}
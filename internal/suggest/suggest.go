@@ -0,0 +1,326 @@
+// Package suggest finds Go tools used on this machine outside glix, from
+// shell history and the binaries in GOBIN, so they can be brought under glix
+// management.
+package suggest
+
+import (
+	"bufio"
+	"debug/buildinfo"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/inovacc/glix/internal/module"
+)
+
+// Sources of a suggestion
+const (
+	SourceHistory = "history"
+	SourceGoBin   = "gobin"
+)
+
+// Suggestion is a tool worth installing with glix
+type Suggestion struct {
+	Module   string   // Package path, as passed to go install
+	Version  string   // Version to install, or "" for the latest
+	Binary   string   // Binary name
+	Sources  []string // Where the tool was found
+	Installs int      // go install invocations in the history
+	Uses     int      // Times the binary was run in the history
+}
+
+// Spec returns the suggestion as a module[@version] argument to install
+func (s Suggestion) Spec() string {
+	if s.Version == "" {
+		return s.Module
+	}
+
+	return s.Module + "@" + s.Version
+}
+
+// History is what the shell history tells about Go tools
+type History struct {
+	Installs map[string]Install // go install invocations by package path
+	Uses     map[string]int     // Commands run, by binary name
+}
+
+// Install is a package installed with go install
+type Install struct {
+	Version string // Version of the last invocation, "" for latest
+	Count   int
+}
+
+// NewHistory returns an empty History
+func NewHistory() *History {
+	return &History{
+		Installs: make(map[string]Install),
+		Uses:     make(map[string]int),
+	}
+}
+
+// HistoryFiles returns the shell history files of the current user that
+// exist: $HISTFILE and the default locations of bash, zsh, fish and
+// PowerShell
+func HistoryFiles() []string {
+	home, _ := os.UserHomeDir()
+
+	candidates := []string{os.Getenv("HISTFILE")}
+
+	if home != "" {
+		candidates = append(candidates,
+			filepath.Join(home, ".bash_history"),
+			filepath.Join(home, ".zsh_history"),
+			filepath.Join(home, ".zhistory"),
+			filepath.Join(home, ".local", "share", "fish", "fish_history"),
+		)
+	}
+
+	if runtime.GOOS == "windows" {
+		if appData := os.Getenv("APPDATA"); appData != "" {
+			candidates = append(candidates, filepath.Join(appData, "Microsoft", "Windows", "PowerShell", "PSReadLine", "ConsoleHost_history.txt"))
+		}
+	}
+
+	var files []string
+
+	for _, path := range candidates {
+		if path == "" || slices.Contains(files, path) {
+			continue
+		}
+
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			files = append(files, path)
+		}
+	}
+
+	return files
+}
+
+// ReadFile adds the commands of a shell history file to h
+func (h *History) ReadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if err := h.Read(f); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Read adds the commands of a shell history to h. Plain, timestamped bash,
+// extended zsh and fish histories are understood.
+func (h *History) Read(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line, ok := historyCommand(scanner.Text())
+		if !ok {
+			continue
+		}
+
+		for _, command := range splitCommands(line) {
+			h.add(command)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// historyCommand returns the command of a history line, without the
+// metadata some shells store with it
+func historyCommand(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+
+	switch {
+	case line == "":
+		return "", false
+	case strings.HasPrefix(line, "- cmd: "):
+		// fish
+		return strings.TrimPrefix(line, "- cmd: "), true
+	case strings.HasPrefix(line, "when: "), strings.HasPrefix(line, "paths:"), strings.HasPrefix(line, "#"):
+		// fish metadata, bash timestamps
+		return "", false
+	case strings.HasPrefix(line, ": "):
+		// zsh extended history, ": <start>:<elapsed>;<command>"
+		if _, command, ok := strings.Cut(line, ";"); ok {
+			return command, true
+		}
+
+		return "", false
+	}
+
+	return line, true
+}
+
+// splitCommands splits a command line on the operators that chain commands
+func splitCommands(line string) []string {
+	return strings.FieldsFunc(line, func(r rune) bool {
+		return r == ';' || r == '|' || r == '&'
+	})
+}
+
+// add records one command
+func (h *History) add(command string) {
+	fields := strings.Fields(command)
+
+	// Skip environment assignments and command wrappers
+	for len(fields) > 0 && (strings.Contains(fields[0], "=") || fields[0] == "sudo" || fields[0] == "env" || fields[0] == "time") {
+		fields = fields[1:]
+	}
+
+	if len(fields) == 0 {
+		return
+	}
+
+	name := strings.Trim(fields[0], `'"`)
+	name = strings.TrimSuffix(filepath.Base(name), ".exe")
+	h.Uses[name]++
+
+	if name != "go" || len(fields) < 3 || fields[1] != "install" {
+		return
+	}
+
+	for _, arg := range fields[2:] {
+		arg = strings.Trim(arg, `'"`)
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+
+		// Without a version go install builds in the current module, which
+		// says nothing about a tool
+		i := strings.LastIndex(arg, "@")
+		if i <= 0 || !strings.Contains(arg[:i], ".") {
+			continue
+		}
+
+		pkg, version := arg[:i], arg[i+1:]
+		if version == "latest" {
+			version = ""
+		}
+
+		h.Installs[pkg] = Install{Version: version, Count: h.Installs[pkg].Count + 1}
+	}
+}
+
+// Binary is a Go binary found in GOBIN
+type Binary struct {
+	Name    string
+	Path    string
+	Package string // Main package path
+	Version string // Module version, "" when not reproducible
+}
+
+// ScanGoBin returns the Go binaries in dir
+func ScanGoBin(dir string) ([]Binary, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	var binaries []Binary
+
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+
+		path := filepath.Join(dir, e.Name())
+
+		info, err := buildinfo.ReadFile(path)
+		if err != nil || info.Path == "" {
+			continue
+		}
+
+		// Local builds record (devel) or a +dirty version, which no proxy
+		// serves
+		version := info.Main.Version
+		if version == "(devel)" || strings.HasSuffix(version, "+dirty") {
+			version = ""
+		}
+
+		binaries = append(binaries, Binary{
+			Name:    strings.TrimSuffix(e.Name(), ".exe"),
+			Path:    path,
+			Package: info.Path,
+			Version: version,
+		})
+	}
+
+	return binaries, nil
+}
+
+// Suggest returns the tools of the history and of GOBIN that glix does not
+// manage, most used first. managed holds the package paths glix installed.
+func Suggest(h *History, binaries []Binary, managed map[string]bool) []Suggestion {
+	byModule := make(map[string]*Suggestion)
+
+	for pkg, install := range h.Installs {
+		if managed[pkg] {
+			continue
+		}
+
+		binary := module.BinaryName(pkg)
+
+		byModule[pkg] = &Suggestion{
+			Module:   pkg,
+			Version:  install.Version,
+			Binary:   binary,
+			Sources:  []string{SourceHistory},
+			Installs: install.Count,
+			Uses:     h.Uses[binary],
+		}
+	}
+
+	for _, b := range binaries {
+		if managed[b.Package] {
+			continue
+		}
+
+		s, ok := byModule[b.Package]
+		if !ok {
+			s = &Suggestion{Module: b.Package, Uses: h.Uses[b.Name]}
+			byModule[b.Package] = s
+		}
+
+		// The binary in GOBIN is what is in use, whatever was installed last
+		s.Binary = b.Name
+		s.Version = b.Version
+		s.Uses = h.Uses[b.Name]
+		s.Sources = append(s.Sources, SourceGoBin)
+	}
+
+	suggestions := make([]Suggestion, 0, len(byModule))
+	for _, s := range byModule {
+		suggestions = append(suggestions, *s)
+	}
+
+	slices.SortFunc(suggestions, func(a, b Suggestion) int {
+		if a.Uses != b.Uses {
+			return b.Uses - a.Uses
+		}
+
+		if a.Installs != b.Installs {
+			return b.Installs - a.Installs
+		}
+
+		return strings.Compare(a.Module, b.Module)
+	})
+
+	return suggestions
+}
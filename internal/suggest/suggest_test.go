@@ -0,0 +1,127 @@
+package suggest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHistory_Read(t *testing.T) {
+	history := `#1700000000
+go install golang.org/x/tools/gopls@v0.16.0
+cd ~/src && go install github.com/go-delve/delve/cmd/dlv@latest
+GOFLAGS=-mod=mod go install -v 'honnef.co/go/tools/cmd/staticcheck@2024.1'
+go install ./cmd/local
+go install golang.org/x/tools/gopls@v0.17.0
+: 1700000001:0;dlv debug ./cmd/app
+- cmd: gopls version
+  when: 1700000002
+/home/me/go/bin/dlv version | head -1
+`
+
+	h := NewHistory()
+	if err := h.Read(strings.NewReader(history)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]Install{
+		"golang.org/x/tools/gopls":           {Version: "v0.17.0", Count: 2},
+		"github.com/go-delve/delve/cmd/dlv":  {Version: "", Count: 1},
+		"honnef.co/go/tools/cmd/staticcheck": {Version: "2024.1", Count: 1},
+	}
+
+	if len(h.Installs) != len(want) {
+		t.Errorf("Installs = %v, want %v", h.Installs, want)
+	}
+
+	for pkg, install := range want {
+		if got := h.Installs[pkg]; got != install {
+			t.Errorf("Installs[%s] = %+v, want %+v", pkg, got, install)
+		}
+	}
+
+	for name, uses := range map[string]int{"go": 5, "dlv": 2, "gopls": 1, "cd": 1, "head": 1} {
+		if got := h.Uses[name]; got != uses {
+			t.Errorf("Uses[%s] = %d, want %d", name, got, uses)
+		}
+	}
+
+	if _, ok := h.Uses["1700000000"]; ok {
+		t.Error("bash timestamps should not count as commands")
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	h := NewHistory()
+	h.Installs["golang.org/x/tools/gopls"] = Install{Version: "v0.16.0", Count: 1}
+	h.Installs["github.com/go-delve/delve/cmd/dlv"] = Install{Count: 2}
+	h.Installs["example.com/managed"] = Install{Count: 1}
+	h.Uses["dlv"] = 10
+	h.Uses["gopls"] = 3
+
+	binaries := []Binary{
+		// Updated since the install in the history
+		{Name: "gopls", Package: "golang.org/x/tools/gopls", Version: "v0.17.0"},
+		{Name: "stringer", Package: "golang.org/x/tools/cmd/stringer", Version: "v0.20.0"},
+		{Name: "managed", Package: "example.com/managed", Version: "v1.0.0"},
+	}
+
+	got := Suggest(h, binaries, map[string]bool{"example.com/managed": true})
+
+	want := []string{
+		"github.com/go-delve/delve/cmd/dlv",
+		"golang.org/x/tools/gopls@v0.17.0",
+		"golang.org/x/tools/cmd/stringer@v0.20.0",
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Suggest() returned %d suggestions, want %d: %+v", len(got), len(want), got)
+	}
+
+	for i, spec := range want {
+		if got[i].Spec() != spec {
+			t.Errorf("suggestion %d = %s, want %s", i, got[i].Spec(), spec)
+		}
+	}
+
+	if sources := strings.Join(got[1].Sources, ","); sources != "history,gobin" {
+		t.Errorf("gopls sources = %s, want history,gobin", sources)
+	}
+}
+
+func TestScanGoBin(t *testing.T) {
+	dir := t.TempDir()
+
+	// The test binary is a Go binary
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(exe)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "tool"), data, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "script"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	binaries, err := ScanGoBin(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(binaries) != 1 || binaries[0].Name != "tool" || binaries[0].Package == "" {
+		t.Errorf("ScanGoBin() = %+v, want the Go binary only", binaries)
+	}
+
+	if binaries, err := ScanGoBin(filepath.Join(dir, "missing")); err != nil || binaries != nil {
+		t.Errorf("ScanGoBin(missing) = %v, %v, want nothing", binaries, err)
+	}
+}
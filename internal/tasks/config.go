@@ -0,0 +1,161 @@
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/inovacc/glix/internal/module"
+)
+
+// TaskConfig overrides a built-in task or defines a user task
+type TaskConfig struct {
+	Schedule string `json:"schedule,omitempty"` // Cron expression; empty keeps the default
+	Disabled bool   `json:"disabled,omitempty"`
+
+	// Command, if set, defines a user task that runs this command. It is
+	// started directly, not through a shell.
+	Command []string `json:"command,omitempty"`
+}
+
+// configStore handles persistent storage of task configuration
+type configStore struct {
+	mu       sync.RWMutex
+	tasks    map[string]TaskConfig
+	filePath string
+}
+
+var (
+	store     *configStore
+	storeOnce sync.Once
+)
+
+// getConfigPath returns the path to the tasks config file
+func getConfigPath() string {
+	configDir, err := module.GetApplicationConfigDirectory()
+	if err != nil {
+		// Fallback to cache directory
+		configDir, _ = module.GetApplicationCacheDirectory()
+	}
+
+	return filepath.Join(configDir, "tasks.json")
+}
+
+// GetStore returns the singleton config store
+func GetStore() *configStore {
+	storeOnce.Do(func() {
+		store = &configStore{
+			filePath: getConfigPath(),
+			tasks:    make(map[string]TaskConfig),
+		}
+		// Load existing config if available
+		_ = store.load()
+	})
+
+	return store
+}
+
+// load reads the configuration from disk
+func (s *configStore) load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil // Use defaults
+		}
+
+		return fmt.Errorf("failed to read task config: %w", err)
+	}
+
+	tasks := make(map[string]TaskConfig)
+	if err := json.Unmarshal(data, &tasks); err != nil {
+		return fmt.Errorf("failed to parse task config: %w", err)
+	}
+
+	s.tasks = tasks
+
+	return nil
+}
+
+// Reload re-reads the configuration from disk, picking up changes made by
+// the CLI (the daemon's scheduler calls this every minute)
+func (s *configStore) Reload() error {
+	return s.load()
+}
+
+// save writes the configuration to disk
+func (s *configStore) save() error {
+	dir := filepath.Dir(s.filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s.tasks, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal task config: %w", err)
+	}
+
+	if err := os.WriteFile(s.filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write task config: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the configuration of a task
+func (s *configStore) Get(name string) (TaskConfig, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.tasks[name]
+
+	return c, ok
+}
+
+// All returns a copy of every task configuration by name
+func (s *configStore) All() map[string]TaskConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	all := make(map[string]TaskConfig, len(s.tasks))
+	for name, c := range s.tasks {
+		all[name] = c
+	}
+
+	return all
+}
+
+// Set stores the configuration of a task. The schedule is validated.
+func (s *configStore) Set(name string, c TaskConfig) error {
+	if c.Schedule != "" {
+		if _, err := Parse(c.Schedule); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tasks[name] = c
+
+	return s.save()
+}
+
+// Delete removes the configuration of a task, restoring a built-in task's
+// defaults or removing a user task
+func (s *configStore) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tasks[name]; !ok {
+		return fmt.Errorf("task %q is not configured", name)
+	}
+
+	delete(s.tasks, name)
+
+	return s.save()
+}
@@ -0,0 +1,158 @@
+package tasks
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// macros are the cron shorthands accepted in place of five fields
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// field bounds: minute, hour, day of month, month, day of week
+var fieldBounds = [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+
+// Schedule is a parsed cron expression: minute, hour, day of month, month,
+// and day of week, each a set of values
+type Schedule struct {
+	fields [5]uint64
+
+	// When both day fields are restricted, a day matching either one
+	// matches, as in cron(8)
+	domAny, dowAny bool
+}
+
+// Parse parses a five-field cron expression or one of the @hourly, @daily,
+// @weekly, @monthly, and @yearly shorthands. Fields accept *, lists, ranges,
+// and steps, e.g. "*/15 9-17 * * 1-5"; day of week 0 and 7 are Sunday.
+func Parse(expr string) (*Schedule, error) {
+	if m, ok := macros[strings.ToLower(strings.TrimSpace(expr))]; ok {
+		expr = m
+	}
+
+	parts := strings.Fields(expr)
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q: expected 5 fields, got %d", expr, len(parts))
+	}
+
+	s := &Schedule{domAny: parts[2] == "*", dowAny: parts[4] == "*"}
+
+	for i, part := range parts {
+		bits, err := parseField(part, fieldBounds[i][0], fieldBounds[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %w", expr, err)
+		}
+
+		s.fields[i] = bits
+	}
+
+	// Sunday may be written as 7
+	if s.fields[4]&(1<<7) != 0 {
+		s.fields[4] |= 1
+	}
+
+	return s, nil
+}
+
+func parseField(field string, lo, hi int) (uint64, error) {
+	var bits uint64
+
+	for item := range strings.SplitSeq(field, ",") {
+		rng, step := item, 1
+
+		if before, after, ok := strings.Cut(item, "/"); ok {
+			n, err := strconv.Atoi(after)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step in %q", item)
+			}
+
+			rng, step = before, n
+		}
+
+		start, end := lo, hi
+
+		if rng != "*" {
+			first, last, isRange := strings.Cut(rng, "-")
+
+			var err error
+			if start, err = strconv.Atoi(first); err != nil {
+				return 0, fmt.Errorf("invalid value in %q", item)
+			}
+
+			end = start
+
+			if isRange {
+				if end, err = strconv.Atoi(last); err != nil {
+					return 0, fmt.Errorf("invalid value in %q", item)
+				}
+			} else if step > 1 {
+				// "5/10" means from 5 to the end in steps of 10
+				end = hi
+			}
+		}
+
+		if start < lo || end > hi || start > end {
+			return 0, fmt.Errorf("%q is out of range %d-%d", item, lo, hi)
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << v
+		}
+	}
+
+	return bits, nil
+}
+
+func (s *Schedule) has(field, value int) bool {
+	return s.fields[field]&(1<<value) != 0
+}
+
+// dayMatches reports whether the day of t matches the day fields
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := s.has(2, t.Day())
+	dow := s.has(4, int(t.Weekday()))
+
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dow
+	case s.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// Next returns the first time after t the schedule fires, in t's location,
+// or the zero time if it never does (e.g. February 30)
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case !s.has(3, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case !s.has(1, t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case !s.has(0, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,443 @@
+// Package tasks runs recurring daemon jobs, built in or user defined, on
+// cron schedules.
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/inovacc/glix/pkg/exec"
+)
+
+// CommandTimeout bounds a run of a user task
+const CommandTimeout = time.Hour
+
+// ErrSkipped is returned by a scheduled run that had nothing to do, e.g. an
+// update check that is not due yet. Skipped runs are not recorded.
+var ErrSkipped = errors.New("skipped")
+
+// validName matches task names: lowercase words separated by dashes
+var validName = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Task is a job run on a schedule
+type Task struct {
+	Name        string
+	Description string
+	Schedule    string // Default cron expression
+
+	// Run performs the task and returns a one-line summary. manual is set
+	// when the run was requested rather than scheduled.
+	Run func(ctx context.Context, manual bool) (string, error)
+
+	// Due, if set, returns when the task next has work to do, for tasks
+	// scheduled more often than they have any, such as the auto-update check
+	// that runs every minute and skips until its interval has passed. The
+	// zero time means no work is planned.
+	Due func() time.Time
+
+	// Opportunistic tasks only keep caches warm; an idle on-demand server
+	// shuts down rather than wait for them.
+	Opportunistic bool
+}
+
+// Status describes a task and its last run
+type Status struct {
+	Name         string
+	Description  string
+	Schedule     string
+	Enabled      bool
+	Builtin      bool
+	Running      bool
+	Next         time.Time // Zero when disabled
+	LastRun      time.Time // Zero when it has not run yet
+	LastDuration time.Duration
+	LastSuccess  bool
+	LastResult   string
+}
+
+// resolvedTask is a task with its configuration applied
+type resolvedTask struct {
+	Task
+
+	schedule *Schedule
+	enabled  bool
+	builtin  bool
+}
+
+// runState is what the scheduler remembers about a task's runs
+type runState struct {
+	running  bool
+	last     time.Time
+	duration time.Duration
+	success  bool
+	result   string
+}
+
+// Scheduler runs built-in tasks and user tasks from the config store
+type Scheduler struct {
+	logger  *slog.Logger
+	store   *configStore
+	builtin []Task
+
+	mu     sync.Mutex
+	state  map[string]*runState
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewScheduler creates a scheduler for the built-in tasks. Their schedules
+// can be overridden, and user tasks added, in the tasks config file.
+func NewScheduler(logger *slog.Logger, builtin ...Task) *Scheduler {
+	if logger == nil {
+		logger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+			Level: slog.LevelInfo,
+		}))
+	}
+
+	return &Scheduler{
+		logger:  logger,
+		store:   GetStore(),
+		builtin: builtin,
+		state:   make(map[string]*runState),
+	}
+}
+
+// ValidateName checks that name is usable for a user task
+func ValidateName(name string) error {
+	if !validName.MatchString(name) {
+		return fmt.Errorf("invalid task name %q: use lowercase letters, digits, and dashes", name)
+	}
+
+	return nil
+}
+
+// Start runs due tasks at the start of every minute until Stop is called or
+// ctx is done
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		return
+	}
+
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	s.wg.Add(1)
+
+	go s.run(ctx)
+
+	s.logger.Info("task scheduler started", "tasks", len(s.resolve()))
+}
+
+// Stop stops the scheduler and waits for running tasks to finish
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+
+	if s.cancel == nil {
+		s.mu.Unlock()
+		return
+	}
+
+	s.cancel()
+	s.cancel = nil
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	s.logger.Info("task scheduler stopped")
+}
+
+// run is the main scheduler loop
+func (s *Scheduler) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	for {
+		now := time.Now()
+		next := now.Truncate(time.Minute).Add(time.Minute)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(next.Sub(now)):
+			s.tick(ctx, next)
+		}
+	}
+}
+
+// tick starts the enabled tasks scheduled for minute
+func (s *Scheduler) tick(ctx context.Context, minute time.Time) {
+	// Pick up schedules changed by the CLI since the last tick
+	if err := s.store.Reload(); err != nil {
+		s.logger.Warn("failed to reload task config", "error", err)
+	}
+
+	for _, t := range s.resolve() {
+		if !t.enabled || !t.schedule.Next(minute.Add(-time.Minute)).Equal(minute) {
+			continue
+		}
+
+		if !s.begin(t.Name) {
+			s.logger.Warn("task still running, skipping scheduled run", "task", t.Name)
+			continue
+		}
+
+		s.wg.Add(1)
+
+		go func() {
+			defer s.wg.Done()
+
+			_, _ = s.execute(ctx, t, false)
+		}()
+	}
+}
+
+// Run runs a task now and waits for it to finish
+func (s *Scheduler) Run(ctx context.Context, name string) (string, error) {
+	if err := s.store.Reload(); err != nil {
+		s.logger.Warn("failed to reload task config", "error", err)
+	}
+
+	tasks := s.resolve()
+
+	i := slices.IndexFunc(tasks, func(t resolvedTask) bool { return t.Name == name })
+	if i < 0 {
+		return "", fmt.Errorf("unknown task %q", name)
+	}
+
+	if !s.begin(name) {
+		return "", fmt.Errorf("task %q is already running", name)
+	}
+
+	return s.execute(ctx, tasks[i], true)
+}
+
+// List returns the status of every task, built-in tasks first
+func (s *Scheduler) List() []Status {
+	if err := s.store.Reload(); err != nil {
+		s.logger.Warn("failed to reload task config", "error", err)
+	}
+
+	now := time.Now()
+	tasks := s.resolve()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]Status, 0, len(tasks))
+
+	for _, t := range tasks {
+		st := Status{
+			Name:        t.Name,
+			Description: t.Description,
+			Schedule:    t.Schedule,
+			Enabled:     t.enabled,
+			Builtin:     t.builtin,
+		}
+
+		if t.enabled {
+			st.Next = t.schedule.Next(now)
+		}
+
+		if rs, ok := s.state[t.Name]; ok {
+			st.Running = rs.running
+			st.LastRun = rs.last
+			st.LastDuration = rs.duration
+			st.LastSuccess = rs.success
+			st.LastResult = rs.result
+		}
+
+		statuses = append(statuses, st)
+	}
+
+	return statuses
+}
+
+// NextDue returns the enabled task, opportunistic ones aside, that next has
+// work to do after now and when it runs, or now for a task that is running.
+// The name is empty when no task is planned.
+func (s *Scheduler) NextDue(now time.Time) (string, time.Time) {
+	if err := s.store.Reload(); err != nil {
+		s.logger.Warn("failed to reload task config", "error", err)
+	}
+
+	tasks := s.resolve()
+
+	var (
+		name string
+		next time.Time
+	)
+
+	for _, t := range tasks {
+		if !t.enabled || t.Opportunistic {
+			continue
+		}
+
+		s.mu.Lock()
+		rs, ok := s.state[t.Name]
+		running := ok && rs.running
+		s.mu.Unlock()
+
+		at := t.schedule.Next(now)
+		if running {
+			at = now
+		} else if t.Due != nil {
+			due := t.Due()
+			if due.IsZero() {
+				continue
+			}
+
+			// The first scheduled run at or after the work is due
+			if due.After(now) {
+				at = t.schedule.Next(due.Add(-time.Nanosecond))
+			}
+		}
+
+		if !at.IsZero() && (next.IsZero() || at.Before(next)) {
+			name, next = t.Name, at
+		}
+	}
+
+	return name, next
+}
+
+// begin marks a task running; it returns false if it already is
+func (s *Scheduler) begin(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rs, ok := s.state[name]
+	if !ok {
+		rs = &runState{}
+		s.state[name] = rs
+	}
+
+	if rs.running {
+		return false
+	}
+
+	rs.running = true
+
+	return true
+}
+
+// execute runs a task marked running by begin and records the outcome
+func (s *Scheduler) execute(ctx context.Context, t resolvedTask, manual bool) (string, error) {
+	start := time.Now()
+	result, err := t.Run(ctx, manual)
+	elapsed := time.Since(start)
+
+	s.mu.Lock()
+	rs := s.state[t.Name]
+	rs.running = false
+
+	if !errors.Is(err, ErrSkipped) {
+		rs.last = start
+		rs.duration = elapsed
+		rs.success = err == nil
+		rs.result = result
+
+		if err != nil {
+			rs.result = err.Error()
+		}
+	}
+	s.mu.Unlock()
+
+	switch {
+	case errors.Is(err, ErrSkipped):
+	case err != nil:
+		s.logger.Error("task failed", "task", t.Name, "duration", elapsed, "error", err)
+	default:
+		s.logger.Info("task completed", "task", t.Name, "duration", elapsed, "result", result)
+	}
+
+	return result, err
+}
+
+// resolve applies the config store to the built-in tasks and adds the user
+// tasks it defines. Invalid schedules disable the task.
+func (s *Scheduler) resolve() []resolvedTask {
+	configs := s.store.All()
+	tasks := make([]resolvedTask, 0, len(s.builtin)+len(configs))
+
+	for _, t := range s.builtin {
+		c := configs[t.Name]
+		if c.Schedule != "" {
+			t.Schedule = c.Schedule
+		}
+
+		tasks = append(tasks, s.resolveTask(t, !c.Disabled, true))
+	}
+
+	var user []string
+
+	for name, c := range configs {
+		if len(c.Command) > 0 && !slices.ContainsFunc(s.builtin, func(t Task) bool { return t.Name == name }) {
+			user = append(user, name)
+		}
+	}
+
+	slices.Sort(user)
+
+	for _, name := range user {
+		c := configs[name]
+
+		tasks = append(tasks, s.resolveTask(Task{
+			Name:        name,
+			Description: strings.Join(c.Command, " "),
+			Schedule:    c.Schedule,
+			Run:         commandRunner(c.Command),
+		}, !c.Disabled, false))
+	}
+
+	return tasks
+}
+
+func (s *Scheduler) resolveTask(t Task, enabled, builtin bool) resolvedTask {
+	schedule, err := Parse(t.Schedule)
+	if err != nil {
+		s.logger.Warn("task disabled", "task", t.Name, "error", err)
+	}
+
+	return resolvedTask{Task: t, schedule: schedule, enabled: enabled && err == nil, builtin: builtin}
+}
+
+// commandRunner returns the Run function of a user task
+func commandRunner(command []string) func(ctx context.Context, manual bool) (string, error) {
+	return func(ctx context.Context, _ bool) (string, error) {
+		ctx, cancel := context.WithTimeout(ctx, CommandTimeout)
+		defer cancel()
+
+		output, err := exec.CommandContext(ctx, command[0], command[1:]...).CombinedOutput()
+
+		summary := lastLine(string(output))
+		if err != nil {
+			if summary != "" {
+				return "", fmt.Errorf("%w: %s", err, summary)
+			}
+
+			return "", err
+		}
+
+		return summary, nil
+	}
+}
+
+// lastLine returns the last non-empty line of command output, shortened
+func lastLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	line := strings.TrimSpace(lines[len(lines)-1])
+
+	if len(line) > 200 {
+		line = line[:197] + "..."
+	}
+
+	return line
+}
@@ -0,0 +1,202 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func newTestScheduler(t *testing.T, builtin ...Task) *Scheduler {
+	t.Helper()
+
+	s := NewScheduler(nil, builtin...)
+	s.store = &configStore{
+		filePath: filepath.Join(t.TempDir(), "tasks.json"),
+		tasks:    make(map[string]TaskConfig),
+	}
+
+	return s
+}
+
+func TestScheduler_Tick(t *testing.T) {
+	ran := make(chan string, 4)
+
+	task := func(name, schedule string) Task {
+		return Task{Name: name, Schedule: schedule, Run: func(context.Context, bool) (string, error) {
+			ran <- name
+			return "done", nil
+		}}
+	}
+
+	s := newTestScheduler(t, task("hourly", "@hourly"), task("nightly", "0 3 * * *"), task("off", "@hourly"))
+
+	if err := s.store.Set("off", TaskConfig{Disabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.tick(context.Background(), time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local))
+	s.wg.Wait()
+	close(ran)
+
+	var got []string
+	for name := range ran {
+		got = append(got, name)
+	}
+
+	if len(got) != 1 || got[0] != "hourly" {
+		t.Errorf("ran %v, want [hourly]", got)
+	}
+
+	for _, st := range s.List() {
+		switch st.Name {
+		case "hourly":
+			if st.LastRun.IsZero() || !st.LastSuccess || st.LastResult != "done" {
+				t.Errorf("hourly status = %+v, want a successful run", st)
+			}
+		case "off":
+			if st.Enabled || !st.Next.IsZero() {
+				t.Errorf("off status = %+v, want disabled", st)
+			}
+		}
+	}
+}
+
+func TestScheduler_Run(t *testing.T) {
+	s := newTestScheduler(t,
+		Task{Name: "failing", Schedule: "@daily", Run: func(context.Context, bool) (string, error) {
+			return "", errors.New("boom")
+		}},
+		Task{Name: "idle", Schedule: "@daily", Run: func(_ context.Context, manual bool) (string, error) {
+			if !manual {
+				return "", ErrSkipped
+			}
+
+			return "forced", nil
+		}},
+	)
+
+	if _, err := s.Run(context.Background(), "missing"); err == nil {
+		t.Error("expected an error for an unknown task")
+	}
+
+	if _, err := s.Run(context.Background(), "failing"); err == nil || err.Error() != "boom" {
+		t.Errorf("Run(failing) error = %v, want boom", err)
+	}
+
+	if result, err := s.Run(context.Background(), "idle"); err != nil || result != "forced" {
+		t.Errorf("Run(idle) = %q, %v, want forced", result, err)
+	}
+
+	// Scheduled runs that skip leave no trace
+	s.tick(context.Background(), time.Date(2026, 3, 5, 0, 0, 0, 0, time.Local))
+	s.wg.Wait()
+
+	for _, st := range s.List() {
+		switch st.Name {
+		case "failing":
+			if st.LastSuccess || st.LastResult != "boom" {
+				t.Errorf("failing status = %+v, want the error recorded", st)
+			}
+		case "idle":
+			if st.LastResult != "forced" {
+				t.Errorf("idle status = %+v, want the forced run recorded", st)
+			}
+		}
+	}
+}
+
+func TestScheduler_NextDue(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 7, 30, 0, time.Local)
+	due := now.Add(2 * time.Hour)
+
+	noop := func(context.Context, bool) (string, error) { return "", nil }
+
+	s := newTestScheduler(t,
+		Task{Name: "update", Schedule: "* * * * *", Run: noop, Due: func() time.Time { return due }},
+		Task{Name: "nightly", Schedule: "0 3 * * *", Run: noop},
+		Task{Name: "refresh", Schedule: "*/30 * * * *", Run: noop, Opportunistic: true},
+	)
+
+	// Runs every minute but has work in two hours; opportunistic tasks are ignored
+	if name, at := s.NextDue(now); name != "update" || !at.Equal(time.Date(2026, 3, 4, 12, 8, 0, 0, time.Local)) {
+		t.Errorf("NextDue = %s at %v, want update at 12:08", name, at)
+	}
+
+	// Overdue work runs at the next tick
+	due = now.Add(-time.Hour)
+	if name, at := s.NextDue(now); name != "update" || !at.Equal(time.Date(2026, 3, 4, 10, 8, 0, 0, time.Local)) {
+		t.Errorf("NextDue = %s at %v, want update at 10:08", name, at)
+	}
+
+	// No planned work leaves the next scheduled task
+	due = time.Time{}
+	if name, at := s.NextDue(now); name != "nightly" || !at.Equal(time.Date(2026, 3, 5, 3, 0, 0, 0, time.Local)) {
+		t.Errorf("NextDue = %s at %v, want nightly at 03:00", name, at)
+	}
+
+	// A running task is due now
+	s.begin("nightly")
+
+	if name, at := s.NextDue(now); name != "nightly" || !at.Equal(now) {
+		t.Errorf("NextDue = %s at %v, want the running nightly now", name, at)
+	}
+
+	if err := s.store.Set("nightly", TaskConfig{Disabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if name, _ := s.NextDue(now); name != "" {
+		t.Errorf("NextDue = %s, want no task planned", name)
+	}
+}
+
+func TestScheduler_UserTasks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	s := newTestScheduler(t, Task{Name: "backup", Schedule: "@daily", Run: func(context.Context, bool) (string, error) {
+		return "", nil
+	}})
+
+	if err := s.store.Set("greet", TaskConfig{Schedule: "*/5 * * * *", Command: []string{"sh", "-c", "echo one; echo two"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A command cannot replace a built-in task
+	if err := s.store.Set("backup", TaskConfig{Command: []string{"true"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.store.Set("bad", TaskConfig{Schedule: "never", Command: []string{"true"}}); err == nil {
+		t.Error("expected an invalid schedule to be rejected")
+	}
+
+	statuses := s.List()
+	if len(statuses) != 2 || statuses[1].Name != "greet" || statuses[1].Builtin {
+		t.Fatalf("statuses = %+v, want backup and the user task greet", statuses)
+	}
+
+	result, err := s.Run(context.Background(), "greet")
+	if err != nil || result != "two" {
+		t.Errorf("Run(greet) = %q, %v, want the last output line", result, err)
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	for name, valid := range map[string]bool{
+		"cache-gc": true,
+		"backup2":  true,
+		"Backup":   false,
+		"-gc":      false,
+		"a b":      false,
+		"":         false,
+	} {
+		if err := ValidateName(name); (err == nil) != valid {
+			t.Errorf("ValidateName(%q) = %v, want valid=%v", name, err, valid)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package transport
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeSelfSigned writes a self-signed certificate and key to dir
+func writeSelfSigned(t *testing.T, dir string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "glix"},
+		DNSNames:     []string{"glix.example"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
+
+func TestCredentials(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeSelfSigned(t, dir)
+
+	if _, err := ServerCredentials(certFile, keyFile); err != nil {
+		t.Errorf("ServerCredentials: %v", err)
+	}
+
+	if _, err := PinnedCredentials(certFile); err != nil {
+		t.Errorf("PinnedCredentials: %v", err)
+	}
+
+	creds, err := ClientCredentials(TLS{Enabled: true, CAFile: certFile})
+	if err != nil {
+		t.Fatalf("ClientCredentials: %v", err)
+	}
+
+	if got := creds.Info().SecurityProtocol; got != "tls" {
+		t.Errorf("SecurityProtocol = %q, want tls", got)
+	}
+
+	creds, err = ClientCredentials(TLS{})
+	if err != nil || creds.Info().SecurityProtocol != "insecure" {
+		t.Errorf("disabled TLS should give insecure credentials, got %v, %v", creds, err)
+	}
+
+	// A key is not a CA bundle
+	if _, err := ClientCredentials(TLS{Enabled: true, CAFile: keyFile}); err == nil {
+		t.Error("expected error for a CA file without certificates")
+	}
+
+	if _, err := PinnedCredentials(keyFile); err == nil {
+		t.Error("expected error for a certificate file without certificates")
+	}
+}
@@ -1,9 +1,12 @@
 package tui
 
+import pb "github.com/inovacc/glix/pkg/api/v1"
+
 // ProgressMsg represents a progress update from module operations
 type ProgressMsg struct {
 	Phase   string
 	Message string
+	Update  *pb.ProgressUpdate // Structured phase, timing, and step counters
 }
 
 // OutputMsg represents output from go install or build commands
@@ -22,3 +25,11 @@ type DoneMsg struct {
 	Success bool
 	Error   error
 }
+
+// SelectMsg asks to pick one of choices, replying with the index picked or
+// -1 when cancelled
+type SelectMsg struct {
+	Title   string
+	Choices []Choice
+	Reply   chan<- int
+}
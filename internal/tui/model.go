@@ -2,33 +2,69 @@ package tui
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/aymanbagabas/go-osc52/v2"
+	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/inovacc/glix/internal/humanize"
+	"github.com/inovacc/glix/internal/progress"
+	pb "github.com/inovacc/glix/pkg/api/v1"
 )
 
 const (
 	defaultMaxLogs = 15
+
+	// maxHistory is how many lines are kept to scroll back through and copy
+	maxHistory = 1000
+
+	// defaultCopyLines is how many lines c copies without a count
+	defaultCopyLines = 50
 )
 
+// clipboardOutput is the terminal the clipboard escape sequence is written
+// to; stderr, so it does not interleave with the frames on stdout
+var clipboardOutput io.Writer = os.Stderr
+
 // Model represents the Bubble Tea model for the TUI
 type Model struct {
 	spinner spinner.Model
 	phase   string
 	message string
-	logs    []logEntry
-	maxLogs int
-	status  string
-	done    bool
-	err     error
-	width   int
-	height  int
+	update  *pb.ProgressUpdate
+	logs    []logEntry // Last maxHistory lines
+	maxLogs int        // Lines shown at once
+	seq     int        // Sequence number of the last line
+
+	// Keybindings
+	paused    bool   // Autoscroll paused
+	anchor    int    // Sequence number of the bottom line shown while paused
+	condensed bool   // Phases only, without the raw build output
+	count     string // Digits typed before a command, as in 200c
+	notice    string // Outcome of the last command
+	status    string
+	done      bool
+
+	// Selection asked for by the operation, shown in place of the logs
+	picking   bool
+	choices   list.Model
+	pickReply chan<- int
+
+	err    error
+	width  int
+	height int
 }
 
 type logEntry struct {
+	seq      int
 	text     string
 	isStderr bool
+	isPhase  bool // Progress line rather than build output
 }
 
 // NewModel creates a new TUI model
@@ -52,24 +88,51 @@ func (m Model) Init() tea.Cmd {
 
 // Update implements tea.Model
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if !m.picking {
+		return m.updateProgress(msg)
+	}
+
+	// Keys go to the selection; progress keeps coming in meanwhile
+	if key, ok := msg.(tea.KeyMsg); ok {
+		return m.handlePickKey(key)
+	}
+
+	var listCmd tea.Cmd
+
+	m.choices, listCmd = m.choices.Update(msg)
+
+	next, cmd := m.updateProgress(msg)
+
+	return next, tea.Batch(listCmd, cmd)
+}
+
+// updateProgress applies a message to the progress view
+func (m Model) updateProgress(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q":
-			return m, tea.Quit
-		}
+		return m.handleKey(msg)
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 
+		if m.picking {
+			resizeChoiceList(&m.choices, m.width, m.height-4)
+		}
+
+	case SelectMsg:
+		m.picking = true
+		m.choices = newChoiceList(msg.Title, msg.Choices, m.width, m.height-4)
+		m.pickReply = msg.Reply
+
 	case ProgressMsg:
 		m.phase = msg.Phase
 		m.message = msg.Message
-		m.addLog(fmt.Sprintf("[%s] %s", msg.Phase, msg.Message), false)
+		m.update = msg.Update
+		m.addLog(logEntry{text: fmt.Sprintf("[%s] %s", msg.Phase, msg.Message), isPhase: true})
 
 	case OutputMsg:
-		m.addLog(msg.Line, msg.Stream == "stderr")
+		m.addLog(logEntry{text: msg.Line, isStderr: msg.Stream == "stderr"})
 
 	case StatusMsg:
 		m.status = msg.Text
@@ -97,14 +160,193 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// addLog adds a log entry and maintains the max log size
-func (m *Model) addLog(text string, isStderr bool) {
-	m.logs = append(m.logs, logEntry{text: text, isStderr: isStderr})
-	if len(m.logs) > m.maxLogs {
+// handlePickKey passes a key to the selection, replying once a choice was
+// picked or the selection cancelled
+func (m Model) handlePickKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if done, index := choose(m.choices, msg); done {
+		m.picking = false
+		m.pickReply <- index
+
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+
+	m.choices, cmd = m.choices.Update(msg)
+
+	return m, cmd
+}
+
+// addLog adds a log entry and maintains the history size
+func (m *Model) addLog(entry logEntry) {
+	m.seq++
+	entry.seq = m.seq
+
+	m.logs = append(m.logs, entry)
+	if len(m.logs) > maxHistory {
 		m.logs = m.logs[1:]
 	}
 }
 
+// handleKey runs the command bound to a key:
+//
+//	p, space   pause or resume autoscroll
+//	up, down   scroll a line, pausing autoscroll
+//	pgup, pgdn scroll a page
+//	end, G     jump to the newest line and resume autoscroll
+//	v          toggle the raw build output, leaving the phases
+//	[N]c       copy the last N shown lines to the clipboard
+//	q, ctrl+c  quit
+func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+
+	if len(key) == 1 && key[0] >= '0' && key[0] <= '9' {
+		m.count += key
+		return m, nil
+	}
+
+	count := m.count
+	m.count = ""
+
+	switch key {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	case "p", " ":
+		if m.paused {
+			m.paused = false
+		} else {
+			m.pause()
+		}
+	case "up", "k":
+		m.scroll(-1)
+	case "down", "j":
+		m.scroll(1)
+	case "pgup", "b":
+		m.scroll(-m.maxLogs)
+	case "pgdown", "f":
+		m.scroll(m.maxLogs)
+	case "end", "G":
+		m.paused = false
+	case "v":
+		m.condensed = !m.condensed
+		if m.condensed {
+			m.notice = "Showing phases only"
+		} else {
+			m.notice = "Showing build output"
+		}
+
+		// The bottom line may be hidden now; keep the view on the nearest
+		if m.paused {
+			m.scroll(0)
+		}
+	case "c":
+		n := defaultCopyLines
+		if v, err := strconv.Atoi(count); err == nil && v > 0 {
+			n = v
+		}
+
+		m.notice = m.copyLines(n)
+	}
+
+	return m, nil
+}
+
+// visible returns the lines of the current mode up to the bottom line
+// shown: the newest, unless autoscroll is paused
+func (m Model) visible() []logEntry {
+	lines := make([]logEntry, 0, len(m.logs))
+
+	for _, entry := range m.logs {
+		if m.paused && entry.seq > m.anchor {
+			break
+		}
+
+		if m.condensed && !entry.isPhase {
+			continue
+		}
+
+		lines = append(lines, entry)
+	}
+
+	return lines
+}
+
+// pause stops autoscroll at the newest line
+func (m *Model) pause() {
+	m.paused = true
+	m.anchor = m.seq
+}
+
+// scroll moves the bottom line shown by delta lines of the current mode,
+// pausing autoscroll; scrolling past the newest line resumes it
+func (m *Model) scroll(delta int) {
+	if !m.paused {
+		m.pause()
+	}
+
+	var lines []logEntry
+
+	for _, entry := range m.logs {
+		if !m.condensed || entry.isPhase {
+			lines = append(lines, entry)
+		}
+	}
+
+	if len(lines) == 0 {
+		return
+	}
+
+	// Index of the bottom line shown
+	i := len(lines) - 1
+	for i > 0 && lines[i].seq > m.anchor {
+		i--
+	}
+
+	i += delta
+
+	if i >= len(lines)-1 && delta > 0 {
+		m.paused = false
+		return
+	}
+
+	// The top page stays full
+	i = max(i, min(m.maxLogs, len(lines))-1)
+	m.anchor = lines[max(i, 0)].seq
+}
+
+// copyLines copies the last n lines shown, up to the bottom line, to the
+// clipboard with the OSC 52 terminal escape sequence, and describes the
+// outcome
+func (m Model) copyLines(n int) string {
+	lines := m.visible()
+	if len(lines) == 0 {
+		return "Nothing to copy"
+	}
+
+	lines = lines[max(0, len(lines)-n):]
+
+	texts := make([]string, len(lines))
+	for i, entry := range lines {
+		texts[i] = entry.text
+	}
+
+	seq := osc52.New(strings.Join(texts, "\n") + "\n")
+
+	// Multiplexers pass the sequence on to the terminal only when wrapped
+	switch {
+	case os.Getenv("TMUX") != "":
+		seq = seq.Tmux()
+	case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		seq = seq.Screen()
+	}
+
+	if _, err := seq.WriteTo(clipboardOutput); err != nil {
+		return fmt.Sprintf("Copy failed: %v", err)
+	}
+
+	return fmt.Sprintf("Copied %d line(s) to the clipboard", len(lines))
+}
+
 // View implements tea.Model
 func (m Model) View() string {
 	var b strings.Builder
@@ -121,16 +363,26 @@ func (m Model) View() string {
 		b.WriteString(" ")
 	}
 
-	if m.phase != "" {
-		b.WriteString(PhaseStyle.Render(m.phase))
+	if header := m.phaseHeader(); header != "" {
+		b.WriteString(PhaseStyle.Render(header))
 		b.WriteString(" ")
 	}
 
 	b.WriteString(MessageStyle.Render(m.message))
 	b.WriteString("\n\n")
 
+	if m.picking {
+		b.WriteString(m.choices.View())
+		b.WriteString("\n")
+
+		return b.String()
+	}
+
 	// Log view
-	for _, entry := range m.logs {
+	lines := m.visible()
+	lines = lines[max(0, len(lines)-m.maxLogs):]
+
+	for _, entry := range lines {
 		b.WriteString("  ")
 
 		if entry.isStderr {
@@ -143,7 +395,7 @@ func (m Model) View() string {
 	}
 
 	// Padding to push status bar to consistent position
-	logLines := len(m.logs)
+	logLines := len(lines)
 	if logLines < m.maxLogs {
 		for i := 0; i < m.maxLogs-logLines; i++ {
 			b.WriteString("\n")
@@ -163,5 +415,63 @@ func (m Model) View() string {
 
 	b.WriteString("\n")
 
+	if !m.done {
+		b.WriteString(m.helpLine())
+		b.WriteString("\n")
+	}
+
 	return b.String()
 }
+
+// phaseHeader renders the current install phase as "[step/total] phase
+// elapsed", falling back to the raw phase label for operations that do not
+// map to install phases
+func (m Model) phaseHeader() string {
+	if m.update == nil || m.update.GetStep() == 0 {
+		return m.phase
+	}
+
+	header := fmt.Sprintf("[%d/%d] %s", m.update.GetStep(), m.update.GetTotalSteps(), progress.PhaseName(m.update.GetPhase()))
+
+	if !m.done {
+		elapsed := time.Since(time.Unix(0, m.update.GetStartedAtUnixNano()))
+		header += " " + humanize.Duration(elapsed)
+	}
+
+	return header
+}
+
+// helpLine describes the keybindings and the scroll state
+func (m Model) helpLine() string {
+	var parts []string
+
+	if m.paused {
+		newer := 0
+
+		for _, entry := range m.logs {
+			if entry.seq > m.anchor && (!m.condensed || entry.isPhase) {
+				newer++
+			}
+		}
+
+		parts = append(parts, WarningStyle.Render(fmt.Sprintf("PAUSED, %d newer line(s)", newer)))
+	}
+
+	if m.notice != "" {
+		parts = append(parts, m.notice)
+	}
+
+	verbose := "v phases only"
+	if m.condensed {
+		verbose = "v build output"
+	}
+
+	pause := "p pause"
+	if m.paused {
+		pause = "p resume"
+	}
+
+	parts = append(parts, LogStyle.Render(pause+" · ↑/↓ scroll · "+verbose+" · [N]c copy · q quit"))
+
+	return strings.Join(parts, "  ")
+}
@@ -0,0 +1,170 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// press sends keys to the model, one per rune unless named
+func press(m Model, keys ...string) Model {
+	for _, key := range keys {
+		var msg tea.KeyMsg
+
+		switch key {
+		case "up":
+			msg = tea.KeyMsg{Type: tea.KeyUp}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+
+		next, _ := m.Update(msg)
+		m = next.(Model)
+	}
+
+	return m
+}
+
+// feed sends output lines, and a phase every fifth line, to the model
+func feed(m Model, from, to int) Model {
+	for i := from; i <= to; i++ {
+		var msg tea.Msg = OutputMsg{Stream: "stdout", Line: fmt.Sprintf("line %d", i)}
+		if i%5 == 0 {
+			msg = ProgressMsg{Phase: "build", Message: fmt.Sprintf("phase %d", i)}
+		}
+
+		next, _ := m.Update(msg)
+		m = next.(Model)
+	}
+
+	return m
+}
+
+func texts(entries []logEntry) []string {
+	var out []string
+	for _, entry := range entries {
+		out = append(out, entry.text)
+	}
+
+	return out
+}
+
+func TestModel_PauseAndScroll(t *testing.T) {
+	m := feed(NewModel(), 1, 30)
+
+	m = press(m, "p")
+	if !m.paused {
+		t.Fatal("p did not pause")
+	}
+
+	// Lines arriving while paused stay below the view
+	m = feed(m, 31, 40)
+	if got := texts(m.visible()); got[len(got)-1] != "[build] phase 30" {
+		t.Errorf("bottom line while paused = %q, want the line paused at", got[len(got)-1])
+	}
+
+	if !strings.Contains(m.View(), "PAUSED, 10 newer line(s)") {
+		t.Errorf("view does not count the newer lines:\n%s", m.View())
+	}
+
+	m = press(m, "up", "up")
+	if got := texts(m.visible()); got[len(got)-1] != "line 28" {
+		t.Errorf("bottom line after scrolling up = %q, want line 28", got[len(got)-1])
+	}
+
+	// Scrolling up stops at a full first page
+	for range 50 {
+		m = press(m, "up")
+	}
+
+	if got := texts(m.visible()); len(got) != m.maxLogs {
+		t.Errorf("scrolled to the top: %d lines shown, want %d", len(got), m.maxLogs)
+	}
+
+	m = press(m, "G")
+	if m.paused {
+		t.Error("G did not resume autoscroll")
+	}
+
+	if got := texts(m.visible()); got[len(got)-1] != "[build] phase 40" {
+		t.Errorf("bottom line after resuming = %q", got[len(got)-1])
+	}
+
+	// Scrolling down past the newest line resumes autoscroll
+	m = press(m, "up", "down", "down")
+	if m.paused {
+		t.Error("scrolling to the newest line did not resume autoscroll")
+	}
+}
+
+func TestModel_Condensed(t *testing.T) {
+	m := feed(NewModel(), 1, 20)
+
+	m = press(m, "v")
+
+	got := texts(m.visible())
+	if len(got) != 4 || got[0] != "[build] phase 5" {
+		t.Errorf("condensed lines = %v, want the 4 phases", got)
+	}
+
+	m = press(m, "v")
+	if got := m.visible(); len(got) != 20 {
+		t.Errorf("verbose shows %d lines, want 20", len(got))
+	}
+}
+
+func TestModel_Copy(t *testing.T) {
+	var buf bytes.Buffer
+
+	old := clipboardOutput
+	clipboardOutput = &buf
+
+	t.Cleanup(func() {
+		clipboardOutput = old
+	})
+
+	t.Setenv("TMUX", "")
+	t.Setenv("TERM", "xterm")
+
+	m := feed(NewModel(), 1, 12)
+	m = press(m, "3", "c")
+
+	if m.notice != "Copied 3 line(s) to the clipboard" {
+		t.Errorf("notice = %q", m.notice)
+	}
+
+	// ESC ] 52 ; c ; <base64> BEL
+	seq := buf.String()
+	start := strings.Index(seq, ";c;")
+	end := strings.IndexByte(seq, '\a')
+
+	if start < 0 || end < start {
+		t.Fatalf("no OSC 52 sequence written: %q", seq)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(seq[start+3 : end])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "[build] phase 10\nline 11\nline 12\n"; string(data) != want {
+		t.Errorf("copied %q, want %q", data, want)
+	}
+
+	// Without a count, the default number of lines is copied
+	buf.Reset()
+
+	if m = press(m, "c"); m.notice != "Copied 12 line(s) to the clipboard" {
+		t.Errorf("notice = %q", m.notice)
+	}
+}
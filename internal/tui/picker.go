@@ -0,0 +1,160 @@
+package tui
+
+import (
+	"context"
+	"errors"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ErrPickCancelled is returned when a selection is left without picking
+var ErrPickCancelled = errors.New("selection cancelled")
+
+// maxPickerHeight bounds the lines a picker takes, so it pages through long
+// lists instead of filling a tall terminal
+const maxPickerHeight = 20
+
+// Choice is an option of a picker
+type Choice struct {
+	Title       string
+	Description string
+}
+
+// choiceItem is a choice in the list of a picker, with its position in the
+// choices given
+type choiceItem struct {
+	choice Choice
+	index  int
+}
+
+func (i choiceItem) Title() string       { return i.choice.Title }
+func (i choiceItem) Description() string { return i.choice.Description }
+func (i choiceItem) FilterValue() string { return i.choice.Title }
+
+// pickKeys are the keys ending a selection, shown in the help of the list
+var pickKeys = struct {
+	pick, cancel key.Binding
+}{
+	pick:   key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "select")),
+	cancel: key.NewBinding(key.WithKeys("esc", "q", "ctrl+c"), key.WithHelp("esc", "cancel")),
+}
+
+// newChoiceList creates the filterable list of a picker; quitting is left to
+// the model embedding it
+func newChoiceList(title string, choices []Choice, width, height int) list.Model {
+	items := make([]list.Item, len(choices))
+	for i, c := range choices {
+		items[i] = choiceItem{choice: c, index: i}
+	}
+
+	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
+	l.Title = title
+	l.SetShowStatusBar(false)
+	l.DisableQuitKeybindings()
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{pickKeys.pick, pickKeys.cancel}
+	}
+
+	resizeChoiceList(&l, width, height)
+
+	return l
+}
+
+// resizeChoiceList fits a picker list into the terminal
+func resizeChoiceList(l *list.Model, width, height int) {
+	if height <= 0 || height > maxPickerHeight {
+		height = maxPickerHeight
+	}
+
+	l.SetSize(width, height)
+}
+
+// choose handles the keys ending a selection: enter picks the highlighted
+// choice, while esc, q and ctrl+c cancel. It reports whether the selection
+// ended, and the index of the choice picked or -1 when cancelled.
+func choose(l list.Model, msg tea.KeyMsg) (bool, int) {
+	if msg.String() == "ctrl+c" {
+		return true, -1
+	}
+
+	// Keys typed into the filter belong to it, and esc clears an applied one
+	switch l.FilterState() {
+	case list.Filtering:
+		return false, 0
+	case list.FilterApplied:
+		if msg.String() == "esc" {
+			return false, 0
+		}
+	}
+
+	switch {
+	case key.Matches(msg, pickKeys.pick):
+		if item, ok := l.SelectedItem().(choiceItem); ok {
+			return true, item.index
+		}
+	case key.Matches(msg, pickKeys.cancel):
+		return true, -1
+	}
+
+	return false, 0
+}
+
+// pickerModel is the program of Pick
+type pickerModel struct {
+	list   list.Model
+	chosen int
+	done   bool
+}
+
+// Init implements tea.Model
+func (m pickerModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model
+func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		resizeChoiceList(&m.list, msg.Width, msg.Height)
+		return m, nil
+	case tea.KeyMsg:
+		if done, index := choose(m.list, msg); done {
+			m.chosen, m.done = index, true
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+
+	m.list, cmd = m.list.Update(msg)
+
+	return m, cmd
+}
+
+// View implements tea.Model
+func (m pickerModel) View() string {
+	if m.done {
+		return ""
+	}
+
+	return m.list.View()
+}
+
+// Pick asks to pick one of choices in the terminal and returns its index, or
+// ErrPickCancelled when none was picked. Choices can be filtered by typing /.
+func Pick(ctx context.Context, title string, choices []Choice) (int, error) {
+	model := pickerModel{list: newChoiceList(title, choices, 0, 0), chosen: -1}
+
+	final, err := tea.NewProgram(model, tea.WithContext(ctx)).Run()
+	if err != nil {
+		return -1, err
+	}
+
+	if chosen := final.(pickerModel).chosen; chosen >= 0 {
+		return chosen, nil
+	}
+
+	return -1, ErrPickCancelled
+}
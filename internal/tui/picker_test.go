@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var cliChoices = []Choice{
+	{Title: "example.com/tool/cmd/tool", Description: "recommended"},
+	{Title: "example.com/tool/cmd/gen"},
+	{Title: "example.com/tool/tools/lint"},
+}
+
+// selecting returns a model showing a selection of cliChoices, and the
+// channel its reply is sent on
+func selecting(t *testing.T) (Model, chan int) {
+	t.Helper()
+
+	reply := make(chan int, 1)
+
+	next, _ := NewModel().Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	next, _ = next.Update(SelectMsg{Title: "Pick a CLI", Choices: cliChoices, Reply: reply})
+
+	m := next.(Model)
+	if !m.picking {
+		t.Fatal("SelectMsg did not start a selection")
+	}
+
+	return m, reply
+}
+
+func TestModel_Select(t *testing.T) {
+	m, reply := selecting(t)
+
+	if view := m.View(); !strings.Contains(view, "example.com/tool/cmd/gen") {
+		t.Errorf("View() does not list the choices:\n%s", view)
+	}
+
+	// Progress keeps coming in while picking
+	next, _ := m.Update(ProgressMsg{Phase: "discover", Message: "still here"})
+	m = next.(Model)
+
+	if m.message != "still here" {
+		t.Errorf("message = %q, want the progress sent while picking", m.message)
+	}
+
+	m = press(m, "down", "down", "up", "enter")
+
+	if m.picking {
+		t.Fatal("still picking after enter")
+	}
+
+	if got := <-reply; got != 1 {
+		t.Errorf("picked %d, want 1", got)
+	}
+}
+
+func TestModel_SelectCancel(t *testing.T) {
+	m, reply := selecting(t)
+
+	// Filtering takes the keys that would cancel otherwise
+	m = press(m, "/", "q")
+
+	if !m.picking {
+		t.Fatal("q typed into the filter cancelled the selection")
+	}
+
+	// The first esc leaves the filter
+	m = press(m, "esc", "esc")
+
+	if m.picking {
+		t.Fatal("still picking after esc")
+	}
+
+	if got := <-reply; got != -1 {
+		t.Errorf("cancelled selection replied %d, want -1", got)
+	}
+}
@@ -2,15 +2,18 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"sync"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/inovacc/glix/internal/progress"
 )
 
 // TUI manages the terminal user interface for glix operations
 type TUI struct {
 	program *tea.Program
 	model   Model
+	tracker *progress.Tracker
 	mu      sync.Mutex
 	running bool
 	done    chan struct{}
@@ -19,8 +22,9 @@ type TUI struct {
 // New creates a new TUI instance
 func New() *TUI {
 	return &TUI{
-		model: NewModel(),
-		done:  make(chan struct{}),
+		model:   NewModel(),
+		tracker: progress.NewTracker(),
+		done:    make(chan struct{}),
 	}
 }
 
@@ -72,7 +76,7 @@ func (t *TUI) SendProgress(phase, message string) {
 	defer t.mu.Unlock()
 
 	if t.program != nil && t.running {
-		t.program.Send(ProgressMsg{Phase: phase, Message: message})
+		t.program.Send(ProgressMsg{Phase: phase, Message: message, Update: t.tracker.Update(phase, message)})
 	}
 }
 
@@ -107,6 +111,33 @@ func (t *TUI) Done(err error) {
 	}
 }
 
+// Select asks to pick one of choices in place of the progress view and
+// returns its index, or ErrPickCancelled when none was picked
+func (t *TUI) Select(title string, choices []Choice) (int, error) {
+	reply := make(chan int, 1)
+
+	t.mu.Lock()
+
+	if t.program == nil || !t.running {
+		t.mu.Unlock()
+		return -1, errors.New("cannot ask for a selection: the TUI is not running")
+	}
+
+	t.program.Send(SelectMsg{Title: title, Choices: choices, Reply: reply})
+	t.mu.Unlock()
+
+	select {
+	case index := <-reply:
+		if index < 0 {
+			return -1, ErrPickCancelled
+		}
+
+		return index, nil
+	case <-t.done:
+		return -1, ErrPickCancelled
+	}
+}
+
 // ProgressHandler returns a handler function compatible with module.ProgressHandler
 func (t *TUI) ProgressHandler() func(phase, message string) {
 	return func(phase, message string) {
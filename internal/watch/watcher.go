@@ -0,0 +1,405 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultDebounce is the quiet period after the last change before a rebuild is triggered
+const DefaultDebounce = 500 * time.Millisecond
+
+// skipDirs are directory names never watched (VCS metadata, vendored code, build output)
+var skipDirs = map[string]bool{
+	".git":     true,
+	".hg":      true,
+	".svn":     true,
+	".idea":    true,
+	".vscode":  true,
+	"vendor":   true,
+	"dist":     true,
+	"testdata": true,
+}
+
+// Config holds watcher configuration
+type Config struct {
+	Dir      string
+	Debounce time.Duration
+	// OnChange is called with the changed paths once the tree has been quiet for Debounce
+	OnChange func(paths []string)
+	// OnError is called for watcher errors that do not stop watching
+	OnError func(err error)
+}
+
+// Run watches Dir recursively for changes to Go sources, module files and
+// files matched by //go:embed patterns, and calls OnChange after each burst
+// of changes. It blocks until ctx is done.
+func Run(ctx context.Context, cfg Config) error {
+	if cfg.Debounce <= 0 {
+		cfg.Debounce = DefaultDebounce
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create watcher: %w", err)
+	}
+
+	defer func() {
+		_ = watcher.Close()
+	}()
+
+	t := newTree()
+
+	if _, err := t.addRecursive(watcher, cfg.Dir); err != nil {
+		return err
+	}
+
+	var (
+		timer   *time.Timer
+		timerC  <-chan time.Time
+		pending = make(map[string]struct{})
+	)
+
+	queue := func(paths ...string) {
+		for _, p := range paths {
+			pending[p] = struct{}{}
+		}
+
+		if timer == nil {
+			timer = time.NewTimer(cfg.Debounce)
+		} else {
+			timer.Reset(cfg.Debounce)
+		}
+
+		timerC = timer.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			// Start watching directories created after startup. Files may
+			// land in them before the watch is added, so the ones found
+			// while walking count as changed.
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if t.skip(event.Name) {
+						continue
+					}
+
+					found, err := t.addRecursive(watcher, event.Name)
+					if err != nil && cfg.OnError != nil {
+						cfg.OnError(err)
+					}
+
+					if len(found) > 0 {
+						queue(found...)
+					}
+
+					continue
+				}
+			}
+
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+
+			// Embed directives may have changed with the source
+			if isGoSource(filepath.Base(event.Name)) {
+				t.scanEmbeds(filepath.Dir(event.Name))
+			}
+
+			if !t.relevant(event.Name) {
+				continue
+			}
+
+			queue(event.Name)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+
+			if cfg.OnError != nil {
+				cfg.OnError(err)
+			}
+
+		case <-timerC:
+			timerC = nil
+
+			paths := make([]string, 0, len(pending))
+			for p := range pending {
+				paths = append(paths, p)
+			}
+
+			clear(pending)
+
+			if cfg.OnChange != nil {
+				cfg.OnChange(paths)
+			}
+		}
+	}
+}
+
+// skipDir reports whether a directory is never watched
+func skipDir(name string) bool {
+	return skipDirs[name] || strings.HasPrefix(name, ".")
+}
+
+// embedPattern is one pattern of a //go:embed directive
+type embedPattern struct {
+	pattern string
+	all     bool // all: prefix, which includes files starting with . or _
+}
+
+// tree tracks the //go:embed patterns of the watched packages
+type tree struct {
+	embeds map[string][]embedPattern // Package directory -> patterns
+}
+
+func newTree() *tree {
+	return &tree{embeds: make(map[string][]embedPattern)}
+}
+
+// addRecursive adds dir and all its non-skipped subdirectories to the
+// watcher, scanning their embed patterns. It returns the relevant files
+// found below dir.
+func (t *tree) addRecursive(watcher *fsnotify.Watcher, dir string) ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() {
+			files = append(files, path)
+			return nil
+		}
+
+		if path != dir && t.skip(path) {
+			return filepath.SkipDir
+		}
+
+		if err := watcher.Add(path); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", path, err)
+		}
+
+		t.scanEmbeds(path)
+
+		return nil
+	})
+
+	// Patterns of every new package are known only after the walk
+	found := files[:0]
+
+	for _, f := range files {
+		if t.relevant(f) {
+			found = append(found, f)
+		}
+	}
+
+	return found, err
+}
+
+// skip reports whether a directory is not watched: its name is skipped and
+// no //go:embed pattern of a package above it names it, as for a dist
+// directory of web assets. The parent's patterns are scanned before its
+// subdirectories are visited.
+func (t *tree) skip(dir string) bool {
+	if !skipDir(filepath.Base(dir)) {
+		return false
+	}
+
+	for pkg := filepath.Dir(dir); ; {
+		if rel, err := filepath.Rel(pkg, dir); err == nil {
+			for _, p := range t.embeds[pkg] {
+				if p.names(filepath.ToSlash(rel)) {
+					return false
+				}
+			}
+		}
+
+		parent := filepath.Dir(pkg)
+		if parent == pkg {
+			return true
+		}
+
+		pkg = parent
+	}
+}
+
+// scanEmbeds reads the //go:embed patterns of the package in dir
+func (t *tree) scanEmbeds(dir string) {
+	delete(t.embeds, dir)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	for _, e := range entries {
+		if e.IsDir() || !isGoSource(e.Name()) {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			continue
+		}
+
+		t.embeds[dir] = append(t.embeds[dir], parseEmbeds(string(data))...)
+	}
+}
+
+// parseEmbeds returns the patterns of the //go:embed directives in src
+func parseEmbeds(src string) []embedPattern {
+	var patterns []embedPattern
+
+	for line := range strings.Lines(src) {
+		args, ok := strings.CutPrefix(strings.TrimSpace(line), "//go:embed")
+		if !ok || (args != "" && args[0] != ' ' && args[0] != '\t') {
+			continue
+		}
+
+		for _, p := range splitEmbedArgs(args) {
+			all := false
+			if rest, ok := strings.CutPrefix(p, "all:"); ok {
+				p, all = rest, true
+			}
+
+			patterns = append(patterns, embedPattern{pattern: p, all: all})
+		}
+	}
+
+	return patterns
+}
+
+// splitEmbedArgs splits the arguments of a //go:embed directive, which may
+// be quoted to contain spaces
+func splitEmbedArgs(args string) []string {
+	var out []string
+
+	for args = strings.TrimSpace(args); args != ""; args = strings.TrimSpace(args) {
+		if q := args[0]; q == '"' || q == '`' {
+			end := strings.IndexByte(args[1:], q)
+			if end < 0 {
+				return out
+			}
+
+			if p, err := strconv.Unquote(args[:end+2]); err == nil {
+				out = append(out, p)
+			}
+
+			args = args[end+2:]
+
+			continue
+		}
+
+		field, rest, _ := strings.Cut(args, " ")
+		out = append(out, field)
+		args = rest
+	}
+
+	return out
+}
+
+// relevant reports whether a changed file affects the build: Go sources,
+// module files, and files embedded by a package above it
+func (t *tree) relevant(path string) bool {
+	base := filepath.Base(path)
+
+	if isGoSource(base) || base == "go.mod" || base == "go.sum" || base == "go.work" {
+		return true
+	}
+
+	for dir := filepath.Dir(path); ; {
+		if rel, err := filepath.Rel(dir, path); err == nil {
+			for _, p := range t.embeds[dir] {
+				if p.matches(filepath.ToSlash(rel)) {
+					return true
+				}
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false
+		}
+
+		dir = parent
+	}
+}
+
+// matches reports whether the pattern embeds the file at rel, relative to
+// the package directory. A pattern naming a directory embeds the files
+// below it, except those starting with . or _ unless the pattern has all:.
+func (p embedPattern) matches(rel string) bool {
+	if ok, _ := path.Match(p.pattern, rel); ok {
+		return true
+	}
+
+	parts := strings.Split(rel, "/")
+
+	for i := 1; i < len(parts); i++ {
+		if ok, _ := path.Match(p.pattern, strings.Join(parts[:i], "/")); !ok {
+			continue
+		}
+
+		if p.all {
+			return true
+		}
+
+		for _, part := range parts[i:] {
+			if strings.HasPrefix(part, ".") || strings.HasPrefix(part, "_") {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// names reports whether the pattern embeds the directory at rel or files
+// below it, such as dist for dist/*
+func (p embedPattern) names(rel string) bool {
+	if p.matches(rel) {
+		return true
+	}
+
+	relParts := strings.Split(rel, "/")
+	patParts := strings.Split(p.pattern, "/")
+
+	if len(patParts) < len(relParts) {
+		return false
+	}
+
+	for i, part := range relParts {
+		if ok, _ := path.Match(patParts[i], part); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isGoSource reports whether a file name is a non-test Go source
+func isGoSource(base string) bool {
+	return strings.HasSuffix(base, ".go") && !strings.HasSuffix(base, "_test.go")
+}
@@ -0,0 +1,217 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseEmbeds(t *testing.T) {
+	src := "package main\n\n" +
+		"import _ \"embed\"\n\n" +
+		"//go:embed version.txt\n" +
+		"var version string\n\n" +
+		"//go:embed templates/*.tmpl \"my file.txt\" all:static\n" +
+		"var files embed.FS\n\n" +
+		"//go:embedded is not a directive\n"
+
+	got := parseEmbeds(src)
+	want := []embedPattern{
+		{pattern: "version.txt"},
+		{pattern: "templates/*.tmpl"},
+		{pattern: "my file.txt"},
+		{pattern: "static", all: true},
+	}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("parseEmbeds() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRelevant(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "main.go"), "package main\n\n//go:embed assets templates/*.tmpl\nvar fs embed.FS\n")
+
+	tr := newTree()
+	tr.scanEmbeds(dir)
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"main.go", true},
+		{"cmd/root.go", true},
+		{"main_test.go", false},
+		{"go.mod", true},
+		{"go.sum", true},
+		{"assets/logo.png", true},
+		{"assets/css/site.css", true},
+		{"assets/.hidden", false},
+		{"templates/page.tmpl", true},
+		{"templates/page.html", false},
+		{"build.log", false},
+		{"coverage.out", false},
+		{"mycli", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := tr.relevant(filepath.Join(dir, tt.path)); got != tt.want {
+				t.Errorf("relevant(%s) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddRecursive(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "pkg", "a.go"), "package pkg\n")
+	writeFile(t, filepath.Join(dir, "pkg", "sub", "b.go"), "package sub\n")
+	writeFile(t, filepath.Join(dir, "pkg", "out.log"), "log\n")
+	writeFile(t, filepath.Join(dir, "pkg", "vendor", "v.go"), "package v\n")
+	writeFile(t, filepath.Join(dir, "pkg", ".cache", "c.go"), "package c\n")
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = watcher.Close()
+	}()
+
+	found, err := newTree().addRecursive(watcher, filepath.Join(dir, "pkg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slices.Sort(found)
+
+	wantFound := []string{filepath.Join(dir, "pkg", "a.go"), filepath.Join(dir, "pkg", "sub", "b.go")}
+	if !slices.Equal(found, wantFound) {
+		t.Errorf("found = %v, want %v", found, wantFound)
+	}
+
+	watched := watcher.WatchList()
+	slices.Sort(watched)
+
+	wantWatched := []string{filepath.Join(dir, "pkg"), filepath.Join(dir, "pkg", "sub")}
+	if !slices.Equal(watched, wantWatched) {
+		t.Errorf("watched = %v, want %v", watched, wantWatched)
+	}
+}
+
+func TestRunNewDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "main.go"), "package main\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan []string, 10)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- Run(ctx, Config{
+			Dir:      dir,
+			Debounce: 50 * time.Millisecond,
+			OnChange: func(paths []string) { changes <- paths },
+		})
+	}()
+
+	// Give Run time to add its watches
+	time.Sleep(200 * time.Millisecond)
+
+	// A skipped directory created after startup stays unwatched
+	writeFile(t, filepath.Join(dir, "vendor", "v.go"), "package v\n")
+
+	// A package moved in whole has its files in place before the watch is added
+	staging := t.TempDir()
+	writeFile(t, filepath.Join(staging, "pkg", "a.go"), "package pkg\n")
+
+	if err := os.Rename(filepath.Join(staging, "pkg"), filepath.Join(dir, "pkg")); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case paths := <-changes:
+		if !slices.Contains(paths, filepath.Join(dir, "pkg", "a.go")) {
+			t.Errorf("changes = %v, want pkg/a.go", paths)
+		}
+
+		for _, p := range paths {
+			if filepath.Base(filepath.Dir(p)) == "vendor" {
+				t.Errorf("change in skipped directory reported: %s", p)
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change reported for the new package")
+	}
+
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Errorf("Run() = %v", err)
+	}
+}
+
+func TestAddRecursiveEmbeddedDist(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "main.go"), "package main\n\n//go:embed dist/*\nvar assets embed.FS\n")
+	writeFile(t, filepath.Join(dir, "dist", "index.html"), "<html></html>\n")
+	writeFile(t, filepath.Join(dir, "dist", "js", "app.js"), "app()\n")
+	writeFile(t, filepath.Join(dir, "ui", "dist", "other.js"), "other()\n")
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = watcher.Close()
+	}()
+
+	tr := newTree()
+
+	if _, err := tr.addRecursive(watcher, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	watched := watcher.WatchList()
+	slices.Sort(watched)
+
+	want := []string{dir, filepath.Join(dir, "dist"), filepath.Join(dir, "dist", "js"), filepath.Join(dir, "ui")}
+	if !slices.Equal(watched, want) {
+		t.Errorf("watched = %v, want %v", watched, want)
+	}
+
+	for _, p := range []string{"dist/index.html", "dist/js/app.js"} {
+		if !tr.relevant(filepath.Join(dir, p)) {
+			t.Errorf("relevant(%s) = false, want true", p)
+		}
+	}
+
+	if tr.relevant(filepath.Join(dir, "ui", "dist", "other.js")) {
+		t.Error("relevant(ui/dist/other.js) = true for a directory no pattern names")
+	}
+}
@@ -21,6 +21,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// EventAction is the kind of change an event records
+type EventAction int32
+
+const (
+	EventAction_EVENT_ACTION_UNSPECIFIED EventAction = 0
+	EventAction_EVENT_ACTION_INSTALL     EventAction = 1
+	EventAction_EVENT_ACTION_UPDATE      EventAction = 2
+	EventAction_EVENT_ACTION_REMOVE      EventAction = 3
+)
+
+// Enum value maps for EventAction.
+var (
+	EventAction_name = map[int32]string{
+		0: "EVENT_ACTION_UNSPECIFIED",
+		1: "EVENT_ACTION_INSTALL",
+		2: "EVENT_ACTION_UPDATE",
+		3: "EVENT_ACTION_REMOVE",
+	}
+	EventAction_value = map[string]int32{
+		"EVENT_ACTION_UNSPECIFIED": 0,
+		"EVENT_ACTION_INSTALL":     1,
+		"EVENT_ACTION_UPDATE":      2,
+		"EVENT_ACTION_REMOVE":      3,
+	}
+)
+
+func (x EventAction) Enum() *EventAction {
+	p := new(EventAction)
+	*p = x
+	return p
+}
+
+func (x EventAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1_database_proto_enumTypes[0].Descriptor()
+}
+
+func (EventAction) Type() protoreflect.EnumType {
+	return &file_proto_v1_database_proto_enumTypes[0]
+}
+
+func (x EventAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventAction.Descriptor instead.
+func (EventAction) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1_database_proto_rawDescGZIP(), []int{0}
+}
+
 // ModuleProto represents an installed Go module with all its metadata
 type ModuleProto struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
@@ -28,8 +81,34 @@ type ModuleProto struct {
 	Version           string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`                                                 // Installed version (e.g., v1.2.3)
 	Versions          []string               `protobuf:"bytes,3,rep,name=versions,proto3" json:"versions,omitempty"`                                               // All available versions
 	Dependencies      []*DependencyProto     `protobuf:"bytes,4,rep,name=dependencies,proto3" json:"dependencies,omitempty"`                                       // Module dependencies
-	Hash              string                 `protobuf:"bytes,5,opt,name=hash,proto3" json:"hash,omitempty"`                                                       // SHA256 hash of module@version
+	Hash              string                 `protobuf:"bytes,5,opt,name=hash,proto3" json:"hash,omitempty"`                                                       // go.sum hash (h1:) of the module zip, taken from the downloaded zip (empty for local installs)
 	TimestampUnixNano int64                  `protobuf:"varint,6,opt,name=timestamp_unix_nano,json=timestampUnixNano,proto3" json:"timestamp_unix_nano,omitempty"` // Installation timestamp in Unix nanoseconds
+	LocalPath         string                 `protobuf:"bytes,7,opt,name=local_path,json=localPath,proto3" json:"local_path,omitempty"`                            // Source directory for local/dev installs (empty for proxy installs)
+	KubectlPlugin     string                 `protobuf:"bytes,8,opt,name=kubectl_plugin,json=kubectlPlugin,proto3" json:"kubectl_plugin,omitempty"`                // kubectl plugin name when registered as kubectl-<name> (empty otherwise)
+	PreviousVersion   string                 `protobuf:"bytes,9,opt,name=previous_version,json=previousVersion,proto3" json:"previous_version,omitempty"`          // Version installed before the current one (maintained by the server)
+	BadVersions       []string               `protobuf:"bytes,10,rep,name=bad_versions,json=badVersions,proto3" json:"bad_versions,omitempty"`                     // Versions reported broken; never auto-updated to
+	BinaryName        string                 `protobuf:"bytes,11,opt,name=binary_name,json=binaryName,proto3" json:"binary_name,omitempty"`                        // Name of the installed executable (e.g., gopls)
+	BinaryPath        string                 `protobuf:"bytes,12,opt,name=binary_path,json=binaryPath,proto3" json:"binary_path,omitempty"`                        // Absolute path of the installed executable
+	Alias             string                 `protobuf:"bytes,13,opt,name=alias,proto3" json:"alias,omitempty"`                                                    // Binary name chosen with --as instead of the default (empty otherwise)
+	BinaryHash        string                 `protobuf:"bytes,14,opt,name=binary_hash,json=binaryHash,proto3" json:"binary_hash,omitempty"`                        // <algorithm>:<hex> of the installed executable, recorded at install (sha256 unless GLIX_HASH_ALGORITHM says sha512)
+	BinDir            string                 `protobuf:"bytes,15,opt,name=bin_dir,json=binDir,proto3" json:"bin_dir,omitempty"`                                    // Directory binaries go to instead of GOBIN: --bin-dir, or the platform directory of cross-compiled installs
+	License           string                 `protobuf:"bytes,16,opt,name=license,proto3" json:"license,omitempty"`                                                // SPDX id from the license file, "unknown", "none", or empty when not detected
+	Profile           string                 `protobuf:"bytes,17,opt,name=profile,proto3" json:"profile,omitempty"`                                                // Install profile whose module cache the module was built from (empty for the shared cache)
+	Platform          string                 `protobuf:"bytes,18,opt,name=platform,proto3" json:"platform,omitempty"`                                              // Target platform as goos/goarch of cross-compiled installs (empty for this machine)
+	BuildFlags        *BuildFlagsProto       `protobuf:"bytes,19,opt,name=build_flags,json=buildFlags,proto3" json:"build_flags,omitempty"`                        // go build flags chosen at install, reused by updates (unset for defaults)
+	VersionConstraint string                 `protobuf:"bytes,20,opt,name=version_constraint,json=versionConstraint,proto3" json:"version_constraint,omitempty"`   // Version range (^1.2, ~1.4) chosen at install that updates stay within
+	PreferBinary      bool                   `protobuf:"varint,21,opt,name=prefer_binary,json=preferBinary,proto3" json:"prefer_binary,omitempty"`                 // Install prebuilt GitHub release binaries when available, reused by updates
+	Private           bool                   `protobuf:"varint,22,opt,name=private,proto3" json:"private,omitempty"`                                               // Fetched directly from its repository without the checksum database (GOPRIVATE), reused by updates
+	RootModule        string                 `protobuf:"bytes,23,opt,name=root_module,json=rootModule,proto3" json:"root_module,omitempty"`                        // Module providing the installed package (e.g., github.com/sqlc-dev/sqlc for its cmd/sqlc)
+	Sum               string                 `protobuf:"bytes,24,opt,name=sum,proto3" json:"sum,omitempty"`                                                        // go.sum hash (h1:) of the root module zip, as the go command downloaded and verified it
+	GoModSum          string                 `protobuf:"bytes,25,opt,name=go_mod_sum,json=goModSum,proto3" json:"go_mod_sum,omitempty"`                            // go.sum hash (h1:) of the root module go.mod
+	BuildStrategy     string                 `protobuf:"bytes,26,opt,name=build_strategy,json=buildStrategy,proto3" json:"build_strategy,omitempty"`               // How the binary is built from source, e.g. "make:cli", reused by updates (empty for auto)
+	Provenance        *BuildProvenanceProto  `protobuf:"bytes,27,opt,name=provenance,proto3" json:"provenance,omitempty"`                                          // How the installed binary was built, as 'go version -m' shows it (unset when unreadable)
+	ShimPath          string                 `protobuf:"bytes,28,opt,name=shim_path,json=shimPath,proto3" json:"shim_path,omitempty"`                              // Shim in GOBIN running the active version from the version store (empty unless installed with --shim)
+	Scan              *ScanResultProto       `protobuf:"bytes,29,opt,name=scan,proto3" json:"scan,omitempty"`                                                      // Verdict of the quarantine scan of the installed binary (unset when no scanner is configured)
+	Prerelease        bool                   `protobuf:"varint,30,opt,name=prerelease,proto3" json:"prerelease,omitempty"`                                         // Pre-releases such as v2.0.0-rc.1 newer than the latest release are installed (--pre), reused by updates
+	Goproxy           string                 `protobuf:"bytes,31,opt,name=goproxy,proto3" json:"goproxy,omitempty"`                                                // GOPROXY the module is downloaded through instead of the environment's (--goproxy), reused by updates
+	Build             *BuildMetricsProto     `protobuf:"bytes,32,opt,name=build,proto3" json:"build,omitempty"`                                                    // How long the install took and the size of the binary it placed
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -106,32 +185,216 @@ func (x *ModuleProto) GetTimestampUnixNano() int64 {
 	return 0
 }
 
-// DependencyProto represents a single dependency with potential nested dependencies
-type DependencyProto struct {
+func (x *ModuleProto) GetLocalPath() string {
+	if x != nil {
+		return x.LocalPath
+	}
+	return ""
+}
+
+func (x *ModuleProto) GetKubectlPlugin() string {
+	if x != nil {
+		return x.KubectlPlugin
+	}
+	return ""
+}
+
+func (x *ModuleProto) GetPreviousVersion() string {
+	if x != nil {
+		return x.PreviousVersion
+	}
+	return ""
+}
+
+func (x *ModuleProto) GetBadVersions() []string {
+	if x != nil {
+		return x.BadVersions
+	}
+	return nil
+}
+
+func (x *ModuleProto) GetBinaryName() string {
+	if x != nil {
+		return x.BinaryName
+	}
+	return ""
+}
+
+func (x *ModuleProto) GetBinaryPath() string {
+	if x != nil {
+		return x.BinaryPath
+	}
+	return ""
+}
+
+func (x *ModuleProto) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *ModuleProto) GetBinaryHash() string {
+	if x != nil {
+		return x.BinaryHash
+	}
+	return ""
+}
+
+func (x *ModuleProto) GetBinDir() string {
+	if x != nil {
+		return x.BinDir
+	}
+	return ""
+}
+
+func (x *ModuleProto) GetLicense() string {
+	if x != nil {
+		return x.License
+	}
+	return ""
+}
+
+func (x *ModuleProto) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *ModuleProto) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *ModuleProto) GetBuildFlags() *BuildFlagsProto {
+	if x != nil {
+		return x.BuildFlags
+	}
+	return nil
+}
+
+func (x *ModuleProto) GetVersionConstraint() string {
+	if x != nil {
+		return x.VersionConstraint
+	}
+	return ""
+}
+
+func (x *ModuleProto) GetPreferBinary() bool {
+	if x != nil {
+		return x.PreferBinary
+	}
+	return false
+}
+
+func (x *ModuleProto) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
+func (x *ModuleProto) GetRootModule() string {
+	if x != nil {
+		return x.RootModule
+	}
+	return ""
+}
+
+func (x *ModuleProto) GetSum() string {
+	if x != nil {
+		return x.Sum
+	}
+	return ""
+}
+
+func (x *ModuleProto) GetGoModSum() string {
+	if x != nil {
+		return x.GoModSum
+	}
+	return ""
+}
+
+func (x *ModuleProto) GetBuildStrategy() string {
+	if x != nil {
+		return x.BuildStrategy
+	}
+	return ""
+}
+
+func (x *ModuleProto) GetProvenance() *BuildProvenanceProto {
+	if x != nil {
+		return x.Provenance
+	}
+	return nil
+}
+
+func (x *ModuleProto) GetShimPath() string {
+	if x != nil {
+		return x.ShimPath
+	}
+	return ""
+}
+
+func (x *ModuleProto) GetScan() *ScanResultProto {
+	if x != nil {
+		return x.Scan
+	}
+	return nil
+}
+
+func (x *ModuleProto) GetPrerelease() bool {
+	if x != nil {
+		return x.Prerelease
+	}
+	return false
+}
+
+func (x *ModuleProto) GetGoproxy() string {
+	if x != nil {
+		return x.Goproxy
+	}
+	return ""
+}
+
+func (x *ModuleProto) GetBuild() *BuildMetricsProto {
+	if x != nil {
+		return x.Build
+	}
+	return nil
+}
+
+// BuildProvenanceProto holds the build information embedded in a binary
+type BuildProvenanceProto struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                 // Dependency module path
-	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`           // Dependency version
-	Versions      []string               `protobuf:"bytes,3,rep,name=versions,proto3" json:"versions,omitempty"`         // Available versions for this dependency
-	Hash          string                 `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`                 // SHA256 hash of dependency@version
-	Dependencies  []*DependencyProto     `protobuf:"bytes,5,rep,name=dependencies,proto3" json:"dependencies,omitempty"` // Nested dependencies (recursive)
+	GoVersion     string                 `protobuf:"bytes,1,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`                                                        // Toolchain that built the binary, e.g. go1.25.1
+	Vcs           string                 `protobuf:"bytes,2,opt,name=vcs,proto3" json:"vcs,omitempty"`                                                                                     // Version control system the source was stamped from, e.g. git (empty when unstamped)
+	VcsRevision   string                 `protobuf:"bytes,3,opt,name=vcs_revision,json=vcsRevision,proto3" json:"vcs_revision,omitempty"`                                                  // Commit the binary was built from
+	VcsTime       string                 `protobuf:"bytes,4,opt,name=vcs_time,json=vcsTime,proto3" json:"vcs_time,omitempty"`                                                              // Commit time, RFC 3339
+	VcsModified   bool                   `protobuf:"varint,5,opt,name=vcs_modified,json=vcsModified,proto3" json:"vcs_modified,omitempty"`                                                 // Built from a working copy with uncommitted changes
+	Settings      map[string]string      `protobuf:"bytes,6,rep,name=settings,proto3" json:"settings,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Other build settings, e.g. -ldflags, CGO_ENABLED, GOOS, GOARCH
+	BinarySize    int64                  `protobuf:"varint,7,opt,name=binary_size,json=binarySize,proto3" json:"binary_size,omitempty"`                                                    // Size of the installed executable in bytes
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DependencyProto) Reset() {
-	*x = DependencyProto{}
+func (x *BuildProvenanceProto) Reset() {
+	*x = BuildProvenanceProto{}
 	mi := &file_proto_v1_database_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DependencyProto) String() string {
+func (x *BuildProvenanceProto) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DependencyProto) ProtoMessage() {}
+func (*BuildProvenanceProto) ProtoMessage() {}
 
-func (x *DependencyProto) ProtoReflect() protoreflect.Message {
+func (x *BuildProvenanceProto) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_v1_database_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -143,68 +406,87 @@ func (x *DependencyProto) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DependencyProto.ProtoReflect.Descriptor instead.
-func (*DependencyProto) Descriptor() ([]byte, []int) {
+// Deprecated: Use BuildProvenanceProto.ProtoReflect.Descriptor instead.
+func (*BuildProvenanceProto) Descriptor() ([]byte, []int) {
 	return file_proto_v1_database_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *DependencyProto) GetName() string {
+func (x *BuildProvenanceProto) GetGoVersion() string {
 	if x != nil {
-		return x.Name
+		return x.GoVersion
 	}
 	return ""
 }
 
-func (x *DependencyProto) GetVersion() string {
+func (x *BuildProvenanceProto) GetVcs() string {
 	if x != nil {
-		return x.Version
+		return x.Vcs
 	}
 	return ""
 }
 
-func (x *DependencyProto) GetVersions() []string {
+func (x *BuildProvenanceProto) GetVcsRevision() string {
 	if x != nil {
-		return x.Versions
+		return x.VcsRevision
 	}
-	return nil
+	return ""
 }
 
-func (x *DependencyProto) GetHash() string {
+func (x *BuildProvenanceProto) GetVcsTime() string {
 	if x != nil {
-		return x.Hash
+		return x.VcsTime
 	}
 	return ""
 }
 
-func (x *DependencyProto) GetDependencies() []*DependencyProto {
+func (x *BuildProvenanceProto) GetVcsModified() bool {
 	if x != nil {
-		return x.Dependencies
+		return x.VcsModified
+	}
+	return false
+}
+
+func (x *BuildProvenanceProto) GetSettings() map[string]string {
+	if x != nil {
+		return x.Settings
 	}
 	return nil
 }
 
-// DependenciesProto wraps a list of dependencies for a module
-type DependenciesProto struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Dependencies  []*DependencyProto     `protobuf:"bytes,1,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *BuildProvenanceProto) GetBinarySize() int64 {
+	if x != nil {
+		return x.BinarySize
+	}
+	return 0
 }
 
-func (x *DependenciesProto) Reset() {
-	*x = DependenciesProto{}
+// ScanResultProto is the verdict of the quarantine scanner on a built binary,
+// given before the binary was placed in the bin directory
+type ScanResultProto struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Scanner         string                 `protobuf:"bytes,1,opt,name=scanner,proto3" json:"scanner,omitempty"` // Scanner command or ICAP endpoint
+	Clean           bool                   `protobuf:"varint,2,opt,name=clean,proto3" json:"clean,omitempty"`    // Whether the scanner passed the binary
+	Detail          string                 `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`   // What the scanner reported, e.g. the threat found
+	Sha256          string                 `protobuf:"bytes,4,opt,name=sha256,proto3" json:"sha256,omitempty"`   // sha256:<hex> of the scanned binary
+	ScannedUnixNano int64                  `protobuf:"varint,5,opt,name=scanned_unix_nano,json=scannedUnixNano,proto3" json:"scanned_unix_nano,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ScanResultProto) Reset() {
+	*x = ScanResultProto{}
 	mi := &file_proto_v1_database_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DependenciesProto) String() string {
+func (x *ScanResultProto) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DependenciesProto) ProtoMessage() {}
+func (*ScanResultProto) ProtoMessage() {}
 
-func (x *DependenciesProto) ProtoReflect() protoreflect.Message {
+func (x *ScanResultProto) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_v1_database_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -216,40 +498,70 @@ func (x *DependenciesProto) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DependenciesProto.ProtoReflect.Descriptor instead.
-func (*DependenciesProto) Descriptor() ([]byte, []int) {
+// Deprecated: Use ScanResultProto.ProtoReflect.Descriptor instead.
+func (*ScanResultProto) Descriptor() ([]byte, []int) {
 	return file_proto_v1_database_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *DependenciesProto) GetDependencies() []*DependencyProto {
+func (x *ScanResultProto) GetScanner() string {
 	if x != nil {
-		return x.Dependencies
+		return x.Scanner
 	}
-	return nil
+	return ""
 }
 
-// VersionListProto stores a list of versions for secondary indexing
-type VersionListProto struct {
+func (x *ScanResultProto) GetClean() bool {
+	if x != nil {
+		return x.Clean
+	}
+	return false
+}
+
+func (x *ScanResultProto) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *ScanResultProto) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *ScanResultProto) GetScannedUnixNano() int64 {
+	if x != nil {
+		return x.ScannedUnixNano
+	}
+	return 0
+}
+
+// BuildMetricsProto measures an install
+type BuildMetricsProto struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Versions      []string               `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+	DurationNano  int64                  `protobuf:"varint,1,opt,name=duration_nano,json=durationNano,proto3" json:"duration_nano,omitempty"` // Wall time from fetching the source to placing the binary, hooks excluded
+	CacheHit      bool                   `protobuf:"varint,2,opt,name=cache_hit,json=cacheHit,proto3" json:"cache_hit,omitempty"`             // The module source was in the module cache already; cold builds download it first
+	BinarySize    int64                  `protobuf:"varint,3,opt,name=binary_size,json=binarySize,proto3" json:"binary_size,omitempty"`       // Size in bytes of the installed binary
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VersionListProto) Reset() {
-	*x = VersionListProto{}
+func (x *BuildMetricsProto) Reset() {
+	*x = BuildMetricsProto{}
 	mi := &file_proto_v1_database_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VersionListProto) String() string {
+func (x *BuildMetricsProto) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VersionListProto) ProtoMessage() {}
+func (*BuildMetricsProto) ProtoMessage() {}
 
-func (x *VersionListProto) ProtoReflect() protoreflect.Message {
+func (x *BuildMetricsProto) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_v1_database_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -261,40 +573,1269 @@ func (x *VersionListProto) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VersionListProto.ProtoReflect.Descriptor instead.
-func (*VersionListProto) Descriptor() ([]byte, []int) {
+// Deprecated: Use BuildMetricsProto.ProtoReflect.Descriptor instead.
+func (*BuildMetricsProto) Descriptor() ([]byte, []int) {
 	return file_proto_v1_database_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *VersionListProto) GetVersions() []string {
+func (x *BuildMetricsProto) GetDurationNano() int64 {
 	if x != nil {
-		return x.Versions
+		return x.DurationNano
 	}
-	return nil
+	return 0
 }
 
-var File_proto_v1_database_proto protoreflect.FileDescriptor
+func (x *BuildMetricsProto) GetCacheHit() bool {
+	if x != nil {
+		return x.CacheHit
+	}
+	return false
+}
 
-const file_proto_v1_database_proto_rawDesc = "" +
-	"\n" +
-	"\x17proto/v1/database.proto\x12\bdatabase\"\xda\x01\n" +
-	"\vModuleProto\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
-	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1a\n" +
-	"\bversions\x18\x03 \x03(\tR\bversions\x12=\n" +
-	"\fdependencies\x18\x04 \x03(\v2\x19.database.DependencyProtoR\fdependencies\x12\x12\n" +
-	"\x04hash\x18\x05 \x01(\tR\x04hash\x12.\n" +
-	"\x13timestamp_unix_nano\x18\x06 \x01(\x03R\x11timestampUnixNano\"\xae\x01\n" +
-	"\x0fDependencyProto\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
-	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1a\n" +
-	"\bversions\x18\x03 \x03(\tR\bversions\x12\x12\n" +
-	"\x04hash\x18\x04 \x01(\tR\x04hash\x12=\n" +
-	"\fdependencies\x18\x05 \x03(\v2\x19.database.DependencyProtoR\fdependencies\"R\n" +
-	"\x11DependenciesProto\x12=\n" +
-	"\fdependencies\x18\x01 \x03(\v2\x19.database.DependencyProtoR\fdependencies\".\n" +
-	"\x10VersionListProto\x12\x1a\n" +
-	"\bversions\x18\x01 \x03(\tR\bversionsB$Z\"github.com/inovacc/glix/pkg/api/v1b\x06proto3"
+func (x *BuildMetricsProto) GetBinarySize() int64 {
+	if x != nil {
+		return x.BinarySize
+	}
+	return 0
+}
+
+// BuildFlagsProto holds the go build flags of an install
+type BuildFlagsProto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ldflags       string                 `protobuf:"bytes,1,opt,name=ldflags,proto3" json:"ldflags,omitempty"`    // Value of -ldflags, e.g. "-s -w"
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`          // Build tags
+	Trimpath      bool                   `protobuf:"varint,3,opt,name=trimpath,proto3" json:"trimpath,omitempty"` // Build with -trimpath
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuildFlagsProto) Reset() {
+	*x = BuildFlagsProto{}
+	mi := &file_proto_v1_database_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildFlagsProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildFlagsProto) ProtoMessage() {}
+
+func (x *BuildFlagsProto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_database_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildFlagsProto.ProtoReflect.Descriptor instead.
+func (*BuildFlagsProto) Descriptor() ([]byte, []int) {
+	return file_proto_v1_database_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BuildFlagsProto) GetLdflags() string {
+	if x != nil {
+		return x.Ldflags
+	}
+	return ""
+}
+
+func (x *BuildFlagsProto) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *BuildFlagsProto) GetTrimpath() bool {
+	if x != nil {
+		return x.Trimpath
+	}
+	return false
+}
+
+// DependencyProto represents a single dependency with potential nested dependencies
+type DependencyProto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                 // Dependency module path
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`           // Dependency version
+	Versions      []string               `protobuf:"bytes,3,rep,name=versions,proto3" json:"versions,omitempty"`         // Available versions for this dependency
+	Hash          string                 `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`                 // go.sum hash (h1:) of the dependency zip (empty when the build only needs its go.mod)
+	Dependencies  []*DependencyProto     `protobuf:"bytes,5,rep,name=dependencies,proto3" json:"dependencies,omitempty"` // Nested dependencies (recursive)
+	License       string                 `protobuf:"bytes,6,opt,name=license,proto3" json:"license,omitempty"`           // SPDX id from the license file, "unknown", "none", or empty when not detected
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DependencyProto) Reset() {
+	*x = DependencyProto{}
+	mi := &file_proto_v1_database_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DependencyProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependencyProto) ProtoMessage() {}
+
+func (x *DependencyProto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_database_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DependencyProto.ProtoReflect.Descriptor instead.
+func (*DependencyProto) Descriptor() ([]byte, []int) {
+	return file_proto_v1_database_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DependencyProto) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DependencyProto) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *DependencyProto) GetVersions() []string {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *DependencyProto) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *DependencyProto) GetDependencies() []*DependencyProto {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+func (x *DependencyProto) GetLicense() string {
+	if x != nil {
+		return x.License
+	}
+	return ""
+}
+
+// DependenciesProto wraps a list of dependencies for a module
+type DependenciesProto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dependencies  []*DependencyProto     `protobuf:"bytes,1,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DependenciesProto) Reset() {
+	*x = DependenciesProto{}
+	mi := &file_proto_v1_database_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DependenciesProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependenciesProto) ProtoMessage() {}
+
+func (x *DependenciesProto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_database_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DependenciesProto.ProtoReflect.Descriptor instead.
+func (*DependenciesProto) Descriptor() ([]byte, []int) {
+	return file_proto_v1_database_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DependenciesProto) GetDependencies() []*DependencyProto {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+// VersionListProto stores a list of versions for secondary indexing
+type VersionListProto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Versions      []string               `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VersionListProto) Reset() {
+	*x = VersionListProto{}
+	mi := &file_proto_v1_database_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VersionListProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionListProto) ProtoMessage() {}
+
+func (x *VersionListProto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_database_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionListProto.ProtoReflect.Descriptor instead.
+func (*VersionListProto) Descriptor() ([]byte, []int) {
+	return file_proto_v1_database_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *VersionListProto) GetVersions() []string {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+// VersionCacheProto is a cached answer of go list -m -versions for a module
+// path, reused until it is older than the version cache TTL
+type VersionCacheProto struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Path            string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                                                 // Module path queried
+	RootModule      string                 `protobuf:"bytes,2,opt,name=root_module,json=rootModule,proto3" json:"root_module,omitempty"`                   // Module path the query resolved to
+	Version         string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`                                           // Latest version, or the pseudo-version of untagged modules
+	Versions        []string               `protobuf:"bytes,4,rep,name=versions,proto3" json:"versions,omitempty"`                                         // Available versions, newest first
+	TimeUnixNano    int64                  `protobuf:"varint,5,opt,name=time_unix_nano,json=timeUnixNano,proto3" json:"time_unix_nano,omitempty"`          // Time of the latest version
+	FetchedUnixNano int64                  `protobuf:"varint,6,opt,name=fetched_unix_nano,json=fetchedUnixNano,proto3" json:"fetched_unix_nano,omitempty"` // When the module proxy was queried
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *VersionCacheProto) Reset() {
+	*x = VersionCacheProto{}
+	mi := &file_proto_v1_database_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VersionCacheProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionCacheProto) ProtoMessage() {}
+
+func (x *VersionCacheProto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_database_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionCacheProto.ProtoReflect.Descriptor instead.
+func (*VersionCacheProto) Descriptor() ([]byte, []int) {
+	return file_proto_v1_database_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *VersionCacheProto) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *VersionCacheProto) GetRootModule() string {
+	if x != nil {
+		return x.RootModule
+	}
+	return ""
+}
+
+func (x *VersionCacheProto) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *VersionCacheProto) GetVersions() []string {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *VersionCacheProto) GetTimeUnixNano() int64 {
+	if x != nil {
+		return x.TimeUnixNano
+	}
+	return 0
+}
+
+func (x *VersionCacheProto) GetFetchedUnixNano() int64 {
+	if x != nil {
+		return x.FetchedUnixNano
+	}
+	return 0
+}
+
+// LibraryWatchProto is a library module tracked for new releases and
+// vulnerabilities without installing anything, as it has no CLI to install
+type LibraryWatchProto struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Path            string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`       // Module path (unique)
+	Version         string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"` // Version in use: newer releases and vulnerabilities affecting it are reported
+	AddedUnixNano   int64                  `protobuf:"varint,3,opt,name=added_unix_nano,json=addedUnixNano,proto3" json:"added_unix_nano,omitempty"`
+	LatestVersion   string                 `protobuf:"bytes,4,opt,name=latest_version,json=latestVersion,proto3" json:"latest_version,omitempty"`          // Newest release found by the last check
+	CheckedUnixNano int64                  `protobuf:"varint,5,opt,name=checked_unix_nano,json=checkedUnixNano,proto3" json:"checked_unix_nano,omitempty"` // When the last check ran, 0 before the first
+	Vulnerabilities []string               `protobuf:"bytes,6,rep,name=vulnerabilities,proto3" json:"vulnerabilities,omitempty"`                           // IDs of known vulnerabilities affecting version
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LibraryWatchProto) Reset() {
+	*x = LibraryWatchProto{}
+	mi := &file_proto_v1_database_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LibraryWatchProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LibraryWatchProto) ProtoMessage() {}
+
+func (x *LibraryWatchProto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_database_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LibraryWatchProto.ProtoReflect.Descriptor instead.
+func (*LibraryWatchProto) Descriptor() ([]byte, []int) {
+	return file_proto_v1_database_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *LibraryWatchProto) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *LibraryWatchProto) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *LibraryWatchProto) GetAddedUnixNano() int64 {
+	if x != nil {
+		return x.AddedUnixNano
+	}
+	return 0
+}
+
+func (x *LibraryWatchProto) GetLatestVersion() string {
+	if x != nil {
+		return x.LatestVersion
+	}
+	return ""
+}
+
+func (x *LibraryWatchProto) GetCheckedUnixNano() int64 {
+	if x != nil {
+		return x.CheckedUnixNano
+	}
+	return 0
+}
+
+func (x *LibraryWatchProto) GetVulnerabilities() []string {
+	if x != nil {
+		return x.Vulnerabilities
+	}
+	return nil
+}
+
+// AutoUpdateConfigProto is the auto-update configuration and the statistics
+// of its checks, kept by the daemon so clients on any machine share them
+type AutoUpdateConfigProto struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Enabled            bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	IntervalNano       int64                  `protobuf:"varint,2,opt,name=interval_nano,json=intervalNano,proto3" json:"interval_nano,omitempty"` // Time between checks, at least an hour
+	NotifyOnly         bool                   `protobuf:"varint,3,opt,name=notify_only,json=notifyOnly,proto3" json:"notify_only,omitempty"`       // Report updates without installing them
+	IncludePrerelease  bool                   `protobuf:"varint,4,opt,name=include_prerelease,json=includePrerelease,proto3" json:"include_prerelease,omitempty"`
+	LastCheckUnixNano  int64                  `protobuf:"varint,5,opt,name=last_check_unix_nano,json=lastCheckUnixNano,proto3" json:"last_check_unix_nano,omitempty"`    // 0 before the first check
+	LastUpdateUnixNano int64                  `protobuf:"varint,6,opt,name=last_update_unix_nano,json=lastUpdateUnixNano,proto3" json:"last_update_unix_nano,omitempty"` // 0 before the first installed update
+	CheckedCount       int64                  `protobuf:"varint,7,opt,name=checked_count,json=checkedCount,proto3" json:"checked_count,omitempty"`                       // Checks run
+	UpdatedCount       int64                  `protobuf:"varint,8,opt,name=updated_count,json=updatedCount,proto3" json:"updated_count,omitempty"`                       // Updates installed
+	PendingCount       int64                  `protobuf:"varint,9,opt,name=pending_count,json=pendingCount,proto3" json:"pending_count,omitempty"`                       // Updates found but not installed by the last check
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AutoUpdateConfigProto) Reset() {
+	*x = AutoUpdateConfigProto{}
+	mi := &file_proto_v1_database_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AutoUpdateConfigProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutoUpdateConfigProto) ProtoMessage() {}
+
+func (x *AutoUpdateConfigProto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_database_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutoUpdateConfigProto.ProtoReflect.Descriptor instead.
+func (*AutoUpdateConfigProto) Descriptor() ([]byte, []int) {
+	return file_proto_v1_database_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AutoUpdateConfigProto) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *AutoUpdateConfigProto) GetIntervalNano() int64 {
+	if x != nil {
+		return x.IntervalNano
+	}
+	return 0
+}
+
+func (x *AutoUpdateConfigProto) GetNotifyOnly() bool {
+	if x != nil {
+		return x.NotifyOnly
+	}
+	return false
+}
+
+func (x *AutoUpdateConfigProto) GetIncludePrerelease() bool {
+	if x != nil {
+		return x.IncludePrerelease
+	}
+	return false
+}
+
+func (x *AutoUpdateConfigProto) GetLastCheckUnixNano() int64 {
+	if x != nil {
+		return x.LastCheckUnixNano
+	}
+	return 0
+}
+
+func (x *AutoUpdateConfigProto) GetLastUpdateUnixNano() int64 {
+	if x != nil {
+		return x.LastUpdateUnixNano
+	}
+	return 0
+}
+
+func (x *AutoUpdateConfigProto) GetCheckedCount() int64 {
+	if x != nil {
+		return x.CheckedCount
+	}
+	return 0
+}
+
+func (x *AutoUpdateConfigProto) GetUpdatedCount() int64 {
+	if x != nil {
+		return x.UpdatedCount
+	}
+	return 0
+}
+
+func (x *AutoUpdateConfigProto) GetPendingCount() int64 {
+	if x != nil {
+		return x.PendingCount
+	}
+	return 0
+}
+
+// TokenProto is a scoped API token for automation such as CI agents and
+// dashboards. Only the SHA-256 of the token is stored; the token itself is
+// shown once, when it is created.
+type TokenProto struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`   // Token name (unique)
+	Scope            string                 `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"` // read, install or admin
+	Hash             string                 `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`   // Hex SHA-256 of the token, never sent to clients
+	CreatedUnixNano  int64                  `protobuf:"varint,4,opt,name=created_unix_nano,json=createdUnixNano,proto3" json:"created_unix_nano,omitempty"`
+	ExpiresUnixNano  int64                  `protobuf:"varint,5,opt,name=expires_unix_nano,json=expiresUnixNano,proto3" json:"expires_unix_nano,omitempty"`      // 0 if the token never expires
+	LastUsedUnixNano int64                  `protobuf:"varint,6,opt,name=last_used_unix_nano,json=lastUsedUnixNano,proto3" json:"last_used_unix_nano,omitempty"` // 0 if never used, updated at most once a minute
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TokenProto) Reset() {
+	*x = TokenProto{}
+	mi := &file_proto_v1_database_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenProto) ProtoMessage() {}
+
+func (x *TokenProto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_database_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenProto.ProtoReflect.Descriptor instead.
+func (*TokenProto) Descriptor() ([]byte, []int) {
+	return file_proto_v1_database_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TokenProto) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TokenProto) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *TokenProto) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *TokenProto) GetCreatedUnixNano() int64 {
+	if x != nil {
+		return x.CreatedUnixNano
+	}
+	return 0
+}
+
+func (x *TokenProto) GetExpiresUnixNano() int64 {
+	if x != nil {
+		return x.ExpiresUnixNano
+	}
+	return 0
+}
+
+func (x *TokenProto) GetLastUsedUnixNano() int64 {
+	if x != nil {
+		return x.LastUsedUnixNano
+	}
+	return 0
+}
+
+// SnapshotProto captures the full installed module set under a name
+type SnapshotProto struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                 // Snapshot name (unique)
+	Description     string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`                                   // Optional free-form description
+	CreatedUnixNano int64                  `protobuf:"varint,3,opt,name=created_unix_nano,json=createdUnixNano,proto3" json:"created_unix_nano,omitempty"` // Creation timestamp in Unix nanoseconds
+	Modules         []*ModuleProto         `protobuf:"bytes,4,rep,name=modules,proto3" json:"modules,omitempty"`                                           // Modules installed when the snapshot was taken
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SnapshotProto) Reset() {
+	*x = SnapshotProto{}
+	mi := &file_proto_v1_database_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotProto) ProtoMessage() {}
+
+func (x *SnapshotProto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_database_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotProto.ProtoReflect.Descriptor instead.
+func (*SnapshotProto) Descriptor() ([]byte, []int) {
+	return file_proto_v1_database_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SnapshotProto) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SnapshotProto) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SnapshotProto) GetCreatedUnixNano() int64 {
+	if x != nil {
+		return x.CreatedUnixNano
+	}
+	return 0
+}
+
+func (x *SnapshotProto) GetModules() []*ModuleProto {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+// InventoryProto is the module inventory a workstation reports to a central
+// glix server in fleet mode
+type InventoryProto struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Host             string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"` // Reporting machine (unique)
+	Goos             string                 `protobuf:"bytes,2,opt,name=goos,proto3" json:"goos,omitempty"`
+	Goarch           string                 `protobuf:"bytes,3,opt,name=goarch,proto3" json:"goarch,omitempty"`
+	ReportedUnixNano int64                  `protobuf:"varint,4,opt,name=reported_unix_nano,json=reportedUnixNano,proto3" json:"reported_unix_nano,omitempty"` // When the workstation sent the report
+	Modules          []*ModuleProto         `protobuf:"bytes,5,rep,name=modules,proto3" json:"modules,omitempty"`                                              // Modules installed on the machine
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *InventoryProto) Reset() {
+	*x = InventoryProto{}
+	mi := &file_proto_v1_database_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventoryProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventoryProto) ProtoMessage() {}
+
+func (x *InventoryProto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_database_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventoryProto.ProtoReflect.Descriptor instead.
+func (*InventoryProto) Descriptor() ([]byte, []int) {
+	return file_proto_v1_database_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *InventoryProto) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *InventoryProto) GetGoos() string {
+	if x != nil {
+		return x.Goos
+	}
+	return ""
+}
+
+func (x *InventoryProto) GetGoarch() string {
+	if x != nil {
+		return x.Goarch
+	}
+	return ""
+}
+
+func (x *InventoryProto) GetReportedUnixNano() int64 {
+	if x != nil {
+		return x.ReportedUnixNano
+	}
+	return 0
+}
+
+func (x *InventoryProto) GetModules() []*ModuleProto {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+// InstallHistoryProto lists the records of the versions a module has had
+// installed, oldest first, so an update can be rolled back
+type InstallHistoryProto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Installs      []*ModuleProto         `protobuf:"bytes,1,rep,name=installs,proto3" json:"installs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstallHistoryProto) Reset() {
+	*x = InstallHistoryProto{}
+	mi := &file_proto_v1_database_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstallHistoryProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallHistoryProto) ProtoMessage() {}
+
+func (x *InstallHistoryProto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_database_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstallHistoryProto.ProtoReflect.Descriptor instead.
+func (*InstallHistoryProto) Descriptor() ([]byte, []int) {
+	return file_proto_v1_database_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *InstallHistoryProto) GetInstalls() []*ModuleProto {
+	if x != nil {
+		return x.Installs
+	}
+	return nil
+}
+
+// EventProto records one install, update, or remove of a module, whether it
+// succeeded or not
+type EventProto struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TimestampUnixNano int64                  `protobuf:"varint,1,opt,name=timestamp_unix_nano,json=timestampUnixNano,proto3" json:"timestamp_unix_nano,omitempty"` // When the change finished
+	Action            EventAction            `protobuf:"varint,2,opt,name=action,proto3,enum=database.EventAction" json:"action,omitempty"`
+	Name              string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`                                  // Module path
+	FromVersion       string                 `protobuf:"bytes,4,opt,name=from_version,json=fromVersion,proto3" json:"from_version,omitempty"` // Version before the change (update, remove)
+	ToVersion         string                 `protobuf:"bytes,5,opt,name=to_version,json=toVersion,proto3" json:"to_version,omitempty"`       // Version after the change (install, update)
+	Success           bool                   `protobuf:"varint,6,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage      string                 `protobuf:"bytes,7,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"` // Why the change failed
+	Scan              *ScanResultProto       `protobuf:"bytes,8,opt,name=scan,proto3" json:"scan,omitempty"`                                     // Quarantine scan of the binary the change installed (unset when not scanned)
+	Build             *BuildMetricsProto     `protobuf:"bytes,9,opt,name=build,proto3" json:"build,omitempty"`                                   // How long a successful install or update took (unset for removes and failures)
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *EventProto) Reset() {
+	*x = EventProto{}
+	mi := &file_proto_v1_database_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventProto) ProtoMessage() {}
+
+func (x *EventProto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_database_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventProto.ProtoReflect.Descriptor instead.
+func (*EventProto) Descriptor() ([]byte, []int) {
+	return file_proto_v1_database_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *EventProto) GetTimestampUnixNano() int64 {
+	if x != nil {
+		return x.TimestampUnixNano
+	}
+	return 0
+}
+
+func (x *EventProto) GetAction() EventAction {
+	if x != nil {
+		return x.Action
+	}
+	return EventAction_EVENT_ACTION_UNSPECIFIED
+}
+
+func (x *EventProto) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EventProto) GetFromVersion() string {
+	if x != nil {
+		return x.FromVersion
+	}
+	return ""
+}
+
+func (x *EventProto) GetToVersion() string {
+	if x != nil {
+		return x.ToVersion
+	}
+	return ""
+}
+
+func (x *EventProto) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *EventProto) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *EventProto) GetScan() *ScanResultProto {
+	if x != nil {
+		return x.Scan
+	}
+	return nil
+}
+
+func (x *EventProto) GetBuild() *BuildMetricsProto {
+	if x != nil {
+		return x.Build
+	}
+	return nil
+}
+
+// VulnFindingProto is a known vulnerability govulncheck found in a binary
+type VulnFindingProto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Go vulnerability ID, e.g. GO-2024-2687
+	Summary       string                 `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
+	Aliases       []string               `protobuf:"bytes,3,rep,name=aliases,proto3" json:"aliases,omitempty"`                               // CVE and GHSA IDs
+	Module        string                 `protobuf:"bytes,4,opt,name=module,proto3" json:"module,omitempty"`                                 // Affected module, "stdlib" for the standard library
+	FoundVersion  string                 `protobuf:"bytes,5,opt,name=found_version,json=foundVersion,proto3" json:"found_version,omitempty"` // Version of the module in the binary
+	FixedVersion  string                 `protobuf:"bytes,6,opt,name=fixed_version,json=fixedVersion,proto3" json:"fixed_version,omitempty"` // First version fixing it, empty when there is none
+	Called        bool                   `protobuf:"varint,7,opt,name=called,proto3" json:"called,omitempty"`                                // Whether the binary uses the vulnerable symbols
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VulnFindingProto) Reset() {
+	*x = VulnFindingProto{}
+	mi := &file_proto_v1_database_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VulnFindingProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VulnFindingProto) ProtoMessage() {}
+
+func (x *VulnFindingProto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_database_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VulnFindingProto.ProtoReflect.Descriptor instead.
+func (*VulnFindingProto) Descriptor() ([]byte, []int) {
+	return file_proto_v1_database_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *VulnFindingProto) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *VulnFindingProto) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *VulnFindingProto) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *VulnFindingProto) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *VulnFindingProto) GetFoundVersion() string {
+	if x != nil {
+		return x.FoundVersion
+	}
+	return ""
+}
+
+func (x *VulnFindingProto) GetFixedVersion() string {
+	if x != nil {
+		return x.FixedVersion
+	}
+	return ""
+}
+
+func (x *VulnFindingProto) GetCalled() bool {
+	if x != nil {
+		return x.Called
+	}
+	return false
+}
+
+// VulnReportProto is the result of the latest govulncheck scan of an
+// installed module's binary
+type VulnReportProto struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`       // Module path
+	Version         string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"` // Version that was scanned
+	ScannedUnixNano int64                  `protobuf:"varint,3,opt,name=scanned_unix_nano,json=scannedUnixNano,proto3" json:"scanned_unix_nano,omitempty"`
+	Findings        []*VulnFindingProto    `protobuf:"bytes,4,rep,name=findings,proto3" json:"findings,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *VulnReportProto) Reset() {
+	*x = VulnReportProto{}
+	mi := &file_proto_v1_database_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VulnReportProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VulnReportProto) ProtoMessage() {}
+
+func (x *VulnReportProto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_database_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VulnReportProto.ProtoReflect.Descriptor instead.
+func (*VulnReportProto) Descriptor() ([]byte, []int) {
+	return file_proto_v1_database_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *VulnReportProto) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VulnReportProto) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *VulnReportProto) GetScannedUnixNano() int64 {
+	if x != nil {
+		return x.ScannedUnixNano
+	}
+	return 0
+}
+
+func (x *VulnReportProto) GetFindings() []*VulnFindingProto {
+	if x != nil {
+		return x.Findings
+	}
+	return nil
+}
+
+var File_proto_v1_database_proto protoreflect.FileDescriptor
+
+const file_proto_v1_database_proto_rawDesc = "" +
+	"\n" +
+	"\x17proto/v1/database.proto\x12\bdatabase\"\xeb\b\n" +
+	"\vModuleProto\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1a\n" +
+	"\bversions\x18\x03 \x03(\tR\bversions\x12=\n" +
+	"\fdependencies\x18\x04 \x03(\v2\x19.database.DependencyProtoR\fdependencies\x12\x12\n" +
+	"\x04hash\x18\x05 \x01(\tR\x04hash\x12.\n" +
+	"\x13timestamp_unix_nano\x18\x06 \x01(\x03R\x11timestampUnixNano\x12\x1d\n" +
+	"\n" +
+	"local_path\x18\a \x01(\tR\tlocalPath\x12%\n" +
+	"\x0ekubectl_plugin\x18\b \x01(\tR\rkubectlPlugin\x12)\n" +
+	"\x10previous_version\x18\t \x01(\tR\x0fpreviousVersion\x12!\n" +
+	"\fbad_versions\x18\n" +
+	" \x03(\tR\vbadVersions\x12\x1f\n" +
+	"\vbinary_name\x18\v \x01(\tR\n" +
+	"binaryName\x12\x1f\n" +
+	"\vbinary_path\x18\f \x01(\tR\n" +
+	"binaryPath\x12\x14\n" +
+	"\x05alias\x18\r \x01(\tR\x05alias\x12\x1f\n" +
+	"\vbinary_hash\x18\x0e \x01(\tR\n" +
+	"binaryHash\x12\x17\n" +
+	"\abin_dir\x18\x0f \x01(\tR\x06binDir\x12\x18\n" +
+	"\alicense\x18\x10 \x01(\tR\alicense\x12\x18\n" +
+	"\aprofile\x18\x11 \x01(\tR\aprofile\x12\x1a\n" +
+	"\bplatform\x18\x12 \x01(\tR\bplatform\x12:\n" +
+	"\vbuild_flags\x18\x13 \x01(\v2\x19.database.BuildFlagsProtoR\n" +
+	"buildFlags\x12-\n" +
+	"\x12version_constraint\x18\x14 \x01(\tR\x11versionConstraint\x12#\n" +
+	"\rprefer_binary\x18\x15 \x01(\bR\fpreferBinary\x12\x18\n" +
+	"\aprivate\x18\x16 \x01(\bR\aprivate\x12\x1f\n" +
+	"\vroot_module\x18\x17 \x01(\tR\n" +
+	"rootModule\x12\x10\n" +
+	"\x03sum\x18\x18 \x01(\tR\x03sum\x12\x1c\n" +
+	"\n" +
+	"go_mod_sum\x18\x19 \x01(\tR\bgoModSum\x12%\n" +
+	"\x0ebuild_strategy\x18\x1a \x01(\tR\rbuildStrategy\x12>\n" +
+	"\n" +
+	"provenance\x18\x1b \x01(\v2\x1e.database.BuildProvenanceProtoR\n" +
+	"provenance\x12\x1b\n" +
+	"\tshim_path\x18\x1c \x01(\tR\bshimPath\x12-\n" +
+	"\x04scan\x18\x1d \x01(\v2\x19.database.ScanResultProtoR\x04scan\x12\x1e\n" +
+	"\n" +
+	"prerelease\x18\x1e \x01(\bR\n" +
+	"prerelease\x12\x18\n" +
+	"\agoproxy\x18\x1f \x01(\tR\agoproxy\x121\n" +
+	"\x05build\x18  \x01(\v2\x1b.database.BuildMetricsProtoR\x05build\"\xd0\x02\n" +
+	"\x14BuildProvenanceProto\x12\x1d\n" +
+	"\n" +
+	"go_version\x18\x01 \x01(\tR\tgoVersion\x12\x10\n" +
+	"\x03vcs\x18\x02 \x01(\tR\x03vcs\x12!\n" +
+	"\fvcs_revision\x18\x03 \x01(\tR\vvcsRevision\x12\x19\n" +
+	"\bvcs_time\x18\x04 \x01(\tR\avcsTime\x12!\n" +
+	"\fvcs_modified\x18\x05 \x01(\bR\vvcsModified\x12H\n" +
+	"\bsettings\x18\x06 \x03(\v2,.database.BuildProvenanceProto.SettingsEntryR\bsettings\x12\x1f\n" +
+	"\vbinary_size\x18\a \x01(\x03R\n" +
+	"binarySize\x1a;\n" +
+	"\rSettingsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x9d\x01\n" +
+	"\x0fScanResultProto\x12\x18\n" +
+	"\ascanner\x18\x01 \x01(\tR\ascanner\x12\x14\n" +
+	"\x05clean\x18\x02 \x01(\bR\x05clean\x12\x16\n" +
+	"\x06detail\x18\x03 \x01(\tR\x06detail\x12\x16\n" +
+	"\x06sha256\x18\x04 \x01(\tR\x06sha256\x12*\n" +
+	"\x11scanned_unix_nano\x18\x05 \x01(\x03R\x0fscannedUnixNano\"v\n" +
+	"\x11BuildMetricsProto\x12#\n" +
+	"\rduration_nano\x18\x01 \x01(\x03R\fdurationNano\x12\x1b\n" +
+	"\tcache_hit\x18\x02 \x01(\bR\bcacheHit\x12\x1f\n" +
+	"\vbinary_size\x18\x03 \x01(\x03R\n" +
+	"binarySize\"[\n" +
+	"\x0fBuildFlagsProto\x12\x18\n" +
+	"\aldflags\x18\x01 \x01(\tR\aldflags\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x12\x1a\n" +
+	"\btrimpath\x18\x03 \x01(\bR\btrimpath\"\xc8\x01\n" +
+	"\x0fDependencyProto\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1a\n" +
+	"\bversions\x18\x03 \x03(\tR\bversions\x12\x12\n" +
+	"\x04hash\x18\x04 \x01(\tR\x04hash\x12=\n" +
+	"\fdependencies\x18\x05 \x03(\v2\x19.database.DependencyProtoR\fdependencies\x12\x18\n" +
+	"\alicense\x18\x06 \x01(\tR\alicense\"R\n" +
+	"\x11DependenciesProto\x12=\n" +
+	"\fdependencies\x18\x01 \x03(\v2\x19.database.DependencyProtoR\fdependencies\".\n" +
+	"\x10VersionListProto\x12\x1a\n" +
+	"\bversions\x18\x01 \x03(\tR\bversions\"\xd0\x01\n" +
+	"\x11VersionCacheProto\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1f\n" +
+	"\vroot_module\x18\x02 \x01(\tR\n" +
+	"rootModule\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1a\n" +
+	"\bversions\x18\x04 \x03(\tR\bversions\x12$\n" +
+	"\x0etime_unix_nano\x18\x05 \x01(\x03R\ftimeUnixNano\x12*\n" +
+	"\x11fetched_unix_nano\x18\x06 \x01(\x03R\x0ffetchedUnixNano\"\xe6\x01\n" +
+	"\x11LibraryWatchProto\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12&\n" +
+	"\x0fadded_unix_nano\x18\x03 \x01(\x03R\raddedUnixNano\x12%\n" +
+	"\x0elatest_version\x18\x04 \x01(\tR\rlatestVersion\x12*\n" +
+	"\x11checked_unix_nano\x18\x05 \x01(\x03R\x0fcheckedUnixNano\x12(\n" +
+	"\x0fvulnerabilities\x18\x06 \x03(\tR\x0fvulnerabilities\"\xf9\x02\n" +
+	"\x15AutoUpdateConfigProto\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12#\n" +
+	"\rinterval_nano\x18\x02 \x01(\x03R\fintervalNano\x12\x1f\n" +
+	"\vnotify_only\x18\x03 \x01(\bR\n" +
+	"notifyOnly\x12-\n" +
+	"\x12include_prerelease\x18\x04 \x01(\bR\x11includePrerelease\x12/\n" +
+	"\x14last_check_unix_nano\x18\x05 \x01(\x03R\x11lastCheckUnixNano\x121\n" +
+	"\x15last_update_unix_nano\x18\x06 \x01(\x03R\x12lastUpdateUnixNano\x12#\n" +
+	"\rchecked_count\x18\a \x01(\x03R\fcheckedCount\x12#\n" +
+	"\rupdated_count\x18\b \x01(\x03R\fupdatedCount\x12#\n" +
+	"\rpending_count\x18\t \x01(\x03R\fpendingCount\"\xd1\x01\n" +
+	"\n" +
+	"TokenProto\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05scope\x18\x02 \x01(\tR\x05scope\x12\x12\n" +
+	"\x04hash\x18\x03 \x01(\tR\x04hash\x12*\n" +
+	"\x11created_unix_nano\x18\x04 \x01(\x03R\x0fcreatedUnixNano\x12*\n" +
+	"\x11expires_unix_nano\x18\x05 \x01(\x03R\x0fexpiresUnixNano\x12-\n" +
+	"\x13last_used_unix_nano\x18\x06 \x01(\x03R\x10lastUsedUnixNano\"\xa2\x01\n" +
+	"\rSnapshotProto\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12*\n" +
+	"\x11created_unix_nano\x18\x03 \x01(\x03R\x0fcreatedUnixNano\x12/\n" +
+	"\amodules\x18\x04 \x03(\v2\x15.database.ModuleProtoR\amodules\"\xaf\x01\n" +
+	"\x0eInventoryProto\x12\x12\n" +
+	"\x04host\x18\x01 \x01(\tR\x04host\x12\x12\n" +
+	"\x04goos\x18\x02 \x01(\tR\x04goos\x12\x16\n" +
+	"\x06goarch\x18\x03 \x01(\tR\x06goarch\x12,\n" +
+	"\x12reported_unix_nano\x18\x04 \x01(\x03R\x10reportedUnixNano\x12/\n" +
+	"\amodules\x18\x05 \x03(\v2\x15.database.ModuleProtoR\amodules\"H\n" +
+	"\x13InstallHistoryProto\x121\n" +
+	"\binstalls\x18\x01 \x03(\v2\x15.database.ModuleProtoR\binstalls\"\xe2\x02\n" +
+	"\n" +
+	"EventProto\x12.\n" +
+	"\x13timestamp_unix_nano\x18\x01 \x01(\x03R\x11timestampUnixNano\x12-\n" +
+	"\x06action\x18\x02 \x01(\x0e2\x15.database.EventActionR\x06action\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12!\n" +
+	"\ffrom_version\x18\x04 \x01(\tR\vfromVersion\x12\x1d\n" +
+	"\n" +
+	"to_version\x18\x05 \x01(\tR\ttoVersion\x12\x18\n" +
+	"\asuccess\x18\x06 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\a \x01(\tR\ferrorMessage\x12-\n" +
+	"\x04scan\x18\b \x01(\v2\x19.database.ScanResultProtoR\x04scan\x121\n" +
+	"\x05build\x18\t \x01(\v2\x1b.database.BuildMetricsProtoR\x05build\"\xd0\x01\n" +
+	"\x10VulnFindingProto\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\asummary\x18\x02 \x01(\tR\asummary\x12\x18\n" +
+	"\aaliases\x18\x03 \x03(\tR\aaliases\x12\x16\n" +
+	"\x06module\x18\x04 \x01(\tR\x06module\x12#\n" +
+	"\rfound_version\x18\x05 \x01(\tR\ffoundVersion\x12#\n" +
+	"\rfixed_version\x18\x06 \x01(\tR\ffixedVersion\x12\x16\n" +
+	"\x06called\x18\a \x01(\bR\x06called\"\xa3\x01\n" +
+	"\x0fVulnReportProto\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12*\n" +
+	"\x11scanned_unix_nano\x18\x03 \x01(\x03R\x0fscannedUnixNano\x126\n" +
+	"\bfindings\x18\x04 \x03(\v2\x1a.database.VulnFindingProtoR\bfindings*w\n" +
+	"\vEventAction\x12\x1c\n" +
+	"\x18EVENT_ACTION_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14EVENT_ACTION_INSTALL\x10\x01\x12\x17\n" +
+	"\x13EVENT_ACTION_UPDATE\x10\x02\x12\x17\n" +
+	"\x13EVENT_ACTION_REMOVE\x10\x03B$Z\"github.com/inovacc/glix/pkg/api/v1b\x06proto3"
 
 var (
 	file_proto_v1_database_proto_rawDescOnce sync.Once
@@ -308,22 +1849,51 @@ func file_proto_v1_database_proto_rawDescGZIP() []byte {
 	return file_proto_v1_database_proto_rawDescData
 }
 
-var file_proto_v1_database_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_v1_database_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_v1_database_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_proto_v1_database_proto_goTypes = []any{
-	(*ModuleProto)(nil),       // 0: database.ModuleProto
-	(*DependencyProto)(nil),   // 1: database.DependencyProto
-	(*DependenciesProto)(nil), // 2: database.DependenciesProto
-	(*VersionListProto)(nil),  // 3: database.VersionListProto
+	(EventAction)(0),              // 0: database.EventAction
+	(*ModuleProto)(nil),           // 1: database.ModuleProto
+	(*BuildProvenanceProto)(nil),  // 2: database.BuildProvenanceProto
+	(*ScanResultProto)(nil),       // 3: database.ScanResultProto
+	(*BuildMetricsProto)(nil),     // 4: database.BuildMetricsProto
+	(*BuildFlagsProto)(nil),       // 5: database.BuildFlagsProto
+	(*DependencyProto)(nil),       // 6: database.DependencyProto
+	(*DependenciesProto)(nil),     // 7: database.DependenciesProto
+	(*VersionListProto)(nil),      // 8: database.VersionListProto
+	(*VersionCacheProto)(nil),     // 9: database.VersionCacheProto
+	(*LibraryWatchProto)(nil),     // 10: database.LibraryWatchProto
+	(*AutoUpdateConfigProto)(nil), // 11: database.AutoUpdateConfigProto
+	(*TokenProto)(nil),            // 12: database.TokenProto
+	(*SnapshotProto)(nil),         // 13: database.SnapshotProto
+	(*InventoryProto)(nil),        // 14: database.InventoryProto
+	(*InstallHistoryProto)(nil),   // 15: database.InstallHistoryProto
+	(*EventProto)(nil),            // 16: database.EventProto
+	(*VulnFindingProto)(nil),      // 17: database.VulnFindingProto
+	(*VulnReportProto)(nil),       // 18: database.VulnReportProto
+	nil,                           // 19: database.BuildProvenanceProto.SettingsEntry
 }
 var file_proto_v1_database_proto_depIdxs = []int32{
-	1, // 0: database.ModuleProto.dependencies:type_name -> database.DependencyProto
-	1, // 1: database.DependencyProto.dependencies:type_name -> database.DependencyProto
-	1, // 2: database.DependenciesProto.dependencies:type_name -> database.DependencyProto
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	6,  // 0: database.ModuleProto.dependencies:type_name -> database.DependencyProto
+	5,  // 1: database.ModuleProto.build_flags:type_name -> database.BuildFlagsProto
+	2,  // 2: database.ModuleProto.provenance:type_name -> database.BuildProvenanceProto
+	3,  // 3: database.ModuleProto.scan:type_name -> database.ScanResultProto
+	4,  // 4: database.ModuleProto.build:type_name -> database.BuildMetricsProto
+	19, // 5: database.BuildProvenanceProto.settings:type_name -> database.BuildProvenanceProto.SettingsEntry
+	6,  // 6: database.DependencyProto.dependencies:type_name -> database.DependencyProto
+	6,  // 7: database.DependenciesProto.dependencies:type_name -> database.DependencyProto
+	1,  // 8: database.SnapshotProto.modules:type_name -> database.ModuleProto
+	1,  // 9: database.InventoryProto.modules:type_name -> database.ModuleProto
+	1,  // 10: database.InstallHistoryProto.installs:type_name -> database.ModuleProto
+	0,  // 11: database.EventProto.action:type_name -> database.EventAction
+	3,  // 12: database.EventProto.scan:type_name -> database.ScanResultProto
+	4,  // 13: database.EventProto.build:type_name -> database.BuildMetricsProto
+	17, // 14: database.VulnReportProto.findings:type_name -> database.VulnFindingProto
+	15, // [15:15] is the sub-list for method output_type
+	15, // [15:15] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_proto_v1_database_proto_init() }
@@ -336,13 +1906,14 @@ func file_proto_v1_database_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_v1_database_proto_rawDesc), len(file_proto_v1_database_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      1,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_proto_v1_database_proto_goTypes,
 		DependencyIndexes: file_proto_v1_database_proto_depIdxs,
+		EnumInfos:         file_proto_v1_database_proto_enumTypes,
 		MessageInfos:      file_proto_v1_database_proto_msgTypes,
 	}.Build()
 	File_proto_v1_database_proto = out.File
@@ -22,6 +22,183 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// BinaryIntegrity is how an installed binary compares with its install
+// record
+type BinaryIntegrity int32
+
+const (
+	BinaryIntegrity_BINARY_INTEGRITY_UNSPECIFIED BinaryIntegrity = 0
+	BinaryIntegrity_BINARY_INTEGRITY_OK          BinaryIntegrity = 1 // The binary hashes as recorded
+	BinaryIntegrity_BINARY_INTEGRITY_MODIFIED    BinaryIntegrity = 2 // Same module and version, different bytes
+	BinaryIntegrity_BINARY_INTEGRITY_REPLACED    BinaryIntegrity = 3 // Another module, version, or a non-Go file
+	BinaryIntegrity_BINARY_INTEGRITY_MISSING     BinaryIntegrity = 4 // No binary where the record says
+	BinaryIntegrity_BINARY_INTEGRITY_UNRECORDED  BinaryIntegrity = 5 // Installed before hashes were recorded
+)
+
+// Enum value maps for BinaryIntegrity.
+var (
+	BinaryIntegrity_name = map[int32]string{
+		0: "BINARY_INTEGRITY_UNSPECIFIED",
+		1: "BINARY_INTEGRITY_OK",
+		2: "BINARY_INTEGRITY_MODIFIED",
+		3: "BINARY_INTEGRITY_REPLACED",
+		4: "BINARY_INTEGRITY_MISSING",
+		5: "BINARY_INTEGRITY_UNRECORDED",
+	}
+	BinaryIntegrity_value = map[string]int32{
+		"BINARY_INTEGRITY_UNSPECIFIED": 0,
+		"BINARY_INTEGRITY_OK":          1,
+		"BINARY_INTEGRITY_MODIFIED":    2,
+		"BINARY_INTEGRITY_REPLACED":    3,
+		"BINARY_INTEGRITY_MISSING":     4,
+		"BINARY_INTEGRITY_UNRECORDED":  5,
+	}
+)
+
+func (x BinaryIntegrity) Enum() *BinaryIntegrity {
+	p := new(BinaryIntegrity)
+	*p = x
+	return p
+}
+
+func (x BinaryIntegrity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BinaryIntegrity) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1_service_proto_enumTypes[0].Descriptor()
+}
+
+func (BinaryIntegrity) Type() protoreflect.EnumType {
+	return &file_proto_v1_service_proto_enumTypes[0]
+}
+
+func (x BinaryIntegrity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BinaryIntegrity.Descriptor instead.
+func (BinaryIntegrity) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{0}
+}
+
+// SumIntegrity is how the module checksums recorded at install compare
+// with the module cache and the checksum database
+type SumIntegrity int32
+
+const (
+	SumIntegrity_SUM_INTEGRITY_UNSPECIFIED SumIntegrity = 0 // Not checked
+	SumIntegrity_SUM_INTEGRITY_OK          SumIntegrity = 1 // Every available source agrees with the record
+	SumIntegrity_SUM_INTEGRITY_MISMATCH    SumIntegrity = 2 // The module cache or the checksum database disagrees
+	SumIntegrity_SUM_INTEGRITY_UNRECORDED  SumIntegrity = 3 // Installed before checksums were recorded, or from a local directory
+	SumIntegrity_SUM_INTEGRITY_UNCHECKED   SumIntegrity = 4 // Neither the module cache nor the checksum database could be consulted
+)
+
+// Enum value maps for SumIntegrity.
+var (
+	SumIntegrity_name = map[int32]string{
+		0: "SUM_INTEGRITY_UNSPECIFIED",
+		1: "SUM_INTEGRITY_OK",
+		2: "SUM_INTEGRITY_MISMATCH",
+		3: "SUM_INTEGRITY_UNRECORDED",
+		4: "SUM_INTEGRITY_UNCHECKED",
+	}
+	SumIntegrity_value = map[string]int32{
+		"SUM_INTEGRITY_UNSPECIFIED": 0,
+		"SUM_INTEGRITY_OK":          1,
+		"SUM_INTEGRITY_MISMATCH":    2,
+		"SUM_INTEGRITY_UNRECORDED":  3,
+		"SUM_INTEGRITY_UNCHECKED":   4,
+	}
+)
+
+func (x SumIntegrity) Enum() *SumIntegrity {
+	p := new(SumIntegrity)
+	*p = x
+	return p
+}
+
+func (x SumIntegrity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SumIntegrity) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1_service_proto_enumTypes[1].Descriptor()
+}
+
+func (SumIntegrity) Type() protoreflect.EnumType {
+	return &file_proto_v1_service_proto_enumTypes[1]
+}
+
+func (x SumIntegrity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SumIntegrity.Descriptor instead.
+func (SumIntegrity) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{1}
+}
+
+// InstallPhase is a step of an install, in order, so clients can render
+// progress without matching phase labels
+type InstallPhase int32
+
+const (
+	InstallPhase_INSTALL_PHASE_UNSPECIFIED InstallPhase = 0
+	InstallPhase_INSTALL_PHASE_RESOLVE     InstallPhase = 1 // Resolving the module, its versions and dependencies
+	InstallPhase_INSTALL_PHASE_POLICY      InstallPhase = 2 // Denylist, constraint and policy checks
+	InstallPhase_INSTALL_PHASE_BUILD       InstallPhase = 3 // Downloading sources and building the binary
+	InstallPhase_INSTALL_PHASE_STORE       InstallPhase = 4 // Recording the install
+	InstallPhase_INSTALL_PHASE_COMPLETE    InstallPhase = 5
+)
+
+// Enum value maps for InstallPhase.
+var (
+	InstallPhase_name = map[int32]string{
+		0: "INSTALL_PHASE_UNSPECIFIED",
+		1: "INSTALL_PHASE_RESOLVE",
+		2: "INSTALL_PHASE_POLICY",
+		3: "INSTALL_PHASE_BUILD",
+		4: "INSTALL_PHASE_STORE",
+		5: "INSTALL_PHASE_COMPLETE",
+	}
+	InstallPhase_value = map[string]int32{
+		"INSTALL_PHASE_UNSPECIFIED": 0,
+		"INSTALL_PHASE_RESOLVE":     1,
+		"INSTALL_PHASE_POLICY":      2,
+		"INSTALL_PHASE_BUILD":       3,
+		"INSTALL_PHASE_STORE":       4,
+		"INSTALL_PHASE_COMPLETE":    5,
+	}
+)
+
+func (x InstallPhase) Enum() *InstallPhase {
+	p := new(InstallPhase)
+	*p = x
+	return p
+}
+
+func (x InstallPhase) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InstallPhase) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1_service_proto_enumTypes[2].Descriptor()
+}
+
+func (InstallPhase) Type() protoreflect.EnumType {
+	return &file_proto_v1_service_proto_enumTypes[2]
+}
+
+func (x InstallPhase) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InstallPhase.Descriptor instead.
+func (InstallPhase) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{2}
+}
+
 type OutputLine_Stream int32
 
 const (
@@ -52,11 +229,11 @@ func (x OutputLine_Stream) String() string {
 }
 
 func (OutputLine_Stream) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_v1_service_proto_enumTypes[0].Descriptor()
+	return file_proto_v1_service_proto_enumTypes[3].Descriptor()
 }
 
 func (OutputLine_Stream) Type() protoreflect.EnumType {
-	return &file_proto_v1_service_proto_enumTypes[0]
+	return &file_proto_v1_service_proto_enumTypes[3]
 }
 
 func (x OutputLine_Stream) Number() protoreflect.EnumNumber {
@@ -65,7 +242,7 @@ func (x OutputLine_Stream) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use OutputLine_Stream.Descriptor instead.
 func (OutputLine_Stream) EnumDescriptor() ([]byte, []int) {
-	return file_proto_v1_service_proto_rawDescGZIP(), []int{15, 0}
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{76, 0}
 }
 
 type ServerConfig struct {
@@ -137,15 +314,19 @@ func (x *ServerConfig) GetBindAddress() string {
 }
 
 type ServerStatus struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Running       bool                   `protobuf:"varint,1,opt,name=running,proto3" json:"running,omitempty"`
-	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	DatabasePath  string                 `protobuf:"bytes,3,opt,name=database_path,json=databasePath,proto3" json:"database_path,omitempty"`
-	Address       string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
-	UptimeSeconds int64                  `protobuf:"varint,5,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
-	ModuleCount   int64                  `protobuf:"varint,6,opt,name=module_count,json=moduleCount,proto3" json:"module_count,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                       protoimpl.MessageState `protogen:"open.v1"`
+	Running                     bool                   `protobuf:"varint,1,opt,name=running,proto3" json:"running,omitempty"`
+	Namespace                   string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	DatabasePath                string                 `protobuf:"bytes,3,opt,name=database_path,json=databasePath,proto3" json:"database_path,omitempty"`
+	Address                     string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	UptimeSeconds               int64                  `protobuf:"varint,5,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	ModuleCount                 int64                  `protobuf:"varint,6,opt,name=module_count,json=moduleCount,proto3" json:"module_count,omitempty"`
+	AutoupdateEnabled           bool                   `protobuf:"varint,7,opt,name=autoupdate_enabled,json=autoupdateEnabled,proto3" json:"autoupdate_enabled,omitempty"`
+	PendingUpdateCount          int64                  `protobuf:"varint,8,opt,name=pending_update_count,json=pendingUpdateCount,proto3" json:"pending_update_count,omitempty"`                                // Updates found but not installed by the last auto-update check
+	LastAutoupdateCheckUnixNano int64                  `protobuf:"varint,9,opt,name=last_autoupdate_check_unix_nano,json=lastAutoupdateCheckUnixNano,proto3" json:"last_autoupdate_check_unix_nano,omitempty"` // 0 if auto-update never checked
+	Hostname                    string                 `protobuf:"bytes,10,opt,name=hostname,proto3" json:"hostname,omitempty"`                                                                                // Host the server runs on
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
 }
 
 func (x *ServerStatus) Reset() {
@@ -220,11 +401,41 @@ func (x *ServerStatus) GetModuleCount() int64 {
 	return 0
 }
 
+func (x *ServerStatus) GetAutoupdateEnabled() bool {
+	if x != nil {
+		return x.AutoupdateEnabled
+	}
+	return false
+}
+
+func (x *ServerStatus) GetPendingUpdateCount() int64 {
+	if x != nil {
+		return x.PendingUpdateCount
+	}
+	return 0
+}
+
+func (x *ServerStatus) GetLastAutoupdateCheckUnixNano() int64 {
+	if x != nil {
+		return x.LastAutoupdateCheckUnixNano
+	}
+	return 0
+}
+
+func (x *ServerStatus) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
 // StoreModuleRequest is used by the CLI to store module info after local installation
 type StoreModuleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Module        *ModuleProto           `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
 	Dependencies  *DependenciesProto     `protobuf:"bytes,2,opt,name=dependencies,proto3" json:"dependencies,omitempty"`
+	Refresh       bool                   `protobuf:"varint,3,opt,name=refresh,proto3" json:"refresh,omitempty"`                           // Refreshed metadata of the installed version, stored without an install event
+	RenamedFrom   string                 `protobuf:"bytes,4,opt,name=renamed_from,json=renamedFrom,proto3" json:"renamed_from,omitempty"` // Name the module was stored under before its package moved
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -273,6 +484,20 @@ func (x *StoreModuleRequest) GetDependencies() *DependenciesProto {
 	return nil
 }
 
+func (x *StoreModuleRequest) GetRefresh() bool {
+	if x != nil {
+		return x.Refresh
+	}
+	return false
+}
+
+func (x *StoreModuleRequest) GetRenamedFrom() string {
+	if x != nil {
+		return x.RenamedFrom
+	}
+	return ""
+}
+
 type StoreModuleResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -562,6 +787,7 @@ type ListModulesRequest struct {
 	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`                            // Pagination limit
 	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`                          // Pagination offset
 	NameFilter    string                 `protobuf:"bytes,3,opt,name=name_filter,json=nameFilter,proto3" json:"name_filter,omitempty"` // Optional name filter
+	Security      bool                   `protobuf:"varint,4,opt,name=security,proto3" json:"security,omitempty"`                      // Roll up the vulnerabilities and flagged licenses of each module
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -617,10 +843,18 @@ func (x *ListModulesRequest) GetNameFilter() string {
 	return ""
 }
 
+func (x *ListModulesRequest) GetSecurity() bool {
+	if x != nil {
+		return x.Security
+	}
+	return false
+}
+
 type ListModulesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Modules       []*ModuleProto         `protobuf:"bytes,1,rep,name=modules,proto3" json:"modules,omitempty"`
 	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Security      []*SecuritySummary     `protobuf:"bytes,3,rep,name=security,proto3" json:"security,omitempty"` // With security: one per module, in the same order
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -669,6 +903,108 @@ func (x *ListModulesResponse) GetTotalCount() int64 {
 	return 0
 }
 
+func (x *ListModulesResponse) GetSecurity() []*SecuritySummary {
+	if x != nil {
+		return x.Security
+	}
+	return nil
+}
+
+// SecuritySummary rolls up what is known about a module and its dependency
+// tree from the last vulnerability scan and the licenses detected at install,
+// without looking anything up
+type SecuritySummary struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Name                  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                                 // Module path
+	Scanned               bool                   `protobuf:"varint,2,opt,name=scanned,proto3" json:"scanned,omitempty"`                                                          // Whether the installed version was scanned for vulnerabilities
+	Vulnerabilities       int32                  `protobuf:"varint,3,opt,name=vulnerabilities,proto3" json:"vulnerabilities,omitempty"`                                          // Known vulnerabilities the last scan found
+	CalledVulnerabilities int32                  `protobuf:"varint,4,opt,name=called_vulnerabilities,json=calledVulnerabilities,proto3" json:"called_vulnerabilities,omitempty"` // Those the binary calls
+	Dependencies          int32                  `protobuf:"varint,5,opt,name=dependencies,proto3" json:"dependencies,omitempty"`                                                // Modules in the dependency tree
+	FlaggedLicenses       int32                  `protobuf:"varint,6,opt,name=flagged_licenses,json=flaggedLicenses,proto3" json:"flagged_licenses,omitempty"`                   // Modules, itself included, with a flagged license
+	Flagged               []string               `protobuf:"bytes,7,rep,name=flagged,proto3" json:"flagged,omitempty"`                                                           // Flagged licenses, e.g. "none" or "AGPL-3.0", most frequent first
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *SecuritySummary) Reset() {
+	*x = SecuritySummary{}
+	mi := &file_proto_v1_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecuritySummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecuritySummary) ProtoMessage() {}
+
+func (x *SecuritySummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecuritySummary.ProtoReflect.Descriptor instead.
+func (*SecuritySummary) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SecuritySummary) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SecuritySummary) GetScanned() bool {
+	if x != nil {
+		return x.Scanned
+	}
+	return false
+}
+
+func (x *SecuritySummary) GetVulnerabilities() int32 {
+	if x != nil {
+		return x.Vulnerabilities
+	}
+	return 0
+}
+
+func (x *SecuritySummary) GetCalledVulnerabilities() int32 {
+	if x != nil {
+		return x.CalledVulnerabilities
+	}
+	return 0
+}
+
+func (x *SecuritySummary) GetDependencies() int32 {
+	if x != nil {
+		return x.Dependencies
+	}
+	return 0
+}
+
+func (x *SecuritySummary) GetFlaggedLicenses() int32 {
+	if x != nil {
+		return x.FlaggedLicenses
+	}
+	return 0
+}
+
+func (x *SecuritySummary) GetFlagged() []string {
+	if x != nil {
+		return x.Flagged
+	}
+	return nil
+}
+
 type GetModuleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -679,7 +1015,7 @@ type GetModuleRequest struct {
 
 func (x *GetModuleRequest) Reset() {
 	*x = GetModuleRequest{}
-	mi := &file_proto_v1_service_proto_msgTypes[10]
+	mi := &file_proto_v1_service_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -691,7 +1027,7 @@ func (x *GetModuleRequest) String() string {
 func (*GetModuleRequest) ProtoMessage() {}
 
 func (x *GetModuleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_service_proto_msgTypes[10]
+	mi := &file_proto_v1_service_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -704,7 +1040,7 @@ func (x *GetModuleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetModuleRequest.ProtoReflect.Descriptor instead.
 func (*GetModuleRequest) Descriptor() ([]byte, []int) {
-	return file_proto_v1_service_proto_rawDescGZIP(), []int{10}
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetModuleRequest) GetName() string {
@@ -731,7 +1067,7 @@ type GetModuleResponse struct {
 
 func (x *GetModuleResponse) Reset() {
 	*x = GetModuleResponse{}
-	mi := &file_proto_v1_service_proto_msgTypes[11]
+	mi := &file_proto_v1_service_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -743,7 +1079,7 @@ func (x *GetModuleResponse) String() string {
 func (*GetModuleResponse) ProtoMessage() {}
 
 func (x *GetModuleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_service_proto_msgTypes[11]
+	mi := &file_proto_v1_service_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -756,7 +1092,7 @@ func (x *GetModuleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetModuleResponse.ProtoReflect.Descriptor instead.
 func (*GetModuleResponse) Descriptor() ([]byte, []int) {
-	return file_proto_v1_service_proto_rawDescGZIP(), []int{11}
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetModuleResponse) GetModule() *ModuleProto {
@@ -783,7 +1119,7 @@ type GetDependenciesResponse struct {
 
 func (x *GetDependenciesResponse) Reset() {
 	*x = GetDependenciesResponse{}
-	mi := &file_proto_v1_service_proto_msgTypes[12]
+	mi := &file_proto_v1_service_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -795,7 +1131,7 @@ func (x *GetDependenciesResponse) String() string {
 func (*GetDependenciesResponse) ProtoMessage() {}
 
 func (x *GetDependenciesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_service_proto_msgTypes[12]
+	mi := &file_proto_v1_service_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -808,7 +1144,7 @@ func (x *GetDependenciesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDependenciesResponse.ProtoReflect.Descriptor instead.
 func (*GetDependenciesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_v1_service_proto_rawDescGZIP(), []int{12}
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetDependenciesResponse) GetDependencies() *DependenciesProto {
@@ -835,7 +1171,7 @@ type UpdateRequest struct {
 
 func (x *UpdateRequest) Reset() {
 	*x = UpdateRequest{}
-	mi := &file_proto_v1_service_proto_msgTypes[13]
+	mi := &file_proto_v1_service_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -847,7 +1183,7 @@ func (x *UpdateRequest) String() string {
 func (*UpdateRequest) ProtoMessage() {}
 
 func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_service_proto_msgTypes[13]
+	mi := &file_proto_v1_service_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -860,7 +1196,7 @@ func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
 func (*UpdateRequest) Descriptor() ([]byte, []int) {
-	return file_proto_v1_service_proto_rawDescGZIP(), []int{13}
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *UpdateRequest) GetModulePath() string {
@@ -889,7 +1225,7 @@ type UpdateResponse struct {
 
 func (x *UpdateResponse) Reset() {
 	*x = UpdateResponse{}
-	mi := &file_proto_v1_service_proto_msgTypes[14]
+	mi := &file_proto_v1_service_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -901,7 +1237,7 @@ func (x *UpdateResponse) String() string {
 func (*UpdateResponse) ProtoMessage() {}
 
 func (x *UpdateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_service_proto_msgTypes[14]
+	mi := &file_proto_v1_service_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -914,7 +1250,7 @@ func (x *UpdateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateResponse.ProtoReflect.Descriptor instead.
 func (*UpdateResponse) Descriptor() ([]byte, []int) {
-	return file_proto_v1_service_proto_rawDescGZIP(), []int{14}
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *UpdateResponse) GetOldModule() *ModuleProto {
@@ -945,30 +1281,29 @@ func (x *UpdateResponse) GetErrorMessage() string {
 	return ""
 }
 
-type OutputLine struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Stream            OutputLine_Stream      `protobuf:"varint,1,opt,name=stream,proto3,enum=glix.v1.OutputLine_Stream" json:"stream,omitempty"`
-	Line              string                 `protobuf:"bytes,2,opt,name=line,proto3" json:"line,omitempty"`
-	TimestampUnixNano int64                  `protobuf:"varint,3,opt,name=timestamp_unix_nano,json=timestampUnixNano,proto3" json:"timestamp_unix_nano,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+type MarkBadVersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OutputLine) Reset() {
-	*x = OutputLine{}
-	mi := &file_proto_v1_service_proto_msgTypes[15]
+func (x *MarkBadVersionRequest) Reset() {
+	*x = MarkBadVersionRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OutputLine) String() string {
+func (x *MarkBadVersionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OutputLine) ProtoMessage() {}
+func (*MarkBadVersionRequest) ProtoMessage() {}
 
-func (x *OutputLine) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_service_proto_msgTypes[15]
+func (x *MarkBadVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -979,44 +1314,3426 @@ func (x *OutputLine) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OutputLine.ProtoReflect.Descriptor instead.
-func (*OutputLine) Descriptor() ([]byte, []int) {
-	return file_proto_v1_service_proto_rawDescGZIP(), []int{15}
-}
-
-func (x *OutputLine) GetStream() OutputLine_Stream {
-	if x != nil {
-		return x.Stream
-	}
-	return OutputLine_STDOUT
+// Deprecated: Use MarkBadVersionRequest.ProtoReflect.Descriptor instead.
+func (*MarkBadVersionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *OutputLine) GetLine() string {
+func (x *MarkBadVersionRequest) GetName() string {
 	if x != nil {
-		return x.Line
+		return x.Name
 	}
 	return ""
 }
 
-func (x *OutputLine) GetTimestampUnixNano() int64 {
+func (x *MarkBadVersionRequest) GetVersion() string {
 	if x != nil {
-		return x.TimestampUnixNano
+		return x.Version
 	}
-	return 0
+	return ""
 }
 
-type ProgressUpdate struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Phase           string                 `protobuf:"bytes,1,opt,name=phase,proto3" json:"phase,omitempty"` // e.g., "downloading", "compiling", "installing"
-	Message         string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	PercentComplete int32                  `protobuf:"varint,3,opt,name=percent_complete,json=percentComplete,proto3" json:"percent_complete,omitempty"` // 0-100, -1 if unknown
+type MarkBadVersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Module        *ModuleProto           `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkBadVersionResponse) Reset() {
+	*x = MarkBadVersionResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkBadVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkBadVersionResponse) ProtoMessage() {}
+
+func (x *MarkBadVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkBadVersionResponse.ProtoReflect.Descriptor instead.
+func (*MarkBadVersionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *MarkBadVersionResponse) GetModule() *ModuleProto {
+	if x != nil {
+		return x.Module
+	}
+	return nil
+}
+
+func (x *MarkBadVersionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *MarkBadVersionResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type SetAliasRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Alias         string                 `protobuf:"bytes,2,opt,name=alias,proto3" json:"alias,omitempty"`                             // Empty restores the default binary name
+	BinaryPath    string                 `protobuf:"bytes,3,opt,name=binary_path,json=binaryPath,proto3" json:"binary_path,omitempty"` // Where the binary was moved to
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAliasRequest) Reset() {
+	*x = SetAliasRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAliasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAliasRequest) ProtoMessage() {}
+
+func (x *SetAliasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAliasRequest.ProtoReflect.Descriptor instead.
+func (*SetAliasRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SetAliasRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetAliasRequest) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *SetAliasRequest) GetBinaryPath() string {
+	if x != nil {
+		return x.BinaryPath
+	}
+	return ""
+}
+
+type SetAliasResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Module        *ModuleProto           `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAliasResponse) Reset() {
+	*x = SetAliasResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAliasResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAliasResponse) ProtoMessage() {}
+
+func (x *SetAliasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAliasResponse.ProtoReflect.Descriptor instead.
+func (*SetAliasResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SetAliasResponse) GetModule() *ModuleProto {
+	if x != nil {
+		return x.Module
+	}
+	return nil
+}
+
+func (x *SetAliasResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetAliasResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type VerifyBinariesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Names         []string               `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`    // Modules to verify; empty verifies all
+	Accept        bool                   `protobuf:"varint,2,opt,name=accept,proto3" json:"accept,omitempty"` // Record the current hashes as the expected ones
+	Sums          bool                   `protobuf:"varint,3,opt,name=sums,proto3" json:"sums,omitempty"`     // Also check the recorded module checksums
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyBinariesRequest) Reset() {
+	*x = VerifyBinariesRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyBinariesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyBinariesRequest) ProtoMessage() {}
+
+func (x *VerifyBinariesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyBinariesRequest.ProtoReflect.Descriptor instead.
+func (*VerifyBinariesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *VerifyBinariesRequest) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *VerifyBinariesRequest) GetAccept() bool {
+	if x != nil {
+		return x.Accept
+	}
+	return false
+}
+
+func (x *VerifyBinariesRequest) GetSums() bool {
+	if x != nil {
+		return x.Sums
+	}
+	return false
+}
+
+type BinaryVerification struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	BinaryPath    string                 `protobuf:"bytes,3,opt,name=binary_path,json=binaryPath,proto3" json:"binary_path,omitempty"`
+	Integrity     BinaryIntegrity        `protobuf:"varint,4,opt,name=integrity,proto3,enum=glix.v1.BinaryIntegrity" json:"integrity,omitempty"`
+	RecordedHash  string                 `protobuf:"bytes,5,opt,name=recorded_hash,json=recordedHash,proto3" json:"recorded_hash,omitempty"`
+	ActualHash    string                 `protobuf:"bytes,6,opt,name=actual_hash,json=actualHash,proto3" json:"actual_hash,omitempty"`
+	Detail        string                 `protobuf:"bytes,7,opt,name=detail,proto3" json:"detail,omitempty"`                                                            // What replaced the binary, or why it could not be read
+	Accepted      bool                   `protobuf:"varint,8,opt,name=accepted,proto3" json:"accepted,omitempty"`                                                       // The actual hash was recorded
+	SumIntegrity  SumIntegrity           `protobuf:"varint,9,opt,name=sum_integrity,json=sumIntegrity,proto3,enum=glix.v1.SumIntegrity" json:"sum_integrity,omitempty"` // Unspecified unless sums were requested
+	SumDetail     string                 `protobuf:"bytes,10,opt,name=sum_detail,json=sumDetail,proto3" json:"sum_detail,omitempty"`                                    // Which source disagrees, or why none was consulted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BinaryVerification) Reset() {
+	*x = BinaryVerification{}
+	mi := &file_proto_v1_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BinaryVerification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BinaryVerification) ProtoMessage() {}
+
+func (x *BinaryVerification) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BinaryVerification.ProtoReflect.Descriptor instead.
+func (*BinaryVerification) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *BinaryVerification) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BinaryVerification) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *BinaryVerification) GetBinaryPath() string {
+	if x != nil {
+		return x.BinaryPath
+	}
+	return ""
+}
+
+func (x *BinaryVerification) GetIntegrity() BinaryIntegrity {
+	if x != nil {
+		return x.Integrity
+	}
+	return BinaryIntegrity_BINARY_INTEGRITY_UNSPECIFIED
+}
+
+func (x *BinaryVerification) GetRecordedHash() string {
+	if x != nil {
+		return x.RecordedHash
+	}
+	return ""
+}
+
+func (x *BinaryVerification) GetActualHash() string {
+	if x != nil {
+		return x.ActualHash
+	}
+	return ""
+}
+
+func (x *BinaryVerification) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *BinaryVerification) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *BinaryVerification) GetSumIntegrity() SumIntegrity {
+	if x != nil {
+		return x.SumIntegrity
+	}
+	return SumIntegrity_SUM_INTEGRITY_UNSPECIFIED
+}
+
+func (x *BinaryVerification) GetSumDetail() string {
+	if x != nil {
+		return x.SumDetail
+	}
+	return ""
+}
+
+type VerifyBinariesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BinaryVerification  `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyBinariesResponse) Reset() {
+	*x = VerifyBinariesResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyBinariesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyBinariesResponse) ProtoMessage() {}
+
+func (x *VerifyBinariesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyBinariesResponse.ProtoReflect.Descriptor instead.
+func (*VerifyBinariesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *VerifyBinariesResponse) GetResults() []*BinaryVerification {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *VerifyBinariesResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type GetInstallHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInstallHistoryRequest) Reset() {
+	*x = GetInstallHistoryRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInstallHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInstallHistoryRequest) ProtoMessage() {}
+
+func (x *GetInstallHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInstallHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetInstallHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetInstallHistoryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetInstallHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Installs      []*ModuleProto         `protobuf:"bytes,1,rep,name=installs,proto3" json:"installs,omitempty"` // Oldest first
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInstallHistoryResponse) Reset() {
+	*x = GetInstallHistoryResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInstallHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInstallHistoryResponse) ProtoMessage() {}
+
+func (x *GetInstallHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInstallHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetInstallHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetInstallHistoryResponse) GetInstalls() []*ModuleProto {
+	if x != nil {
+		return x.Installs
+	}
+	return nil
+}
+
+func (x *GetInstallHistoryResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type RecordEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         *EventProto            `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordEventRequest) Reset() {
+	*x = RecordEventRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordEventRequest) ProtoMessage() {}
+
+func (x *RecordEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordEventRequest.ProtoReflect.Descriptor instead.
+func (*RecordEventRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RecordEventRequest) GetEvent() *EventProto {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type RecordEventResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordEventResponse) Reset() {
+	*x = RecordEventResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordEventResponse) ProtoMessage() {}
+
+func (x *RecordEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordEventResponse.ProtoReflect.Descriptor instead.
+func (*RecordEventResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RecordEventResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RecordEventResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type GetHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`    // Optional: only events of this module
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // Optional: newest events to return (0 = all)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryRequest) Reset() {
+	*x = GetHistoryRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryRequest) ProtoMessage() {}
+
+func (x *GetHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetHistoryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*EventProto          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"` // Newest first
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryResponse) Reset() {
+	*x = GetHistoryResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryResponse) ProtoMessage() {}
+
+func (x *GetHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetHistoryResponse) GetEvents() []*EventProto {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *GetHistoryResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type StoreVulnReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Report        *VulnReportProto       `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreVulnReportRequest) Reset() {
+	*x = StoreVulnReportRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreVulnReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreVulnReportRequest) ProtoMessage() {}
+
+func (x *StoreVulnReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreVulnReportRequest.ProtoReflect.Descriptor instead.
+func (*StoreVulnReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *StoreVulnReportRequest) GetReport() *VulnReportProto {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+type StoreVulnReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreVulnReportResponse) Reset() {
+	*x = StoreVulnReportResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreVulnReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreVulnReportResponse) ProtoMessage() {}
+
+func (x *StoreVulnReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreVulnReportResponse.ProtoReflect.Descriptor instead.
+func (*StoreVulnReportResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *StoreVulnReportResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StoreVulnReportResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type GetVersionCacheRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"` // Module path queried
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVersionCacheRequest) Reset() {
+	*x = GetVersionCacheRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersionCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionCacheRequest) ProtoMessage() {}
+
+func (x *GetVersionCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionCacheRequest.ProtoReflect.Descriptor instead.
+func (*GetVersionCacheRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetVersionCacheRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type GetVersionCacheResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Entry         *VersionCacheProto     `protobuf:"bytes,2,opt,name=entry,proto3" json:"entry,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVersionCacheResponse) Reset() {
+	*x = GetVersionCacheResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersionCacheResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionCacheResponse) ProtoMessage() {}
+
+func (x *GetVersionCacheResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionCacheResponse.ProtoReflect.Descriptor instead.
+func (*GetVersionCacheResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetVersionCacheResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *GetVersionCacheResponse) GetEntry() *VersionCacheProto {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *GetVersionCacheResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type StoreVersionCacheRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *VersionCacheProto     `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreVersionCacheRequest) Reset() {
+	*x = StoreVersionCacheRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreVersionCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreVersionCacheRequest) ProtoMessage() {}
+
+func (x *StoreVersionCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreVersionCacheRequest.ProtoReflect.Descriptor instead.
+func (*StoreVersionCacheRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *StoreVersionCacheRequest) GetEntry() *VersionCacheProto {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type StoreVersionCacheResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreVersionCacheResponse) Reset() {
+	*x = StoreVersionCacheResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreVersionCacheResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreVersionCacheResponse) ProtoMessage() {}
+
+func (x *StoreVersionCacheResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreVersionCacheResponse.ProtoReflect.Descriptor instead.
+func (*StoreVersionCacheResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *StoreVersionCacheResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StoreVersionCacheResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type ListVulnReportsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Optional: only the report of this module
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVulnReportsRequest) Reset() {
+	*x = ListVulnReportsRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVulnReportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVulnReportsRequest) ProtoMessage() {}
+
+func (x *ListVulnReportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVulnReportsRequest.ProtoReflect.Descriptor instead.
+func (*ListVulnReportsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListVulnReportsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListVulnReportsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reports       []*VulnReportProto     `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"` // Ordered by module
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVulnReportsResponse) Reset() {
+	*x = ListVulnReportsResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVulnReportsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVulnReportsResponse) ProtoMessage() {}
+
+func (x *ListVulnReportsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVulnReportsResponse.ProtoReflect.Descriptor instead.
+func (*ListVulnReportsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListVulnReportsResponse) GetReports() []*VulnReportProto {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+func (x *ListVulnReportsResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type GetStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Weeks         int32                  `protobuf:"varint,1,opt,name=weeks,proto3" json:"weeks,omitempty"` // Weeks to aggregate, ending with the current one (0 = 12)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetStatsRequest) GetWeeks() int32 {
+	if x != nil {
+		return x.Weeks
+	}
+	return 0
+}
+
+// WeeklyStats counts the events of a week. Installs and updates count
+// successful changes; failures count failed changes of any action.
+type WeeklyStats struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	WeekStartUnixNano int64                  `protobuf:"varint,1,opt,name=week_start_unix_nano,json=weekStartUnixNano,proto3" json:"week_start_unix_nano,omitempty"` // Monday 00:00 local time of the server
+	Installs          int32                  `protobuf:"varint,2,opt,name=installs,proto3" json:"installs,omitempty"`
+	Updates           int32                  `protobuf:"varint,3,opt,name=updates,proto3" json:"updates,omitempty"`
+	Removes           int32                  `protobuf:"varint,4,opt,name=removes,proto3" json:"removes,omitempty"`
+	Failures          int32                  `protobuf:"varint,5,opt,name=failures,proto3" json:"failures,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *WeeklyStats) Reset() {
+	*x = WeeklyStats{}
+	mi := &file_proto_v1_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WeeklyStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeeklyStats) ProtoMessage() {}
+
+func (x *WeeklyStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeeklyStats.ProtoReflect.Descriptor instead.
+func (*WeeklyStats) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *WeeklyStats) GetWeekStartUnixNano() int64 {
+	if x != nil {
+		return x.WeekStartUnixNano
+	}
+	return 0
+}
+
+func (x *WeeklyStats) GetInstalls() int32 {
+	if x != nil {
+		return x.Installs
+	}
+	return 0
+}
+
+func (x *WeeklyStats) GetUpdates() int32 {
+	if x != nil {
+		return x.Updates
+	}
+	return 0
+}
+
+func (x *WeeklyStats) GetRemoves() int32 {
+	if x != nil {
+		return x.Removes
+	}
+	return 0
+}
+
+func (x *WeeklyStats) GetFailures() int32 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+// BuildStats aggregates the build metrics of the installs and updates of
+// the weeks
+type BuildStats struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	Builds                   int32                  `protobuf:"varint,1,opt,name=builds,proto3" json:"builds,omitempty"`                        // Successful installs and updates with build metrics
+	CacheHits                int32                  `protobuf:"varint,2,opt,name=cache_hits,json=cacheHits,proto3" json:"cache_hits,omitempty"` // Builds whose module source was in the module cache already
+	MedianDurationNano       int64                  `protobuf:"varint,3,opt,name=median_duration_nano,json=medianDurationNano,proto3" json:"median_duration_nano,omitempty"`
+	P95DurationNano          int64                  `protobuf:"varint,4,opt,name=p95_duration_nano,json=p95DurationNano,proto3" json:"p95_duration_nano,omitempty"`
+	MedianColdDurationNano   int64                  `protobuf:"varint,5,opt,name=median_cold_duration_nano,json=medianColdDurationNano,proto3" json:"median_cold_duration_nano,omitempty"`       // Of builds that downloaded their source (0 when there were none)
+	MedianCachedDurationNano int64                  `protobuf:"varint,6,opt,name=median_cached_duration_nano,json=medianCachedDurationNano,proto3" json:"median_cached_duration_nano,omitempty"` // Of cache hits (0 when there were none)
+	MedianBinarySize         int64                  `protobuf:"varint,7,opt,name=median_binary_size,json=medianBinarySize,proto3" json:"median_binary_size,omitempty"`
+	TotalBinarySize          int64                  `protobuf:"varint,8,opt,name=total_binary_size,json=totalBinarySize,proto3" json:"total_binary_size,omitempty"`
+	Slowest                  string                 `protobuf:"bytes,9,opt,name=slowest,proto3" json:"slowest,omitempty"` // Module of the longest build
+	SlowestDurationNano      int64                  `protobuf:"varint,10,opt,name=slowest_duration_nano,json=slowestDurationNano,proto3" json:"slowest_duration_nano,omitempty"`
+	Largest                  string                 `protobuf:"bytes,11,opt,name=largest,proto3" json:"largest,omitempty"` // Module of the largest binary
+	LargestBinarySize        int64                  `protobuf:"varint,12,opt,name=largest_binary_size,json=largestBinarySize,proto3" json:"largest_binary_size,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *BuildStats) Reset() {
+	*x = BuildStats{}
+	mi := &file_proto_v1_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildStats) ProtoMessage() {}
+
+func (x *BuildStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildStats.ProtoReflect.Descriptor instead.
+func (*BuildStats) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *BuildStats) GetBuilds() int32 {
+	if x != nil {
+		return x.Builds
+	}
+	return 0
+}
+
+func (x *BuildStats) GetCacheHits() int32 {
+	if x != nil {
+		return x.CacheHits
+	}
+	return 0
+}
+
+func (x *BuildStats) GetMedianDurationNano() int64 {
+	if x != nil {
+		return x.MedianDurationNano
+	}
+	return 0
+}
+
+func (x *BuildStats) GetP95DurationNano() int64 {
+	if x != nil {
+		return x.P95DurationNano
+	}
+	return 0
+}
+
+func (x *BuildStats) GetMedianColdDurationNano() int64 {
+	if x != nil {
+		return x.MedianColdDurationNano
+	}
+	return 0
+}
+
+func (x *BuildStats) GetMedianCachedDurationNano() int64 {
+	if x != nil {
+		return x.MedianCachedDurationNano
+	}
+	return 0
+}
+
+func (x *BuildStats) GetMedianBinarySize() int64 {
+	if x != nil {
+		return x.MedianBinarySize
+	}
+	return 0
+}
+
+func (x *BuildStats) GetTotalBinarySize() int64 {
+	if x != nil {
+		return x.TotalBinarySize
+	}
+	return 0
+}
+
+func (x *BuildStats) GetSlowest() string {
+	if x != nil {
+		return x.Slowest
+	}
+	return ""
+}
+
+func (x *BuildStats) GetSlowestDurationNano() int64 {
+	if x != nil {
+		return x.SlowestDurationNano
+	}
+	return 0
+}
+
+func (x *BuildStats) GetLargest() string {
+	if x != nil {
+		return x.Largest
+	}
+	return ""
+}
+
+func (x *BuildStats) GetLargestBinarySize() int64 {
+	if x != nil {
+		return x.LargestBinarySize
+	}
+	return 0
+}
+
+type GetStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Weeks         []*WeeklyStats         `protobuf:"bytes,1,rep,name=weeks,proto3" json:"weeks,omitempty"` // Oldest first, one per week including empty ones
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Builds        *BuildStats            `protobuf:"bytes,3,opt,name=builds,proto3" json:"builds,omitempty"` // Build metrics of the weeks (unset when no event has them)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsResponse) Reset() {
+	*x = GetStatsResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsResponse) ProtoMessage() {}
+
+func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetStatsResponse) GetWeeks() []*WeeklyStats {
+	if x != nil {
+		return x.Weeks
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *GetStatsResponse) GetBuilds() *BuildStats {
+	if x != nil {
+		return x.Builds
+	}
+	return nil
+}
+
+type CreateSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Overwrite     bool                   `protobuf:"varint,3,opt,name=overwrite,proto3" json:"overwrite,omitempty"` // Replace an existing snapshot with the same name
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSnapshotRequest) Reset() {
+	*x = CreateSnapshotRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSnapshotRequest) ProtoMessage() {}
+
+func (x *CreateSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*CreateSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CreateSnapshotRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSnapshotRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateSnapshotRequest) GetOverwrite() bool {
+	if x != nil {
+		return x.Overwrite
+	}
+	return false
+}
+
+type CreateSnapshotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Snapshot      *SnapshotProto         `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSnapshotResponse) Reset() {
+	*x = CreateSnapshotResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSnapshotResponse) ProtoMessage() {}
+
+func (x *CreateSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*CreateSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CreateSnapshotResponse) GetSnapshot() *SnapshotProto {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (x *CreateSnapshotResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateSnapshotResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type GetSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSnapshotRequest) Reset() {
+	*x = GetSnapshotRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSnapshotRequest) ProtoMessage() {}
+
+func (x *GetSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*GetSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetSnapshotRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetSnapshotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Snapshot      *SnapshotProto         `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	Found         bool                   `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSnapshotResponse) Reset() {
+	*x = GetSnapshotResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSnapshotResponse) ProtoMessage() {}
+
+func (x *GetSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*GetSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetSnapshotResponse) GetSnapshot() *SnapshotProto {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (x *GetSnapshotResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+type ListSnapshotsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Snapshots     []*SnapshotProto       `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSnapshotsResponse) Reset() {
+	*x = ListSnapshotsResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSnapshotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotsResponse) ProtoMessage() {}
+
+func (x *ListSnapshotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotsResponse.ProtoReflect.Descriptor instead.
+func (*ListSnapshotsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListSnapshotsResponse) GetSnapshots() []*SnapshotProto {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+type DeleteSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSnapshotRequest) Reset() {
+	*x = DeleteSnapshotRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSnapshotRequest) ProtoMessage() {}
+
+func (x *DeleteSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DeleteSnapshotRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteSnapshotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSnapshotResponse) Reset() {
+	*x = DeleteSnapshotResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSnapshotResponse) ProtoMessage() {}
+
+func (x *DeleteSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *DeleteSnapshotResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteSnapshotResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type AggregateInventoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Inventory     *InventoryProto        `protobuf:"bytes,1,opt,name=inventory,proto3" json:"inventory,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AggregateInventoryRequest) Reset() {
+	*x = AggregateInventoryRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AggregateInventoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateInventoryRequest) ProtoMessage() {}
+
+func (x *AggregateInventoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregateInventoryRequest.ProtoReflect.Descriptor instead.
+func (*AggregateInventoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *AggregateInventoryRequest) GetInventory() *InventoryProto {
+	if x != nil {
+		return x.Inventory
+	}
+	return nil
+}
+
+type AggregateInventoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AggregateInventoryResponse) Reset() {
+	*x = AggregateInventoryResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AggregateInventoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateInventoryResponse) ProtoMessage() {}
+
+func (x *AggregateInventoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregateInventoryResponse.ProtoReflect.Descriptor instead.
+func (*AggregateInventoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *AggregateInventoryResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AggregateInventoryResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type ListInventoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Module        string                 `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"` // Only modules whose path contains this (empty = all)
+	Host          string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`     // Only this host (empty = all)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInventoriesRequest) Reset() {
+	*x = ListInventoriesRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInventoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInventoriesRequest) ProtoMessage() {}
+
+func (x *ListInventoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInventoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListInventoriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListInventoriesRequest) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *ListInventoriesRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+type ListInventoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Inventories   []*InventoryProto      `protobuf:"bytes,1,rep,name=inventories,proto3" json:"inventories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInventoriesResponse) Reset() {
+	*x = ListInventoriesResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInventoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInventoriesResponse) ProtoMessage() {}
+
+func (x *ListInventoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInventoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListInventoriesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListInventoriesResponse) GetInventories() []*InventoryProto {
+	if x != nil {
+		return x.Inventories
+	}
+	return nil
+}
+
+type GetLatestVersionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Names         []string               `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`      // Installed module paths to look up
+	Refresh       bool                   `protobuf:"varint,2,opt,name=refresh,proto3" json:"refresh,omitempty"` // Bypass the daemon's version cache
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLatestVersionsRequest) Reset() {
+	*x = GetLatestVersionsRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLatestVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLatestVersionsRequest) ProtoMessage() {}
+
+func (x *GetLatestVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLatestVersionsRequest.ProtoReflect.Descriptor instead.
+func (*GetLatestVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetLatestVersionsRequest) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *GetLatestVersionsRequest) GetRefresh() bool {
+	if x != nil {
+		return x.Refresh
+	}
+	return false
+}
+
+type LatestVersionInfo struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	LatestVersion   string                 `protobuf:"bytes,2,opt,name=latest_version,json=latestVersion,proto3" json:"latest_version,omitempty"`
+	CheckedUnixNano int64                  `protobuf:"varint,3,opt,name=checked_unix_nano,json=checkedUnixNano,proto3" json:"checked_unix_nano,omitempty"` // When the proxy was last queried for this module
+	ErrorMessage    string                 `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Cached          bool                   `protobuf:"varint,5,opt,name=cached,proto3" json:"cached,omitempty"` // Served from the daemon's version cache
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
+func (x *LatestVersionInfo) Reset() {
+	*x = LatestVersionInfo{}
+	mi := &file_proto_v1_service_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LatestVersionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatestVersionInfo) ProtoMessage() {}
+
+func (x *LatestVersionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatestVersionInfo.ProtoReflect.Descriptor instead.
+func (*LatestVersionInfo) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *LatestVersionInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LatestVersionInfo) GetLatestVersion() string {
+	if x != nil {
+		return x.LatestVersion
+	}
+	return ""
+}
+
+func (x *LatestVersionInfo) GetCheckedUnixNano() int64 {
+	if x != nil {
+		return x.CheckedUnixNano
+	}
+	return 0
+}
+
+func (x *LatestVersionInfo) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *LatestVersionInfo) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
+type GetLatestVersionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Versions      []*LatestVersionInfo   `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLatestVersionsResponse) Reset() {
+	*x = GetLatestVersionsResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLatestVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLatestVersionsResponse) ProtoMessage() {}
+
+func (x *GetLatestVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLatestVersionsResponse.ProtoReflect.Descriptor instead.
+func (*GetLatestVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GetLatestVersionsResponse) GetVersions() []*LatestVersionInfo {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+type SearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // Maximum results (0 = server default)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *SearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`         // Package import path, installable with glix install
+	Synopsis      string                 `protobuf:"bytes,2,opt,name=synopsis,proto3" json:"synopsis,omitempty"` // One-line package description
+	Version       string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`   // Latest version known to the index
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_proto_v1_service_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *SearchResult) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *SearchResult) GetSynopsis() string {
+	if x != nil {
+		return x.Synopsis
+	}
+	return ""
+}
+
+func (x *SearchResult) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type SearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *SearchResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type TaskProto struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description      string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Schedule         string                 `protobuf:"bytes,3,opt,name=schedule,proto3" json:"schedule,omitempty"` // Cron expression
+	Enabled          bool                   `protobuf:"varint,4,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Builtin          bool                   `protobuf:"varint,5,opt,name=builtin,proto3" json:"builtin,omitempty"` // Built into the daemon, as opposed to a user-defined command
+	Running          bool                   `protobuf:"varint,6,opt,name=running,proto3" json:"running,omitempty"`
+	NextRunUnixNano  int64                  `protobuf:"varint,7,opt,name=next_run_unix_nano,json=nextRunUnixNano,proto3" json:"next_run_unix_nano,omitempty"` // 0 when disabled
+	LastRunUnixNano  int64                  `protobuf:"varint,8,opt,name=last_run_unix_nano,json=lastRunUnixNano,proto3" json:"last_run_unix_nano,omitempty"` // 0 when it has not run since the daemon started
+	LastDurationNano int64                  `protobuf:"varint,9,opt,name=last_duration_nano,json=lastDurationNano,proto3" json:"last_duration_nano,omitempty"`
+	LastSuccess      bool                   `protobuf:"varint,10,opt,name=last_success,json=lastSuccess,proto3" json:"last_success,omitempty"`
+	LastResult       string                 `protobuf:"bytes,11,opt,name=last_result,json=lastResult,proto3" json:"last_result,omitempty"` // Summary of the last run, or its error
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TaskProto) Reset() {
+	*x = TaskProto{}
+	mi := &file_proto_v1_service_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskProto) ProtoMessage() {}
+
+func (x *TaskProto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskProto.ProtoReflect.Descriptor instead.
+func (*TaskProto) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *TaskProto) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TaskProto) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TaskProto) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *TaskProto) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *TaskProto) GetBuiltin() bool {
+	if x != nil {
+		return x.Builtin
+	}
+	return false
+}
+
+func (x *TaskProto) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *TaskProto) GetNextRunUnixNano() int64 {
+	if x != nil {
+		return x.NextRunUnixNano
+	}
+	return 0
+}
+
+func (x *TaskProto) GetLastRunUnixNano() int64 {
+	if x != nil {
+		return x.LastRunUnixNano
+	}
+	return 0
+}
+
+func (x *TaskProto) GetLastDurationNano() int64 {
+	if x != nil {
+		return x.LastDurationNano
+	}
+	return 0
+}
+
+func (x *TaskProto) GetLastSuccess() bool {
+	if x != nil {
+		return x.LastSuccess
+	}
+	return false
+}
+
+func (x *TaskProto) GetLastResult() string {
+	if x != nil {
+		return x.LastResult
+	}
+	return ""
+}
+
+type ListTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*TaskProto           `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ListTasksResponse) GetTasks() []*TaskProto {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type RunTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunTaskRequest) Reset() {
+	*x = RunTaskRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunTaskRequest) ProtoMessage() {}
+
+func (x *RunTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunTaskRequest.ProtoReflect.Descriptor instead.
+func (*RunTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *RunTaskRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RunTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Result        string                 `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"` // Summary of the run
+	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunTaskResponse) Reset() {
+	*x = RunTaskResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunTaskResponse) ProtoMessage() {}
+
+func (x *RunTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunTaskResponse.ProtoReflect.Descriptor instead.
+func (*RunTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *RunTaskResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RunTaskResponse) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *RunTaskResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type StoreLibraryWatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Watch         *LibraryWatchProto     `protobuf:"bytes,1,opt,name=watch,proto3" json:"watch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreLibraryWatchRequest) Reset() {
+	*x = StoreLibraryWatchRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreLibraryWatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreLibraryWatchRequest) ProtoMessage() {}
+
+func (x *StoreLibraryWatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreLibraryWatchRequest.ProtoReflect.Descriptor instead.
+func (*StoreLibraryWatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *StoreLibraryWatchRequest) GetWatch() *LibraryWatchProto {
+	if x != nil {
+		return x.Watch
+	}
+	return nil
+}
+
+type StoreLibraryWatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreLibraryWatchResponse) Reset() {
+	*x = StoreLibraryWatchResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreLibraryWatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreLibraryWatchResponse) ProtoMessage() {}
+
+func (x *StoreLibraryWatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreLibraryWatchResponse.ProtoReflect.Descriptor instead.
+func (*StoreLibraryWatchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *StoreLibraryWatchResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StoreLibraryWatchResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type ListLibraryWatchesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Watches       []*LibraryWatchProto   `protobuf:"bytes,1,rep,name=watches,proto3" json:"watches,omitempty"` // Ordered by path
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLibraryWatchesResponse) Reset() {
+	*x = ListLibraryWatchesResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLibraryWatchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLibraryWatchesResponse) ProtoMessage() {}
+
+func (x *ListLibraryWatchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLibraryWatchesResponse.ProtoReflect.Descriptor instead.
+func (*ListLibraryWatchesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ListLibraryWatchesResponse) GetWatches() []*LibraryWatchProto {
+	if x != nil {
+		return x.Watches
+	}
+	return nil
+}
+
+func (x *ListLibraryWatchesResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type RemoveLibraryWatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveLibraryWatchRequest) Reset() {
+	*x = RemoveLibraryWatchRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveLibraryWatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveLibraryWatchRequest) ProtoMessage() {}
+
+func (x *RemoveLibraryWatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveLibraryWatchRequest.ProtoReflect.Descriptor instead.
+func (*RemoveLibraryWatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *RemoveLibraryWatchRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type RemoveLibraryWatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveLibraryWatchResponse) Reset() {
+	*x = RemoveLibraryWatchResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveLibraryWatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveLibraryWatchResponse) ProtoMessage() {}
+
+func (x *RemoveLibraryWatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveLibraryWatchResponse.ProtoReflect.Descriptor instead.
+func (*RemoveLibraryWatchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *RemoveLibraryWatchResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RemoveLibraryWatchResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type GetAutoUpdateConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        *AutoUpdateConfigProto `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAutoUpdateConfigResponse) Reset() {
+	*x = GetAutoUpdateConfigResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAutoUpdateConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAutoUpdateConfigResponse) ProtoMessage() {}
+
+func (x *GetAutoUpdateConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAutoUpdateConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetAutoUpdateConfigResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *GetAutoUpdateConfigResponse) GetConfig() *AutoUpdateConfigProto {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *GetAutoUpdateConfigResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// SetAutoUpdateConfigRequest changes the fields it sets and leaves the
+// others alone. Changes are applied one at a time, so concurrent clients
+// never overwrite each other's.
+type SetAutoUpdateConfigRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Enabled           *bool                  `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	IntervalNano      *int64                 `protobuf:"varint,2,opt,name=interval_nano,json=intervalNano,proto3,oneof" json:"interval_nano,omitempty"`
+	NotifyOnly        *bool                  `protobuf:"varint,3,opt,name=notify_only,json=notifyOnly,proto3,oneof" json:"notify_only,omitempty"`
+	RecordCheck       *AutoUpdateCheck       `protobuf:"bytes,4,opt,name=record_check,json=recordCheck,proto3" json:"record_check,omitempty"`                          // Counts a check that ran, when set
+	IncludePrerelease *bool                  `protobuf:"varint,5,opt,name=include_prerelease,json=includePrerelease,proto3,oneof" json:"include_prerelease,omitempty"` // Update every module to pre-releases, not only those installed with --pre
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SetAutoUpdateConfigRequest) Reset() {
+	*x = SetAutoUpdateConfigRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAutoUpdateConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAutoUpdateConfigRequest) ProtoMessage() {}
+
+func (x *SetAutoUpdateConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAutoUpdateConfigRequest.ProtoReflect.Descriptor instead.
+func (*SetAutoUpdateConfigRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *SetAutoUpdateConfigRequest) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *SetAutoUpdateConfigRequest) GetIntervalNano() int64 {
+	if x != nil && x.IntervalNano != nil {
+		return *x.IntervalNano
+	}
+	return 0
+}
+
+func (x *SetAutoUpdateConfigRequest) GetNotifyOnly() bool {
+	if x != nil && x.NotifyOnly != nil {
+		return *x.NotifyOnly
+	}
+	return false
+}
+
+func (x *SetAutoUpdateConfigRequest) GetRecordCheck() *AutoUpdateCheck {
+	if x != nil {
+		return x.RecordCheck
+	}
+	return nil
+}
+
+func (x *SetAutoUpdateConfigRequest) GetIncludePrerelease() bool {
+	if x != nil && x.IncludePrerelease != nil {
+		return *x.IncludePrerelease
+	}
+	return false
+}
+
+// AutoUpdateCheck is the outcome of an update check, for the statistics
+type AutoUpdateCheck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Updated       int64                  `protobuf:"varint,1,opt,name=updated,proto3" json:"updated,omitempty"` // Updates installed
+	Pending       int64                  `protobuf:"varint,2,opt,name=pending,proto3" json:"pending,omitempty"` // Updates found but not installed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AutoUpdateCheck) Reset() {
+	*x = AutoUpdateCheck{}
+	mi := &file_proto_v1_service_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AutoUpdateCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutoUpdateCheck) ProtoMessage() {}
+
+func (x *AutoUpdateCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutoUpdateCheck.ProtoReflect.Descriptor instead.
+func (*AutoUpdateCheck) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *AutoUpdateCheck) GetUpdated() int64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *AutoUpdateCheck) GetPending() int64 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+type SetAutoUpdateConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Config        *AutoUpdateConfigProto `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"` // The configuration after the change
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAutoUpdateConfigResponse) Reset() {
+	*x = SetAutoUpdateConfigResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAutoUpdateConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAutoUpdateConfigResponse) ProtoMessage() {}
+
+func (x *SetAutoUpdateConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAutoUpdateConfigResponse.ProtoReflect.Descriptor instead.
+func (*SetAutoUpdateConfigResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *SetAutoUpdateConfigResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetAutoUpdateConfigResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *SetAutoUpdateConfigResponse) GetConfig() *AutoUpdateConfigProto {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type CreateTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Scope         string                 `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`                     // read, install or admin
+	TtlNano       int64                  `protobuf:"varint,3,opt,name=ttl_nano,json=ttlNano,proto3" json:"ttl_nano,omitempty"` // Lifetime of the token, 0 for no expiry
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTokenRequest) Reset() {
+	*x = CreateTokenRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTokenRequest) ProtoMessage() {}
+
+func (x *CreateTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateTokenRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *CreateTokenRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTokenRequest) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *CreateTokenRequest) GetTtlNano() int64 {
+	if x != nil {
+		return x.TtlNano
+	}
+	return 0
+}
+
+type CreateTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         *TokenProto            `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"` // The token, never returned again
+	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTokenResponse) Reset() {
+	*x = CreateTokenResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTokenResponse) ProtoMessage() {}
+
+func (x *CreateTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTokenResponse.ProtoReflect.Descriptor instead.
+func (*CreateTokenResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *CreateTokenResponse) GetToken() *TokenProto {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *CreateTokenResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *CreateTokenResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type ListTokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tokens        []*TokenProto          `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"` // Ordered by name, without hashes
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTokensResponse) Reset() {
+	*x = ListTokensResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTokensResponse) ProtoMessage() {}
+
+func (x *ListTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTokensResponse.ProtoReflect.Descriptor instead.
+func (*ListTokensResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ListTokensResponse) GetTokens() []*TokenProto {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *ListTokensResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type RevokeTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeTokenRequest) Reset() {
+	*x = RevokeTokenRequest{}
+	mi := &file_proto_v1_service_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeTokenRequest) ProtoMessage() {}
+
+func (x *RevokeTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeTokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokeTokenRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *RevokeTokenRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RevokeTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeTokenResponse) Reset() {
+	*x = RevokeTokenResponse{}
+	mi := &file_proto_v1_service_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeTokenResponse) ProtoMessage() {}
+
+func (x *RevokeTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeTokenResponse.ProtoReflect.Descriptor instead.
+func (*RevokeTokenResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *RevokeTokenResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RevokeTokenResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type OutputLine struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Stream            OutputLine_Stream      `protobuf:"varint,1,opt,name=stream,proto3,enum=glix.v1.OutputLine_Stream" json:"stream,omitempty"`
+	Line              string                 `protobuf:"bytes,2,opt,name=line,proto3" json:"line,omitempty"`
+	TimestampUnixNano int64                  `protobuf:"varint,3,opt,name=timestamp_unix_nano,json=timestampUnixNano,proto3" json:"timestamp_unix_nano,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *OutputLine) Reset() {
+	*x = OutputLine{}
+	mi := &file_proto_v1_service_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutputLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutputLine) ProtoMessage() {}
+
+func (x *OutputLine) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_service_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutputLine.ProtoReflect.Descriptor instead.
+func (*OutputLine) Descriptor() ([]byte, []int) {
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *OutputLine) GetStream() OutputLine_Stream {
+	if x != nil {
+		return x.Stream
+	}
+	return OutputLine_STDOUT
+}
+
+func (x *OutputLine) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
+}
+
+func (x *OutputLine) GetTimestampUnixNano() int64 {
+	if x != nil {
+		return x.TimestampUnixNano
+	}
+	return 0
+}
+
+type ProgressUpdate struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Message           string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	PercentComplete   int32                  `protobuf:"varint,3,opt,name=percent_complete,json=percentComplete,proto3" json:"percent_complete,omitempty"` // 0-100, -1 if unknown
+	Phase             InstallPhase           `protobuf:"varint,4,opt,name=phase,proto3,enum=glix.v1.InstallPhase" json:"phase,omitempty"`
+	Label             string                 `protobuf:"bytes,5,opt,name=label,proto3" json:"label,omitempty"`                                                       // Fine-grained step label, e.g. "versions", "kubectl"; informational only
+	StartedAtUnixNano int64                  `protobuf:"varint,6,opt,name=started_at_unix_nano,json=startedAtUnixNano,proto3" json:"started_at_unix_nano,omitempty"` // When the current phase started
+	ElapsedNano       int64                  `protobuf:"varint,7,opt,name=elapsed_nano,json=elapsedNano,proto3" json:"elapsed_nano,omitempty"`                       // Time spent in the current phase so far
+	Step              int32                  `protobuf:"varint,8,opt,name=step,proto3" json:"step,omitempty"`                                                        // 1-based position of phase among the install phases
+	TotalSteps        int32                  `protobuf:"varint,9,opt,name=total_steps,json=totalSteps,proto3" json:"total_steps,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
 func (x *ProgressUpdate) Reset() {
 	*x = ProgressUpdate{}
-	mi := &file_proto_v1_service_proto_msgTypes[16]
+	mi := &file_proto_v1_service_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1028,7 +4745,7 @@ func (x *ProgressUpdate) String() string {
 func (*ProgressUpdate) ProtoMessage() {}
 
 func (x *ProgressUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_service_proto_msgTypes[16]
+	mi := &file_proto_v1_service_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1041,26 +4758,61 @@ func (x *ProgressUpdate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProgressUpdate.ProtoReflect.Descriptor instead.
 func (*ProgressUpdate) Descriptor() ([]byte, []int) {
-	return file_proto_v1_service_proto_rawDescGZIP(), []int{16}
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *ProgressUpdate) GetPhase() string {
+func (x *ProgressUpdate) GetMessage() string {
 	if x != nil {
-		return x.Phase
+		return x.Message
 	}
 	return ""
 }
 
-func (x *ProgressUpdate) GetMessage() string {
+func (x *ProgressUpdate) GetPercentComplete() int32 {
 	if x != nil {
-		return x.Message
+		return x.PercentComplete
+	}
+	return 0
+}
+
+func (x *ProgressUpdate) GetPhase() InstallPhase {
+	if x != nil {
+		return x.Phase
+	}
+	return InstallPhase_INSTALL_PHASE_UNSPECIFIED
+}
+
+func (x *ProgressUpdate) GetLabel() string {
+	if x != nil {
+		return x.Label
 	}
 	return ""
 }
 
-func (x *ProgressUpdate) GetPercentComplete() int32 {
+func (x *ProgressUpdate) GetStartedAtUnixNano() int64 {
 	if x != nil {
-		return x.PercentComplete
+		return x.StartedAtUnixNano
+	}
+	return 0
+}
+
+func (x *ProgressUpdate) GetElapsedNano() int64 {
+	if x != nil {
+		return x.ElapsedNano
+	}
+	return 0
+}
+
+func (x *ProgressUpdate) GetStep() int32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+func (x *ProgressUpdate) GetTotalSteps() int32 {
+	if x != nil {
+		return x.TotalSteps
 	}
 	return 0
 }
@@ -1079,7 +4831,7 @@ type InstallProgress struct {
 
 func (x *InstallProgress) Reset() {
 	*x = InstallProgress{}
-	mi := &file_proto_v1_service_proto_msgTypes[17]
+	mi := &file_proto_v1_service_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1091,7 +4843,7 @@ func (x *InstallProgress) String() string {
 func (*InstallProgress) ProtoMessage() {}
 
 func (x *InstallProgress) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_service_proto_msgTypes[17]
+	mi := &file_proto_v1_service_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1104,7 +4856,7 @@ func (x *InstallProgress) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstallProgress.ProtoReflect.Descriptor instead.
 func (*InstallProgress) Descriptor() ([]byte, []int) {
-	return file_proto_v1_service_proto_rawDescGZIP(), []int{17}
+	return file_proto_v1_service_proto_rawDescGZIP(), []int{78}
 }
 
 func (x *InstallProgress) GetUpdate() isInstallProgress_Update {
@@ -1172,17 +4924,24 @@ const file_proto_v1_service_proto_rawDesc = "" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12#\n" +
 	"\rdatabase_path\x18\x02 \x01(\tR\fdatabasePath\x12\x12\n" +
 	"\x04port\x18\x03 \x01(\x05R\x04port\x12!\n" +
-	"\fbind_address\x18\x04 \x01(\tR\vbindAddress\"\xcf\x01\n" +
+	"\fbind_address\x18\x04 \x01(\tR\vbindAddress\"\x92\x03\n" +
 	"\fServerStatus\x12\x18\n" +
 	"\arunning\x18\x01 \x01(\bR\arunning\x12\x1c\n" +
 	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12#\n" +
 	"\rdatabase_path\x18\x03 \x01(\tR\fdatabasePath\x12\x18\n" +
 	"\aaddress\x18\x04 \x01(\tR\aaddress\x12%\n" +
 	"\x0euptime_seconds\x18\x05 \x01(\x03R\ruptimeSeconds\x12!\n" +
-	"\fmodule_count\x18\x06 \x01(\x03R\vmoduleCount\"\x84\x01\n" +
+	"\fmodule_count\x18\x06 \x01(\x03R\vmoduleCount\x12-\n" +
+	"\x12autoupdate_enabled\x18\a \x01(\bR\x11autoupdateEnabled\x120\n" +
+	"\x14pending_update_count\x18\b \x01(\x03R\x12pendingUpdateCount\x12D\n" +
+	"\x1flast_autoupdate_check_unix_nano\x18\t \x01(\x03R\x1blastAutoupdateCheckUnixNano\x12\x1a\n" +
+	"\bhostname\x18\n" +
+	" \x01(\tR\bhostname\"\xc1\x01\n" +
 	"\x12StoreModuleRequest\x12-\n" +
 	"\x06module\x18\x01 \x01(\v2\x15.database.ModuleProtoR\x06module\x12?\n" +
-	"\fdependencies\x18\x02 \x01(\v2\x1b.database.DependenciesProtoR\fdependencies\"T\n" +
+	"\fdependencies\x18\x02 \x01(\v2\x1b.database.DependenciesProtoR\fdependencies\x12\x18\n" +
+	"\arefresh\x18\x03 \x01(\bR\arefresh\x12!\n" +
+	"\frenamed_from\x18\x04 \x01(\tR\vrenamedFrom\"T\n" +
 	"\x13StoreModuleResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
 	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\x86\x01\n" +
@@ -1202,16 +4961,26 @@ const file_proto_v1_service_proto_rawDesc = "" +
 	"\aversion\x18\x02 \x01(\tR\aversion\"O\n" +
 	"\x0eRemoveResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
-	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"c\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\x7f\n" +
 	"\x12ListModulesRequest\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
 	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x1f\n" +
 	"\vname_filter\x18\x03 \x01(\tR\n" +
-	"nameFilter\"g\n" +
+	"nameFilter\x12\x1a\n" +
+	"\bsecurity\x18\x04 \x01(\bR\bsecurity\"\x9d\x01\n" +
 	"\x13ListModulesResponse\x12/\n" +
 	"\amodules\x18\x01 \x03(\v2\x15.database.ModuleProtoR\amodules\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x03R\n" +
-	"totalCount\"@\n" +
+	"totalCount\x124\n" +
+	"\bsecurity\x18\x03 \x03(\v2\x18.glix.v1.SecuritySummaryR\bsecurity\"\x89\x02\n" +
+	"\x0fSecuritySummary\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\ascanned\x18\x02 \x01(\bR\ascanned\x12(\n" +
+	"\x0fvulnerabilities\x18\x03 \x01(\x05R\x0fvulnerabilities\x125\n" +
+	"\x16called_vulnerabilities\x18\x04 \x01(\x05R\x15calledVulnerabilities\x12\"\n" +
+	"\fdependencies\x18\x05 \x01(\x05R\fdependencies\x12)\n" +
+	"\x10flagged_licenses\x18\x06 \x01(\x05R\x0fflaggedLicenses\x12\x18\n" +
+	"\aflagged\x18\a \x03(\tR\aflagged\"@\n" +
 	"\x10GetModuleRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\tR\aversion\"X\n" +
@@ -1231,7 +5000,234 @@ const file_proto_v1_service_proto_rawDesc = "" +
 	"\n" +
 	"new_module\x18\x02 \x01(\v2\x15.database.ModuleProtoR\tnewModule\x12\x18\n" +
 	"\asuccess\x18\x03 \x01(\bR\asuccess\x12#\n" +
-	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\"\xa6\x01\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\"E\n" +
+	"\x15MarkBadVersionRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\"\x86\x01\n" +
+	"\x16MarkBadVersionResponse\x12-\n" +
+	"\x06module\x18\x01 \x01(\v2\x15.database.ModuleProtoR\x06module\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\"\\\n" +
+	"\x0fSetAliasRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05alias\x18\x02 \x01(\tR\x05alias\x12\x1f\n" +
+	"\vbinary_path\x18\x03 \x01(\tR\n" +
+	"binaryPath\"\x80\x01\n" +
+	"\x10SetAliasResponse\x12-\n" +
+	"\x06module\x18\x01 \x01(\v2\x15.database.ModuleProtoR\x06module\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\"Y\n" +
+	"\x15VerifyBinariesRequest\x12\x14\n" +
+	"\x05names\x18\x01 \x03(\tR\x05names\x12\x16\n" +
+	"\x06accept\x18\x02 \x01(\bR\x06accept\x12\x12\n" +
+	"\x04sums\x18\x03 \x01(\bR\x04sums\"\xf0\x02\n" +
+	"\x12BinaryVerification\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1f\n" +
+	"\vbinary_path\x18\x03 \x01(\tR\n" +
+	"binaryPath\x126\n" +
+	"\tintegrity\x18\x04 \x01(\x0e2\x18.glix.v1.BinaryIntegrityR\tintegrity\x12#\n" +
+	"\rrecorded_hash\x18\x05 \x01(\tR\frecordedHash\x12\x1f\n" +
+	"\vactual_hash\x18\x06 \x01(\tR\n" +
+	"actualHash\x12\x16\n" +
+	"\x06detail\x18\a \x01(\tR\x06detail\x12\x1a\n" +
+	"\baccepted\x18\b \x01(\bR\baccepted\x12:\n" +
+	"\rsum_integrity\x18\t \x01(\x0e2\x15.glix.v1.SumIntegrityR\fsumIntegrity\x12\x1d\n" +
+	"\n" +
+	"sum_detail\x18\n" +
+	" \x01(\tR\tsumDetail\"t\n" +
+	"\x16VerifyBinariesResponse\x125\n" +
+	"\aresults\x18\x01 \x03(\v2\x1b.glix.v1.BinaryVerificationR\aresults\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\".\n" +
+	"\x18GetInstallHistoryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"s\n" +
+	"\x19GetInstallHistoryResponse\x121\n" +
+	"\binstalls\x18\x01 \x03(\v2\x15.database.ModuleProtoR\binstalls\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"@\n" +
+	"\x12RecordEventRequest\x12*\n" +
+	"\x05event\x18\x01 \x01(\v2\x14.database.EventProtoR\x05event\"T\n" +
+	"\x13RecordEventResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"=\n" +
+	"\x11GetHistoryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"g\n" +
+	"\x12GetHistoryResponse\x12,\n" +
+	"\x06events\x18\x01 \x03(\v2\x14.database.EventProtoR\x06events\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"K\n" +
+	"\x16StoreVulnReportRequest\x121\n" +
+	"\x06report\x18\x01 \x01(\v2\x19.database.VulnReportProtoR\x06report\"X\n" +
+	"\x17StoreVulnReportResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\",\n" +
+	"\x16GetVersionCacheRequest\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\"\x87\x01\n" +
+	"\x17GetVersionCacheResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x121\n" +
+	"\x05entry\x18\x02 \x01(\v2\x1b.database.VersionCacheProtoR\x05entry\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\"M\n" +
+	"\x18StoreVersionCacheRequest\x121\n" +
+	"\x05entry\x18\x01 \x01(\v2\x1b.database.VersionCacheProtoR\x05entry\"Z\n" +
+	"\x19StoreVersionCacheResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\",\n" +
+	"\x16ListVulnReportsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"s\n" +
+	"\x17ListVulnReportsResponse\x123\n" +
+	"\areports\x18\x01 \x03(\v2\x19.database.VulnReportProtoR\areports\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"'\n" +
+	"\x0fGetStatsRequest\x12\x14\n" +
+	"\x05weeks\x18\x01 \x01(\x05R\x05weeks\"\xaa\x01\n" +
+	"\vWeeklyStats\x12/\n" +
+	"\x14week_start_unix_nano\x18\x01 \x01(\x03R\x11weekStartUnixNano\x12\x1a\n" +
+	"\binstalls\x18\x02 \x01(\x05R\binstalls\x12\x18\n" +
+	"\aupdates\x18\x03 \x01(\x05R\aupdates\x12\x18\n" +
+	"\aremoves\x18\x04 \x01(\x05R\aremoves\x12\x1a\n" +
+	"\bfailures\x18\x05 \x01(\x05R\bfailures\"\x8d\x04\n" +
+	"\n" +
+	"BuildStats\x12\x16\n" +
+	"\x06builds\x18\x01 \x01(\x05R\x06builds\x12\x1d\n" +
+	"\n" +
+	"cache_hits\x18\x02 \x01(\x05R\tcacheHits\x120\n" +
+	"\x14median_duration_nano\x18\x03 \x01(\x03R\x12medianDurationNano\x12*\n" +
+	"\x11p95_duration_nano\x18\x04 \x01(\x03R\x0fp95DurationNano\x129\n" +
+	"\x19median_cold_duration_nano\x18\x05 \x01(\x03R\x16medianColdDurationNano\x12=\n" +
+	"\x1bmedian_cached_duration_nano\x18\x06 \x01(\x03R\x18medianCachedDurationNano\x12,\n" +
+	"\x12median_binary_size\x18\a \x01(\x03R\x10medianBinarySize\x12*\n" +
+	"\x11total_binary_size\x18\b \x01(\x03R\x0ftotalBinarySize\x12\x18\n" +
+	"\aslowest\x18\t \x01(\tR\aslowest\x122\n" +
+	"\x15slowest_duration_nano\x18\n" +
+	" \x01(\x03R\x13slowestDurationNano\x12\x18\n" +
+	"\alargest\x18\v \x01(\tR\alargest\x12.\n" +
+	"\x13largest_binary_size\x18\f \x01(\x03R\x11largestBinarySize\"\x90\x01\n" +
+	"\x10GetStatsResponse\x12*\n" +
+	"\x05weeks\x18\x01 \x03(\v2\x14.glix.v1.WeeklyStatsR\x05weeks\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\x12+\n" +
+	"\x06builds\x18\x03 \x01(\v2\x13.glix.v1.BuildStatsR\x06builds\"k\n" +
+	"\x15CreateSnapshotRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1c\n" +
+	"\toverwrite\x18\x03 \x01(\bR\toverwrite\"\x8c\x01\n" +
+	"\x16CreateSnapshotResponse\x123\n" +
+	"\bsnapshot\x18\x01 \x01(\v2\x17.database.SnapshotProtoR\bsnapshot\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\"(\n" +
+	"\x12GetSnapshotRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"`\n" +
+	"\x13GetSnapshotResponse\x123\n" +
+	"\bsnapshot\x18\x01 \x01(\v2\x17.database.SnapshotProtoR\bsnapshot\x12\x14\n" +
+	"\x05found\x18\x02 \x01(\bR\x05found\"N\n" +
+	"\x15ListSnapshotsResponse\x125\n" +
+	"\tsnapshots\x18\x01 \x03(\v2\x17.database.SnapshotProtoR\tsnapshots\"+\n" +
+	"\x15DeleteSnapshotRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"W\n" +
+	"\x16DeleteSnapshotResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"S\n" +
+	"\x19AggregateInventoryRequest\x126\n" +
+	"\tinventory\x18\x01 \x01(\v2\x18.database.InventoryProtoR\tinventory\"[\n" +
+	"\x1aAggregateInventoryResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"D\n" +
+	"\x16ListInventoriesRequest\x12\x16\n" +
+	"\x06module\x18\x01 \x01(\tR\x06module\x12\x12\n" +
+	"\x04host\x18\x02 \x01(\tR\x04host\"U\n" +
+	"\x17ListInventoriesResponse\x12:\n" +
+	"\vinventories\x18\x01 \x03(\v2\x18.database.InventoryProtoR\vinventories\"J\n" +
+	"\x18GetLatestVersionsRequest\x12\x14\n" +
+	"\x05names\x18\x01 \x03(\tR\x05names\x12\x18\n" +
+	"\arefresh\x18\x02 \x01(\bR\arefresh\"\xb7\x01\n" +
+	"\x11LatestVersionInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
+	"\x0elatest_version\x18\x02 \x01(\tR\rlatestVersion\x12*\n" +
+	"\x11checked_unix_nano\x18\x03 \x01(\x03R\x0fcheckedUnixNano\x12#\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\x12\x16\n" +
+	"\x06cached\x18\x05 \x01(\bR\x06cached\"S\n" +
+	"\x19GetLatestVersionsResponse\x126\n" +
+	"\bversions\x18\x01 \x03(\v2\x1a.glix.v1.LatestVersionInfoR\bversions\";\n" +
+	"\rSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"X\n" +
+	"\fSearchResult\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1a\n" +
+	"\bsynopsis\x18\x02 \x01(\tR\bsynopsis\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\"f\n" +
+	"\x0eSearchResponse\x12/\n" +
+	"\aresults\x18\x01 \x03(\v2\x15.glix.v1.SearchResultR\aresults\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\xf7\x02\n" +
+	"\tTaskProto\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bschedule\x18\x03 \x01(\tR\bschedule\x12\x18\n" +
+	"\aenabled\x18\x04 \x01(\bR\aenabled\x12\x18\n" +
+	"\abuiltin\x18\x05 \x01(\bR\abuiltin\x12\x18\n" +
+	"\arunning\x18\x06 \x01(\bR\arunning\x12+\n" +
+	"\x12next_run_unix_nano\x18\a \x01(\x03R\x0fnextRunUnixNano\x12+\n" +
+	"\x12last_run_unix_nano\x18\b \x01(\x03R\x0flastRunUnixNano\x12,\n" +
+	"\x12last_duration_nano\x18\t \x01(\x03R\x10lastDurationNano\x12!\n" +
+	"\flast_success\x18\n" +
+	" \x01(\bR\vlastSuccess\x12\x1f\n" +
+	"\vlast_result\x18\v \x01(\tR\n" +
+	"lastResult\"=\n" +
+	"\x11ListTasksResponse\x12(\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x12.glix.v1.TaskProtoR\x05tasks\"$\n" +
+	"\x0eRunTaskRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"h\n" +
+	"\x0fRunTaskResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06result\x18\x02 \x01(\tR\x06result\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\"M\n" +
+	"\x18StoreLibraryWatchRequest\x121\n" +
+	"\x05watch\x18\x01 \x01(\v2\x1b.database.LibraryWatchProtoR\x05watch\"Z\n" +
+	"\x19StoreLibraryWatchResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"x\n" +
+	"\x1aListLibraryWatchesResponse\x125\n" +
+	"\awatches\x18\x01 \x03(\v2\x1b.database.LibraryWatchProtoR\awatches\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"/\n" +
+	"\x19RemoveLibraryWatchRequest\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\"[\n" +
+	"\x1aRemoveLibraryWatchResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"{\n" +
+	"\x1bGetAutoUpdateConfigResponse\x127\n" +
+	"\x06config\x18\x01 \x01(\v2\x1f.database.AutoUpdateConfigProtoR\x06config\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\xc1\x02\n" +
+	"\x1aSetAutoUpdateConfigRequest\x12\x1d\n" +
+	"\aenabled\x18\x01 \x01(\bH\x00R\aenabled\x88\x01\x01\x12(\n" +
+	"\rinterval_nano\x18\x02 \x01(\x03H\x01R\fintervalNano\x88\x01\x01\x12$\n" +
+	"\vnotify_only\x18\x03 \x01(\bH\x02R\n" +
+	"notifyOnly\x88\x01\x01\x12;\n" +
+	"\frecord_check\x18\x04 \x01(\v2\x18.glix.v1.AutoUpdateCheckR\vrecordCheck\x122\n" +
+	"\x12include_prerelease\x18\x05 \x01(\bH\x03R\x11includePrerelease\x88\x01\x01B\n" +
+	"\n" +
+	"\b_enabledB\x10\n" +
+	"\x0e_interval_nanoB\x0e\n" +
+	"\f_notify_onlyB\x15\n" +
+	"\x13_include_prerelease\"E\n" +
+	"\x0fAutoUpdateCheck\x12\x18\n" +
+	"\aupdated\x18\x01 \x01(\x03R\aupdated\x12\x18\n" +
+	"\apending\x18\x02 \x01(\x03R\apending\"\x95\x01\n" +
+	"\x1bSetAutoUpdateConfigResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\x127\n" +
+	"\x06config\x18\x03 \x01(\v2\x1f.database.AutoUpdateConfigProtoR\x06config\"Y\n" +
+	"\x12CreateTokenRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05scope\x18\x02 \x01(\tR\x05scope\x12\x19\n" +
+	"\bttl_nano\x18\x03 \x01(\x03R\attlNano\"|\n" +
+	"\x13CreateTokenResponse\x12*\n" +
+	"\x05token\x18\x01 \x01(\v2\x14.database.TokenProtoR\x05token\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\"g\n" +
+	"\x12ListTokensResponse\x12,\n" +
+	"\x06tokens\x18\x01 \x03(\v2\x14.database.TokenProtoR\x06tokens\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"(\n" +
+	"\x12RevokeTokenRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"T\n" +
+	"\x13RevokeTokenResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\xa6\x01\n" +
 	"\n" +
 	"OutputLine\x122\n" +
 	"\x06stream\x18\x01 \x01(\x0e2\x1a.glix.v1.OutputLine.StreamR\x06stream\x12\x12\n" +
@@ -1241,22 +5237,79 @@ const file_proto_v1_service_proto_rawDesc = "" +
 	"\n" +
 	"\x06STDOUT\x10\x00\x12\n" +
 	"\n" +
-	"\x06STDERR\x10\x01\"k\n" +
-	"\x0eProgressUpdate\x12\x14\n" +
-	"\x05phase\x18\x01 \x01(\tR\x05phase\x12\x18\n" +
+	"\x06STDERR\x10\x01\"\xa7\x02\n" +
+	"\x0eProgressUpdate\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12)\n" +
-	"\x10percent_complete\x18\x03 \x01(\x05R\x0fpercentComplete\"\xb5\x01\n" +
+	"\x10percent_complete\x18\x03 \x01(\x05R\x0fpercentComplete\x12+\n" +
+	"\x05phase\x18\x04 \x01(\x0e2\x15.glix.v1.InstallPhaseR\x05phase\x12\x14\n" +
+	"\x05label\x18\x05 \x01(\tR\x05label\x12/\n" +
+	"\x14started_at_unix_nano\x18\x06 \x01(\x03R\x11startedAtUnixNano\x12!\n" +
+	"\felapsed_nano\x18\a \x01(\x03R\velapsedNano\x12\x12\n" +
+	"\x04step\x18\b \x01(\x05R\x04step\x12\x1f\n" +
+	"\vtotal_steps\x18\t \x01(\x05R\n" +
+	"totalStepsJ\x04\b\x01\x10\x02\"\xb5\x01\n" +
 	"\x0fInstallProgress\x12-\n" +
 	"\x06output\x18\x01 \x01(\v2\x13.glix.v1.OutputLineH\x00R\x06output\x125\n" +
 	"\bprogress\x18\x02 \x01(\v2\x17.glix.v1.ProgressUpdateH\x00R\bprogress\x122\n" +
 	"\x06result\x18\x03 \x01(\v2\x18.glix.v1.InstallResponseH\x00R\x06resultB\b\n" +
-	"\x06update2\xe4\x03\n" +
+	"\x06update*\xc9\x01\n" +
+	"\x0fBinaryIntegrity\x12 \n" +
+	"\x1cBINARY_INTEGRITY_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13BINARY_INTEGRITY_OK\x10\x01\x12\x1d\n" +
+	"\x19BINARY_INTEGRITY_MODIFIED\x10\x02\x12\x1d\n" +
+	"\x19BINARY_INTEGRITY_REPLACED\x10\x03\x12\x1c\n" +
+	"\x18BINARY_INTEGRITY_MISSING\x10\x04\x12\x1f\n" +
+	"\x1bBINARY_INTEGRITY_UNRECORDED\x10\x05*\x9a\x01\n" +
+	"\fSumIntegrity\x12\x1d\n" +
+	"\x19SUM_INTEGRITY_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10SUM_INTEGRITY_OK\x10\x01\x12\x1a\n" +
+	"\x16SUM_INTEGRITY_MISMATCH\x10\x02\x12\x1c\n" +
+	"\x18SUM_INTEGRITY_UNRECORDED\x10\x03\x12\x1b\n" +
+	"\x17SUM_INTEGRITY_UNCHECKED\x10\x04*\xb0\x01\n" +
+	"\fInstallPhase\x12\x1d\n" +
+	"\x19INSTALL_PHASE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15INSTALL_PHASE_RESOLVE\x10\x01\x12\x18\n" +
+	"\x14INSTALL_PHASE_POLICY\x10\x02\x12\x17\n" +
+	"\x13INSTALL_PHASE_BUILD\x10\x03\x12\x17\n" +
+	"\x13INSTALL_PHASE_STORE\x10\x04\x12\x1a\n" +
+	"\x16INSTALL_PHASE_COMPLETE\x10\x052\xf7\x15\n" +
 	"\vGlixService\x12H\n" +
 	"\vStoreModule\x12\x1b.glix.v1.StoreModuleRequest\x1a\x1c.glix.v1.StoreModuleResponse\x12H\n" +
 	"\vListModules\x12\x1b.glix.v1.ListModulesRequest\x1a\x1c.glix.v1.ListModulesResponse\x12B\n" +
 	"\tGetModule\x12\x19.glix.v1.GetModuleRequest\x1a\x1a.glix.v1.GetModuleResponse\x12N\n" +
-	"\x0fGetDependencies\x12\x19.glix.v1.GetModuleRequest\x1a .glix.v1.GetDependenciesResponse\x129\n" +
-	"\x06Remove\x12\x16.glix.v1.RemoveRequest\x1a\x17.glix.v1.RemoveResponse\x12:\n" +
+	"\x0fGetDependencies\x12\x19.glix.v1.GetModuleRequest\x1a .glix.v1.GetDependenciesResponse\x12Z\n" +
+	"\x11GetLatestVersions\x12!.glix.v1.GetLatestVersionsRequest\x1a\".glix.v1.GetLatestVersionsResponse\x129\n" +
+	"\x06Search\x12\x16.glix.v1.SearchRequest\x1a\x17.glix.v1.SearchResponse\x129\n" +
+	"\x06Remove\x12\x16.glix.v1.RemoveRequest\x1a\x17.glix.v1.RemoveResponse\x12Q\n" +
+	"\x0eMarkBadVersion\x12\x1e.glix.v1.MarkBadVersionRequest\x1a\x1f.glix.v1.MarkBadVersionResponse\x12?\n" +
+	"\bSetAlias\x12\x18.glix.v1.SetAliasRequest\x1a\x19.glix.v1.SetAliasResponse\x12Q\n" +
+	"\x0eVerifyBinaries\x12\x1e.glix.v1.VerifyBinariesRequest\x1a\x1f.glix.v1.VerifyBinariesResponse\x12Z\n" +
+	"\x11GetInstallHistory\x12!.glix.v1.GetInstallHistoryRequest\x1a\".glix.v1.GetInstallHistoryResponse\x12H\n" +
+	"\vRecordEvent\x12\x1b.glix.v1.RecordEventRequest\x1a\x1c.glix.v1.RecordEventResponse\x12E\n" +
+	"\n" +
+	"GetHistory\x12\x1a.glix.v1.GetHistoryRequest\x1a\x1b.glix.v1.GetHistoryResponse\x12T\n" +
+	"\x0fStoreVulnReport\x12\x1f.glix.v1.StoreVulnReportRequest\x1a .glix.v1.StoreVulnReportResponse\x12T\n" +
+	"\x0fListVulnReports\x12\x1f.glix.v1.ListVulnReportsRequest\x1a .glix.v1.ListVulnReportsResponse\x12T\n" +
+	"\x0fGetVersionCache\x12\x1f.glix.v1.GetVersionCacheRequest\x1a .glix.v1.GetVersionCacheResponse\x12Z\n" +
+	"\x11StoreVersionCache\x12!.glix.v1.StoreVersionCacheRequest\x1a\".glix.v1.StoreVersionCacheResponse\x12Z\n" +
+	"\x11StoreLibraryWatch\x12!.glix.v1.StoreLibraryWatchRequest\x1a\".glix.v1.StoreLibraryWatchResponse\x12Q\n" +
+	"\x12ListLibraryWatches\x12\x16.google.protobuf.Empty\x1a#.glix.v1.ListLibraryWatchesResponse\x12]\n" +
+	"\x12RemoveLibraryWatch\x12\".glix.v1.RemoveLibraryWatchRequest\x1a#.glix.v1.RemoveLibraryWatchResponse\x12S\n" +
+	"\x13GetAutoUpdateConfig\x12\x16.google.protobuf.Empty\x1a$.glix.v1.GetAutoUpdateConfigResponse\x12`\n" +
+	"\x13SetAutoUpdateConfig\x12#.glix.v1.SetAutoUpdateConfigRequest\x1a$.glix.v1.SetAutoUpdateConfigResponse\x12?\n" +
+	"\bGetStats\x12\x18.glix.v1.GetStatsRequest\x1a\x19.glix.v1.GetStatsResponse\x12Q\n" +
+	"\x0eCreateSnapshot\x12\x1e.glix.v1.CreateSnapshotRequest\x1a\x1f.glix.v1.CreateSnapshotResponse\x12H\n" +
+	"\vGetSnapshot\x12\x1b.glix.v1.GetSnapshotRequest\x1a\x1c.glix.v1.GetSnapshotResponse\x12G\n" +
+	"\rListSnapshots\x12\x16.google.protobuf.Empty\x1a\x1e.glix.v1.ListSnapshotsResponse\x12Q\n" +
+	"\x0eDeleteSnapshot\x12\x1e.glix.v1.DeleteSnapshotRequest\x1a\x1f.glix.v1.DeleteSnapshotResponse\x12]\n" +
+	"\x12AggregateInventory\x12\".glix.v1.AggregateInventoryRequest\x1a#.glix.v1.AggregateInventoryResponse\x12T\n" +
+	"\x0fListInventories\x12\x1f.glix.v1.ListInventoriesRequest\x1a .glix.v1.ListInventoriesResponse\x12?\n" +
+	"\tListTasks\x12\x16.google.protobuf.Empty\x1a\x1a.glix.v1.ListTasksResponse\x12<\n" +
+	"\aRunTask\x12\x17.glix.v1.RunTaskRequest\x1a\x18.glix.v1.RunTaskResponse\x12H\n" +
+	"\vCreateToken\x12\x1b.glix.v1.CreateTokenRequest\x1a\x1c.glix.v1.CreateTokenResponse\x12A\n" +
+	"\n" +
+	"ListTokens\x12\x16.google.protobuf.Empty\x1a\x1b.glix.v1.ListTokensResponse\x12H\n" +
+	"\vRevokeToken\x12\x1b.glix.v1.RevokeTokenRequest\x1a\x1c.glix.v1.RevokeTokenResponse\x12:\n" +
 	"\tGetStatus\x12\x16.google.protobuf.Empty\x1a\x15.glix.v1.ServerStatus\x126\n" +
 	"\x04Ping\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.EmptyB$Z\"github.com/inovacc/glix/pkg/api/v1b\x06proto3"
 
@@ -1272,64 +5325,225 @@ func file_proto_v1_service_proto_rawDescGZIP() []byte {
 	return file_proto_v1_service_proto_rawDescData
 }
 
-var file_proto_v1_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_proto_v1_service_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_proto_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 79)
 var file_proto_v1_service_proto_goTypes = []any{
-	(OutputLine_Stream)(0),          // 0: glix.v1.OutputLine.Stream
-	(*ServerConfig)(nil),            // 1: glix.v1.ServerConfig
-	(*ServerStatus)(nil),            // 2: glix.v1.ServerStatus
-	(*StoreModuleRequest)(nil),      // 3: glix.v1.StoreModuleRequest
-	(*StoreModuleResponse)(nil),     // 4: glix.v1.StoreModuleResponse
-	(*InstallRequest)(nil),          // 5: glix.v1.InstallRequest
-	(*InstallResponse)(nil),         // 6: glix.v1.InstallResponse
-	(*RemoveRequest)(nil),           // 7: glix.v1.RemoveRequest
-	(*RemoveResponse)(nil),          // 8: glix.v1.RemoveResponse
-	(*ListModulesRequest)(nil),      // 9: glix.v1.ListModulesRequest
-	(*ListModulesResponse)(nil),     // 10: glix.v1.ListModulesResponse
-	(*GetModuleRequest)(nil),        // 11: glix.v1.GetModuleRequest
-	(*GetModuleResponse)(nil),       // 12: glix.v1.GetModuleResponse
-	(*GetDependenciesResponse)(nil), // 13: glix.v1.GetDependenciesResponse
-	(*UpdateRequest)(nil),           // 14: glix.v1.UpdateRequest
-	(*UpdateResponse)(nil),          // 15: glix.v1.UpdateResponse
-	(*OutputLine)(nil),              // 16: glix.v1.OutputLine
-	(*ProgressUpdate)(nil),          // 17: glix.v1.ProgressUpdate
-	(*InstallProgress)(nil),         // 18: glix.v1.InstallProgress
-	(*ModuleProto)(nil),             // 19: database.ModuleProto
-	(*DependenciesProto)(nil),       // 20: database.DependenciesProto
-	(*emptypb.Empty)(nil),           // 21: google.protobuf.Empty
+	(BinaryIntegrity)(0),                // 0: glix.v1.BinaryIntegrity
+	(SumIntegrity)(0),                   // 1: glix.v1.SumIntegrity
+	(InstallPhase)(0),                   // 2: glix.v1.InstallPhase
+	(OutputLine_Stream)(0),              // 3: glix.v1.OutputLine.Stream
+	(*ServerConfig)(nil),                // 4: glix.v1.ServerConfig
+	(*ServerStatus)(nil),                // 5: glix.v1.ServerStatus
+	(*StoreModuleRequest)(nil),          // 6: glix.v1.StoreModuleRequest
+	(*StoreModuleResponse)(nil),         // 7: glix.v1.StoreModuleResponse
+	(*InstallRequest)(nil),              // 8: glix.v1.InstallRequest
+	(*InstallResponse)(nil),             // 9: glix.v1.InstallResponse
+	(*RemoveRequest)(nil),               // 10: glix.v1.RemoveRequest
+	(*RemoveResponse)(nil),              // 11: glix.v1.RemoveResponse
+	(*ListModulesRequest)(nil),          // 12: glix.v1.ListModulesRequest
+	(*ListModulesResponse)(nil),         // 13: glix.v1.ListModulesResponse
+	(*SecuritySummary)(nil),             // 14: glix.v1.SecuritySummary
+	(*GetModuleRequest)(nil),            // 15: glix.v1.GetModuleRequest
+	(*GetModuleResponse)(nil),           // 16: glix.v1.GetModuleResponse
+	(*GetDependenciesResponse)(nil),     // 17: glix.v1.GetDependenciesResponse
+	(*UpdateRequest)(nil),               // 18: glix.v1.UpdateRequest
+	(*UpdateResponse)(nil),              // 19: glix.v1.UpdateResponse
+	(*MarkBadVersionRequest)(nil),       // 20: glix.v1.MarkBadVersionRequest
+	(*MarkBadVersionResponse)(nil),      // 21: glix.v1.MarkBadVersionResponse
+	(*SetAliasRequest)(nil),             // 22: glix.v1.SetAliasRequest
+	(*SetAliasResponse)(nil),            // 23: glix.v1.SetAliasResponse
+	(*VerifyBinariesRequest)(nil),       // 24: glix.v1.VerifyBinariesRequest
+	(*BinaryVerification)(nil),          // 25: glix.v1.BinaryVerification
+	(*VerifyBinariesResponse)(nil),      // 26: glix.v1.VerifyBinariesResponse
+	(*GetInstallHistoryRequest)(nil),    // 27: glix.v1.GetInstallHistoryRequest
+	(*GetInstallHistoryResponse)(nil),   // 28: glix.v1.GetInstallHistoryResponse
+	(*RecordEventRequest)(nil),          // 29: glix.v1.RecordEventRequest
+	(*RecordEventResponse)(nil),         // 30: glix.v1.RecordEventResponse
+	(*GetHistoryRequest)(nil),           // 31: glix.v1.GetHistoryRequest
+	(*GetHistoryResponse)(nil),          // 32: glix.v1.GetHistoryResponse
+	(*StoreVulnReportRequest)(nil),      // 33: glix.v1.StoreVulnReportRequest
+	(*StoreVulnReportResponse)(nil),     // 34: glix.v1.StoreVulnReportResponse
+	(*GetVersionCacheRequest)(nil),      // 35: glix.v1.GetVersionCacheRequest
+	(*GetVersionCacheResponse)(nil),     // 36: glix.v1.GetVersionCacheResponse
+	(*StoreVersionCacheRequest)(nil),    // 37: glix.v1.StoreVersionCacheRequest
+	(*StoreVersionCacheResponse)(nil),   // 38: glix.v1.StoreVersionCacheResponse
+	(*ListVulnReportsRequest)(nil),      // 39: glix.v1.ListVulnReportsRequest
+	(*ListVulnReportsResponse)(nil),     // 40: glix.v1.ListVulnReportsResponse
+	(*GetStatsRequest)(nil),             // 41: glix.v1.GetStatsRequest
+	(*WeeklyStats)(nil),                 // 42: glix.v1.WeeklyStats
+	(*BuildStats)(nil),                  // 43: glix.v1.BuildStats
+	(*GetStatsResponse)(nil),            // 44: glix.v1.GetStatsResponse
+	(*CreateSnapshotRequest)(nil),       // 45: glix.v1.CreateSnapshotRequest
+	(*CreateSnapshotResponse)(nil),      // 46: glix.v1.CreateSnapshotResponse
+	(*GetSnapshotRequest)(nil),          // 47: glix.v1.GetSnapshotRequest
+	(*GetSnapshotResponse)(nil),         // 48: glix.v1.GetSnapshotResponse
+	(*ListSnapshotsResponse)(nil),       // 49: glix.v1.ListSnapshotsResponse
+	(*DeleteSnapshotRequest)(nil),       // 50: glix.v1.DeleteSnapshotRequest
+	(*DeleteSnapshotResponse)(nil),      // 51: glix.v1.DeleteSnapshotResponse
+	(*AggregateInventoryRequest)(nil),   // 52: glix.v1.AggregateInventoryRequest
+	(*AggregateInventoryResponse)(nil),  // 53: glix.v1.AggregateInventoryResponse
+	(*ListInventoriesRequest)(nil),      // 54: glix.v1.ListInventoriesRequest
+	(*ListInventoriesResponse)(nil),     // 55: glix.v1.ListInventoriesResponse
+	(*GetLatestVersionsRequest)(nil),    // 56: glix.v1.GetLatestVersionsRequest
+	(*LatestVersionInfo)(nil),           // 57: glix.v1.LatestVersionInfo
+	(*GetLatestVersionsResponse)(nil),   // 58: glix.v1.GetLatestVersionsResponse
+	(*SearchRequest)(nil),               // 59: glix.v1.SearchRequest
+	(*SearchResult)(nil),                // 60: glix.v1.SearchResult
+	(*SearchResponse)(nil),              // 61: glix.v1.SearchResponse
+	(*TaskProto)(nil),                   // 62: glix.v1.TaskProto
+	(*ListTasksResponse)(nil),           // 63: glix.v1.ListTasksResponse
+	(*RunTaskRequest)(nil),              // 64: glix.v1.RunTaskRequest
+	(*RunTaskResponse)(nil),             // 65: glix.v1.RunTaskResponse
+	(*StoreLibraryWatchRequest)(nil),    // 66: glix.v1.StoreLibraryWatchRequest
+	(*StoreLibraryWatchResponse)(nil),   // 67: glix.v1.StoreLibraryWatchResponse
+	(*ListLibraryWatchesResponse)(nil),  // 68: glix.v1.ListLibraryWatchesResponse
+	(*RemoveLibraryWatchRequest)(nil),   // 69: glix.v1.RemoveLibraryWatchRequest
+	(*RemoveLibraryWatchResponse)(nil),  // 70: glix.v1.RemoveLibraryWatchResponse
+	(*GetAutoUpdateConfigResponse)(nil), // 71: glix.v1.GetAutoUpdateConfigResponse
+	(*SetAutoUpdateConfigRequest)(nil),  // 72: glix.v1.SetAutoUpdateConfigRequest
+	(*AutoUpdateCheck)(nil),             // 73: glix.v1.AutoUpdateCheck
+	(*SetAutoUpdateConfigResponse)(nil), // 74: glix.v1.SetAutoUpdateConfigResponse
+	(*CreateTokenRequest)(nil),          // 75: glix.v1.CreateTokenRequest
+	(*CreateTokenResponse)(nil),         // 76: glix.v1.CreateTokenResponse
+	(*ListTokensResponse)(nil),          // 77: glix.v1.ListTokensResponse
+	(*RevokeTokenRequest)(nil),          // 78: glix.v1.RevokeTokenRequest
+	(*RevokeTokenResponse)(nil),         // 79: glix.v1.RevokeTokenResponse
+	(*OutputLine)(nil),                  // 80: glix.v1.OutputLine
+	(*ProgressUpdate)(nil),              // 81: glix.v1.ProgressUpdate
+	(*InstallProgress)(nil),             // 82: glix.v1.InstallProgress
+	(*ModuleProto)(nil),                 // 83: database.ModuleProto
+	(*DependenciesProto)(nil),           // 84: database.DependenciesProto
+	(*EventProto)(nil),                  // 85: database.EventProto
+	(*VulnReportProto)(nil),             // 86: database.VulnReportProto
+	(*VersionCacheProto)(nil),           // 87: database.VersionCacheProto
+	(*SnapshotProto)(nil),               // 88: database.SnapshotProto
+	(*InventoryProto)(nil),              // 89: database.InventoryProto
+	(*LibraryWatchProto)(nil),           // 90: database.LibraryWatchProto
+	(*AutoUpdateConfigProto)(nil),       // 91: database.AutoUpdateConfigProto
+	(*TokenProto)(nil),                  // 92: database.TokenProto
+	(*emptypb.Empty)(nil),               // 93: google.protobuf.Empty
 }
 var file_proto_v1_service_proto_depIdxs = []int32{
-	19, // 0: glix.v1.StoreModuleRequest.module:type_name -> database.ModuleProto
-	20, // 1: glix.v1.StoreModuleRequest.dependencies:type_name -> database.DependenciesProto
-	19, // 2: glix.v1.InstallResponse.module:type_name -> database.ModuleProto
-	19, // 3: glix.v1.ListModulesResponse.modules:type_name -> database.ModuleProto
-	19, // 4: glix.v1.GetModuleResponse.module:type_name -> database.ModuleProto
-	20, // 5: glix.v1.GetDependenciesResponse.dependencies:type_name -> database.DependenciesProto
-	19, // 6: glix.v1.UpdateResponse.old_module:type_name -> database.ModuleProto
-	19, // 7: glix.v1.UpdateResponse.new_module:type_name -> database.ModuleProto
-	0,  // 8: glix.v1.OutputLine.stream:type_name -> glix.v1.OutputLine.Stream
-	16, // 9: glix.v1.InstallProgress.output:type_name -> glix.v1.OutputLine
-	17, // 10: glix.v1.InstallProgress.progress:type_name -> glix.v1.ProgressUpdate
-	6,  // 11: glix.v1.InstallProgress.result:type_name -> glix.v1.InstallResponse
-	3,  // 12: glix.v1.GlixService.StoreModule:input_type -> glix.v1.StoreModuleRequest
-	9,  // 13: glix.v1.GlixService.ListModules:input_type -> glix.v1.ListModulesRequest
-	11, // 14: glix.v1.GlixService.GetModule:input_type -> glix.v1.GetModuleRequest
-	11, // 15: glix.v1.GlixService.GetDependencies:input_type -> glix.v1.GetModuleRequest
-	7,  // 16: glix.v1.GlixService.Remove:input_type -> glix.v1.RemoveRequest
-	21, // 17: glix.v1.GlixService.GetStatus:input_type -> google.protobuf.Empty
-	21, // 18: glix.v1.GlixService.Ping:input_type -> google.protobuf.Empty
-	4,  // 19: glix.v1.GlixService.StoreModule:output_type -> glix.v1.StoreModuleResponse
-	10, // 20: glix.v1.GlixService.ListModules:output_type -> glix.v1.ListModulesResponse
-	12, // 21: glix.v1.GlixService.GetModule:output_type -> glix.v1.GetModuleResponse
-	13, // 22: glix.v1.GlixService.GetDependencies:output_type -> glix.v1.GetDependenciesResponse
-	8,  // 23: glix.v1.GlixService.Remove:output_type -> glix.v1.RemoveResponse
-	2,  // 24: glix.v1.GlixService.GetStatus:output_type -> glix.v1.ServerStatus
-	21, // 25: glix.v1.GlixService.Ping:output_type -> google.protobuf.Empty
-	19, // [19:26] is the sub-list for method output_type
-	12, // [12:19] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	83, // 0: glix.v1.StoreModuleRequest.module:type_name -> database.ModuleProto
+	84, // 1: glix.v1.StoreModuleRequest.dependencies:type_name -> database.DependenciesProto
+	83, // 2: glix.v1.InstallResponse.module:type_name -> database.ModuleProto
+	83, // 3: glix.v1.ListModulesResponse.modules:type_name -> database.ModuleProto
+	14, // 4: glix.v1.ListModulesResponse.security:type_name -> glix.v1.SecuritySummary
+	83, // 5: glix.v1.GetModuleResponse.module:type_name -> database.ModuleProto
+	84, // 6: glix.v1.GetDependenciesResponse.dependencies:type_name -> database.DependenciesProto
+	83, // 7: glix.v1.UpdateResponse.old_module:type_name -> database.ModuleProto
+	83, // 8: glix.v1.UpdateResponse.new_module:type_name -> database.ModuleProto
+	83, // 9: glix.v1.MarkBadVersionResponse.module:type_name -> database.ModuleProto
+	83, // 10: glix.v1.SetAliasResponse.module:type_name -> database.ModuleProto
+	0,  // 11: glix.v1.BinaryVerification.integrity:type_name -> glix.v1.BinaryIntegrity
+	1,  // 12: glix.v1.BinaryVerification.sum_integrity:type_name -> glix.v1.SumIntegrity
+	25, // 13: glix.v1.VerifyBinariesResponse.results:type_name -> glix.v1.BinaryVerification
+	83, // 14: glix.v1.GetInstallHistoryResponse.installs:type_name -> database.ModuleProto
+	85, // 15: glix.v1.RecordEventRequest.event:type_name -> database.EventProto
+	85, // 16: glix.v1.GetHistoryResponse.events:type_name -> database.EventProto
+	86, // 17: glix.v1.StoreVulnReportRequest.report:type_name -> database.VulnReportProto
+	87, // 18: glix.v1.GetVersionCacheResponse.entry:type_name -> database.VersionCacheProto
+	87, // 19: glix.v1.StoreVersionCacheRequest.entry:type_name -> database.VersionCacheProto
+	86, // 20: glix.v1.ListVulnReportsResponse.reports:type_name -> database.VulnReportProto
+	42, // 21: glix.v1.GetStatsResponse.weeks:type_name -> glix.v1.WeeklyStats
+	43, // 22: glix.v1.GetStatsResponse.builds:type_name -> glix.v1.BuildStats
+	88, // 23: glix.v1.CreateSnapshotResponse.snapshot:type_name -> database.SnapshotProto
+	88, // 24: glix.v1.GetSnapshotResponse.snapshot:type_name -> database.SnapshotProto
+	88, // 25: glix.v1.ListSnapshotsResponse.snapshots:type_name -> database.SnapshotProto
+	89, // 26: glix.v1.AggregateInventoryRequest.inventory:type_name -> database.InventoryProto
+	89, // 27: glix.v1.ListInventoriesResponse.inventories:type_name -> database.InventoryProto
+	57, // 28: glix.v1.GetLatestVersionsResponse.versions:type_name -> glix.v1.LatestVersionInfo
+	60, // 29: glix.v1.SearchResponse.results:type_name -> glix.v1.SearchResult
+	62, // 30: glix.v1.ListTasksResponse.tasks:type_name -> glix.v1.TaskProto
+	90, // 31: glix.v1.StoreLibraryWatchRequest.watch:type_name -> database.LibraryWatchProto
+	90, // 32: glix.v1.ListLibraryWatchesResponse.watches:type_name -> database.LibraryWatchProto
+	91, // 33: glix.v1.GetAutoUpdateConfigResponse.config:type_name -> database.AutoUpdateConfigProto
+	73, // 34: glix.v1.SetAutoUpdateConfigRequest.record_check:type_name -> glix.v1.AutoUpdateCheck
+	91, // 35: glix.v1.SetAutoUpdateConfigResponse.config:type_name -> database.AutoUpdateConfigProto
+	92, // 36: glix.v1.CreateTokenResponse.token:type_name -> database.TokenProto
+	92, // 37: glix.v1.ListTokensResponse.tokens:type_name -> database.TokenProto
+	3,  // 38: glix.v1.OutputLine.stream:type_name -> glix.v1.OutputLine.Stream
+	2,  // 39: glix.v1.ProgressUpdate.phase:type_name -> glix.v1.InstallPhase
+	80, // 40: glix.v1.InstallProgress.output:type_name -> glix.v1.OutputLine
+	81, // 41: glix.v1.InstallProgress.progress:type_name -> glix.v1.ProgressUpdate
+	9,  // 42: glix.v1.InstallProgress.result:type_name -> glix.v1.InstallResponse
+	6,  // 43: glix.v1.GlixService.StoreModule:input_type -> glix.v1.StoreModuleRequest
+	12, // 44: glix.v1.GlixService.ListModules:input_type -> glix.v1.ListModulesRequest
+	15, // 45: glix.v1.GlixService.GetModule:input_type -> glix.v1.GetModuleRequest
+	15, // 46: glix.v1.GlixService.GetDependencies:input_type -> glix.v1.GetModuleRequest
+	56, // 47: glix.v1.GlixService.GetLatestVersions:input_type -> glix.v1.GetLatestVersionsRequest
+	59, // 48: glix.v1.GlixService.Search:input_type -> glix.v1.SearchRequest
+	10, // 49: glix.v1.GlixService.Remove:input_type -> glix.v1.RemoveRequest
+	20, // 50: glix.v1.GlixService.MarkBadVersion:input_type -> glix.v1.MarkBadVersionRequest
+	22, // 51: glix.v1.GlixService.SetAlias:input_type -> glix.v1.SetAliasRequest
+	24, // 52: glix.v1.GlixService.VerifyBinaries:input_type -> glix.v1.VerifyBinariesRequest
+	27, // 53: glix.v1.GlixService.GetInstallHistory:input_type -> glix.v1.GetInstallHistoryRequest
+	29, // 54: glix.v1.GlixService.RecordEvent:input_type -> glix.v1.RecordEventRequest
+	31, // 55: glix.v1.GlixService.GetHistory:input_type -> glix.v1.GetHistoryRequest
+	33, // 56: glix.v1.GlixService.StoreVulnReport:input_type -> glix.v1.StoreVulnReportRequest
+	39, // 57: glix.v1.GlixService.ListVulnReports:input_type -> glix.v1.ListVulnReportsRequest
+	35, // 58: glix.v1.GlixService.GetVersionCache:input_type -> glix.v1.GetVersionCacheRequest
+	37, // 59: glix.v1.GlixService.StoreVersionCache:input_type -> glix.v1.StoreVersionCacheRequest
+	66, // 60: glix.v1.GlixService.StoreLibraryWatch:input_type -> glix.v1.StoreLibraryWatchRequest
+	93, // 61: glix.v1.GlixService.ListLibraryWatches:input_type -> google.protobuf.Empty
+	69, // 62: glix.v1.GlixService.RemoveLibraryWatch:input_type -> glix.v1.RemoveLibraryWatchRequest
+	93, // 63: glix.v1.GlixService.GetAutoUpdateConfig:input_type -> google.protobuf.Empty
+	72, // 64: glix.v1.GlixService.SetAutoUpdateConfig:input_type -> glix.v1.SetAutoUpdateConfigRequest
+	41, // 65: glix.v1.GlixService.GetStats:input_type -> glix.v1.GetStatsRequest
+	45, // 66: glix.v1.GlixService.CreateSnapshot:input_type -> glix.v1.CreateSnapshotRequest
+	47, // 67: glix.v1.GlixService.GetSnapshot:input_type -> glix.v1.GetSnapshotRequest
+	93, // 68: glix.v1.GlixService.ListSnapshots:input_type -> google.protobuf.Empty
+	50, // 69: glix.v1.GlixService.DeleteSnapshot:input_type -> glix.v1.DeleteSnapshotRequest
+	52, // 70: glix.v1.GlixService.AggregateInventory:input_type -> glix.v1.AggregateInventoryRequest
+	54, // 71: glix.v1.GlixService.ListInventories:input_type -> glix.v1.ListInventoriesRequest
+	93, // 72: glix.v1.GlixService.ListTasks:input_type -> google.protobuf.Empty
+	64, // 73: glix.v1.GlixService.RunTask:input_type -> glix.v1.RunTaskRequest
+	75, // 74: glix.v1.GlixService.CreateToken:input_type -> glix.v1.CreateTokenRequest
+	93, // 75: glix.v1.GlixService.ListTokens:input_type -> google.protobuf.Empty
+	78, // 76: glix.v1.GlixService.RevokeToken:input_type -> glix.v1.RevokeTokenRequest
+	93, // 77: glix.v1.GlixService.GetStatus:input_type -> google.protobuf.Empty
+	93, // 78: glix.v1.GlixService.Ping:input_type -> google.protobuf.Empty
+	7,  // 79: glix.v1.GlixService.StoreModule:output_type -> glix.v1.StoreModuleResponse
+	13, // 80: glix.v1.GlixService.ListModules:output_type -> glix.v1.ListModulesResponse
+	16, // 81: glix.v1.GlixService.GetModule:output_type -> glix.v1.GetModuleResponse
+	17, // 82: glix.v1.GlixService.GetDependencies:output_type -> glix.v1.GetDependenciesResponse
+	58, // 83: glix.v1.GlixService.GetLatestVersions:output_type -> glix.v1.GetLatestVersionsResponse
+	61, // 84: glix.v1.GlixService.Search:output_type -> glix.v1.SearchResponse
+	11, // 85: glix.v1.GlixService.Remove:output_type -> glix.v1.RemoveResponse
+	21, // 86: glix.v1.GlixService.MarkBadVersion:output_type -> glix.v1.MarkBadVersionResponse
+	23, // 87: glix.v1.GlixService.SetAlias:output_type -> glix.v1.SetAliasResponse
+	26, // 88: glix.v1.GlixService.VerifyBinaries:output_type -> glix.v1.VerifyBinariesResponse
+	28, // 89: glix.v1.GlixService.GetInstallHistory:output_type -> glix.v1.GetInstallHistoryResponse
+	30, // 90: glix.v1.GlixService.RecordEvent:output_type -> glix.v1.RecordEventResponse
+	32, // 91: glix.v1.GlixService.GetHistory:output_type -> glix.v1.GetHistoryResponse
+	34, // 92: glix.v1.GlixService.StoreVulnReport:output_type -> glix.v1.StoreVulnReportResponse
+	40, // 93: glix.v1.GlixService.ListVulnReports:output_type -> glix.v1.ListVulnReportsResponse
+	36, // 94: glix.v1.GlixService.GetVersionCache:output_type -> glix.v1.GetVersionCacheResponse
+	38, // 95: glix.v1.GlixService.StoreVersionCache:output_type -> glix.v1.StoreVersionCacheResponse
+	67, // 96: glix.v1.GlixService.StoreLibraryWatch:output_type -> glix.v1.StoreLibraryWatchResponse
+	68, // 97: glix.v1.GlixService.ListLibraryWatches:output_type -> glix.v1.ListLibraryWatchesResponse
+	70, // 98: glix.v1.GlixService.RemoveLibraryWatch:output_type -> glix.v1.RemoveLibraryWatchResponse
+	71, // 99: glix.v1.GlixService.GetAutoUpdateConfig:output_type -> glix.v1.GetAutoUpdateConfigResponse
+	74, // 100: glix.v1.GlixService.SetAutoUpdateConfig:output_type -> glix.v1.SetAutoUpdateConfigResponse
+	44, // 101: glix.v1.GlixService.GetStats:output_type -> glix.v1.GetStatsResponse
+	46, // 102: glix.v1.GlixService.CreateSnapshot:output_type -> glix.v1.CreateSnapshotResponse
+	48, // 103: glix.v1.GlixService.GetSnapshot:output_type -> glix.v1.GetSnapshotResponse
+	49, // 104: glix.v1.GlixService.ListSnapshots:output_type -> glix.v1.ListSnapshotsResponse
+	51, // 105: glix.v1.GlixService.DeleteSnapshot:output_type -> glix.v1.DeleteSnapshotResponse
+	53, // 106: glix.v1.GlixService.AggregateInventory:output_type -> glix.v1.AggregateInventoryResponse
+	55, // 107: glix.v1.GlixService.ListInventories:output_type -> glix.v1.ListInventoriesResponse
+	63, // 108: glix.v1.GlixService.ListTasks:output_type -> glix.v1.ListTasksResponse
+	65, // 109: glix.v1.GlixService.RunTask:output_type -> glix.v1.RunTaskResponse
+	76, // 110: glix.v1.GlixService.CreateToken:output_type -> glix.v1.CreateTokenResponse
+	77, // 111: glix.v1.GlixService.ListTokens:output_type -> glix.v1.ListTokensResponse
+	79, // 112: glix.v1.GlixService.RevokeToken:output_type -> glix.v1.RevokeTokenResponse
+	5,  // 113: glix.v1.GlixService.GetStatus:output_type -> glix.v1.ServerStatus
+	93, // 114: glix.v1.GlixService.Ping:output_type -> google.protobuf.Empty
+	79, // [79:115] is the sub-list for method output_type
+	43, // [43:79] is the sub-list for method input_type
+	43, // [43:43] is the sub-list for extension type_name
+	43, // [43:43] is the sub-list for extension extendee
+	0,  // [0:43] is the sub-list for field type_name
 }
 
 func init() { file_proto_v1_service_proto_init() }
@@ -1338,7 +5552,8 @@ func file_proto_v1_service_proto_init() {
 		return
 	}
 	file_proto_v1_database_proto_init()
-	file_proto_v1_service_proto_msgTypes[17].OneofWrappers = []any{
+	file_proto_v1_service_proto_msgTypes[68].OneofWrappers = []any{}
+	file_proto_v1_service_proto_msgTypes[78].OneofWrappers = []any{
 		(*InstallProgress_Output)(nil),
 		(*InstallProgress_Progress)(nil),
 		(*InstallProgress_Result)(nil),
@@ -1348,8 +5563,8 @@ func file_proto_v1_service_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_v1_service_proto_rawDesc), len(file_proto_v1_service_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   18,
+			NumEnums:      4,
+			NumMessages:   79,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
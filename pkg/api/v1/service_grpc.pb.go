@@ -20,13 +20,42 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	GlixService_StoreModule_FullMethodName     = "/glix.v1.GlixService/StoreModule"
-	GlixService_ListModules_FullMethodName     = "/glix.v1.GlixService/ListModules"
-	GlixService_GetModule_FullMethodName       = "/glix.v1.GlixService/GetModule"
-	GlixService_GetDependencies_FullMethodName = "/glix.v1.GlixService/GetDependencies"
-	GlixService_Remove_FullMethodName          = "/glix.v1.GlixService/Remove"
-	GlixService_GetStatus_FullMethodName       = "/glix.v1.GlixService/GetStatus"
-	GlixService_Ping_FullMethodName            = "/glix.v1.GlixService/Ping"
+	GlixService_StoreModule_FullMethodName         = "/glix.v1.GlixService/StoreModule"
+	GlixService_ListModules_FullMethodName         = "/glix.v1.GlixService/ListModules"
+	GlixService_GetModule_FullMethodName           = "/glix.v1.GlixService/GetModule"
+	GlixService_GetDependencies_FullMethodName     = "/glix.v1.GlixService/GetDependencies"
+	GlixService_GetLatestVersions_FullMethodName   = "/glix.v1.GlixService/GetLatestVersions"
+	GlixService_Search_FullMethodName              = "/glix.v1.GlixService/Search"
+	GlixService_Remove_FullMethodName              = "/glix.v1.GlixService/Remove"
+	GlixService_MarkBadVersion_FullMethodName      = "/glix.v1.GlixService/MarkBadVersion"
+	GlixService_SetAlias_FullMethodName            = "/glix.v1.GlixService/SetAlias"
+	GlixService_VerifyBinaries_FullMethodName      = "/glix.v1.GlixService/VerifyBinaries"
+	GlixService_GetInstallHistory_FullMethodName   = "/glix.v1.GlixService/GetInstallHistory"
+	GlixService_RecordEvent_FullMethodName         = "/glix.v1.GlixService/RecordEvent"
+	GlixService_GetHistory_FullMethodName          = "/glix.v1.GlixService/GetHistory"
+	GlixService_StoreVulnReport_FullMethodName     = "/glix.v1.GlixService/StoreVulnReport"
+	GlixService_ListVulnReports_FullMethodName     = "/glix.v1.GlixService/ListVulnReports"
+	GlixService_GetVersionCache_FullMethodName     = "/glix.v1.GlixService/GetVersionCache"
+	GlixService_StoreVersionCache_FullMethodName   = "/glix.v1.GlixService/StoreVersionCache"
+	GlixService_StoreLibraryWatch_FullMethodName   = "/glix.v1.GlixService/StoreLibraryWatch"
+	GlixService_ListLibraryWatches_FullMethodName  = "/glix.v1.GlixService/ListLibraryWatches"
+	GlixService_RemoveLibraryWatch_FullMethodName  = "/glix.v1.GlixService/RemoveLibraryWatch"
+	GlixService_GetAutoUpdateConfig_FullMethodName = "/glix.v1.GlixService/GetAutoUpdateConfig"
+	GlixService_SetAutoUpdateConfig_FullMethodName = "/glix.v1.GlixService/SetAutoUpdateConfig"
+	GlixService_GetStats_FullMethodName            = "/glix.v1.GlixService/GetStats"
+	GlixService_CreateSnapshot_FullMethodName      = "/glix.v1.GlixService/CreateSnapshot"
+	GlixService_GetSnapshot_FullMethodName         = "/glix.v1.GlixService/GetSnapshot"
+	GlixService_ListSnapshots_FullMethodName       = "/glix.v1.GlixService/ListSnapshots"
+	GlixService_DeleteSnapshot_FullMethodName      = "/glix.v1.GlixService/DeleteSnapshot"
+	GlixService_AggregateInventory_FullMethodName  = "/glix.v1.GlixService/AggregateInventory"
+	GlixService_ListInventories_FullMethodName     = "/glix.v1.GlixService/ListInventories"
+	GlixService_ListTasks_FullMethodName           = "/glix.v1.GlixService/ListTasks"
+	GlixService_RunTask_FullMethodName             = "/glix.v1.GlixService/RunTask"
+	GlixService_CreateToken_FullMethodName         = "/glix.v1.GlixService/CreateToken"
+	GlixService_ListTokens_FullMethodName          = "/glix.v1.GlixService/ListTokens"
+	GlixService_RevokeToken_FullMethodName         = "/glix.v1.GlixService/RevokeToken"
+	GlixService_GetStatus_FullMethodName           = "/glix.v1.GlixService/GetStatus"
+	GlixService_Ping_FullMethodName                = "/glix.v1.GlixService/Ping"
 )
 
 // GlixServiceClient is the client API for GlixService service.
@@ -39,8 +68,53 @@ type GlixServiceClient interface {
 	ListModules(ctx context.Context, in *ListModulesRequest, opts ...grpc.CallOption) (*ListModulesResponse, error)
 	GetModule(ctx context.Context, in *GetModuleRequest, opts ...grpc.CallOption) (*GetModuleResponse, error)
 	GetDependencies(ctx context.Context, in *GetModuleRequest, opts ...grpc.CallOption) (*GetDependenciesResponse, error)
+	GetLatestVersions(ctx context.Context, in *GetLatestVersionsRequest, opts ...grpc.CallOption) (*GetLatestVersionsResponse, error)
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
 	// Module management (database only)
 	Remove(ctx context.Context, in *RemoveRequest, opts ...grpc.CallOption) (*RemoveResponse, error)
+	MarkBadVersion(ctx context.Context, in *MarkBadVersionRequest, opts ...grpc.CallOption) (*MarkBadVersionResponse, error)
+	SetAlias(ctx context.Context, in *SetAliasRequest, opts ...grpc.CallOption) (*SetAliasResponse, error)
+	VerifyBinaries(ctx context.Context, in *VerifyBinariesRequest, opts ...grpc.CallOption) (*VerifyBinariesResponse, error)
+	GetInstallHistory(ctx context.Context, in *GetInstallHistoryRequest, opts ...grpc.CallOption) (*GetInstallHistoryResponse, error)
+	// Event history of installs, updates, and removes. Successful changes are
+	// recorded by StoreModule and Remove; clients record failures.
+	RecordEvent(ctx context.Context, in *RecordEventRequest, opts ...grpc.CallOption) (*RecordEventResponse, error)
+	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
+	// govulncheck findings of installed binaries, replaced by every scan
+	StoreVulnReport(ctx context.Context, in *StoreVulnReportRequest, opts ...grpc.CallOption) (*StoreVulnReportResponse, error)
+	ListVulnReports(ctx context.Context, in *ListVulnReportsRequest, opts ...grpc.CallOption) (*ListVulnReportsResponse, error)
+	// Cached go list -m -versions answers, shared by every client so module
+	// versions are not queried again within the version cache TTL
+	GetVersionCache(ctx context.Context, in *GetVersionCacheRequest, opts ...grpc.CallOption) (*GetVersionCacheResponse, error)
+	StoreVersionCache(ctx context.Context, in *StoreVersionCacheRequest, opts ...grpc.CallOption) (*StoreVersionCacheResponse, error)
+	// Library modules watched for new releases and vulnerabilities, which
+	// have no binary; auto-update checks them
+	StoreLibraryWatch(ctx context.Context, in *StoreLibraryWatchRequest, opts ...grpc.CallOption) (*StoreLibraryWatchResponse, error)
+	ListLibraryWatches(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListLibraryWatchesResponse, error)
+	RemoveLibraryWatch(ctx context.Context, in *RemoveLibraryWatchRequest, opts ...grpc.CallOption) (*RemoveLibraryWatchResponse, error)
+	// Auto-update configuration and statistics, read by the daemon's
+	// autoupdate task
+	GetAutoUpdateConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetAutoUpdateConfigResponse, error)
+	SetAutoUpdateConfig(ctx context.Context, in *SetAutoUpdateConfigRequest, opts ...grpc.CallOption) (*SetAutoUpdateConfigResponse, error)
+	// Weekly counts of the event history, for trend charts
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
+	// Snapshots of the installed module set
+	CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...grpc.CallOption) (*CreateSnapshotResponse, error)
+	GetSnapshot(ctx context.Context, in *GetSnapshotRequest, opts ...grpc.CallOption) (*GetSnapshotResponse, error)
+	ListSnapshots(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSnapshotsResponse, error)
+	DeleteSnapshot(ctx context.Context, in *DeleteSnapshotRequest, opts ...grpc.CallOption) (*DeleteSnapshotResponse, error)
+	// Fleet mode: workstations push inventories to a central server
+	AggregateInventory(ctx context.Context, in *AggregateInventoryRequest, opts ...grpc.CallOption) (*AggregateInventoryResponse, error)
+	ListInventories(ctx context.Context, in *ListInventoriesRequest, opts ...grpc.CallOption) (*ListInventoriesResponse, error)
+	// Scheduled tasks run by the daemon; RunTask runs one now and waits
+	ListTasks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListTasksResponse, error)
+	RunTask(ctx context.Context, in *RunTaskRequest, opts ...grpc.CallOption) (*RunTaskResponse, error)
+	// Scoped API tokens. Without one, clients on this machine may call
+	// everything; once a token exists, clients on other machines need one.
+	// Managing tokens takes an admin token or a client on this machine.
+	CreateToken(ctx context.Context, in *CreateTokenRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error)
+	ListTokens(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListTokensResponse, error)
+	RevokeToken(ctx context.Context, in *RevokeTokenRequest, opts ...grpc.CallOption) (*RevokeTokenResponse, error)
 	// Server management
 	GetStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ServerStatus, error)
 	Ping(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
@@ -94,6 +168,26 @@ func (c *glixServiceClient) GetDependencies(ctx context.Context, in *GetModuleRe
 	return out, nil
 }
 
+func (c *glixServiceClient) GetLatestVersions(ctx context.Context, in *GetLatestVersionsRequest, opts ...grpc.CallOption) (*GetLatestVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLatestVersionsResponse)
+	err := c.cc.Invoke(ctx, GlixService_GetLatestVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, GlixService_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *glixServiceClient) Remove(ctx context.Context, in *RemoveRequest, opts ...grpc.CallOption) (*RemoveResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(RemoveResponse)
@@ -104,6 +198,276 @@ func (c *glixServiceClient) Remove(ctx context.Context, in *RemoveRequest, opts
 	return out, nil
 }
 
+func (c *glixServiceClient) MarkBadVersion(ctx context.Context, in *MarkBadVersionRequest, opts ...grpc.CallOption) (*MarkBadVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarkBadVersionResponse)
+	err := c.cc.Invoke(ctx, GlixService_MarkBadVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) SetAlias(ctx context.Context, in *SetAliasRequest, opts ...grpc.CallOption) (*SetAliasResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetAliasResponse)
+	err := c.cc.Invoke(ctx, GlixService_SetAlias_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) VerifyBinaries(ctx context.Context, in *VerifyBinariesRequest, opts ...grpc.CallOption) (*VerifyBinariesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyBinariesResponse)
+	err := c.cc.Invoke(ctx, GlixService_VerifyBinaries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) GetInstallHistory(ctx context.Context, in *GetInstallHistoryRequest, opts ...grpc.CallOption) (*GetInstallHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInstallHistoryResponse)
+	err := c.cc.Invoke(ctx, GlixService_GetInstallHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) RecordEvent(ctx context.Context, in *RecordEventRequest, opts ...grpc.CallOption) (*RecordEventResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordEventResponse)
+	err := c.cc.Invoke(ctx, GlixService_RecordEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHistoryResponse)
+	err := c.cc.Invoke(ctx, GlixService_GetHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) StoreVulnReport(ctx context.Context, in *StoreVulnReportRequest, opts ...grpc.CallOption) (*StoreVulnReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StoreVulnReportResponse)
+	err := c.cc.Invoke(ctx, GlixService_StoreVulnReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) ListVulnReports(ctx context.Context, in *ListVulnReportsRequest, opts ...grpc.CallOption) (*ListVulnReportsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVulnReportsResponse)
+	err := c.cc.Invoke(ctx, GlixService_ListVulnReports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) GetVersionCache(ctx context.Context, in *GetVersionCacheRequest, opts ...grpc.CallOption) (*GetVersionCacheResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVersionCacheResponse)
+	err := c.cc.Invoke(ctx, GlixService_GetVersionCache_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) StoreVersionCache(ctx context.Context, in *StoreVersionCacheRequest, opts ...grpc.CallOption) (*StoreVersionCacheResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StoreVersionCacheResponse)
+	err := c.cc.Invoke(ctx, GlixService_StoreVersionCache_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) StoreLibraryWatch(ctx context.Context, in *StoreLibraryWatchRequest, opts ...grpc.CallOption) (*StoreLibraryWatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StoreLibraryWatchResponse)
+	err := c.cc.Invoke(ctx, GlixService_StoreLibraryWatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) ListLibraryWatches(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListLibraryWatchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLibraryWatchesResponse)
+	err := c.cc.Invoke(ctx, GlixService_ListLibraryWatches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) RemoveLibraryWatch(ctx context.Context, in *RemoveLibraryWatchRequest, opts ...grpc.CallOption) (*RemoveLibraryWatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveLibraryWatchResponse)
+	err := c.cc.Invoke(ctx, GlixService_RemoveLibraryWatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) GetAutoUpdateConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetAutoUpdateConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAutoUpdateConfigResponse)
+	err := c.cc.Invoke(ctx, GlixService_GetAutoUpdateConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) SetAutoUpdateConfig(ctx context.Context, in *SetAutoUpdateConfigRequest, opts ...grpc.CallOption) (*SetAutoUpdateConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetAutoUpdateConfigResponse)
+	err := c.cc.Invoke(ctx, GlixService_SetAutoUpdateConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatsResponse)
+	err := c.cc.Invoke(ctx, GlixService_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...grpc.CallOption) (*CreateSnapshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSnapshotResponse)
+	err := c.cc.Invoke(ctx, GlixService_CreateSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) GetSnapshot(ctx context.Context, in *GetSnapshotRequest, opts ...grpc.CallOption) (*GetSnapshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSnapshotResponse)
+	err := c.cc.Invoke(ctx, GlixService_GetSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) ListSnapshots(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSnapshotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSnapshotsResponse)
+	err := c.cc.Invoke(ctx, GlixService_ListSnapshots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) DeleteSnapshot(ctx context.Context, in *DeleteSnapshotRequest, opts ...grpc.CallOption) (*DeleteSnapshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSnapshotResponse)
+	err := c.cc.Invoke(ctx, GlixService_DeleteSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) AggregateInventory(ctx context.Context, in *AggregateInventoryRequest, opts ...grpc.CallOption) (*AggregateInventoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AggregateInventoryResponse)
+	err := c.cc.Invoke(ctx, GlixService_AggregateInventory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) ListInventories(ctx context.Context, in *ListInventoriesRequest, opts ...grpc.CallOption) (*ListInventoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInventoriesResponse)
+	err := c.cc.Invoke(ctx, GlixService_ListInventories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) ListTasks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTasksResponse)
+	err := c.cc.Invoke(ctx, GlixService_ListTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) RunTask(ctx context.Context, in *RunTaskRequest, opts ...grpc.CallOption) (*RunTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunTaskResponse)
+	err := c.cc.Invoke(ctx, GlixService_RunTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) CreateToken(ctx context.Context, in *CreateTokenRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTokenResponse)
+	err := c.cc.Invoke(ctx, GlixService_CreateToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) ListTokens(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListTokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTokensResponse)
+	err := c.cc.Invoke(ctx, GlixService_ListTokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glixServiceClient) RevokeToken(ctx context.Context, in *RevokeTokenRequest, opts ...grpc.CallOption) (*RevokeTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeTokenResponse)
+	err := c.cc.Invoke(ctx, GlixService_RevokeToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *glixServiceClient) GetStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ServerStatus, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ServerStatus)
@@ -134,8 +498,53 @@ type GlixServiceServer interface {
 	ListModules(context.Context, *ListModulesRequest) (*ListModulesResponse, error)
 	GetModule(context.Context, *GetModuleRequest) (*GetModuleResponse, error)
 	GetDependencies(context.Context, *GetModuleRequest) (*GetDependenciesResponse, error)
+	GetLatestVersions(context.Context, *GetLatestVersionsRequest) (*GetLatestVersionsResponse, error)
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
 	// Module management (database only)
 	Remove(context.Context, *RemoveRequest) (*RemoveResponse, error)
+	MarkBadVersion(context.Context, *MarkBadVersionRequest) (*MarkBadVersionResponse, error)
+	SetAlias(context.Context, *SetAliasRequest) (*SetAliasResponse, error)
+	VerifyBinaries(context.Context, *VerifyBinariesRequest) (*VerifyBinariesResponse, error)
+	GetInstallHistory(context.Context, *GetInstallHistoryRequest) (*GetInstallHistoryResponse, error)
+	// Event history of installs, updates, and removes. Successful changes are
+	// recorded by StoreModule and Remove; clients record failures.
+	RecordEvent(context.Context, *RecordEventRequest) (*RecordEventResponse, error)
+	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
+	// govulncheck findings of installed binaries, replaced by every scan
+	StoreVulnReport(context.Context, *StoreVulnReportRequest) (*StoreVulnReportResponse, error)
+	ListVulnReports(context.Context, *ListVulnReportsRequest) (*ListVulnReportsResponse, error)
+	// Cached go list -m -versions answers, shared by every client so module
+	// versions are not queried again within the version cache TTL
+	GetVersionCache(context.Context, *GetVersionCacheRequest) (*GetVersionCacheResponse, error)
+	StoreVersionCache(context.Context, *StoreVersionCacheRequest) (*StoreVersionCacheResponse, error)
+	// Library modules watched for new releases and vulnerabilities, which
+	// have no binary; auto-update checks them
+	StoreLibraryWatch(context.Context, *StoreLibraryWatchRequest) (*StoreLibraryWatchResponse, error)
+	ListLibraryWatches(context.Context, *emptypb.Empty) (*ListLibraryWatchesResponse, error)
+	RemoveLibraryWatch(context.Context, *RemoveLibraryWatchRequest) (*RemoveLibraryWatchResponse, error)
+	// Auto-update configuration and statistics, read by the daemon's
+	// autoupdate task
+	GetAutoUpdateConfig(context.Context, *emptypb.Empty) (*GetAutoUpdateConfigResponse, error)
+	SetAutoUpdateConfig(context.Context, *SetAutoUpdateConfigRequest) (*SetAutoUpdateConfigResponse, error)
+	// Weekly counts of the event history, for trend charts
+	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
+	// Snapshots of the installed module set
+	CreateSnapshot(context.Context, *CreateSnapshotRequest) (*CreateSnapshotResponse, error)
+	GetSnapshot(context.Context, *GetSnapshotRequest) (*GetSnapshotResponse, error)
+	ListSnapshots(context.Context, *emptypb.Empty) (*ListSnapshotsResponse, error)
+	DeleteSnapshot(context.Context, *DeleteSnapshotRequest) (*DeleteSnapshotResponse, error)
+	// Fleet mode: workstations push inventories to a central server
+	AggregateInventory(context.Context, *AggregateInventoryRequest) (*AggregateInventoryResponse, error)
+	ListInventories(context.Context, *ListInventoriesRequest) (*ListInventoriesResponse, error)
+	// Scheduled tasks run by the daemon; RunTask runs one now and waits
+	ListTasks(context.Context, *emptypb.Empty) (*ListTasksResponse, error)
+	RunTask(context.Context, *RunTaskRequest) (*RunTaskResponse, error)
+	// Scoped API tokens. Without one, clients on this machine may call
+	// everything; once a token exists, clients on other machines need one.
+	// Managing tokens takes an admin token or a client on this machine.
+	CreateToken(context.Context, *CreateTokenRequest) (*CreateTokenResponse, error)
+	ListTokens(context.Context, *emptypb.Empty) (*ListTokensResponse, error)
+	RevokeToken(context.Context, *RevokeTokenRequest) (*RevokeTokenResponse, error)
 	// Server management
 	GetStatus(context.Context, *emptypb.Empty) (*ServerStatus, error)
 	Ping(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
@@ -161,9 +570,96 @@ func (UnimplementedGlixServiceServer) GetModule(context.Context, *GetModuleReque
 func (UnimplementedGlixServiceServer) GetDependencies(context.Context, *GetModuleRequest) (*GetDependenciesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetDependencies not implemented")
 }
+func (UnimplementedGlixServiceServer) GetLatestVersions(context.Context, *GetLatestVersionsRequest) (*GetLatestVersionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLatestVersions not implemented")
+}
+func (UnimplementedGlixServiceServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Search not implemented")
+}
 func (UnimplementedGlixServiceServer) Remove(context.Context, *RemoveRequest) (*RemoveResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Remove not implemented")
 }
+func (UnimplementedGlixServiceServer) MarkBadVersion(context.Context, *MarkBadVersionRequest) (*MarkBadVersionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MarkBadVersion not implemented")
+}
+func (UnimplementedGlixServiceServer) SetAlias(context.Context, *SetAliasRequest) (*SetAliasResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetAlias not implemented")
+}
+func (UnimplementedGlixServiceServer) VerifyBinaries(context.Context, *VerifyBinariesRequest) (*VerifyBinariesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method VerifyBinaries not implemented")
+}
+func (UnimplementedGlixServiceServer) GetInstallHistory(context.Context, *GetInstallHistoryRequest) (*GetInstallHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetInstallHistory not implemented")
+}
+func (UnimplementedGlixServiceServer) RecordEvent(context.Context, *RecordEventRequest) (*RecordEventResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RecordEvent not implemented")
+}
+func (UnimplementedGlixServiceServer) GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetHistory not implemented")
+}
+func (UnimplementedGlixServiceServer) StoreVulnReport(context.Context, *StoreVulnReportRequest) (*StoreVulnReportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StoreVulnReport not implemented")
+}
+func (UnimplementedGlixServiceServer) ListVulnReports(context.Context, *ListVulnReportsRequest) (*ListVulnReportsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListVulnReports not implemented")
+}
+func (UnimplementedGlixServiceServer) GetVersionCache(context.Context, *GetVersionCacheRequest) (*GetVersionCacheResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetVersionCache not implemented")
+}
+func (UnimplementedGlixServiceServer) StoreVersionCache(context.Context, *StoreVersionCacheRequest) (*StoreVersionCacheResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StoreVersionCache not implemented")
+}
+func (UnimplementedGlixServiceServer) StoreLibraryWatch(context.Context, *StoreLibraryWatchRequest) (*StoreLibraryWatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StoreLibraryWatch not implemented")
+}
+func (UnimplementedGlixServiceServer) ListLibraryWatches(context.Context, *emptypb.Empty) (*ListLibraryWatchesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListLibraryWatches not implemented")
+}
+func (UnimplementedGlixServiceServer) RemoveLibraryWatch(context.Context, *RemoveLibraryWatchRequest) (*RemoveLibraryWatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveLibraryWatch not implemented")
+}
+func (UnimplementedGlixServiceServer) GetAutoUpdateConfig(context.Context, *emptypb.Empty) (*GetAutoUpdateConfigResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAutoUpdateConfig not implemented")
+}
+func (UnimplementedGlixServiceServer) SetAutoUpdateConfig(context.Context, *SetAutoUpdateConfigRequest) (*SetAutoUpdateConfigResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetAutoUpdateConfig not implemented")
+}
+func (UnimplementedGlixServiceServer) GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedGlixServiceServer) CreateSnapshot(context.Context, *CreateSnapshotRequest) (*CreateSnapshotResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateSnapshot not implemented")
+}
+func (UnimplementedGlixServiceServer) GetSnapshot(context.Context, *GetSnapshotRequest) (*GetSnapshotResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSnapshot not implemented")
+}
+func (UnimplementedGlixServiceServer) ListSnapshots(context.Context, *emptypb.Empty) (*ListSnapshotsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSnapshots not implemented")
+}
+func (UnimplementedGlixServiceServer) DeleteSnapshot(context.Context, *DeleteSnapshotRequest) (*DeleteSnapshotResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteSnapshot not implemented")
+}
+func (UnimplementedGlixServiceServer) AggregateInventory(context.Context, *AggregateInventoryRequest) (*AggregateInventoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AggregateInventory not implemented")
+}
+func (UnimplementedGlixServiceServer) ListInventories(context.Context, *ListInventoriesRequest) (*ListInventoriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListInventories not implemented")
+}
+func (UnimplementedGlixServiceServer) ListTasks(context.Context, *emptypb.Empty) (*ListTasksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTasks not implemented")
+}
+func (UnimplementedGlixServiceServer) RunTask(context.Context, *RunTaskRequest) (*RunTaskResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RunTask not implemented")
+}
+func (UnimplementedGlixServiceServer) CreateToken(context.Context, *CreateTokenRequest) (*CreateTokenResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateToken not implemented")
+}
+func (UnimplementedGlixServiceServer) ListTokens(context.Context, *emptypb.Empty) (*ListTokensResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTokens not implemented")
+}
+func (UnimplementedGlixServiceServer) RevokeToken(context.Context, *RevokeTokenRequest) (*RevokeTokenResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeToken not implemented")
+}
 func (UnimplementedGlixServiceServer) GetStatus(context.Context, *emptypb.Empty) (*ServerStatus, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetStatus not implemented")
 }
@@ -263,64 +759,586 @@ func _GlixService_GetDependencies_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GlixService_Remove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveRequest)
+func _GlixService_GetLatestVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLatestVersionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GlixServiceServer).Remove(ctx, in)
+		return srv.(GlixServiceServer).GetLatestVersions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GlixService_Remove_FullMethodName,
+		FullMethod: GlixService_GetLatestVersions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GlixServiceServer).Remove(ctx, req.(*RemoveRequest))
+		return srv.(GlixServiceServer).GetLatestVersions(ctx, req.(*GetLatestVersionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GlixService_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _GlixService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GlixServiceServer).GetStatus(ctx, in)
+		return srv.(GlixServiceServer).Search(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GlixService_GetStatus_FullMethodName,
+		FullMethod: GlixService_Search_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GlixServiceServer).GetStatus(ctx, req.(*emptypb.Empty))
+		return srv.(GlixServiceServer).Search(ctx, req.(*SearchRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GlixService_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _GlixService_Remove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GlixServiceServer).Ping(ctx, in)
+		return srv.(GlixServiceServer).Remove(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GlixService_Ping_FullMethodName,
+		FullMethod: GlixService_Remove_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GlixServiceServer).Ping(ctx, req.(*emptypb.Empty))
+		return srv.(GlixServiceServer).Remove(ctx, req.(*RemoveRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// GlixService_ServiceDesc is the grpc.ServiceDesc for GlixService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var GlixService_ServiceDesc = grpc.ServiceDesc{
+func _GlixService_MarkBadVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkBadVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).MarkBadVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_MarkBadVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).MarkBadVersion(ctx, req.(*MarkBadVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_SetAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAliasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).SetAlias(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_SetAlias_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).SetAlias(ctx, req.(*SetAliasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_VerifyBinaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyBinariesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).VerifyBinaries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_VerifyBinaries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).VerifyBinaries(ctx, req.(*VerifyBinariesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_GetInstallHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstallHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).GetInstallHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_GetInstallHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).GetInstallHistory(ctx, req.(*GetInstallHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_RecordEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).RecordEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_RecordEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).RecordEvent(ctx, req.(*RecordEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_GetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).GetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_GetHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).GetHistory(ctx, req.(*GetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_StoreVulnReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreVulnReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).StoreVulnReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_StoreVulnReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).StoreVulnReport(ctx, req.(*StoreVulnReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_ListVulnReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVulnReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).ListVulnReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_ListVulnReports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).ListVulnReports(ctx, req.(*ListVulnReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_GetVersionCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersionCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).GetVersionCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_GetVersionCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).GetVersionCache(ctx, req.(*GetVersionCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_StoreVersionCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreVersionCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).StoreVersionCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_StoreVersionCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).StoreVersionCache(ctx, req.(*StoreVersionCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_StoreLibraryWatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreLibraryWatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).StoreLibraryWatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_StoreLibraryWatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).StoreLibraryWatch(ctx, req.(*StoreLibraryWatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_ListLibraryWatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).ListLibraryWatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_ListLibraryWatches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).ListLibraryWatches(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_RemoveLibraryWatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveLibraryWatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).RemoveLibraryWatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_RemoveLibraryWatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).RemoveLibraryWatch(ctx, req.(*RemoveLibraryWatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_GetAutoUpdateConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).GetAutoUpdateConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_GetAutoUpdateConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).GetAutoUpdateConfig(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_SetAutoUpdateConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAutoUpdateConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).SetAutoUpdateConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_SetAutoUpdateConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).SetAutoUpdateConfig(ctx, req.(*SetAutoUpdateConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_CreateSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).CreateSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_CreateSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).CreateSnapshot(ctx, req.(*CreateSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_GetSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).GetSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_GetSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).GetSnapshot(ctx, req.(*GetSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_ListSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).ListSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_ListSnapshots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).ListSnapshots(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_DeleteSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).DeleteSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_DeleteSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).DeleteSnapshot(ctx, req.(*DeleteSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_AggregateInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AggregateInventoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).AggregateInventory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_AggregateInventory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).AggregateInventory(ctx, req.(*AggregateInventoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_ListInventories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInventoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).ListInventories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_ListInventories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).ListInventories(ctx, req.(*ListInventoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_ListTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).ListTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_ListTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).ListTasks(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_RunTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).RunTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_RunTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).RunTask(ctx, req.(*RunTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_CreateToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).CreateToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_CreateToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).CreateToken(ctx, req.(*CreateTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_ListTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).ListTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_ListTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).ListTokens(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_RevokeToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).RevokeToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_RevokeToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).RevokeToken(ctx, req.(*RevokeTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).GetStatus(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlixService_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlixServiceServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlixService_Ping_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlixServiceServer).Ping(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GlixService_ServiceDesc is the grpc.ServiceDesc for GlixService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GlixService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "glix.v1.GlixService",
 	HandlerType: (*GlixServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
@@ -340,10 +1358,126 @@ var GlixService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetDependencies",
 			Handler:    _GlixService_GetDependencies_Handler,
 		},
+		{
+			MethodName: "GetLatestVersions",
+			Handler:    _GlixService_GetLatestVersions_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _GlixService_Search_Handler,
+		},
 		{
 			MethodName: "Remove",
 			Handler:    _GlixService_Remove_Handler,
 		},
+		{
+			MethodName: "MarkBadVersion",
+			Handler:    _GlixService_MarkBadVersion_Handler,
+		},
+		{
+			MethodName: "SetAlias",
+			Handler:    _GlixService_SetAlias_Handler,
+		},
+		{
+			MethodName: "VerifyBinaries",
+			Handler:    _GlixService_VerifyBinaries_Handler,
+		},
+		{
+			MethodName: "GetInstallHistory",
+			Handler:    _GlixService_GetInstallHistory_Handler,
+		},
+		{
+			MethodName: "RecordEvent",
+			Handler:    _GlixService_RecordEvent_Handler,
+		},
+		{
+			MethodName: "GetHistory",
+			Handler:    _GlixService_GetHistory_Handler,
+		},
+		{
+			MethodName: "StoreVulnReport",
+			Handler:    _GlixService_StoreVulnReport_Handler,
+		},
+		{
+			MethodName: "ListVulnReports",
+			Handler:    _GlixService_ListVulnReports_Handler,
+		},
+		{
+			MethodName: "GetVersionCache",
+			Handler:    _GlixService_GetVersionCache_Handler,
+		},
+		{
+			MethodName: "StoreVersionCache",
+			Handler:    _GlixService_StoreVersionCache_Handler,
+		},
+		{
+			MethodName: "StoreLibraryWatch",
+			Handler:    _GlixService_StoreLibraryWatch_Handler,
+		},
+		{
+			MethodName: "ListLibraryWatches",
+			Handler:    _GlixService_ListLibraryWatches_Handler,
+		},
+		{
+			MethodName: "RemoveLibraryWatch",
+			Handler:    _GlixService_RemoveLibraryWatch_Handler,
+		},
+		{
+			MethodName: "GetAutoUpdateConfig",
+			Handler:    _GlixService_GetAutoUpdateConfig_Handler,
+		},
+		{
+			MethodName: "SetAutoUpdateConfig",
+			Handler:    _GlixService_SetAutoUpdateConfig_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _GlixService_GetStats_Handler,
+		},
+		{
+			MethodName: "CreateSnapshot",
+			Handler:    _GlixService_CreateSnapshot_Handler,
+		},
+		{
+			MethodName: "GetSnapshot",
+			Handler:    _GlixService_GetSnapshot_Handler,
+		},
+		{
+			MethodName: "ListSnapshots",
+			Handler:    _GlixService_ListSnapshots_Handler,
+		},
+		{
+			MethodName: "DeleteSnapshot",
+			Handler:    _GlixService_DeleteSnapshot_Handler,
+		},
+		{
+			MethodName: "AggregateInventory",
+			Handler:    _GlixService_AggregateInventory_Handler,
+		},
+		{
+			MethodName: "ListInventories",
+			Handler:    _GlixService_ListInventories_Handler,
+		},
+		{
+			MethodName: "ListTasks",
+			Handler:    _GlixService_ListTasks_Handler,
+		},
+		{
+			MethodName: "RunTask",
+			Handler:    _GlixService_RunTask_Handler,
+		},
+		{
+			MethodName: "CreateToken",
+			Handler:    _GlixService_CreateToken_Handler,
+		},
+		{
+			MethodName: "ListTokens",
+			Handler:    _GlixService_ListTokens_Handler,
+		},
+		{
+			MethodName: "RevokeToken",
+			Handler:    _GlixService_RevokeToken_Handler,
+		},
 		{
 			MethodName: "GetStatus",
 			Handler:    _GlixService_GetStatus_Handler,
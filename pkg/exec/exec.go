@@ -4,8 +4,13 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"time"
 )
 
+// WaitDelay is how long a cancelled command's output is waited for after
+// its process group was killed
+const WaitDelay = 5 * time.Second
+
 var debug bool
 
 type ExitError = exec.ExitError
@@ -23,11 +28,22 @@ func Command(name string, arg ...string) *exec.Cmd {
 	return exec.Command(name, arg...)
 }
 
-// CommandContext returns the [Cmd] struct to execute the named program with
+// CommandContext returns the [Cmd] struct to execute the named program with.
+// The program runs in a process group of its own, which is killed as a
+// whole when ctx is done, so the compilers and linkers go build starts do
+// not outlive a cancelled command.
 func CommandContext(ctx context.Context, name string, arg ...string) *exec.Cmd {
 	if debug {
 		fmt.Printf("Executing: %s > Args: %v\n", name, arg)
 	}
 
-	return exec.CommandContext(ctx, name, arg...)
+	cmd := exec.CommandContext(ctx, name, arg...)
+	setProcessGroup(cmd)
+
+	cmd.Cancel = func() error {
+		return KillProcessGroup(cmd.Process.Pid)
+	}
+	cmd.WaitDelay = WaitDelay
+
+	return cmd
 }
@@ -0,0 +1,53 @@
+//go:build !windows
+
+package exec
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// startedWriter signals its first write
+type startedWriter struct {
+	once    sync.Once
+	started chan struct{}
+}
+
+func (w *startedWriter) Write(p []byte) (int, error) {
+	w.once.Do(func() { close(w.started) })
+	return len(p), nil
+}
+
+func TestCommandContext_KillsProcessGroup(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The background sleep inherits stdout, which Wait copies until it is
+	// closed; Wait only returns early if the sleep is killed with the shell
+	cmd := CommandContext(ctx, "sh", "-c", "sleep 30 & echo started; wait")
+
+	w := &startedWriter{started: make(chan struct{})}
+	cmd.Stdout = w
+
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	<-w.started
+
+	if !ProcessGroupAlive(cmd.Process.Pid) {
+		t.Fatal("process group not running")
+	}
+
+	start := time.Now()
+
+	cancel()
+
+	_ = cmd.Wait()
+
+	if elapsed := time.Since(start); elapsed >= WaitDelay {
+		t.Errorf("Wait took %s: the background sleep outlived the cancelled command", elapsed)
+	}
+}
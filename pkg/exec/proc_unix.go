@@ -0,0 +1,40 @@
+//go:build !windows
+
+package exec
+
+import (
+	"errors"
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup starts cmd as the leader of a new process group
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// KillProcessGroup kills the process group led by pid, the process and
+// every child it started
+func KillProcessGroup(pid int) error {
+	err := syscall.Kill(-pid, syscall.SIGKILL)
+	if errors.Is(err, syscall.ESRCH) {
+		return nil
+	}
+
+	return err
+}
+
+// ProcessGroupAlive reports whether any process of the group led by pid is
+// still running
+func ProcessGroupAlive(pid int) bool {
+	err := syscall.Kill(-pid, 0)
+
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// ProcessAlive reports whether the process pid is still running
+func ProcessAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
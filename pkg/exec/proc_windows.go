@@ -0,0 +1,55 @@
+//go:build windows
+
+package exec
+
+import (
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+// stillActive is the exit code GetExitCodeProcess reports for a running
+// process
+const stillActive = 259
+
+// setProcessGroup starts cmd in a new process group
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP,
+	}
+}
+
+// KillProcessGroup kills the process pid and its descendants. Windows has
+// no process group signals, so the tree is walked by taskkill.
+func KillProcessGroup(pid int) error {
+	if !ProcessGroupAlive(pid) {
+		return nil
+	}
+
+	return exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(pid)).Run()
+}
+
+// ProcessGroupAlive reports whether the process pid is still running;
+// descendants of an exited process cannot be found
+func ProcessGroupAlive(pid int) bool {
+	return ProcessAlive(pid)
+}
+
+// ProcessAlive reports whether the process pid is still running
+func ProcessAlive(pid int) bool {
+	h, err := syscall.OpenProcess(syscall.PROCESS_QUERY_INFORMATION, false, uint32(pid))
+	if err != nil {
+		return false
+	}
+
+	defer func() {
+		_ = syscall.CloseHandle(h)
+	}()
+
+	var code uint32
+	if err := syscall.GetExitCodeProcess(h, &code); err != nil {
+		return false
+	}
+
+	return code == stillActive
+}
@@ -0,0 +1,264 @@
+// Package glix installs Go tools from Go code through the glix server, so
+// go:generate wrappers and test helpers can bootstrap the tools of a repo
+// in one line:
+//
+//	stringer, err := glix.EnsureTool(ctx, "golang.org/x/tools/cmd/stringer@v0.24.0")
+//
+// Tools are installed to GOBIN and recorded like those of 'glix install', so
+// 'glix list', updates and removes manage them as well.
+package glix
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/inovacc/glix/internal/client"
+	"github.com/inovacc/glix/internal/hooks"
+	"github.com/inovacc/glix/internal/module"
+	"github.com/inovacc/glix/internal/owners"
+	"github.com/inovacc/glix/internal/policy"
+	"github.com/inovacc/glix/internal/profiles"
+	"github.com/inovacc/glix/internal/quarantine"
+	"github.com/inovacc/glix/internal/receipts"
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+// EnsureTool installs a tool unless it is installed at the requested version
+// already, and returns the path of its binary.
+//
+// tool is a module or package path with an optional @version. Without a
+// version, or with @latest, any installed version is accepted and the
+// latest one is installed otherwise. Branches, commits and queries such as
+// @v1.2 are resolved on every call and installed when they resolve to
+// another version.
+//
+// The local glix server records the install. When it is not running it is
+// started from the glix executable on PATH or in GOBIN, as the glix CLI
+// does.
+func EnsureTool(ctx context.Context, tool string) (string, error) {
+	name, version := splitTool(tool)
+	if name == "" {
+		return "", fmt.Errorf("invalid tool %q: want <module>[@version]", tool)
+	}
+
+	grpcClient, err := connect(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = grpcClient.Close()
+	}()
+
+	var installed *pb.ModuleProto
+	if resp, err := grpcClient.GetModule(ctx, name, ""); err == nil && resp.GetFound() {
+		installed = resp.GetModule()
+	}
+
+	// Exact and unpinned requests are answered without the network
+	if binPath, ok := installedBinary(installed); ok && (version == "latest" || version == installed.GetVersion()) {
+		return binPath, nil
+	}
+
+	return install(ctx, grpcClient, installed, name, version)
+}
+
+// splitTool splits a tool into its path and version, "latest" when none is
+// given
+func splitTool(tool string) (string, string) {
+	name, version, ok := strings.Cut(strings.TrimSpace(tool), "@")
+	if !ok || version == "" {
+		version = "latest"
+	}
+
+	return name, version
+}
+
+// installedBinary returns the binary of an installed module, if it exists
+func installedBinary(mod *pb.ModuleProto) (string, bool) {
+	binPath := mod.GetBinaryPath()
+	if binPath == "" {
+		return "", false
+	}
+
+	if info, err := os.Stat(binPath); err != nil || info.IsDir() {
+		return "", false
+	}
+
+	return binPath, true
+}
+
+// install builds a tool and records it, keeping the alias, bin directory,
+// profile, platform, build flags and version range of an installed version
+func install(ctx context.Context, grpcClient *client.Client, installed *pb.ModuleProto, name, version string) (string, error) {
+	workDir, err := module.NewWorkDir("install")
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = os.RemoveAll(workDir)
+	}()
+
+	m, err := module.NewModule(ctx, "go", workDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to create module: %w", err)
+	}
+
+	m.SetVersionStore(grpcClient.VersionStore())
+
+	if installed != nil {
+		m.Alias = installed.GetAlias()
+		m.SetBinDir(installed.GetBinDir())
+		m.SetRecordedPlatform(installed.GetPlatform())
+		m.SetBuildFlags(module.BuildFlagsFromProto(installed.GetBuildFlags()))
+		m.SetPreferBinary(installed.GetPreferBinary())
+		m.SetPrerelease(installed.GetPrerelease())
+		m.SetShim(installed.GetShimPath() != "")
+		m.SetBuildStrategy(module.BuildStrategyFromRecord(installed.GetBuildStrategy()))
+		m.SetGoProxy(installed.GetGoproxy())
+		m.SetPrivate(installed.GetPrivate())
+		m.SetConstraint(installed.GetVersionConstraint())
+
+		if err := profiles.Apply(m, installed.GetProfile()); err != nil {
+			return "", err
+		}
+	}
+
+	if err := quarantine.Apply(m); err != nil {
+		return "", err
+	}
+
+	if err := hooks.Apply(m); err != nil {
+		return "", err
+	}
+
+	m.SetPreviousVersion(installed.GetVersion())
+
+	target := name
+	if version != "latest" {
+		target = fmt.Sprintf("%s@%s", name, version)
+	}
+
+	if err := m.FetchModuleInfo(target); err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", target, err)
+	}
+
+	// A branch or query may resolve to the installed version
+	if binPath, ok := installedBinary(installed); ok && m.Version == installed.GetVersion() {
+		return binPath, nil
+	}
+
+	if err := checkPolicy(ctx, m); err != nil {
+		return "", err
+	}
+
+	// Binaries the server of another namespace manages are not overwritten
+	var namespace string
+	if status, err := grpcClient.GetStatus(ctx); err == nil {
+		namespace = status.GetNamespace()
+	}
+
+	if owner, conflict := owners.GetStore().Conflict(m.InstallPath(), namespace); conflict {
+		return "", fmt.Errorf("%s is managed by %s", m.InstallPath(), owner)
+	}
+
+	// Build output is only of interest when the build fails
+	var output bytes.Buffer
+
+	if err := m.InstallModuleWithStreaming(ctx, func(_, line string) {
+		output.WriteString(line + "\n")
+	}); err != nil {
+		if out := strings.TrimSpace(output.String()); out != "" {
+			return "", fmt.Errorf("failed to install %s@%s: %w\n%s", m.Name, m.Version, err, out)
+		}
+
+		return "", fmt.Errorf("failed to install %s@%s: %w", m.Name, m.Version, err)
+	}
+
+	if err := grpcClient.StoreModule(ctx, m); err != nil {
+		return "", fmt.Errorf("installed %s but failed to record it: %w", m.BinaryPath, err)
+	}
+
+	if namespace != "" {
+		_ = owners.GetStore().Claim(owners.Owner{Path: m.BinaryPath, Namespace: namespace, Module: m.Name, Profile: m.Profile()})
+		_ = receipts.GetStore().Write(namespace, m.Name, m.Version, receipts.InstalledFiles(m.BinaryPath, m.ShimPath)...)
+	}
+
+	return m.BinaryPath, nil
+}
+
+// checkPolicy refuses installs the install policy denies
+func checkPolicy(ctx context.Context, m *module.Module) error {
+	p, err := policy.Load(policy.DefaultPath())
+	if err != nil {
+		return err
+	}
+
+	if p == nil {
+		return nil
+	}
+
+	in, _ := policy.BuildInput(ctx, p, m)
+
+	d, err := p.Evaluate(ctx, in)
+	if err != nil {
+		return fmt.Errorf("policy evaluation failed: %w", err)
+	}
+
+	if d.Action == policy.ActionDeny {
+		return fmt.Errorf("%s@%s blocked by policy (%s)", m.Name, m.Version, d)
+	}
+
+	return nil
+}
+
+// connect returns a client of the local server, starting the server from the
+// glix executable when needed
+func connect(ctx context.Context) (*client.Client, error) {
+	cfg := client.DefaultDiscoveryConfig()
+	cfg.Logger = nil
+
+	exe, lookErr := glixExecutable()
+	if lookErr == nil {
+		cfg.Executable = exe
+	} else {
+		cfg.Existing = true
+	}
+
+	grpcClient, err := client.GetClient(ctx, cfg)
+	if err != nil {
+		if lookErr != nil {
+			return nil, fmt.Errorf("no glix server is running and it cannot be started: %w", lookErr)
+		}
+
+		return nil, fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	return grpcClient, nil
+}
+
+// glixExecutable finds the glix executable on PATH or in GOBIN
+func glixExecutable() (string, error) {
+	if exe, err := exec.LookPath("glix"); err == nil {
+		return exe, nil
+	}
+
+	name := "glix"
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+
+	exe := filepath.Join(module.GetGoBinDirectory(), name)
+	if _, err := os.Stat(exe); err != nil {
+		return "", fmt.Errorf("glix not found on PATH or in %s; install it with 'go install github.com/inovacc/glix@latest'", module.GetGoBinDirectory())
+	}
+
+	return exe, nil
+}
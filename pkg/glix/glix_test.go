@@ -0,0 +1,51 @@
+package glix
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/inovacc/glix/pkg/api/v1"
+)
+
+func TestSplitTool(t *testing.T) {
+	tests := []struct {
+		tool, name, version string
+	}{
+		{"golang.org/x/tools/cmd/stringer", "golang.org/x/tools/cmd/stringer", "latest"},
+		{"golang.org/x/tools/cmd/stringer@", "golang.org/x/tools/cmd/stringer", "latest"},
+		{"golang.org/x/tools/cmd/stringer@v0.24.0", "golang.org/x/tools/cmd/stringer", "v0.24.0"},
+		{" example.com/tool@main ", "example.com/tool", "main"},
+	}
+
+	for _, tt := range tests {
+		if name, version := splitTool(tt.tool); name != tt.name || version != tt.version {
+			t.Errorf("splitTool(%q) = %q, %q; want %q, %q", tt.tool, name, version, tt.name, tt.version)
+		}
+	}
+}
+
+func TestInstalledBinary(t *testing.T) {
+	dir := t.TempDir()
+	binPath := filepath.Join(dir, "tool")
+
+	if _, ok := installedBinary(nil); ok {
+		t.Error("a module that is not installed has no binary")
+	}
+
+	if _, ok := installedBinary(&pb.ModuleProto{BinaryPath: binPath}); ok {
+		t.Error("a missing binary should not be reported")
+	}
+
+	if _, ok := installedBinary(&pb.ModuleProto{BinaryPath: dir}); ok {
+		t.Error("a directory should not be reported")
+	}
+
+	if err := os.WriteFile(binPath, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, ok := installedBinary(&pb.ModuleProto{BinaryPath: binPath}); !ok || got != binPath {
+		t.Errorf("installedBinary() = %q, %v; want %q", got, ok, binPath)
+	}
+}